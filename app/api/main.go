@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/satriowisnugroho/catalog/internal/config"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
 	"github.com/satriowisnugroho/catalog/internal/handler/http/middleware"
 	httpv1 "github.com/satriowisnugroho/catalog/internal/handler/http/v1"
 	"github.com/satriowisnugroho/catalog/internal/parser"
@@ -16,7 +17,9 @@ import (
 	"github.com/satriowisnugroho/catalog/internal/usecase"
 	"github.com/satriowisnugroho/catalog/pkg/httpserver"
 	"github.com/satriowisnugroho/catalog/pkg/logger"
+	"github.com/satriowisnugroho/catalog/pkg/notifier"
 	pkgpostgres "github.com/satriowisnugroho/catalog/pkg/postgres"
+	"github.com/satriowisnugroho/catalog/pkg/worker"
 )
 
 func main() {
@@ -34,12 +37,70 @@ func main() {
 	// Initialize repositories
 	dbTransactionRepo := postgres.NewPostgresTransactionRepository(postgresDb.Db)
 	productRepo := postgres.NewProductRepository(postgresDb.Db)
+	priceScheduleRepo := postgres.NewPriceScheduleRepository(postgresDb.Db)
+	priceHistoryRepo := postgres.NewPriceHistoryRepository(postgresDb.Db)
+	taxClassRepo := postgres.NewTaxClassRepository(postgresDb.Db)
+	discountRuleRepo := postgres.NewDiscountRuleRepository(postgresDb.Db)
+	locationRepo := postgres.NewLocationRepository(postgresDb.Db)
+	productStockRepo := postgres.NewProductStockRepository(postgresDb.Db)
+	reservationRepo := postgres.NewReservationRepository(postgresDb.Db)
+	stockMovementRepo := postgres.NewStockMovementRepository(postgresDb.Db)
+	idempotencyKeyRepo := postgres.NewIdempotencyKeyRepository(postgresDb.Db)
+	lowStockRepo := postgres.NewLowStockRepository(postgresDb.Db)
+	cycleCountRepo := postgres.NewCycleCountRepository(postgresDb.Db)
+	transferRepo := postgres.NewTransferRepository(postgresDb.Db)
+
+	allocationStrategy, ok := types.AllocationStrategyTypeNameToValue[cfg.StockConfig.AllocationStrategy]
+	if !ok {
+		l.Fatal(fmt.Errorf("app - api - invalid stock allocation strategy: %s", cfg.StockConfig.AllocationStrategy))
+	}
+
+	// Initialize notifier
+	var lowStockNotifier notifier.NotifierInterface
+	switch cfg.NotifierConfig.Type {
+	case "log":
+		lowStockNotifier = notifier.NewLogNotifier(l)
+	case "webhook":
+		lowStockNotifier = notifier.NewWebhookNotifier(cfg.NotifierConfig.WebhookURL, notifier.Timeout(cfg.NotifierConfig.WebhookTimeout))
+	default:
+		l.Fatal(fmt.Errorf("app - api - invalid notifier type: %s", cfg.NotifierConfig.Type))
+	}
 
 	// Initialize usecases
-	productUsecase := usecase.NewProductUsecase(productRepo, dbTransactionRepo)
+	productUsecase := usecase.NewProductUsecase(productRepo, dbTransactionRepo, priceScheduleRepo, taxClassRepo, discountRuleRepo, locationRepo, productStockRepo, stockMovementRepo, lowStockRepo, allocationStrategy)
+	priceScheduleUsecase := usecase.NewPriceScheduleUsecase(priceScheduleRepo, productRepo, dbTransactionRepo)
+	priceHistoryUsecase := usecase.NewPriceHistoryUsecase(priceHistoryRepo, productRepo)
+	taxClassUsecase := usecase.NewTaxClassUsecase(taxClassRepo, dbTransactionRepo)
+	discountRuleUsecase := usecase.NewDiscountRuleUsecase(discountRuleRepo)
+	reportUsecase := usecase.NewReportUsecase(productRepo, productStockRepo)
+	locationUsecase := usecase.NewLocationUsecase(locationRepo, dbTransactionRepo)
+	stockMovementUsecase := usecase.NewStockMovementUsecase(stockMovementRepo, productRepo)
+	reservationUsecase := usecase.NewReservationUsecase(reservationRepo, productRepo, productStockRepo, stockMovementRepo, lowStockRepo, dbTransactionRepo, allocationStrategy, cfg.StockConfig.ReservationTTL)
+	idempotencyKeyUsecase := usecase.NewIdempotencyKeyUsecase(idempotencyKeyRepo, cfg.IdempotencyConfig.KeyTTL, cfg.IdempotencyConfig.InProgressTTL)
+	lowStockUsecase := usecase.NewLowStockUsecase(lowStockRepo, dbTransactionRepo, lowStockNotifier)
+	cycleCountUsecase := usecase.NewCycleCountUsecase(cycleCountRepo, productRepo, locationRepo, productStockRepo, stockMovementRepo, lowStockRepo, dbTransactionRepo, allocationStrategy)
+	serialUsecase := usecase.NewSerialUsecase(productRepo, productStockRepo)
+	transferUsecase := usecase.NewTransferUsecase(transferRepo, productRepo, locationRepo, productStockRepo, stockMovementRepo, lowStockRepo, dbTransactionRepo, allocationStrategy)
 
 	// Initialize parsers
 	productParser := parser.NewProductParser()
+	priceScheduleParser := parser.NewPriceScheduleParser()
+	priceHistoryParser := parser.NewPriceHistoryParser()
+	taxClassParser := parser.NewTaxClassParser()
+	discountRuleParser := parser.NewDiscountRuleParser()
+	locationParser := parser.NewLocationParser()
+	reservationParser := parser.NewReservationParser()
+	stockMovementParser := parser.NewStockMovementParser()
+	lowStockParser := parser.NewLowStockParser()
+	cycleCountParser := parser.NewCycleCountParser()
+	serialParser := parser.NewSerialParser()
+	transferParser := parser.NewTransferParser()
+
+	// Background workers
+	priceScheduler := worker.New("price-scheduler", priceScheduleUsecase.ApplyDuePriceSchedules, l, worker.Interval(cfg.WorkerConfig.PriceSchedulerInterval))
+	reservationSweeper := worker.New("reservation-sweeper", reservationUsecase.ReleaseExpiredReservations, l, worker.Interval(cfg.WorkerConfig.ReservationSweeperInterval))
+	idempotencySweeper := worker.New("idempotency-sweeper", idempotencyKeyUsecase.DeleteExpiredIdempotencyKeys, l, worker.Interval(cfg.WorkerConfig.IdempotencySweeperInterval))
+	lowStockAlertDispatcher := worker.New("low-stock-alert-dispatcher", lowStockUsecase.DispatchLowStockAlerts, l, worker.Interval(cfg.WorkerConfig.LowStockAlertDispatcherInterval))
 
 	// HTTP Server
 	handler := gin.New()
@@ -48,7 +109,7 @@ func main() {
 	handler.Use(middleware.Tenant())
 
 	// Set router
-	httpv1.NewRouter(handler, l, productParser, productUsecase)
+	httpv1.NewRouter(handler, l, productParser, productUsecase, priceScheduleParser, priceScheduleUsecase, priceHistoryParser, priceHistoryUsecase, taxClassParser, taxClassUsecase, discountRuleParser, discountRuleUsecase, reportUsecase, locationParser, locationUsecase, reservationParser, reservationUsecase, stockMovementParser, stockMovementUsecase, idempotencyKeyUsecase, lowStockParser, lowStockUsecase, cycleCountParser, cycleCountUsecase, serialParser, serialUsecase, transferParser, transferUsecase)
 	httpServer := httpserver.New(handler, httpserver.Port(fmt.Sprint(cfg.Port)))
 
 	// Waiting signal
@@ -67,4 +128,9 @@ func main() {
 	if err != nil {
 		l.Error(fmt.Errorf("app - api - httpServer.Shutdown: %w", err))
 	}
+
+	priceScheduler.Shutdown()
+	reservationSweeper.Shutdown()
+	idempotencySweeper.Shutdown()
+	lowStockAlertDispatcher.Shutdown()
 }
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS price_schedules;
+ALTER TABLE "products" DROP COLUMN IF EXISTS "promotion_price";
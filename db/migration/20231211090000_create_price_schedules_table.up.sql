@@ -0,0 +1,18 @@
+ALTER TABLE "products" ADD COLUMN "promotion_price" integer;
+
+CREATE TABLE "price_schedules" (
+  "id" SERIAL PRIMARY KEY,
+  "product_id" integer NOT NULL REFERENCES "products" ("id") ON DELETE CASCADE,
+  "tenant" smallint NOT NULL,
+  "type" smallint NOT NULL,
+  "status" smallint NOT NULL,
+  "price" integer NOT NULL,
+  "starts_at" timestamptz NOT NULL,
+  "ends_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "price_schedules" ("product_id");
+CREATE INDEX ON "price_schedules" ("status", "starts_at");
+CREATE INDEX ON "price_schedules" ("status", "ends_at");
@@ -0,0 +1,14 @@
+CREATE TABLE "price_histories" (
+  "id" SERIAL PRIMARY KEY,
+  "product_id" integer NOT NULL REFERENCES "products" ("id") ON DELETE CASCADE,
+  "tenant" smallint NOT NULL,
+  "price" integer NOT NULL,
+  "promotion_price" integer,
+  "effective_price" integer NOT NULL,
+  "recorded_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "price_histories" ("product_id", "recorded_at");
+
+INSERT INTO "price_histories" ("product_id", "tenant", "price", "promotion_price", "effective_price", "recorded_at")
+SELECT "id", "tenant", "price", "promotion_price", COALESCE("promotion_price", "price"), "updated_at" FROM "products";
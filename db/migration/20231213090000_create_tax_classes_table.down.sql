@@ -0,0 +1,5 @@
+ALTER TABLE "products" DROP COLUMN IF EXISTS "tax_class_id";
+
+DROP TABLE IF EXISTS "category_tax_classes";
+DROP TABLE IF EXISTS "tax_rates";
+DROP TABLE IF EXISTS "tax_classes";
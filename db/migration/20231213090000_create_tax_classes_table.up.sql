@@ -0,0 +1,33 @@
+CREATE TABLE "tax_classes" (
+  "id" SERIAL PRIMARY KEY,
+  "tenant" smallint NOT NULL,
+  "name" varchar NOT NULL,
+  "price_includes_tax" boolean NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX "tax_classes_name_tenant_idx" ON "tax_classes" ("name", "tenant");
+
+-- Empty region holds the default rate of the tax class for regions without their own rate
+CREATE TABLE "tax_rates" (
+  "id" SERIAL PRIMARY KEY,
+  "tax_class_id" integer NOT NULL REFERENCES "tax_classes" ("id") ON DELETE CASCADE,
+  "region" varchar NOT NULL DEFAULT '',
+  "rate" integer NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "tax_rates" ("tax_class_id", "region");
+
+CREATE TABLE "category_tax_classes" (
+  "tenant" smallint NOT NULL,
+  "category" smallint NOT NULL,
+  "tax_class_id" integer NOT NULL REFERENCES "tax_classes" ("id") ON DELETE CASCADE,
+  PRIMARY KEY ("tenant", "category")
+);
+
+CREATE INDEX ON "category_tax_classes" ("tax_class_id");
+
+ALTER TABLE "products" ADD COLUMN "tax_class_id" integer REFERENCES "tax_classes" ("id") ON DELETE SET NULL;
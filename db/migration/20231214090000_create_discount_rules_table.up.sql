@@ -0,0 +1,21 @@
+-- Rules match the products of any of the categories and conditions, like the filters of the product search.
+-- Conditions which are null match every product
+CREATE TABLE "discount_rules" (
+  "id" SERIAL PRIMARY KEY,
+  "tenant" smallint NOT NULL,
+  "name" varchar NOT NULL,
+  "type" smallint NOT NULL,
+  "value" integer NOT NULL,
+  "sku" varchar,
+  "keyword" varchar,
+  "categories" smallint[],
+  "conditions" smallint[],
+  "priority" integer NOT NULL DEFAULT 0,
+  "stackable" boolean NOT NULL DEFAULT false,
+  "starts_at" timestamptz,
+  "ends_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "discount_rules" ("tenant", "priority");
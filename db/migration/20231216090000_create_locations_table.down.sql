@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "product_stocks";
+DROP TABLE IF EXISTS "locations";
@@ -0,0 +1,33 @@
+CREATE TABLE "locations" (
+  "id" SERIAL PRIMARY KEY,
+  "tenant" smallint NOT NULL,
+  "code" varchar NOT NULL,
+  "name" varchar NOT NULL,
+  "priority" integer NOT NULL DEFAULT 0,
+  "is_default" boolean NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX "locations_code_tenant_idx" ON "locations" ("code", "tenant");
+-- A tenant has at most one default location, which receives stock given without location
+CREATE UNIQUE INDEX "locations_default_tenant_idx" ON "locations" ("tenant") WHERE "is_default";
+
+CREATE TABLE "product_stocks" (
+  "product_id" integer NOT NULL REFERENCES "products" ("id") ON DELETE CASCADE,
+  "location_id" integer NOT NULL REFERENCES "locations" ("id") ON DELETE CASCADE,
+  "qty" integer NOT NULL CHECK ("qty" >= 0),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("product_id", "location_id")
+);
+
+CREATE INDEX ON "product_stocks" ("location_id");
+
+-- Move the existing stock into a default location of each tenant
+INSERT INTO "locations" ("tenant", "code", "name", "is_default")
+SELECT DISTINCT "tenant", 'default', 'Default', true FROM "products";
+
+INSERT INTO "product_stocks" ("product_id", "location_id", "qty")
+SELECT "products"."id", "locations"."id", "products"."qty"
+FROM "products" JOIN "locations" ON "locations"."tenant" = "products"."tenant" AND "locations"."is_default"
+WHERE "products"."qty" > 0;
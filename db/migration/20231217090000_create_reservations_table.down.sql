@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "reservation_items";
+DROP TABLE IF EXISTS "reservations";
+ALTER TABLE "products" DROP COLUMN IF EXISTS "reserved_qty";
@@ -0,0 +1,22 @@
+-- Qty held by active reservations, the available qty is qty - reserved_qty
+ALTER TABLE "products" ADD COLUMN "reserved_qty" integer NOT NULL DEFAULT 0 CHECK ("reserved_qty" >= 0);
+
+CREATE TABLE "reservations" (
+  "id" SERIAL PRIMARY KEY,
+  "tenant" smallint NOT NULL,
+  "status" smallint NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "reservations" ("status", "expires_at");
+
+CREATE TABLE "reservation_items" (
+  "id" SERIAL PRIMARY KEY,
+  "reservation_id" integer NOT NULL REFERENCES "reservations" ("id") ON DELETE CASCADE,
+  "product_id" integer NOT NULL REFERENCES "products" ("id") ON DELETE CASCADE,
+  "qty" integer NOT NULL CHECK ("qty" > 0)
+);
+
+CREATE INDEX ON "reservation_items" ("reservation_id");
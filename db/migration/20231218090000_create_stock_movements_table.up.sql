@@ -0,0 +1,17 @@
+CREATE TABLE "stock_movements" (
+  "id" SERIAL PRIMARY KEY,
+  "product_id" integer NOT NULL REFERENCES "products" ("id") ON DELETE CASCADE,
+  "tenant" smallint NOT NULL,
+  "delta" integer NOT NULL,
+  "resulting_qty" integer NOT NULL,
+  "reason" smallint NOT NULL,
+  "actor" varchar NOT NULL DEFAULT '',
+  "reference_id" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "stock_movements" ("product_id", "created_at");
+
+-- Record the qty on hand as an adjustment so that the deltas of a product add up to its qty
+INSERT INTO "stock_movements" ("product_id", "tenant", "delta", "resulting_qty", "reason", "reference_id", "created_at")
+SELECT "id", "tenant", "qty", "qty", 3, 'opening-balance', "updated_at" FROM "products" WHERE "qty" <> 0;
@@ -0,0 +1,15 @@
+-- Responses of requests sent with an Idempotency-Key header, replayed for retries until expires_at.
+-- status_code is 0 while the first request is still processed, response_headers hold the headers replayed with the body, such as ETag and Location
+CREATE TABLE "idempotency_keys" (
+  "tenant" smallint NOT NULL,
+  "key" varchar(255) NOT NULL,
+  "request_hash" varchar(64) NOT NULL,
+  "status_code" smallint NOT NULL DEFAULT 0,
+  "response_headers" jsonb NOT NULL DEFAULT '{}',
+  "response_body" bytea,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "expires_at" timestamptz NOT NULL,
+  PRIMARY KEY ("tenant", "key")
+);
+
+CREATE INDEX ON "idempotency_keys" ("expires_at");
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "low_stock_alerts";
+DROP TABLE IF EXISTS "low_stock_thresholds";
+ALTER TABLE "products" DROP COLUMN IF EXISTS "low_stock_alerted";
+ALTER TABLE "products" DROP COLUMN IF EXISTS "low_stock_threshold";
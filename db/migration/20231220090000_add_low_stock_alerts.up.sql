@@ -0,0 +1,25 @@
+-- A product without threshold uses the threshold of its tenant
+ALTER TABLE "products" ADD COLUMN "low_stock_threshold" integer CHECK ("low_stock_threshold" >= 0);
+-- Set when the low stock alert of the product is created, cleared when the qty recovers above the threshold
+ALTER TABLE "products" ADD COLUMN "low_stock_alerted" boolean NOT NULL DEFAULT false;
+
+CREATE TABLE "low_stock_thresholds" (
+  "tenant" smallint PRIMARY KEY,
+  "threshold" integer NOT NULL CHECK ("threshold" >= 0),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "low_stock_alerts" (
+  "id" SERIAL PRIMARY KEY,
+  "product_id" integer NOT NULL REFERENCES "products" ("id") ON DELETE CASCADE,
+  "tenant" smallint NOT NULL,
+  "sku" varchar NOT NULL,
+  "title" varchar NOT NULL,
+  "qty" integer NOT NULL,
+  "threshold" integer NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "sent_at" timestamptz
+);
+
+-- The dispatcher only looks for the alerts which are not sent yet
+CREATE INDEX "low_stock_alerts_pending_idx" ON "low_stock_alerts" ("id") WHERE "sent_at" IS NULL;
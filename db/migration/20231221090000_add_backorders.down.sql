@@ -0,0 +1,4 @@
+ALTER TABLE "products" DROP COLUMN IF EXISTS "backordered_qty";
+ALTER TABLE "products" DROP COLUMN IF EXISTS "restock_date";
+ALTER TABLE "products" DROP COLUMN IF EXISTS "backorder_limit";
+ALTER TABLE "products" DROP COLUMN IF EXISTS "backorder_policy";
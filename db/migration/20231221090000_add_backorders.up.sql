@@ -0,0 +1,6 @@
+-- 1 is deny, 2 is limited up to backorder_limit and 3 is unlimited
+ALTER TABLE "products" ADD COLUMN "backorder_policy" smallint NOT NULL DEFAULT 1;
+ALTER TABLE "products" ADD COLUMN "backorder_limit" integer CHECK ("backorder_limit" > 0);
+ALTER TABLE "products" ADD COLUMN "restock_date" timestamptz;
+-- Qty ordered beyond the available qty, it is filled first when the qty is increased
+ALTER TABLE "products" ADD COLUMN "backordered_qty" integer NOT NULL DEFAULT 0 CHECK ("backordered_qty" >= 0);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "cycle_count_items";
+DROP TABLE IF EXISTS "cycle_counts";
@@ -0,0 +1,20 @@
+CREATE TABLE "cycle_counts" (
+  "id" SERIAL PRIMARY KEY,
+  "tenant" smallint NOT NULL,
+  "status" smallint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "cycle_counts" ("tenant", "status");
+
+-- The system qty is the qty of the product when it is counted, the diff is applied on approval
+CREATE TABLE "cycle_count_items" (
+  "id" SERIAL PRIMARY KEY,
+  "cycle_count_id" integer NOT NULL REFERENCES "cycle_counts" ("id") ON DELETE CASCADE,
+  "product_id" integer NOT NULL REFERENCES "products" ("id") ON DELETE CASCADE,
+  "system_qty" integer NOT NULL,
+  "counted_qty" integer NOT NULL CHECK ("counted_qty" >= 0),
+  "diff_qty" integer NOT NULL,
+  UNIQUE ("cycle_count_id", "product_id")
+);
@@ -0,0 +1,13 @@
+-- The qty of the lots is part of the qty of the product, the qty which is not in any lot has no expiry
+CREATE TABLE "product_lots" (
+  "id" SERIAL PRIMARY KEY,
+  "product_id" integer NOT NULL REFERENCES "products" ("id") ON DELETE CASCADE,
+  "lot_number" varchar NOT NULL,
+  "expiry_date" timestamptz NOT NULL,
+  "qty" integer NOT NULL CHECK ("qty" >= 0),
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  UNIQUE ("product_id", "lot_number")
+);
+
+CREATE INDEX ON "product_lots" ("expiry_date");
@@ -0,0 +1,20 @@
+-- The qty of a serialized product is added with a serial number for each unit
+ALTER TABLE "products" ADD COLUMN "serialized" boolean NOT NULL DEFAULT false;
+
+-- 1 is in stock, 2 is sold and 3 is written off. The reference id, actor and deducted at of a serial number
+-- which is out of stock are of the deduction which took it, e.g. the sale
+CREATE TABLE "product_serials" (
+  "id" SERIAL PRIMARY KEY,
+  "product_id" integer NOT NULL REFERENCES "products" ("id") ON DELETE CASCADE,
+  "tenant" smallint NOT NULL,
+  "serial_number" varchar NOT NULL,
+  "status" smallint NOT NULL,
+  "reference_id" varchar NOT NULL DEFAULT '',
+  "actor" varchar NOT NULL DEFAULT '',
+  "deducted_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  UNIQUE ("tenant", "serial_number")
+);
+
+CREATE INDEX ON "product_serials" ("product_id", "status");
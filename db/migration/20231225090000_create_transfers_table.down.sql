@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "transfer_items";
+DROP TABLE IF EXISTS "transfers";
+ALTER TABLE "products" DROP COLUMN IF EXISTS "in_transit_qty";
@@ -0,0 +1,27 @@
+-- Qty dispatched from a location which is not received on the destination yet, it is not part of the qty
+ALTER TABLE "products" ADD COLUMN "in_transit_qty" integer NOT NULL DEFAULT 0 CHECK ("in_transit_qty" >= 0);
+
+-- 1 is draft, 2 is in transit and 3 is received
+CREATE TABLE "transfers" (
+  "id" SERIAL PRIMARY KEY,
+  "tenant" smallint NOT NULL,
+  "from_location_id" integer NOT NULL REFERENCES "locations" ("id"),
+  "to_location_id" integer NOT NULL REFERENCES "locations" ("id"),
+  "status" smallint NOT NULL,
+  "reference_id" varchar NOT NULL DEFAULT '',
+  "dispatched_at" timestamptz,
+  "received_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("from_location_id" <> "to_location_id")
+);
+
+CREATE INDEX ON "transfers" ("tenant", "status");
+
+CREATE TABLE "transfer_items" (
+  "id" SERIAL PRIMARY KEY,
+  "transfer_id" integer NOT NULL REFERENCES "transfers" ("id") ON DELETE CASCADE,
+  "product_id" integer NOT NULL REFERENCES "products" ("id") ON DELETE CASCADE,
+  "qty" integer NOT NULL CHECK ("qty" > 0),
+  UNIQUE ("transfer_id", "product_id")
+);
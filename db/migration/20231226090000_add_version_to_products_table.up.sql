@@ -0,0 +1,2 @@
+-- Incremented on each update of the product, a write with a stale version is rejected
+ALTER TABLE "products" ADD COLUMN "version" integer NOT NULL DEFAULT 1;
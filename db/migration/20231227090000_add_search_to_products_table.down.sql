@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS "tenant_search_configs_apply_trg" ON "tenant_search_configs";
+DROP FUNCTION IF EXISTS "apply_tenant_search_config"();
+DROP TRIGGER IF EXISTS "products_search_config_trg" ON "products";
+DROP FUNCTION IF EXISTS "set_product_search_config"();
+DROP INDEX IF EXISTS "products_search_vector_idx";
+ALTER TABLE "products" DROP COLUMN IF EXISTS "search_vector";
+ALTER TABLE "products" DROP COLUMN IF EXISTS "search_config";
+DROP TABLE IF EXISTS "tenant_search_configs";
+ALTER TABLE "products" DROP COLUMN IF EXISTS "attributes";
+ALTER TABLE "products" DROP COLUMN IF EXISTS "description";
@@ -0,0 +1,42 @@
+ALTER TABLE "products" ADD COLUMN "description" text NOT NULL DEFAULT '';
+-- Free form attributes of the product, e.g. {"color": "red"}, their values are searched with the title and description
+ALTER TABLE "products" ADD COLUMN "attributes" jsonb NOT NULL DEFAULT '{}';
+
+-- The text search config of the tenant decides the language the products are stemmed with,
+-- tenants without config use the simple config which does not stem
+CREATE TABLE "tenant_search_configs" (
+  "tenant" smallint PRIMARY KEY,
+  "config" regconfig NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+-- The search config of the tenant is copied to the product so the search vector can be generated from the row
+ALTER TABLE "products" ADD COLUMN "search_config" regconfig NOT NULL DEFAULT 'simple';
+ALTER TABLE "products" ADD COLUMN "search_vector" tsvector GENERATED ALWAYS AS (
+  setweight(to_tsvector("search_config", "title"), 'A') ||
+  setweight(to_tsvector("search_config", "description"), 'B') ||
+  setweight(jsonb_to_tsvector("search_config", "attributes", '["string"]'), 'C')
+) STORED;
+
+CREATE INDEX "products_search_vector_idx" ON "products" USING GIN ("search_vector");
+
+CREATE FUNCTION "set_product_search_config"() RETURNS trigger AS $$
+BEGIN
+  NEW."search_config" := COALESCE((SELECT "config" FROM "tenant_search_configs" WHERE "tenant" = NEW."tenant"), 'simple');
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "products_search_config_trg" BEFORE INSERT ON "products"
+  FOR EACH ROW EXECUTE FUNCTION "set_product_search_config"();
+
+-- Changing the config of the tenant regenerates the search vector of its products
+CREATE FUNCTION "apply_tenant_search_config"() RETURNS trigger AS $$
+BEGIN
+  UPDATE "products" SET "search_config" = NEW."config" WHERE "tenant" = NEW."tenant" AND "search_config" <> NEW."config";
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "tenant_search_configs_apply_trg" AFTER INSERT OR UPDATE ON "tenant_search_configs"
+  FOR EACH ROW EXECUTE FUNCTION "apply_tenant_search_config"();
@@ -1,10 +1,10 @@
-// Package docs GENERATED BY THE COMMAND ABOVE; DO NOT EDIT
-// This file was generated by swaggo/swag
+// Code generated by swaggo/swag. DO NOT EDIT.
+
 package docs
 
 import "github.com/swaggo/swag"
 
-const docTemplate_swagger = `{
+const docTemplate = `{
     "schemes": {{ marshal .Schemes }},
     "swagger": "2.0",
     "info": {
@@ -16,20 +16,21 @@ const docTemplate_swagger = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/products": {
-            "get": {
-                "description": "An API to show product list",
+        "/cycle-counts": {
+            "post": {
+                "description": "An API to upload a count sheet of the physical stock, the response is the diff report of each counted qty against the current qty.\nThe sheet is either a json payload or a csv with the sku and counted_qty header. The qty is only changed when the cycle count is approved",
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "cycle-count"
                 ],
-                "summary": "Show Product List",
-                "operationId": "list",
+                "summary": "Create Cycle Count",
+                "operationId": "create-cycle-count",
                 "parameters": [
                     {
                         "type": "string",
@@ -41,47 +42,94 @@ const docTemplate_swagger = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "title search by keyword",
-                        "name": "keyword",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "sku product",
-                        "name": "sku",
-                        "in": "query"
+                        "description": "payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.CycleCountPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.CycleCount"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "category product",
-                        "name": "category",
-                        "in": "query"
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "example": "new, preloved",
-                        "description": "condition product",
-                        "name": "condition",
-                        "in": "query"
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "order by",
-                        "name": "orderby",
-                        "in": "query"
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/cycle-counts/{id}": {
+            "get": {
+                "description": "An API to show cycle count detail with the diff report of its items",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cycle-count"
+                ],
+                "summary": "Show Cycle Count Detail",
+                "operationId": "detail-cycle-count",
+                "parameters": [
                     {
                         "type": "integer",
-                        "description": "offset",
-                        "name": "offset",
-                        "in": "query"
+                        "description": "Cycle Count ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "limit",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -96,10 +144,7 @@ const docTemplate_swagger = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/entity.Product"
-                                            }
+                                            "$ref": "#/definitions/entity.CycleCount"
                                         },
                                         "meta": {
                                             "$ref": "#/definitions/response.MetaInfo"
@@ -109,6 +154,12 @@ const docTemplate_swagger = `{
                             ]
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -122,9 +173,11 @@ const docTemplate_swagger = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/cycle-counts/{id}/approve": {
             "post": {
-                "description": "An API to create product",
+                "description": "An API to approve a pending cycle count, the diff of each item is applied to the qty of its product in one transaction\nand recorded as an adjustment on the stock movements. The diff against the qty when the products were counted is applied,\nso the qty changes since the count are kept",
                 "consumes": [
                     "application/json"
                 ],
@@ -132,11 +185,18 @@ const docTemplate_swagger = `{
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "cycle-count"
                 ],
-                "summary": "Create Product",
-                "operationId": "create",
+                "summary": "Approve Cycle Count",
+                "operationId": "approve-cycle-count",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cycle Count ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "default": "lorem",
@@ -147,13 +207,11 @@ const docTemplate_swagger = `{
                         "required": true
                     },
                     {
-                        "description": "payload",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/entity.SwaggerProductPayload"
-                        }
+                        "type": "string",
+                        "example": "jane@example.com",
+                        "description": "Actor Header, recorded on the stock movements",
+                        "name": "X-Actor",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -168,7 +226,7 @@ const docTemplate_swagger = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/entity.Product"
+                                            "$ref": "#/definitions/entity.CycleCount"
                                         },
                                         "meta": {
                                             "$ref": "#/definitions/response.MetaInfo"
@@ -178,6 +236,12 @@ const docTemplate_swagger = `{
                             ]
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -199,9 +263,9 @@ const docTemplate_swagger = `{
                 }
             }
         },
-        "/products/bulk-reduce-qty": {
+        "/cycle-counts/{id}/reject": {
             "post": {
-                "description": "An API to bulk reduce quantity product",
+                "description": "An API to reject a pending cycle count without changing the qty of its products",
                 "consumes": [
                     "application/json"
                 ],
@@ -209,11 +273,18 @@ const docTemplate_swagger = `{
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "cycle-count"
                 ],
-                "summary": "Bulk Reduce Quantity Product",
-                "operationId": "bulk-reduce-qty",
+                "summary": "Reject Cycle Count",
+                "operationId": "reject-cycle-count",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cycle Count ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "default": "lorem",
@@ -222,15 +293,6 @@ const docTemplate_swagger = `{
                         "name": "X-Tenant",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "Payload",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/entity.BulkReduceQtyProductPayload"
-                        }
                     }
                 ],
                 "responses": {
@@ -245,7 +307,7 @@ const docTemplate_swagger = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/entity.Product"
+                                            "$ref": "#/definitions/entity.CycleCount"
                                         },
                                         "meta": {
                                             "$ref": "#/definitions/response.MetaInfo"
@@ -255,6 +317,12 @@ const docTemplate_swagger = `{
                             ]
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -276,9 +344,9 @@ const docTemplate_swagger = `{
                 }
             }
         },
-        "/products/{id}": {
+        "/discount-rules": {
             "get": {
-                "description": "An API to show product detail",
+                "description": "An API to show discount rules of the tenant in the evaluation order",
                 "consumes": [
                     "application/json"
                 ],
@@ -286,18 +354,68 @@ const docTemplate_swagger = `{
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "discount-rule"
                 ],
-                "summary": "Show Product Detail",
-                "operationId": "detail",
+                "summary": "Show Discount Rule List",
+                "operationId": "list-discount-rule",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.DiscountRule"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "An API to create discount rule applied to every product matching all of its conditions.\nThe conditions are the filters of the product search: any of the categories, any of the conditions,\nand every word of the keyword in the title, description or attribute values, without stemming.\nPercentage value is in basis points. Rules are evaluated from the highest priority, then the oldest one.\nStackable rules are applied on the price left by the previous rules,\nwhile a non-stackable rule is only applied when no rule has been applied and stops the evaluation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "discount-rule"
+                ],
+                "summary": "Create Discount Rule",
+                "operationId": "create-discount-rule",
+                "parameters": [
                     {
                         "type": "string",
                         "default": "lorem",
@@ -306,6 +424,15 @@ const docTemplate_swagger = `{
                         "name": "X-Tenant",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.SwaggerDiscountRulePayload"
+                        }
                     }
                 ],
                 "responses": {
@@ -320,7 +447,7 @@ const docTemplate_swagger = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/entity.Product"
+                                            "$ref": "#/definitions/entity.DiscountRule"
                                         },
                                         "meta": {
                                             "$ref": "#/definitions/response.MetaInfo"
@@ -330,8 +457,8 @@ const docTemplate_swagger = `{
                             ]
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorBody"
                         }
@@ -343,9 +470,11 @@ const docTemplate_swagger = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/discount-rules/{id}": {
             "put": {
-                "description": "An API to update product",
+                "description": "An API to update discount rule",
                 "consumes": [
                     "application/json"
                 ],
@@ -353,14 +482,14 @@ const docTemplate_swagger = `{
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "discount-rule"
                 ],
-                "summary": "Update Product",
-                "operationId": "update",
+                "summary": "Update Discount Rule",
+                "operationId": "update-discount-rule",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
+                        "description": "Discount Rule ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -380,7 +509,7 @@ const docTemplate_swagger = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.SwaggerProductPayload"
+                            "$ref": "#/definitions/entity.SwaggerDiscountRulePayload"
                         }
                     }
                 ],
@@ -396,7 +525,7 @@ const docTemplate_swagger = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/entity.Product"
+                                            "$ref": "#/definitions/entity.DiscountRule"
                                         },
                                         "meta": {
                                             "$ref": "#/definitions/response.MetaInfo"
@@ -425,46 +554,4104 @@ const docTemplate_swagger = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "entity.BulkReduceQtyProductItemPayload": {
-            "type": "object",
-            "properties": {
-                "req_qty": {
-                    "type": "integer"
-                },
-                "sku": {
-                    "type": "string"
-                }
-            }
+            },
+            "delete": {
+                "description": "An API to delete discount rule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "discount-rule"
+                ],
+                "summary": "Delete Discount Rule",
+                "operationId": "delete-discount-rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Discount Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations": {
+            "get": {
+                "description": "An API to show locations of the tenant from the highest priority",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "location"
+                ],
+                "summary": "Show Location List",
+                "operationId": "list-location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.Location"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "An API to create warehouse or store location which keeps stock.\nStock given without location goes to the default location, setting is_default moves the default from the current one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "location"
+                ],
+                "summary": "Create Location",
+                "operationId": "create-location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.SwaggerLocationPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Location"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{id}": {
+            "put": {
+                "description": "An API to update location, the default location stays the default until another location is made the default",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "location"
+                ],
+                "summary": "Update Location",
+                "operationId": "update-location",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.SwaggerLocationPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Location"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/low-stock-threshold": {
+            "get": {
+                "description": "An API to show the low stock threshold of the tenant, it is used by the products which have no threshold of their own",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "low stock"
+                ],
+                "summary": "Show Low Stock Threshold",
+                "operationId": "detail-low-stock-threshold",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.LowStockThreshold"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "An API to set the low stock threshold of the tenant. An alert is sent when the qty of a product falls to its threshold,\nand it is not sent again until the qty of the product recovers above the threshold",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "low stock"
+                ],
+                "summary": "Update Low Stock Threshold",
+                "operationId": "update-low-stock-threshold",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.SwaggerLowStockThresholdPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.LowStockThreshold"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "get": {
+                "description": "An API to show product list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Show Product List",
+                "operationId": "list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "ID",
+                        "description": "Region Header",
+                        "name": "X-Region",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "finance",
+                        "description": "Scopes Header, finance scope is required to see cost price",
+                        "name": "X-Scopes",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "full-text search of title, description and attributes in the language of the tenant, supports quoted phrases, or and -word. Matches are sorted by relevance when no sort is given and get a highlight",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sku product, comma separated",
+                        "name": "sku",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "book,bag",
+                        "description": "category product, comma separated",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "new,preloved",
+                        "description": "condition product, comma separated",
+                        "name": "condition",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "minimum price",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum price",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "minimum qty",
+                        "name": "min_qty",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum qty",
+                        "name": "max_qty",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2023-12-01",
+                        "description": "created at or after, RFC3339 or date",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2023-12-31",
+                        "description": "created at or before, RFC3339 or date",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2023-12-01",
+                        "description": "updated at or after, RFC3339 or date",
+                        "name": "updated_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2023-12-31",
+                        "description": "updated at or before, RFC3339 or date",
+                        "name": "updated_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "low, in, out",
+                        "description": "stock filter, low returns the products at or below their low stock threshold, in and out return the products with and without qty left after the reserved and backordered qty",
+                        "name": "stock",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "-price,title",
+                        "description": "comma separated sort keys of price, qty, title, updated_at, created_at and relevance, a key prefixed by - is sorted descending, the id breaks the ties",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "created_at DESC",
+                        "description": "order by a single field, ignored when sort is given",
+                        "name": "orderby",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor or prev_cursor of a page, the offset is ignored and the order of the cursor is kept",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "id,title,price",
+                        "description": "comma separated fields of the response, all fields are given when it is empty",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "stocks,lots",
+                        "description": "comma separated relations embedded in the response, stocks and lots are embedded when neither fields nor include is given",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.Product"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "An API to create product\nStock per location is given with stocks, otherwise qty is kept on the default location of the tenant.\nThe initial qty is recorded on the stock movements with stock_reason, restock when it is not given",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Create Product",
+                "operationId": "create",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "ID",
+                        "description": "Region Header",
+                        "name": "X-Region",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "finance",
+                        "description": "Scopes Header, finance scope is required to see and set cost price",
+                        "name": "X-Scopes",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "jane@example.com",
+                        "description": "Actor Header, recorded on the stock movement of the initial qty",
+                        "name": "X-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "order-123-attempt",
+                        "description": "Idempotency Key Header, retries with the same key get the stored response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.SwaggerProductPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Product"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the product"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/bulk-increase-qty": {
+            "post": {
+                "description": "An API to bulk increase quantity product on the given location, or on the default location of the tenant.\nOnly the qty is changed, each increase is recorded on the stock movements with the given reason and reference_id.\nThe reason is restock when it is not given, only restock, return and adjustment are allowed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Bulk Increase Quantity Product",
+                "operationId": "bulk-increase-qty",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "jane@example.com",
+                        "description": "Actor Header, recorded on the stock movements",
+                        "name": "X-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.SwaggerBulkIncreaseQtyProductPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/bulk-reduce-qty": {
+            "post": {
+                "description": "An API to bulk reduce quantity product from the given location,\nor from the locations picked by the configured allocation strategy when no location is given.\nQty held by active reservations can not be reduced, only the available qty.\nThe qty beyond the available qty is backordered when the backorder policy of the product allows it,\nthe response shows the status, the qty fulfilled from stock and the qty backordered of each item with the updated products.\nEach reduction from stock is recorded as a sale on the stock movements with the given reference_id.\nNo item is reduced when one of them fails, the field of the error is the failed item.\nWith partial mode the items which can be fulfilled are reduced and the failed ones are reported with their error code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Bulk Reduce Quantity Product",
+                "operationId": "bulk-reduce-qty",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "ID",
+                        "description": "Region Header",
+                        "name": "X-Region",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "finance",
+                        "description": "Scopes Header, finance scope is required to see cost price",
+                        "name": "X-Scopes",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "jane@example.com",
+                        "description": "Actor Header, recorded on the stock movements",
+                        "name": "X-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "order-123-attempt",
+                        "description": "Idempotency Key Header, retries with the same key get the stored response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.BulkReduceQtyProductPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.BulkReduceQtyProductResult"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "description": "An API to show product detail, available_qty is the qty which is not held by any active reservation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Show Product Detail",
+                "operationId": "detail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "ID",
+                        "description": "Region Header",
+                        "name": "X-Region",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "finance",
+                        "description": "Scopes Header, finance scope is required to see cost price",
+                        "name": "X-Scopes",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "id,title,price",
+                        "description": "comma separated fields of the response, all fields are given when it is empty",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "stocks,lots",
+                        "description": "comma separated relations embedded in the response, stocks and lots are embedded when neither fields nor include is given",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Product"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the product, given as If-Match to update it"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "An API to update product\nStocks replace the stock of all locations. Without stocks, added qty goes to the default location\nand reduced qty is taken from the locations picked by the configured allocation strategy.\nThe cost price is kept when it is not given. With weighted_average_cost, the cost price is the unit cost of the added qty\nand the stored cost price becomes the weighted average of the current stock and the added qty.\nThe change of qty is recorded on the stock movements with stock_reason, adjustment when it is not given",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Update Product",
+                "operationId": "update",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "ID",
+                        "description": "Region Header",
+                        "name": "X-Region",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "finance",
+                        "description": "Scopes Header, finance scope is required to see and set cost price",
+                        "name": "X-Scopes",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "jane@example.com",
+                        "description": "Actor Header, recorded on the stock movement when qty changes",
+                        "name": "X-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "order-123-attempt",
+                        "description": "Idempotency Key Header, retries with the same key get the stored response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"3\"",
+                        "description": "ETags of the product the change is based on, the change is rejected when the product has been changed since. Without it the change is applied on the latest version",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.SwaggerProductPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Product"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the product"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "An API to partially update product with a json merge patch (RFC 7396), only the fields of the patch are validated and changed.\nA nullable field is removed with null, e.g. the tax_class_id, cost_price, low_stock_threshold, backorder_limit and restock_date.\nThe attributes are merged key by key, an attribute is removed with null and all of them with null attributes.\nThe qty and stocks are changed like on update product, the other attributes of the update payload are options of the change",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Patch Product",
+                "operationId": "patch",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "ID",
+                        "description": "Region Header",
+                        "name": "X-Region",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "finance",
+                        "description": "Scopes Header, finance scope is required to see and change cost price",
+                        "name": "X-Scopes",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "jane@example.com",
+                        "description": "Actor Header, recorded on the stock movement when qty changes",
+                        "name": "X-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "order-123-attempt",
+                        "description": "Idempotency Key Header, retries with the same key get the stored response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"3\"",
+                        "description": "ETags of the product the patch is based on, the patch is rejected when the product has been changed since. Without it the patch is applied on the latest version",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Merge patch of the product payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.SwaggerProductPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Product"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the product"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/adjust-qty": {
+            "post": {
+                "description": "An API to add a signed delta to the quantity product, a negative delta reduces the available qty\nfrom the given location or from the locations picked by the allocation strategy, a positive one is added\nto the given location or to the default location of the tenant. Only the qty is changed.\nThe change is recorded on the stock movements with the given reason, adjustment when it is not given.\nSale and damage must reduce the qty, restock and return must increase it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Adjust Quantity Product",
+                "operationId": "adjust-qty",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "jane@example.com",
+                        "description": "Actor Header, recorded on the stock movement",
+                        "name": "X-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.SwaggerAdjustQtyProductPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/price-history": {
+            "get": {
+                "description": "An API to show price history of a product and its lowest price in the last 30 days",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Show Product Price History",
+                "operationId": "price-history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "start of period, RFC3339 or YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "end of period, RFC3339 or YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.ProductPriceHistory"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/price-schedules": {
+            "get": {
+                "description": "An API to show price schedules of a product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "price-schedule"
+                ],
+                "summary": "Show Price Schedule List",
+                "operationId": "list-price-schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.PriceSchedule"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "An API to schedule a future price change or a time-boxed promotion of a product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "price-schedule"
+                ],
+                "summary": "Create Price Schedule",
+                "operationId": "create-price-schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.SwaggerPriceSchedulePayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.PriceSchedule"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/price-schedules/{schedule_id}": {
+            "delete": {
+                "description": "An API to cancel a pending price schedule or end a running promotion",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "price-schedule"
+                ],
+                "summary": "Cancel Price Schedule",
+                "operationId": "cancel-price-schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Price Schedule ID",
+                        "name": "schedule_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.PriceSchedule"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/serials": {
+            "get": {
+                "description": "An API to show the serial numbers of a product in the order they were registered",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "serial"
+                ],
+                "summary": "Show Product Serial Numbers",
+                "operationId": "product-serials",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "in_stock, sold, written_off",
+                        "description": "status of the serial number",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.ProductSerial"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/stock-movements": {
+            "get": {
+                "description": "An API to show the ledger of qty changes of a product from the latest one.\nEach movement has the delta, the qty after the change, the reason, the actor and the reference id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Show Product Stock Movements",
+                "operationId": "stock-movements",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "sale, restock, adjustment, return, damage",
+                        "description": "reason of the movement",
+                        "name": "reason",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "start of period, RFC3339 or YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "end of period, RFC3339 or YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.StockMovement"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/expiring-lots": {
+            "get": {
+                "description": "An API to show the lots in stock which expire within the given days, the first expiring first.\nThe expired lots are included, their qty is still on hand but not available",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "report"
+                ],
+                "summary": "Show Expiring Lot Report",
+                "operationId": "report-expiring-lot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Days ahead, default 30",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.ExpiringLot"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/inventory-valuation": {
+            "get": {
+                "description": "An API to show value of the stock on hand at cost and at regular price per category and condition",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "report"
+                ],
+                "summary": "Show Inventory Valuation Report",
+                "operationId": "report-inventory-valuation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "finance",
+                        "description": "Scopes Header",
+                        "name": "X-Scopes",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.InventoryValuation"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/margins": {
+            "get": {
+                "description": "An API to show margin of products on their running price, margin rate is in basis points.\nMargin is empty for products without cost price.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "report"
+                ],
+                "summary": "Show Product Margin Report",
+                "operationId": "report-margin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "finance",
+                        "description": "Scopes Header",
+                        "name": "X-Scopes",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "full-text search of title, description and attributes in the language of the tenant",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sku product, comma separated",
+                        "name": "sku",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "book,bag",
+                        "description": "category product, comma separated",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "new,preloved",
+                        "description": "condition product, comma separated",
+                        "name": "condition",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "-price,title",
+                        "description": "comma separated sort keys of price, qty, title, updated_at, created_at and relevance, a key prefixed by - is sorted descending, the id breaks the ties",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "created_at DESC",
+                        "description": "order by a single field, ignored when sort is given",
+                        "name": "orderby",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.ProductMargin"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations": {
+            "post": {
+                "description": "An API to hold qty of products for a checkout, the held qty can not be reduced by other requests.\nThe reservation is released automatically when it is not confirmed before ttl_seconds, the configured ttl is used when it is not given",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservation"
+                ],
+                "summary": "Create Reservation",
+                "operationId": "create-reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.SwaggerReservationPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Reservation"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}": {
+            "get": {
+                "description": "An API to show reservation detail",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservation"
+                ],
+                "summary": "Show Reservation Detail",
+                "operationId": "detail-reservation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Reservation"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}/confirm": {
+            "post": {
+                "description": "An API to confirm an active reservation, the held qty is reduced from the stock\nusing the configured allocation strategy and recorded as a sale on the stock movements",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservation"
+                ],
+                "summary": "Confirm Reservation",
+                "operationId": "confirm-reservation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "jane@example.com",
+                        "description": "Actor Header, recorded on the stock movements",
+                        "name": "X-Actor",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Reservation"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}/release": {
+            "post": {
+                "description": "An API to release an active reservation, the held qty becomes available again without reducing the stock",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservation"
+                ],
+                "summary": "Release Reservation",
+                "operationId": "release-reservation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Reservation"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/serials/{serial_number}": {
+            "get": {
+                "description": "An API to look up the product of a serial number. A serial number which is out of stock\nhas the reference id, actor and time of the deduction which took it, e.g. the sale",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "serial"
+                ],
+                "summary": "Show Serial Number Detail",
+                "operationId": "detail-serial",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Serial Number",
+                        "name": "serial_number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.ProductSerial"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/tax-classes": {
+            "get": {
+                "description": "An API to show tax classes of the tenant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-class"
+                ],
+                "summary": "Show Tax Class List",
+                "operationId": "list-tax-class",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.TaxClass"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "An API to create tax class with its rates per region, rate is in basis points and empty region is the default rate",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-class"
+                ],
+                "summary": "Create Tax Class",
+                "operationId": "create-tax-class",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.SwaggerTaxClassPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.TaxClass"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/tax-classes/{id}": {
+            "put": {
+                "description": "An API to update tax class, its rates and the categories defaulted to it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-class"
+                ],
+                "summary": "Update Tax Class",
+                "operationId": "update-tax-class",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax Class ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.SwaggerTaxClassPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.TaxClass"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers": {
+            "post": {
+                "description": "An API to create a draft transfer of stock from a location to another location of the tenant.\nThe qty of the products is only changed when the transfer is dispatched",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfer"
+                ],
+                "summary": "Create Transfer",
+                "operationId": "create-transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.TransferPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Transfer"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{id}": {
+            "get": {
+                "description": "An API to show transfer detail with its items",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfer"
+                ],
+                "summary": "Show Transfer Detail",
+                "operationId": "detail-transfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Transfer"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{id}/dispatch": {
+            "post": {
+                "description": "An API to dispatch a draft transfer, the qty of its items is reduced from the source location in one transaction\nand kept as the in transit qty of the products until the transfer is received",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfer"
+                ],
+                "summary": "Dispatch Transfer",
+                "operationId": "dispatch-transfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "jane@example.com",
+                        "description": "Actor Header, recorded on the stock movements",
+                        "name": "X-Actor",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Transfer"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{id}/receive": {
+            "post": {
+                "description": "An API to receive an in transit transfer, the qty of its items is added to the destination location in one transaction\nand fills the open backorders of the products",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfer"
+                ],
+                "summary": "Receive Transfer",
+                "operationId": "receive-transfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "lorem",
+                        "example": "lorem, ipsum",
+                        "description": "Tenant Header",
+                        "name": "X-Tenant",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "jane@example.com",
+                        "description": "Actor Header, recorded on the stock movements",
+                        "name": "X-Actor",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.SuccessBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Transfer"
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/response.MetaInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorBody"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "entity.AppliedPromotion": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "discount_rule_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/types.DiscountType"
+                },
+                "value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.BulkIncreaseQtyProductItemPayload": {
+            "type": "object",
+            "properties": {
+                "expiry_date": {
+                    "type": "string"
+                },
+                "location_id": {
+                    "type": "integer"
+                },
+                "lot_number": {
+                    "description": "LotNumber and ExpiryDate are given together to add the qty to a lot, the lot is created when it does not exist",
+                    "type": "string"
+                },
+                "req_qty": {
+                    "type": "integer"
+                },
+                "serial_numbers": {
+                    "description": "SerialNumbers are registered as the added units of a serialized product, one for each unit of the qty",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sku": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.BulkReduceQtyProductItemPayload": {
+            "type": "object",
+            "properties": {
+                "location_id": {
+                    "type": "integer"
+                },
+                "req_qty": {
+                    "type": "integer"
+                },
+                "serial_numbers": {
+                    "description": "SerialNumbers are the units of a serialized product which are sold, one for each unit of the qty.\nThe units in stock first are sold when they are not given",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sku": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.BulkReduceQtyProductItemResult": {
+            "type": "object",
+            "properties": {
+                "backordered_qty": {
+                    "type": "integer"
+                },
+                "error_code": {
+                    "type": "integer"
+                },
+                "fulfilled_qty": {
+                    "type": "integer"
+                },
+                "req_qty": {
+                    "type": "integer"
+                },
+                "restock_date": {
+                    "type": "string"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/types.BulkItemStatusType"
+                }
+            }
+        },
+        "entity.BulkReduceQtyProductPayload": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.BulkReduceQtyProductItemPayload"
+                    }
+                },
+                "mode": {
+                    "description": "Mode is atomic when it is not given, no item is reduced when one of them fails.\nThe items which can be fulfilled are reduced in partial mode and the failed ones are reported",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.BulkModeType"
+                        }
+                    ]
+                },
+                "reference_id": {
+                    "description": "ReferenceID is recorded on the stock movements, e.g. the order id",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.BulkReduceQtyProductResult": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.BulkReduceQtyProductItemResult"
+                    }
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Product"
+                    }
+                }
+            }
+        },
+        "entity.CycleCount": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.CycleCountItem"
+                    }
+                },
+                "status": {
+                    "$ref": "#/definitions/types.CycleCountStatusType"
+                },
+                "tenant": {
+                    "$ref": "#/definitions/types.TenantType"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.CycleCountItem": {
+            "type": "object",
+            "properties": {
+                "counted_qty": {
+                    "type": "integer"
+                },
+                "diff_qty": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "system_qty": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.CycleCountItemPayload": {
+            "type": "object",
+            "properties": {
+                "counted_qty": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.CycleCountPayload": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.CycleCountItemPayload"
+                    }
+                }
+            }
+        },
+        "entity.DiscountRule": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.CategoryType"
+                    }
+                },
+                "conditions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ConditionType"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "keyword": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stackable": {
+                    "type": "boolean"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "tenant": {
+                    "$ref": "#/definitions/types.TenantType"
+                },
+                "type": {
+                    "$ref": "#/definitions/types.DiscountType"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.ExpiringLot": {
+            "type": "object",
+            "properties": {
+                "expired": {
+                    "type": "boolean"
+                },
+                "expiry_date": {
+                    "type": "string"
+                },
+                "lot_number": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "qty": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.InventoryValuation": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "$ref": "#/definitions/types.CategoryType"
+                },
+                "condition": {
+                    "$ref": "#/definitions/types.ConditionType"
+                },
+                "cost_value": {
+                    "type": "integer"
+                },
+                "product_count": {
+                    "type": "integer"
+                },
+                "qty": {
+                    "type": "integer"
+                },
+                "retail_value": {
+                    "type": "integer"
+                },
+                "uncosted_product_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.Location": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "tenant": {
+                    "$ref": "#/definitions/types.TenantType"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.LowStockThreshold": {
+            "type": "object",
+            "properties": {
+                "tenant": {
+                    "$ref": "#/definitions/types.TenantType"
+                },
+                "threshold": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.PriceHistory": {
+            "type": "object",
+            "properties": {
+                "effective_price": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "promotion_price": {
+                    "type": "integer"
+                },
+                "recorded_at": {
+                    "type": "string"
+                },
+                "tenant": {
+                    "$ref": "#/definitions/types.TenantType"
+                }
+            }
+        },
+        "entity.PriceSchedule": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/types.PriceScheduleStatusType"
+                },
+                "tenant": {
+                    "$ref": "#/definitions/types.TenantType"
+                },
+                "type": {
+                    "$ref": "#/definitions/types.PriceScheduleType"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Product": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "available_qty": {
+                    "type": "integer"
+                },
+                "backorder_limit": {
+                    "type": "integer"
+                },
+                "backorder_policy": {
+                    "$ref": "#/definitions/types.BackorderPolicyType"
+                },
+                "backordered_qty": {
+                    "type": "integer"
+                },
+                "category": {
+                    "$ref": "#/definitions/types.CategoryType"
+                },
+                "compare_at_price": {
+                    "type": "integer"
+                },
+                "condition": {
+                    "$ref": "#/definitions/types.ConditionType"
+                },
+                "cost_price": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "expired_qty": {
+                    "type": "integer"
+                },
+                "highlight": {
+                    "description": "Highlight is the snippet of the title and description with the words matching the keyword marked",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "in_transit_qty": {
+                    "type": "integer"
+                },
+                "lots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ProductLot"
+                    }
+                },
+                "low_stock_threshold": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.AppliedPromotion"
+                    }
+                },
+                "qty": {
+                    "type": "integer"
+                },
+                "reserved_qty": {
+                    "type": "integer"
+                },
+                "restock_date": {
+                    "type": "string"
+                },
+                "serialized": {
+                    "type": "boolean"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stocks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ProductStock"
+                    }
+                },
+                "tax": {
+                    "$ref": "#/definitions/entity.TaxAmount"
+                },
+                "tax_class_id": {
+                    "type": "integer"
+                },
+                "tenant": {
+                    "$ref": "#/definitions/types.TenantType"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.ProductLot": {
+            "type": "object",
+            "properties": {
+                "expired": {
+                    "type": "boolean"
+                },
+                "expiry_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lot_number": {
+                    "type": "string"
+                },
+                "qty": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.ProductMargin": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "$ref": "#/definitions/types.CategoryType"
+                },
+                "condition": {
+                    "$ref": "#/definitions/types.ConditionType"
+                },
+                "cost_price": {
+                    "type": "integer"
+                },
+                "margin": {
+                    "type": "integer"
+                },
+                "margin_rate": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "qty": {
+                    "type": "integer"
+                },
+                "selling_price": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ProductPriceHistory": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PriceHistory"
+                    }
+                },
+                "lowest_price_30d": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.ProductSerial": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deducted_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "product": {
+                    "$ref": "#/definitions/entity.Product"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "reference_id": {
+                    "type": "string"
+                },
+                "serial_number": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/types.SerialStatusType"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ProductStock": {
+            "type": "object",
+            "properties": {
+                "location_code": {
+                    "type": "string"
+                },
+                "location_id": {
+                    "type": "integer"
+                },
+                "location_name": {
+                    "type": "string"
+                },
+                "qty": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.ProductStockPayload": {
+            "type": "object",
+            "properties": {
+                "location_id": {
+                    "type": "integer"
+                },
+                "qty": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.Reservation": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ReservationItem"
+                    }
+                },
+                "status": {
+                    "$ref": "#/definitions/types.ReservationStatusType"
+                },
+                "tenant": {
+                    "$ref": "#/definitions/types.TenantType"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ReservationItem": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "qty": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ReservationItemPayload": {
+            "type": "object",
+            "properties": {
+                "qty": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.StockMovement": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delta": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "$ref": "#/definitions/types.StockMovementReasonType"
+                },
+                "reference_id": {
+                    "type": "string"
+                },
+                "resulting_qty": {
+                    "type": "integer"
+                },
+                "tenant": {
+                    "$ref": "#/definitions/types.TenantType"
+                }
+            }
         },
-        "entity.BulkReduceQtyProductPayload": {
+        "entity.SwaggerAdjustQtyProductPayload": {
+            "type": "object",
+            "properties": {
+                "delta": {
+                    "type": "integer",
+                    "example": -2
+                },
+                "location_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "damage"
+                },
+                "reference_id": {
+                    "type": "string"
+                },
+                "serial_numbers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "entity.SwaggerBulkIncreaseQtyProductPayload": {
             "type": "object",
             "properties": {
                 "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/entity.BulkReduceQtyProductItemPayload"
+                        "$ref": "#/definitions/entity.BulkIncreaseQtyProductItemPayload"
                     }
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "restock"
+                },
+                "reference_id": {
+                    "type": "string"
                 }
             }
         },
-        "entity.Product": {
+        "entity.SwaggerDiscountRulePayload": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "book"
+                    ]
+                },
+                "conditions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "preloved"
+                    ]
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2023-12-27T00:00:00Z"
+                },
+                "keyword": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Preloved book sale"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stackable": {
+                    "type": "boolean"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2023-12-24T00:00:00Z"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "percentage"
+                },
+                "value": {
+                    "type": "integer",
+                    "example": 2000
+                }
+            }
+        },
+        "entity.SwaggerLocationPayload": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "jkt-1"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Jakarta Warehouse"
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "entity.SwaggerLowStockThresholdPayload": {
+            "type": "object",
+            "properties": {
+                "threshold": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "entity.SwaggerPriceSchedulePayload": {
+            "type": "object",
+            "properties": {
+                "ends_at": {
+                    "type": "string",
+                    "example": "2023-12-27T00:00:00Z"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2023-12-24T00:00:00Z"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "promotion"
+                }
+            }
+        },
+        "entity.SwaggerProductPayload": {
             "type": "object",
             "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "backorder_limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "backorder_policy": {
+                    "type": "string",
+                    "example": "limited"
+                },
                 "category": {
                     "type": "string"
                 },
                 "condition": {
+                    "type": "string"
+                },
+                "cost_price": {
                     "type": "integer"
                 },
-                "created_at": {
+                "description": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "low_stock_threshold": {
+                    "type": "integer",
+                    "example": 5
                 },
                 "price": {
                     "type": "integer"
@@ -472,37 +4659,242 @@ const docTemplate_swagger = `{
                 "qty": {
                     "type": "integer"
                 },
-                "sku": {
+                "reference_id": {
                     "type": "string"
                 },
-                "tenant": {
+                "restock_date": {
+                    "type": "string",
+                    "example": "2024-01-31T00:00:00Z"
+                },
+                "serialized": {
+                    "type": "boolean"
+                },
+                "stock_reason": {
+                    "type": "string",
+                    "example": "restock"
+                },
+                "stocks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ProductStockPayload"
+                    }
+                },
+                "tax_class_id": {
                     "type": "integer"
                 },
                 "title": {
                     "type": "string"
                 },
+                "weighted_average_cost": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "entity.SwaggerReservationPayload": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ReservationItemPayload"
+                    }
+                },
+                "ttl_seconds": {
+                    "type": "integer",
+                    "example": 900
+                }
+            }
+        },
+        "entity.SwaggerTaxClassPayload": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "book"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "standard"
+                },
+                "price_includes_tax": {
+                    "type": "boolean"
+                },
+                "rates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.TaxRatePayload"
+                    }
+                }
+            }
+        },
+        "entity.TaxAmount": {
+            "type": "object",
+            "properties": {
+                "gross": {
+                    "type": "integer"
+                },
+                "net": {
+                    "type": "integer"
+                },
+                "rate": {
+                    "type": "integer"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "tax": {
+                    "type": "integer"
+                },
+                "tax_class_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.TaxClass": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.CategoryType"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price_includes_tax": {
+                    "type": "boolean"
+                },
+                "rates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.TaxRate"
+                    }
+                },
+                "tenant": {
+                    "$ref": "#/definitions/types.TenantType"
+                },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "entity.SwaggerProductPayload": {
+        "entity.TaxRate": {
             "type": "object",
             "properties": {
-                "category": {
+                "rate": {
+                    "type": "integer"
+                },
+                "region": {
                     "type": "string"
+                }
+            }
+        },
+        "entity.TaxRatePayload": {
+            "type": "object",
+            "properties": {
+                "rate": {
+                    "type": "integer"
                 },
-                "condition": {
+                "region": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Transfer": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
-                "price": {
+                "dispatched_at": {
+                    "type": "string"
+                },
+                "from_location_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.TransferItem"
+                    }
+                },
+                "received_at": {
+                    "type": "string"
+                },
+                "reference_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/types.TransferStatusType"
+                },
+                "tenant": {
+                    "$ref": "#/definitions/types.TenantType"
+                },
+                "to_location_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.TransferItem": {
+            "type": "object",
+            "properties": {
+                "product_id": {
                     "type": "integer"
                 },
                 "qty": {
                     "type": "integer"
                 },
-                "title": {
+                "sku": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.TransferItemPayload": {
+            "type": "object",
+            "properties": {
+                "qty": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.TransferPayload": {
+            "type": "object",
+            "properties": {
+                "from_location_id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.TransferItemPayload"
+                    }
+                },
+                "reference_id": {
                     "type": "string"
+                },
+                "to_location_id": {
+                    "type": "integer"
                 }
             }
         },
@@ -541,9 +4933,15 @@ const docTemplate_swagger = `{
                 "limit": {
                     "type": "integer"
                 },
+                "next_cursor": {
+                    "type": "string"
+                },
                 "offset": {
                     "type": "integer"
                 },
+                "prev_cursor": {
+                    "type": "string"
+                },
                 "total": {
                     "type": "integer"
                 }
@@ -558,6 +4956,214 @@ const docTemplate_swagger = `{
                 },
                 "meta": {}
             }
+        },
+        "types.BackorderPolicyType": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3
+            ],
+            "x-enum-varnames": [
+                "BackorderPolicyEmptyType",
+                "BackorderPolicyDenyType",
+                "BackorderPolicyLimitedType",
+                "BackorderPolicyUnlimitedType"
+            ]
+        },
+        "types.BulkItemStatusType": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2
+            ],
+            "x-enum-varnames": [
+                "BulkItemStatusEmptyType",
+                "BulkItemStatusAppliedType",
+                "BulkItemStatusFailedType"
+            ]
+        },
+        "types.BulkModeType": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2
+            ],
+            "x-enum-varnames": [
+                "BulkModeEmptyType",
+                "BulkModeAtomicType",
+                "BulkModePartialType"
+            ]
+        },
+        "types.CategoryType": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3
+            ],
+            "x-enum-varnames": [
+                "CategoryEmptyType",
+                "CategoryBookType",
+                "CategoryComputerType",
+                "CategoryBagType"
+            ]
+        },
+        "types.ConditionType": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2
+            ],
+            "x-enum-varnames": [
+                "ConditionEmptyType",
+                "ConditionNewType",
+                "ConditionPrelovedType"
+            ]
+        },
+        "types.CycleCountStatusType": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3
+            ],
+            "x-enum-varnames": [
+                "CycleCountStatusEmptyType",
+                "CycleCountStatusPendingType",
+                "CycleCountStatusApprovedType",
+                "CycleCountStatusRejectedType"
+            ]
+        },
+        "types.DiscountType": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2
+            ],
+            "x-enum-varnames": [
+                "DiscountEmptyType",
+                "DiscountPercentageType",
+                "DiscountFixedAmountType"
+            ]
+        },
+        "types.PriceScheduleStatusType": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3,
+                4
+            ],
+            "x-enum-varnames": [
+                "PriceScheduleStatusEmptyType",
+                "PriceScheduleStatusPendingType",
+                "PriceScheduleStatusActiveType",
+                "PriceScheduleStatusCompletedType",
+                "PriceScheduleStatusCancelledType"
+            ]
+        },
+        "types.PriceScheduleType": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2
+            ],
+            "x-enum-varnames": [
+                "PriceScheduleEmptyType",
+                "PriceSchedulePriceChangeType",
+                "PriceSchedulePromotionType"
+            ]
+        },
+        "types.ReservationStatusType": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3,
+                4
+            ],
+            "x-enum-varnames": [
+                "ReservationStatusEmptyType",
+                "ReservationStatusActiveType",
+                "ReservationStatusConfirmedType",
+                "ReservationStatusReleasedType",
+                "ReservationStatusExpiredType"
+            ]
+        },
+        "types.SerialStatusType": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3
+            ],
+            "x-enum-varnames": [
+                "SerialStatusEmptyType",
+                "SerialStatusInStockType",
+                "SerialStatusSoldType",
+                "SerialStatusWrittenOffType"
+            ]
+        },
+        "types.StockMovementReasonType": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3,
+                4,
+                5,
+                6
+            ],
+            "x-enum-varnames": [
+                "StockMovementReasonEmptyType",
+                "StockMovementReasonSaleType",
+                "StockMovementReasonRestockType",
+                "StockMovementReasonAdjustmentType",
+                "StockMovementReasonReturnType",
+                "StockMovementReasonDamageType",
+                "StockMovementReasonTransferType"
+            ]
+        },
+        "types.TenantType": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2
+            ],
+            "x-enum-varnames": [
+                "TenantEmptyType",
+                "TenantLoremType",
+                "TenantIpsumType"
+            ]
+        },
+        "types.TransferStatusType": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3
+            ],
+            "x-enum-varnames": [
+                "TransferStatusEmptyType",
+                "TransferStatusDraftType",
+                "TransferStatusInTransitType",
+                "TransferStatusReceivedType"
+            ]
         }
     }
 }`
@@ -571,7 +5177,7 @@ var SwaggerInfo = &swag.Spec{
 	Title:            "Catalog API",
 	Description:      "An API Documentation",
 	InfoInstanceName: "swagger",
-	SwaggerTemplate:  docTemplate_swagger,
+	SwaggerTemplate:  docTemplate,
 }
 
 func init() {
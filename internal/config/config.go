@@ -1,15 +1,21 @@
 package config
 
 import (
+	"time"
+
 	"github.com/joeshaw/envdecode"
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Port           uint16 `env:"PORT,default=9999"`
-	Env            string `env:"ENV"`
-	LogLevel       string `env:"LOG_LEVEL,default=debug"`
-	DatabaseConfig DatabaseConfig
+	Port              uint16 `env:"PORT,default=9999"`
+	Env               string `env:"ENV"`
+	LogLevel          string `env:"LOG_LEVEL,default=debug"`
+	DatabaseConfig    DatabaseConfig
+	WorkerConfig      WorkerConfig
+	StockConfig       StockConfig
+	IdempotencyConfig IdempotencyConfig
+	NotifierConfig    NotifierConfig
 }
 
 type DatabaseConfig struct {
@@ -23,6 +29,37 @@ type DatabaseConfig struct {
 	Pool     int    `env:"DATABASE_POOL,default=50"`
 }
 
+type WorkerConfig struct {
+	PriceSchedulerInterval          time.Duration `env:"PRICE_SCHEDULER_INTERVAL,default=1m"`
+	ReservationSweeperInterval      time.Duration `env:"RESERVATION_SWEEPER_INTERVAL,default=1m"`
+	IdempotencySweeperInterval      time.Duration `env:"IDEMPOTENCY_SWEEPER_INTERVAL,default=1h"`
+	LowStockAlertDispatcherInterval time.Duration `env:"LOW_STOCK_ALERT_DISPATCHER_INTERVAL,default=30s"`
+}
+
+type StockConfig struct {
+	// AllocationStrategy is used to pick the locations to reduce stock from when no location is given,
+	// one of priority, most_stock or single_location
+	AllocationStrategy string `env:"STOCK_ALLOCATION_STRATEGY,default=priority"`
+	// ReservationTTL is how long a reservation holds stock when the request gives no ttl
+	ReservationTTL time.Duration `env:"STOCK_RESERVATION_TTL,default=15m"`
+}
+
+type IdempotencyConfig struct {
+	// KeyTTL is how long the response of a request is replayed for retries with the same idempotency key
+	KeyTTL time.Duration `env:"IDEMPOTENCY_KEY_TTL,default=24h"`
+	// InProgressTTL is how long a key blocks the retries while its first request is processed,
+	// after that the key is taken over by the retry in case the first request never stored its response
+	InProgressTTL time.Duration `env:"IDEMPOTENCY_IN_PROGRESS_TTL,default=1m"`
+}
+
+type NotifierConfig struct {
+	// Type is where the alerts are sent, one of log or webhook
+	Type string `env:"NOTIFIER_TYPE,default=log"`
+	// WebhookURL receives the alerts as JSON POST requests when the type is webhook
+	WebhookURL     string        `env:"NOTIFIER_WEBHOOK_URL"`
+	WebhookTimeout time.Duration `env:"NOTIFIER_WEBHOOK_TIMEOUT,default=5s"`
+}
+
 func NewConfig() *Config {
 	var cfg Config
 	godotenv.Load(".env")
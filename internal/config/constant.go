@@ -5,6 +5,16 @@ const (
 	ServiceName = "catalog"
 	// UniqueConstraintViolationCode is the pgError code for unique constraint violation error
 	UniqueConstraintViolationCode = "23505"
+	// SerializationFailureCode is the pgError code for a transaction which can not be serialized with a concurrent transaction
+	SerializationFailureCode = "40001"
+	// DeadlockDetectedCode is the pgError code for a transaction aborted to break a deadlock
+	DeadlockDetectedCode = "40P01"
 	// SKUTenantUniqueConstraint is the name sku and tenant index name
 	SKUTenantUniqueConstraint = "products_sku_tenant_idx"
+	// TaxClassNameTenantUniqueConstraint is the name of tax class name and tenant index name
+	TaxClassNameTenantUniqueConstraint = "tax_classes_name_tenant_idx"
+	// LocationCodeTenantUniqueConstraint is the name of location code and tenant index name
+	LocationCodeTenantUniqueConstraint = "locations_code_tenant_idx"
+	// FinanceScope is the scope required to see and change the cost of products
+	FinanceScope = "finance"
 )
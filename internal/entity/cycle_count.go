@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// CycleCount struct holds entity of a physical stock count reconciled against the qty of the products
+type CycleCount struct {
+	ID        int                        `json:"id"`
+	Tenant    types.TenantType           `json:"tenant"`
+	Status    types.CycleCountStatusType `json:"status"`
+	Items     []*CycleCountItem          `json:"items"`
+	CreatedAt time.Time                  `json:"created_at"`
+	UpdatedAt time.Time                  `json:"updated_at"`
+}
+
+// ItemsByProductID return a copy of the items in the order their products are locked
+func (c *CycleCount) ItemsByProductID() []*CycleCountItem {
+	items := make([]*CycleCountItem, len(c.Items))
+	copy(items, c.Items)
+	sortByProductID(items, func(i int) int { return items[i].ProductID })
+
+	return items
+}
+
+// CycleCountItem struct holds entity of the counted qty of a product and its diff against the qty on the system
+type CycleCountItem struct {
+	ID           int    `json:"-"`
+	CycleCountID int    `json:"-"`
+	ProductID    int    `json:"product_id"`
+	SKU          string `json:"sku"`
+	SystemQty    int    `json:"system_qty"`
+	CountedQty   int    `json:"counted_qty"`
+	DiffQty      int    `json:"diff_qty"`
+}
+
+// SetSystemQty set the qty on the system when the item is counted and the diff of the counted qty against it
+func (i *CycleCountItem) SetSystemQty(qty int) {
+	i.SystemQty = qty
+	i.DiffQty = i.CountedQty - qty
+}
+
+// CycleCountPayload holds cycle count payload representative
+type CycleCountPayload struct {
+	Items  []CycleCountItemPayload `json:"items"`
+	Tenant types.TenantType        `json:"-"`
+}
+
+// CycleCountItemPayload holds cycle count item payload representative
+type CycleCountItemPayload struct {
+	SKU        string `json:"sku"`
+	CountedQty int    `json:"counted_qty"`
+}
+
+// ToEntity to convert cycle count payload to entity contract
+func (p *CycleCountPayload) ToEntity() *CycleCount {
+	items := make([]*CycleCountItem, 0, len(p.Items))
+	for _, item := range p.Items {
+		items = append(items, &CycleCountItem{
+			SKU:        strings.TrimSpace(item.SKU),
+			CountedQty: item.CountedQty,
+		})
+	}
+
+	return &CycleCount{
+		Tenant: p.Tenant,
+		Status: types.CycleCountStatusPendingType,
+		Items:  items,
+	}
+}
+
+// Validate is func to validate payload
+func (p *CycleCountPayload) Validate() error {
+	if len(p.Items) == 0 {
+		return response.ErrInvalidCycleCountItems
+	}
+
+	skus := make(map[string]bool, len(p.Items))
+	for _, item := range p.Items {
+		sku := strings.TrimSpace(item.SKU)
+		if sku == "" || skus[sku] {
+			return response.ErrInvalidCycleCountItems
+		}
+		skus[sku] = true
+
+		if item.CountedQty < 0 {
+			return response.ErrInvalidQty
+		}
+	}
+
+	if p.Tenant == types.TenantEmptyType {
+		return response.ErrInvalidTenant
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCycleCountPayloadValidate(t *testing.T) {
+	items := []entity.CycleCountItemPayload{{SKU: "SKU-1", CountedQty: 0}, {SKU: "SKU-2", CountedQty: 5}}
+
+	testcases := []struct {
+		name    string
+		payload *entity.CycleCountPayload
+		wantErr error
+	}{
+		{
+			name:    "without items",
+			payload: &entity.CycleCountPayload{Tenant: types.TenantLoremType},
+			wantErr: response.ErrInvalidCycleCountItems,
+		},
+		{
+			name:    "blank sku",
+			payload: &entity.CycleCountPayload{Items: []entity.CycleCountItemPayload{{SKU: " ", CountedQty: 1}}, Tenant: types.TenantLoremType},
+			wantErr: response.ErrInvalidCycleCountItems,
+		},
+		{
+			name:    "sku counted twice",
+			payload: &entity.CycleCountPayload{Items: []entity.CycleCountItemPayload{{SKU: "SKU-1", CountedQty: 1}, {SKU: " SKU-1", CountedQty: 2}}, Tenant: types.TenantLoremType},
+			wantErr: response.ErrInvalidCycleCountItems,
+		},
+		{
+			name:    "negative counted qty",
+			payload: &entity.CycleCountPayload{Items: []entity.CycleCountItemPayload{{SKU: "SKU-1", CountedQty: -1}}, Tenant: types.TenantLoremType},
+			wantErr: response.ErrInvalidQty,
+		},
+		{
+			name:    "invalid tenant",
+			payload: &entity.CycleCountPayload{Items: items},
+			wantErr: response.ErrInvalidTenant,
+		},
+		{
+			name:    "valid",
+			payload: &entity.CycleCountPayload{Items: items, Tenant: types.TenantLoremType},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantErr, tc.payload.Validate())
+		})
+	}
+}
+
+func TestCycleCountPayloadToEntity(t *testing.T) {
+	payload := &entity.CycleCountPayload{Items: []entity.CycleCountItemPayload{{SKU: " SKU-1 ", CountedQty: 7}}, Tenant: types.TenantLoremType}
+
+	cycleCount := payload.ToEntity()
+	assert.Equal(t, types.TenantLoremType, cycleCount.Tenant)
+	assert.Equal(t, types.CycleCountStatusPendingType, cycleCount.Status)
+	assert.Equal(t, []*entity.CycleCountItem{{SKU: "SKU-1", CountedQty: 7}}, cycleCount.Items)
+}
+
+func TestCycleCountItemSetSystemQty(t *testing.T) {
+	testcases := []struct {
+		name        string
+		countedQty  int
+		systemQty   int
+		wantDiffQty int
+	}{
+		{
+			name:        "counted more than the system",
+			countedQty:  12,
+			systemQty:   10,
+			wantDiffQty: 2,
+		},
+		{
+			name:        "counted less than the system",
+			countedQty:  7,
+			systemQty:   10,
+			wantDiffQty: -3,
+		},
+		{
+			name:        "counted the system qty",
+			countedQty:  10,
+			systemQty:   10,
+			wantDiffQty: 0,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			item := &entity.CycleCountItem{CountedQty: tc.countedQty}
+			item.SetSystemQty(tc.systemQty)
+			assert.Equal(t, tc.systemQty, item.SystemQty)
+			assert.Equal(t, tc.wantDiffQty, item.DiffQty)
+		})
+	}
+}
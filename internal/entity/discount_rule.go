@@ -0,0 +1,299 @@
+package entity
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// DiscountPercentageBase is the percentage discount value equal to 100%, percentages are in basis points
+const DiscountPercentageBase = 10000
+
+// DiscountRule struct holds entity of discount rule.
+// Conditions which are nil or empty match every product of the tenant.
+type DiscountRule struct {
+	ID         int                   `json:"id"`
+	Tenant     types.TenantType      `json:"tenant"`
+	Name       string                `json:"name"`
+	Type       types.DiscountType    `json:"type"`
+	Value      int                   `json:"value"`
+	SKU        *string               `json:"sku"`
+	Keyword    *string               `json:"keyword"`
+	Categories []types.CategoryType  `json:"categories"`
+	Conditions []types.ConditionType `json:"conditions"`
+	Priority   int                   `json:"priority"`
+	Stackable  bool                  `json:"stackable"`
+	StartsAt   *time.Time            `json:"starts_at"`
+	EndsAt     *time.Time            `json:"ends_at"`
+	CreatedAt  time.Time             `json:"created_at"`
+	UpdatedAt  time.Time             `json:"updated_at"`
+}
+
+// IsActive check whether the rule is running at the given time
+func (r *DiscountRule) IsActive(now time.Time) bool {
+	if r.StartsAt != nil && now.Before(*r.StartsAt) {
+		return false
+	}
+
+	if r.EndsAt != nil && !now.Before(*r.EndsAt) {
+		return false
+	}
+
+	return true
+}
+
+// Matches check whether the product satisfies all conditions of the rule. The conditions are the filters of the product search:
+// the product is in any of the categories and any of the conditions, and every word of the keyword is a word
+// of its title, description or attribute values. Unlike the search, the words are not stemmed with the text search config
+// of the tenant, so "books" does not match "book", and the operators of the web search syntax are not supported
+func (r *DiscountRule) Matches(product *Product) bool {
+	if r.Tenant != product.Tenant {
+		return false
+	}
+
+	if r.SKU != nil && *r.SKU != product.SKU {
+		return false
+	}
+
+	if r.Keyword != nil && !product.hasWords(keywordWords(*r.Keyword)) {
+		return false
+	}
+
+	if len(r.Categories) > 0 && !hasCategory(r.Categories, product.Category) {
+		return false
+	}
+
+	if len(r.Conditions) > 0 && !hasCondition(r.Conditions, product.Condition) {
+		return false
+	}
+
+	return true
+}
+
+// hasWords check whether every word is a word of the title, description or attribute values of the product
+func (p *Product) hasWords(words []string) bool {
+	texts := make([]string, 0, len(p.Attributes)+2)
+	texts = append(texts, p.Title, p.Description)
+	for _, value := range p.Attributes {
+		texts = append(texts, value)
+	}
+
+	productWords := make(map[string]bool)
+	for _, text := range texts {
+		for _, word := range keywordWords(text) {
+			productWords[word] = true
+		}
+	}
+
+	for _, word := range words {
+		if !productWords[word] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// keywordWords split the text into its lower case words, like the simple text search config without stemming
+func keywordWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func hasCategory(categories []types.CategoryType, category types.CategoryType) bool {
+	for _, value := range categories {
+		if value == category {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasCondition(conditions []types.ConditionType, condition types.ConditionType) bool {
+	for _, value := range conditions {
+		if value == condition {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DiscountAmount return the amount to be deducted from the given price,
+// percentage discount is rounded half up and the amount never exceeds the price
+func (r *DiscountRule) DiscountAmount(price int) int {
+	amount := r.Value
+	if r.Type == types.DiscountPercentageType {
+		amount = divideRoundHalfUp(price*r.Value, DiscountPercentageBase)
+	}
+
+	if amount > price {
+		return price
+	}
+
+	return amount
+}
+
+// AppliedPromotion struct holds discount rule applied to a product
+type AppliedPromotion struct {
+	DiscountRuleID int                `json:"discount_rule_id"`
+	Name           string             `json:"name"`
+	Type           types.DiscountType `json:"type"`
+	Value          int                `json:"value"`
+	Amount         int                `json:"amount"`
+}
+
+// ShowDiscounts apply the matching discount rules on the shown price of the product
+// and keep the price before discount as compare at price. The rules are evaluated
+// from the highest priority, rules with the same priority are evaluated by the oldest one:
+//   - a stackable rule is applied on the price left by the previously applied rules
+//   - a non-stackable rule is applied only when no rule has been applied yet,
+//     and no other rule is evaluated after it
+func (p *Product) ShowDiscounts(rules []*DiscountRule, now time.Time) {
+	p.Promotions = []*AppliedPromotion{}
+
+	sortedRules := make([]*DiscountRule, len(rules))
+	copy(sortedRules, rules)
+	sort.SliceStable(sortedRules, func(i, j int) bool {
+		if sortedRules[i].Priority != sortedRules[j].Priority {
+			return sortedRules[i].Priority > sortedRules[j].Priority
+		}
+
+		return sortedRules[i].ID < sortedRules[j].ID
+	})
+
+	price := p.Price
+	for _, rule := range sortedRules {
+		if !rule.IsActive(now) || !rule.Matches(p) {
+			continue
+		}
+
+		if !rule.Stackable && len(p.Promotions) > 0 {
+			continue
+		}
+
+		amount := rule.DiscountAmount(price)
+		price -= amount
+		p.Promotions = append(p.Promotions, &AppliedPromotion{
+			DiscountRuleID: rule.ID,
+			Name:           rule.Name,
+			Type:           rule.Type,
+			Value:          rule.Value,
+			Amount:         amount,
+		})
+
+		if !rule.Stackable {
+			break
+		}
+	}
+
+	if len(p.Promotions) == 0 {
+		return
+	}
+
+	if p.CompareAtPrice == nil {
+		regularPrice := p.Price
+		p.CompareAtPrice = &regularPrice
+	}
+	p.Price = price
+}
+
+// DiscountRulePayload holds discount rule payload representative
+type DiscountRulePayload struct {
+	Name       string                `json:"name"`
+	Type       types.DiscountType    `json:"type"`
+	Value      int                   `json:"value"`
+	SKU        *string               `json:"sku"`
+	Keyword    *string               `json:"keyword"`
+	Categories []types.CategoryType  `json:"categories"`
+	Conditions []types.ConditionType `json:"conditions"`
+	Priority   int                   `json:"priority"`
+	Stackable  bool                  `json:"stackable"`
+	StartsAt   *time.Time            `json:"starts_at"`
+	EndsAt     *time.Time            `json:"ends_at"`
+	Tenant     types.TenantType      `json:"-"`
+}
+
+// SwaggerDiscountRulePayload holds discount rule payload for swagger docs
+// Do not remove this struct
+// Everytime you update the DiscountRulePayload
+// you must adjust this struct for swagger docs
+type SwaggerDiscountRulePayload struct {
+	Name       string   `json:"name" example:"Preloved book sale"`
+	Type       string   `json:"type" example:"percentage"`
+	Value      int      `json:"value" example:"2000"`
+	SKU        string   `json:"sku"`
+	Keyword    string   `json:"keyword"`
+	Categories []string `json:"categories" example:"book"`
+	Conditions []string `json:"conditions" example:"preloved"`
+	Priority   int      `json:"priority"`
+	Stackable  bool     `json:"stackable"`
+	StartsAt   string   `json:"starts_at" example:"2023-12-24T00:00:00Z"`
+	EndsAt     string   `json:"ends_at" example:"2023-12-27T00:00:00Z"`
+}
+
+// ToEntity to convert discount rule payload to entity contract
+func (p *DiscountRulePayload) ToEntity() *DiscountRule {
+	return &DiscountRule{
+		Tenant:     p.Tenant,
+		Name:       strings.TrimSpace(p.Name),
+		Type:       p.Type,
+		Value:      p.Value,
+		SKU:        p.SKU,
+		Keyword:    p.Keyword,
+		Categories: p.Categories,
+		Conditions: p.Conditions,
+		Priority:   p.Priority,
+		Stackable:  p.Stackable,
+		StartsAt:   p.StartsAt,
+		EndsAt:     p.EndsAt,
+	}
+}
+
+// Validate is func to validate payload
+func (p *DiscountRulePayload) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return response.ErrInvalidDiscountRuleName
+	}
+
+	if p.Type == types.DiscountEmptyType {
+		return response.ErrInvalidDiscountType
+	}
+
+	if p.Value <= 0 || (p.Type == types.DiscountPercentageType && p.Value > DiscountPercentageBase) {
+		return response.ErrInvalidDiscountValue
+	}
+
+	// A keyword without any word would match every product
+	if p.Keyword != nil && len(keywordWords(*p.Keyword)) == 0 {
+		return response.ErrInvalidKeyword
+	}
+
+	for _, category := range p.Categories {
+		if category == types.CategoryEmptyType {
+			return response.ErrInvalidCategory
+		}
+	}
+
+	for _, condition := range p.Conditions {
+		if condition == types.ConditionEmptyType {
+			return response.ErrInvalidCondition
+		}
+	}
+
+	if p.StartsAt != nil && p.EndsAt != nil && !p.EndsAt.After(*p.StartsAt) {
+		return response.ErrInvalidSchedulePeriod
+	}
+
+	if p.Tenant == types.TenantEmptyType {
+		return response.ErrInvalidTenant
+	}
+
+	return nil
+}
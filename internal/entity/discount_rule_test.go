@@ -0,0 +1,254 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDiscountRuleMatches documents the conditions of discount rules, which are the filters of the product search
+// except that the keyword is not stemmed and the web search operators are not supported
+func TestDiscountRuleMatches(t *testing.T) {
+	sku := "SKU-123"
+	keyword := func(value string) *string { return &value }
+
+	product := &entity.Product{
+		SKU:         sku,
+		Title:       "Learning Golang",
+		Description: "A practical book about concurrency.",
+		Attributes:  map[string]string{"author": "Jane Doe", "cover": "hardcover"},
+		Category:    types.CategoryBookType,
+		Condition:   types.ConditionPrelovedType,
+		Tenant:      types.TenantLoremType,
+	}
+
+	testcases := []struct {
+		name string
+		rule *entity.DiscountRule
+		want bool
+	}{
+		{
+			name: "without condition",
+			rule: &entity.DiscountRule{Tenant: types.TenantLoremType},
+			want: true,
+		},
+		{
+			name: "another tenant",
+			rule: &entity.DiscountRule{Tenant: types.TenantIpsumType},
+			want: false,
+		},
+		{
+			name: "all conditions are satisfied",
+			rule: &entity.DiscountRule{Tenant: types.TenantLoremType, SKU: &sku, Keyword: keyword("golang"), Categories: []types.CategoryType{types.CategoryBookType}, Conditions: []types.ConditionType{types.ConditionPrelovedType}},
+			want: true,
+		},
+		{
+			name: "one condition is not satisfied",
+			rule: &entity.DiscountRule{Tenant: types.TenantLoremType, Keyword: keyword("golang"), Categories: []types.CategoryType{types.CategoryBagType}},
+			want: false,
+		},
+		{
+			name: "any of the categories and conditions",
+			rule: &entity.DiscountRule{Tenant: types.TenantLoremType, Categories: []types.CategoryType{types.CategoryBagType, types.CategoryBookType}, Conditions: []types.ConditionType{types.ConditionNewType, types.ConditionPrelovedType}},
+			want: true,
+		},
+		{
+			name: "keyword in the description",
+			rule: &entity.DiscountRule{Tenant: types.TenantLoremType, Keyword: keyword("Concurrency")},
+			want: true,
+		},
+		{
+			name: "keyword in the attribute values",
+			rule: &entity.DiscountRule{Tenant: types.TenantLoremType, Keyword: keyword("jane hardcover")},
+			want: true,
+		},
+		{
+			name: "every word of the keyword is required",
+			rule: &entity.DiscountRule{Tenant: types.TenantLoremType, Keyword: keyword("golang rust")},
+			want: false,
+		},
+		{
+			name: "keyword is a whole word instead of a part of a word",
+			rule: &entity.DiscountRule{Tenant: types.TenantLoremType, Keyword: keyword("lang")},
+			want: false,
+		},
+		{
+			name: "keyword is not stemmed unlike the search with a language config",
+			rule: &entity.DiscountRule{Tenant: types.TenantLoremType, Keyword: keyword("books")},
+			want: false,
+		},
+		{
+			name: "attribute keys are not searched",
+			rule: &entity.DiscountRule{Tenant: types.TenantLoremType, Keyword: keyword("author")},
+			want: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.rule.Matches(product))
+		})
+	}
+}
+
+func TestDiscountRulePayloadValidate(t *testing.T) {
+	keyword := func(value string) *string { return &value }
+
+	testcases := []struct {
+		name    string
+		payload *entity.DiscountRulePayload
+		wantErr error
+	}{
+		{
+			name:    "keyword without any word",
+			payload: &entity.DiscountRulePayload{Keyword: keyword(" - ")},
+			wantErr: response.ErrInvalidKeyword,
+		},
+		{
+			name:    "one of the categories is empty",
+			payload: &entity.DiscountRulePayload{Categories: []types.CategoryType{types.CategoryBookType, types.CategoryEmptyType}},
+			wantErr: response.ErrInvalidCategory,
+		},
+		{
+			name:    "one of the conditions is empty",
+			payload: &entity.DiscountRulePayload{Conditions: []types.ConditionType{types.ConditionEmptyType}},
+			wantErr: response.ErrInvalidCondition,
+		},
+		{
+			name:    "short keyword",
+			payload: &entity.DiscountRulePayload{Keyword: keyword("go"), Categories: []types.CategoryType{types.CategoryBookType, types.CategoryBagType}},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.payload.Name = "Sale"
+			tc.payload.Type = types.DiscountPercentageType
+			tc.payload.Value = 1000
+			tc.payload.Tenant = types.TenantLoremType
+
+			err := tc.payload.Validate()
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+// TestShowDiscounts documents the stacking and priority semantics of discount rules
+func TestShowDiscounts(t *testing.T) {
+	now := time.Now()
+	yesterday := now.Add(-24 * time.Hour)
+	tomorrow := now.Add(24 * time.Hour)
+
+	percentage := func(id int, value int, priority int, stackable bool) *entity.DiscountRule {
+		return &entity.DiscountRule{ID: id, Tenant: types.TenantLoremType, Type: types.DiscountPercentageType, Value: value, Priority: priority, Stackable: stackable}
+	}
+	fixedAmount := func(id int, value int, priority int, stackable bool) *entity.DiscountRule {
+		return &entity.DiscountRule{ID: id, Tenant: types.TenantLoremType, Type: types.DiscountFixedAmountType, Value: value, Priority: priority, Stackable: stackable}
+	}
+
+	testcases := []struct {
+		name           string
+		compareAtPrice *int
+		rules          []*entity.DiscountRule
+		wantPrice      int
+		wantCompareAt  *int
+		wantRuleIDs    []int
+	}{
+		{
+			name:        "no rule",
+			wantPrice:   10000,
+			wantRuleIDs: []int{},
+		},
+		{
+			name:          "single percentage rule",
+			rules:         []*entity.DiscountRule{percentage(1, 2000, 0, false)},
+			wantPrice:     8000,
+			wantCompareAt: intPtr(10000),
+			wantRuleIDs:   []int{1},
+		},
+		{
+			name:        "inactive rule is skipped",
+			rules:       []*entity.DiscountRule{{ID: 1, Tenant: types.TenantLoremType, Type: types.DiscountPercentageType, Value: 2000, StartsAt: &tomorrow}, {ID: 2, Tenant: types.TenantLoremType, Type: types.DiscountPercentageType, Value: 2000, EndsAt: &yesterday}},
+			wantPrice:   10000,
+			wantRuleIDs: []int{},
+		},
+		{
+			name:          "only the highest priority non-stackable rule is applied",
+			rules:         []*entity.DiscountRule{percentage(1, 5000, 1, false), fixedAmount(2, 1000, 5, false)},
+			wantPrice:     9000,
+			wantCompareAt: intPtr(10000),
+			wantRuleIDs:   []int{2},
+		},
+		{
+			name:          "the oldest rule wins on the same priority",
+			rules:         []*entity.DiscountRule{fixedAmount(2, 1000, 0, false), fixedAmount(1, 500, 0, false)},
+			wantPrice:     9500,
+			wantCompareAt: intPtr(10000),
+			wantRuleIDs:   []int{1},
+		},
+		{
+			name:          "stackable rules compound on the price left by the previous rules",
+			rules:         []*entity.DiscountRule{percentage(1, 1000, 1, true), percentage(2, 1000, 2, true), fixedAmount(3, 100, 0, true)},
+			wantPrice:     8000, // 10000 - 10% = 9000, 9000 - 10% = 8100, 8100 - 100 = 8000
+			wantCompareAt: intPtr(10000),
+			wantRuleIDs:   []int{2, 1, 3},
+		},
+		{
+			name:          "non-stackable rule after an applied rule is skipped",
+			rules:         []*entity.DiscountRule{percentage(1, 1000, 2, true), percentage(2, 5000, 1, false), fixedAmount(3, 100, 0, true)},
+			wantPrice:     8900,
+			wantCompareAt: intPtr(10000),
+			wantRuleIDs:   []int{1, 3},
+		},
+		{
+			name:          "non-stackable rule applied first stops the evaluation",
+			rules:         []*entity.DiscountRule{percentage(1, 1000, 2, false), fixedAmount(2, 100, 1, true)},
+			wantPrice:     9000,
+			wantCompareAt: intPtr(10000),
+			wantRuleIDs:   []int{1},
+		},
+		{
+			name:          "price never goes below zero",
+			rules:         []*entity.DiscountRule{fixedAmount(1, 8000, 1, true), fixedAmount(2, 8000, 0, true)},
+			wantPrice:     0,
+			wantCompareAt: intPtr(10000),
+			wantRuleIDs:   []int{1, 2},
+		},
+		{
+			name:           "compare at price of a running promotion is kept",
+			compareAtPrice: intPtr(12000),
+			rules:          []*entity.DiscountRule{percentage(1, 1000, 0, false)},
+			wantPrice:      9000,
+			wantCompareAt:  intPtr(12000),
+			wantRuleIDs:    []int{1},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			product := &entity.Product{Tenant: types.TenantLoremType, Price: 10000, CompareAtPrice: tc.compareAtPrice}
+			product.ShowDiscounts(tc.rules, now)
+
+			ruleIDs := []int{}
+			for _, promotion := range product.Promotions {
+				ruleIDs = append(ruleIDs, promotion.DiscountRuleID)
+			}
+
+			assert.Equal(t, tc.wantPrice, product.Price)
+			assert.Equal(t, tc.wantCompareAt, product.CompareAtPrice)
+			assert.Equal(t, tc.wantRuleIDs, ruleIDs)
+		})
+	}
+}
+
+func intPtr(value int) *int {
+	return &value
+}
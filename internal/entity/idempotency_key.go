@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// MaxIdempotencyKeyLength is the longest idempotency key accepted
+const MaxIdempotencyKeyLength = 255
+
+// IdempotencyKey struct holds entity of the response stored for a request sent with an idempotency key
+type IdempotencyKey struct {
+	Tenant types.TenantType
+	Key    string
+	// RequestHash identify the method, path and body of the first request
+	RequestHash string
+	// StatusCode is zero while the first request is still processed
+	StatusCode int
+	// ResponseHeaders hold the headers of the response which are replayed with its body
+	ResponseHeaders map[string]string
+	ResponseBody    []byte
+	// CreatedAt identify the claim of the request which processes the key
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	// InProgressUntil is when the request which claimed the key is cancelled,
+	// the key is only taken over by a retry after it so that both of them can not commit their changes
+	InProgressUntil time.Time
+}
+
+// IsCompleted check whether the response of the first request is already stored
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.StatusCode != 0
+}
+
+// Validate is func to validate idempotency key
+func (k *IdempotencyKey) Validate() error {
+	if k.Key == "" || len(k.Key) > MaxIdempotencyKeyLength {
+		return response.ErrInvalidIdempotencyKey
+	}
+
+	if k.Tenant == types.TenantEmptyType {
+		return response.ErrInvalidTenant
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyKeyValidate(t *testing.T) {
+	testcases := []struct {
+		name    string
+		key     *entity.IdempotencyKey
+		wantErr error
+	}{
+		{
+			name:    "blank key",
+			key:     &entity.IdempotencyKey{Tenant: types.TenantLoremType},
+			wantErr: response.ErrInvalidIdempotencyKey,
+		},
+		{
+			name:    "key is too long",
+			key:     &entity.IdempotencyKey{Key: strings.Repeat("a", entity.MaxIdempotencyKeyLength+1), Tenant: types.TenantLoremType},
+			wantErr: response.ErrInvalidIdempotencyKey,
+		},
+		{
+			name:    "invalid tenant",
+			key:     &entity.IdempotencyKey{Key: "key"},
+			wantErr: response.ErrInvalidTenant,
+		},
+		{
+			name: "valid",
+			key:  &entity.IdempotencyKey{Key: "key", Tenant: types.TenantLoremType},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantErr, tc.key.Validate())
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package entity
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// locationCodePattern match location code such as "jkt-1" or "store_bdg"
+var locationCodePattern = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+
+// Location struct holds entity of warehouse or store which keeps stock
+type Location struct {
+	ID        int              `json:"id"`
+	Tenant    types.TenantType `json:"tenant"`
+	Code      string           `json:"code"`
+	Name      string           `json:"name"`
+	Priority  int              `json:"priority"`
+	IsDefault bool             `json:"is_default"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// ProductStock struct holds entity of stock of a product on a location
+type ProductStock struct {
+	ProductID        int       `json:"-"`
+	LocationID       int       `json:"location_id"`
+	LocationCode     string    `json:"location_code"`
+	LocationName     string    `json:"location_name"`
+	LocationPriority int       `json:"-"`
+	Qty              int       `json:"qty"`
+	UpdatedAt        time.Time `json:"-"`
+}
+
+// AllocateStock reduce qty from the stocks with the given strategy and return the reduced stocks.
+//   - priority: take from the location with the highest priority first
+//   - most_stock: take from the location with the most stock first to minimize split shipments
+//   - single_location: take all from the location with the highest priority which has enough stock
+func AllocateStock(stocks []*ProductStock, qty int, strategy types.AllocationStrategyType) ([]*ProductStock, error) {
+	sorted := make([]*ProductStock, len(stocks))
+	copy(sorted, stocks)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if strategy == types.AllocationStrategyMostStockType && sorted[i].Qty != sorted[j].Qty {
+			return sorted[i].Qty > sorted[j].Qty
+		}
+
+		if sorted[i].LocationPriority != sorted[j].LocationPriority {
+			return sorted[i].LocationPriority > sorted[j].LocationPriority
+		}
+
+		return sorted[i].LocationID < sorted[j].LocationID
+	})
+
+	if strategy == types.AllocationStrategySingleLocationType {
+		for _, stock := range sorted {
+			if stock.Qty >= qty {
+				stock.Qty -= qty
+				return []*ProductStock{stock}, nil
+			}
+		}
+
+		return nil, response.ErrInsufficientStock
+	}
+
+	total := 0
+	for _, stock := range sorted {
+		total += stock.Qty
+	}
+
+	if total < qty {
+		return nil, response.ErrInsufficientStock
+	}
+
+	reduced := make([]*ProductStock, 0)
+	for _, stock := range sorted {
+		if qty == 0 {
+			break
+		}
+
+		if stock.Qty == 0 {
+			continue
+		}
+
+		take := stock.Qty
+		if take > qty {
+			take = qty
+		}
+
+		stock.Qty -= take
+		qty -= take
+		reduced = append(reduced, stock)
+	}
+
+	return reduced, nil
+}
+
+// ReduceStockAtLocation reduce qty from the stock of the given location and return the reduced stock
+func ReduceStockAtLocation(stocks []*ProductStock, locationID int, qty int) (*ProductStock, error) {
+	for _, stock := range stocks {
+		if stock.LocationID != locationID {
+			continue
+		}
+
+		if stock.Qty < qty {
+			return nil, response.ErrInsufficientStock
+		}
+
+		stock.Qty -= qty
+		return stock, nil
+	}
+
+	return nil, response.ErrInsufficientStock
+}
+
+// TotalStock sum qty of the stocks
+func TotalStock(stocks []*ProductStock) int {
+	total := 0
+	for _, stock := range stocks {
+		total += stock.Qty
+	}
+
+	return total
+}
+
+// LocationPayload holds location payload representative
+type LocationPayload struct {
+	Code      string           `json:"code"`
+	Name      string           `json:"name"`
+	Priority  int              `json:"priority"`
+	IsDefault bool             `json:"is_default"`
+	Tenant    types.TenantType `json:"-"`
+}
+
+// SwaggerLocationPayload holds location payload for swagger docs
+// Do not remove this struct
+// Everytime you update the LocationPayload
+// you must adjust this struct for swagger docs
+type SwaggerLocationPayload struct {
+	Code      string `json:"code" example:"jkt-1"`
+	Name      string `json:"name" example:"Jakarta Warehouse"`
+	Priority  int    `json:"priority" example:"10"`
+	IsDefault bool   `json:"is_default"`
+}
+
+// ToEntity to convert location payload to entity contract
+func (p *LocationPayload) ToEntity() *Location {
+	return &Location{
+		Tenant:    p.Tenant,
+		Code:      strings.ToLower(strings.TrimSpace(p.Code)),
+		Name:      strings.TrimSpace(p.Name),
+		Priority:  p.Priority,
+		IsDefault: p.IsDefault,
+	}
+}
+
+// Validate is func to validate payload
+func (p *LocationPayload) Validate() error {
+	if !locationCodePattern.MatchString(strings.ToLower(strings.TrimSpace(p.Code))) {
+		return response.ErrInvalidLocationCode
+	}
+
+	if strings.TrimSpace(p.Name) == "" {
+		return response.ErrInvalidLocationName
+	}
+
+	if p.Tenant == types.TenantEmptyType {
+		return response.ErrInvalidTenant
+	}
+
+	return nil
+}
+
+// ProductStockPayload holds stock of a product on a location payload representative
+type ProductStockPayload struct {
+	LocationID int `json:"location_id"`
+	Qty        int `json:"qty"`
+}
@@ -0,0 +1,164 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAllocateStock documents which locations the stock is taken from by each allocation strategy.
+// Locations are sorted by priority, then by the oldest location.
+func TestAllocateStock(t *testing.T) {
+	newStocks := func() []*entity.ProductStock {
+		return []*entity.ProductStock{
+			{LocationID: 1, LocationPriority: 0, Qty: 8},
+			{LocationID: 2, LocationPriority: 10, Qty: 3},
+			{LocationID: 3, LocationPriority: 10, Qty: 2},
+		}
+	}
+
+	testcases := []struct {
+		name     string
+		qty      int
+		strategy types.AllocationStrategyType
+		wantQty  map[int]int
+		wantErr  error
+	}{
+		{
+			name:     "priority takes from the highest priority first",
+			qty:      4,
+			strategy: types.AllocationStrategyPriorityType,
+			wantQty:  map[int]int{2: 0, 3: 1},
+		},
+		{
+			name:     "priority splits across all locations",
+			qty:      10,
+			strategy: types.AllocationStrategyPriorityType,
+			wantQty:  map[int]int{2: 0, 3: 0, 1: 3},
+		},
+		{
+			name:     "priority with insufficient stock",
+			qty:      14,
+			strategy: types.AllocationStrategyPriorityType,
+			wantErr:  response.ErrInsufficientStock,
+		},
+		{
+			name:     "most stock takes from the location with the most stock first",
+			qty:      9,
+			strategy: types.AllocationStrategyMostStockType,
+			wantQty:  map[int]int{1: 0, 2: 2},
+		},
+		{
+			name:     "single location takes from the highest priority location with enough stock",
+			qty:      3,
+			strategy: types.AllocationStrategySingleLocationType,
+			wantQty:  map[int]int{2: 0},
+		},
+		{
+			name:     "single location skips locations without enough stock",
+			qty:      5,
+			strategy: types.AllocationStrategySingleLocationType,
+			wantQty:  map[int]int{1: 3},
+		},
+		{
+			name:     "single location without any location with enough stock",
+			qty:      9,
+			strategy: types.AllocationStrategySingleLocationType,
+			wantErr:  response.ErrInsufficientStock,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			reduced, err := entity.AllocateStock(newStocks(), tc.qty, tc.strategy)
+			assert.Equal(t, tc.wantErr, err)
+
+			if tc.wantErr == nil {
+				gotQty := make(map[int]int, len(reduced))
+				for _, stock := range reduced {
+					gotQty[stock.LocationID] = stock.Qty
+				}
+				assert.Equal(t, tc.wantQty, gotQty)
+			}
+		})
+	}
+}
+
+func TestReduceStockAtLocation(t *testing.T) {
+	testcases := []struct {
+		name       string
+		locationID int
+		qty        int
+		wantQty    int
+		wantErr    error
+	}{
+		{
+			name:       "location without stock",
+			locationID: 3,
+			qty:        1,
+			wantErr:    response.ErrInsufficientStock,
+		},
+		{
+			name:       "insufficient stock",
+			locationID: 2,
+			qty:        4,
+			wantErr:    response.ErrInsufficientStock,
+		},
+		{
+			name:       "success",
+			locationID: 2,
+			qty:        3,
+			wantQty:    0,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			stocks := []*entity.ProductStock{{LocationID: 1, Qty: 8}, {LocationID: 2, Qty: 3}}
+
+			stock, err := entity.ReduceStockAtLocation(stocks, tc.locationID, tc.qty)
+			assert.Equal(t, tc.wantErr, err)
+			if tc.wantErr == nil {
+				assert.Equal(t, tc.wantQty, stock.Qty)
+				assert.Equal(t, 8, entity.TotalStock(stocks))
+			}
+		})
+	}
+}
+
+func TestLocationPayloadValidate(t *testing.T) {
+	testcases := []struct {
+		name    string
+		payload *entity.LocationPayload
+		wantErr error
+	}{
+		{
+			name:    "invalid code",
+			payload: &entity.LocationPayload{Code: "jkt 1", Name: "Jakarta", Tenant: types.TenantLoremType},
+			wantErr: response.ErrInvalidLocationCode,
+		},
+		{
+			name:    "invalid name",
+			payload: &entity.LocationPayload{Code: "jkt-1", Name: " ", Tenant: types.TenantLoremType},
+			wantErr: response.ErrInvalidLocationName,
+		},
+		{
+			name:    "invalid tenant",
+			payload: &entity.LocationPayload{Code: "jkt-1", Name: "Jakarta"},
+			wantErr: response.ErrInvalidTenant,
+		},
+		{
+			name:    "valid with uppercase code",
+			payload: &entity.LocationPayload{Code: " JKT-1 ", Name: "Jakarta", Tenant: types.TenantLoremType},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantErr, tc.payload.Validate())
+		})
+	}
+}
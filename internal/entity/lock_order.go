@@ -0,0 +1,12 @@
+package entity
+
+import "sort"
+
+// sortByProductID sort the items, a slice, by the product id of each item and keep the order of the items
+// of the same product. The products of a document are changed in this order so that concurrent transactions
+// lock them in the same order instead of deadlocking
+func sortByProductID(items interface{}, productID func(i int) int) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return productID(i) < productID(j)
+	})
+}
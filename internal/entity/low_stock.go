@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+const (
+	// LowStockFilter is the stock filter of the product list which returns the products at or below their threshold
+	LowStockFilter = "low"
+	// LowStockAlertEventType is the type of the event sent for a low stock alert
+	LowStockAlertEventType = "product.low_stock"
+)
+
+// LowStockThreshold struct holds entity of the low stock threshold of the products of a tenant
+type LowStockThreshold struct {
+	Tenant    types.TenantType `json:"tenant"`
+	Threshold int              `json:"threshold"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// LowStockThresholdPayload holds low stock threshold payload representative
+type LowStockThresholdPayload struct {
+	Threshold int              `json:"threshold"`
+	Tenant    types.TenantType `json:"-"`
+}
+
+// SwaggerLowStockThresholdPayload holds low stock threshold payload for swagger docs
+// Do not remove this struct
+// Everytime you update the LowStockThresholdPayload
+// you must adjust this struct for swagger docs
+type SwaggerLowStockThresholdPayload struct {
+	Threshold int `json:"threshold" example:"5"`
+}
+
+// ToEntity to convert low stock threshold payload to entity contract
+func (p *LowStockThresholdPayload) ToEntity() *LowStockThreshold {
+	return &LowStockThreshold{
+		Tenant:    p.Tenant,
+		Threshold: p.Threshold,
+	}
+}
+
+// Validate is func to validate payload
+func (p *LowStockThresholdPayload) Validate() error {
+	if p.Threshold < 0 {
+		return response.ErrInvalidLowStockThreshold
+	}
+
+	if p.Tenant == types.TenantEmptyType {
+		return response.ErrInvalidTenant
+	}
+
+	return nil
+}
+
+// LowStockAlert struct holds entity of the alert created when the qty of a product falls to its low stock threshold
+type LowStockAlert struct {
+	ID        int              `json:"id"`
+	ProductID int              `json:"product_id"`
+	Tenant    types.TenantType `json:"tenant"`
+	SKU       string           `json:"sku"`
+	Title     string           `json:"title"`
+	Qty       int              `json:"qty"`
+	Threshold int              `json:"threshold"`
+	CreatedAt time.Time        `json:"created_at"`
+	SentAt    *time.Time       `json:"-"`
+}
+
+// NewLowStockAlert create alert of the current qty of the product
+func NewLowStockAlert(product *Product, threshold int) *LowStockAlert {
+	return &LowStockAlert{
+		ProductID: product.ID,
+		Tenant:    product.Tenant,
+		SKU:       product.SKU,
+		Title:     product.Title,
+		Qty:       product.Qty,
+		Threshold: threshold,
+	}
+}
+
+// GetLowStockThreshold return the threshold of the product, or the threshold of the tenant when the product has none
+func (p *Product) GetLowStockThreshold(tenantThreshold *LowStockThreshold) *int {
+	if p.LowStockThreshold != nil || tenantThreshold == nil {
+		return p.LowStockThreshold
+	}
+
+	threshold := tenantThreshold.Threshold
+	return &threshold
+}
+
+// IsLowStock check whether the qty of the product is at or below the given threshold
+func (p *Product) IsLowStock(threshold *int) bool {
+	return threshold != nil && p.Qty <= *threshold
+}
@@ -0,0 +1,115 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLowStockThresholdPayloadValidate(t *testing.T) {
+	testcases := []struct {
+		name    string
+		payload *entity.LowStockThresholdPayload
+		wantErr error
+	}{
+		{
+			name:    "negative threshold",
+			payload: &entity.LowStockThresholdPayload{Threshold: -1, Tenant: types.TenantLoremType},
+			wantErr: response.ErrInvalidLowStockThreshold,
+		},
+		{
+			name:    "empty tenant",
+			payload: &entity.LowStockThresholdPayload{Threshold: 5},
+			wantErr: response.ErrInvalidTenant,
+		},
+		{
+			name:    "zero threshold",
+			payload: &entity.LowStockThresholdPayload{Threshold: 0, Tenant: types.TenantLoremType},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantErr, tc.payload.Validate())
+		})
+	}
+}
+
+func TestProductPayloadValidateLowStockThreshold(t *testing.T) {
+	testcases := []struct {
+		name    string
+		payload *entity.ProductPayload
+		wantErr error
+	}{
+		{
+			name:    "without low stock threshold",
+			payload: &entity.ProductPayload{},
+		},
+		{
+			name:    "negative low stock threshold",
+			payload: &entity.ProductPayload{LowStockThreshold: intPtr(-1)},
+			wantErr: response.ErrInvalidLowStockThreshold,
+		},
+		{
+			name:    "zero low stock threshold",
+			payload: &entity.ProductPayload{LowStockThreshold: intPtr(0)},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.payload.Category = types.CategoryBookType
+			tc.payload.Condition = types.ConditionNewType
+			tc.payload.Tenant = types.TenantLoremType
+			assert.Equal(t, tc.wantErr, tc.payload.Validate())
+		})
+	}
+}
+
+func TestProductIsLowStock(t *testing.T) {
+	productThreshold := 5
+
+	testcases := []struct {
+		name            string
+		product         *entity.Product
+		tenantThreshold *entity.LowStockThreshold
+		wantThreshold   *int
+		wantLowStock    bool
+	}{
+		{
+			name:    "no threshold",
+			product: &entity.Product{Qty: 0},
+		},
+		{
+			name:            "tenant threshold",
+			product:         &entity.Product{Qty: 3},
+			tenantThreshold: &entity.LowStockThreshold{Threshold: 3},
+			wantThreshold:   intPtr(3),
+			wantLowStock:    true,
+		},
+		{
+			name:            "product threshold takes precedence over tenant threshold",
+			product:         &entity.Product{Qty: 6, LowStockThreshold: &productThreshold},
+			tenantThreshold: &entity.LowStockThreshold{Threshold: 10},
+			wantThreshold:   &productThreshold,
+			wantLowStock:    false,
+		},
+		{
+			name:          "at product threshold",
+			product:       &entity.Product{Qty: 5, LowStockThreshold: &productThreshold},
+			wantThreshold: &productThreshold,
+			wantLowStock:  true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			threshold := tc.product.GetLowStockThreshold(tc.tenantThreshold)
+			assert.Equal(t, tc.wantThreshold, threshold)
+			assert.Equal(t, tc.wantLowStock, tc.product.IsLowStock(threshold))
+		})
+	}
+}
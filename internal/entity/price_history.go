@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// PriceHistory struct holds entity of price history
+type PriceHistory struct {
+	ID             int              `json:"id"`
+	ProductID      int              `json:"product_id"`
+	Tenant         types.TenantType `json:"tenant"`
+	Price          int              `json:"price"`
+	PromotionPrice *int             `json:"promotion_price"`
+	EffectivePrice int              `json:"effective_price"`
+	RecordedAt     time.Time        `json:"recorded_at"`
+}
+
+// ProductPriceHistory holds price histories of a product
+type ProductPriceHistory struct {
+	ProductID      int             `json:"product_id"`
+	LowestPrice30d int             `json:"lowest_price_30d"`
+	Entries        []*PriceHistory `json:"entries"`
+}
+
+// GetPriceHistoryPayload holds get price history payload representative
+type GetPriceHistoryPayload struct {
+	ProductID int
+	Tenant    types.TenantType
+	From      *time.Time
+	To        *time.Time
+}
+
+// Validate is func to validate payload
+func (p *GetPriceHistoryPayload) Validate() error {
+	if p.From != nil && p.To != nil && p.From.After(*p.To) {
+		return response.ErrInvalidDateRange
+	}
+
+	return nil
+}
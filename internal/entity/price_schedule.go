@@ -0,0 +1,128 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// PriceSchedule struct holds entity of price schedule
+type PriceSchedule struct {
+	ID        int                           `json:"id"`
+	ProductID int                           `json:"product_id"`
+	Tenant    types.TenantType              `json:"tenant"`
+	Type      types.PriceScheduleType       `json:"type"`
+	Status    types.PriceScheduleStatusType `json:"status"`
+	Price     int                           `json:"price"`
+	StartsAt  time.Time                     `json:"starts_at"`
+	EndsAt    *time.Time                    `json:"ends_at"`
+	CreatedAt time.Time                     `json:"created_at"`
+	UpdatedAt time.Time                     `json:"updated_at"`
+}
+
+// Apply moves the price schedule to its next status when it is due at the given time
+// and reflects the change on the product. It returns false when nothing is due yet.
+func (s *PriceSchedule) Apply(product *Product, now time.Time) bool {
+	switch s.Status {
+	case types.PriceScheduleStatusPendingType:
+		if now.Before(s.StartsAt) {
+			return false
+		}
+
+		if s.Type == types.PriceSchedulePriceChangeType {
+			product.Price = s.Price
+			s.Status = types.PriceScheduleStatusCompletedType
+			return true
+		}
+
+		// The whole promotion period has passed before it could be applied
+		if s.EndsAt != nil && !now.Before(*s.EndsAt) {
+			s.Status = types.PriceScheduleStatusCompletedType
+			return true
+		}
+
+		promotionPrice := s.Price
+		product.PromotionPrice = &promotionPrice
+		s.Status = types.PriceScheduleStatusActiveType
+		return true
+	case types.PriceScheduleStatusActiveType:
+		if s.EndsAt == nil || now.Before(*s.EndsAt) {
+			return false
+		}
+
+		product.PromotionPrice = nil
+		s.Status = types.PriceScheduleStatusCompletedType
+		return true
+	}
+
+	return false
+}
+
+// PriceSchedulePayload holds price schedule payload representative
+type PriceSchedulePayload struct {
+	Type      types.PriceScheduleType `json:"type"`
+	Price     int                     `json:"price"`
+	StartsAt  time.Time               `json:"starts_at"`
+	EndsAt    *time.Time              `json:"ends_at"`
+	ProductID int                     `json:"-"`
+	Tenant    types.TenantType        `json:"-"`
+}
+
+// SwaggerPriceSchedulePayload holds price schedule payload for swagger docs
+// Do not remove this struct
+// Everytime you update the PriceSchedulePayload
+// you must adjust this struct for swagger docs
+type SwaggerPriceSchedulePayload struct {
+	Type     string `json:"type" example:"promotion"`
+	Price    int    `json:"price"`
+	StartsAt string `json:"starts_at" example:"2023-12-24T00:00:00Z"`
+	EndsAt   string `json:"ends_at" example:"2023-12-27T00:00:00Z"`
+}
+
+// ToEntity to convert price schedule payload to entity contract
+func (p *PriceSchedulePayload) ToEntity() *PriceSchedule {
+	return &PriceSchedule{
+		ProductID: p.ProductID,
+		Tenant:    p.Tenant,
+		Type:      p.Type,
+		Status:    types.PriceScheduleStatusPendingType,
+		Price:     p.Price,
+		StartsAt:  p.StartsAt,
+		EndsAt:    p.EndsAt,
+	}
+}
+
+// Validate is func to validate payload
+func (p *PriceSchedulePayload) Validate() error {
+	if p.Type == types.PriceScheduleEmptyType {
+		return response.ErrInvalidPriceScheduleType
+	}
+
+	if p.Price <= 0 {
+		return response.ErrInvalidPrice
+	}
+
+	if p.StartsAt.IsZero() {
+		return response.ErrInvalidSchedulePeriod
+	}
+
+	// Promotion must be time-boxed, while price change is permanent
+	if p.Type == types.PriceSchedulePromotionType && p.EndsAt == nil {
+		return response.ErrInvalidSchedulePeriod
+	}
+
+	if p.Type == types.PriceSchedulePriceChangeType && p.EndsAt != nil {
+		return response.ErrInvalidSchedulePeriod
+	}
+
+	if p.EndsAt != nil && !p.EndsAt.After(p.StartsAt) {
+		return response.ErrInvalidSchedulePeriod
+	}
+
+	if p.Tenant == types.TenantEmptyType {
+		return response.ErrInvalidTenant
+	}
+
+	return nil
+}
@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"encoding/json"
+	"sort"
 	"time"
 
 	"github.com/satriowisnugroho/catalog/internal/entity/types"
@@ -9,39 +11,442 @@ import (
 
 // Product struct holds entity of product
 type Product struct {
-	ID        int                 `json:"id"`
-	SKU       string              `json:"sku"`
-	Title     string              `json:"title"`
-	Category  types.CategoryType  `json:"category"`
-	Condition types.ConditionType `json:"condition"`
-	Tenant    types.TenantType    `json:"tenant"`
-	Qty       int                 `json:"qty"`
-	Price     int                 `json:"price"`
-	CreatedAt time.Time           `json:"created_at"`
-	UpdatedAt time.Time           `json:"updated_at"`
+	ID                int                       `json:"id"`
+	SKU               string                    `json:"sku"`
+	Title             string                    `json:"title"`
+	Description       string                    `json:"description"`
+	Attributes        map[string]string         `json:"attributes"`
+	Category          types.CategoryType        `json:"category"`
+	Condition         types.ConditionType       `json:"condition"`
+	Tenant            types.TenantType          `json:"tenant"`
+	Qty               int                       `json:"qty"`
+	ReservedQty       int                       `json:"reserved_qty"`
+	BackorderedQty    int                       `json:"backordered_qty"`
+	InTransitQty      int                       `json:"in_transit_qty"`
+	ExpiredQty        int                       `json:"expired_qty"`
+	AvailableQty      int                       `json:"available_qty"`
+	Stocks            []*ProductStock           `json:"stocks"`
+	Lots              []*ProductLot             `json:"lots"`
+	Price             int                       `json:"price"`
+	CompareAtPrice    *int                      `json:"compare_at_price"`
+	PromotionPrice    *int                      `json:"-"`
+	TaxClassID        *int                      `json:"tax_class_id"`
+	CostPrice         *int                      `json:"cost_price,omitempty"`
+	LowStockThreshold *int                      `json:"low_stock_threshold"`
+	LowStockAlerted   bool                      `json:"-"`
+	BackorderPolicy   types.BackorderPolicyType `json:"backorder_policy"`
+	BackorderLimit    *int                      `json:"backorder_limit"`
+	RestockDate       *time.Time                `json:"restock_date"`
+	Serialized        bool                      `json:"serialized"`
+	Promotions        []*AppliedPromotion       `json:"promotions"`
+	Tax               *TaxAmount                `json:"tax"`
+	Version           int                       `json:"version"`
+	// Relevance is how well the product matches the keyword of the product list
+	Relevance float64 `json:"-"`
+	// Highlight is the snippet of the title and description with the words matching the keyword marked
+	Highlight string    `json:"highlight,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// ShowPromotionPrice expose the running promotion price as price
+// and keep the regular price as compare at price
+func (p *Product) ShowPromotionPrice() {
+	if p.PromotionPrice == nil || p.CompareAtPrice != nil {
+		return
+	}
+
+	regularPrice := p.Price
+	p.CompareAtPrice = &regularPrice
+	p.Price = *p.PromotionPrice
+}
+
+// ShowTax compute the tax amount of the shown price with the given tax class
+func (p *Product) ShowTax(taxClass *TaxClass, region string) {
+	tax := CalculateTax(p.Price, 0, false)
+	if taxClass != nil {
+		if rate, ok := taxClass.GetRate(region); ok {
+			tax = CalculateTax(p.Price, rate.Rate, taxClass.PriceIncludesTax)
+			taxClassID := taxClass.ID
+			tax.TaxClassID = &taxClassID
+		}
+	}
+
+	tax.Region = region
+	p.Tax = &tax
+}
+
+// ShowAvailableQty compute the qty which is not held by active reservations, owed to backorders nor expired,
+// it is zero when the on-hand qty has been reduced below the reserved qty
+func (p *Product) ShowAvailableQty() {
+	p.AvailableQty = p.Qty - p.ReservedQty - p.BackorderedQty - p.ExpiredQty
+	if p.AvailableQty < 0 {
+		p.AvailableQty = 0
+	}
+}
+
+// ShowLots attach the lots of the product and set the qty of the expired lots which is not available
+func (p *Product) ShowLots(lots []*ProductLot, now time.Time) {
+	for _, lot := range lots {
+		lot.Expired = lot.IsExpired(now)
+	}
+
+	p.Lots = lots
+	p.ExpiredQty = ExpiredLotQty(lots, now)
+}
+
+// SplitBackorder split the ordered qty into the qty fulfilled from the available qty and the qty backordered,
+// it fails when the backorder policy of the product does not allow the backordered qty
+func (p *Product) SplitBackorder(qty int) (int, int, error) {
+	p.ShowAvailableQty()
+
+	fulfilledQty := qty
+	if p.AvailableQty < qty {
+		fulfilledQty = p.AvailableQty
+	}
+
+	backorderedQty := qty - fulfilledQty
+	if backorderedQty > 0 && !p.CanBackorder(backorderedQty) {
+		return 0, 0, response.ErrInsufficientStock
+	}
+
+	return fulfilledQty, backorderedQty, nil
+}
+
+// CanBackorder check whether the backorder policy of the product allows the qty to be backordered on top of the current backorders
+func (p *Product) CanBackorder(qty int) bool {
+	switch p.BackorderPolicy {
+	case types.BackorderPolicyUnlimitedType:
+		return true
+	case types.BackorderPolicyLimitedType:
+		return p.BackorderLimit != nil && p.BackorderedQty+qty <= *p.BackorderLimit
+	default:
+		return false
+	}
+}
+
+// FillableBackorderQty return the qty of the backorders which are filled by the added qty
+func (p *Product) FillableBackorderQty(addedQty int) int {
+	if p.BackorderedQty < addedQty {
+		return p.BackorderedQty
+	}
+
+	return addedQty
+}
+
+// ApplyBackorderPolicy set the backorder policy of the payload, a product without policy denies backorders
+func (p *Product) ApplyBackorderPolicy(payload *ProductPayload) {
+	p.BackorderPolicy = payload.BackorderPolicy
+	if p.BackorderPolicy == types.BackorderPolicyEmptyType {
+		p.BackorderPolicy = types.BackorderPolicyDenyType
+	}
+
+	p.BackorderLimit = payload.BackorderLimit
+	p.RestockDate = payload.RestockDate
+}
+
+// SyncQty take the qty attributes of the locked product, they are changed by sales, reservations and transfers
+// without changing the version of the product
+func (p *Product) SyncQty(locked *Product) {
+	p.Qty = locked.Qty
+	p.ReservedQty = locked.ReservedQty
+	p.BackorderedQty = locked.BackorderedQty
+	p.InTransitQty = locked.InTransitQty
+	p.LowStockAlerted = locked.LowStockAlerted
+}
+
+// CheckVersion make sure the change is based on the current version of the product when the versions are given
+func (p *Product) CheckVersion(versions []int) error {
+	if versions == nil {
+		return nil
+	}
+
+	for _, version := range versions {
+		if version == p.Version {
+			return nil
+		}
+	}
+
+	return response.ErrStaleVersion
+}
+
+// HideCost remove the cost attributes for callers without finance scope
+func (p *Product) HideCost() {
+	p.CostPrice = nil
+}
+
+// ApplyCostPrice set the cost price of the payload before the qty of the payload is applied.
+// With weighted average cost, the cost price of the payload is the unit cost of the added qty
+// and the current cost is kept when no qty is added.
+func (p *Product) ApplyCostPrice(payload *ProductPayload) {
+	if payload.CostPrice == nil {
+		return
+	}
+
+	if !payload.WeightedAverageCost || p.CostPrice == nil {
+		costPrice := *payload.CostPrice
+		p.CostPrice = &costPrice
+		return
+	}
+
+	if payload.Qty > p.Qty {
+		costPrice := WeightedAverageCost(p.Qty, *p.CostPrice, payload.Qty-p.Qty, *payload.CostPrice)
+		p.CostPrice = &costPrice
+	}
+}
+
+// WeightedAverageCost compute the unit cost of the stock after adding qty units bought at the given unit cost
+func WeightedAverageCost(currentQty int, currentCost int, addedQty int, addedCost int) int {
+	if currentQty <= 0 {
+		return addedCost
+	}
+
+	return divideRoundHalfUp(currentQty*currentCost+addedQty*addedCost, currentQty+addedQty)
+}
+
+// GetProductByIDPayload holds get product by id payload representative
+type GetProductByIDPayload struct {
+	ID           int
+	Tenant       types.TenantType
+	Region       string
+	FinanceScope bool
+	// Fieldset selects the fields and the relations of the response, the full product is given when it is nil
+	Fieldset *ProductFieldset
+}
+
+const (
+	// InStockFilter is the stock filter of the product list which returns the products with qty left after the reserved and backordered qty
+	InStockFilter = "in"
+	// OutOfStockFilter is the stock filter of the product list which returns the products without qty left after the reserved and backordered qty
+	OutOfStockFilter = "out"
+)
+
 // GetProductPayload holds get product payload representative
 type GetProductPayload struct {
-	SKU          string
-	TitleKeyword string
-	Category     types.CategoryType
-	Condition    types.ConditionType
+	SKUs []string
+	// Keyword is searched in the title, description and attributes of the products with the text search config of the tenant
+	Keyword      string
+	Categories   []types.CategoryType
+	Conditions   []types.ConditionType
 	Tenant       types.TenantType
-	OrderBy      string
-	Offset       int
-	Limit        int
+	Region       string
+	FinanceScope bool
+	MinPrice     *int
+	MaxPrice     *int
+	MinQty       *int
+	MaxQty       *int
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	UpdatedFrom  *time.Time
+	UpdatedTo    *time.Time
+	// Stock is one of the stock filters, the products are not filtered by their stock when it is empty
+	Stock string
+	// Sort holds the keys the products are sorted by, the id breaks the ties
+	Sort   ProductSort
+	Offset int
+	Limit  int
+	// Cursor is the opaque token of a page given by the previous page, the offset is ignored when it is given
+	Cursor string
+	// Position is the decoded cursor, the list keeps the order of the cursor
+	Position *ProductCursor
+	// Fieldset selects the fields and the relations of the response, the full products are given when it is nil
+	Fieldset *ProductFieldset
+}
+
+// Validate is func to validate payload
+func (p *GetProductPayload) Validate() error {
+	if p.MinPrice != nil && p.MaxPrice != nil && *p.MinPrice > *p.MaxPrice {
+		return response.ErrInvalidPrice
+	}
+
+	if p.MinQty != nil && p.MaxQty != nil && *p.MinQty > *p.MaxQty {
+		return response.ErrInvalidQty
+	}
+
+	if p.CreatedFrom != nil && p.CreatedTo != nil && p.CreatedFrom.After(*p.CreatedTo) {
+		return response.ErrInvalidDateRange
+	}
+
+	if p.UpdatedFrom != nil && p.UpdatedTo != nil && p.UpdatedFrom.After(*p.UpdatedTo) {
+		return response.ErrInvalidDateRange
+	}
+
+	return nil
+}
+
+// SortKeys return the sort of the product list, it is the sort of the cursor when the list is paged by cursor.
+// The products matching the keyword are sorted by relevance when no sort is given
+func (p *GetProductPayload) SortKeys() ProductSort {
+	if p.Position != nil {
+		return p.Position.Sort
+	}
+
+	if len(p.Sort) == 0 && p.Keyword != "" {
+		return ProductSort{{Field: RelevanceSortField, Desc: true}}
+	}
+
+	return p.Sort
 }
 
 // BulkReduceQtyProductPayload holds bulk reduce qty product payload representative
 type BulkReduceQtyProductPayload struct {
 	Items []BulkReduceQtyProductItemPayload `json:"items"`
+	// ReferenceID is recorded on the stock movements, e.g. the order id
+	ReferenceID string `json:"reference_id"`
+	// Mode is atomic when it is not given, no item is reduced when one of them fails.
+	// The items which can be fulfilled are reduced in partial mode and the failed ones are reported
+	Mode         types.BulkModeType `json:"mode"`
+	Region       string             `json:"-"`
+	FinanceScope bool               `json:"-"`
+	Actor        string             `json:"-"`
 }
 
 // BulkReduceQtyProductItemPayload holds bulk reduce qty product item payload representative
 type BulkReduceQtyProductItemPayload struct {
-	SKU    string `json:"sku"`
-	ReqQty int    `json:"req_qty"`
+	SKU        string `json:"sku"`
+	ReqQty     int    `json:"req_qty"`
+	LocationID *int   `json:"location_id"`
+	// SerialNumbers are the units of a serialized product which are sold, one for each unit of the qty.
+	// The units in stock first are sold when they are not given
+	SerialNumbers []string `json:"serial_numbers"`
+}
+
+// BulkReduceQtyProductResult holds the result of each item and the products updated by a bulk reduce
+type BulkReduceQtyProductResult struct {
+	Items    []*BulkReduceQtyProductItemResult `json:"items"`
+	Products []*Product                        `json:"products"`
+}
+
+// BulkReduceQtyProductItemResult holds the qty of an item fulfilled from the stock and the qty backordered,
+// the error code is of the error which failed the item
+type BulkReduceQtyProductItemResult struct {
+	SKU            string                   `json:"sku"`
+	Status         types.BulkItemStatusType `json:"status"`
+	ErrorCode      int                      `json:"error_code,omitempty"`
+	ReqQty         int                      `json:"req_qty"`
+	FulfilledQty   int                      `json:"fulfilled_qty"`
+	BackorderedQty int                      `json:"backordered_qty"`
+	RestockDate    *time.Time               `json:"restock_date"`
+}
+
+// NewFailedBulkReduceQtyProductItemResult create the result of an item which is failed by the given error
+func NewFailedBulkReduceQtyProductItemResult(item BulkReduceQtyProductItemPayload, err response.CustomError) *BulkReduceQtyProductItemResult {
+	return &BulkReduceQtyProductItemResult{
+		SKU:       item.SKU,
+		Status:    types.BulkItemStatusFailedType,
+		ErrorCode: err.Code,
+		ReqQty:    item.ReqQty,
+	}
+}
+
+// Validate is func to validate the item
+func (p *BulkReduceQtyProductItemPayload) Validate() error {
+	if p.ReqQty <= 0 {
+		return response.ErrInvalidQty
+	}
+
+	return ValidateSerialNumbers(p.SerialNumbers, p.ReqQty)
+}
+
+// BulkIncreaseQtyProductPayload holds bulk increase qty product payload representative
+type BulkIncreaseQtyProductPayload struct {
+	Items []BulkIncreaseQtyProductItemPayload `json:"items"`
+	// Reason is recorded on the stock movements, it is restock when it is not given
+	Reason      types.StockMovementReasonType `json:"reason"`
+	ReferenceID string                        `json:"reference_id"`
+	Actor       string                        `json:"-"`
+}
+
+// BulkIncreaseQtyProductItemPayload holds bulk increase qty product item payload representative
+type BulkIncreaseQtyProductItemPayload struct {
+	SKU        string `json:"sku"`
+	ReqQty     int    `json:"req_qty"`
+	LocationID *int   `json:"location_id"`
+	// LotNumber and ExpiryDate are given together to add the qty to a lot, the lot is created when it does not exist
+	LotNumber  string     `json:"lot_number"`
+	ExpiryDate *time.Time `json:"expiry_date"`
+	// SerialNumbers are registered as the added units of a serialized product, one for each unit of the qty
+	SerialNumbers []string `json:"serial_numbers"`
+}
+
+// ToLot convert the lot of the item to the lot entity, it is nil when the item has no lot
+func (p *BulkIncreaseQtyProductItemPayload) ToLot() *ProductLot {
+	if p.LotNumber == "" {
+		return nil
+	}
+
+	return &ProductLot{
+		LotNumber:  p.LotNumber,
+		ExpiryDate: *p.ExpiryDate,
+		Qty:        p.ReqQty,
+	}
+}
+
+// Validate is func to validate payload
+func (p *BulkIncreaseQtyProductPayload) Validate() error {
+	for _, item := range p.Items {
+		if item.ReqQty <= 0 {
+			return response.ErrInvalidQty
+		}
+
+		if (item.LotNumber == "") != (item.ExpiryDate == nil) {
+			return response.ErrInvalidLot
+		}
+
+		if err := ValidateSerialNumbers(item.SerialNumbers, item.ReqQty); err != nil {
+			return err
+		}
+	}
+
+	return ValidateStockMovementReason(p.Reason, 1)
+}
+
+// SwaggerBulkIncreaseQtyProductPayload holds bulk increase qty product payload for swagger docs
+type SwaggerBulkIncreaseQtyProductPayload struct {
+	Items       []BulkIncreaseQtyProductItemPayload `json:"items"`
+	Reason      string                              `json:"reason" example:"restock"`
+	ReferenceID string                              `json:"reference_id"`
+}
+
+// AdjustQtyProductPayload holds adjust qty product payload representative
+type AdjustQtyProductPayload struct {
+	// Delta is added to the qty, a negative one reduces the qty
+	Delta      int  `json:"delta"`
+	LocationID *int `json:"location_id"`
+	// SerialNumbers are the added or deducted units of a serialized product, one for each unit of the delta.
+	// They are required to add qty, the units in stock first are deducted when they are not given
+	SerialNumbers []string `json:"serial_numbers"`
+	// Reason is recorded on the stock movement, it is adjustment when it is not given
+	Reason      types.StockMovementReasonType `json:"reason"`
+	ReferenceID string                        `json:"reference_id"`
+	Tenant      types.TenantType              `json:"-"`
+	Actor       string                        `json:"-"`
+}
+
+// Validate is func to validate payload
+func (p *AdjustQtyProductPayload) Validate() error {
+	if p.Delta == 0 {
+		return response.ErrInvalidQty
+	}
+
+	qty := p.Delta
+	if qty < 0 {
+		qty = -qty
+	}
+	if err := ValidateSerialNumbers(p.SerialNumbers, qty); err != nil {
+		return err
+	}
+
+	return ValidateStockMovementReason(p.Reason, p.Delta)
+}
+
+// SwaggerAdjustQtyProductPayload holds adjust qty product payload for swagger docs
+type SwaggerAdjustQtyProductPayload struct {
+	Delta         int      `json:"delta" example:"-2"`
+	LocationID    *int     `json:"location_id"`
+	SerialNumbers []string `json:"serial_numbers"`
+	Reason        string   `json:"reason" example:"damage"`
+	ReferenceID   string   `json:"reference_id"`
 }
 
 // SwaggerProductPayload holds product payload for swagger docs
@@ -49,48 +454,420 @@ type BulkReduceQtyProductItemPayload struct {
 // Everytime you update the ProductPayload
 // you must adjust this struct for swagger docs
 type SwaggerProductPayload struct {
-	Title     string `json:"title"`
-	Category  string `json:"category"`
-	Condition string `json:"condition"`
-	Qty       int    `json:"qty"`
-	Price     int    `json:"price"`
+	Title               string                `json:"title"`
+	Description         string                `json:"description"`
+	Attributes          map[string]string     `json:"attributes"`
+	Category            string                `json:"category"`
+	Condition           string                `json:"condition"`
+	Qty                 int                   `json:"qty"`
+	Stocks              []ProductStockPayload `json:"stocks"`
+	Price               int                   `json:"price"`
+	TaxClassID          *int                  `json:"tax_class_id"`
+	CostPrice           *int                  `json:"cost_price"`
+	WeightedAverageCost bool                  `json:"weighted_average_cost"`
+	LowStockThreshold   *int                  `json:"low_stock_threshold" example:"5"`
+	BackorderPolicy     string                `json:"backorder_policy" example:"limited"`
+	BackorderLimit      *int                  `json:"backorder_limit" example:"20"`
+	RestockDate         string                `json:"restock_date" example:"2024-01-31T00:00:00Z"`
+	Serialized          bool                  `json:"serialized"`
+	StockReason         string                `json:"stock_reason" example:"restock"`
+	ReferenceID         string                `json:"reference_id"`
 }
 
 // ProductPayload holds product payload representative
 type ProductPayload struct {
-	Title     string              `json:"title"`
-	Category  types.CategoryType  `json:"category"`
-	Condition types.ConditionType `json:"condition"`
-	Tenant    types.TenantType    `json:"-"`
-	Qty       int                 `json:"qty"`
-	Price     int                 `json:"price"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Attributes  map[string]string   `json:"attributes"`
+	Category    types.CategoryType  `json:"category"`
+	Condition   types.ConditionType `json:"condition"`
+	Tenant      types.TenantType    `json:"-"`
+	Region      string              `json:"-"`
+	Qty         int                 `json:"qty"`
+	// Stocks is the stock per location, the stock of locations which are not given is removed.
+	// Without stocks, the change of qty is applied on the default location or allocated when qty is reduced
+	Stocks     []ProductStockPayload `json:"stocks"`
+	Price      int                   `json:"price"`
+	TaxClassID *int                  `json:"tax_class_id"`
+	// CostPrice is the unit cost of the stock, or of the added stock when WeightedAverageCost is set
+	CostPrice           *int `json:"cost_price"`
+	WeightedAverageCost bool `json:"weighted_average_cost"`
+	FinanceScope        bool `json:"-"`
+	// LowStockThreshold overrides the low stock threshold of the tenant, the tenant threshold is used when it is not given
+	LowStockThreshold *int `json:"low_stock_threshold"`
+	// BackorderPolicy decides whether the qty can be ordered beyond the available qty, it is deny when it is not given.
+	// BackorderLimit is the max qty of open backorders of the limited policy
+	BackorderPolicy types.BackorderPolicyType `json:"backorder_policy"`
+	BackorderLimit  *int                      `json:"backorder_limit"`
+	// RestockDate is the date the backordered qty is expected to be in stock
+	RestockDate *time.Time `json:"restock_date"`
+	// Serialized products get their qty added with a serial number for each unit, so their qty
+	// can only be increased by restock or adjustment with the serial numbers
+	Serialized bool `json:"serialized"`
+	// StockReason is recorded on the stock movement when the qty changes,
+	// it is restock on create and adjustment on update when it is not given
+	StockReason types.StockMovementReasonType `json:"stock_reason"`
+	ReferenceID string                        `json:"reference_id"`
+	Actor       string                        `json:"-"`
+	// Versions are the versions of the product the change is based on, any version when none is given
+	Versions []int `json:"-"`
 }
 
 // ToEntity to convert product payload to entity contract
 func (p *ProductPayload) ToEntity() *Product {
-	return &Product{
-		Title:     p.Title,
-		Category:  p.Category,
-		Condition: p.Condition,
-		Tenant:    p.Tenant,
-		Qty:       p.Qty,
-		Price:     p.Price,
+	product := &Product{
+		Title:             p.Title,
+		Description:       p.Description,
+		Attributes:        p.Attributes,
+		Category:          p.Category,
+		Condition:         p.Condition,
+		Tenant:            p.Tenant,
+		Qty:               p.Qty,
+		Price:             p.Price,
+		TaxClassID:        p.TaxClassID,
+		CostPrice:         p.CostPrice,
+		LowStockThreshold: p.LowStockThreshold,
+		Serialized:        p.Serialized,
 	}
+	product.ApplyBackorderPolicy(p)
+
+	return product
+}
+
+// productPayloadRule holds a validation of the product payload and the fields it validates,
+// a rule without fields is always validated
+type productPayloadRule struct {
+	fields   []string
+	validate func(p *ProductPayload) error
+}
+
+// productPayloadRules list the validations of the product payload in the order they are checked
+var productPayloadRules = []productPayloadRule{
+	{
+		fields: []string{"category"},
+		validate: func(p *ProductPayload) error {
+			if p.Category == types.CategoryEmptyType {
+				return response.ErrInvalidCategory
+			}
+			return nil
+		},
+	},
+	{
+		fields: []string{"condition"},
+		validate: func(p *ProductPayload) error {
+			if p.Condition == types.ConditionEmptyType {
+				return response.ErrInvalidCondition
+			}
+			return nil
+		},
+	},
+	{
+		validate: func(p *ProductPayload) error {
+			if p.Tenant == types.TenantEmptyType {
+				return response.ErrInvalidTenant
+			}
+			return nil
+		},
+	},
+	{
+		fields: []string{"qty"},
+		validate: func(p *ProductPayload) error {
+			if p.Qty < 0 {
+				return response.ErrInvalidQty
+			}
+			return nil
+		},
+	},
+	{
+		fields: []string{"low_stock_threshold"},
+		validate: func(p *ProductPayload) error {
+			if p.LowStockThreshold != nil && *p.LowStockThreshold < 0 {
+				return response.ErrInvalidLowStockThreshold
+			}
+			return nil
+		},
+	},
+	{
+		fields: []string{"backorder_policy", "backorder_limit"},
+		validate: func(p *ProductPayload) error {
+			if p.BackorderPolicy == types.BackorderPolicyLimitedType {
+				if p.BackorderLimit == nil || *p.BackorderLimit <= 0 {
+					return response.ErrInvalidBackorderLimit
+				}
+			} else if p.BackorderLimit != nil {
+				return response.ErrInvalidBackorderLimit
+			}
+			return nil
+		},
+	},
+	{
+		fields: []string{"stocks"},
+		validate: func(p *ProductPayload) error {
+			locationIDs := make(map[int]bool, len(p.Stocks))
+			for _, stock := range p.Stocks {
+				if stock.Qty < 0 {
+					return response.ErrInvalidQty
+				}
+
+				if locationIDs[stock.LocationID] {
+					return response.ErrInvalidLocation
+				}
+				locationIDs[stock.LocationID] = true
+			}
+			return nil
+		},
+	},
+	{
+		fields: []string{"cost_price", "weighted_average_cost"},
+		validate: func(p *ProductPayload) error {
+			if p.CostPrice != nil || p.WeightedAverageCost {
+				if !p.FinanceScope {
+					return response.ErrForbidden
+				}
+
+				if p.CostPrice == nil || *p.CostPrice < 0 {
+					return response.ErrInvalidCostPrice
+				}
+			}
+			return nil
+		},
+	},
 }
 
 // Validate is func to validate payload
 func (p *ProductPayload) Validate() error {
-	if p.Category == types.CategoryEmptyType {
-		return response.ErrInvalidCategory
+	for _, rule := range productPayloadRules {
+		if err := rule.validate(p); err != nil {
+			return err
+		}
 	}
 
-	if p.Condition == types.ConditionEmptyType {
-		return response.ErrInvalidCondition
-	}
+	return nil
+}
+
+// ValidateFields validate only the given fields of the payload, e.g. the fields changed by a patch
+func (p *ProductPayload) ValidateFields(fields map[string]bool) error {
+	for _, rule := range productPayloadRules {
+		validated := len(rule.fields) == 0
+		for _, field := range rule.fields {
+			validated = validated || fields[field]
+		}
+
+		if !validated {
+			continue
+		}
 
-	if p.Tenant == types.TenantEmptyType {
-		return response.ErrInvalidTenant
+		if err := rule.validate(p); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
+
+// ToPayload convert the product to the payload of its current attributes
+func (p *Product) ToPayload() *ProductPayload {
+	return &ProductPayload{
+		Title:             p.Title,
+		Description:       p.Description,
+		Attributes:        copyAttributes(p.Attributes),
+		Category:          p.Category,
+		Condition:         p.Condition,
+		Tenant:            p.Tenant,
+		Qty:               p.Qty,
+		Price:             p.Price,
+		TaxClassID:        p.TaxClassID,
+		CostPrice:         p.CostPrice,
+		LowStockThreshold: p.LowStockThreshold,
+		BackorderPolicy:   p.BackorderPolicy,
+		BackorderLimit:    p.BackorderLimit,
+		RestockDate:       p.RestockDate,
+		Serialized:        p.Serialized,
+	}
+}
+
+// copyAttributes copy the attributes so the copy can be changed without changing the product
+func copyAttributes(attributes map[string]string) map[string]string {
+	if attributes == nil {
+		return nil
+	}
+
+	result := make(map[string]string, len(attributes))
+	for key, value := range attributes {
+		result[key] = value
+	}
+
+	return result
+}
+
+// productPatchField holds the product column changed by a field of a patch and whether the field can be removed with null,
+// the fields without column are only options of the change
+type productPatchField struct {
+	column   string
+	nullable bool
+}
+
+// productPatchFields list the fields of the product payload which can be patched
+var productPatchFields = map[string]productPatchField{
+	"title":                 {column: "title"},
+	"description":           {column: "description"},
+	"attributes":            {column: "attributes", nullable: true},
+	"category":              {column: "category"},
+	"condition":             {column: "condition"},
+	"qty":                   {column: "qty"},
+	"stocks":                {column: "qty"},
+	"price":                 {column: "price"},
+	"tax_class_id":          {column: "tax_class_id", nullable: true},
+	"cost_price":            {column: "cost_price", nullable: true},
+	"weighted_average_cost": {column: "cost_price"},
+	"low_stock_threshold":   {column: "low_stock_threshold", nullable: true},
+	"backorder_policy":      {column: "backorder_policy"},
+	"backorder_limit":       {column: "backorder_limit", nullable: true},
+	"restock_date":          {column: "restock_date", nullable: true},
+	"serialized":            {column: "serialized"},
+	"stock_reason":          {},
+	"reference_id":          {},
+}
+
+// ProductPatchPayload holds a json merge patch (RFC 7396) of a product, only the fields of the patch are changed
+type ProductPatchPayload struct {
+	Fields       map[string]json.RawMessage
+	Tenant       types.TenantType
+	Region       string
+	FinanceScope bool
+	Actor        string
+	// Versions are the versions of the product the patch is based on, any version when none is given
+	Versions []int
+}
+
+// Apply merge the patch into the current attributes of the product, the given fields replace the current ones
+// and null removes a nullable field. The attributes are merged key by key, so null removes an attribute
+// and null attributes remove all of them. It returns the merged payload and the fields changed by the patch
+func (p *ProductPatchPayload) Apply(product *Product) (*ProductPayload, map[string]bool, error) {
+	changed := make(map[string]bool, len(p.Fields))
+	for name, value := range p.Fields {
+		field, ok := productPatchFields[name]
+		if !ok || (!field.nullable && string(value) == "null") {
+			err := response.ErrInvalidPatch
+			err.Field = name
+			return nil, nil, err
+		}
+		changed[name] = true
+	}
+
+	// Removing the cost price needs the finance scope as well as setting it
+	if (changed["cost_price"] || changed["weighted_average_cost"]) && !p.FinanceScope {
+		return nil, nil, response.ErrForbidden
+	}
+
+	fields := p.Fields
+	payload := product.ToPayload()
+	if value, ok := p.Fields["attributes"]; ok {
+		attributes, err := mergeAttributes(payload.Attributes, value)
+		if err != nil {
+			err := response.ErrInvalidPatch
+			err.Field = "attributes"
+			return nil, nil, err
+		}
+		payload.Attributes = attributes
+
+		fields = make(map[string]json.RawMessage, len(p.Fields))
+		for name, value := range p.Fields {
+			if name != "attributes" {
+				fields[name] = value
+			}
+		}
+	}
+
+	patch, err := json.Marshal(fields)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := json.Unmarshal(patch, payload); err != nil {
+		if typeErr, ok := err.(*json.UnmarshalTypeError); ok {
+			err := response.ErrInvalidPatch
+			err.Field = typeErr.Field
+			return nil, nil, err
+		}
+		return nil, nil, err
+	}
+
+	payload.Tenant = p.Tenant
+	payload.Region = p.Region
+	payload.FinanceScope = p.FinanceScope
+	payload.Actor = p.Actor
+	payload.Versions = p.Versions
+	if changed["stocks"] {
+		changed["qty"] = true
+	}
+
+	return payload, changed, nil
+}
+
+// mergeAttributes apply the merge patch of the attributes on the current attributes
+func mergeAttributes(attributes map[string]string, patch json.RawMessage) (map[string]string, error) {
+	var patchValue interface{}
+	if err := json.Unmarshal(patch, &patchValue); err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]interface{}, len(attributes))
+	for key, value := range attributes {
+		current[key] = value
+	}
+
+	data, err := json.Marshal(mergePatch(current, patchValue))
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[string]string
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// mergePatch apply a json merge patch (RFC 7396) on the target, the members of an object patch are merged
+// recursively into the target object and a null member removes the member. A patch which is not an object replaces the target
+func mergePatch(target interface{}, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{}, len(patchObject))
+	}
+
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = mergePatch(targetObject[name], value)
+	}
+
+	return targetObject
+}
+
+// Columns return the product columns changed by the patch
+func (p *ProductPatchPayload) Columns() []string {
+	columns := make([]string, 0, len(p.Fields))
+	seen := make(map[string]bool, len(p.Fields))
+	for name := range p.Fields {
+		column := productPatchFields[name].column
+		if column == "" || seen[column] {
+			continue
+		}
+
+		seen[column] = true
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	return columns
+}
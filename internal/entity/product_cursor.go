@@ -0,0 +1,209 @@
+package entity
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// RelevanceSortField is the sort key of the product list which sorts the products by how well they match the keyword
+const RelevanceSortField = "relevance"
+
+// productSortFields list the fields the product list can be sorted by, the id always breaks the ties
+var productSortFields = []string{"price", "qty", "title", "updated_at", "created_at", RelevanceSortField}
+
+// ProductSortKey holds a key of the sort of the product list
+type ProductSortKey struct {
+	Field string `json:"f"`
+	Desc  bool   `json:"d"`
+}
+
+// ProductSort holds the keys of the sort of the product list in order, the products are sorted by id descending without keys
+type ProductSort []ProductSortKey
+
+// ParseProductSort parse the sort of the product list from comma separated fields,
+// a field prefixed by "-" is sorted descending, e.g. "-price,title"
+func ParseProductSort(sort string) (ProductSort, error) {
+	result := ProductSort{}
+	if strings.TrimSpace(sort) == "" {
+		return result, nil
+	}
+
+	for _, item := range strings.Split(sort, ",") {
+		item = strings.TrimSpace(item)
+		result = append(result, ProductSortKey{Field: strings.TrimPrefix(item, "-"), Desc: strings.HasPrefix(item, "-")})
+	}
+
+	if !result.valid() {
+		return nil, response.ErrInvalidSort
+	}
+
+	return result, nil
+}
+
+// ParseProductOrderBy parse the sort of the product list from the "field direction" format of the orderby query,
+// the field is sorted descending unless the direction is ASC
+func ParseProductOrderBy(orderBy string) (ProductSort, error) {
+	parts := strings.Fields(orderBy)
+	if len(parts) == 0 {
+		return ProductSort{}, nil
+	}
+
+	if len(parts) > 2 || !isProductSortField(parts[0]) {
+		return nil, response.ErrInvalidSort
+	}
+
+	return ProductSort{{Field: parts[0], Desc: len(parts) < 2 || parts[1] != "ASC"}}, nil
+}
+
+// IDDesc return whether the id which breaks the ties is sorted descending, it follows the direction of the last key
+func (s ProductSort) IDDesc() bool {
+	if len(s) == 0 {
+		return true
+	}
+
+	return s[len(s)-1].Desc
+}
+
+// Values return the values of the sort keys of the product as text
+func (s ProductSort) Values(product *Product) []string {
+	values := make([]string, 0, len(s))
+	for _, key := range s {
+		values = append(values, key.Value(product))
+	}
+
+	return values
+}
+
+// Value return the value of the sort key of the product as text
+func (k ProductSortKey) Value(product *Product) string {
+	switch k.Field {
+	case "price":
+		return strconv.Itoa(product.Price)
+	case "qty":
+		return strconv.Itoa(product.Qty)
+	case "title":
+		return product.Title
+	case "updated_at":
+		return product.UpdatedAt.Format(time.RFC3339Nano)
+	case "created_at":
+		return product.CreatedAt.Format(time.RFC3339Nano)
+	case RelevanceSortField:
+		return strconv.FormatFloat(product.Relevance, 'g', -1, 64)
+	default:
+		return ""
+	}
+}
+
+// valid check whether the keys are fields the product list can be sorted by and no field is sorted twice
+func (s ProductSort) valid() bool {
+	seen := map[string]bool{}
+	for _, key := range s {
+		if !isProductSortField(key.Field) || seen[key.Field] {
+			return false
+		}
+		seen[key.Field] = true
+	}
+
+	return true
+}
+
+func isProductSortField(field string) bool {
+	for _, f := range productSortFields {
+		if field == f {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ProductCursor holds the position of a product on the sorted product list, the page of the cursor
+// starts after the product, or ends before it when the cursor is backward
+type ProductCursor struct {
+	Sort     ProductSort `json:"s,omitempty"`
+	Values   []string    `json:"v,omitempty"`
+	ID       int         `json:"i"`
+	Backward bool        `json:"b,omitempty"`
+}
+
+// NewProductCursor create the cursor of the position of the product on the list sorted by the given sort
+func NewProductCursor(sort ProductSort, product *Product, backward bool) *ProductCursor {
+	return &ProductCursor{
+		Sort:     sort,
+		Values:   sort.Values(product),
+		ID:       product.ID,
+		Backward: backward,
+	}
+}
+
+// DecodeProductCursor decode the cursor from the opaque token given to the clients
+func DecodeProductCursor(token string) (*ProductCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, response.ErrInvalidCursor
+	}
+
+	var cursor ProductCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || !cursor.Sort.valid() || len(cursor.Values) != len(cursor.Sort) {
+		return nil, response.ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
+
+// Encode encode the cursor to the opaque token given to the clients
+func (c *ProductCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// ProductPage holds a page of the product list with the total of the list and the cursors of the pages around it
+type ProductPage struct {
+	Products   []*Product
+	Total      int
+	NextCursor string
+	PrevCursor string
+}
+
+// SetCursors set the cursors of the pages around the products reached from the given position, the first page has no position.
+// It is called before the shown price of the products is computed, the cursors hold the stored price the products are sorted by.
+// A full page may be followed by another page in the direction it was reached, a page reached from a position
+// is preceded by the products of that position
+func (p *ProductPage) SetCursors(sort ProductSort, position *ProductCursor, limit int) {
+	backward := position != nil && position.Backward
+	if len(p.Products) == 0 {
+		if position != nil {
+			reverse := *position
+			reverse.Backward = !position.Backward
+			if backward {
+				p.NextCursor = reverse.Encode()
+			} else {
+				p.PrevCursor = reverse.Encode()
+			}
+		}
+		return
+	}
+
+	first := NewProductCursor(sort, p.Products[0], true)
+	last := NewProductCursor(sort, p.Products[len(p.Products)-1], false)
+	full := len(p.Products) >= limit
+	if backward {
+		if full {
+			p.PrevCursor = first.Encode()
+		}
+		p.NextCursor = last.Encode()
+		return
+	}
+
+	if full {
+		p.NextCursor = last.Encode()
+	}
+	if position != nil {
+		p.PrevCursor = first.Encode()
+	}
+}
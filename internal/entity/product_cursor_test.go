@@ -0,0 +1,210 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProductSort(t *testing.T) {
+	testcases := []struct {
+		name    string
+		sort    string
+		want    entity.ProductSort
+		wantErr error
+	}{
+		{
+			name: "default sort",
+			want: entity.ProductSort{},
+		},
+		{
+			name: "multiple keys",
+			sort: "-price, title",
+			want: entity.ProductSort{{Field: "price", Desc: true}, {Field: "title"}},
+		},
+		{
+			name:    "field can not be sorted by",
+			sort:    "-id",
+			wantErr: response.ErrInvalidSort,
+		},
+		{
+			name:    "empty key",
+			sort:    "price,",
+			wantErr: response.ErrInvalidSort,
+		},
+		{
+			name:    "field sorted twice",
+			sort:    "price,-price",
+			wantErr: response.ErrInvalidSort,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := entity.ParseProductSort(tc.sort)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
+
+func TestParseProductOrderBy(t *testing.T) {
+	testcases := []struct {
+		name    string
+		orderBy string
+		want    entity.ProductSort
+		wantErr error
+	}{
+		{
+			name: "default sort",
+			want: entity.ProductSort{},
+		},
+		{
+			name:    "ascending",
+			orderBy: "created_at ASC",
+			want:    entity.ProductSort{{Field: "created_at"}},
+		},
+		{
+			name:    "descending without direction",
+			orderBy: "created_at",
+			want:    entity.ProductSort{{Field: "created_at", Desc: true}},
+		},
+		{
+			name:    "field can not be sorted by",
+			orderBy: "sku ASC",
+			wantErr: response.ErrInvalidSort,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := entity.ParseProductOrderBy(tc.orderBy)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
+
+func TestDecodeProductCursor(t *testing.T) {
+	cursor := &entity.ProductCursor{Sort: entity.ProductSort{{Field: "created_at"}}, Values: []string{"2023-12-01T00:00:00Z"}, ID: 5, Backward: true}
+
+	testcases := []struct {
+		name    string
+		token   string
+		want    *entity.ProductCursor
+		wantErr error
+	}{
+		{
+			name:    "not base64",
+			token:   "!!",
+			wantErr: response.ErrInvalidCursor,
+		},
+		{
+			name:    "not json",
+			token:   "bm90IGpzb24",
+			wantErr: response.ErrInvalidCursor,
+		},
+		{
+			name:    "field can not be sorted by",
+			token:   (&entity.ProductCursor{Sort: entity.ProductSort{{Field: "sku"}}, Values: []string{"SKU-1"}, ID: 5}).Encode(),
+			wantErr: response.ErrInvalidCursor,
+		},
+		{
+			name:    "values do not match the sort",
+			token:   (&entity.ProductCursor{Sort: entity.ProductSort{{Field: "price"}}, ID: 5}).Encode(),
+			wantErr: response.ErrInvalidCursor,
+		},
+		{
+			name:  "success",
+			token: cursor.Encode(),
+			want:  cursor,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := entity.DecodeProductCursor(tc.token)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
+
+func TestProductPageSetCursors(t *testing.T) {
+	sort := entity.ProductSort{{Field: "price", Desc: true}, {Field: "created_at"}}
+	createdAt := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+	products := []*entity.Product{{ID: 3, Price: 200, CreatedAt: createdAt}, {ID: 2, Price: 100, CreatedAt: createdAt}}
+	first := &entity.ProductCursor{Sort: sort, Values: []string{"200", createdAt.Format(time.RFC3339Nano)}, ID: 3, Backward: true}
+	last := &entity.ProductCursor{Sort: sort, Values: []string{"100", createdAt.Format(time.RFC3339Nano)}, ID: 2}
+	position := &entity.ProductCursor{Sort: sort, Values: []string{"300", createdAt.Format(time.RFC3339Nano)}, ID: 4}
+	backwardPosition := &entity.ProductCursor{Sort: sort, Values: []string{"50", createdAt.Format(time.RFC3339Nano)}, ID: 1, Backward: true}
+
+	testcases := []struct {
+		name           string
+		products       []*entity.Product
+		position       *entity.ProductCursor
+		limit          int
+		wantNextCursor string
+		wantPrevCursor string
+	}{
+		{
+			name:     "first page is the only page",
+			products: products,
+			limit:    10,
+		},
+		{
+			name:           "first full page",
+			products:       products,
+			limit:          2,
+			wantNextCursor: last.Encode(),
+		},
+		{
+			name:           "full page after a cursor",
+			products:       products,
+			position:       position,
+			limit:          2,
+			wantNextCursor: last.Encode(),
+			wantPrevCursor: first.Encode(),
+		},
+		{
+			name:           "last page after a cursor",
+			products:       products,
+			position:       position,
+			limit:          10,
+			wantPrevCursor: first.Encode(),
+		},
+		{
+			name:           "full page before a cursor",
+			products:       products,
+			position:       backwardPosition,
+			limit:          2,
+			wantNextCursor: last.Encode(),
+			wantPrevCursor: first.Encode(),
+		},
+		{
+			name:           "first page before a cursor",
+			products:       products,
+			position:       backwardPosition,
+			limit:          10,
+			wantNextCursor: last.Encode(),
+		},
+		{
+			name:           "empty page after a cursor",
+			position:       position,
+			limit:          2,
+			wantPrevCursor: (&entity.ProductCursor{Sort: sort, Values: position.Values, ID: 4, Backward: true}).Encode(),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			page := &entity.ProductPage{Products: tc.products}
+			page.SetCursors(sort, tc.position, tc.limit)
+			assert.Equal(t, tc.wantNextCursor, page.NextCursor)
+			assert.Equal(t, tc.wantPrevCursor, page.PrevCursor)
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package entity
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+const (
+	// StocksRelation is the relation of the product which embeds the stock per location
+	StocksRelation = "stocks"
+	// LotsRelation is the relation of the product which embeds the lots
+	LotsRelation = "lots"
+)
+
+// productBaseColumns list the columns which are always read, they are needed to check the tenant and the version of the product
+var productBaseColumns = []string{"id", "tenant", "version"}
+
+// productPriceColumns list the columns the shown price is computed from, the price schedules,
+// discount rules and tax classes are matched by them
+var productPriceColumns = []string{"price", "promotion_price", "sku", "title", "category", "condition", "tax_class_id"}
+
+// productFieldColumns map the fields of the product response to the columns they are computed from
+var productFieldColumns = map[string][]string{
+	"id":                  {},
+	"sku":                 {"sku"},
+	"title":               {"title"},
+	"description":         {"description"},
+	"attributes":          {"attributes"},
+	"category":            {"category"},
+	"condition":           {"condition"},
+	"tenant":              {},
+	"qty":                 {"qty"},
+	"reserved_qty":        {"reserved_qty"},
+	"backordered_qty":     {"backordered_qty"},
+	"in_transit_qty":      {"in_transit_qty"},
+	"expired_qty":         {},
+	"available_qty":       {"qty", "reserved_qty", "backordered_qty"},
+	"price":               productPriceColumns,
+	"compare_at_price":    productPriceColumns,
+	"promotions":          productPriceColumns,
+	"tax":                 productPriceColumns,
+	"tax_class_id":        {"tax_class_id"},
+	"cost_price":          {"cost_price"},
+	"low_stock_threshold": {"low_stock_threshold"},
+	"backorder_policy":    {"backorder_policy"},
+	"backorder_limit":     {"backorder_limit"},
+	"restock_date":        {"restock_date"},
+	"serialized":          {"serialized"},
+	"version":             {},
+	"highlight":           {},
+	"created_at":          {"created_at"},
+	"updated_at":          {"updated_at"},
+}
+
+// productRelations list the relations which can be embedded in the product response
+var productRelations = []string{StocksRelation, LotsRelation}
+
+// ProductFieldset holds the fields and the relations of the product response, all fields are given when no field is selected.
+// A nil fieldset gives the full product with all of its relations
+type ProductFieldset struct {
+	Fields    []string
+	Relations []string
+}
+
+// ParseProductFieldset parse the comma separated fields and relations of the product response,
+// it is nil when neither of them is given
+func ParseProductFieldset(fields, include string) (*ProductFieldset, error) {
+	fieldset := &ProductFieldset{}
+	for _, field := range splitList(fields) {
+		if _, ok := productFieldColumns[field]; !ok {
+			return nil, response.ErrInvalidFields
+		}
+		fieldset.Fields = append(fieldset.Fields, field)
+	}
+
+	for _, relation := range splitList(include) {
+		if !isProductRelation(relation) {
+			return nil, response.ErrInvalidInclude
+		}
+		fieldset.Relations = append(fieldset.Relations, relation)
+	}
+
+	if fieldset.Fields == nil && fieldset.Relations == nil {
+		return nil, nil
+	}
+
+	return fieldset, nil
+}
+
+// HasField check whether the field is in the product response
+func (f *ProductFieldset) HasField(field string) bool {
+	if f == nil || f.Fields == nil {
+		return true
+	}
+
+	return containsString(f.Fields, field)
+}
+
+// HasRelation check whether the relation is embedded in the product response
+func (f *ProductFieldset) HasRelation(relation string) bool {
+	if f == nil {
+		return true
+	}
+
+	return containsString(f.Relations, relation)
+}
+
+// HasPrice check whether the product response has a field computed from the shown price
+func (f *ProductFieldset) HasPrice() bool {
+	return f.HasField("price") || f.HasField("compare_at_price") || f.HasField("promotions") || f.HasField("tax")
+}
+
+// Columns return the columns to read for the product response and for the cursors of the given sort,
+// it is nil when all fields are given so all of the columns are read
+func (f *ProductFieldset) Columns(sortKeys ProductSort) []string {
+	if f == nil || f.Fields == nil {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	columns := []string{}
+	add := func(cols ...string) {
+		for _, col := range cols {
+			if !seen[col] {
+				seen[col] = true
+				columns = append(columns, col)
+			}
+		}
+	}
+
+	add(productBaseColumns...)
+	for _, field := range f.Fields {
+		add(productFieldColumns[field]...)
+	}
+	for _, key := range sortKeys {
+		if key.Field != RelevanceSortField {
+			add(key.Field)
+		}
+	}
+
+	sort.Strings(columns[len(productBaseColumns):])
+
+	return columns
+}
+
+// Select return the fields and the relations of the product response, only the selected fields are marshaled
+// so the fields whose columns are not read never reach their json marshaler
+func (f *ProductFieldset) Select(product *Product) (map[string]json.RawMessage, error) {
+	values := map[string]json.RawMessage{}
+
+	value := reflect.ValueOf(product).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		key, omitEmpty := jsonFieldName(value.Type().Field(i))
+		if key == "" || !f.hasKey(key) {
+			continue
+		}
+
+		field := value.Field(i)
+		if omitEmpty && field.IsZero() {
+			continue
+		}
+
+		data, err := json.Marshal(field.Interface())
+		if err != nil {
+			return nil, err
+		}
+		values[key] = data
+	}
+
+	return values, nil
+}
+
+// SelectList return the fields and the relations of the product response of each product
+func (f *ProductFieldset) SelectList(products []*Product) ([]map[string]json.RawMessage, error) {
+	result := make([]map[string]json.RawMessage, 0, len(products))
+	for _, product := range products {
+		values, err := f.Select(product)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, values)
+	}
+
+	return result, nil
+}
+
+// hasKey check whether the key of the product json is a selected field or an embedded relation
+func (f *ProductFieldset) hasKey(key string) bool {
+	if isProductRelation(key) {
+		return f.HasRelation(key)
+	}
+
+	return f.HasField(key)
+}
+
+// jsonFieldName return the key of the struct field in json and whether it is omitted when empty,
+// the key is empty when the field is never marshaled
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+
+	parts := strings.Split(tag, ",")
+	key := parts[0]
+	if key == "" {
+		key = field.Name
+	}
+
+	return key, containsString(parts[1:], "omitempty")
+}
+
+// isProductRelation check whether the relation can be embedded in the product response
+func isProductRelation(relation string) bool {
+	return containsString(productRelations, relation)
+}
+
+// containsString check whether the value is one of the values
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitList split the comma separated values, the empty values are skipped
+func splitList(value string) []string {
+	values := []string{}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
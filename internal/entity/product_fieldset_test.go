@@ -0,0 +1,153 @@
+package entity_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProductFieldset(t *testing.T) {
+	testcases := []struct {
+		name    string
+		fields  string
+		include string
+		want    *entity.ProductFieldset
+		wantErr error
+	}{
+		{
+			name: "full product",
+		},
+		{
+			name:    "invalid field",
+			fields:  "id,media",
+			wantErr: response.ErrInvalidFields,
+		},
+		{
+			name:    "invalid relation",
+			include: "media",
+			wantErr: response.ErrInvalidInclude,
+		},
+		{
+			name:   "fields without relations",
+			fields: "id, title,price",
+			want:   &entity.ProductFieldset{Fields: []string{"id", "title", "price"}},
+		},
+		{
+			name:    "all fields with relations",
+			include: "stocks",
+			want:    &entity.ProductFieldset{Relations: []string{entity.StocksRelation}},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := entity.ParseProductFieldset(tc.fields, tc.include)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
+
+func TestProductFieldsetColumns(t *testing.T) {
+	testcases := []struct {
+		name     string
+		fieldset *entity.ProductFieldset
+		sort     entity.ProductSort
+		want     []string
+	}{
+		{
+			name: "full product",
+		},
+		{
+			name:     "all fields with relations",
+			fieldset: &entity.ProductFieldset{Relations: []string{entity.StocksRelation}},
+		},
+		{
+			name:     "fields and sort keys",
+			fieldset: &entity.ProductFieldset{Fields: []string{"title", "available_qty"}},
+			sort:     entity.ProductSort{{Field: "created_at"}, {Field: entity.RelevanceSortField}},
+			want:     []string{"id", "tenant", "version", "backordered_qty", "created_at", "qty", "reserved_qty", "title"},
+		},
+		{
+			name:     "price",
+			fieldset: &entity.ProductFieldset{Fields: []string{"price"}},
+			want:     []string{"id", "tenant", "version", "category", "condition", "price", "promotion_price", "sku", "tax_class_id", "title"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.fieldset.Columns(tc.sort))
+		})
+	}
+}
+
+func TestProductFieldsetSelect(t *testing.T) {
+	product := &entity.Product{
+		ID:              123,
+		Title:           "New Product",
+		Category:        types.CategoryBookType,
+		Condition:       types.ConditionNewType,
+		Tenant:          types.TenantLoremType,
+		BackorderPolicy: types.BackorderPolicyDenyType,
+		Stocks:          []*entity.ProductStock{},
+		Lots:            []*entity.ProductLot{},
+	}
+
+	testcases := []struct {
+		name     string
+		fieldset *entity.ProductFieldset
+		wantKeys []string
+	}{
+		{
+			name:     "fields without relations",
+			fieldset: &entity.ProductFieldset{Fields: []string{"id", "title"}},
+			wantKeys: []string{"id", "title"},
+		},
+		{
+			name:     "fields with relations",
+			fieldset: &entity.ProductFieldset{Fields: []string{"id"}, Relations: []string{entity.LotsRelation}},
+			wantKeys: []string{"id", "lots"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := tc.fieldset.Select(product)
+			assert.NoError(t, err)
+
+			keys := []string{}
+			for key := range res {
+				keys = append(keys, key)
+			}
+			assert.ElementsMatch(t, tc.wantKeys, keys)
+			assert.Equal(t, json.RawMessage(`123`), res["id"])
+		})
+	}
+}
+
+func TestProductFieldsetSelectReadColumns(t *testing.T) {
+	fieldset := &entity.ProductFieldset{Fields: []string{"id", "title", "cost_price"}}
+	assert.Equal(t, []string{"id", "tenant", "version", "cost_price", "title"}, fieldset.Columns(nil))
+
+	// the product holds only the read columns, its category, condition and backorder policy are left empty
+	product := &entity.Product{
+		ID:      123,
+		Title:   "New Product",
+		Tenant:  types.TenantLoremType,
+		Version: 2,
+	}
+
+	res, err := fieldset.SelectList([]*entity.Product{product})
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]json.RawMessage{
+		{
+			"id":    json.RawMessage(`123`),
+			"title": json.RawMessage(`"New Product"`),
+		},
+	}, res)
+}
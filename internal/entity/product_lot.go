@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"sort"
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// ProductLot struct holds entity of a lot of a product, the qty of the lots is part of the qty of the product
+type ProductLot struct {
+	ID         int       `json:"id"`
+	ProductID  int       `json:"-"`
+	LotNumber  string    `json:"lot_number"`
+	ExpiryDate time.Time `json:"expiry_date"`
+	Qty        int       `json:"qty"`
+	Expired    bool      `json:"expired"`
+	CreatedAt  time.Time `json:"-"`
+	UpdatedAt  time.Time `json:"-"`
+}
+
+// IsExpired check whether the lot is expired at the given time
+func (l *ProductLot) IsExpired(now time.Time) bool {
+	return !l.ExpiryDate.After(now)
+}
+
+// ExpiredLotQty sum qty of the lots which are expired at the given time
+func ExpiredLotQty(lots []*ProductLot, now time.Time) int {
+	total := 0
+	for _, lot := range lots {
+		if lot.IsExpired(now) {
+			total += lot.Qty
+		}
+	}
+
+	return total
+}
+
+// ConsumeLots take qty from the lots first-expiring-first-out and return the reduced lots,
+// the expired lots are skipped unless withExpired is set. The qty of the product which is not in any lot
+// has no expiry, so it is taken after the lots. The on hand qty is the qty of the product before it is reduced
+func ConsumeLots(lots []*ProductLot, onHandQty int, qty int, now time.Time, withExpired bool) ([]*ProductLot, error) {
+	sorted := make([]*ProductLot, len(lots))
+	copy(sorted, lots)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].ExpiryDate.Equal(sorted[j].ExpiryDate) {
+			return sorted[i].ExpiryDate.Before(sorted[j].ExpiryDate)
+		}
+
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	lotQty, consumableQty := 0, 0
+	for _, lot := range sorted {
+		lotQty += lot.Qty
+		if withExpired || !lot.IsExpired(now) {
+			consumableQty += lot.Qty
+		}
+	}
+
+	if untrackedQty := onHandQty - lotQty; untrackedQty > 0 {
+		consumableQty += untrackedQty
+	}
+
+	if consumableQty < qty {
+		return nil, response.ErrInsufficientStock
+	}
+
+	reduced := make([]*ProductLot, 0)
+	for _, lot := range sorted {
+		if qty == 0 {
+			break
+		}
+
+		if lot.Qty == 0 || (!withExpired && lot.IsExpired(now)) {
+			continue
+		}
+
+		take := lot.Qty
+		if take > qty {
+			take = qty
+		}
+
+		lot.Qty -= take
+		qty -= take
+		reduced = append(reduced, lot)
+	}
+
+	return reduced, nil
+}
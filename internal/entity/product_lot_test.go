@@ -0,0 +1,107 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConsumeLots documents which lots the qty is taken from, the first expiring lot first
+// and the qty which is not in any lot last.
+func TestConsumeLots(t *testing.T) {
+	now := time.Now()
+	newLots := func() []*entity.ProductLot {
+		return []*entity.ProductLot{
+			{ID: 1, LotNumber: "B", ExpiryDate: now.AddDate(0, 0, 10), Qty: 4},
+			{ID: 2, LotNumber: "A", ExpiryDate: now.AddDate(0, 0, 5), Qty: 3},
+			{ID: 3, LotNumber: "X", ExpiryDate: now.AddDate(0, 0, -1), Qty: 2},
+		}
+	}
+
+	testcases := []struct {
+		name        string
+		onHandQty   int
+		qty         int
+		withExpired bool
+		withoutLots bool
+		wantQty     map[int]int
+		wantErr     error
+	}{
+		{
+			name:      "takes from the first expiring lot first",
+			onHandQty: 9,
+			qty:       2,
+			wantQty:   map[int]int{2: 1},
+		},
+		{
+			name:      "splits across lots",
+			onHandQty: 9,
+			qty:       5,
+			wantQty:   map[int]int{2: 0, 1: 2},
+		},
+		{
+			name:      "takes the qty which is not in any lot last",
+			onHandQty: 12,
+			qty:       9,
+			wantQty:   map[int]int{2: 0, 1: 0},
+		},
+		{
+			name:      "skips the expired lots",
+			onHandQty: 9,
+			qty:       8,
+			wantErr:   response.ErrInsufficientStock,
+		},
+		{
+			name:        "takes the expired lots first when they are written off",
+			onHandQty:   9,
+			qty:         3,
+			withExpired: true,
+			wantQty:     map[int]int{3: 0, 2: 2},
+		},
+		{
+			name:        "product without lots",
+			onHandQty:   9,
+			qty:         9,
+			withoutLots: true,
+			wantQty:     map[int]int{},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			lots := newLots()
+			if tc.withoutLots {
+				lots = nil
+			}
+
+			reduced, err := entity.ConsumeLots(lots, tc.onHandQty, tc.qty, now, tc.withExpired)
+			assert.Equal(t, tc.wantErr, err)
+
+			if tc.wantErr == nil {
+				gotQty := make(map[int]int, len(reduced))
+				for _, lot := range reduced {
+					gotQty[lot.ID] = lot.Qty
+				}
+				assert.Equal(t, tc.wantQty, gotQty)
+			}
+		})
+	}
+}
+
+func TestShowLots(t *testing.T) {
+	now := time.Now()
+	product := &entity.Product{Qty: 10}
+	product.ShowLots([]*entity.ProductLot{
+		{ID: 1, ExpiryDate: now.AddDate(0, 0, -1), Qty: 3},
+		{ID: 2, ExpiryDate: now.AddDate(0, 0, 1), Qty: 4},
+	}, now)
+	product.ShowAvailableQty()
+
+	assert.Equal(t, 3, product.ExpiredQty)
+	assert.Equal(t, 7, product.AvailableQty)
+	assert.True(t, product.Lots[0].Expired)
+	assert.False(t, product.Lots[1].Expired)
+}
@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// ProductSerial struct holds entity of a serial number of a unit of a serialized product.
+// The reference id, actor and deducted at of a serial number which is out of stock are of the deduction which took it
+type ProductSerial struct {
+	ID           int                    `json:"id"`
+	ProductID    int                    `json:"product_id"`
+	Tenant       types.TenantType       `json:"-"`
+	SerialNumber string                 `json:"serial_number"`
+	Status       types.SerialStatusType `json:"status"`
+	ReferenceID  string                 `json:"reference_id"`
+	Actor        string                 `json:"actor"`
+	DeductedAt   *time.Time             `json:"deducted_at"`
+	Product      *Product               `json:"product,omitempty"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+}
+
+// NewProductSerials create the in stock serial numbers of the product
+func NewProductSerials(product *Product, serialNumbers []string) []*ProductSerial {
+	serials := make([]*ProductSerial, 0, len(serialNumbers))
+	for _, serialNumber := range serialNumbers {
+		serials = append(serials, &ProductSerial{
+			ProductID:    product.ID,
+			Tenant:       product.Tenant,
+			SerialNumber: serialNumber,
+			Status:       types.SerialStatusInStockType,
+		})
+	}
+
+	return serials
+}
+
+// SerialStatusOfReason return the status of the serial numbers deducted with the given reason,
+// the units which are not sold are written off
+func SerialStatusOfReason(reason types.StockMovementReasonType) types.SerialStatusType {
+	if reason == types.StockMovementReasonSaleType {
+		return types.SerialStatusSoldType
+	}
+
+	return types.SerialStatusWrittenOffType
+}
+
+// ValidateSerialNumbers make sure the given serial numbers are one for each unit of the qty, not blank and not repeated,
+// no serial numbers is valid as well
+func ValidateSerialNumbers(serialNumbers []string, qty int) error {
+	if len(serialNumbers) == 0 {
+		return nil
+	}
+
+	if len(serialNumbers) != qty {
+		return response.ErrInvalidSerialNumbers
+	}
+
+	seen := make(map[string]bool, len(serialNumbers))
+	for _, serialNumber := range serialNumbers {
+		if strings.TrimSpace(serialNumber) == "" || seen[serialNumber] {
+			return response.ErrInvalidSerialNumbers
+		}
+		seen[serialNumber] = true
+	}
+
+	return nil
+}
+
+// CheckSerialNumbers make sure serial numbers are only given for a serialized product,
+// and that they are given when the qty of a serialized product is increased
+func (p *Product) CheckSerialNumbers(serialNumbers []string, increase bool) error {
+	if len(serialNumbers) > 0 && !p.Serialized {
+		return response.ErrInvalidSerialNumbers
+	}
+
+	if len(serialNumbers) == 0 && p.Serialized && increase {
+		return response.ErrSerialNumbersRequired
+	}
+
+	return nil
+}
+
+// GetProductSerialPayload holds get product serial payload representative
+type GetProductSerialPayload struct {
+	ProductID int
+	Tenant    types.TenantType
+	Status    types.SerialStatusType
+	Offset    int
+	Limit     int
+}
@@ -0,0 +1,112 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProductSerials(t *testing.T) {
+	product := &entity.Product{ID: 1, Tenant: types.TenantLoremType}
+
+	serials := entity.NewProductSerials(product, []string{"SN-1", "SN-2"})
+	assert.Equal(t, []*entity.ProductSerial{
+		{ProductID: 1, Tenant: types.TenantLoremType, SerialNumber: "SN-1", Status: types.SerialStatusInStockType},
+		{ProductID: 1, Tenant: types.TenantLoremType, SerialNumber: "SN-2", Status: types.SerialStatusInStockType},
+	}, serials)
+}
+
+func TestSerialStatusOfReason(t *testing.T) {
+	assert.Equal(t, types.SerialStatusSoldType, entity.SerialStatusOfReason(types.StockMovementReasonSaleType))
+	assert.Equal(t, types.SerialStatusWrittenOffType, entity.SerialStatusOfReason(types.StockMovementReasonDamageType))
+	assert.Equal(t, types.SerialStatusWrittenOffType, entity.SerialStatusOfReason(types.StockMovementReasonAdjustmentType))
+}
+
+func TestValidateSerialNumbers(t *testing.T) {
+	testcases := []struct {
+		name          string
+		serialNumbers []string
+		qty           int
+		wantErr       error
+	}{
+		{
+			name: "no serial numbers",
+			qty:  2,
+		},
+		{
+			name:          "fewer serial numbers than qty",
+			serialNumbers: []string{"SN-1"},
+			qty:           2,
+			wantErr:       response.ErrInvalidSerialNumbers,
+		},
+		{
+			name:          "blank serial number",
+			serialNumbers: []string{"SN-1", " "},
+			qty:           2,
+			wantErr:       response.ErrInvalidSerialNumbers,
+		},
+		{
+			name:          "repeated serial number",
+			serialNumbers: []string{"SN-1", "SN-1"},
+			qty:           2,
+			wantErr:       response.ErrInvalidSerialNumbers,
+		},
+		{
+			name:          "valid",
+			serialNumbers: []string{"SN-1", "SN-2"},
+			qty:           2,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantErr, entity.ValidateSerialNumbers(tc.serialNumbers, tc.qty))
+		})
+	}
+}
+
+func TestCheckSerialNumbers(t *testing.T) {
+	testcases := []struct {
+		name          string
+		serialized    bool
+		serialNumbers []string
+		increase      bool
+		wantErr       error
+	}{
+		{
+			name:          "serial numbers of a product which is not serialized",
+			serialNumbers: []string{"SN-1"},
+			wantErr:       response.ErrInvalidSerialNumbers,
+		},
+		{
+			name:       "increase serialized product without serial numbers",
+			serialized: true,
+			increase:   true,
+			wantErr:    response.ErrSerialNumbersRequired,
+		},
+		{
+			name:       "reduce serialized product without serial numbers",
+			serialized: true,
+		},
+		{
+			name:     "increase product which is not serialized",
+			increase: true,
+		},
+		{
+			name:          "increase serialized product with serial numbers",
+			serialized:    true,
+			serialNumbers: []string{"SN-1"},
+			increase:      true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			product := &entity.Product{Serialized: tc.serialized}
+			assert.Equal(t, tc.wantErr, product.CheckSerialNumbers(tc.serialNumbers, tc.increase))
+		})
+	}
+}
@@ -0,0 +1,517 @@
+package entity_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductPayloadValidateCostPrice(t *testing.T) {
+	testcases := []struct {
+		name    string
+		payload *entity.ProductPayload
+		wantErr error
+	}{
+		{
+			name:    "without cost price",
+			payload: &entity.ProductPayload{},
+		},
+		{
+			name:    "cost price without finance scope",
+			payload: &entity.ProductPayload{CostPrice: intPtr(100)},
+			wantErr: response.ErrForbidden,
+		},
+		{
+			name:    "negative cost price",
+			payload: &entity.ProductPayload{CostPrice: intPtr(-1), FinanceScope: true},
+			wantErr: response.ErrInvalidCostPrice,
+		},
+		{
+			name:    "weighted average cost without cost price",
+			payload: &entity.ProductPayload{WeightedAverageCost: true, FinanceScope: true},
+			wantErr: response.ErrInvalidCostPrice,
+		},
+		{
+			name:    "valid cost price",
+			payload: &entity.ProductPayload{CostPrice: intPtr(100), FinanceScope: true},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.payload.Category = types.CategoryBookType
+			tc.payload.Condition = types.ConditionNewType
+			tc.payload.Tenant = types.TenantLoremType
+			assert.Equal(t, tc.wantErr, tc.payload.Validate())
+		})
+	}
+}
+
+func TestApplyCostPrice(t *testing.T) {
+	testcases := []struct {
+		name          string
+		product       *entity.Product
+		payload       *entity.ProductPayload
+		wantCostPrice *int
+	}{
+		{
+			name:          "cost price is not given",
+			product:       &entity.Product{Qty: 10, CostPrice: intPtr(100)},
+			payload:       &entity.ProductPayload{Qty: 20},
+			wantCostPrice: intPtr(100),
+		},
+		{
+			name:          "replace cost price",
+			product:       &entity.Product{Qty: 10, CostPrice: intPtr(100)},
+			payload:       &entity.ProductPayload{Qty: 20, CostPrice: intPtr(130)},
+			wantCostPrice: intPtr(130),
+		},
+		{
+			name:          "weighted average cost on added qty",
+			product:       &entity.Product{Qty: 10, CostPrice: intPtr(100)},
+			payload:       &entity.ProductPayload{Qty: 40, CostPrice: intPtr(140), WeightedAverageCost: true},
+			wantCostPrice: intPtr(130),
+		},
+		{
+			name:          "weighted average cost is rounded half up",
+			product:       &entity.Product{Qty: 1, CostPrice: intPtr(100)},
+			payload:       &entity.ProductPayload{Qty: 3, CostPrice: intPtr(101), WeightedAverageCost: true},
+			wantCostPrice: intPtr(101),
+		},
+		{
+			name:          "weighted average cost without added qty",
+			product:       &entity.Product{Qty: 10, CostPrice: intPtr(100)},
+			payload:       &entity.ProductPayload{Qty: 5, CostPrice: intPtr(140), WeightedAverageCost: true},
+			wantCostPrice: intPtr(100),
+		},
+		{
+			name:          "weighted average cost without current cost",
+			product:       &entity.Product{Qty: 10},
+			payload:       &entity.ProductPayload{Qty: 20, CostPrice: intPtr(140), WeightedAverageCost: true},
+			wantCostPrice: intPtr(140),
+		},
+		{
+			name:          "weighted average cost without current stock",
+			product:       &entity.Product{Qty: 0, CostPrice: intPtr(100)},
+			payload:       &entity.ProductPayload{Qty: 5, CostPrice: intPtr(140), WeightedAverageCost: true},
+			wantCostPrice: intPtr(140),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.product.ApplyCostPrice(tc.payload)
+			assert.Equal(t, tc.wantCostPrice, tc.product.CostPrice)
+		})
+	}
+}
+
+func TestShowAvailableQty(t *testing.T) {
+	testcases := []struct {
+		name    string
+		product *entity.Product
+		wantQty int
+	}{
+		{
+			name:    "without reservation",
+			product: &entity.Product{Qty: 10},
+			wantQty: 10,
+		},
+		{
+			name:    "with reservation",
+			product: &entity.Product{Qty: 10, ReservedQty: 4},
+			wantQty: 6,
+		},
+		{
+			name:    "qty reduced below the reserved qty",
+			product: &entity.Product{Qty: 3, ReservedQty: 4},
+			wantQty: 0,
+		},
+		{
+			name:    "with backorder",
+			product: &entity.Product{Qty: 10, ReservedQty: 4, BackorderedQty: 3},
+			wantQty: 3,
+		},
+		{
+			name:    "with expired lots",
+			product: &entity.Product{Qty: 10, ReservedQty: 4, ExpiredQty: 2},
+			wantQty: 4,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.product.ShowAvailableQty()
+			assert.Equal(t, tc.wantQty, tc.product.AvailableQty)
+		})
+	}
+}
+
+func TestProductPayloadValidateBackorder(t *testing.T) {
+	testcases := []struct {
+		name    string
+		payload *entity.ProductPayload
+		wantErr error
+	}{
+		{
+			name:    "without backorder policy",
+			payload: &entity.ProductPayload{},
+		},
+		{
+			name:    "limited backorder without limit",
+			payload: &entity.ProductPayload{BackorderPolicy: types.BackorderPolicyLimitedType},
+			wantErr: response.ErrInvalidBackorderLimit,
+		},
+		{
+			name:    "limited backorder with zero limit",
+			payload: &entity.ProductPayload{BackorderPolicy: types.BackorderPolicyLimitedType, BackorderLimit: intPtr(0)},
+			wantErr: response.ErrInvalidBackorderLimit,
+		},
+		{
+			name:    "unlimited backorder with limit",
+			payload: &entity.ProductPayload{BackorderPolicy: types.BackorderPolicyUnlimitedType, BackorderLimit: intPtr(5)},
+			wantErr: response.ErrInvalidBackorderLimit,
+		},
+		{
+			name:    "valid limited backorder",
+			payload: &entity.ProductPayload{BackorderPolicy: types.BackorderPolicyLimitedType, BackorderLimit: intPtr(5)},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.payload.Category = types.CategoryBookType
+			tc.payload.Condition = types.ConditionNewType
+			tc.payload.Tenant = types.TenantLoremType
+			assert.Equal(t, tc.wantErr, tc.payload.Validate())
+		})
+	}
+}
+
+func TestProductPayloadValidateFields(t *testing.T) {
+	testcases := []struct {
+		name    string
+		payload *entity.ProductPayload
+		fields  map[string]bool
+		wantErr error
+	}{
+		{
+			name:    "fields which are not changed are not validated",
+			payload: &entity.ProductPayload{Tenant: types.TenantLoremType, Qty: -1},
+			fields:  map[string]bool{"price": true},
+		},
+		{
+			name:    "tenant is always validated",
+			payload: &entity.ProductPayload{},
+			fields:  map[string]bool{},
+			wantErr: response.ErrInvalidTenant,
+		},
+		{
+			name:    "changed field",
+			payload: &entity.ProductPayload{Tenant: types.TenantLoremType, Qty: -1},
+			fields:  map[string]bool{"qty": true},
+			wantErr: response.ErrInvalidQty,
+		},
+		{
+			name:    "field validated with another changed field",
+			payload: &entity.ProductPayload{Tenant: types.TenantLoremType, BackorderPolicy: types.BackorderPolicyDenyType, BackorderLimit: intPtr(5)},
+			fields:  map[string]bool{"backorder_policy": true},
+			wantErr: response.ErrInvalidBackorderLimit,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantErr, tc.payload.ValidateFields(tc.fields))
+		})
+	}
+}
+
+func TestProductPatchPayloadColumns(t *testing.T) {
+	payload := &entity.ProductPatchPayload{Fields: map[string]json.RawMessage{
+		"title":                 json.RawMessage(`"Book"`),
+		"stocks":                json.RawMessage(`[]`),
+		"qty":                   json.RawMessage(`5`),
+		"weighted_average_cost": json.RawMessage(`true`),
+		"cost_price":            json.RawMessage(`500`),
+		"reference_id":          json.RawMessage(`"PO-1"`),
+	}}
+
+	assert.Equal(t, []string{"cost_price", "qty", "title"}, payload.Columns())
+}
+
+func TestGetProductPayloadValidate(t *testing.T) {
+	low, high := 1, 2
+	earlier := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+
+	testcases := []struct {
+		name    string
+		payload *entity.GetProductPayload
+		wantErr error
+	}{
+		{
+			name:    "invalid price range",
+			payload: &entity.GetProductPayload{MinPrice: &high, MaxPrice: &low},
+			wantErr: response.ErrInvalidPrice,
+		},
+		{
+			name:    "invalid qty range",
+			payload: &entity.GetProductPayload{MinQty: &high, MaxQty: &low},
+			wantErr: response.ErrInvalidQty,
+		},
+		{
+			name:    "invalid created date range",
+			payload: &entity.GetProductPayload{CreatedFrom: &later, CreatedTo: &earlier},
+			wantErr: response.ErrInvalidDateRange,
+		},
+		{
+			name:    "invalid updated date range",
+			payload: &entity.GetProductPayload{UpdatedFrom: &later, UpdatedTo: &earlier},
+			wantErr: response.ErrInvalidDateRange,
+		},
+		{
+			name:    "valid ranges",
+			payload: &entity.GetProductPayload{MinPrice: &low, MaxPrice: &low, MinQty: &low, MaxQty: &high, CreatedFrom: &earlier, CreatedTo: &later, UpdatedFrom: &earlier},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantErr, tc.payload.Validate())
+		})
+	}
+}
+
+func TestGetProductPayloadSortKeys(t *testing.T) {
+	cursorSort := entity.ProductSort{{Field: "price"}}
+
+	testcases := []struct {
+		name    string
+		payload *entity.GetProductPayload
+		want    entity.ProductSort
+	}{
+		{
+			name:    "default sort without keyword",
+			payload: &entity.GetProductPayload{Sort: entity.ProductSort{}},
+			want:    entity.ProductSort{},
+		},
+		{
+			name:    "relevance by default with keyword",
+			payload: &entity.GetProductPayload{Keyword: "shirt", Sort: entity.ProductSort{}},
+			want:    entity.ProductSort{{Field: entity.RelevanceSortField, Desc: true}},
+		},
+		{
+			name:    "given sort with keyword",
+			payload: &entity.GetProductPayload{Keyword: "shirt", Sort: entity.ProductSort{{Field: "title"}}},
+			want:    entity.ProductSort{{Field: "title"}},
+		},
+		{
+			name:    "sort of the cursor",
+			payload: &entity.GetProductPayload{Keyword: "shirt", Position: &entity.ProductCursor{Sort: cursorSort}},
+			want:    cursorSort,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.payload.SortKeys())
+		})
+	}
+}
+
+func TestProductPatchPayloadApplyAttributes(t *testing.T) {
+	testcases := []struct {
+		name           string
+		attributes     string
+		wantAttributes map[string]string
+		wantErr        error
+	}{
+		{
+			name:           "attributes are merged key by key",
+			attributes:     `{"color": "blue", "material": "cotton"}`,
+			wantAttributes: map[string]string{"color": "blue", "size": "M", "material": "cotton"},
+		},
+		{
+			name:           "null member removes the attribute",
+			attributes:     `{"color": null, "size": "L"}`,
+			wantAttributes: map[string]string{"size": "L"},
+		},
+		{
+			name:           "null attributes remove all of them",
+			attributes:     `null`,
+			wantAttributes: nil,
+		},
+		{
+			name:       "attribute which is not a string",
+			attributes: `{"color": {"name": "blue"}}`,
+			wantErr:    response.CustomError{Message: response.ErrInvalidPatch.Message, Field: "attributes", Code: response.ErrorCodeInvalidPatch, HTTPCode: response.ErrInvalidPatch.HTTPCode},
+		},
+		{
+			name:       "attributes which are not an object",
+			attributes: `"blue"`,
+			wantErr:    response.CustomError{Message: response.ErrInvalidPatch.Message, Field: "attributes", Code: response.ErrorCodeInvalidPatch, HTTPCode: response.ErrInvalidPatch.HTTPCode},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			product := &entity.Product{Title: "Shirt", Attributes: map[string]string{"color": "red", "size": "M"}}
+			payload := &entity.ProductPatchPayload{Fields: map[string]json.RawMessage{
+				"attributes": json.RawMessage(tc.attributes),
+			}}
+
+			res, changed, err := payload.Apply(product)
+			assert.Equal(t, map[string]string{"color": "red", "size": "M"}, product.Attributes)
+			if tc.wantErr != nil {
+				assert.Equal(t, tc.wantErr, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]bool{"attributes": true}, changed)
+			assert.Equal(t, tc.wantAttributes, res.Attributes)
+			assert.Equal(t, "Shirt", res.Title)
+			assert.Equal(t, []string{"attributes"}, payload.Columns())
+		})
+	}
+}
+
+func TestSplitBackorder(t *testing.T) {
+	testcases := []struct {
+		name               string
+		product            *entity.Product
+		qty                int
+		wantFulfilledQty   int
+		wantBackorderedQty int
+		wantErr            error
+	}{
+		{
+			name:             "available qty",
+			product:          &entity.Product{Qty: 10, BackorderPolicy: types.BackorderPolicyDenyType},
+			qty:              10,
+			wantFulfilledQty: 10,
+		},
+		{
+			name:    "denied backorder",
+			product: &entity.Product{Qty: 10, BackorderPolicy: types.BackorderPolicyDenyType},
+			qty:     11,
+			wantErr: response.ErrInsufficientStock,
+		},
+		{
+			name:               "backorder within the limit",
+			product:            &entity.Product{Qty: 10, BackorderedQty: 2, BackorderPolicy: types.BackorderPolicyLimitedType, BackorderLimit: intPtr(5)},
+			qty:                11,
+			wantFulfilledQty:   8,
+			wantBackorderedQty: 3,
+		},
+		{
+			name:    "backorder over the limit",
+			product: &entity.Product{Qty: 10, BackorderedQty: 2, BackorderPolicy: types.BackorderPolicyLimitedType, BackorderLimit: intPtr(5)},
+			qty:     12,
+			wantErr: response.ErrInsufficientStock,
+		},
+		{
+			name:               "unlimited backorder of reserved qty",
+			product:            &entity.Product{Qty: 10, ReservedQty: 10, BackorderPolicy: types.BackorderPolicyUnlimitedType},
+			qty:                100,
+			wantBackorderedQty: 100,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fulfilledQty, backorderedQty, err := tc.product.SplitBackorder(tc.qty)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantFulfilledQty, fulfilledQty)
+			assert.Equal(t, tc.wantBackorderedQty, backorderedQty)
+		})
+	}
+}
+
+func TestAdjustQtyProductPayloadValidate(t *testing.T) {
+	testcases := []struct {
+		name    string
+		payload *entity.AdjustQtyProductPayload
+		wantErr error
+	}{
+		{
+			name:    "zero delta",
+			payload: &entity.AdjustQtyProductPayload{Reason: types.StockMovementReasonAdjustmentType},
+			wantErr: response.ErrInvalidQty,
+		},
+		{
+			name:    "reason does not match delta",
+			payload: &entity.AdjustQtyProductPayload{Delta: -1, Reason: types.StockMovementReasonReturnType},
+			wantErr: response.ErrInvalidStockMovementReason,
+		},
+		{
+			name:    "valid",
+			payload: &entity.AdjustQtyProductPayload{Delta: -1, Reason: types.StockMovementReasonDamageType},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantErr, tc.payload.Validate())
+		})
+	}
+}
+
+func TestBulkIncreaseQtyProductPayloadValidate(t *testing.T) {
+	expiryDate := time.Now()
+
+	testcases := []struct {
+		name    string
+		item    entity.BulkIncreaseQtyProductItemPayload
+		wantErr error
+	}{
+		{
+			name:    "zero qty",
+			item:    entity.BulkIncreaseQtyProductItemPayload{SKU: "SKU1"},
+			wantErr: response.ErrInvalidQty,
+		},
+		{
+			name:    "lot number without expiry date",
+			item:    entity.BulkIncreaseQtyProductItemPayload{SKU: "SKU1", ReqQty: 1, LotNumber: "LOT1"},
+			wantErr: response.ErrInvalidLot,
+		},
+		{
+			name:    "expiry date without lot number",
+			item:    entity.BulkIncreaseQtyProductItemPayload{SKU: "SKU1", ReqQty: 1, ExpiryDate: &expiryDate},
+			wantErr: response.ErrInvalidLot,
+		},
+		{
+			name: "with lot",
+			item: entity.BulkIncreaseQtyProductItemPayload{SKU: "SKU1", ReqQty: 1, LotNumber: "LOT1", ExpiryDate: &expiryDate},
+		},
+		{
+			name: "without lot",
+			item: entity.BulkIncreaseQtyProductItemPayload{SKU: "SKU1", ReqQty: 1},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			payload := &entity.BulkIncreaseQtyProductPayload{
+				Items:  []entity.BulkIncreaseQtyProductItemPayload{tc.item},
+				Reason: types.StockMovementReasonRestockType,
+			}
+			assert.Equal(t, tc.wantErr, payload.Validate())
+		})
+	}
+}
+
+func TestCheckVersion(t *testing.T) {
+	product := &entity.Product{Version: 2}
+
+	assert.NoError(t, product.CheckVersion(nil))
+	assert.NoError(t, product.CheckVersion([]int{2}))
+	assert.NoError(t, product.CheckVersion([]int{1, 2}))
+	assert.Equal(t, response.ErrStaleVersion, product.CheckVersion([]int{1}))
+}
@@ -0,0 +1,116 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+)
+
+const (
+	// MarginRateBase is the margin rate equal to 100%, margin rates are in basis points
+	MarginRateBase = 10000
+	// DefaultExpiringLotDays is the number of days ahead of the expiring lots report when it is not given
+	DefaultExpiringLotDays = 30
+)
+
+// ProductMargin struct holds margin of a product on its selling price
+type ProductMargin struct {
+	ProductID    int                 `json:"product_id"`
+	SKU          string              `json:"sku"`
+	Title        string              `json:"title"`
+	Category     types.CategoryType  `json:"category"`
+	Condition    types.ConditionType `json:"condition"`
+	Qty          int                 `json:"qty"`
+	SellingPrice int                 `json:"selling_price"`
+	CostPrice    *int                `json:"cost_price"`
+	Margin       *int                `json:"margin"`
+	MarginRate   *int                `json:"margin_rate"`
+}
+
+// NewProductMargin compute the margin of the running price of the product,
+// margin is left empty when the cost of the product is unknown
+func NewProductMargin(p *Product) *ProductMargin {
+	sellingPrice := p.Price
+	if p.PromotionPrice != nil {
+		sellingPrice = *p.PromotionPrice
+	}
+
+	productMargin := &ProductMargin{
+		ProductID:    p.ID,
+		SKU:          p.SKU,
+		Title:        p.Title,
+		Category:     p.Category,
+		Condition:    p.Condition,
+		Qty:          p.Qty,
+		SellingPrice: sellingPrice,
+		CostPrice:    p.CostPrice,
+	}
+
+	if p.CostPrice == nil {
+		return productMargin
+	}
+
+	margin := sellingPrice - *p.CostPrice
+	productMargin.Margin = &margin
+	if sellingPrice > 0 {
+		marginRate := divideRoundHalfUp(abs(margin)*MarginRateBase, sellingPrice)
+		if margin < 0 {
+			marginRate = -marginRate
+		}
+		productMargin.MarginRate = &marginRate
+	}
+
+	return productMargin
+}
+
+// InventoryValuation struct holds value of the stock on hand of a category and condition
+type InventoryValuation struct {
+	Category             types.CategoryType  `json:"category"`
+	Condition            types.ConditionType `json:"condition"`
+	ProductCount         int                 `json:"product_count"`
+	Qty                  int                 `json:"qty"`
+	CostValue            int                 `json:"cost_value"`
+	RetailValue          int                 `json:"retail_value"`
+	UncostedProductCount int                 `json:"uncosted_product_count"`
+}
+
+// GetInventoryValuationPayload holds get inventory valuation payload representative
+type GetInventoryValuationPayload struct {
+	Tenant       types.TenantType
+	FinanceScope bool
+}
+
+// ExpiringLot struct holds a lot in stock which expires soon or is already expired
+type ExpiringLot struct {
+	ProductID  int       `json:"product_id"`
+	SKU        string    `json:"sku"`
+	Title      string    `json:"title"`
+	LotNumber  string    `json:"lot_number"`
+	ExpiryDate time.Time `json:"expiry_date"`
+	Qty        int       `json:"qty"`
+	Expired    bool      `json:"expired"`
+}
+
+// GetExpiringLotPayload holds get expiring lot payload representative
+type GetExpiringLotPayload struct {
+	Tenant types.TenantType
+	// Days is the number of days ahead to look for expiring lots
+	Days int
+}
+
+// ExpiresBefore return the time until when the lots are reported, the default days are used when no days are given
+func (p *GetExpiringLotPayload) ExpiresBefore(now time.Time) time.Time {
+	days := p.Days
+	if days <= 0 {
+		days = DefaultExpiringLotDays
+	}
+
+	return now.AddDate(0, 0, days)
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,60 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProductMargin(t *testing.T) {
+	testcases := []struct {
+		name             string
+		product          *entity.Product
+		wantSellingPrice int
+		wantMargin       *int
+		wantMarginRate   *int
+	}{
+		{
+			name:             "without cost price",
+			product:          &entity.Product{Price: 1000},
+			wantSellingPrice: 1000,
+		},
+		{
+			name:             "with cost price",
+			product:          &entity.Product{Price: 1000, CostPrice: intPtr(700)},
+			wantSellingPrice: 1000,
+			wantMargin:       intPtr(300),
+			wantMarginRate:   intPtr(3000),
+		},
+		{
+			name:             "on promotion price",
+			product:          &entity.Product{Price: 1000, PromotionPrice: intPtr(800), CostPrice: intPtr(700)},
+			wantSellingPrice: 800,
+			wantMargin:       intPtr(100),
+			wantMarginRate:   intPtr(1250),
+		},
+		{
+			name:             "negative margin",
+			product:          &entity.Product{Price: 300, CostPrice: intPtr(400)},
+			wantSellingPrice: 300,
+			wantMargin:       intPtr(-100),
+			wantMarginRate:   intPtr(-3333),
+		},
+		{
+			name:             "free product",
+			product:          &entity.Product{Price: 0, CostPrice: intPtr(400)},
+			wantSellingPrice: 0,
+			wantMargin:       intPtr(-400),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := entity.NewProductMargin(tc.product)
+			assert.Equal(t, tc.wantSellingPrice, res.SellingPrice)
+			assert.Equal(t, tc.wantMargin, res.Margin)
+			assert.Equal(t, tc.wantMarginRate, res.MarginRate)
+		})
+	}
+}
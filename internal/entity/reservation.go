@@ -0,0 +1,121 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+const (
+	// MaxReservationTTLSeconds is the longest time a reservation can hold stock
+	MaxReservationTTLSeconds = 24 * 60 * 60
+)
+
+// Reservation struct holds entity of stock held for a checkout until it is confirmed, released or expired
+type Reservation struct {
+	ID        int                         `json:"id"`
+	Tenant    types.TenantType            `json:"tenant"`
+	Status    types.ReservationStatusType `json:"status"`
+	Items     []*ReservationItem          `json:"items"`
+	ExpiresAt time.Time                   `json:"expires_at"`
+	CreatedAt time.Time                   `json:"created_at"`
+	UpdatedAt time.Time                   `json:"updated_at"`
+}
+
+// IsActive check whether the reservation still holds stock at the given time
+func (r *Reservation) IsActive(now time.Time) bool {
+	return r.Status == types.ReservationStatusActiveType && now.Before(r.ExpiresAt)
+}
+
+// ItemsByProductID return a copy of the items in the order their products are locked
+func (r *Reservation) ItemsByProductID() []*ReservationItem {
+	items := make([]*ReservationItem, len(r.Items))
+	copy(items, r.Items)
+	sortByProductID(items, func(i int) int { return items[i].ProductID })
+
+	return items
+}
+
+// ReservationItem struct holds entity of qty of a product held by a reservation
+type ReservationItem struct {
+	ID            int    `json:"-"`
+	ReservationID int    `json:"-"`
+	ProductID     int    `json:"product_id"`
+	SKU           string `json:"sku"`
+	Qty           int    `json:"qty"`
+}
+
+// ReservationPayload holds reservation payload representative
+type ReservationPayload struct {
+	Items      []ReservationItemPayload `json:"items"`
+	TTLSeconds int                      `json:"ttl_seconds"`
+	Tenant     types.TenantType         `json:"-"`
+}
+
+// ReservationItemPayload holds reservation item payload representative
+type ReservationItemPayload struct {
+	SKU string `json:"sku"`
+	Qty int    `json:"qty"`
+}
+
+// SwaggerReservationPayload holds reservation payload for swagger docs
+// Do not remove this struct
+// Everytime you update the ReservationPayload
+// you must adjust this struct for swagger docs
+type SwaggerReservationPayload struct {
+	Items      []ReservationItemPayload `json:"items"`
+	TTLSeconds int                      `json:"ttl_seconds" example:"900"`
+}
+
+// ToEntity to convert reservation payload to entity contract,
+// the default ttl is used when the payload has none
+func (p *ReservationPayload) ToEntity(now time.Time, defaultTTL time.Duration) *Reservation {
+	ttl := defaultTTL
+	if p.TTLSeconds > 0 {
+		ttl = time.Duration(p.TTLSeconds) * time.Second
+	}
+
+	items := make([]*ReservationItem, 0, len(p.Items))
+	for _, item := range p.Items {
+		items = append(items, &ReservationItem{
+			SKU: strings.TrimSpace(item.SKU),
+			Qty: item.Qty,
+		})
+	}
+
+	return &Reservation{
+		Tenant:    p.Tenant,
+		Status:    types.ReservationStatusActiveType,
+		Items:     items,
+		ExpiresAt: now.Add(ttl),
+	}
+}
+
+// Validate is func to validate payload
+func (p *ReservationPayload) Validate() error {
+	if len(p.Items) == 0 {
+		return response.ErrInvalidReservationItems
+	}
+
+	for _, item := range p.Items {
+		if strings.TrimSpace(item.SKU) == "" {
+			return response.ErrInvalidReservationItems
+		}
+
+		if item.Qty <= 0 {
+			return response.ErrInvalidQty
+		}
+	}
+
+	if p.TTLSeconds < 0 || p.TTLSeconds > MaxReservationTTLSeconds {
+		return response.ErrInvalidReservationTTL
+	}
+
+	if p.Tenant == types.TenantEmptyType {
+		return response.ErrInvalidTenant
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReservationPayloadValidate(t *testing.T) {
+	items := []entity.ReservationItemPayload{{SKU: "SKU-1", Qty: 2}}
+
+	testcases := []struct {
+		name    string
+		payload *entity.ReservationPayload
+		wantErr error
+	}{
+		{
+			name:    "without items",
+			payload: &entity.ReservationPayload{Tenant: types.TenantLoremType},
+			wantErr: response.ErrInvalidReservationItems,
+		},
+		{
+			name:    "blank sku",
+			payload: &entity.ReservationPayload{Items: []entity.ReservationItemPayload{{SKU: " ", Qty: 1}}, Tenant: types.TenantLoremType},
+			wantErr: response.ErrInvalidReservationItems,
+		},
+		{
+			name:    "invalid qty",
+			payload: &entity.ReservationPayload{Items: []entity.ReservationItemPayload{{SKU: "SKU-1", Qty: 0}}, Tenant: types.TenantLoremType},
+			wantErr: response.ErrInvalidQty,
+		},
+		{
+			name:    "negative ttl",
+			payload: &entity.ReservationPayload{Items: items, TTLSeconds: -1, Tenant: types.TenantLoremType},
+			wantErr: response.ErrInvalidReservationTTL,
+		},
+		{
+			name:    "ttl longer than the max",
+			payload: &entity.ReservationPayload{Items: items, TTLSeconds: entity.MaxReservationTTLSeconds + 1, Tenant: types.TenantLoremType},
+			wantErr: response.ErrInvalidReservationTTL,
+		},
+		{
+			name:    "invalid tenant",
+			payload: &entity.ReservationPayload{Items: items},
+			wantErr: response.ErrInvalidTenant,
+		},
+		{
+			name:    "valid",
+			payload: &entity.ReservationPayload{Items: items, TTLSeconds: 60, Tenant: types.TenantLoremType},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantErr, tc.payload.Validate())
+		})
+	}
+}
+
+func TestReservationPayloadToEntity(t *testing.T) {
+	now := time.Date(2023, 12, 17, 9, 0, 0, 0, time.UTC)
+	items := []entity.ReservationItemPayload{{SKU: " SKU-1 ", Qty: 2}}
+
+	testcases := []struct {
+		name          string
+		payload       *entity.ReservationPayload
+		wantExpiresAt time.Time
+	}{
+		{
+			name:          "default ttl",
+			payload:       &entity.ReservationPayload{Items: items, Tenant: types.TenantLoremType},
+			wantExpiresAt: now.Add(15 * time.Minute),
+		},
+		{
+			name:          "given ttl",
+			payload:       &entity.ReservationPayload{Items: items, TTLSeconds: 60, Tenant: types.TenantLoremType},
+			wantExpiresAt: now.Add(time.Minute),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			reservation := tc.payload.ToEntity(now, 15*time.Minute)
+			assert.Equal(t, types.ReservationStatusActiveType, reservation.Status)
+			assert.Equal(t, tc.wantExpiresAt, reservation.ExpiresAt)
+			assert.Equal(t, "SKU-1", reservation.Items[0].SKU)
+		})
+	}
+}
+
+func TestReservationIsActive(t *testing.T) {
+	now := time.Now()
+
+	testcases := []struct {
+		name        string
+		reservation *entity.Reservation
+		want        bool
+	}{
+		{
+			name:        "active",
+			reservation: &entity.Reservation{Status: types.ReservationStatusActiveType, ExpiresAt: now.Add(time.Minute)},
+			want:        true,
+		},
+		{
+			name:        "expired but not released yet",
+			reservation: &entity.Reservation{Status: types.ReservationStatusActiveType, ExpiresAt: now},
+			want:        false,
+		},
+		{
+			name:        "confirmed",
+			reservation: &entity.Reservation{Status: types.ReservationStatusConfirmedType, ExpiresAt: now.Add(time.Minute)},
+			want:        false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.reservation.IsActive(now))
+		})
+	}
+}
+
+func TestReservationItemsByProductID(t *testing.T) {
+	reservation := &entity.Reservation{
+		Items: []*entity.ReservationItem{
+			{ProductID: 3, Qty: 1},
+			{ProductID: 1, Qty: 2},
+			{ProductID: 2, Qty: 3},
+		},
+	}
+
+	items := reservation.ItemsByProductID()
+
+	assert.Equal(t, []int{1, 2, 3}, []int{items[0].ProductID, items[1].ProductID, items[2].ProductID})
+	assert.Equal(t, 3, reservation.Items[0].ProductID, "the items of the reservation keep their order")
+}
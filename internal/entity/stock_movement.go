@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// StockMovement struct holds entity of a change of the qty of a product
+type StockMovement struct {
+	ID           int                           `json:"id"`
+	ProductID    int                           `json:"product_id"`
+	Tenant       types.TenantType              `json:"tenant"`
+	Delta        int                           `json:"delta"`
+	ResultingQty int                           `json:"resulting_qty"`
+	Reason       types.StockMovementReasonType `json:"reason"`
+	Actor        string                        `json:"actor"`
+	ReferenceID  string                        `json:"reference_id"`
+	CreatedAt    time.Time                     `json:"created_at"`
+}
+
+// NewStockMovement record the given change of qty of the product, the product must already have the resulting qty
+func NewStockMovement(product *Product, delta int, reason types.StockMovementReasonType, actor string, referenceID string) *StockMovement {
+	return &StockMovement{
+		ProductID:    product.ID,
+		Tenant:       product.Tenant,
+		Delta:        delta,
+		ResultingQty: product.Qty,
+		Reason:       reason,
+		Actor:        actor,
+		ReferenceID:  referenceID,
+	}
+}
+
+// ValidateStockMovementReason make sure the reason matches the direction of the qty change,
+// sale and damage reduce the qty, restock and return increase it and adjustment goes both ways.
+// Transfer is only recorded by the transfers, so it is not a valid reason of a qty change
+func ValidateStockMovementReason(reason types.StockMovementReasonType, delta int) error {
+	switch reason {
+	case types.StockMovementReasonSaleType, types.StockMovementReasonDamageType:
+		if delta < 0 {
+			return nil
+		}
+	case types.StockMovementReasonRestockType, types.StockMovementReasonReturnType:
+		if delta > 0 {
+			return nil
+		}
+	case types.StockMovementReasonAdjustmentType:
+		return nil
+	}
+
+	return response.ErrInvalidStockMovementReason
+}
+
+// GetStockMovementPayload holds get stock movement payload representative
+type GetStockMovementPayload struct {
+	ProductID int
+	Tenant    types.TenantType
+	Reason    types.StockMovementReasonType
+	From      *time.Time
+	To        *time.Time
+	Offset    int
+	Limit     int
+}
+
+// Validate is func to validate payload
+func (p *GetStockMovementPayload) Validate() error {
+	if p.From != nil && p.To != nil && p.From.After(*p.To) {
+		return response.ErrInvalidDateRange
+	}
+
+	return nil
+}
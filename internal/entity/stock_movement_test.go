@@ -0,0 +1,111 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStockMovement(t *testing.T) {
+	product := &entity.Product{ID: 1, Tenant: types.TenantLoremType, Qty: 8}
+
+	movement := entity.NewStockMovement(product, -2, types.StockMovementReasonSaleType, "jane", "ORDER-1")
+	assert.Equal(t, &entity.StockMovement{
+		ProductID:    1,
+		Tenant:       types.TenantLoremType,
+		Delta:        -2,
+		ResultingQty: 8,
+		Reason:       types.StockMovementReasonSaleType,
+		Actor:        "jane",
+		ReferenceID:  "ORDER-1",
+	}, movement)
+}
+
+func TestGetStockMovementPayloadValidate(t *testing.T) {
+	from := time.Now()
+	to := from.Add(time.Hour)
+
+	testcases := []struct {
+		name    string
+		payload *entity.GetStockMovementPayload
+		wantErr error
+	}{
+		{
+			name:    "from is after to",
+			payload: &entity.GetStockMovementPayload{From: &to, To: &from},
+			wantErr: response.ErrInvalidDateRange,
+		},
+		{
+			name:    "only from",
+			payload: &entity.GetStockMovementPayload{From: &from},
+		},
+		{
+			name:    "valid",
+			payload: &entity.GetStockMovementPayload{From: &from, To: &to},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantErr, tc.payload.Validate())
+		})
+	}
+}
+
+func TestValidateStockMovementReason(t *testing.T) {
+	testcases := []struct {
+		name    string
+		reason  types.StockMovementReasonType
+		delta   int
+		wantErr error
+	}{
+		{
+			name:    "empty reason",
+			delta:   1,
+			wantErr: response.ErrInvalidStockMovementReason,
+		},
+		{
+			name:    "sale increasing qty",
+			reason:  types.StockMovementReasonSaleType,
+			delta:   1,
+			wantErr: response.ErrInvalidStockMovementReason,
+		},
+		{
+			name:   "damage reducing qty",
+			reason: types.StockMovementReasonDamageType,
+			delta:  -1,
+		},
+		{
+			name:    "restock reducing qty",
+			reason:  types.StockMovementReasonRestockType,
+			delta:   -1,
+			wantErr: response.ErrInvalidStockMovementReason,
+		},
+		{
+			name:   "return increasing qty",
+			reason: types.StockMovementReasonReturnType,
+			delta:  1,
+		},
+		{
+			name:   "adjustment reducing qty",
+			reason: types.StockMovementReasonAdjustmentType,
+			delta:  -1,
+		},
+		{
+			name:    "transfer reducing qty",
+			reason:  types.StockMovementReasonTransferType,
+			delta:   -1,
+			wantErr: response.ErrInvalidStockMovementReason,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantErr, entity.ValidateStockMovementReason(tc.reason, tc.delta))
+		})
+	}
+}
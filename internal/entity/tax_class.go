@@ -0,0 +1,185 @@
+package entity
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+const (
+	// TaxRateBase is the rate value equal to 100%, rates are stored in basis points
+	TaxRateBase = 10000
+	// MaxTaxRate is the highest accepted rate
+	MaxTaxRate = TaxRateBase
+)
+
+// regionPattern match region code such as "ID", "SG" or "ID-JK"
+var regionPattern = regexp.MustCompile(`^[A-Z0-9-]{0,10}$`)
+
+// TaxClass struct holds entity of tax class
+type TaxClass struct {
+	ID               int                  `json:"id"`
+	Tenant           types.TenantType     `json:"tenant"`
+	Name             string               `json:"name"`
+	PriceIncludesTax bool                 `json:"price_includes_tax"`
+	Rates            []*TaxRate           `json:"rates"`
+	Categories       []types.CategoryType `json:"categories"`
+	CreatedAt        time.Time            `json:"created_at"`
+	UpdatedAt        time.Time            `json:"updated_at"`
+}
+
+// GetRate return the rate of the given region,
+// fallback to the default rate which has empty region
+func (t *TaxClass) GetRate(region string) (*TaxRate, bool) {
+	var defaultRate *TaxRate
+	for _, rate := range t.Rates {
+		if rate.Region == region {
+			return rate, true
+		}
+
+		if rate.Region == "" {
+			defaultRate = rate
+		}
+	}
+
+	return defaultRate, defaultRate != nil
+}
+
+// TaxRate struct holds entity of tax rate
+type TaxRate struct {
+	ID         int       `json:"-"`
+	TaxClassID int       `json:"-"`
+	Region     string    `json:"region"`
+	Rate       int       `json:"rate"`
+	CreatedAt  time.Time `json:"-"`
+	UpdatedAt  time.Time `json:"-"`
+}
+
+// CategoryTaxClass struct holds entity of the default tax class of a category
+type CategoryTaxClass struct {
+	Tenant     types.TenantType
+	Category   types.CategoryType
+	TaxClassID int
+}
+
+// TaxAmount struct holds the tax computation of a price
+type TaxAmount struct {
+	TaxClassID *int   `json:"tax_class_id"`
+	Region     string `json:"region"`
+	Rate       int    `json:"rate"`
+	Net        int    `json:"net"`
+	Tax        int    `json:"tax"`
+	Gross      int    `json:"gross"`
+}
+
+// CalculateTax compute net, tax and gross amount of the price with the given rate in basis points.
+// When the price includes tax, the price is the gross amount and the net amount is derived from it,
+// otherwise the price is the net amount. The derived amount is rounded half up to the nearest unit
+// and the tax is always the difference, so that net + tax = gross.
+func CalculateTax(price int, rate int, priceIncludesTax bool) TaxAmount {
+	if priceIncludesTax {
+		net := divideRoundHalfUp(price*TaxRateBase, TaxRateBase+rate)
+		return TaxAmount{Rate: rate, Net: net, Tax: price - net, Gross: price}
+	}
+
+	tax := divideRoundHalfUp(price*rate, TaxRateBase)
+	return TaxAmount{Rate: rate, Net: price, Tax: tax, Gross: price + tax}
+}
+
+// divideRoundHalfUp divide non-negative numbers and round the result half up
+func divideRoundHalfUp(dividend int, divisor int) int {
+	return (2*dividend + divisor) / (2 * divisor)
+}
+
+// TaxClassPayload holds tax class payload representative
+type TaxClassPayload struct {
+	Name             string               `json:"name"`
+	PriceIncludesTax bool                 `json:"price_includes_tax"`
+	Rates            []TaxRatePayload     `json:"rates"`
+	Categories       []types.CategoryType `json:"categories"`
+	Tenant           types.TenantType     `json:"-"`
+}
+
+// TaxRatePayload holds tax rate payload representative
+type TaxRatePayload struct {
+	Region string `json:"region"`
+	Rate   int    `json:"rate"`
+}
+
+// SwaggerTaxClassPayload holds tax class payload for swagger docs
+// Do not remove this struct
+// Everytime you update the TaxClassPayload
+// you must adjust this struct for swagger docs
+type SwaggerTaxClassPayload struct {
+	Name             string           `json:"name" example:"standard"`
+	PriceIncludesTax bool             `json:"price_includes_tax"`
+	Rates            []TaxRatePayload `json:"rates"`
+	Categories       []string         `json:"categories" example:"book"`
+}
+
+// ToEntity to convert tax class payload to entity contract
+func (p *TaxClassPayload) ToEntity() *TaxClass {
+	rates := make([]*TaxRate, 0, len(p.Rates))
+	for _, rate := range p.Rates {
+		rates = append(rates, &TaxRate{
+			Region: strings.ToUpper(rate.Region),
+			Rate:   rate.Rate,
+		})
+	}
+
+	categories := p.Categories
+	if categories == nil {
+		categories = []types.CategoryType{}
+	}
+
+	return &TaxClass{
+		Tenant:           p.Tenant,
+		Name:             strings.TrimSpace(p.Name),
+		PriceIncludesTax: p.PriceIncludesTax,
+		Rates:            rates,
+		Categories:       categories,
+	}
+}
+
+// Validate is func to validate payload
+func (p *TaxClassPayload) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return response.ErrInvalidTaxClassName
+	}
+
+	if len(p.Rates) == 0 {
+		return response.ErrInvalidTaxRate
+	}
+
+	regions := make(map[string]bool, len(p.Rates))
+	for _, rate := range p.Rates {
+		region := strings.ToUpper(rate.Region)
+		if !regionPattern.MatchString(region) {
+			return response.ErrInvalidRegion
+		}
+
+		if regions[region] {
+			return response.ErrDuplicateTaxRateRegion
+		}
+		regions[region] = true
+
+		if rate.Rate < 0 || rate.Rate > MaxTaxRate {
+			return response.ErrInvalidTaxRate
+		}
+	}
+
+	for _, category := range p.Categories {
+		if category == types.CategoryEmptyType {
+			return response.ErrInvalidCategory
+		}
+	}
+
+	if p.Tenant == types.TenantEmptyType {
+		return response.ErrInvalidTenant
+	}
+
+	return nil
+}
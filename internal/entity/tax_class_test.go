@@ -0,0 +1,171 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCalculateTax documents the rounding rules of the tax computation:
+// rates are in basis points, the derived amount is rounded half up to the nearest unit,
+// and the tax is the difference between gross and net so the amounts always add up.
+func TestCalculateTax(t *testing.T) {
+	testcases := []struct {
+		name             string
+		price            int
+		rate             int
+		priceIncludesTax bool
+		wantNet          int
+		wantTax          int
+		wantGross        int
+	}{
+		{
+			name:      "zero rate",
+			price:     10000,
+			rate:      0,
+			wantNet:   10000,
+			wantTax:   0,
+			wantGross: 10000,
+		},
+		{
+			name:      "exact tax on net price",
+			price:     10000,
+			rate:      1100,
+			wantNet:   10000,
+			wantTax:   1100,
+			wantGross: 11100,
+		},
+		{
+			name:      "tax on net price is rounded down below half",
+			price:     104,
+			rate:      1100,
+			wantNet:   104,
+			wantTax:   11, // 11.44
+			wantGross: 115,
+		},
+		{
+			name:      "tax on net price is rounded up at exactly half",
+			price:     50,
+			rate:      1100,
+			wantNet:   50,
+			wantTax:   6, // 5.5
+			wantGross: 56,
+		},
+		{
+			name:      "tax on net price is rounded up above half",
+			price:     105,
+			rate:      1100,
+			wantNet:   105,
+			wantTax:   12, // 11.55
+			wantGross: 117,
+		},
+		{
+			name:             "exact net on gross price",
+			price:            11100,
+			rate:             1100,
+			priceIncludesTax: true,
+			wantNet:          10000,
+			wantTax:          1100,
+			wantGross:        11100,
+		},
+		{
+			name:             "net on gross price is rounded half up and tax takes the remainder",
+			price:            10000,
+			rate:             1100,
+			priceIncludesTax: true,
+			wantNet:          9009, // 9009.009
+			wantTax:          991,
+			wantGross:        10000,
+		},
+		{
+			name:             "net on gross price is rounded up at exactly half",
+			price:            21,
+			rate:             10000,
+			priceIncludesTax: true,
+			wantNet:          11, // 10.5
+			wantTax:          10,
+			wantGross:        21,
+		},
+		{
+			name:             "zero price",
+			price:            0,
+			rate:             1100,
+			priceIncludesTax: true,
+			wantNet:          0,
+			wantTax:          0,
+			wantGross:        0,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := entity.CalculateTax(tc.price, tc.rate, tc.priceIncludesTax)
+			assert.Equal(t, tc.rate, res.Rate)
+			assert.Equal(t, tc.wantNet, res.Net)
+			assert.Equal(t, tc.wantTax, res.Tax)
+			assert.Equal(t, tc.wantGross, res.Gross)
+			assert.Equal(t, res.Gross, res.Net+res.Tax)
+		})
+	}
+}
+
+func TestShowTax(t *testing.T) {
+	taxClass := &entity.TaxClass{
+		ID: 1,
+		Rates: []*entity.TaxRate{
+			{Region: "", Rate: 1000},
+			{Region: "ID", Rate: 1100},
+		},
+	}
+
+	testcases := []struct {
+		name           string
+		taxClass       *entity.TaxClass
+		region         string
+		wantTaxClassID bool
+		wantRate       int
+		wantGross      int
+	}{
+		{
+			name:      "without tax class",
+			region:    "ID",
+			wantRate:  0,
+			wantGross: 1000,
+		},
+		{
+			name:           "rate of the region",
+			taxClass:       taxClass,
+			region:         "ID",
+			wantTaxClassID: true,
+			wantRate:       1100,
+			wantGross:      1110,
+		},
+		{
+			name:           "default rate for region without rate",
+			taxClass:       taxClass,
+			region:         "SG",
+			wantTaxClassID: true,
+			wantRate:       1000,
+			wantGross:      1100,
+		},
+		{
+			name:      "no rate for the region",
+			taxClass:  &entity.TaxClass{ID: 2, Rates: []*entity.TaxRate{{Region: "ID", Rate: 1100}}},
+			region:    "SG",
+			wantRate:  0,
+			wantGross: 1000,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			product := &entity.Product{Price: 1000}
+			product.ShowTax(tc.taxClass, tc.region)
+			assert.Equal(t, tc.wantTaxClassID, product.Tax.TaxClassID != nil)
+			assert.Equal(t, tc.region, product.Tax.Region)
+			assert.Equal(t, tc.wantRate, product.Tax.Rate)
+			assert.Equal(t, tc.wantGross, product.Tax.Gross)
+		})
+	}
+}
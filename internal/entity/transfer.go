@@ -0,0 +1,108 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// Transfer struct holds entity of stock moved from a location to another. The qty of the items leaves
+// the source location when the transfer is dispatched and arrives on the destination when it is received
+type Transfer struct {
+	ID             int                      `json:"id"`
+	Tenant         types.TenantType         `json:"tenant"`
+	FromLocationID int                      `json:"from_location_id"`
+	ToLocationID   int                      `json:"to_location_id"`
+	Status         types.TransferStatusType `json:"status"`
+	ReferenceID    string                   `json:"reference_id"`
+	Items          []*TransferItem          `json:"items"`
+	DispatchedAt   *time.Time               `json:"dispatched_at"`
+	ReceivedAt     *time.Time               `json:"received_at"`
+	CreatedAt      time.Time                `json:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at"`
+}
+
+// ItemsByProductID return a copy of the items in the order their products are locked
+func (t *Transfer) ItemsByProductID() []*TransferItem {
+	items := make([]*TransferItem, len(t.Items))
+	copy(items, t.Items)
+	sortByProductID(items, func(i int) int { return items[i].ProductID })
+
+	return items
+}
+
+// TransferItem struct holds entity of the qty of a product moved by a transfer
+type TransferItem struct {
+	ID         int    `json:"-"`
+	TransferID int    `json:"-"`
+	ProductID  int    `json:"product_id"`
+	SKU        string `json:"sku"`
+	Qty        int    `json:"qty"`
+}
+
+// TransferPayload holds transfer payload representative
+type TransferPayload struct {
+	FromLocationID int                   `json:"from_location_id"`
+	ToLocationID   int                   `json:"to_location_id"`
+	Items          []TransferItemPayload `json:"items"`
+	ReferenceID    string                `json:"reference_id"`
+	Tenant         types.TenantType      `json:"-"`
+}
+
+// TransferItemPayload holds transfer item payload representative
+type TransferItemPayload struct {
+	SKU string `json:"sku"`
+	Qty int    `json:"qty"`
+}
+
+// ToEntity to convert transfer payload to entity contract
+func (p *TransferPayload) ToEntity() *Transfer {
+	items := make([]*TransferItem, 0, len(p.Items))
+	for _, item := range p.Items {
+		items = append(items, &TransferItem{
+			SKU: strings.TrimSpace(item.SKU),
+			Qty: item.Qty,
+		})
+	}
+
+	return &Transfer{
+		Tenant:         p.Tenant,
+		FromLocationID: p.FromLocationID,
+		ToLocationID:   p.ToLocationID,
+		Status:         types.TransferStatusDraftType,
+		ReferenceID:    p.ReferenceID,
+		Items:          items,
+	}
+}
+
+// Validate is func to validate payload
+func (p *TransferPayload) Validate() error {
+	if p.FromLocationID == p.ToLocationID {
+		return response.ErrInvalidLocation
+	}
+
+	if len(p.Items) == 0 {
+		return response.ErrInvalidTransferItems
+	}
+
+	skus := make(map[string]bool, len(p.Items))
+	for _, item := range p.Items {
+		sku := strings.TrimSpace(item.SKU)
+		if sku == "" || skus[sku] {
+			return response.ErrInvalidTransferItems
+		}
+		skus[sku] = true
+
+		if item.Qty <= 0 {
+			return response.ErrInvalidQty
+		}
+	}
+
+	if p.Tenant == types.TenantEmptyType {
+		return response.ErrInvalidTenant
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferPayloadValidate(t *testing.T) {
+	items := []entity.TransferItemPayload{{SKU: "SKU-1", Qty: 1}, {SKU: "SKU-2", Qty: 5}}
+
+	testcases := []struct {
+		name    string
+		payload *entity.TransferPayload
+		wantErr error
+	}{
+		{
+			name:    "same location",
+			payload: &entity.TransferPayload{FromLocationID: 1, ToLocationID: 1, Items: items, Tenant: types.TenantLoremType},
+			wantErr: response.ErrInvalidLocation,
+		},
+		{
+			name:    "without items",
+			payload: &entity.TransferPayload{FromLocationID: 1, ToLocationID: 2, Tenant: types.TenantLoremType},
+			wantErr: response.ErrInvalidTransferItems,
+		},
+		{
+			name:    "blank sku",
+			payload: &entity.TransferPayload{FromLocationID: 1, ToLocationID: 2, Items: []entity.TransferItemPayload{{SKU: " ", Qty: 1}}, Tenant: types.TenantLoremType},
+			wantErr: response.ErrInvalidTransferItems,
+		},
+		{
+			name:    "sku transferred twice",
+			payload: &entity.TransferPayload{FromLocationID: 1, ToLocationID: 2, Items: []entity.TransferItemPayload{{SKU: "SKU-1", Qty: 1}, {SKU: " SKU-1", Qty: 2}}, Tenant: types.TenantLoremType},
+			wantErr: response.ErrInvalidTransferItems,
+		},
+		{
+			name:    "zero qty",
+			payload: &entity.TransferPayload{FromLocationID: 1, ToLocationID: 2, Items: []entity.TransferItemPayload{{SKU: "SKU-1"}}, Tenant: types.TenantLoremType},
+			wantErr: response.ErrInvalidQty,
+		},
+		{
+			name:    "invalid tenant",
+			payload: &entity.TransferPayload{FromLocationID: 1, ToLocationID: 2, Items: items},
+			wantErr: response.ErrInvalidTenant,
+		},
+		{
+			name:    "valid",
+			payload: &entity.TransferPayload{FromLocationID: 1, ToLocationID: 2, Items: items, Tenant: types.TenantLoremType},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantErr, tc.payload.Validate())
+		})
+	}
+}
+
+func TestTransferPayloadToEntity(t *testing.T) {
+	payload := &entity.TransferPayload{FromLocationID: 1, ToLocationID: 2, Items: []entity.TransferItemPayload{{SKU: " SKU-1 ", Qty: 3}}, ReferenceID: "TRF-1", Tenant: types.TenantLoremType}
+
+	transfer := payload.ToEntity()
+	assert.Equal(t, types.TenantLoremType, transfer.Tenant)
+	assert.Equal(t, 1, transfer.FromLocationID)
+	assert.Equal(t, 2, transfer.ToLocationID)
+	assert.Equal(t, types.TransferStatusDraftType, transfer.Status)
+	assert.Equal(t, "TRF-1", transfer.ReferenceID)
+	assert.Equal(t, []*entity.TransferItem{{SKU: "SKU-1", Qty: 3}}, transfer.Items)
+}
+
+func TestTransferItemsByProductID(t *testing.T) {
+	transfer := &entity.Transfer{Items: []*entity.TransferItem{{ProductID: 3}, {ProductID: 1}, {ProductID: 2}}}
+
+	items := transfer.ItemsByProductID()
+	assert.Equal(t, []*entity.TransferItem{{ProductID: 1}, {ProductID: 2}, {ProductID: 3}}, items)
+	assert.Equal(t, 3, transfer.Items[0].ProductID)
+}
@@ -0,0 +1,65 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// AllocationStrategyType represent stock allocation strategy
+type AllocationStrategyType int8
+
+// AllocationStrategy(*)Type represent stock allocation strategy enum
+const (
+	AllocationStrategyEmptyType AllocationStrategyType = iota
+	AllocationStrategyPriorityType
+	AllocationStrategyMostStockType
+	AllocationStrategySingleLocationType
+)
+
+var (
+	AllocationStrategyTypeNameToValue = map[string]AllocationStrategyType{
+		"priority":        AllocationStrategyPriorityType,
+		"most_stock":      AllocationStrategyMostStockType,
+		"single_location": AllocationStrategySingleLocationType,
+	}
+
+	_AllocationStrategyTypeValueToName = map[AllocationStrategyType]string{
+		AllocationStrategyPriorityType:       "priority",
+		AllocationStrategyMostStockType:      "most_stock",
+		AllocationStrategySingleLocationType: "single_location",
+	}
+)
+
+// Scan is used for Scan
+func (t *AllocationStrategyType) Scan(value interface{}) error {
+	val := AllocationStrategyType(value.(int64))
+	if val == 0 || int(value.(int64)) > len(AllocationStrategyTypeNameToValue) {
+		return errInvalidEnum("allocation_strategy", fmt.Sprint(value.(int64)))
+	}
+
+	*t = val
+	return nil
+}
+
+// MarshalJSON defined so that AllocationStrategyType satisfies json.Marshaler
+func (t AllocationStrategyType) MarshalJSON() ([]byte, error) {
+	s, ok := _AllocationStrategyTypeValueToName[t]
+	if !ok {
+		return nil, errInvalidEnum("allocation_strategy", fmt.Sprint(t))
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON defined so that AllocationStrategyType satisfies json.Unmarshaler
+func (r *AllocationStrategyType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("AllocationStrategyType should be a string, got %s", data)
+	}
+	v, ok := AllocationStrategyTypeNameToValue[s]
+	if !ok {
+		return errInvalidValue("allocation_strategy", s)
+	}
+	*r = v
+	return nil
+}
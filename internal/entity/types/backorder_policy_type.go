@@ -0,0 +1,65 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// BackorderPolicyType represent backorder policy of product
+type BackorderPolicyType int8
+
+// BackorderPolicy(*)Type represent backorder policy of product enum
+const (
+	BackorderPolicyEmptyType BackorderPolicyType = iota
+	BackorderPolicyDenyType
+	BackorderPolicyLimitedType
+	BackorderPolicyUnlimitedType
+)
+
+var (
+	BackorderPolicyTypeNameToValue = map[string]BackorderPolicyType{
+		"deny":      BackorderPolicyDenyType,
+		"limited":   BackorderPolicyLimitedType,
+		"unlimited": BackorderPolicyUnlimitedType,
+	}
+
+	_BackorderPolicyTypeValueToName = map[BackorderPolicyType]string{
+		BackorderPolicyDenyType:      "deny",
+		BackorderPolicyLimitedType:   "limited",
+		BackorderPolicyUnlimitedType: "unlimited",
+	}
+)
+
+// Scan is used for Scan
+func (t *BackorderPolicyType) Scan(value interface{}) error {
+	val := BackorderPolicyType(value.(int64))
+	if val == 0 || int(value.(int64)) > len(BackorderPolicyTypeNameToValue) {
+		return errInvalidEnum("backorder_policy", fmt.Sprint(value.(int64)))
+	}
+
+	*t = val
+	return nil
+}
+
+// MarshalJSON defined so that BackorderPolicyType satisfies json.Marshaler
+func (t BackorderPolicyType) MarshalJSON() ([]byte, error) {
+	s, ok := _BackorderPolicyTypeValueToName[t]
+	if !ok {
+		return nil, errInvalidEnum("backorder_policy", fmt.Sprint(t))
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON defined so that BackorderPolicyType satisfies json.Unmarshaler
+func (r *BackorderPolicyType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("BackorderPolicyType should be a string, got %s", data)
+	}
+	v, ok := BackorderPolicyTypeNameToValue[s]
+	if !ok {
+		return errInvalidValue("backorder_policy", s)
+	}
+	*r = v
+	return nil
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// BulkItemStatusType represent bulk item status type
+type BulkItemStatusType int8
+
+// BulkItemStatus(*)Type represent bulk item status type enum
+const (
+	BulkItemStatusEmptyType BulkItemStatusType = iota
+	BulkItemStatusAppliedType
+	BulkItemStatusFailedType
+)
+
+var (
+	BulkItemStatusTypeNameToValue = map[string]BulkItemStatusType{
+		"applied": BulkItemStatusAppliedType,
+		"failed":  BulkItemStatusFailedType,
+	}
+
+	_BulkItemStatusTypeValueToName = map[BulkItemStatusType]string{
+		BulkItemStatusAppliedType: "applied",
+		BulkItemStatusFailedType:  "failed",
+	}
+)
+
+// Scan is used for Scan
+func (t *BulkItemStatusType) Scan(value interface{}) error {
+	val := BulkItemStatusType(value.(int64))
+	if val == 0 || int(value.(int64)) > len(BulkItemStatusTypeNameToValue) {
+		return errInvalidEnum("bulk_item_status", fmt.Sprint(value.(int64)))
+	}
+
+	*t = val
+	return nil
+}
+
+// MarshalJSON defined so that BulkItemStatusType satisfies json.Marshaler
+func (t BulkItemStatusType) MarshalJSON() ([]byte, error) {
+	s, ok := _BulkItemStatusTypeValueToName[t]
+	if !ok {
+		return nil, errInvalidEnum("bulk_item_status", fmt.Sprint(t))
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON defined so that BulkItemStatusType satisfies json.Unmarshaler
+func (r *BulkItemStatusType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("BulkItemStatusType should be a string, got %s", data)
+	}
+	v, ok := BulkItemStatusTypeNameToValue[s]
+	if !ok {
+		return errInvalidValue("bulk_item_status", s)
+	}
+	*r = v
+	return nil
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// BulkModeType represent bulk mode type
+type BulkModeType int8
+
+// BulkMode(*)Type represent bulk mode type enum
+const (
+	BulkModeEmptyType BulkModeType = iota
+	BulkModeAtomicType
+	BulkModePartialType
+)
+
+var (
+	BulkModeTypeNameToValue = map[string]BulkModeType{
+		"atomic":  BulkModeAtomicType,
+		"partial": BulkModePartialType,
+	}
+
+	_BulkModeTypeValueToName = map[BulkModeType]string{
+		BulkModeAtomicType:  "atomic",
+		BulkModePartialType: "partial",
+	}
+)
+
+// Scan is used for Scan
+func (t *BulkModeType) Scan(value interface{}) error {
+	val := BulkModeType(value.(int64))
+	if val == 0 || int(value.(int64)) > len(BulkModeTypeNameToValue) {
+		return errInvalidEnum("bulk_mode", fmt.Sprint(value.(int64)))
+	}
+
+	*t = val
+	return nil
+}
+
+// MarshalJSON defined so that BulkModeType satisfies json.Marshaler
+func (t BulkModeType) MarshalJSON() ([]byte, error) {
+	s, ok := _BulkModeTypeValueToName[t]
+	if !ok {
+		return nil, errInvalidEnum("bulk_mode", fmt.Sprint(t))
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON defined so that BulkModeType satisfies json.Unmarshaler
+func (r *BulkModeType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("BulkModeType should be a string, got %s", data)
+	}
+	v, ok := BulkModeTypeNameToValue[s]
+	if !ok {
+		return errInvalidValue("bulk_mode", s)
+	}
+	*r = v
+	return nil
+}
@@ -0,0 +1,65 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CycleCountStatusType represent cycle count status type
+type CycleCountStatusType int8
+
+// CycleCountStatus(*)Type represent cycle count status type enum
+const (
+	CycleCountStatusEmptyType CycleCountStatusType = iota
+	CycleCountStatusPendingType
+	CycleCountStatusApprovedType
+	CycleCountStatusRejectedType
+)
+
+var (
+	CycleCountStatusTypeNameToValue = map[string]CycleCountStatusType{
+		"pending":  CycleCountStatusPendingType,
+		"approved": CycleCountStatusApprovedType,
+		"rejected": CycleCountStatusRejectedType,
+	}
+
+	_CycleCountStatusTypeValueToName = map[CycleCountStatusType]string{
+		CycleCountStatusPendingType:  "pending",
+		CycleCountStatusApprovedType: "approved",
+		CycleCountStatusRejectedType: "rejected",
+	}
+)
+
+// Scan is used for Scan
+func (t *CycleCountStatusType) Scan(value interface{}) error {
+	val := CycleCountStatusType(value.(int64))
+	if val == 0 || int(value.(int64)) > len(CycleCountStatusTypeNameToValue) {
+		return errInvalidEnum("cycle_count_status", fmt.Sprint(value.(int64)))
+	}
+
+	*t = val
+	return nil
+}
+
+// MarshalJSON defined so that CycleCountStatusType satisfies json.Marshaler
+func (t CycleCountStatusType) MarshalJSON() ([]byte, error) {
+	s, ok := _CycleCountStatusTypeValueToName[t]
+	if !ok {
+		return nil, errInvalidEnum("cycle_count_status", fmt.Sprint(t))
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON defined so that CycleCountStatusType satisfies json.Unmarshaler
+func (r *CycleCountStatusType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("CycleCountStatusType should be a string, got %s", data)
+	}
+	v, ok := CycleCountStatusTypeNameToValue[s]
+	if !ok {
+		return errInvalidValue("cycle_count_status", s)
+	}
+	*r = v
+	return nil
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DiscountType represent discount type
+type DiscountType int8
+
+// Discount(*)Type represent discount type enum
+const (
+	DiscountEmptyType DiscountType = iota
+	DiscountPercentageType
+	DiscountFixedAmountType
+)
+
+var (
+	DiscountTypeNameToValue = map[string]DiscountType{
+		"percentage":   DiscountPercentageType,
+		"fixed_amount": DiscountFixedAmountType,
+	}
+
+	_DiscountTypeValueToName = map[DiscountType]string{
+		DiscountPercentageType:  "percentage",
+		DiscountFixedAmountType: "fixed_amount",
+	}
+)
+
+// Scan is used for Scan
+func (t *DiscountType) Scan(value interface{}) error {
+	val := DiscountType(value.(int64))
+	if val == 0 || int(value.(int64)) > len(DiscountTypeNameToValue) {
+		return errInvalidEnum("discount_type", fmt.Sprint(value.(int64)))
+	}
+
+	*t = val
+	return nil
+}
+
+// MarshalJSON defined so that DiscountType satisfies json.Marshaler
+func (t DiscountType) MarshalJSON() ([]byte, error) {
+	s, ok := _DiscountTypeValueToName[t]
+	if !ok {
+		return nil, errInvalidEnum("discount_type", fmt.Sprint(t))
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON defined so that DiscountType satisfies json.Unmarshaler
+func (r *DiscountType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("DiscountType should be a string, got %s", data)
+	}
+	v, ok := DiscountTypeNameToValue[s]
+	if !ok {
+		return errInvalidValue("discount_type", s)
+	}
+	*r = v
+	return nil
+}
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// PriceScheduleStatusType represent price schedule status type
+type PriceScheduleStatusType int8
+
+// PriceScheduleStatus(*)Type represent price schedule status type enum
+const (
+	PriceScheduleStatusEmptyType PriceScheduleStatusType = iota
+	PriceScheduleStatusPendingType
+	PriceScheduleStatusActiveType
+	PriceScheduleStatusCompletedType
+	PriceScheduleStatusCancelledType
+)
+
+var (
+	PriceScheduleStatusTypeNameToValue = map[string]PriceScheduleStatusType{
+		"pending":   PriceScheduleStatusPendingType,
+		"active":    PriceScheduleStatusActiveType,
+		"completed": PriceScheduleStatusCompletedType,
+		"cancelled": PriceScheduleStatusCancelledType,
+	}
+
+	_PriceScheduleStatusTypeValueToName = map[PriceScheduleStatusType]string{
+		PriceScheduleStatusPendingType:   "pending",
+		PriceScheduleStatusActiveType:    "active",
+		PriceScheduleStatusCompletedType: "completed",
+		PriceScheduleStatusCancelledType: "cancelled",
+	}
+)
+
+// Scan is used for Scan
+func (t *PriceScheduleStatusType) Scan(value interface{}) error {
+	val := PriceScheduleStatusType(value.(int64))
+	if val == 0 || int(value.(int64)) > len(PriceScheduleStatusTypeNameToValue) {
+		return errInvalidEnum("price_schedule_status_type", fmt.Sprint(value.(int64)))
+	}
+
+	*t = val
+	return nil
+}
+
+// MarshalJSON defined so that PriceScheduleStatusType satisfies json.Marshaler
+func (t PriceScheduleStatusType) MarshalJSON() ([]byte, error) {
+	s, ok := _PriceScheduleStatusTypeValueToName[t]
+	if !ok {
+		return nil, errInvalidEnum("price_schedule_status_type", fmt.Sprint(t))
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON defined so that PriceScheduleStatusType satisfies json.Unmarshaler
+func (r *PriceScheduleStatusType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("PriceScheduleStatusType should be a string, got %s", data)
+	}
+	v, ok := PriceScheduleStatusTypeNameToValue[s]
+	if !ok {
+		return errInvalidValue("price_schedule_status_type", s)
+	}
+	*r = v
+	return nil
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// PriceScheduleType represent price schedule type
+type PriceScheduleType int8
+
+// PriceSchedule(*)Type represent price schedule type enum
+const (
+	PriceScheduleEmptyType PriceScheduleType = iota
+	PriceSchedulePriceChangeType
+	PriceSchedulePromotionType
+)
+
+var (
+	PriceScheduleTypeNameToValue = map[string]PriceScheduleType{
+		"price_change": PriceSchedulePriceChangeType,
+		"promotion":    PriceSchedulePromotionType,
+	}
+
+	_PriceScheduleTypeValueToName = map[PriceScheduleType]string{
+		PriceSchedulePriceChangeType: "price_change",
+		PriceSchedulePromotionType:   "promotion",
+	}
+)
+
+// Scan is used for Scan
+func (t *PriceScheduleType) Scan(value interface{}) error {
+	val := PriceScheduleType(value.(int64))
+	if val == 0 || int(value.(int64)) > len(PriceScheduleTypeNameToValue) {
+		return errInvalidEnum("price_schedule_type", fmt.Sprint(value.(int64)))
+	}
+
+	*t = val
+	return nil
+}
+
+// MarshalJSON defined so that PriceScheduleType satisfies json.Marshaler
+func (t PriceScheduleType) MarshalJSON() ([]byte, error) {
+	s, ok := _PriceScheduleTypeValueToName[t]
+	if !ok {
+		return nil, errInvalidEnum("price_schedule_type", fmt.Sprint(t))
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON defined so that PriceScheduleType satisfies json.Unmarshaler
+func (r *PriceScheduleType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("PriceScheduleType should be a string, got %s", data)
+	}
+	v, ok := PriceScheduleTypeNameToValue[s]
+	if !ok {
+		return errInvalidValue("price_schedule_type", s)
+	}
+	*r = v
+	return nil
+}
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ReservationStatusType represent reservation status type
+type ReservationStatusType int8
+
+// ReservationStatus(*)Type represent reservation status type enum
+const (
+	ReservationStatusEmptyType ReservationStatusType = iota
+	ReservationStatusActiveType
+	ReservationStatusConfirmedType
+	ReservationStatusReleasedType
+	ReservationStatusExpiredType
+)
+
+var (
+	ReservationStatusTypeNameToValue = map[string]ReservationStatusType{
+		"active":    ReservationStatusActiveType,
+		"confirmed": ReservationStatusConfirmedType,
+		"released":  ReservationStatusReleasedType,
+		"expired":   ReservationStatusExpiredType,
+	}
+
+	_ReservationStatusTypeValueToName = map[ReservationStatusType]string{
+		ReservationStatusActiveType:    "active",
+		ReservationStatusConfirmedType: "confirmed",
+		ReservationStatusReleasedType:  "released",
+		ReservationStatusExpiredType:   "expired",
+	}
+)
+
+// Scan is used for Scan
+func (t *ReservationStatusType) Scan(value interface{}) error {
+	val := ReservationStatusType(value.(int64))
+	if val == 0 || int(value.(int64)) > len(ReservationStatusTypeNameToValue) {
+		return errInvalidEnum("reservation_status", fmt.Sprint(value.(int64)))
+	}
+
+	*t = val
+	return nil
+}
+
+// MarshalJSON defined so that ReservationStatusType satisfies json.Marshaler
+func (t ReservationStatusType) MarshalJSON() ([]byte, error) {
+	s, ok := _ReservationStatusTypeValueToName[t]
+	if !ok {
+		return nil, errInvalidEnum("reservation_status", fmt.Sprint(t))
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON defined so that ReservationStatusType satisfies json.Unmarshaler
+func (r *ReservationStatusType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("ReservationStatusType should be a string, got %s", data)
+	}
+	v, ok := ReservationStatusTypeNameToValue[s]
+	if !ok {
+		return errInvalidValue("reservation_status", s)
+	}
+	*r = v
+	return nil
+}
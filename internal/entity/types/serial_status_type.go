@@ -0,0 +1,65 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SerialStatusType represent serial status type
+type SerialStatusType int8
+
+// SerialStatus(*)Type represent serial status type enum
+const (
+	SerialStatusEmptyType SerialStatusType = iota
+	SerialStatusInStockType
+	SerialStatusSoldType
+	SerialStatusWrittenOffType
+)
+
+var (
+	SerialStatusTypeNameToValue = map[string]SerialStatusType{
+		"in_stock":    SerialStatusInStockType,
+		"sold":        SerialStatusSoldType,
+		"written_off": SerialStatusWrittenOffType,
+	}
+
+	_SerialStatusTypeValueToName = map[SerialStatusType]string{
+		SerialStatusInStockType:    "in_stock",
+		SerialStatusSoldType:       "sold",
+		SerialStatusWrittenOffType: "written_off",
+	}
+)
+
+// Scan is used for Scan
+func (t *SerialStatusType) Scan(value interface{}) error {
+	val := SerialStatusType(value.(int64))
+	if val == 0 || int(value.(int64)) > len(SerialStatusTypeNameToValue) {
+		return errInvalidEnum("serial_status", fmt.Sprint(value.(int64)))
+	}
+
+	*t = val
+	return nil
+}
+
+// MarshalJSON defined so that SerialStatusType satisfies json.Marshaler
+func (t SerialStatusType) MarshalJSON() ([]byte, error) {
+	s, ok := _SerialStatusTypeValueToName[t]
+	if !ok {
+		return nil, errInvalidEnum("serial_status", fmt.Sprint(t))
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON defined so that SerialStatusType satisfies json.Unmarshaler
+func (r *SerialStatusType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("SerialStatusType should be a string, got %s", data)
+	}
+	v, ok := SerialStatusTypeNameToValue[s]
+	if !ok {
+		return errInvalidValue("serial_status", s)
+	}
+	*r = v
+	return nil
+}
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// StockMovementReasonType represent stock movement reason type
+type StockMovementReasonType int8
+
+// StockMovementReason(*)Type represent stock movement reason type enum
+const (
+	StockMovementReasonEmptyType StockMovementReasonType = iota
+	StockMovementReasonSaleType
+	StockMovementReasonRestockType
+	StockMovementReasonAdjustmentType
+	StockMovementReasonReturnType
+	StockMovementReasonDamageType
+	StockMovementReasonTransferType
+)
+
+var (
+	StockMovementReasonTypeNameToValue = map[string]StockMovementReasonType{
+		"sale":       StockMovementReasonSaleType,
+		"restock":    StockMovementReasonRestockType,
+		"adjustment": StockMovementReasonAdjustmentType,
+		"return":     StockMovementReasonReturnType,
+		"damage":     StockMovementReasonDamageType,
+		"transfer":   StockMovementReasonTransferType,
+	}
+
+	_StockMovementReasonTypeValueToName = map[StockMovementReasonType]string{
+		StockMovementReasonSaleType:       "sale",
+		StockMovementReasonRestockType:    "restock",
+		StockMovementReasonAdjustmentType: "adjustment",
+		StockMovementReasonReturnType:     "return",
+		StockMovementReasonDamageType:     "damage",
+		StockMovementReasonTransferType:   "transfer",
+	}
+)
+
+// Scan is used for Scan
+func (t *StockMovementReasonType) Scan(value interface{}) error {
+	val := StockMovementReasonType(value.(int64))
+	if val == 0 || int(value.(int64)) > len(StockMovementReasonTypeNameToValue) {
+		return errInvalidEnum("stock_movement_reason", fmt.Sprint(value.(int64)))
+	}
+
+	*t = val
+	return nil
+}
+
+// MarshalJSON defined so that StockMovementReasonType satisfies json.Marshaler
+func (t StockMovementReasonType) MarshalJSON() ([]byte, error) {
+	s, ok := _StockMovementReasonTypeValueToName[t]
+	if !ok {
+		return nil, errInvalidEnum("stock_movement_reason", fmt.Sprint(t))
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON defined so that StockMovementReasonType satisfies json.Unmarshaler
+func (r *StockMovementReasonType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("StockMovementReasonType should be a string, got %s", data)
+	}
+	v, ok := StockMovementReasonTypeNameToValue[s]
+	if !ok {
+		return errInvalidValue("stock_movement_reason", s)
+	}
+	*r = v
+	return nil
+}
@@ -0,0 +1,65 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TransferStatusType represent transfer status type
+type TransferStatusType int8
+
+// TransferStatus(*)Type represent transfer status type enum
+const (
+	TransferStatusEmptyType TransferStatusType = iota
+	TransferStatusDraftType
+	TransferStatusInTransitType
+	TransferStatusReceivedType
+)
+
+var (
+	TransferStatusTypeNameToValue = map[string]TransferStatusType{
+		"draft":      TransferStatusDraftType,
+		"in_transit": TransferStatusInTransitType,
+		"received":   TransferStatusReceivedType,
+	}
+
+	_TransferStatusTypeValueToName = map[TransferStatusType]string{
+		TransferStatusDraftType:     "draft",
+		TransferStatusInTransitType: "in_transit",
+		TransferStatusReceivedType:  "received",
+	}
+)
+
+// Scan is used for Scan
+func (t *TransferStatusType) Scan(value interface{}) error {
+	val := TransferStatusType(value.(int64))
+	if val == 0 || int(value.(int64)) > len(TransferStatusTypeNameToValue) {
+		return errInvalidEnum("transfer_status", fmt.Sprint(value.(int64)))
+	}
+
+	*t = val
+	return nil
+}
+
+// MarshalJSON defined so that TransferStatusType satisfies json.Marshaler
+func (t TransferStatusType) MarshalJSON() ([]byte, error) {
+	s, ok := _TransferStatusTypeValueToName[t]
+	if !ok {
+		return nil, errInvalidEnum("transfer_status", fmt.Sprint(t))
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON defined so that TransferStatusType satisfies json.Unmarshaler
+func (r *TransferStatusType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("TransferStatusType should be a string, got %s", data)
+	}
+	v, ok := TransferStatusTypeNameToValue[s]
+	if !ok {
+		return errInvalidValue("transfer_status", s)
+	}
+	*r = v
+	return nil
+}
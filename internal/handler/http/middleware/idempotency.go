@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/pkg/logger"
+)
+
+// IdempotentReplayedHeader is set on the responses replayed from an idempotency key
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// replayedHeaders list the headers of the response which are stored and replayed with its body
+var replayedHeaders = []string{"Content-Type", "ETag", "Location"}
+
+// responseRecorder keep a copy of the response body so that it can be stored
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency store the response of a request sent with an Idempotency-Key header and replay it
+// for the retries of the same request. A retry with the same key but another method, path or body is rejected.
+// Server errors and panics are not stored so that the retry is processed again
+func Idempotency(l logger.LoggerInterface, uc usecase.IdempotencyKeyUsecaseInterface) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		functionName := "middleware.Idempotency"
+
+		keyValue := helper.GetIdempotencyKey(c)
+		if keyValue == "" {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			err = errors.Wrap(fmt.Errorf("io.ReadAll: %w", err), functionName)
+			l.Error(err)
+			response.Error(c, err)
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		key := &entity.IdempotencyKey{
+			Tenant:      helper.GetTenant(c),
+			Key:         keyValue,
+			RequestHash: hashRequest(c.Request, body),
+		}
+
+		storedKey, err := uc.BeginIdempotentRequest(c.Request.Context(), key)
+		if err != nil {
+			if customErr, ok := err.(response.CustomError); ok {
+				response.Error(c, customErr)
+				c.Abort()
+				return
+			}
+
+			err = errors.Wrap(fmt.Errorf("uc.BeginIdempotentRequest: %w", err), functionName)
+			l.Error(err)
+			response.Error(c, err)
+			c.Abort()
+			return
+		}
+
+		if storedKey != nil {
+			contentType := gin.MIMEJSON + "; charset=utf-8"
+			for name, value := range storedKey.ResponseHeaders {
+				if name == "Content-Type" {
+					contentType = value
+					continue
+				}
+				c.Header(name, value)
+			}
+			c.Header(IdempotentReplayedHeader, "true")
+			c.Data(storedKey.StatusCode, contentType, storedKey.ResponseBody)
+			c.Abort()
+			return
+		}
+
+		// The request is cancelled before its key can be taken over by a retry,
+		// so its transactions are rolled back instead of being committed with the ones of the retry
+		ctx, cancel := context.WithDeadline(c.Request.Context(), key.InProgressUntil)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		// The key is stored or released even when the handler panics, otherwise the retries
+		// are rejected as in progress until the key is taken over
+		defer func() {
+			recovered := recover()
+
+			// The client may already give up waiting, the outcome is still kept for its retry
+			ctx := context.Background()
+			if recovered != nil || recorder.Status() >= http.StatusInternalServerError {
+				if err := uc.AbortIdempotentRequest(ctx, key); err != nil {
+					l.Error(errors.Wrap(fmt.Errorf("uc.AbortIdempotentRequest: %w", err), functionName))
+				}
+
+				// The panic is still handled by the recovery middleware
+				if recovered != nil {
+					panic(recovered)
+				}
+				return
+			}
+
+			key.StatusCode = recorder.Status()
+			key.ResponseHeaders = storedHeaders(recorder.Header())
+			key.ResponseBody = recorder.body.Bytes()
+			if err := uc.CompleteIdempotentRequest(ctx, key); err != nil {
+				l.Error(errors.Wrap(fmt.Errorf("uc.CompleteIdempotentRequest: %w", err), functionName))
+			}
+		}()
+
+		c.Next()
+	}
+}
+
+// storedHeaders pick the replayed headers which are set on the response
+func storedHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string)
+	for _, name := range replayedHeaders {
+		if value := header.Get(name); value != "" {
+			headers[name] = value
+		}
+	}
+
+	return headers
+}
+
+// hashRequest identify the request by its method, path and body
+func hashRequest(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
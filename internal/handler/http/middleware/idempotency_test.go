@@ -0,0 +1,173 @@
+package middleware_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/handler/http/middleware"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIdempotency(t *testing.T) {
+	testcases := []struct {
+		name              string
+		key               string
+		uBeginRes         *entity.IdempotencyKey
+		uBeginErr         error
+		handlerStatusCode int
+		handlerPanics     bool
+		httpStatusCodeRes int
+		wantBody          string
+		wantHeaders       map[string]string
+		wantHandlerCalled bool
+		wantCompleted     bool
+		wantAborted       bool
+	}{
+		{
+			name:              "without key",
+			handlerStatusCode: http.StatusOK,
+			httpStatusCodeRes: http.StatusOK,
+			wantBody:          `{"qty":1}`,
+			wantHandlerCalled: true,
+		},
+		{
+			name:              "key is used by another request",
+			key:               "key",
+			uBeginErr:         response.ErrIdempotencyKeyMismatch,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to begin idempotent request",
+			key:               "key",
+			uBeginErr:         errors.New("error begin idempotent request"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "retry",
+			key:               "key",
+			uBeginRes:         &entity.IdempotencyKey{StatusCode: http.StatusOK, ResponseBody: []byte(`{"qty":0}`)},
+			httpStatusCodeRes: http.StatusOK,
+			wantBody:          `{"qty":0}`,
+			wantHeaders:       map[string]string{"Content-Type": "application/json; charset=utf-8"},
+		},
+		{
+			name:              "retry replays the stored headers",
+			key:               "key",
+			uBeginRes:         &entity.IdempotencyKey{StatusCode: http.StatusCreated, ResponseHeaders: map[string]string{"Content-Type": "application/json", "ETag": `"1"`, "Location": "/products/1"}, ResponseBody: []byte(`{"qty":0}`)},
+			httpStatusCodeRes: http.StatusCreated,
+			wantBody:          `{"qty":0}`,
+			wantHeaders:       map[string]string{"Content-Type": "application/json", "ETag": `"1"`, "Location": "/products/1"},
+		},
+		{
+			name:              "first request",
+			key:               "key",
+			handlerStatusCode: http.StatusOK,
+			httpStatusCodeRes: http.StatusOK,
+			wantBody:          `{"qty":1}`,
+			wantHandlerCalled: true,
+			wantCompleted:     true,
+		},
+		{
+			name:              "first request failed on the server",
+			key:               "key",
+			handlerStatusCode: http.StatusInternalServerError,
+			httpStatusCodeRes: http.StatusInternalServerError,
+			wantBody:          `{"qty":1}`,
+			wantHandlerCalled: true,
+			wantAborted:       true,
+		},
+		{
+			name:              "first request panics",
+			key:               "key",
+			handlerPanics:     true,
+			httpStatusCodeRes: http.StatusInternalServerError,
+			wantHandlerCalled: true,
+			wantAborted:       true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			inProgressUntil := time.Now().Add(time.Minute)
+			idempotencyKeyUsecase := &testmock.IdempotencyKeyUsecaseInterface{}
+			idempotencyKeyUsecase.On("BeginIdempotentRequest", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				args.Get(1).(*entity.IdempotencyKey).InProgressUntil = inProgressUntil
+			}).Return(tc.uBeginRes, tc.uBeginErr)
+			idempotencyKeyUsecase.On("CompleteIdempotentRequest", mock.Anything, mock.Anything).Return(nil)
+			idempotencyKeyUsecase.On("AbortIdempotentRequest", mock.Anything, mock.Anything).Return(nil)
+
+			handlerCalled := false
+			var deadline time.Time
+			var hasDeadline bool
+			router := gin.New()
+			router.Use(gin.RecoveryWithWriter(io.Discard))
+			router.POST("/products", middleware.Idempotency(l, idempotencyKeyUsecase), func(c *gin.Context) {
+				handlerCalled = true
+				deadline, hasDeadline = c.Request.Context().Deadline()
+
+				body, _ := c.GetRawData()
+				assert.Equal(t, `{"title":"book"}`, string(body))
+
+				if tc.handlerPanics {
+					panic("handler panics")
+				}
+
+				c.Header("ETag", `"1"`)
+				c.Data(tc.handlerStatusCode, gin.MIMEJSON, []byte(`{"qty":1}`))
+			})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/products", strings.NewReader(`{"title":"book"}`))
+			req.Header.Set("X-Tenant", "lorem")
+			req.Header.Set("Idempotency-Key", tc.key)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+			assert.Equal(t, tc.wantHandlerCalled, handlerCalled)
+			if handlerCalled && tc.key != "" {
+				// The first request is cancelled before its key can be taken over
+				assert.True(t, hasDeadline)
+				assert.True(t, inProgressUntil.Equal(deadline))
+			} else {
+				assert.False(t, hasDeadline)
+			}
+			if tc.wantBody != "" {
+				assert.Equal(t, tc.wantBody, w.Body.String())
+			}
+			for name, value := range tc.wantHeaders {
+				assert.Equal(t, value, w.Header().Get(name))
+			}
+			if tc.uBeginRes != nil {
+				assert.Equal(t, "true", w.Header().Get(middleware.IdempotentReplayedHeader))
+			}
+
+			if tc.wantCompleted {
+				idempotencyKeyUsecase.AssertCalled(t, "CompleteIdempotentRequest", mock.Anything, mock.MatchedBy(func(key *entity.IdempotencyKey) bool {
+					return key.Key == tc.key && key.StatusCode == tc.handlerStatusCode && string(key.ResponseBody) == `{"qty":1}` && key.RequestHash != "" &&
+						key.ResponseHeaders["ETag"] == `"1"` && key.ResponseHeaders["Content-Type"] == gin.MIMEJSON
+				}))
+			} else {
+				idempotencyKeyUsecase.AssertNotCalled(t, "CompleteIdempotentRequest", mock.Anything, mock.Anything)
+			}
+
+			if tc.wantAborted {
+				idempotencyKeyUsecase.AssertCalled(t, "AbortIdempotentRequest", mock.Anything, mock.Anything)
+			} else {
+				idempotencyKeyUsecase.AssertNotCalled(t, "AbortIdempotentRequest", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package v1
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	"github.com/satriowisnugroho/catalog/internal/parser"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/pkg/logger"
+)
+
+type CycleCountHandler struct {
+	Logger            logger.LoggerInterface
+	CycleCountParser  parser.CycleCountParserInterface
+	CycleCountUsecase usecase.CycleCountUsecaseInterface
+}
+
+func newCycleCountHandler(
+	handler *gin.RouterGroup,
+	l logger.LoggerInterface,
+	ccp parser.CycleCountParserInterface,
+	ccu usecase.CycleCountUsecaseInterface,
+) {
+	r := &CycleCountHandler{l, ccp, ccu}
+
+	h := handler.Group("/cycle-counts")
+	{
+		h.POST("/", r.CreateCycleCount)
+		h.GET("/:id", r.GetCycleCountByID)
+		h.POST("/:id/approve", r.ApproveCycleCount)
+		h.POST("/:id/reject", r.RejectCycleCount)
+	}
+}
+
+// @Summary     Create Cycle Count
+// @Description An API to upload a count sheet of the physical stock, the response is the diff report of each counted qty against the current qty.
+// @Description The sheet is either a json payload or a csv with the sku and counted_qty header. The qty is only changed when the cycle count is approved
+// @ID          create-cycle-count
+// @Tags  	    cycle-count
+// @Accept      json,text/csv
+// @Produce     json
+// @Param       X-Tenant	header	string 										true	"Tenant Header"		default(lorem)	example(lorem, ipsum)
+// @Param       request		body 		entity.CycleCountPayload	true 	"payload"
+// @Success     200 {object} response.SuccessBody{data=entity.CycleCount,meta=response.MetaInfo}
+// @Failure     403 {object} response.ErrorBody
+// @Failure     404 {object} response.ErrorBody
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /cycle-counts [post]
+func (h *CycleCountHandler) CreateCycleCount(c *gin.Context) {
+	functionName := "CycleCountHandler.CreateCycleCount"
+
+	payload, err := h.CycleCountParser.ParseCycleCountPayload(c.ContentType(), c.Request.Body)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.CycleCountParser.ParseCycleCountPayload: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+		return
+	}
+
+	payload.Tenant = helper.GetTenant(c)
+	cycleCount, err := h.CycleCountUsecase.CreateCycleCount(c.Request.Context(), payload)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.CycleCountUsecase.CreateCycleCount: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, cycleCount, "")
+}
+
+// @Summary     Show Cycle Count Detail
+// @Description An API to show cycle count detail with the diff report of its items
+// @ID          detail-cycle-count
+// @Tags  	    cycle-count
+// @Accept      json
+// @Produce     json
+// @Param      	id				path		int			true	"Cycle Count ID"
+// @Param       X-Tenant	header	string	true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Success     200 {object} response.SuccessBody{data=entity.CycleCount,meta=response.MetaInfo}
+// @Failure     403 {object} response.ErrorBody
+// @Failure     404 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /cycle-counts/{id} [get]
+func (h *CycleCountHandler) GetCycleCountByID(c *gin.Context) {
+	cycleCountID, _ := strconv.Atoi(c.Param("id"))
+	cycleCount, err := h.CycleCountUsecase.GetCycleCountByID(c.Request.Context(), helper.GetTenant(c), cycleCountID)
+	if err != nil {
+		h.Logger.Error(err, "http - v1 - GetCycleCountByID")
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, cycleCount, "")
+}
+
+// @Summary     Approve Cycle Count
+// @Description An API to approve a pending cycle count, the diff of each item is applied to the qty of its product in one transaction
+// @Description and recorded as an adjustment on the stock movements. The diff against the qty when the products were counted is applied,
+// @Description so the qty changes since the count are kept
+// @ID          approve-cycle-count
+// @Tags  	    cycle-count
+// @Accept      json
+// @Produce     json
+// @Param      	id				path		int			true	"Cycle Count ID"
+// @Param       X-Tenant	header	string	true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Param       X-Actor		header	string	false	"Actor Header, recorded on the stock movements"	example(jane@example.com)
+// @Success     200 {object} response.SuccessBody{data=entity.CycleCount,meta=response.MetaInfo}
+// @Failure     403 {object} response.ErrorBody
+// @Failure     404 {object} response.ErrorBody
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /cycle-counts/{id}/approve [post]
+func (h *CycleCountHandler) ApproveCycleCount(c *gin.Context) {
+	functionName := "CycleCountHandler.ApproveCycleCount"
+
+	cycleCountID, _ := strconv.Atoi(c.Param("id"))
+	cycleCount, err := h.CycleCountUsecase.ApproveCycleCount(c.Request.Context(), helper.GetTenant(c), cycleCountID, helper.GetActor(c))
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.CycleCountUsecase.ApproveCycleCount: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, cycleCount, "")
+}
+
+// @Summary     Reject Cycle Count
+// @Description An API to reject a pending cycle count without changing the qty of its products
+// @ID          reject-cycle-count
+// @Tags  	    cycle-count
+// @Accept      json
+// @Produce     json
+// @Param      	id				path		int			true	"Cycle Count ID"
+// @Param       X-Tenant	header	string	true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Success     200 {object} response.SuccessBody{data=entity.CycleCount,meta=response.MetaInfo}
+// @Failure     403 {object} response.ErrorBody
+// @Failure     404 {object} response.ErrorBody
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /cycle-counts/{id}/reject [post]
+func (h *CycleCountHandler) RejectCycleCount(c *gin.Context) {
+	functionName := "CycleCountHandler.RejectCycleCount"
+
+	cycleCountID, _ := strconv.Atoi(c.Param("id"))
+	cycleCount, err := h.CycleCountUsecase.RejectCycleCount(c.Request.Context(), helper.GetTenant(c), cycleCountID)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.CycleCountUsecase.RejectCycleCount: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, cycleCount, "")
+}
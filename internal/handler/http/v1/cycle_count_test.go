@@ -0,0 +1,232 @@
+package v1_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	httpv1 "github.com/satriowisnugroho/catalog/internal/handler/http/v1"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateCycleCount(t *testing.T) {
+	testcases := []struct {
+		name              string
+		pPayloadRes       *entity.CycleCountPayload
+		pPayloadErr       error
+		uCycleCountRes    *entity.CycleCount
+		uCycleCountErr    error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "parser return custom error",
+			pPayloadErr:       response.ErrInvalidCycleCountSheet,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to parse cycle count payload",
+			pPayloadErr:       errors.New("error parse cycle count payload"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "product not found",
+			pPayloadRes:       &entity.CycleCountPayload{},
+			uCycleCountErr:    response.ErrNotFound,
+			httpStatusCodeRes: http.StatusNotFound,
+		},
+		{
+			name:              "invalid items",
+			pPayloadRes:       &entity.CycleCountPayload{},
+			uCycleCountErr:    response.ErrInvalidCycleCountItems,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to create cycle count",
+			pPayloadRes:       &entity.CycleCountPayload{},
+			uCycleCountErr:    errors.New("error create cycle count"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			pPayloadRes:       &entity.CycleCountPayload{},
+			uCycleCountRes:    &entity.CycleCount{Tenant: types.TenantLoremType, Status: types.CycleCountStatusPendingType},
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "POST",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			ccp := &testmock.CycleCountParserInterface{}
+			ccp.On("ParseCycleCountPayload", mock.Anything, mock.Anything).Return(tc.pPayloadRes, tc.pPayloadErr)
+
+			cycleCountUsecase := &testmock.CycleCountUsecaseInterface{}
+			cycleCountUsecase.On("CreateCycleCount", mock.Anything, mock.Anything).Return(tc.uCycleCountRes, tc.uCycleCountErr)
+
+			h := &httpv1.CycleCountHandler{l, ccp, cycleCountUsecase}
+			h.CreateCycleCount(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
+
+func TestGetCycleCountByID(t *testing.T) {
+	testcases := []struct {
+		name              string
+		uCycleCountErr    error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "cycle count not found",
+			uCycleCountErr:    response.ErrNotFound,
+			httpStatusCodeRes: http.StatusNotFound,
+		},
+		{
+			name:              "failed to get cycle count",
+			uCycleCountErr:    errors.New("error get cycle count"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "GET",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			cycleCountUsecase := &testmock.CycleCountUsecaseInterface{}
+			cycleCountUsecase.On("GetCycleCountByID", mock.Anything, mock.Anything, mock.Anything).Return(&entity.CycleCount{Tenant: types.TenantLoremType, Status: types.CycleCountStatusPendingType}, tc.uCycleCountErr)
+
+			h := &httpv1.CycleCountHandler{l, &testmock.CycleCountParserInterface{}, cycleCountUsecase}
+			h.GetCycleCountByID(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
+
+func TestApproveCycleCount(t *testing.T) {
+	testcases := []struct {
+		name              string
+		uCycleCountErr    error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "cycle count is not pending",
+			uCycleCountErr:    response.ErrCycleCountNotPending,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "insufficient stock",
+			uCycleCountErr:    response.ErrInsufficientStock,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to approve cycle count",
+			uCycleCountErr:    errors.New("error approve cycle count"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "POST",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			cycleCountUsecase := &testmock.CycleCountUsecaseInterface{}
+			cycleCountUsecase.On("ApproveCycleCount", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&entity.CycleCount{Tenant: types.TenantLoremType, Status: types.CycleCountStatusApprovedType}, tc.uCycleCountErr)
+
+			h := &httpv1.CycleCountHandler{l, &testmock.CycleCountParserInterface{}, cycleCountUsecase}
+			h.ApproveCycleCount(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
+
+func TestRejectCycleCount(t *testing.T) {
+	testcases := []struct {
+		name              string
+		uCycleCountErr    error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "cycle count is not pending",
+			uCycleCountErr:    response.ErrCycleCountNotPending,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to reject cycle count",
+			uCycleCountErr:    errors.New("error reject cycle count"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "POST",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			cycleCountUsecase := &testmock.CycleCountUsecaseInterface{}
+			cycleCountUsecase.On("RejectCycleCount", mock.Anything, mock.Anything, mock.Anything).Return(&entity.CycleCount{Tenant: types.TenantLoremType, Status: types.CycleCountStatusRejectedType}, tc.uCycleCountErr)
+
+			h := &httpv1.CycleCountHandler{l, &testmock.CycleCountParserInterface{}, cycleCountUsecase}
+			h.RejectCycleCount(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
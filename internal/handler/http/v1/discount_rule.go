@@ -0,0 +1,191 @@
+package v1
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	"github.com/satriowisnugroho/catalog/internal/parser"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/pkg/logger"
+)
+
+type DiscountRuleHandler struct {
+	Logger              logger.LoggerInterface
+	DiscountRuleParser  parser.DiscountRuleParserInterface
+	DiscountRuleUsecase usecase.DiscountRuleUsecaseInterface
+}
+
+func newDiscountRuleHandler(
+	handler *gin.RouterGroup,
+	l logger.LoggerInterface,
+	drp parser.DiscountRuleParserInterface,
+	dru usecase.DiscountRuleUsecaseInterface,
+) {
+	r := &DiscountRuleHandler{l, drp, dru}
+
+	h := handler.Group("/discount-rules")
+	{
+		h.POST("/", r.CreateDiscountRule)
+		h.GET("/", r.GetDiscountRules)
+		h.PUT("/:id", r.UpdateDiscountRule)
+		h.DELETE("/:id", r.DeleteDiscountRule)
+	}
+}
+
+// @Summary     Create Discount Rule
+// @Description An API to create discount rule applied to every product matching all of its conditions.
+// @Description The conditions are the filters of the product search: any of the categories, any of the conditions,
+// @Description and every word of the keyword in the title, description or attribute values, without stemming.
+// @Description Percentage value is in basis points. Rules are evaluated from the highest priority, then the oldest one.
+// @Description Stackable rules are applied on the price left by the previous rules,
+// @Description while a non-stackable rule is only applied when no rule has been applied and stops the evaluation.
+// @ID          create-discount-rule
+// @Tags  	    discount-rule
+// @Accept      json
+// @Produce     json
+// @Param       X-Tenant	header	string 														true	"Tenant Header"		default(lorem)	example(lorem, ipsum)
+// @Param       request		body 		entity.SwaggerDiscountRulePayload	true 	"payload"
+// @Success     200 {object} response.SuccessBody{data=entity.DiscountRule,meta=response.MetaInfo}
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /discount-rules [post]
+func (h *DiscountRuleHandler) CreateDiscountRule(c *gin.Context) {
+	functionName := "DiscountRuleHandler.CreateDiscountRule"
+
+	payload, err := h.DiscountRuleParser.ParseDiscountRulePayload(c.Request.Body)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.DiscountRuleParser.ParseDiscountRulePayload: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+		return
+	}
+
+	payload.Tenant = helper.GetTenant(c)
+	discountRule, err := h.DiscountRuleUsecase.CreateDiscountRule(c.Request.Context(), payload)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.DiscountRuleUsecase.CreateDiscountRule: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, discountRule, "")
+}
+
+// @Summary     Show Discount Rule List
+// @Description An API to show discount rules of the tenant in the evaluation order
+// @ID          list-discount-rule
+// @Tags  	    discount-rule
+// @Accept      json
+// @Produce     json
+// @Param       X-Tenant	header	string	true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Success     200 {object} response.SuccessBody{data=[]entity.DiscountRule,meta=response.MetaInfo}
+// @Failure     500 {object} response.ErrorBody
+// @Router      /discount-rules [get]
+func (h *DiscountRuleHandler) GetDiscountRules(c *gin.Context) {
+	discountRules, err := h.DiscountRuleUsecase.GetDiscountRules(c.Request.Context(), helper.GetTenant(c))
+	if err != nil {
+		h.Logger.Error(err, "http - v1 - GetDiscountRules")
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, discountRules, "")
+}
+
+// @Summary     Update Discount Rule
+// @Description An API to update discount rule
+// @ID          update-discount-rule
+// @Tags  	    discount-rule
+// @Accept      json
+// @Produce     json
+// @Param      	id				path		int																true	"Discount Rule ID"
+// @Param       X-Tenant	header	string 														true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Param       request 	body 		entity.SwaggerDiscountRulePayload	true	"payload"
+// @Success     200 {object} response.SuccessBody{data=entity.DiscountRule,meta=response.MetaInfo}
+// @Failure     404 {object} response.ErrorBody
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /discount-rules/{id} [put]
+func (h *DiscountRuleHandler) UpdateDiscountRule(c *gin.Context) {
+	functionName := "DiscountRuleHandler.UpdateDiscountRule"
+
+	payload, err := h.DiscountRuleParser.ParseDiscountRulePayload(c.Request.Body)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.DiscountRuleParser.ParseDiscountRulePayload: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+		return
+	}
+
+	discountRuleID, _ := strconv.Atoi(c.Param("id"))
+	payload.Tenant = helper.GetTenant(c)
+	discountRule, err := h.DiscountRuleUsecase.UpdateDiscountRule(c.Request.Context(), discountRuleID, payload)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.DiscountRuleUsecase.UpdateDiscountRule: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, discountRule, "")
+}
+
+// @Summary     Delete Discount Rule
+// @Description An API to delete discount rule
+// @ID          delete-discount-rule
+// @Tags  	    discount-rule
+// @Accept      json
+// @Produce     json
+// @Param      	id				path		int			true	"Discount Rule ID"
+// @Param       X-Tenant	header	string	true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Success     200 {object} response.SuccessBody{meta=response.MetaInfo}
+// @Failure     404 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /discount-rules/{id} [delete]
+func (h *DiscountRuleHandler) DeleteDiscountRule(c *gin.Context) {
+	functionName := "DiscountRuleHandler.DeleteDiscountRule"
+
+	discountRuleID, _ := strconv.Atoi(c.Param("id"))
+	if err := h.DiscountRuleUsecase.DeleteDiscountRule(c.Request.Context(), helper.GetTenant(c), discountRuleID); err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.DiscountRuleUsecase.DeleteDiscountRule: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, nil, "Successfully delete discount rule")
+}
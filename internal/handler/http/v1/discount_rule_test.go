@@ -0,0 +1,236 @@
+package v1_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	httpv1 "github.com/satriowisnugroho/catalog/internal/handler/http/v1"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateDiscountRule(t *testing.T) {
+	testcases := []struct {
+		name              string
+		pPayloadRes       *entity.DiscountRulePayload
+		pPayloadErr       error
+		uDiscountRuleRes  *entity.DiscountRule
+		uDiscountRuleErr  error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "parser return custom error",
+			pPayloadErr:       response.ErrInvalidCategory,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to parse discount rule payload",
+			pPayloadErr:       errors.New("error parse discount rule payload"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "invalid discount value",
+			pPayloadRes:       &entity.DiscountRulePayload{},
+			uDiscountRuleErr:  response.ErrInvalidDiscountValue,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to create discount rule",
+			pPayloadRes:       &entity.DiscountRulePayload{},
+			uDiscountRuleErr:  errors.New("error create discount rule"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			pPayloadRes:       &entity.DiscountRulePayload{},
+			uDiscountRuleRes:  &entity.DiscountRule{Tenant: types.TenantLoremType, Type: types.DiscountPercentageType},
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "POST",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			drp := &testmock.DiscountRuleParserInterface{}
+			drp.On("ParseDiscountRulePayload", mock.Anything).Return(tc.pPayloadRes, tc.pPayloadErr)
+
+			discountRuleUsecase := &testmock.DiscountRuleUsecaseInterface{}
+			discountRuleUsecase.On("CreateDiscountRule", mock.Anything, mock.Anything).Return(tc.uDiscountRuleRes, tc.uDiscountRuleErr)
+
+			h := &httpv1.DiscountRuleHandler{l, drp, discountRuleUsecase}
+			h.CreateDiscountRule(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
+
+func TestGetDiscountRules(t *testing.T) {
+	testcases := []struct {
+		name              string
+		uDiscountRulesErr error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "failed to get discount rules",
+			uDiscountRulesErr: errors.New("error get discount rules"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "GET",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			discountRuleUsecase := &testmock.DiscountRuleUsecaseInterface{}
+			discountRuleUsecase.On("GetDiscountRules", mock.Anything, mock.Anything).Return([]*entity.DiscountRule{{Tenant: types.TenantLoremType, Type: types.DiscountPercentageType}}, tc.uDiscountRulesErr)
+
+			h := &httpv1.DiscountRuleHandler{l, &testmock.DiscountRuleParserInterface{}, discountRuleUsecase}
+			h.GetDiscountRules(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
+
+func TestUpdateDiscountRule(t *testing.T) {
+	testcases := []struct {
+		name              string
+		pPayloadRes       *entity.DiscountRulePayload
+		pPayloadErr       error
+		uDiscountRuleRes  *entity.DiscountRule
+		uDiscountRuleErr  error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "parser return custom error",
+			pPayloadErr:       response.ErrInvalidCategory,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to parse discount rule payload",
+			pPayloadErr:       errors.New("error parse discount rule payload"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "discount rule is not found",
+			pPayloadRes:       &entity.DiscountRulePayload{},
+			uDiscountRuleErr:  response.ErrNotFound,
+			httpStatusCodeRes: http.StatusNotFound,
+		},
+		{
+			name:              "failed to update discount rule",
+			pPayloadRes:       &entity.DiscountRulePayload{},
+			uDiscountRuleErr:  errors.New("error update discount rule"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			pPayloadRes:       &entity.DiscountRulePayload{},
+			uDiscountRuleRes:  &entity.DiscountRule{Tenant: types.TenantLoremType, Type: types.DiscountFixedAmountType},
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "PUT",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			drp := &testmock.DiscountRuleParserInterface{}
+			drp.On("ParseDiscountRulePayload", mock.Anything).Return(tc.pPayloadRes, tc.pPayloadErr)
+
+			discountRuleUsecase := &testmock.DiscountRuleUsecaseInterface{}
+			discountRuleUsecase.On("UpdateDiscountRule", mock.Anything, mock.Anything, mock.Anything).Return(tc.uDiscountRuleRes, tc.uDiscountRuleErr)
+
+			h := &httpv1.DiscountRuleHandler{l, drp, discountRuleUsecase}
+			h.UpdateDiscountRule(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
+
+func TestDeleteDiscountRule(t *testing.T) {
+	testcases := []struct {
+		name              string
+		uDiscountRuleErr  error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "discount rule is not found",
+			uDiscountRuleErr:  response.ErrNotFound,
+			httpStatusCodeRes: http.StatusNotFound,
+		},
+		{
+			name:              "failed to delete discount rule",
+			uDiscountRuleErr:  errors.New("error delete discount rule"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "DELETE",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			discountRuleUsecase := &testmock.DiscountRuleUsecaseInterface{}
+			discountRuleUsecase.On("DeleteDiscountRule", mock.Anything, mock.Anything, mock.Anything).Return(tc.uDiscountRuleErr)
+
+			h := &httpv1.DiscountRuleHandler{l, &testmock.DiscountRuleParserInterface{}, discountRuleUsecase}
+			h.DeleteDiscountRule(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package v1
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	"github.com/satriowisnugroho/catalog/internal/parser"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/pkg/logger"
+)
+
+type LocationHandler struct {
+	Logger          logger.LoggerInterface
+	LocationParser  parser.LocationParserInterface
+	LocationUsecase usecase.LocationUsecaseInterface
+}
+
+func newLocationHandler(
+	handler *gin.RouterGroup,
+	l logger.LoggerInterface,
+	lp parser.LocationParserInterface,
+	lu usecase.LocationUsecaseInterface,
+) {
+	r := &LocationHandler{l, lp, lu}
+
+	h := handler.Group("/locations")
+	{
+		h.POST("/", r.CreateLocation)
+		h.GET("/", r.GetLocations)
+		h.PUT("/:id", r.UpdateLocation)
+	}
+}
+
+// @Summary     Create Location
+// @Description An API to create warehouse or store location which keeps stock.
+// @Description Stock given without location goes to the default location, setting is_default moves the default from the current one
+// @ID          create-location
+// @Tags  	    location
+// @Accept      json
+// @Produce     json
+// @Param       X-Tenant	header	string 												true	"Tenant Header"		default(lorem)	example(lorem, ipsum)
+// @Param       request		body 		entity.SwaggerLocationPayload	true 	"payload"
+// @Success     200 {object} response.SuccessBody{data=entity.Location,meta=response.MetaInfo}
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /locations [post]
+func (h *LocationHandler) CreateLocation(c *gin.Context) {
+	functionName := "LocationHandler.CreateLocation"
+
+	payload, err := h.LocationParser.ParseLocationPayload(c.Request.Body)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.LocationParser.ParseLocationPayload: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+		return
+	}
+
+	payload.Tenant = helper.GetTenant(c)
+	location, err := h.LocationUsecase.CreateLocation(c.Request.Context(), payload)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.LocationUsecase.CreateLocation: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, location, "")
+}
+
+// @Summary     Show Location List
+// @Description An API to show locations of the tenant from the highest priority
+// @ID          list-location
+// @Tags  	    location
+// @Accept      json
+// @Produce     json
+// @Param       X-Tenant	header	string	true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Success     200 {object} response.SuccessBody{data=[]entity.Location,meta=response.MetaInfo}
+// @Failure     500 {object} response.ErrorBody
+// @Router      /locations [get]
+func (h *LocationHandler) GetLocations(c *gin.Context) {
+	locations, err := h.LocationUsecase.GetLocations(c.Request.Context(), helper.GetTenant(c))
+	if err != nil {
+		h.Logger.Error(err, "http - v1 - GetLocations")
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, locations, "")
+}
+
+// @Summary     Update Location
+// @Description An API to update location, the default location stays the default until another location is made the default
+// @ID          update-location
+// @Tags  	    location
+// @Accept      json
+// @Produce     json
+// @Param      	id				path		int														true	"Location ID"
+// @Param       X-Tenant	header	string 												true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Param       request 	body 		entity.SwaggerLocationPayload	true	"payload"
+// @Success     200 {object} response.SuccessBody{data=entity.Location,meta=response.MetaInfo}
+// @Failure     404 {object} response.ErrorBody
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /locations/{id} [put]
+func (h *LocationHandler) UpdateLocation(c *gin.Context) {
+	functionName := "LocationHandler.UpdateLocation"
+
+	payload, err := h.LocationParser.ParseLocationPayload(c.Request.Body)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.LocationParser.ParseLocationPayload: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+		return
+	}
+
+	locationID, _ := strconv.Atoi(c.Param("id"))
+	payload.Tenant = helper.GetTenant(c)
+	location, err := h.LocationUsecase.UpdateLocation(c.Request.Context(), locationID, payload)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.LocationUsecase.UpdateLocation: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, location, "")
+}
@@ -0,0 +1,190 @@
+package v1_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	httpv1 "github.com/satriowisnugroho/catalog/internal/handler/http/v1"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateLocation(t *testing.T) {
+	testcases := []struct {
+		name              string
+		pPayloadRes       *entity.LocationPayload
+		pPayloadErr       error
+		uLocationRes      *entity.Location
+		uLocationErr      error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "parser return custom error",
+			pPayloadErr:       response.ErrInvalidLocationCode,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to parse location payload",
+			pPayloadErr:       errors.New("error parse location payload"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "duplicate location code",
+			pPayloadRes:       &entity.LocationPayload{},
+			uLocationErr:      response.ErrDuplicateLocationCode,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to create location",
+			pPayloadRes:       &entity.LocationPayload{},
+			uLocationErr:      errors.New("error create location"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			pPayloadRes:       &entity.LocationPayload{},
+			uLocationRes:      &entity.Location{Tenant: types.TenantLoremType, Code: "jkt-1"},
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "POST",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			lp := &testmock.LocationParserInterface{}
+			lp.On("ParseLocationPayload", mock.Anything).Return(tc.pPayloadRes, tc.pPayloadErr)
+
+			locationUsecase := &testmock.LocationUsecaseInterface{}
+			locationUsecase.On("CreateLocation", mock.Anything, mock.Anything).Return(tc.uLocationRes, tc.uLocationErr)
+
+			h := &httpv1.LocationHandler{l, lp, locationUsecase}
+			h.CreateLocation(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
+
+func TestGetLocations(t *testing.T) {
+	testcases := []struct {
+		name              string
+		uLocationsErr     error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "failed to get locations",
+			uLocationsErr:     errors.New("error get locations"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "GET",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			locationUsecase := &testmock.LocationUsecaseInterface{}
+			locationUsecase.On("GetLocations", mock.Anything, mock.Anything).Return([]*entity.Location{{Tenant: types.TenantLoremType}}, tc.uLocationsErr)
+
+			h := &httpv1.LocationHandler{l, &testmock.LocationParserInterface{}, locationUsecase}
+			h.GetLocations(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
+
+func TestUpdateLocation(t *testing.T) {
+	testcases := []struct {
+		name              string
+		pPayloadRes       *entity.LocationPayload
+		pPayloadErr       error
+		uLocationRes      *entity.Location
+		uLocationErr      error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "parser return custom error",
+			pPayloadErr:       response.ErrInvalidLocationCode,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to parse location payload",
+			pPayloadErr:       errors.New("error parse location payload"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "location is not found",
+			pPayloadRes:       &entity.LocationPayload{},
+			uLocationErr:      response.ErrNotFound,
+			httpStatusCodeRes: http.StatusNotFound,
+		},
+		{
+			name:              "failed to update location",
+			pPayloadRes:       &entity.LocationPayload{},
+			uLocationErr:      errors.New("error update location"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			pPayloadRes:       &entity.LocationPayload{},
+			uLocationRes:      &entity.Location{Tenant: types.TenantLoremType},
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "PUT",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			lp := &testmock.LocationParserInterface{}
+			lp.On("ParseLocationPayload", mock.Anything).Return(tc.pPayloadRes, tc.pPayloadErr)
+
+			locationUsecase := &testmock.LocationUsecaseInterface{}
+			locationUsecase.On("UpdateLocation", mock.Anything, mock.Anything, mock.Anything).Return(tc.uLocationRes, tc.uLocationErr)
+
+			h := &httpv1.LocationHandler{l, lp, locationUsecase}
+			h.UpdateLocation(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	"github.com/satriowisnugroho/catalog/internal/parser"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/pkg/logger"
+)
+
+type LowStockHandler struct {
+	Logger          logger.LoggerInterface
+	LowStockParser  parser.LowStockParserInterface
+	LowStockUsecase usecase.LowStockUsecaseInterface
+}
+
+func newLowStockHandler(
+	handler *gin.RouterGroup,
+	l logger.LoggerInterface,
+	lsp parser.LowStockParserInterface,
+	lsu usecase.LowStockUsecaseInterface,
+) {
+	r := &LowStockHandler{l, lsp, lsu}
+
+	h := handler.Group("/low-stock-threshold")
+	{
+		h.GET("/", r.GetLowStockThreshold)
+		h.PUT("/", r.UpdateLowStockThreshold)
+	}
+}
+
+// @Summary     Show Low Stock Threshold
+// @Description An API to show the low stock threshold of the tenant, it is used by the products which have no threshold of their own
+// @ID          detail-low-stock-threshold
+// @Tags  	    low stock
+// @Accept      json
+// @Produce     json
+// @Param       X-Tenant	header	string	true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Success     200 {object} response.SuccessBody{data=entity.LowStockThreshold,meta=response.MetaInfo}
+// @Failure     404 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /low-stock-threshold [get]
+func (h *LowStockHandler) GetLowStockThreshold(c *gin.Context) {
+	functionName := "LowStockHandler.GetLowStockThreshold"
+
+	threshold, err := h.LowStockUsecase.GetLowStockThreshold(c.Request.Context(), helper.GetTenant(c))
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.LowStockUsecase.GetLowStockThreshold: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, threshold, "")
+}
+
+// @Summary     Update Low Stock Threshold
+// @Description An API to set the low stock threshold of the tenant. An alert is sent when the qty of a product falls to its threshold,
+// @Description and it is not sent again until the qty of the product recovers above the threshold
+// @ID          update-low-stock-threshold
+// @Tags  	    low stock
+// @Accept      json
+// @Produce     json
+// @Param       X-Tenant	header	string 																true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Param       request 	body 		entity.SwaggerLowStockThresholdPayload	true	"payload"
+// @Success     200 {object} response.SuccessBody{data=entity.LowStockThreshold,meta=response.MetaInfo}
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /low-stock-threshold [put]
+func (h *LowStockHandler) UpdateLowStockThreshold(c *gin.Context) {
+	functionName := "LowStockHandler.UpdateLowStockThreshold"
+
+	payload, err := h.LowStockParser.ParseLowStockThresholdPayload(c.Request.Body)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.LowStockParser.ParseLowStockThresholdPayload: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+		return
+	}
+
+	payload.Tenant = helper.GetTenant(c)
+	threshold, err := h.LowStockUsecase.UpdateLowStockThreshold(c.Request.Context(), payload)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.LowStockUsecase.UpdateLowStockThreshold: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, threshold, "")
+}
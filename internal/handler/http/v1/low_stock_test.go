@@ -0,0 +1,131 @@
+package v1_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	httpv1 "github.com/satriowisnugroho/catalog/internal/handler/http/v1"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetLowStockThreshold(t *testing.T) {
+	testcases := []struct {
+		name              string
+		uThresholdRes     *entity.LowStockThreshold
+		uThresholdErr     error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "threshold is not found",
+			uThresholdErr:     response.ErrNotFound,
+			httpStatusCodeRes: http.StatusNotFound,
+		},
+		{
+			name:              "failed to get threshold",
+			uThresholdErr:     errors.New("error get threshold"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			uThresholdRes:     &entity.LowStockThreshold{Tenant: types.TenantLoremType, Threshold: 5},
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "GET",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			lowStockUsecase := &testmock.LowStockUsecaseInterface{}
+			lowStockUsecase.On("GetLowStockThreshold", mock.Anything, mock.Anything).Return(tc.uThresholdRes, tc.uThresholdErr)
+
+			h := &httpv1.LowStockHandler{l, &testmock.LowStockParserInterface{}, lowStockUsecase}
+			h.GetLowStockThreshold(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
+
+func TestUpdateLowStockThreshold(t *testing.T) {
+	testcases := []struct {
+		name              string
+		pPayloadRes       *entity.LowStockThresholdPayload
+		pPayloadErr       error
+		uThresholdRes     *entity.LowStockThreshold
+		uThresholdErr     error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "parser return custom error",
+			pPayloadErr:       response.ErrInvalidLowStockThreshold,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to parse low stock threshold payload",
+			pPayloadErr:       errors.New("error parse low stock threshold payload"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "invalid threshold",
+			pPayloadRes:       &entity.LowStockThresholdPayload{Threshold: -1},
+			uThresholdErr:     response.ErrInvalidLowStockThreshold,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to update threshold",
+			pPayloadRes:       &entity.LowStockThresholdPayload{Threshold: 5},
+			uThresholdErr:     errors.New("error update threshold"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			pPayloadRes:       &entity.LowStockThresholdPayload{Threshold: 5},
+			uThresholdRes:     &entity.LowStockThreshold{Tenant: types.TenantLoremType, Threshold: 5},
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "PUT",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			lsp := &testmock.LowStockParserInterface{}
+			lsp.On("ParseLowStockThresholdPayload", mock.Anything).Return(tc.pPayloadRes, tc.pPayloadErr)
+
+			lowStockUsecase := &testmock.LowStockUsecaseInterface{}
+			lowStockUsecase.On("UpdateLowStockThreshold", mock.Anything, mock.Anything).Return(tc.uThresholdRes, tc.uThresholdErr)
+
+			h := &httpv1.LowStockHandler{l, lsp, lowStockUsecase}
+			h.UpdateLowStockThreshold(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
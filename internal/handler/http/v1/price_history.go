@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/parser"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/pkg/logger"
+)
+
+type PriceHistoryHandler struct {
+	Logger              logger.LoggerInterface
+	PriceHistoryParser  parser.PriceHistoryParserInterface
+	PriceHistoryUsecase usecase.PriceHistoryUsecaseInterface
+}
+
+func newPriceHistoryHandler(
+	handler *gin.RouterGroup,
+	l logger.LoggerInterface,
+	php parser.PriceHistoryParserInterface,
+	phu usecase.PriceHistoryUsecaseInterface,
+) {
+	r := &PriceHistoryHandler{l, php, phu}
+
+	h := handler.Group("/products/:id/price-history")
+	{
+		h.GET("/", r.GetProductPriceHistory)
+	}
+}
+
+// @Summary     Show Product Price History
+// @Description An API to show price history of a product and its lowest price in the last 30 days
+// @ID          price-history
+// @Tags  	    product
+// @Accept      json
+// @Produce     json
+// @Param      	id				path		int			true	"Product ID"
+// @Param       X-Tenant	header	string	true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Param       from			query		string	false	"start of period, RFC3339 or YYYY-MM-DD"
+// @Param       to				query		string	false	"end of period, RFC3339 or YYYY-MM-DD"
+// @Success     200 {object} response.SuccessBody{data=entity.ProductPriceHistory,meta=response.MetaInfo}
+// @Failure     404 {object} response.ErrorBody
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /products/{id}/price-history [get]
+func (h *PriceHistoryHandler) GetProductPriceHistory(c *gin.Context) {
+	functionName := "PriceHistoryHandler.GetProductPriceHistory"
+
+	payload, err := h.PriceHistoryParser.ParseGetPriceHistoryPayload(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	priceHistory, err := h.PriceHistoryUsecase.GetProductPriceHistory(c.Request.Context(), payload)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.PriceHistoryUsecase.GetProductPriceHistory: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, priceHistory, "")
+}
@@ -0,0 +1,68 @@
+package v1_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	httpv1 "github.com/satriowisnugroho/catalog/internal/handler/http/v1"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetProductPriceHistory(t *testing.T) {
+	testcases := []struct {
+		name              string
+		pPayloadErr       error
+		uHistoryErr       error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "invalid date range",
+			pPayloadErr:       response.ErrInvalidDateRange,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "product is not found",
+			uHistoryErr:       response.ErrNotFound,
+			httpStatusCodeRes: http.StatusNotFound,
+		},
+		{
+			name:              "failed to get price history",
+			uHistoryErr:       errors.New("error get price history"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request, _ = http.NewRequest("GET", "/products/123/price-history?from=2023-12-01", nil)
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			php := &testmock.PriceHistoryParserInterface{}
+			php.On("ParseGetPriceHistoryPayload", mock.Anything).Return(&entity.GetPriceHistoryPayload{}, tc.pPayloadErr)
+
+			priceHistoryUsecase := &testmock.PriceHistoryUsecaseInterface{}
+			priceHistoryUsecase.On("GetProductPriceHistory", mock.Anything, mock.Anything).Return(&entity.ProductPriceHistory{}, tc.uHistoryErr)
+
+			h := &httpv1.PriceHistoryHandler{l, php, priceHistoryUsecase}
+			h.GetProductPriceHistory(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
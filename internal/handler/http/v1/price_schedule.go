@@ -0,0 +1,146 @@
+package v1
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	"github.com/satriowisnugroho/catalog/internal/parser"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/pkg/logger"
+)
+
+type PriceScheduleHandler struct {
+	Logger               logger.LoggerInterface
+	PriceScheduleParser  parser.PriceScheduleParserInterface
+	PriceScheduleUsecase usecase.PriceScheduleUsecaseInterface
+}
+
+func newPriceScheduleHandler(
+	handler *gin.RouterGroup,
+	l logger.LoggerInterface,
+	psp parser.PriceScheduleParserInterface,
+	psu usecase.PriceScheduleUsecaseInterface,
+) {
+	r := &PriceScheduleHandler{l, psp, psu}
+
+	h := handler.Group("/products/:id/price-schedules")
+	{
+		h.POST("/", r.CreatePriceSchedule)
+		h.GET("/", r.GetPriceSchedules)
+		h.DELETE("/:schedule_id", r.CancelPriceSchedule)
+	}
+}
+
+// @Summary     Create Price Schedule
+// @Description An API to schedule a future price change or a time-boxed promotion of a product
+// @ID          create-price-schedule
+// @Tags  	    price-schedule
+// @Accept      json
+// @Produce     json
+// @Param      	id				path		int														true	"Product ID"
+// @Param       X-Tenant	header	string 												true	"Tenant Header"		default(lorem)	example(lorem, ipsum)
+// @Param       request		body 		entity.SwaggerPriceSchedulePayload	true 	"payload"
+// @Success     200 {object} response.SuccessBody{data=entity.PriceSchedule,meta=response.MetaInfo}
+// @Failure     404 {object} response.ErrorBody
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /products/{id}/price-schedules [post]
+func (h *PriceScheduleHandler) CreatePriceSchedule(c *gin.Context) {
+	functionName := "PriceScheduleHandler.CreatePriceSchedule"
+
+	payload, err := h.PriceScheduleParser.ParsePriceSchedulePayload(c.Request.Body)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.PriceScheduleParser.ParsePriceSchedulePayload: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+		return
+	}
+
+	payload.ProductID, _ = strconv.Atoi(c.Param("id"))
+	payload.Tenant = helper.GetTenant(c)
+	priceSchedule, err := h.PriceScheduleUsecase.CreatePriceSchedule(c.Request.Context(), payload)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.PriceScheduleUsecase.CreatePriceSchedule: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, priceSchedule, "")
+}
+
+// @Summary     Show Price Schedule List
+// @Description An API to show price schedules of a product
+// @ID          list-price-schedule
+// @Tags  	    price-schedule
+// @Accept      json
+// @Produce     json
+// @Param      	id				path		int			true	"Product ID"
+// @Param       X-Tenant	header	string	true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Success     200 {object} response.SuccessBody{data=[]entity.PriceSchedule,meta=response.MetaInfo}
+// @Failure     404 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /products/{id}/price-schedules [get]
+func (h *PriceScheduleHandler) GetPriceSchedules(c *gin.Context) {
+	productID, _ := strconv.Atoi(c.Param("id"))
+	priceSchedules, err := h.PriceScheduleUsecase.GetPriceSchedules(c.Request.Context(), helper.GetTenant(c), productID)
+	if err != nil {
+		h.Logger.Error(err, "http - v1 - GetPriceSchedules")
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, priceSchedules, "")
+}
+
+// @Summary     Cancel Price Schedule
+// @Description An API to cancel a pending price schedule or end a running promotion
+// @ID          cancel-price-schedule
+// @Tags  	    price-schedule
+// @Accept      json
+// @Produce     json
+// @Param      	id						path		int			true	"Product ID"
+// @Param      	schedule_id		path		int			true	"Price Schedule ID"
+// @Param       X-Tenant			header	string	true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Success     200 {object} response.SuccessBody{data=entity.PriceSchedule,meta=response.MetaInfo}
+// @Failure     404 {object} response.ErrorBody
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /products/{id}/price-schedules/{schedule_id} [delete]
+func (h *PriceScheduleHandler) CancelPriceSchedule(c *gin.Context) {
+	functionName := "PriceScheduleHandler.CancelPriceSchedule"
+
+	productID, _ := strconv.Atoi(c.Param("id"))
+	priceScheduleID, _ := strconv.Atoi(c.Param("schedule_id"))
+	priceSchedule, err := h.PriceScheduleUsecase.CancelPriceSchedule(c.Request.Context(), helper.GetTenant(c), productID, priceScheduleID)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.PriceScheduleUsecase.CancelPriceSchedule: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, priceSchedule, "")
+}
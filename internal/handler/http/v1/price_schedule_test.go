@@ -0,0 +1,175 @@
+package v1_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	httpv1 "github.com/satriowisnugroho/catalog/internal/handler/http/v1"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreatePriceSchedule(t *testing.T) {
+	testcases := []struct {
+		name              string
+		pPayloadRes       *entity.PriceSchedulePayload
+		pPayloadErr       error
+		uScheduleRes      *entity.PriceSchedule
+		uScheduleErr      error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "parser return custom error",
+			pPayloadErr:       response.ErrInvalidPriceScheduleType,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to parse price schedule payload",
+			pPayloadErr:       errors.New("error parse price schedule payload"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "overlapping promotion",
+			pPayloadRes:       &entity.PriceSchedulePayload{},
+			uScheduleErr:      response.ErrOverlappingPromotion,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to create price schedule",
+			pPayloadRes:       &entity.PriceSchedulePayload{},
+			uScheduleErr:      errors.New("error create price schedule"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			pPayloadRes:       &entity.PriceSchedulePayload{},
+			uScheduleRes:      &entity.PriceSchedule{Tenant: types.TenantLoremType, Type: types.PriceSchedulePromotionType, Status: types.PriceScheduleStatusPendingType},
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "POST",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			psp := &testmock.PriceScheduleParserInterface{}
+			psp.On("ParsePriceSchedulePayload", mock.Anything).Return(tc.pPayloadRes, tc.pPayloadErr)
+
+			priceScheduleUsecase := &testmock.PriceScheduleUsecaseInterface{}
+			priceScheduleUsecase.On("CreatePriceSchedule", mock.Anything, mock.Anything).Return(tc.uScheduleRes, tc.uScheduleErr)
+
+			h := &httpv1.PriceScheduleHandler{l, psp, priceScheduleUsecase}
+			h.CreatePriceSchedule(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
+
+func TestGetPriceSchedules(t *testing.T) {
+	testcases := []struct {
+		name              string
+		uScheduleErr      error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "product is not found",
+			uScheduleErr:      response.ErrNotFound,
+			httpStatusCodeRes: http.StatusNotFound,
+		},
+		{
+			name:              "failed to get price schedules",
+			uScheduleErr:      errors.New("error get price schedules"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "GET",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			priceScheduleUsecase := &testmock.PriceScheduleUsecaseInterface{}
+			priceScheduleUsecase.On("GetPriceSchedules", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.PriceSchedule{}, tc.uScheduleErr)
+
+			h := &httpv1.PriceScheduleHandler{l, &testmock.PriceScheduleParserInterface{}, priceScheduleUsecase}
+			h.GetPriceSchedules(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
+
+func TestCancelPriceSchedule(t *testing.T) {
+	testcases := []struct {
+		name              string
+		uScheduleErr      error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "price schedule can not be cancelled",
+			uScheduleErr:      response.ErrPriceScheduleNotCancellable,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to cancel price schedule",
+			uScheduleErr:      errors.New("error cancel price schedule"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "DELETE",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			priceScheduleUsecase := &testmock.PriceScheduleUsecaseInterface{}
+			priceScheduleUsecase.On("CancelPriceSchedule", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&entity.PriceSchedule{Tenant: types.TenantLoremType, Type: types.PriceSchedulePromotionType, Status: types.PriceScheduleStatusPendingType}, tc.uScheduleErr)
+
+			h := &httpv1.PriceScheduleHandler{l, &testmock.PriceScheduleParserInterface{}, priceScheduleUsecase}
+			h.CancelPriceSchedule(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
@@ -6,6 +6,9 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/config"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/handler/http/middleware"
 	"github.com/satriowisnugroho/catalog/internal/helper"
 	"github.com/satriowisnugroho/catalog/internal/parser"
 	"github.com/satriowisnugroho/catalog/internal/response"
@@ -24,29 +27,44 @@ func newProductHandler(
 	l logger.LoggerInterface,
 	pp parser.ProductParserInterface,
 	pu usecase.ProductUsecaseInterface,
+	iu usecase.IdempotencyKeyUsecaseInterface,
 ) {
 	r := &ProductHandler{l, pp, pu}
 
+	// Retries of these requests with the same Idempotency-Key header get the stored response
+	idempotent := middleware.Idempotency(l, iu)
+
 	h := handler.Group("/products")
 	{
-		h.POST("/", r.CreateProduct)
-		h.POST("/bulk-reduce-qty", r.BulkReduceQtyProduct)
+		h.POST("/", idempotent, r.CreateProduct)
+		h.POST("/bulk-reduce-qty", idempotent, r.BulkReduceQtyProduct)
+		h.POST("/bulk-increase-qty", r.BulkIncreaseQtyProduct)
+		h.POST("/:id/adjust-qty", r.AdjustQtyProduct)
 		h.GET("/:id", r.GetProductByID)
 		h.GET("/", r.GetProducts)
-		h.PUT("/:id", r.UpdateProduct)
+		h.PUT("/:id", idempotent, r.UpdateProduct)
+		h.PATCH("/:id", idempotent, r.PatchProduct)
 	}
 }
 
 // @Summary     Create Product
 // @Description An API to create product
+// @Description Stock per location is given with stocks, otherwise qty is kept on the default location of the tenant.
+// @Description The initial qty is recorded on the stock movements with stock_reason, restock when it is not given
 // @ID          create
 // @Tags  	    product
 // @Accept      json
 // @Produce     json
 // @Param       X-Tenant	header	string 												true	"Tenant Header"		default(lorem)	example(lorem, ipsum)
+// @Param       X-Region	header	string 												false	"Region Header"		example(ID)
+// @Param       X-Scopes	header	string 												false	"Scopes Header, finance scope is required to see and set cost price"	example(finance)
+// @Param       X-Actor		header	string 												false	"Actor Header, recorded on the stock movement of the initial qty"	example(jane@example.com)
+// @Param       Idempotency-Key	header	string	false	"Idempotency Key Header, retries with the same key get the stored response"	example(order-123-attempt)
 // @Param       request		body 		entity.SwaggerProductPayload	true 	"payload"
 // @Success     200 {object} response.SuccessBody{data=entity.Product,meta=response.MetaInfo}
+// @Header      200 {string} ETag "Version of the product"
 // @Failure     404 {object} response.ErrorBody
+// @Failure     409 {object} response.ErrorBody
 // @Failure     422 {object} response.ErrorBody
 // @Failure     500 {object} response.ErrorBody
 // @Router      /products [post]
@@ -67,6 +85,9 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 	}
 
 	payload.Tenant = helper.GetTenant(c)
+	payload.Actor = helper.GetActor(c)
+	payload.Region = helper.GetRegion(c)
+	payload.FinanceScope = helper.HasScope(c, config.FinanceScope)
 	product, err := h.ProductUsecase.CreateProduct(c.Request.Context(), payload)
 	if err != nil {
 		if customErr, ok := err.(response.CustomError); ok {
@@ -81,19 +102,32 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", helper.ETag(product.Version))
 	response.OK(c, product, "")
 }
 
 // @Summary     Bulk Reduce Quantity Product
-// @Description An API to bulk reduce quantity product
+// @Description An API to bulk reduce quantity product from the given location,
+// @Description or from the locations picked by the configured allocation strategy when no location is given.
+// @Description Qty held by active reservations can not be reduced, only the available qty.
+// @Description The qty beyond the available qty is backordered when the backorder policy of the product allows it,
+// @Description the response shows the status, the qty fulfilled from stock and the qty backordered of each item with the updated products.
+// @Description Each reduction from stock is recorded as a sale on the stock movements with the given reference_id.
+// @Description No item is reduced when one of them fails, the field of the error is the failed item.
+// @Description With partial mode the items which can be fulfilled are reduced and the failed ones are reported with their error code
 // @ID          bulk-reduce-qty
 // @Tags  	    product
 // @Accept      json
 // @Produce     json
 // @Param       X-Tenant	header	string 															true "Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Param       X-Region	header	string 															false "Region Header"	example(ID)
+// @Param       X-Scopes	header	string 															false "Scopes Header, finance scope is required to see cost price"	example(finance)
+// @Param       X-Actor		header	string 															false "Actor Header, recorded on the stock movements"	example(jane@example.com)
+// @Param       Idempotency-Key	header	string	false	"Idempotency Key Header, retries with the same key get the stored response"	example(order-123-attempt)
 // @Param       request 	body 		entity.BulkReduceQtyProductPayload 	true "Payload"
-// @Success     200 {object} response.SuccessBody{data=entity.Product,meta=response.MetaInfo}
+// @Success     200 {object} response.SuccessBody{data=entity.BulkReduceQtyProductResult,meta=response.MetaInfo}
 // @Failure     404 {object} response.ErrorBody
+// @Failure     409 {object} response.ErrorBody
 // @Failure     422 {object} response.ErrorBody
 // @Failure     500 {object} response.ErrorBody
 // @Router      /products/bulk-reduce-qty [post]
@@ -102,13 +136,22 @@ func (h *ProductHandler) BulkReduceQtyProduct(c *gin.Context) {
 
 	payload, err := h.ProductParser.ParseBulkReduceQtyProductPayload(c.Request.Body)
 	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
 		err = errors.Wrap(fmt.Errorf("h.productParser.ParseBulkReduceQtyProductPayload: %w", err), functionName)
 		h.Logger.Error(err)
 		response.Error(c, err)
 		return
 	}
 
-	if _, err = h.ProductUsecase.BulkReduceQtyProduct(c.Request.Context(), helper.GetTenant(c), payload); err != nil {
+	payload.Region = helper.GetRegion(c)
+	payload.FinanceScope = helper.HasScope(c, config.FinanceScope)
+	payload.Actor = helper.GetActor(c)
+	result, err := h.ProductUsecase.BulkReduceQtyProduct(c.Request.Context(), helper.GetTenant(c), payload)
+	if err != nil {
 		if customErr, ok := err.(response.CustomError); ok {
 			response.Error(c, customErr)
 			return
@@ -121,24 +164,148 @@ func (h *ProductHandler) BulkReduceQtyProduct(c *gin.Context) {
 		return
 	}
 
-	response.OK(c, nil, "Successfully bulk reduce quantity")
+	response.OK(c, result, "Successfully bulk reduce quantity")
+}
+
+// @Summary     Bulk Increase Quantity Product
+// @Description An API to bulk increase quantity product on the given location, or on the default location of the tenant.
+// @Description Only the qty is changed, each increase is recorded on the stock movements with the given reason and reference_id.
+// @Description The reason is restock when it is not given, only restock, return and adjustment are allowed
+// @ID          bulk-increase-qty
+// @Tags  	    product
+// @Accept      json
+// @Produce     json
+// @Param       X-Tenant	header	string 																	true "Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Param       X-Actor		header	string 																	false "Actor Header, recorded on the stock movements"	example(jane@example.com)
+// @Param       request 	body 		entity.SwaggerBulkIncreaseQtyProductPayload 	true "Payload"
+// @Success     200 {object} response.SuccessBody{meta=response.MetaInfo}
+// @Failure     403 {object} response.ErrorBody
+// @Failure     404 {object} response.ErrorBody
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /products/bulk-increase-qty [post]
+func (h *ProductHandler) BulkIncreaseQtyProduct(c *gin.Context) {
+	functionName := "ProductHandler.BulkIncreaseQtyProduct"
+
+	payload, err := h.ProductParser.ParseBulkIncreaseQtyProductPayload(c.Request.Body)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.productParser.ParseBulkIncreaseQtyProductPayload: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+		return
+	}
+
+	payload.Actor = helper.GetActor(c)
+	if _, err = h.ProductUsecase.BulkIncreaseQtyProduct(c.Request.Context(), helper.GetTenant(c), payload); err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.ProductUsecase.BulkIncreaseQtyProduct: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, nil, "Successfully bulk increase quantity")
+}
+
+// @Summary     Adjust Quantity Product
+// @Description An API to add a signed delta to the quantity product, a negative delta reduces the available qty
+// @Description from the given location or from the locations picked by the allocation strategy, a positive one is added
+// @Description to the given location or to the default location of the tenant. Only the qty is changed.
+// @Description The change is recorded on the stock movements with the given reason, adjustment when it is not given.
+// @Description Sale and damage must reduce the qty, restock and return must increase it
+// @ID          adjust-qty
+// @Tags  	    product
+// @Accept      json
+// @Produce     json
+// @Param      	id				path		int																true	"Product ID"
+// @Param       X-Tenant	header	string 														true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Param       X-Actor		header	string 														false	"Actor Header, recorded on the stock movement"	example(jane@example.com)
+// @Param       request 	body 		entity.SwaggerAdjustQtyProductPayload 	true	"Payload"
+// @Success     200 {object} response.SuccessBody{meta=response.MetaInfo}
+// @Failure     403 {object} response.ErrorBody
+// @Failure     404 {object} response.ErrorBody
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /products/{id}/adjust-qty [post]
+func (h *ProductHandler) AdjustQtyProduct(c *gin.Context) {
+	functionName := "ProductHandler.AdjustQtyProduct"
+
+	payload, err := h.ProductParser.ParseAdjustQtyProductPayload(c.Request.Body)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.productParser.ParseAdjustQtyProductPayload: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+		return
+	}
+
+	productID, _ := strconv.Atoi(c.Param("id"))
+	payload.Tenant = helper.GetTenant(c)
+	payload.Actor = helper.GetActor(c)
+	if _, err = h.ProductUsecase.AdjustQtyProduct(c.Request.Context(), productID, payload); err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.ProductUsecase.AdjustQtyProduct: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, nil, "Successfully adjust quantity")
 }
 
 // @Summary     Show Product Detail
-// @Description An API to show product detail
+// @Description An API to show product detail, available_qty is the qty which is not held by any active reservation
 // @ID          detail
 // @Tags  	    product
 // @Accept      json
 // @Produce     json
 // @Param      	id				path		int			true	"Product ID"
 // @Param       X-Tenant	header	string	true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Param       X-Region	header	string	false	"Region Header"	example(ID)
+// @Param       X-Scopes	header	string	false	"Scopes Header, finance scope is required to see cost price"	example(finance)
+// @Param       fields		query		string	false	"comma separated fields of the response, all fields are given when it is empty"	example(id,title,price)
+// @Param       include		query		string	false	"comma separated relations embedded in the response, stocks and lots are embedded when neither fields nor include is given"	example(stocks,lots)
 // @Success     200 {object} response.SuccessBody{data=entity.Product,meta=response.MetaInfo}
+// @Header      200 {string} ETag "Version of the product, given as If-Match to update it"
 // @Failure     404 {object} response.ErrorBody
+// @Failure     422 {object} response.ErrorBody
 // @Failure     500 {object} response.ErrorBody
 // @Router      /products/{id} [get]
 func (h *ProductHandler) GetProductByID(c *gin.Context) {
+	fieldset, err := h.ProductParser.ParseProductFieldset(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
 	productID, _ := strconv.Atoi(c.Param("id"))
-	product, err := h.ProductUsecase.GetProductByID(c.Request.Context(), helper.GetTenant(c), productID)
+	payload := &entity.GetProductByIDPayload{
+		ID:           productID,
+		Tenant:       helper.GetTenant(c),
+		Region:       helper.GetRegion(c),
+		FinanceScope: helper.HasScope(c, config.FinanceScope),
+		Fieldset:     fieldset,
+	}
+	product, err := h.ProductUsecase.GetProductByID(c.Request.Context(), payload)
 	if err != nil {
 		h.Logger.Error(err, "http - v1 - GetProductByID")
 		response.Error(c, err)
@@ -146,7 +313,18 @@ func (h *ProductHandler) GetProductByID(c *gin.Context) {
 		return
 	}
 
-	response.OK(c, product, "")
+	var data interface{} = product
+	if fieldset != nil {
+		if data, err = fieldset.Select(product); err != nil {
+			h.Logger.Error(err, "http - v1 - GetProductByID")
+			response.Error(c, err)
+
+			return
+		}
+	}
+
+	c.Header("ETag", helper.ETag(product.Version))
+	response.OK(c, data, "")
 }
 
 // @Summary     Show Product List
@@ -156,20 +334,41 @@ func (h *ProductHandler) GetProductByID(c *gin.Context) {
 // @Accept      json
 // @Produce     json
 // @Param       X-Tenant 		header	string 		true "Tenant Header" 							default(lorem)	example(lorem, ipsum)
-// @Param       keyword 		query		string 		false "title search by keyword"
-// @Param       sku 				query 	string 		false "sku product"
-// @Param       category 		query 	string 		false "category product"
-// @Param       condition		query 	string		false "condition product"					example(new, preloved)
-// @Param       orderby 		query 	string 		false "order by"
+// @Param       X-Region 		header	string 		false "Region Header" 						example(ID)
+// @Param       X-Scopes 		header	string 		false "Scopes Header, finance scope is required to see cost price" 	example(finance)
+// @Param       keyword 		query		string 		false "full-text search of title, description and attributes in the language of the tenant, supports quoted phrases, or and -word. Matches are sorted by relevance when no sort is given and get a highlight"
+// @Param       sku 				query 	string 		false "sku product, comma separated"
+// @Param       category 		query 	string 		false "category product, comma separated"			example(book,bag)
+// @Param       condition		query 	string		false "condition product, comma separated"		example(new,preloved)
+// @Param       min_price		query 	integer		false "minimum price"
+// @Param       max_price		query 	integer		false "maximum price"
+// @Param       min_qty			query 	integer		false "minimum qty"
+// @Param       max_qty			query 	integer		false "maximum qty"
+// @Param       created_from	query 	string		false "created at or after, RFC3339 or date"		example(2023-12-01)
+// @Param       created_to		query 	string		false "created at or before, RFC3339 or date"		example(2023-12-31)
+// @Param       updated_from	query 	string		false "updated at or after, RFC3339 or date"		example(2023-12-01)
+// @Param       updated_to		query 	string		false "updated at or before, RFC3339 or date"		example(2023-12-31)
+// @Param       stock 			query 	string		false "stock filter, low returns the products at or below their low stock threshold, in and out return the products with and without qty left after the reserved and backordered qty"	example(low, in, out)
+// @Param       sort 			query 	string 		false "comma separated sort keys of price, qty, title, updated_at, created_at and relevance, a key prefixed by - is sorted descending, the id breaks the ties"	example(-price,title)
+// @Param       orderby 		query 	string 		false "order by a single field, ignored when sort is given"	example(created_at DESC)
 // @Param       offset 			query 	integer 	false "offset"
 // @Param       limit 			query 	integer 	false "limit"
+// @Param       cursor 			query 	string 		false "next_cursor or prev_cursor of a page, the offset is ignored and the order of the cursor is kept"
+// @Param       fields			query		string	false	"comma separated fields of the response, all fields are given when it is empty"	example(id,title,price)
+// @Param       include			query		string	false	"comma separated relations embedded in the response, stocks and lots are embedded when neither fields nor include is given"	example(stocks,lots)
 // @Success     200 {object} response.SuccessBody{data=[]entity.Product,meta=response.MetaInfo}
 // @Failure     404 {object} response.ErrorBody
+// @Failure     422 {object} response.ErrorBody
 // @Failure     500 {object} response.ErrorBody
 // @Router      /products [get]
 func (h *ProductHandler) GetProducts(c *gin.Context) {
-	payload := h.ProductParser.ParseGetProductPayload(c)
-	products, total, err := h.ProductUsecase.GetProducts(c.Request.Context(), payload)
+	payload, err := h.ProductParser.ParseGetProductPayload(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	page, err := h.ProductUsecase.GetProducts(c.Request.Context(), payload)
 	if err != nil {
 		h.Logger.Error(err, "http - v1 - GetProducts")
 		response.Error(c, err)
@@ -177,20 +376,43 @@ func (h *ProductHandler) GetProducts(c *gin.Context) {
 		return
 	}
 
-	response.OKWithPagination(c, products, "", total, payload.Offset, payload.Limit)
+	var data interface{} = page.Products
+	if payload.Fieldset != nil {
+		if data, err = payload.Fieldset.SelectList(page.Products); err != nil {
+			h.Logger.Error(err, "http - v1 - GetProducts")
+			response.Error(c, err)
+
+			return
+		}
+	}
+
+	response.OKWithCursors(c, data, "", page.Total, payload.Offset, payload.Limit, page.NextCursor, page.PrevCursor)
 }
 
 // @Summary     Update Product
 // @Description An API to update product
+// @Description Stocks replace the stock of all locations. Without stocks, added qty goes to the default location
+// @Description and reduced qty is taken from the locations picked by the configured allocation strategy.
+// @Description The cost price is kept when it is not given. With weighted_average_cost, the cost price is the unit cost of the added qty
+// @Description and the stored cost price becomes the weighted average of the current stock and the added qty.
+// @Description The change of qty is recorded on the stock movements with stock_reason, adjustment when it is not given
 // @ID          update
 // @Tags  	    product
 // @Param      	id path int true "Product ID"
 // @Accept      json
 // @Produce     json
 // @Param       X-Tenant	header	string 												true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Param       X-Region	header	string 												false	"Region Header"	example(ID)
+// @Param       X-Scopes	header	string 												false	"Scopes Header, finance scope is required to see and set cost price"	example(finance)
+// @Param       X-Actor		header	string 												false	"Actor Header, recorded on the stock movement when qty changes"	example(jane@example.com)
+// @Param       Idempotency-Key	header	string	false	"Idempotency Key Header, retries with the same key get the stored response"	example(order-123-attempt)
+// @Param       If-Match	header	string	false	"ETags of the product the change is based on, the change is rejected when the product has been changed since. Without it the change is applied on the latest version"	example("3")
 // @Param       request 	body 		entity.SwaggerProductPayload	true	"payload"
 // @Success     200 {object} response.SuccessBody{data=entity.Product,meta=response.MetaInfo}
+// @Header      200 {string} ETag "Version of the product"
 // @Failure     404 {object} response.ErrorBody
+// @Failure     409 {object} response.ErrorBody
+// @Failure     412 {object} response.ErrorBody
 // @Failure     422 {object} response.ErrorBody
 // @Failure     500 {object} response.ErrorBody
 // @Router      /products/{id} [put]
@@ -212,6 +434,10 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 
 	productID, _ := strconv.Atoi(c.Param("id"))
 	payload.Tenant = helper.GetTenant(c)
+	payload.Actor = helper.GetActor(c)
+	payload.Region = helper.GetRegion(c)
+	payload.FinanceScope = helper.HasScope(c, config.FinanceScope)
+	payload.Versions = helper.GetIfMatchVersions(c)
 	product, err := h.ProductUsecase.UpdateProduct(c.Request.Context(), productID, payload)
 	if err != nil {
 		if customErr, ok := err.(response.CustomError); ok {
@@ -226,5 +452,72 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", helper.ETag(product.Version))
+	response.OK(c, product, "")
+}
+
+// @Summary     Patch Product
+// @Description An API to partially update product with a json merge patch (RFC 7396), only the fields of the patch are validated and changed.
+// @Description A nullable field is removed with null, e.g. the tax_class_id, cost_price, low_stock_threshold, backorder_limit and restock_date.
+// @Description The attributes are merged key by key, an attribute is removed with null and all of them with null attributes.
+// @Description The qty and stocks are changed like on update product, the other attributes of the update payload are options of the change
+// @ID          patch
+// @Tags  	    product
+// @Param      	id path int true "Product ID"
+// @Accept      application/merge-patch+json,json
+// @Produce     json
+// @Param       X-Tenant	header	string 												true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Param       X-Region	header	string 												false	"Region Header"	example(ID)
+// @Param       X-Scopes	header	string 												false	"Scopes Header, finance scope is required to see and change cost price"	example(finance)
+// @Param       X-Actor		header	string 												false	"Actor Header, recorded on the stock movement when qty changes"	example(jane@example.com)
+// @Param       Idempotency-Key	header	string	false	"Idempotency Key Header, retries with the same key get the stored response"	example(order-123-attempt)
+// @Param       If-Match	header	string	false	"ETags of the product the patch is based on, the patch is rejected when the product has been changed since. Without it the patch is applied on the latest version"	example("3")
+// @Param       request 	body 		entity.SwaggerProductPayload	true	"Merge patch of the product payload"
+// @Success     200 {object} response.SuccessBody{data=entity.Product,meta=response.MetaInfo}
+// @Header      200 {string} ETag "Version of the product"
+// @Failure     403 {object} response.ErrorBody
+// @Failure     404 {object} response.ErrorBody
+// @Failure     409 {object} response.ErrorBody
+// @Failure     412 {object} response.ErrorBody
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /products/{id} [patch]
+func (h *ProductHandler) PatchProduct(c *gin.Context) {
+	functionName := "ProductHandler.PatchProduct"
+
+	payload, err := h.ProductParser.ParseProductPatchPayload(c.Request.Body)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.productParser.ParseProductPatchPayload: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+		return
+	}
+
+	productID, _ := strconv.Atoi(c.Param("id"))
+	payload.Tenant = helper.GetTenant(c)
+	payload.Actor = helper.GetActor(c)
+	payload.Region = helper.GetRegion(c)
+	payload.FinanceScope = helper.HasScope(c, config.FinanceScope)
+	payload.Versions = helper.GetIfMatchVersions(c)
+	product, err := h.ProductUsecase.PatchProduct(c.Request.Context(), productID, payload)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.ProductUsecase.PatchProduct: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	c.Header("ETag", helper.ETag(product.Version))
 	response.OK(c, product, "")
 }
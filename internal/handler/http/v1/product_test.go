@@ -1,9 +1,11 @@
 package v1_test
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -50,7 +52,7 @@ func TestCreateProduct(t *testing.T) {
 		{
 			name:              "success",
 			pProductRes:       &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType},
-			uProductRes:       &entity.Product{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType},
+			uProductRes:       &entity.Product{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, BackorderPolicy: types.BackorderPolicyDenyType},
 			httpStatusCodeRes: http.StatusOK,
 		},
 	}
@@ -87,7 +89,7 @@ func TestBulkReduceQtyProduct(t *testing.T) {
 		name              string
 		pProductRes       *entity.BulkReduceQtyProductPayload
 		pProductErr       error
-		uProductRes       []*entity.Product
+		uProductRes       *entity.BulkReduceQtyProductResult
 		uProductErr       error
 		httpStatusCodeRes int
 	}{
@@ -98,18 +100,23 @@ func TestBulkReduceQtyProduct(t *testing.T) {
 		},
 		{
 			name:              "insufficient error",
+			pProductRes:       &entity.BulkReduceQtyProductPayload{},
 			uProductErr:       response.ErrInsufficientStock,
 			httpStatusCodeRes: http.StatusUnprocessableEntity,
 		},
 		{
 			name:              "failed to bulk reduce qty product",
+			pProductRes:       &entity.BulkReduceQtyProductPayload{},
 			uProductErr:       errors.New("error bulk reduce qty product"),
 			httpStatusCodeRes: http.StatusInternalServerError,
 		},
 		{
-			name:              "success",
-			pProductRes:       &entity.BulkReduceQtyProductPayload{},
-			uProductRes:       []*entity.Product{{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType}},
+			name:        "success",
+			pProductRes: &entity.BulkReduceQtyProductPayload{},
+			uProductRes: &entity.BulkReduceQtyProductResult{
+				Items:    []*entity.BulkReduceQtyProductItemResult{{SKU: "SKU-1", ReqQty: 3, FulfilledQty: 2, BackorderedQty: 1, Status: types.BulkItemStatusAppliedType}},
+				Products: []*entity.Product{{ID: 1, SKU: "SKU-1", Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, BackorderPolicy: types.BackorderPolicyDenyType}},
+			},
 			httpStatusCodeRes: http.StatusOK,
 		},
 	}
@@ -141,12 +148,145 @@ func TestBulkReduceQtyProduct(t *testing.T) {
 	}
 }
 
+func TestBulkIncreaseQtyProduct(t *testing.T) {
+	testcases := []struct {
+		name              string
+		pProductRes       *entity.BulkIncreaseQtyProductPayload
+		pProductErr       error
+		uProductErr       error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "invalid reason",
+			pProductErr:       response.ErrInvalidStockMovementReason,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to parse payload",
+			pProductErr:       errors.New("error parse payload"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "product is not found",
+			pProductRes:       &entity.BulkIncreaseQtyProductPayload{},
+			uProductErr:       response.ErrNotFound,
+			httpStatusCodeRes: http.StatusNotFound,
+		},
+		{
+			name:              "failed to bulk increase qty product",
+			pProductRes:       &entity.BulkIncreaseQtyProductPayload{},
+			uProductErr:       errors.New("error bulk increase qty product"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			pProductRes:       &entity.BulkIncreaseQtyProductPayload{},
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "POST",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			pp := &testmock.ProductParserInterface{}
+			pp.On("ParseBulkIncreaseQtyProductPayload", mock.Anything).Return(tc.pProductRes, tc.pProductErr)
+
+			productUsecase := &testmock.ProductUsecaseInterface{}
+			productUsecase.On("BulkIncreaseQtyProduct", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.Product{}, tc.uProductErr)
+
+			h := &httpv1.ProductHandler{l, pp, productUsecase}
+			h.BulkIncreaseQtyProduct(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
+
+func TestAdjustQtyProduct(t *testing.T) {
+	testcases := []struct {
+		name              string
+		pProductRes       *entity.AdjustQtyProductPayload
+		pProductErr       error
+		uProductErr       error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "failed to parse payload",
+			pProductErr:       errors.New("error parse payload"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "insufficient stock",
+			pProductRes:       &entity.AdjustQtyProductPayload{},
+			uProductErr:       response.ErrInsufficientStock,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to adjust qty product",
+			pProductRes:       &entity.AdjustQtyProductPayload{},
+			uProductErr:       errors.New("error adjust qty product"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			pProductRes:       &entity.AdjustQtyProductPayload{},
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "POST",
+			}
+			ctx.Params = gin.Params{{Key: "id", Value: "123"}}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			pp := &testmock.ProductParserInterface{}
+			pp.On("ParseAdjustQtyProductPayload", mock.Anything).Return(tc.pProductRes, tc.pProductErr)
+
+			productUsecase := &testmock.ProductUsecaseInterface{}
+			productUsecase.On("AdjustQtyProduct", mock.Anything, 123, mock.Anything).Return(&entity.Product{}, tc.uProductErr)
+
+			h := &httpv1.ProductHandler{l, pp, productUsecase}
+			h.AdjustQtyProduct(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
+
 func TestGetProductByID(t *testing.T) {
 	testcases := []struct {
 		name              string
+		pFieldsetRes      *entity.ProductFieldset
+		pFieldsetErr      error
 		uProductErr       error
 		httpStatusCodeRes int
+		wantETag          string
+		wantData          string
 	}{
+		{
+			name:              "invalid fields",
+			pFieldsetErr:      response.ErrInvalidFields,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
 		{
 			name:              "product is not found",
 			uProductErr:       response.ErrNotFound,
@@ -160,6 +300,14 @@ func TestGetProductByID(t *testing.T) {
 		{
 			name:              "success",
 			httpStatusCodeRes: http.StatusOK,
+			wantETag:          `"3"`,
+		},
+		{
+			name:              "success with fields",
+			pFieldsetRes:      &entity.ProductFieldset{Fields: []string{"id", "title"}},
+			httpStatusCodeRes: http.StatusOK,
+			wantETag:          `"3"`,
+			wantData:          `{"id":123,"title":"New Product"}`,
 		},
 	}
 
@@ -177,14 +325,23 @@ func TestGetProductByID(t *testing.T) {
 			l.On("Error", mock.Anything, mock.Anything)
 
 			pp := &testmock.ProductParserInterface{}
+			pp.On("ParseProductFieldset", mock.Anything).Return(tc.pFieldsetRes, tc.pFieldsetErr)
 
 			productUsecase := &testmock.ProductUsecaseInterface{}
-			productUsecase.On("GetProductByID", mock.Anything, mock.Anything, mock.Anything).Return(&entity.Product{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType}, tc.uProductErr)
+			productUsecase.On("GetProductByID", mock.Anything, mock.Anything).Return(&entity.Product{ID: 123, Title: "New Product", Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, BackorderPolicy: types.BackorderPolicyDenyType, Version: 3}, tc.uProductErr)
 
 			h := &httpv1.ProductHandler{l, pp, productUsecase}
 			h.GetProductByID(ctx)
 
 			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+			assert.Equal(t, tc.wantETag, w.Header().Get("ETag"))
+			if tc.wantData != "" {
+				var body struct {
+					Data json.RawMessage `json:"data"`
+				}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				assert.JSONEq(t, tc.wantData, string(body.Data))
+			}
 		})
 	}
 }
@@ -192,14 +349,25 @@ func TestGetProductByID(t *testing.T) {
 func TestGetProducts(t *testing.T) {
 	testcases := []struct {
 		name              string
+		pProductErr       error
 		uProductErr       error
 		httpStatusCodeRes int
 	}{
+		{
+			name:              "invalid filter",
+			pProductErr:       response.ErrInvalidCategory,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
 		{
 			name:              "failed to get products",
 			uProductErr:       errors.New("error get products"),
 			httpStatusCodeRes: http.StatusInternalServerError,
 		},
+		{
+			name:              "invalid cursor",
+			uProductErr:       response.ErrInvalidCursor,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
 		{
 			name:              "success",
 			httpStatusCodeRes: http.StatusOK,
@@ -217,10 +385,10 @@ func TestGetProducts(t *testing.T) {
 			l.On("Error", mock.Anything, mock.Anything)
 
 			pp := &testmock.ProductParserInterface{}
-			pp.On("ParseGetProductPayload", mock.Anything).Return(&entity.GetProductPayload{})
+			pp.On("ParseGetProductPayload", mock.Anything).Return(&entity.GetProductPayload{}, tc.pProductErr)
 
 			productUsecase := &testmock.ProductUsecaseInterface{}
-			productUsecase.On("GetProducts", mock.Anything, mock.Anything).Return([]*entity.Product{{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType}}, 10, tc.uProductErr)
+			productUsecase.On("GetProducts", mock.Anything, mock.Anything).Return(&entity.ProductPage{Products: []*entity.Product{{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, BackorderPolicy: types.BackorderPolicyDenyType}}, Total: 10}, tc.uProductErr)
 
 			h := &httpv1.ProductHandler{l, pp, productUsecase}
 			h.GetProducts(ctx)
@@ -237,6 +405,8 @@ func TestUpdateProduct(t *testing.T) {
 		pProductErr       error
 		uProductRes       *entity.Product
 		uProductErr       error
+		ifMatch           string
+		wantVersions      []int
 		httpStatusCodeRes int
 	}{
 		{
@@ -255,6 +425,14 @@ func TestUpdateProduct(t *testing.T) {
 			uProductErr:       response.ErrForbidden,
 			httpStatusCodeRes: http.StatusForbidden,
 		},
+		{
+			name:              "stale version",
+			pProductRes:       &entity.ProductPayload{},
+			uProductErr:       response.ErrStaleVersion,
+			ifMatch:           `"2"`,
+			wantVersions:      []int{2},
+			httpStatusCodeRes: http.StatusPreconditionFailed,
+		},
 		{
 			name:              "failed to update product",
 			pProductRes:       &entity.ProductPayload{},
@@ -262,9 +440,32 @@ func TestUpdateProduct(t *testing.T) {
 			httpStatusCodeRes: http.StatusInternalServerError,
 		},
 		{
-			name:              "success",
+			name:              "success without if match",
+			pProductRes:       &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType},
+			uProductRes:       &entity.Product{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, BackorderPolicy: types.BackorderPolicyDenyType},
+			httpStatusCodeRes: http.StatusOK,
+		},
+		{
+			name:              "success with any version",
+			pProductRes:       &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType},
+			uProductRes:       &entity.Product{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, BackorderPolicy: types.BackorderPolicyDenyType},
+			ifMatch:           "*",
+			httpStatusCodeRes: http.StatusOK,
+		},
+		{
+			name:              "weak entity tag never matches",
 			pProductRes:       &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType},
-			uProductRes:       &entity.Product{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType},
+			uProductErr:       response.ErrStaleVersion,
+			ifMatch:           `W/"2"`,
+			wantVersions:      []int{0},
+			httpStatusCodeRes: http.StatusPreconditionFailed,
+		},
+		{
+			name:              "success with list of entity tags",
+			pProductRes:       &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType},
+			uProductRes:       &entity.Product{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, BackorderPolicy: types.BackorderPolicyDenyType},
+			ifMatch:           `"1", W/"2", "other"`,
+			wantVersions:      []int{1, 0, 0},
 			httpStatusCodeRes: http.StatusOK,
 		},
 	}
@@ -278,6 +479,9 @@ func TestUpdateProduct(t *testing.T) {
 				Header: make(http.Header),
 				Method: "PUT",
 			}
+			if tc.ifMatch != "" {
+				ctx.Request.Header.Set("If-Match", tc.ifMatch)
+			}
 
 			l := &testmock.LoggerInterface{}
 			l.On("Error", mock.Anything, mock.Anything)
@@ -286,7 +490,9 @@ func TestUpdateProduct(t *testing.T) {
 			pp.On("ParseProductPayload", mock.Anything).Return(tc.pProductRes, tc.pProductErr)
 
 			productUsecase := &testmock.ProductUsecaseInterface{}
-			productUsecase.On("UpdateProduct", mock.Anything, mock.Anything, mock.Anything).Return(tc.uProductRes, tc.uProductErr)
+			productUsecase.On("UpdateProduct", mock.Anything, mock.Anything, mock.MatchedBy(func(payload *entity.ProductPayload) bool {
+				return reflect.DeepEqual(tc.wantVersions, payload.Versions)
+			})).Return(tc.uProductRes, tc.uProductErr)
 
 			h := &httpv1.ProductHandler{l, pp, productUsecase}
 			h.UpdateProduct(ctx)
@@ -295,3 +501,92 @@ func TestUpdateProduct(t *testing.T) {
 		})
 	}
 }
+
+func TestPatchProduct(t *testing.T) {
+	testcases := []struct {
+		name              string
+		pProductRes       *entity.ProductPatchPayload
+		pProductErr       error
+		uProductRes       *entity.Product
+		uProductErr       error
+		ifMatch           string
+		wantVersions      []int
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "patch is not a json object",
+			pProductErr:       response.ErrInvalidPatch,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to parse patch",
+			pProductErr:       errors.New("error parse patch"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "forbidden",
+			pProductRes:       &entity.ProductPatchPayload{},
+			uProductErr:       response.ErrForbidden,
+			httpStatusCodeRes: http.StatusForbidden,
+		},
+		{
+			name:              "failed to patch product",
+			pProductRes:       &entity.ProductPatchPayload{},
+			uProductErr:       errors.New("error patch product"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "stale version",
+			pProductRes:       &entity.ProductPatchPayload{},
+			uProductErr:       response.ErrStaleVersion,
+			ifMatch:           `"2"`,
+			wantVersions:      []int{2},
+			httpStatusCodeRes: http.StatusPreconditionFailed,
+		},
+		{
+			name:              "success without if match",
+			pProductRes:       &entity.ProductPatchPayload{},
+			uProductRes:       &entity.Product{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, BackorderPolicy: types.BackorderPolicyDenyType},
+			httpStatusCodeRes: http.StatusOK,
+		},
+		{
+			name:              "success with list of entity tags",
+			pProductRes:       &entity.ProductPatchPayload{},
+			uProductRes:       &entity.Product{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, BackorderPolicy: types.BackorderPolicyDenyType},
+			ifMatch:           `W/"1", "2"`,
+			wantVersions:      []int{0, 2},
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "PATCH",
+			}
+			if tc.ifMatch != "" {
+				ctx.Request.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			pp := &testmock.ProductParserInterface{}
+			pp.On("ParseProductPatchPayload", mock.Anything).Return(tc.pProductRes, tc.pProductErr)
+
+			productUsecase := &testmock.ProductUsecaseInterface{}
+			productUsecase.On("PatchProduct", mock.Anything, mock.Anything, mock.MatchedBy(func(payload *entity.ProductPatchPayload) bool {
+				return reflect.DeepEqual(tc.wantVersions, payload.Versions)
+			})).Return(tc.uProductRes, tc.uProductErr)
+
+			h := &httpv1.ProductHandler{l, pp, productUsecase}
+			h.PatchProduct(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package v1
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/config"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	"github.com/satriowisnugroho/catalog/internal/parser"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/pkg/logger"
+)
+
+type ReportHandler struct {
+	Logger        logger.LoggerInterface
+	ProductParser parser.ProductParserInterface
+	ReportUsecase usecase.ReportUsecaseInterface
+}
+
+func newReportHandler(
+	handler *gin.RouterGroup,
+	l logger.LoggerInterface,
+	pp parser.ProductParserInterface,
+	ru usecase.ReportUsecaseInterface,
+) {
+	r := &ReportHandler{l, pp, ru}
+
+	h := handler.Group("/reports")
+	{
+		h.GET("/margins", r.GetProductMargins)
+		h.GET("/inventory-valuation", r.GetInventoryValuations)
+		h.GET("/expiring-lots", r.GetExpiringLots)
+	}
+}
+
+// @Summary     Show Product Margin Report
+// @Description An API to show margin of products on their running price, margin rate is in basis points.
+// @Description Margin is empty for products without cost price.
+// @ID          report-margin
+// @Tags  	    report
+// @Accept      json
+// @Produce     json
+// @Param       X-Tenant 		header	string 		true "Tenant Header" 		default(lorem)	example(lorem, ipsum)
+// @Param       X-Scopes 		header	string 		true "Scopes Header" 		example(finance)
+// @Param       keyword 		query		string 		false "full-text search of title, description and attributes in the language of the tenant"
+// @Param       sku 				query 	string 		false "sku product, comma separated"
+// @Param       category 		query 	string 		false "category product, comma separated"	example(book,bag)
+// @Param       condition		query 	string		false "condition product, comma separated"	example(new,preloved)
+// @Param       sort 			query 	string 		false "comma separated sort keys of price, qty, title, updated_at, created_at and relevance, a key prefixed by - is sorted descending, the id breaks the ties"	example(-price,title)
+// @Param       orderby 		query 	string 		false "order by a single field, ignored when sort is given"	example(created_at DESC)
+// @Param       offset 			query 	integer 	false "offset"
+// @Param       limit 			query 	integer 	false "limit"
+// @Success     200 {object} response.SuccessBody{data=[]entity.ProductMargin,meta=response.MetaInfo}
+// @Failure     403 {object} response.ErrorBody
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /reports/margins [get]
+func (h *ReportHandler) GetProductMargins(c *gin.Context) {
+	functionName := "ReportHandler.GetProductMargins"
+
+	payload, err := h.ProductParser.ParseGetProductPayload(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	productMargins, total, err := h.ReportUsecase.GetProductMargins(c.Request.Context(), payload)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.ReportUsecase.GetProductMargins: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OKWithPagination(c, productMargins, "", total, payload.Offset, payload.Limit)
+}
+
+// @Summary     Show Inventory Valuation Report
+// @Description An API to show value of the stock on hand at cost and at regular price per category and condition
+// @ID          report-inventory-valuation
+// @Tags  	    report
+// @Accept      json
+// @Produce     json
+// @Param       X-Tenant	header	string	true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Param       X-Scopes	header	string	true	"Scopes Header"	example(finance)
+// @Success     200 {object} response.SuccessBody{data=[]entity.InventoryValuation,meta=response.MetaInfo}
+// @Failure     403 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /reports/inventory-valuation [get]
+func (h *ReportHandler) GetInventoryValuations(c *gin.Context) {
+	functionName := "ReportHandler.GetInventoryValuations"
+
+	payload := &entity.GetInventoryValuationPayload{
+		Tenant:       helper.GetTenant(c),
+		FinanceScope: helper.HasScope(c, config.FinanceScope),
+	}
+	valuations, err := h.ReportUsecase.GetInventoryValuations(c.Request.Context(), payload)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.ReportUsecase.GetInventoryValuations: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, valuations, "")
+}
+
+// @Summary     Show Expiring Lot Report
+// @Description An API to show the lots in stock which expire within the given days, the first expiring first.
+// @Description The expired lots are included, their qty is still on hand but not available
+// @ID          report-expiring-lot
+// @Tags  	    report
+// @Accept      json
+// @Produce     json
+// @Param       X-Tenant	header	string	true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Param       days			query		int			false	"Days ahead, default 30"
+// @Success     200 {object} response.SuccessBody{data=[]entity.ExpiringLot,meta=response.MetaInfo}
+// @Failure     500 {object} response.ErrorBody
+// @Router      /reports/expiring-lots [get]
+func (h *ReportHandler) GetExpiringLots(c *gin.Context) {
+	functionName := "ReportHandler.GetExpiringLots"
+
+	days, _ := strconv.Atoi(c.Query("days"))
+	payload := &entity.GetExpiringLotPayload{
+		Tenant: helper.GetTenant(c),
+		Days:   days,
+	}
+	lots, err := h.ReportUsecase.GetExpiringLots(c.Request.Context(), payload)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.ReportUsecase.GetExpiringLots: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, lots, "")
+}
@@ -0,0 +1,161 @@
+package v1_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	httpv1 "github.com/satriowisnugroho/catalog/internal/handler/http/v1"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetProductMargins(t *testing.T) {
+	testcases := []struct {
+		name              string
+		pProductErr       error
+		uMarginsErr       error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "invalid filter",
+			pProductErr:       response.ErrInvalidCategory,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "without finance scope",
+			uMarginsErr:       response.ErrForbidden,
+			httpStatusCodeRes: http.StatusForbidden,
+		},
+		{
+			name:              "failed to get product margins",
+			uMarginsErr:       errors.New("error get product margins"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "GET",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			pp := &testmock.ProductParserInterface{}
+			pp.On("ParseGetProductPayload", mock.Anything).Return(&entity.GetProductPayload{}, tc.pProductErr)
+
+			margins := []*entity.ProductMargin{{Category: types.CategoryBookType, Condition: types.ConditionNewType}}
+			reportUsecase := &testmock.ReportUsecaseInterface{}
+			reportUsecase.On("GetProductMargins", mock.Anything, mock.Anything).Return(margins, 1, tc.uMarginsErr)
+
+			h := &httpv1.ReportHandler{l, pp, reportUsecase}
+			h.GetProductMargins(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
+
+func TestGetInventoryValuations(t *testing.T) {
+	testcases := []struct {
+		name              string
+		uValuationsErr    error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "without finance scope",
+			uValuationsErr:    response.ErrForbidden,
+			httpStatusCodeRes: http.StatusForbidden,
+		},
+		{
+			name:              "failed to get inventory valuations",
+			uValuationsErr:    errors.New("error get inventory valuations"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "GET",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			valuations := []*entity.InventoryValuation{{Category: types.CategoryBookType, Condition: types.ConditionNewType}}
+			reportUsecase := &testmock.ReportUsecaseInterface{}
+			reportUsecase.On("GetInventoryValuations", mock.Anything, mock.Anything).Return(valuations, tc.uValuationsErr)
+
+			h := &httpv1.ReportHandler{l, &testmock.ProductParserInterface{}, reportUsecase}
+			h.GetInventoryValuations(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
+
+func TestGetExpiringLots(t *testing.T) {
+	testcases := []struct {
+		name              string
+		uLotsErr          error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "failed to get expiring lots",
+			uLotsErr:          errors.New("error get expiring lots"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request, _ = http.NewRequest("GET", "/reports/expiring-lots?days=7", nil)
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			lots := []*entity.ExpiringLot{{ProductID: 123, SKU: "SKU-123", LotNumber: "LOT-1", Qty: 5}}
+			reportUsecase := &testmock.ReportUsecaseInterface{}
+			reportUsecase.On("GetExpiringLots", mock.Anything, mock.MatchedBy(func(payload *entity.GetExpiringLotPayload) bool {
+				return payload.Days == 7
+			})).Return(lots, tc.uLotsErr)
+
+			h := &httpv1.ReportHandler{l, &testmock.ProductParserInterface{}, reportUsecase}
+			h.GetExpiringLots(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package v1
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	"github.com/satriowisnugroho/catalog/internal/parser"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/pkg/logger"
+)
+
+type ReservationHandler struct {
+	Logger             logger.LoggerInterface
+	ReservationParser  parser.ReservationParserInterface
+	ReservationUsecase usecase.ReservationUsecaseInterface
+}
+
+func newReservationHandler(
+	handler *gin.RouterGroup,
+	l logger.LoggerInterface,
+	rp parser.ReservationParserInterface,
+	ru usecase.ReservationUsecaseInterface,
+) {
+	r := &ReservationHandler{l, rp, ru}
+
+	h := handler.Group("/reservations")
+	{
+		h.POST("/", r.CreateReservation)
+		h.GET("/:id", r.GetReservationByID)
+		h.POST("/:id/confirm", r.ConfirmReservation)
+		h.POST("/:id/release", r.ReleaseReservation)
+	}
+}
+
+// @Summary     Create Reservation
+// @Description An API to hold qty of products for a checkout, the held qty can not be reduced by other requests.
+// @Description The reservation is released automatically when it is not confirmed before ttl_seconds, the configured ttl is used when it is not given
+// @ID          create-reservation
+// @Tags  	    reservation
+// @Accept      json
+// @Produce     json
+// @Param       X-Tenant	header	string 													true	"Tenant Header"		default(lorem)	example(lorem, ipsum)
+// @Param       request		body 		entity.SwaggerReservationPayload	true 	"payload"
+// @Success     200 {object} response.SuccessBody{data=entity.Reservation,meta=response.MetaInfo}
+// @Failure     403 {object} response.ErrorBody
+// @Failure     404 {object} response.ErrorBody
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /reservations [post]
+func (h *ReservationHandler) CreateReservation(c *gin.Context) {
+	functionName := "ReservationHandler.CreateReservation"
+
+	payload, err := h.ReservationParser.ParseReservationPayload(c.Request.Body)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.ReservationParser.ParseReservationPayload: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+		return
+	}
+
+	payload.Tenant = helper.GetTenant(c)
+	reservation, err := h.ReservationUsecase.CreateReservation(c.Request.Context(), payload)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.ReservationUsecase.CreateReservation: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, reservation, "")
+}
+
+// @Summary     Show Reservation Detail
+// @Description An API to show reservation detail
+// @ID          detail-reservation
+// @Tags  	    reservation
+// @Accept      json
+// @Produce     json
+// @Param      	id				path		int			true	"Reservation ID"
+// @Param       X-Tenant	header	string	true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Success     200 {object} response.SuccessBody{data=entity.Reservation,meta=response.MetaInfo}
+// @Failure     403 {object} response.ErrorBody
+// @Failure     404 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /reservations/{id} [get]
+func (h *ReservationHandler) GetReservationByID(c *gin.Context) {
+	reservationID, _ := strconv.Atoi(c.Param("id"))
+	reservation, err := h.ReservationUsecase.GetReservationByID(c.Request.Context(), helper.GetTenant(c), reservationID)
+	if err != nil {
+		h.Logger.Error(err, "http - v1 - GetReservationByID")
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, reservation, "")
+}
+
+// @Summary     Confirm Reservation
+// @Description An API to confirm an active reservation, the held qty is reduced from the stock
+// @Description using the configured allocation strategy and recorded as a sale on the stock movements
+// @ID          confirm-reservation
+// @Tags  	    reservation
+// @Accept      json
+// @Produce     json
+// @Param      	id				path		int			true	"Reservation ID"
+// @Param       X-Tenant	header	string	true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Param       X-Actor		header	string	false	"Actor Header, recorded on the stock movements"	example(jane@example.com)
+// @Success     200 {object} response.SuccessBody{data=entity.Reservation,meta=response.MetaInfo}
+// @Failure     403 {object} response.ErrorBody
+// @Failure     404 {object} response.ErrorBody
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /reservations/{id}/confirm [post]
+func (h *ReservationHandler) ConfirmReservation(c *gin.Context) {
+	functionName := "ReservationHandler.ConfirmReservation"
+
+	reservationID, _ := strconv.Atoi(c.Param("id"))
+	reservation, err := h.ReservationUsecase.ConfirmReservation(c.Request.Context(), helper.GetTenant(c), reservationID, helper.GetActor(c))
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.ReservationUsecase.ConfirmReservation: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, reservation, "")
+}
+
+// @Summary     Release Reservation
+// @Description An API to release an active reservation, the held qty becomes available again without reducing the stock
+// @ID          release-reservation
+// @Tags  	    reservation
+// @Accept      json
+// @Produce     json
+// @Param      	id				path		int			true	"Reservation ID"
+// @Param       X-Tenant	header	string	true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Success     200 {object} response.SuccessBody{data=entity.Reservation,meta=response.MetaInfo}
+// @Failure     403 {object} response.ErrorBody
+// @Failure     404 {object} response.ErrorBody
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /reservations/{id}/release [post]
+func (h *ReservationHandler) ReleaseReservation(c *gin.Context) {
+	functionName := "ReservationHandler.ReleaseReservation"
+
+	reservationID, _ := strconv.Atoi(c.Param("id"))
+	reservation, err := h.ReservationUsecase.ReleaseReservation(c.Request.Context(), helper.GetTenant(c), reservationID)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.ReservationUsecase.ReleaseReservation: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, reservation, "")
+}
@@ -0,0 +1,221 @@
+package v1_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	httpv1 "github.com/satriowisnugroho/catalog/internal/handler/http/v1"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateReservation(t *testing.T) {
+	testcases := []struct {
+		name              string
+		pPayloadRes       *entity.ReservationPayload
+		pPayloadErr       error
+		uReservationRes   *entity.Reservation
+		uReservationErr   error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "parser return custom error",
+			pPayloadErr:       response.ErrInvalidReservationItems,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to parse reservation payload",
+			pPayloadErr:       errors.New("error parse reservation payload"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "insufficient stock",
+			pPayloadRes:       &entity.ReservationPayload{},
+			uReservationErr:   response.ErrInsufficientStock,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to create reservation",
+			pPayloadRes:       &entity.ReservationPayload{},
+			uReservationErr:   errors.New("error create reservation"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			pPayloadRes:       &entity.ReservationPayload{},
+			uReservationRes:   &entity.Reservation{Tenant: types.TenantLoremType, Status: types.ReservationStatusActiveType},
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "POST",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			rp := &testmock.ReservationParserInterface{}
+			rp.On("ParseReservationPayload", mock.Anything).Return(tc.pPayloadRes, tc.pPayloadErr)
+
+			reservationUsecase := &testmock.ReservationUsecaseInterface{}
+			reservationUsecase.On("CreateReservation", mock.Anything, mock.Anything).Return(tc.uReservationRes, tc.uReservationErr)
+
+			h := &httpv1.ReservationHandler{l, rp, reservationUsecase}
+			h.CreateReservation(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
+
+func TestGetReservationByID(t *testing.T) {
+	testcases := []struct {
+		name              string
+		uReservationErr   error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "reservation not found",
+			uReservationErr:   response.ErrNotFound,
+			httpStatusCodeRes: http.StatusNotFound,
+		},
+		{
+			name:              "failed to get reservation",
+			uReservationErr:   errors.New("error get reservation"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "GET",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			reservationUsecase := &testmock.ReservationUsecaseInterface{}
+			reservationUsecase.On("GetReservationByID", mock.Anything, mock.Anything, mock.Anything).Return(&entity.Reservation{Tenant: types.TenantLoremType, Status: types.ReservationStatusActiveType}, tc.uReservationErr)
+
+			h := &httpv1.ReservationHandler{l, &testmock.ReservationParserInterface{}, reservationUsecase}
+			h.GetReservationByID(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
+
+func TestConfirmReservation(t *testing.T) {
+	testcases := []struct {
+		name              string
+		uReservationErr   error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "reservation is not active",
+			uReservationErr:   response.ErrReservationNotActive,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to confirm reservation",
+			uReservationErr:   errors.New("error confirm reservation"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "POST",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			reservationUsecase := &testmock.ReservationUsecaseInterface{}
+			reservationUsecase.On("ConfirmReservation", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&entity.Reservation{Tenant: types.TenantLoremType, Status: types.ReservationStatusConfirmedType}, tc.uReservationErr)
+
+			h := &httpv1.ReservationHandler{l, &testmock.ReservationParserInterface{}, reservationUsecase}
+			h.ConfirmReservation(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
+
+func TestReleaseReservation(t *testing.T) {
+	testcases := []struct {
+		name              string
+		uReservationErr   error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "reservation is not active",
+			uReservationErr:   response.ErrReservationNotActive,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to release reservation",
+			uReservationErr:   errors.New("error release reservation"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "POST",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			reservationUsecase := &testmock.ReservationUsecaseInterface{}
+			reservationUsecase.On("ReleaseReservation", mock.Anything, mock.Anything, mock.Anything).Return(&entity.Reservation{Tenant: types.TenantLoremType, Status: types.ReservationStatusReleasedType}, tc.uReservationErr)
+
+			h := &httpv1.ReservationHandler{l, &testmock.ReservationParserInterface{}, reservationUsecase}
+			h.ReleaseReservation(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
@@ -26,6 +26,30 @@ func NewRouter(
 	l logger.LoggerInterface,
 	pp parser.ProductParserInterface,
 	p usecase.ProductUsecaseInterface,
+	psp parser.PriceScheduleParserInterface,
+	ps usecase.PriceScheduleUsecaseInterface,
+	php parser.PriceHistoryParserInterface,
+	ph usecase.PriceHistoryUsecaseInterface,
+	tcp parser.TaxClassParserInterface,
+	tc usecase.TaxClassUsecaseInterface,
+	drp parser.DiscountRuleParserInterface,
+	dr usecase.DiscountRuleUsecaseInterface,
+	ru usecase.ReportUsecaseInterface,
+	lp parser.LocationParserInterface,
+	lu usecase.LocationUsecaseInterface,
+	rp parser.ReservationParserInterface,
+	rsu usecase.ReservationUsecaseInterface,
+	smp parser.StockMovementParserInterface,
+	smu usecase.StockMovementUsecaseInterface,
+	iu usecase.IdempotencyKeyUsecaseInterface,
+	lsp parser.LowStockParserInterface,
+	lsu usecase.LowStockUsecaseInterface,
+	ccp parser.CycleCountParserInterface,
+	ccu usecase.CycleCountUsecaseInterface,
+	sp parser.SerialParserInterface,
+	su usecase.SerialUsecaseInterface,
+	tp parser.TransferParserInterface,
+	tu usecase.TransferUsecaseInterface,
 ) {
 	// Options
 	handler.Use(gin.Logger())
@@ -41,6 +65,18 @@ func NewRouter(
 	// Routers
 	h := handler.Group("/v1")
 	{
-		newProductHandler(h, l, pp, p)
+		newProductHandler(h, l, pp, p, iu)
+		newPriceScheduleHandler(h, l, psp, ps)
+		newPriceHistoryHandler(h, l, php, ph)
+		newTaxClassHandler(h, l, tcp, tc)
+		newDiscountRuleHandler(h, l, drp, dr)
+		newReportHandler(h, l, pp, ru)
+		newLocationHandler(h, l, lp, lu)
+		newReservationHandler(h, l, rp, rsu)
+		newStockMovementHandler(h, l, smp, smu)
+		newLowStockHandler(h, l, lsp, lsu)
+		newCycleCountHandler(h, l, ccp, ccu)
+		newSerialHandler(h, l, sp, su)
+		newTransferHandler(h, l, tp, tu)
 	}
 }
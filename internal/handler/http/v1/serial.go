@@ -0,0 +1,114 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	"github.com/satriowisnugroho/catalog/internal/parser"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/pkg/logger"
+)
+
+type SerialHandler struct {
+	Logger        logger.LoggerInterface
+	SerialParser  parser.SerialParserInterface
+	SerialUsecase usecase.SerialUsecaseInterface
+}
+
+func newSerialHandler(
+	handler *gin.RouterGroup,
+	l logger.LoggerInterface,
+	sp parser.SerialParserInterface,
+	su usecase.SerialUsecaseInterface,
+) {
+	r := &SerialHandler{l, sp, su}
+
+	h := handler.Group("/serials")
+	{
+		h.GET("/:serial_number", r.GetProductSerialBySerialNumber)
+	}
+
+	hp := handler.Group("/products/:id/serials")
+	{
+		hp.GET("/", r.GetProductSerials)
+	}
+}
+
+// @Summary     Show Serial Number Detail
+// @Description An API to look up the product of a serial number. A serial number which is out of stock
+// @Description has the reference id, actor and time of the deduction which took it, e.g. the sale
+// @ID          detail-serial
+// @Tags  	    serial
+// @Accept      json
+// @Produce     json
+// @Param      	serial_number	path		string	true	"Serial Number"
+// @Param       X-Tenant			header	string	true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Success     200 {object} response.SuccessBody{data=entity.ProductSerial,meta=response.MetaInfo}
+// @Failure     404 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /serials/{serial_number} [get]
+func (h *SerialHandler) GetProductSerialBySerialNumber(c *gin.Context) {
+	functionName := "SerialHandler.GetProductSerialBySerialNumber"
+
+	serial, err := h.SerialUsecase.GetProductSerialBySerialNumber(c.Request.Context(), helper.GetTenant(c), c.Param("serial_number"))
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.SerialUsecase.GetProductSerialBySerialNumber: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, serial, "")
+}
+
+// @Summary     Show Product Serial Numbers
+// @Description An API to show the serial numbers of a product in the order they were registered
+// @ID          product-serials
+// @Tags  	    serial
+// @Accept      json
+// @Produce     json
+// @Param      	id				path		int			true	"Product ID"
+// @Param       X-Tenant	header	string	true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Param       status		query		string	false	"status of the serial number"	example(in_stock, sold, written_off)
+// @Param       offset		query 	integer false	"offset"
+// @Param       limit			query 	integer false	"limit"
+// @Success     200 {object} response.SuccessBody{data=[]entity.ProductSerial,meta=response.MetaInfo}
+// @Failure     403 {object} response.ErrorBody
+// @Failure     404 {object} response.ErrorBody
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /products/{id}/serials [get]
+func (h *SerialHandler) GetProductSerials(c *gin.Context) {
+	functionName := "SerialHandler.GetProductSerials"
+
+	payload, err := h.SerialParser.ParseGetProductSerialPayload(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	serials, total, err := h.SerialUsecase.GetProductSerials(c.Request.Context(), payload)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.SerialUsecase.GetProductSerials: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OKWithPagination(c, serials, "", total, payload.Offset, payload.Limit)
+}
@@ -0,0 +1,114 @@
+package v1_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	httpv1 "github.com/satriowisnugroho/catalog/internal/handler/http/v1"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetProductSerialBySerialNumber(t *testing.T) {
+	testcases := []struct {
+		name              string
+		uSerialErr        error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "serial number is not found",
+			uSerialErr:        response.ErrNotFound,
+			httpStatusCodeRes: http.StatusNotFound,
+		},
+		{
+			name:              "failed to get serial number",
+			uSerialErr:        errors.New("error get serial number"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request, _ = http.NewRequest("GET", "/serials/SN-1", nil)
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			sp := &testmock.SerialParserInterface{}
+
+			serialUsecase := &testmock.SerialUsecaseInterface{}
+			serialUsecase.On("GetProductSerialBySerialNumber", mock.Anything, mock.Anything, mock.Anything).Return(&entity.ProductSerial{Status: types.SerialStatusSoldType}, tc.uSerialErr)
+
+			h := &httpv1.SerialHandler{l, sp, serialUsecase}
+			h.GetProductSerialBySerialNumber(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
+
+func TestGetProductSerials(t *testing.T) {
+	testcases := []struct {
+		name              string
+		pPayloadErr       error
+		uSerialsErr       error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "invalid status",
+			pPayloadErr:       response.ErrInvalidSerialStatus,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "product is not found",
+			uSerialsErr:       response.ErrNotFound,
+			httpStatusCodeRes: http.StatusNotFound,
+		},
+		{
+			name:              "failed to get serial numbers",
+			uSerialsErr:       errors.New("error get serial numbers"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request, _ = http.NewRequest("GET", "/products/123/serials?status=sold", nil)
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			sp := &testmock.SerialParserInterface{}
+			sp.On("ParseGetProductSerialPayload", mock.Anything).Return(&entity.GetProductSerialPayload{}, tc.pPayloadErr)
+
+			serialUsecase := &testmock.SerialUsecaseInterface{}
+			serialUsecase.On("GetProductSerials", mock.Anything, mock.Anything).Return([]*entity.ProductSerial{}, 0, tc.uSerialsErr)
+
+			h := &httpv1.SerialHandler{l, sp, serialUsecase}
+			h.GetProductSerials(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/parser"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/pkg/logger"
+)
+
+type StockMovementHandler struct {
+	Logger               logger.LoggerInterface
+	StockMovementParser  parser.StockMovementParserInterface
+	StockMovementUsecase usecase.StockMovementUsecaseInterface
+}
+
+func newStockMovementHandler(
+	handler *gin.RouterGroup,
+	l logger.LoggerInterface,
+	smp parser.StockMovementParserInterface,
+	smu usecase.StockMovementUsecaseInterface,
+) {
+	r := &StockMovementHandler{l, smp, smu}
+
+	h := handler.Group("/products/:id/stock-movements")
+	{
+		h.GET("/", r.GetStockMovements)
+	}
+}
+
+// @Summary     Show Product Stock Movements
+// @Description An API to show the ledger of qty changes of a product from the latest one.
+// @Description Each movement has the delta, the qty after the change, the reason, the actor and the reference id
+// @ID          stock-movements
+// @Tags  	    product
+// @Accept      json
+// @Produce     json
+// @Param      	id				path		int			true	"Product ID"
+// @Param       X-Tenant	header	string	true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Param       reason		query		string	false	"reason of the movement"	example(sale, restock, adjustment, return, damage)
+// @Param       from			query		string	false	"start of period, RFC3339 or YYYY-MM-DD"
+// @Param       to				query		string	false	"end of period, RFC3339 or YYYY-MM-DD"
+// @Param       offset		query 	integer false	"offset"
+// @Param       limit			query 	integer false	"limit"
+// @Success     200 {object} response.SuccessBody{data=[]entity.StockMovement,meta=response.MetaInfo}
+// @Failure     403 {object} response.ErrorBody
+// @Failure     404 {object} response.ErrorBody
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /products/{id}/stock-movements [get]
+func (h *StockMovementHandler) GetStockMovements(c *gin.Context) {
+	functionName := "StockMovementHandler.GetStockMovements"
+
+	payload, err := h.StockMovementParser.ParseGetStockMovementPayload(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	movements, total, err := h.StockMovementUsecase.GetStockMovements(c.Request.Context(), payload)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.StockMovementUsecase.GetStockMovements: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OKWithPagination(c, movements, "", total, payload.Offset, payload.Limit)
+}
@@ -0,0 +1,68 @@
+package v1_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	httpv1 "github.com/satriowisnugroho/catalog/internal/handler/http/v1"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetStockMovements(t *testing.T) {
+	testcases := []struct {
+		name              string
+		pPayloadErr       error
+		uMovementsErr     error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "invalid reason",
+			pPayloadErr:       response.ErrInvalidStockMovementReason,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "product is not found",
+			uMovementsErr:     response.ErrNotFound,
+			httpStatusCodeRes: http.StatusNotFound,
+		},
+		{
+			name:              "failed to get stock movements",
+			uMovementsErr:     errors.New("error get stock movements"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request, _ = http.NewRequest("GET", "/products/123/stock-movements?reason=sale", nil)
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			smp := &testmock.StockMovementParserInterface{}
+			smp.On("ParseGetStockMovementPayload", mock.Anything).Return(&entity.GetStockMovementPayload{}, tc.pPayloadErr)
+
+			stockMovementUsecase := &testmock.StockMovementUsecaseInterface{}
+			stockMovementUsecase.On("GetStockMovements", mock.Anything, mock.Anything).Return([]*entity.StockMovement{}, 0, tc.uMovementsErr)
+
+			h := &httpv1.StockMovementHandler{l, smp, stockMovementUsecase}
+			h.GetStockMovements(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
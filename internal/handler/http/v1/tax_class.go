@@ -0,0 +1,153 @@
+package v1
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	"github.com/satriowisnugroho/catalog/internal/parser"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/pkg/logger"
+)
+
+type TaxClassHandler struct {
+	Logger          logger.LoggerInterface
+	TaxClassParser  parser.TaxClassParserInterface
+	TaxClassUsecase usecase.TaxClassUsecaseInterface
+}
+
+func newTaxClassHandler(
+	handler *gin.RouterGroup,
+	l logger.LoggerInterface,
+	tcp parser.TaxClassParserInterface,
+	tcu usecase.TaxClassUsecaseInterface,
+) {
+	r := &TaxClassHandler{l, tcp, tcu}
+
+	h := handler.Group("/tax-classes")
+	{
+		h.POST("/", r.CreateTaxClass)
+		h.GET("/", r.GetTaxClasses)
+		h.PUT("/:id", r.UpdateTaxClass)
+	}
+}
+
+// @Summary     Create Tax Class
+// @Description An API to create tax class with its rates per region, rate is in basis points and empty region is the default rate
+// @ID          create-tax-class
+// @Tags  	    tax-class
+// @Accept      json
+// @Produce     json
+// @Param       X-Tenant	header	string 												true	"Tenant Header"		default(lorem)	example(lorem, ipsum)
+// @Param       request		body 		entity.SwaggerTaxClassPayload	true 	"payload"
+// @Success     200 {object} response.SuccessBody{data=entity.TaxClass,meta=response.MetaInfo}
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /tax-classes [post]
+func (h *TaxClassHandler) CreateTaxClass(c *gin.Context) {
+	functionName := "TaxClassHandler.CreateTaxClass"
+
+	payload, err := h.TaxClassParser.ParseTaxClassPayload(c.Request.Body)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.TaxClassParser.ParseTaxClassPayload: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+		return
+	}
+
+	payload.Tenant = helper.GetTenant(c)
+	taxClass, err := h.TaxClassUsecase.CreateTaxClass(c.Request.Context(), payload)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.TaxClassUsecase.CreateTaxClass: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, taxClass, "")
+}
+
+// @Summary     Show Tax Class List
+// @Description An API to show tax classes of the tenant
+// @ID          list-tax-class
+// @Tags  	    tax-class
+// @Accept      json
+// @Produce     json
+// @Param       X-Tenant	header	string	true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Success     200 {object} response.SuccessBody{data=[]entity.TaxClass,meta=response.MetaInfo}
+// @Failure     500 {object} response.ErrorBody
+// @Router      /tax-classes [get]
+func (h *TaxClassHandler) GetTaxClasses(c *gin.Context) {
+	taxClasses, err := h.TaxClassUsecase.GetTaxClasses(c.Request.Context(), helper.GetTenant(c))
+	if err != nil {
+		h.Logger.Error(err, "http - v1 - GetTaxClasses")
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, taxClasses, "")
+}
+
+// @Summary     Update Tax Class
+// @Description An API to update tax class, its rates and the categories defaulted to it
+// @ID          update-tax-class
+// @Tags  	    tax-class
+// @Accept      json
+// @Produce     json
+// @Param      	id				path		int														true	"Tax Class ID"
+// @Param       X-Tenant	header	string 												true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Param       request 	body 		entity.SwaggerTaxClassPayload	true	"payload"
+// @Success     200 {object} response.SuccessBody{data=entity.TaxClass,meta=response.MetaInfo}
+// @Failure     404 {object} response.ErrorBody
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /tax-classes/{id} [put]
+func (h *TaxClassHandler) UpdateTaxClass(c *gin.Context) {
+	functionName := "TaxClassHandler.UpdateTaxClass"
+
+	payload, err := h.TaxClassParser.ParseTaxClassPayload(c.Request.Body)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.TaxClassParser.ParseTaxClassPayload: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+		return
+	}
+
+	taxClassID, _ := strconv.Atoi(c.Param("id"))
+	payload.Tenant = helper.GetTenant(c)
+	taxClass, err := h.TaxClassUsecase.UpdateTaxClass(c.Request.Context(), taxClassID, payload)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.TaxClassUsecase.UpdateTaxClass: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, taxClass, "")
+}
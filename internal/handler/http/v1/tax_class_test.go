@@ -0,0 +1,190 @@
+package v1_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	httpv1 "github.com/satriowisnugroho/catalog/internal/handler/http/v1"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateTaxClass(t *testing.T) {
+	testcases := []struct {
+		name              string
+		pPayloadRes       *entity.TaxClassPayload
+		pPayloadErr       error
+		uTaxClassRes      *entity.TaxClass
+		uTaxClassErr      error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "parser return custom error",
+			pPayloadErr:       response.ErrInvalidCategory,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to parse tax class payload",
+			pPayloadErr:       errors.New("error parse tax class payload"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "duplicate tax class name",
+			pPayloadRes:       &entity.TaxClassPayload{},
+			uTaxClassErr:      response.ErrDuplicateTaxClassName,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to create tax class",
+			pPayloadRes:       &entity.TaxClassPayload{},
+			uTaxClassErr:      errors.New("error create tax class"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			pPayloadRes:       &entity.TaxClassPayload{},
+			uTaxClassRes:      &entity.TaxClass{Tenant: types.TenantLoremType, Categories: []types.CategoryType{types.CategoryBookType}},
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "POST",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			tcp := &testmock.TaxClassParserInterface{}
+			tcp.On("ParseTaxClassPayload", mock.Anything).Return(tc.pPayloadRes, tc.pPayloadErr)
+
+			taxClassUsecase := &testmock.TaxClassUsecaseInterface{}
+			taxClassUsecase.On("CreateTaxClass", mock.Anything, mock.Anything).Return(tc.uTaxClassRes, tc.uTaxClassErr)
+
+			h := &httpv1.TaxClassHandler{l, tcp, taxClassUsecase}
+			h.CreateTaxClass(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
+
+func TestGetTaxClasses(t *testing.T) {
+	testcases := []struct {
+		name              string
+		uTaxClassesErr    error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "failed to get tax classes",
+			uTaxClassesErr:    errors.New("error get tax classes"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "GET",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			taxClassUsecase := &testmock.TaxClassUsecaseInterface{}
+			taxClassUsecase.On("GetTaxClasses", mock.Anything, mock.Anything).Return([]*entity.TaxClass{{Tenant: types.TenantLoremType}}, tc.uTaxClassesErr)
+
+			h := &httpv1.TaxClassHandler{l, &testmock.TaxClassParserInterface{}, taxClassUsecase}
+			h.GetTaxClasses(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
+
+func TestUpdateTaxClass(t *testing.T) {
+	testcases := []struct {
+		name              string
+		pPayloadRes       *entity.TaxClassPayload
+		pPayloadErr       error
+		uTaxClassRes      *entity.TaxClass
+		uTaxClassErr      error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "parser return custom error",
+			pPayloadErr:       response.ErrInvalidCategory,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to parse tax class payload",
+			pPayloadErr:       errors.New("error parse tax class payload"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "tax class is not found",
+			pPayloadRes:       &entity.TaxClassPayload{},
+			uTaxClassErr:      response.ErrNotFound,
+			httpStatusCodeRes: http.StatusNotFound,
+		},
+		{
+			name:              "failed to update tax class",
+			pPayloadRes:       &entity.TaxClassPayload{},
+			uTaxClassErr:      errors.New("error update tax class"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			pPayloadRes:       &entity.TaxClassPayload{},
+			uTaxClassRes:      &entity.TaxClass{Tenant: types.TenantLoremType},
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "PUT",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			tcp := &testmock.TaxClassParserInterface{}
+			tcp.On("ParseTaxClassPayload", mock.Anything).Return(tc.pPayloadRes, tc.pPayloadErr)
+
+			taxClassUsecase := &testmock.TaxClassUsecaseInterface{}
+			taxClassUsecase.On("UpdateTaxClass", mock.Anything, mock.Anything, mock.Anything).Return(tc.uTaxClassRes, tc.uTaxClassErr)
+
+			h := &httpv1.TaxClassHandler{l, tcp, taxClassUsecase}
+			h.UpdateTaxClass(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package v1
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	"github.com/satriowisnugroho/catalog/internal/parser"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/pkg/logger"
+)
+
+type TransferHandler struct {
+	Logger          logger.LoggerInterface
+	TransferParser  parser.TransferParserInterface
+	TransferUsecase usecase.TransferUsecaseInterface
+}
+
+func newTransferHandler(
+	handler *gin.RouterGroup,
+	l logger.LoggerInterface,
+	tp parser.TransferParserInterface,
+	tu usecase.TransferUsecaseInterface,
+) {
+	r := &TransferHandler{l, tp, tu}
+
+	h := handler.Group("/transfers")
+	{
+		h.POST("/", r.CreateTransfer)
+		h.GET("/:id", r.GetTransferByID)
+		h.POST("/:id/dispatch", r.DispatchTransfer)
+		h.POST("/:id/receive", r.ReceiveTransfer)
+	}
+}
+
+// @Summary     Create Transfer
+// @Description An API to create a draft transfer of stock from a location to another location of the tenant.
+// @Description The qty of the products is only changed when the transfer is dispatched
+// @ID          create-transfer
+// @Tags  	    transfer
+// @Accept      json
+// @Produce     json
+// @Param       X-Tenant	header	string 									true	"Tenant Header"		default(lorem)	example(lorem, ipsum)
+// @Param       request		body 		entity.TransferPayload	true 	"payload"
+// @Success     200 {object} response.SuccessBody{data=entity.Transfer,meta=response.MetaInfo}
+// @Failure     403 {object} response.ErrorBody
+// @Failure     404 {object} response.ErrorBody
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /transfers [post]
+func (h *TransferHandler) CreateTransfer(c *gin.Context) {
+	functionName := "TransferHandler.CreateTransfer"
+
+	payload, err := h.TransferParser.ParseTransferPayload(c.Request.Body)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.TransferParser.ParseTransferPayload: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+		return
+	}
+
+	payload.Tenant = helper.GetTenant(c)
+	transfer, err := h.TransferUsecase.CreateTransfer(c.Request.Context(), payload)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.TransferUsecase.CreateTransfer: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, transfer, "")
+}
+
+// @Summary     Show Transfer Detail
+// @Description An API to show transfer detail with its items
+// @ID          detail-transfer
+// @Tags  	    transfer
+// @Accept      json
+// @Produce     json
+// @Param      	id				path		int			true	"Transfer ID"
+// @Param       X-Tenant	header	string	true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Success     200 {object} response.SuccessBody{data=entity.Transfer,meta=response.MetaInfo}
+// @Failure     403 {object} response.ErrorBody
+// @Failure     404 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /transfers/{id} [get]
+func (h *TransferHandler) GetTransferByID(c *gin.Context) {
+	transferID, _ := strconv.Atoi(c.Param("id"))
+	transfer, err := h.TransferUsecase.GetTransferByID(c.Request.Context(), helper.GetTenant(c), transferID)
+	if err != nil {
+		h.Logger.Error(err, "http - v1 - GetTransferByID")
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, transfer, "")
+}
+
+// @Summary     Dispatch Transfer
+// @Description An API to dispatch a draft transfer, the qty of its items is reduced from the source location in one transaction
+// @Description and kept as the in transit qty of the products until the transfer is received
+// @ID          dispatch-transfer
+// @Tags  	    transfer
+// @Accept      json
+// @Produce     json
+// @Param      	id				path		int			true	"Transfer ID"
+// @Param       X-Tenant	header	string	true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Param       X-Actor		header	string	false	"Actor Header, recorded on the stock movements"	example(jane@example.com)
+// @Success     200 {object} response.SuccessBody{data=entity.Transfer,meta=response.MetaInfo}
+// @Failure     403 {object} response.ErrorBody
+// @Failure     404 {object} response.ErrorBody
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /transfers/{id}/dispatch [post]
+func (h *TransferHandler) DispatchTransfer(c *gin.Context) {
+	functionName := "TransferHandler.DispatchTransfer"
+
+	transferID, _ := strconv.Atoi(c.Param("id"))
+	transfer, err := h.TransferUsecase.DispatchTransfer(c.Request.Context(), helper.GetTenant(c), transferID, helper.GetActor(c))
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.TransferUsecase.DispatchTransfer: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, transfer, "")
+}
+
+// @Summary     Receive Transfer
+// @Description An API to receive an in transit transfer, the qty of its items is added to the destination location in one transaction
+// @Description and fills the open backorders of the products
+// @ID          receive-transfer
+// @Tags  	    transfer
+// @Accept      json
+// @Produce     json
+// @Param      	id				path		int			true	"Transfer ID"
+// @Param       X-Tenant	header	string	true	"Tenant Header"	default(lorem)	example(lorem, ipsum)
+// @Param       X-Actor		header	string	false	"Actor Header, recorded on the stock movements"	example(jane@example.com)
+// @Success     200 {object} response.SuccessBody{data=entity.Transfer,meta=response.MetaInfo}
+// @Failure     403 {object} response.ErrorBody
+// @Failure     404 {object} response.ErrorBody
+// @Failure     422 {object} response.ErrorBody
+// @Failure     500 {object} response.ErrorBody
+// @Router      /transfers/{id}/receive [post]
+func (h *TransferHandler) ReceiveTransfer(c *gin.Context) {
+	functionName := "TransferHandler.ReceiveTransfer"
+
+	transferID, _ := strconv.Atoi(c.Param("id"))
+	transfer, err := h.TransferUsecase.ReceiveTransfer(c.Request.Context(), helper.GetTenant(c), transferID, helper.GetActor(c))
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			response.Error(c, customErr)
+			return
+		}
+
+		err = errors.Wrap(fmt.Errorf("h.TransferUsecase.ReceiveTransfer: %w", err), functionName)
+		h.Logger.Error(err)
+		response.Error(c, err)
+
+		return
+	}
+
+	response.OK(c, transfer, "")
+}
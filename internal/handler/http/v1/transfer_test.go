@@ -0,0 +1,232 @@
+package v1_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	httpv1 "github.com/satriowisnugroho/catalog/internal/handler/http/v1"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateTransfer(t *testing.T) {
+	testcases := []struct {
+		name              string
+		pPayloadRes       *entity.TransferPayload
+		pPayloadErr       error
+		uTransferRes      *entity.Transfer
+		uTransferErr      error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "parser return custom error",
+			pPayloadErr:       response.ErrInvalidTransferItems,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to parse transfer payload",
+			pPayloadErr:       errors.New("error parse transfer payload"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "product not found",
+			pPayloadRes:       &entity.TransferPayload{},
+			uTransferErr:      response.ErrNotFound,
+			httpStatusCodeRes: http.StatusNotFound,
+		},
+		{
+			name:              "invalid location",
+			pPayloadRes:       &entity.TransferPayload{},
+			uTransferErr:      response.ErrInvalidLocation,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to create transfer",
+			pPayloadRes:       &entity.TransferPayload{},
+			uTransferErr:      errors.New("error create transfer"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			pPayloadRes:       &entity.TransferPayload{},
+			uTransferRes:      &entity.Transfer{Tenant: types.TenantLoremType, Status: types.TransferStatusDraftType},
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "POST",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			tp := &testmock.TransferParserInterface{}
+			tp.On("ParseTransferPayload", mock.Anything).Return(tc.pPayloadRes, tc.pPayloadErr)
+
+			transferUsecase := &testmock.TransferUsecaseInterface{}
+			transferUsecase.On("CreateTransfer", mock.Anything, mock.Anything).Return(tc.uTransferRes, tc.uTransferErr)
+
+			h := &httpv1.TransferHandler{l, tp, transferUsecase}
+			h.CreateTransfer(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
+
+func TestGetTransferByID(t *testing.T) {
+	testcases := []struct {
+		name              string
+		uTransferErr      error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "transfer not found",
+			uTransferErr:      response.ErrNotFound,
+			httpStatusCodeRes: http.StatusNotFound,
+		},
+		{
+			name:              "failed to get transfer",
+			uTransferErr:      errors.New("error get transfer"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "GET",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			transferUsecase := &testmock.TransferUsecaseInterface{}
+			transferUsecase.On("GetTransferByID", mock.Anything, mock.Anything, mock.Anything).Return(&entity.Transfer{Tenant: types.TenantLoremType, Status: types.TransferStatusDraftType}, tc.uTransferErr)
+
+			h := &httpv1.TransferHandler{l, &testmock.TransferParserInterface{}, transferUsecase}
+			h.GetTransferByID(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
+
+func TestDispatchTransfer(t *testing.T) {
+	testcases := []struct {
+		name              string
+		uTransferErr      error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "invalid transfer status",
+			uTransferErr:      response.ErrInvalidTransferStatus,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "insufficient stock",
+			uTransferErr:      response.ErrInsufficientStock,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to dispatch transfer",
+			uTransferErr:      errors.New("error dispatch transfer"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "POST",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			transferUsecase := &testmock.TransferUsecaseInterface{}
+			transferUsecase.On("DispatchTransfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&entity.Transfer{Tenant: types.TenantLoremType, Status: types.TransferStatusInTransitType}, tc.uTransferErr)
+
+			h := &httpv1.TransferHandler{l, &testmock.TransferParserInterface{}, transferUsecase}
+			h.DispatchTransfer(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
+
+func TestReceiveTransfer(t *testing.T) {
+	testcases := []struct {
+		name              string
+		uTransferErr      error
+		httpStatusCodeRes int
+	}{
+		{
+			name:              "invalid transfer status",
+			uTransferErr:      response.ErrInvalidTransferStatus,
+			httpStatusCodeRes: http.StatusUnprocessableEntity,
+		},
+		{
+			name:              "failed to receive transfer",
+			uTransferErr:      errors.New("error receive transfer"),
+			httpStatusCodeRes: http.StatusInternalServerError,
+		},
+		{
+			name:              "success",
+			httpStatusCodeRes: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = &http.Request{
+				Header: make(http.Header),
+				Method: "POST",
+			}
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			transferUsecase := &testmock.TransferUsecaseInterface{}
+			transferUsecase.On("ReceiveTransfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&entity.Transfer{Tenant: types.TenantLoremType, Status: types.TransferStatusReceivedType}, tc.uTransferErr)
+
+			h := &httpv1.TransferHandler{l, &testmock.TransferParserInterface{}, transferUsecase}
+			h.ReceiveTransfer(ctx)
+
+			assert.Equal(t, tc.httpStatusCodeRes, w.Code)
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package helper
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/gin-gonic/gin"
 	"github.com/satriowisnugroho/catalog/internal/entity/types"
 )
@@ -17,3 +21,58 @@ func StringInArray(target string, arr []string) bool {
 func GetTenant(c *gin.Context) types.TenantType {
 	return types.TenantTypeNameToValue[c.GetHeader("X-Tenant")]
 }
+
+// GetActor return who made the request, it is recorded on the stock movements
+func GetActor(c *gin.Context) string {
+	return strings.TrimSpace(c.GetHeader("X-Actor"))
+}
+
+// GetIdempotencyKey return the key used to replay the response of a retried request
+func GetIdempotencyKey(c *gin.Context) string {
+	return strings.TrimSpace(c.GetHeader("Idempotency-Key"))
+}
+
+func GetRegion(c *gin.Context) string {
+	return strings.ToUpper(strings.TrimSpace(c.GetHeader("X-Region")))
+}
+
+// HasScope check whether the comma separated X-Scopes header contains the given scope
+func HasScope(c *gin.Context, scope string) bool {
+	for _, value := range strings.Split(c.GetHeader("X-Scopes"), ",") {
+		if strings.TrimSpace(value) == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// GetIfMatchVersions return the versions listed on the If-Match header, nil when it is not given or it matches any version.
+// If-Match uses the strong comparison, so a weak entity tag like W/"3" is not a version. Versions start at 1,
+// so an entity tag which is not a version is returned as 0 and never matches
+func GetIfMatchVersions(c *gin.Context) []int {
+	value := strings.TrimSpace(c.GetHeader("If-Match"))
+	if value == "" {
+		return nil
+	}
+
+	var versions []int
+	for _, tag := range strings.Split(value, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return nil
+		}
+
+		version := 0
+		if !strings.HasPrefix(tag, "W/") {
+			version, _ = strconv.Atoi(strings.Trim(tag, `"`))
+		}
+		versions = append(versions, version)
+	}
+
+	return versions
+}
+
+// ETag return the entity tag of the given version
+func ETag(version int) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+const (
+	// CycleCountSheetContentType is the content type of the cycle count sheet uploaded as csv
+	CycleCountSheetContentType = "text/csv"
+)
+
+// CycleCountParserInterface holds interface that parse data for cycle count
+type CycleCountParserInterface interface {
+	ParseCycleCountPayload(contentType string, body io.Reader) (*entity.CycleCountPayload, error)
+}
+
+// CycleCountParser struct for cycle count parser initialization
+type CycleCountParser struct{}
+
+// NewCycleCountParser create cycle count parser
+func NewCycleCountParser() *CycleCountParser {
+	return &CycleCountParser{}
+}
+
+// ParseCycleCountPayload parse request cycle count, either a json payload
+// or a csv sheet with the sku and counted_qty header
+func (p *CycleCountParser) ParseCycleCountPayload(contentType string, body io.Reader) (*entity.CycleCountPayload, error) {
+	functionName := "CycleCountParser.ParseCycleCountPayload"
+
+	if contentType == CycleCountSheetContentType {
+		return parseCycleCountSheet(body)
+	}
+
+	var payload entity.CycleCountPayload
+	if err := json.NewDecoder(body).Decode(&payload); err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return &payload, nil
+}
+
+// parseCycleCountSheet read the items of the csv sheet, the columns are picked by the header
+func parseCycleCountSheet(body io.Reader) (*entity.CycleCountPayload, error) {
+	records, err := csv.NewReader(body).ReadAll()
+	if err != nil || len(records) == 0 {
+		return nil, response.ErrInvalidCycleCountSheet
+	}
+
+	skuColumn, qtyColumn := -1, -1
+	for i, column := range records[0] {
+		switch strings.ToLower(strings.TrimSpace(column)) {
+		case "sku":
+			skuColumn = i
+		case "counted_qty":
+			qtyColumn = i
+		}
+	}
+	if skuColumn < 0 || qtyColumn < 0 {
+		return nil, response.ErrInvalidCycleCountSheet
+	}
+
+	payload := &entity.CycleCountPayload{Items: make([]entity.CycleCountItemPayload, 0, len(records)-1)}
+	for _, record := range records[1:] {
+		countedQty, err := strconv.Atoi(strings.TrimSpace(record[qtyColumn]))
+		if err != nil {
+			return nil, response.ErrInvalidCycleCountSheet
+		}
+
+		payload.Items = append(payload.Items, entity.CycleCountItemPayload{
+			SKU:        record[skuColumn],
+			CountedQty: countedQty,
+		})
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// DiscountRuleParserInterface holds interface that parse data for discount rule
+type DiscountRuleParserInterface interface {
+	ParseDiscountRulePayload(body io.Reader) (*entity.DiscountRulePayload, error)
+}
+
+// DiscountRuleParser struct for discount rule parser initialization
+type DiscountRuleParser struct{}
+
+// NewDiscountRuleParser create discount rule parser
+func NewDiscountRuleParser() *DiscountRuleParser {
+	return &DiscountRuleParser{}
+}
+
+// ParseDiscountRulePayload parse request discount rule
+func (p *DiscountRuleParser) ParseDiscountRulePayload(body io.Reader) (*entity.DiscountRulePayload, error) {
+	functionName := "DiscountRuleParser.ParseDiscountRulePayload"
+
+	var payload entity.DiscountRulePayload
+	if err := json.NewDecoder(body).Decode(&payload); err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return &payload, nil
+}
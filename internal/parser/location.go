@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// LocationParserInterface holds interface that parse data for location
+type LocationParserInterface interface {
+	ParseLocationPayload(body io.Reader) (*entity.LocationPayload, error)
+}
+
+// LocationParser struct for location parser initialization
+type LocationParser struct{}
+
+// NewLocationParser create location parser
+func NewLocationParser() *LocationParser {
+	return &LocationParser{}
+}
+
+// ParseLocationPayload parse request location
+func (p *LocationParser) ParseLocationPayload(body io.Reader) (*entity.LocationPayload, error) {
+	functionName := "LocationParser.ParseLocationPayload"
+
+	var payload entity.LocationPayload
+	if err := json.NewDecoder(body).Decode(&payload); err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return &payload, nil
+}
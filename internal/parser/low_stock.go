@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// LowStockParserInterface holds interface that parse data for low stock
+type LowStockParserInterface interface {
+	ParseLowStockThresholdPayload(body io.Reader) (*entity.LowStockThresholdPayload, error)
+}
+
+// LowStockParser struct for low stock parser initialization
+type LowStockParser struct{}
+
+// NewLowStockParser create low stock parser
+func NewLowStockParser() *LowStockParser {
+	return &LowStockParser{}
+}
+
+// ParseLowStockThresholdPayload parse request low stock threshold
+func (p *LowStockParser) ParseLowStockThresholdPayload(body io.Reader) (*entity.LowStockThresholdPayload, error) {
+	functionName := "LowStockParser.ParseLowStockThresholdPayload"
+
+	var payload entity.LowStockThresholdPayload
+	if err := json.NewDecoder(body).Decode(&payload); err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return &payload, nil
+}
@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// PriceHistoryParserInterface holds interface that parse data for price history
+type PriceHistoryParserInterface interface {
+	ParseGetPriceHistoryPayload(c *gin.Context) (*entity.GetPriceHistoryPayload, error)
+}
+
+// PriceHistoryParser struct for price history parser initialization
+type PriceHistoryParser struct{}
+
+// NewPriceHistoryParser create price history parser
+func NewPriceHistoryParser() *PriceHistoryParser {
+	return &PriceHistoryParser{}
+}
+
+// ParseGetPriceHistoryPayload parse request get price history
+func (p *PriceHistoryParser) ParseGetPriceHistoryPayload(c *gin.Context) (*entity.GetPriceHistoryPayload, error) {
+	productID, _ := strconv.Atoi(c.Param("id"))
+	payload := &entity.GetPriceHistoryPayload{
+		ProductID: productID,
+		Tenant:    helper.GetTenant(c),
+	}
+
+	if from := c.Query("from"); from != "" {
+		t, err := parseTime(from, false)
+		if err != nil {
+			return nil, response.ErrInvalidDateRange
+		}
+		payload.From = &t
+	}
+
+	if to := c.Query("to"); to != "" {
+		t, err := parseTime(to, true)
+		if err != nil {
+			return nil, response.ErrInvalidDateRange
+		}
+		payload.To = &t
+	}
+
+	return payload, nil
+}
+
+// parseTime parse RFC3339 timestamp or date, date is parsed to its end of day when endOfDay is true
+func parseTime(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return t, err
+	}
+
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// PriceScheduleParserInterface holds interface that parse data for price schedule
+type PriceScheduleParserInterface interface {
+	ParsePriceSchedulePayload(body io.Reader) (*entity.PriceSchedulePayload, error)
+}
+
+// PriceScheduleParser struct for price schedule parser initialization
+type PriceScheduleParser struct{}
+
+// NewPriceScheduleParser create price schedule parser
+func NewPriceScheduleParser() *PriceScheduleParser {
+	return &PriceScheduleParser{}
+}
+
+// ParsePriceSchedulePayload parse request price schedule
+func (p *PriceScheduleParser) ParsePriceSchedulePayload(body io.Reader) (*entity.PriceSchedulePayload, error) {
+	functionName := "PriceScheduleParser.ParsePriceSchedulePayload"
+
+	var payload entity.PriceSchedulePayload
+	if err := json.NewDecoder(body).Decode(&payload); err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return &payload, nil
+}
@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"io"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/config"
 	"github.com/satriowisnugroho/catalog/internal/entity"
 	"github.com/satriowisnugroho/catalog/internal/entity/types"
 	"github.com/satriowisnugroho/catalog/internal/helper"
@@ -16,8 +19,12 @@ import (
 // ProductParserInterface holds interface that parse data for product
 type ProductParserInterface interface {
 	ParseProductPayload(body io.Reader) (*entity.ProductPayload, error)
-	ParseGetProductPayload(c *gin.Context) *entity.GetProductPayload
+	ParseGetProductPayload(c *gin.Context) (*entity.GetProductPayload, error)
 	ParseBulkReduceQtyProductPayload(body io.Reader) (*entity.BulkReduceQtyProductPayload, error)
+	ParseBulkIncreaseQtyProductPayload(body io.Reader) (*entity.BulkIncreaseQtyProductPayload, error)
+	ParseAdjustQtyProductPayload(body io.Reader) (*entity.AdjustQtyProductPayload, error)
+	ParseProductPatchPayload(body io.Reader) (*entity.ProductPatchPayload, error)
+	ParseProductFieldset(c *gin.Context) (*entity.ProductFieldset, error)
 }
 
 // ProductParser struct for product parser initialization
@@ -44,22 +51,140 @@ func (p *ProductParser) ParseProductPayload(body io.Reader) (*entity.ProductPayl
 	return &payload, nil
 }
 
-// ParseGetProductPayload parse request get products
-func (p *ProductParser) ParseGetProductPayload(c *gin.Context) *entity.GetProductPayload {
+// ParseGetProductPayload parse request get products, the filters of multiple values are separated by comma
+func (p *ProductParser) ParseGetProductPayload(c *gin.Context) (*entity.GetProductPayload, error) {
 	offset, _ := strconv.Atoi(c.Query("offset"))
 	limit, _ := strconv.Atoi(c.Query("limit"))
 	payload := &entity.GetProductPayload{
-		SKU:          c.Query("sku"),
-		TitleKeyword: c.Query("keyword"),
-		Category:     types.CategoryTypeNameToValue[c.Query("category")],
-		Condition:    types.ConditionTypeNameToValue[c.Query("condition")],
+		SKUs:         splitQuery(c.Query("sku")),
+		Keyword:      strings.TrimSpace(c.Query("keyword")),
 		Tenant:       helper.GetTenant(c),
-		OrderBy:      c.Query("orderby"),
+		Region:       helper.GetRegion(c),
+		FinanceScope: helper.HasScope(c, config.FinanceScope),
 		Offset:       offset,
 		Limit:        limit,
+		Cursor:       c.Query("cursor"),
 	}
 
-	return payload
+	for _, name := range splitQuery(c.Query("category")) {
+		category, ok := types.CategoryTypeNameToValue[name]
+		if !ok {
+			return nil, response.ErrInvalidCategory
+		}
+		payload.Categories = append(payload.Categories, category)
+	}
+
+	for _, name := range splitQuery(c.Query("condition")) {
+		condition, ok := types.ConditionTypeNameToValue[name]
+		if !ok {
+			return nil, response.ErrInvalidCondition
+		}
+		payload.Conditions = append(payload.Conditions, condition)
+	}
+
+	minPrice, err := parseOptionalInt(c.Query("min_price"))
+	if err != nil || (minPrice != nil && *minPrice < 0) {
+		return nil, response.ErrInvalidPrice
+	}
+	payload.MinPrice = minPrice
+
+	maxPrice, err := parseOptionalInt(c.Query("max_price"))
+	if err != nil || (maxPrice != nil && *maxPrice < 0) {
+		return nil, response.ErrInvalidPrice
+	}
+	payload.MaxPrice = maxPrice
+
+	if payload.MinQty, err = parseOptionalInt(c.Query("min_qty")); err != nil {
+		return nil, response.ErrInvalidQty
+	}
+
+	if payload.MaxQty, err = parseOptionalInt(c.Query("max_qty")); err != nil {
+		return nil, response.ErrInvalidQty
+	}
+
+	if payload.CreatedFrom, err = parseOptionalTime(c.Query("created_from"), false); err != nil {
+		return nil, response.ErrInvalidDateRange
+	}
+
+	if payload.CreatedTo, err = parseOptionalTime(c.Query("created_to"), true); err != nil {
+		return nil, response.ErrInvalidDateRange
+	}
+
+	if payload.UpdatedFrom, err = parseOptionalTime(c.Query("updated_from"), false); err != nil {
+		return nil, response.ErrInvalidDateRange
+	}
+
+	if payload.UpdatedTo, err = parseOptionalTime(c.Query("updated_to"), true); err != nil {
+		return nil, response.ErrInvalidDateRange
+	}
+
+	// The orderby query is kept for the clients which sort by a single field before the sort query exists
+	if sort := c.Query("sort"); sort != "" {
+		payload.Sort, err = entity.ParseProductSort(sort)
+	} else {
+		payload.Sort, err = entity.ParseProductOrderBy(c.Query("orderby"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.Fieldset, err = p.ParseProductFieldset(c); err != nil {
+		return nil, err
+	}
+
+	switch stock := c.Query("stock"); stock {
+	case "", entity.LowStockFilter, entity.InStockFilter, entity.OutOfStockFilter:
+		payload.Stock = stock
+	default:
+		return nil, response.ErrInvalidStockFilter
+	}
+
+	return payload, nil
+}
+
+// ParseProductFieldset parse the fields and the relations of the product response from the comma separated fields and include queries
+func (p *ProductParser) ParseProductFieldset(c *gin.Context) (*entity.ProductFieldset, error) {
+	return entity.ParseProductFieldset(c.Query("fields"), c.Query("include"))
+}
+
+// parseOptionalInt parse the integer of a query, it is nil when the query is not given
+func parseOptionalInt(value string) (*int, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &i, nil
+}
+
+// parseOptionalTime parse the time of a query, it is nil when the query is not given
+func parseOptionalTime(value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := parseTime(value, endOfDay)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// splitQuery split the comma separated values of a query, the empty values are skipped
+func splitQuery(value string) []string {
+	values := []string{}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
 }
 
 // ParseBulkReduceQtyProductPayload parse request bulk reduce qty product
@@ -77,3 +202,55 @@ func (p *ProductParser) ParseBulkReduceQtyProductPayload(body io.Reader) (*entit
 
 	return &payload, nil
 }
+
+// ParseBulkIncreaseQtyProductPayload parse request bulk increase qty product
+func (p *ProductParser) ParseBulkIncreaseQtyProductPayload(body io.Reader) (*entity.BulkIncreaseQtyProductPayload, error) {
+	functionName := "ProductParser.ParseBulkIncreaseQtyProductPayload"
+
+	var payload entity.BulkIncreaseQtyProductPayload
+	if err := json.NewDecoder(body).Decode(&payload); err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return &payload, nil
+}
+
+// ParseAdjustQtyProductPayload parse request adjust qty product
+func (p *ProductParser) ParseAdjustQtyProductPayload(body io.Reader) (*entity.AdjustQtyProductPayload, error) {
+	functionName := "ProductParser.ParseAdjustQtyProductPayload"
+
+	var payload entity.AdjustQtyProductPayload
+	if err := json.NewDecoder(body).Decode(&payload); err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return &payload, nil
+}
+
+// ParseProductPatchPayload parse request json merge patch of a product, the patch must be a json object
+func (p *ProductParser) ParseProductPatchPayload(body io.Reader) (*entity.ProductPatchPayload, error) {
+	functionName := "ProductParser.ParseProductPatchPayload"
+
+	var fields map[string]json.RawMessage
+	if err := json.NewDecoder(body).Decode(&fields); err != nil {
+		if _, ok := err.(*json.UnmarshalTypeError); ok {
+			return nil, response.ErrInvalidPatch
+		}
+
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if fields == nil {
+		return nil, response.ErrInvalidPatch
+	}
+
+	return &entity.ProductPatchPayload{Fields: fields}, nil
+}
@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// ReservationParserInterface holds interface that parse data for reservation
+type ReservationParserInterface interface {
+	ParseReservationPayload(body io.Reader) (*entity.ReservationPayload, error)
+}
+
+// ReservationParser struct for reservation parser initialization
+type ReservationParser struct{}
+
+// NewReservationParser create reservation parser
+func NewReservationParser() *ReservationParser {
+	return &ReservationParser{}
+}
+
+// ParseReservationPayload parse request reservation
+func (p *ReservationParser) ParseReservationPayload(body io.Reader) (*entity.ReservationPayload, error) {
+	functionName := "ReservationParser.ParseReservationPayload"
+
+	var payload entity.ReservationPayload
+	if err := json.NewDecoder(body).Decode(&payload); err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return &payload, nil
+}
@@ -0,0 +1,47 @@
+package parser
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// SerialParserInterface holds interface that parse data for serial number
+type SerialParserInterface interface {
+	ParseGetProductSerialPayload(c *gin.Context) (*entity.GetProductSerialPayload, error)
+}
+
+// SerialParser struct for serial number parser initialization
+type SerialParser struct{}
+
+// NewSerialParser create serial number parser
+func NewSerialParser() *SerialParser {
+	return &SerialParser{}
+}
+
+// ParseGetProductSerialPayload parse request get product serial
+func (p *SerialParser) ParseGetProductSerialPayload(c *gin.Context) (*entity.GetProductSerialPayload, error) {
+	productID, _ := strconv.Atoi(c.Param("id"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	payload := &entity.GetProductSerialPayload{
+		ProductID: productID,
+		Tenant:    helper.GetTenant(c),
+		Offset:    offset,
+		Limit:     limit,
+	}
+
+	if status := c.Query("status"); status != "" {
+		value, ok := types.SerialStatusTypeNameToValue[status]
+		if !ok {
+			return nil, response.ErrInvalidSerialStatus
+		}
+		payload.Status = value
+	}
+
+	return payload, nil
+}
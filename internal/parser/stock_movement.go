@@ -0,0 +1,63 @@
+package parser
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// StockMovementParserInterface holds interface that parse data for stock movement
+type StockMovementParserInterface interface {
+	ParseGetStockMovementPayload(c *gin.Context) (*entity.GetStockMovementPayload, error)
+}
+
+// StockMovementParser struct for stock movement parser initialization
+type StockMovementParser struct{}
+
+// NewStockMovementParser create stock movement parser
+func NewStockMovementParser() *StockMovementParser {
+	return &StockMovementParser{}
+}
+
+// ParseGetStockMovementPayload parse request get stock movement
+func (p *StockMovementParser) ParseGetStockMovementPayload(c *gin.Context) (*entity.GetStockMovementPayload, error) {
+	productID, _ := strconv.Atoi(c.Param("id"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	payload := &entity.GetStockMovementPayload{
+		ProductID: productID,
+		Tenant:    helper.GetTenant(c),
+		Offset:    offset,
+		Limit:     limit,
+	}
+
+	if reason := c.Query("reason"); reason != "" {
+		value, ok := types.StockMovementReasonTypeNameToValue[reason]
+		if !ok {
+			return nil, response.ErrInvalidStockMovementReason
+		}
+		payload.Reason = value
+	}
+
+	if from := c.Query("from"); from != "" {
+		t, err := parseTime(from, false)
+		if err != nil {
+			return nil, response.ErrInvalidDateRange
+		}
+		payload.From = &t
+	}
+
+	if to := c.Query("to"); to != "" {
+		t, err := parseTime(to, true)
+		if err != nil {
+			return nil, response.ErrInvalidDateRange
+		}
+		payload.To = &t
+	}
+
+	return payload, nil
+}
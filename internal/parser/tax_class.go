@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// TaxClassParserInterface holds interface that parse data for tax class
+type TaxClassParserInterface interface {
+	ParseTaxClassPayload(body io.Reader) (*entity.TaxClassPayload, error)
+}
+
+// TaxClassParser struct for tax class parser initialization
+type TaxClassParser struct{}
+
+// NewTaxClassParser create tax class parser
+func NewTaxClassParser() *TaxClassParser {
+	return &TaxClassParser{}
+}
+
+// ParseTaxClassPayload parse request tax class
+func (p *TaxClassParser) ParseTaxClassPayload(body io.Reader) (*entity.TaxClassPayload, error) {
+	functionName := "TaxClassParser.ParseTaxClassPayload"
+
+	var payload entity.TaxClassPayload
+	if err := json.NewDecoder(body).Decode(&payload); err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return &payload, nil
+}
@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// TransferParserInterface holds interface that parse data for transfer
+type TransferParserInterface interface {
+	ParseTransferPayload(body io.Reader) (*entity.TransferPayload, error)
+}
+
+// TransferParser struct for transfer parser initialization
+type TransferParser struct{}
+
+// NewTransferParser create transfer parser
+func NewTransferParser() *TransferParser {
+	return &TransferParser{}
+}
+
+// ParseTransferPayload parse request transfer
+func (p *TransferParser) ParseTransferPayload(body io.Reader) (*entity.TransferPayload, error) {
+	functionName := "TransferParser.ParseTransferPayload"
+
+	var payload entity.TransferPayload
+	if err := json.NewDecoder(body).Decode(&payload); err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return &payload, nil
+}
@@ -0,0 +1,244 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	dbentity "github.com/satriowisnugroho/catalog/internal/repository/postgres/entity"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// CycleCountRepositoryInterface define contract for cycle count related functions to repository
+type CycleCountRepositoryInterface interface {
+	CreateCycleCount(ctx context.Context, dbTrx interface{}, cycleCount *entity.CycleCount) error
+	CreateCycleCountItems(ctx context.Context, dbTrx interface{}, cycleCount *entity.CycleCount) error
+	GetCycleCountByID(ctx context.Context, cycleCountID int) (*entity.CycleCount, error)
+	GetCycleCountItemsByCycleCountIDs(ctx context.Context, cycleCountIDs []int) ([]*entity.CycleCountItem, error)
+	UpdateCycleCountStatus(ctx context.Context, dbTrx interface{}, cycleCount *entity.CycleCount, fromStatus types.CycleCountStatusType) error
+}
+
+// CycleCountRepository holds database connection
+type CycleCountRepository struct {
+	db *sqlx.DB
+}
+
+var (
+	// CycleCountTableName hold table name for cycle counts
+	CycleCountTableName = "cycle_counts"
+	// CycleCountColumns list all columns on cycle counts table
+	CycleCountColumns = []string{"id", "tenant", "status", "created_at", "updated_at"}
+	// CycleCountAttributes hold string format of all cycle counts table columns
+	CycleCountAttributes = strings.Join(CycleCountColumns, ", ")
+
+	// CycleCountCreationColumns list all columns used for create cycle count
+	CycleCountCreationColumns = CycleCountColumns[1:]
+	// CycleCountCreationAttributes hold string format of all creation cycle count columns
+	CycleCountCreationAttributes = strings.Join(CycleCountCreationColumns, ", ")
+
+	// CycleCountItemTableName hold table name for cycle count items
+	CycleCountItemTableName = "cycle_count_items"
+	// CycleCountItemColumns list all columns on cycle count items table
+	CycleCountItemColumns = []string{"id", "cycle_count_id", "product_id", "system_qty", "counted_qty", "diff_qty"}
+
+	// CycleCountItemCreationColumns list all columns used for create cycle count item
+	CycleCountItemCreationColumns = CycleCountItemColumns[1:]
+	// CycleCountItemCreationAttributes hold string format of all creation cycle count item columns
+	CycleCountItemCreationAttributes = strings.Join(CycleCountItemCreationColumns, ", ")
+
+	// cycleCountItemWithSKUAttributes hold string format of cycle count items columns joined with the sku of their product
+	cycleCountItemWithSKUAttributes = fmt.Sprintf(
+		"%[1]s.id, %[1]s.cycle_count_id, %[1]s.product_id, %[1]s.system_qty, %[1]s.counted_qty, %[1]s.diff_qty, %[2]s.sku",
+		CycleCountItemTableName,
+		ProductTableName,
+	)
+)
+
+// NewCycleCountRepository create initiate cycle count repository with given database
+func NewCycleCountRepository(db *sqlx.DB) *CycleCountRepository {
+	return &CycleCountRepository{db: db}
+}
+
+func (r *CycleCountRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]*entity.CycleCount, error) {
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]*entity.CycleCount, 0)
+
+	for rows.Next() {
+		tmpEntity := dbentity.CycleCount{}
+		if err := rows.StructScan(&tmpEntity); err != nil {
+			return nil, errors.Wrap(err, "fetch")
+		}
+
+		result = append(result, tmpEntity.ToEntity())
+	}
+
+	return result, nil
+}
+
+func (r *CycleCountRepository) fetchCycleCountItems(ctx context.Context, query string, args ...interface{}) ([]*entity.CycleCountItem, error) {
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]*entity.CycleCountItem, 0)
+
+	for rows.Next() {
+		tmpEntity := dbentity.CycleCountItem{}
+		if err := rows.StructScan(&tmpEntity); err != nil {
+			return nil, errors.Wrap(err, "fetchCycleCountItems")
+		}
+
+		result = append(result, tmpEntity.ToEntity())
+	}
+
+	return result, nil
+}
+
+// CreateCycleCount insert cycle count data into database
+func (r *CycleCountRepository) CreateCycleCount(ctx context.Context, dbTrx interface{}, cycleCount *entity.CycleCount) error {
+	functionName := "CycleCountRepository.CreateCycleCount"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	now := time.Now()
+	cycleCount.CreatedAt = now
+	cycleCount.UpdatedAt = now
+
+	query := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s) RETURNING id`, CycleCountTableName, CycleCountCreationAttributes, EnumeratedBindvars(CycleCountCreationColumns))
+
+	tx := Tx(r.db, dbTrx)
+	err := tx.QueryRowxContext(ctx, query,
+		cycleCount.Tenant,
+		cycleCount.Status,
+		cycleCount.CreatedAt,
+		cycleCount.UpdatedAt,
+	).Scan(&cycleCount.ID)
+	if err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// CreateCycleCountItems insert items of a cycle count into database
+func (r *CycleCountRepository) CreateCycleCountItems(ctx context.Context, dbTrx interface{}, cycleCount *entity.CycleCount) error {
+	functionName := "CycleCountRepository.CreateCycleCountItems"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	if len(cycleCount.Items) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(cycleCount.Items))
+	args := make([]interface{}, 0, len(cycleCount.Items)*len(CycleCountItemCreationColumns))
+	for _, item := range cycleCount.Items {
+		item.CycleCountID = cycleCount.ID
+
+		values = append(values, fmt.Sprintf("(%s)", EnumeratedBindvarsFrom(len(args)+1, len(CycleCountItemCreationColumns))))
+		args = append(args, item.CycleCountID, item.ProductID, item.SystemQty, item.CountedQty, item.DiffQty)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", CycleCountItemTableName, CycleCountItemCreationAttributes, strings.Join(values, ", "))
+
+	tx := Tx(r.db, dbTrx)
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// GetCycleCountByID return cycle count by id
+func (r *CycleCountRepository) GetCycleCountByID(ctx context.Context, cycleCountID int) (*entity.CycleCount, error) {
+	functionName := "CycleCountRepository.GetCycleCountByID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id = $1 LIMIT 1", CycleCountAttributes, CycleCountTableName)
+	rows, err := r.fetch(ctx, query, cycleCountID)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if len(rows) == 0 {
+		return nil, response.ErrNotFound
+	}
+
+	return rows[0], nil
+}
+
+// GetCycleCountItemsByCycleCountIDs return items of the given cycle counts
+func (r *CycleCountRepository) GetCycleCountItemsByCycleCountIDs(ctx context.Context, cycleCountIDs []int) ([]*entity.CycleCountItem, error) {
+	functionName := "CycleCountRepository.GetCycleCountItemsByCycleCountIDs"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf(
+		"SELECT %[1]s FROM %[2]s JOIN %[3]s ON %[3]s.id = %[2]s.product_id WHERE %[2]s.cycle_count_id = ANY($1) ORDER BY %[2]s.id ASC",
+		cycleCountItemWithSKUAttributes,
+		CycleCountItemTableName,
+		ProductTableName,
+	)
+	rows, err := r.fetchCycleCountItems(ctx, query, pq.Array(cycleCountIDs))
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return rows, nil
+}
+
+// UpdateCycleCountStatus update status of a cycle count which is still in the given status
+func (r *CycleCountRepository) UpdateCycleCountStatus(ctx context.Context, dbTrx interface{}, cycleCount *entity.CycleCount, fromStatus types.CycleCountStatusType) error {
+	functionName := "CycleCountRepository.UpdateCycleCountStatus"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	cycleCount.UpdatedAt = time.Now()
+
+	query := fmt.Sprintf("UPDATE %s SET status = $1, updated_at = $2 WHERE id = $3 AND status = $4", CycleCountTableName)
+
+	tx := Tx(r.db, dbTrx)
+	result, err := tx.ExecContext(ctx, query, cycleCount.Status, cycleCount.UpdatedAt, cycleCount.ID, fromStatus)
+	if err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	// The cycle count has been reviewed by someone else
+	if affected == 0 {
+		return response.ErrNotFound
+	}
+
+	return nil
+}
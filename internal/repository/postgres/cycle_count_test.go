@@ -0,0 +1,344 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	"github.com/stretchr/testify/assert"
+)
+
+func cycleCountRow(rows *sqlmock.Rows, c *entity.CycleCount) *sqlmock.Rows {
+	return rows.AddRow(
+		c.ID,
+		c.Tenant,
+		c.Status,
+		time.Now(),
+		time.Now(),
+	)
+}
+
+func TestCreateCycleCount(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		createErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			createErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.createErr != nil {
+				mock.ExpectQuery("^INSERT INTO cycle_counts(.+)").WillReturnError(tc.createErr)
+			} else {
+				mock.ExpectQuery("^INSERT INTO cycle_counts(.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewCycleCountRepository(dbx)
+
+			cycleCount := &entity.CycleCount{}
+			err = repo.CreateCycleCount(tc.ctx, nil, cycleCount)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, 1, cycleCount.ID)
+			}
+		})
+	}
+}
+
+func TestCreateCycleCountItems(t *testing.T) {
+	testcases := []struct {
+		name        string
+		ctx         context.Context
+		cycleCount  *entity.CycleCount
+		createErr   error
+		expectQuery bool
+		wantErr     bool
+	}{
+		{
+			name:       "deadline context",
+			ctx:        fixture.CtxEnded(),
+			cycleCount: &entity.CycleCount{},
+			wantErr:    true,
+		},
+		{
+			name:       "without items",
+			ctx:        context.Background(),
+			cycleCount: &entity.CycleCount{ID: 1},
+			wantErr:    false,
+		},
+		{
+			name:        "fail exec query",
+			ctx:         context.Background(),
+			cycleCount:  &entity.CycleCount{ID: 1, Items: []*entity.CycleCountItem{{ProductID: 1, SystemQty: 10, CountedQty: 8, DiffQty: -2}}},
+			createErr:   errors.New("fail exec"),
+			expectQuery: true,
+			wantErr:     true,
+		},
+		{
+			name:        "success",
+			ctx:         context.Background(),
+			cycleCount:  &entity.CycleCount{ID: 1, Items: []*entity.CycleCountItem{{ProductID: 1, SystemQty: 10, CountedQty: 8, DiffQty: -2}, {ProductID: 2, SystemQty: 0, CountedQty: 3, DiffQty: 3}}},
+			expectQuery: true,
+			wantErr:     false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.expectQuery {
+				query := mock.ExpectExec("^INSERT INTO cycle_count_items \\(cycle_count_id, product_id, system_qty, counted_qty, diff_qty\\) VALUES (.+)")
+				if tc.createErr != nil {
+					query.WillReturnError(tc.createErr)
+				} else {
+					query.WillReturnResult(sqlmock.NewResult(1, int64(len(tc.cycleCount.Items))))
+				}
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewCycleCountRepository(dbx)
+			err = repo.CreateCycleCountItems(tc.ctx, nil, tc.cycleCount)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+			if !tc.wantErr {
+				for _, item := range tc.cycleCount.Items {
+					assert.Equal(t, tc.cycleCount.ID, item.CycleCountID)
+				}
+			}
+		})
+	}
+}
+
+func TestGetCycleCountByID(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		fetchErr  error
+		fetchRows []string
+		expected  *entity.CycleCount
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "fail fetch return error rows",
+			ctx:       context.Background(),
+			fetchRows: []string{"unknown_column"},
+			wantErr:   true,
+		},
+		{
+			name:      "record not found",
+			ctx:       context.Background(),
+			fetchRows: postgres.CycleCountColumns,
+			wantErr:   true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: postgres.CycleCountColumns,
+			expected:  &entity.CycleCount{ID: 1, Tenant: types.TenantLoremType, Status: types.CycleCountStatusPendingType, Items: []*entity.CycleCountItem{}},
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery("^SELECT (.+) FROM cycle_counts WHERE id = \\$1(.+)").WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				if tc.expected != nil {
+					rows = cycleCountRow(rows, tc.expected)
+				} else if len(tc.fetchRows) == 1 {
+					rows = rows.AddRow(1)
+				}
+				mock.ExpectQuery("^SELECT (.+) FROM cycle_counts WHERE id = \\$1(.+)").WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewCycleCountRepository(dbx)
+			result, err := repo.GetCycleCountByID(tc.ctx, 1)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			if tc.expected != nil {
+				assert.Equal(t, tc.expected.ID, result.ID)
+				assert.Equal(t, tc.expected.Status, result.Status)
+				assert.Equal(t, tc.expected.Items, result.Items)
+			}
+		})
+	}
+}
+
+func TestGetCycleCountItemsByCycleCountIDs(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		fetchErr  error
+		fetchRows []string
+		expected  []*entity.CycleCountItem
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "fail fetch return error rows",
+			ctx:       context.Background(),
+			fetchRows: []string{"unknown_column"},
+			wantErr:   true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: []string{"id", "cycle_count_id", "product_id", "system_qty", "counted_qty", "diff_qty", "sku"},
+			expected:  []*entity.CycleCountItem{{ID: 1, CycleCountID: 1, ProductID: 1, SKU: "SKU-1", SystemQty: 10, CountedQty: 8, DiffQty: -2}},
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			query := "^SELECT (.+) FROM cycle_count_items JOIN products (.+) WHERE cycle_count_items.cycle_count_id = ANY\\(\\$1\\)(.+)"
+			if tc.fetchErr != nil {
+				mock.ExpectQuery(query).WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				for _, item := range tc.expected {
+					rows = rows.AddRow(item.ID, item.CycleCountID, item.ProductID, item.SystemQty, item.CountedQty, item.DiffQty, item.SKU)
+				}
+				if len(tc.fetchRows) == 1 {
+					rows = rows.AddRow(1)
+				}
+				mock.ExpectQuery(query).WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewCycleCountRepository(dbx)
+			result, err := repo.GetCycleCountItemsByCycleCountIDs(tc.ctx, []int{1})
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			if !tc.wantErr {
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestUpdateCycleCountStatus(t *testing.T) {
+	testcases := []struct {
+		name         string
+		ctx          context.Context
+		updateErr    error
+		rowsAffected int64
+		wantErr      bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			updateErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:         "status has been changed",
+			ctx:          context.Background(),
+			rowsAffected: 0,
+			wantErr:      true,
+		},
+		{
+			name:         "success",
+			ctx:          context.Background(),
+			rowsAffected: 1,
+			wantErr:      false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.updateErr != nil {
+				mock.ExpectExec("^UPDATE cycle_counts(.+)").WillReturnError(tc.updateErr)
+			} else {
+				mock.ExpectExec("^UPDATE cycle_counts(.+)").WillReturnResult(sqlmock.NewResult(1, tc.rowsAffected))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewCycleCountRepository(dbx)
+			err = repo.UpdateCycleCountStatus(tc.ctx, nil, &entity.CycleCount{Status: types.CycleCountStatusApprovedType}, types.CycleCountStatusPendingType)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+		})
+	}
+}
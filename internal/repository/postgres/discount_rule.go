@@ -0,0 +1,242 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	dbentity "github.com/satriowisnugroho/catalog/internal/repository/postgres/entity"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// DiscountRuleRepositoryInterface define contract for discount rule related functions to repository
+type DiscountRuleRepositoryInterface interface {
+	CreateDiscountRule(ctx context.Context, discountRule *entity.DiscountRule) error
+	GetDiscountRuleByID(ctx context.Context, discountRuleID int) (*entity.DiscountRule, error)
+	GetDiscountRules(ctx context.Context, tenant types.TenantType) ([]*entity.DiscountRule, error)
+	GetActiveDiscountRules(ctx context.Context, tenant types.TenantType, now time.Time) ([]*entity.DiscountRule, error)
+	UpdateDiscountRule(ctx context.Context, discountRule *entity.DiscountRule) error
+	DeleteDiscountRule(ctx context.Context, discountRuleID int) error
+}
+
+// DiscountRuleRepository holds database connection
+type DiscountRuleRepository struct {
+	db *sqlx.DB
+}
+
+var (
+	// DiscountRuleTableName hold table name for discount rules
+	DiscountRuleTableName = "discount_rules"
+	// DiscountRuleColumns list all columns on discount rules table
+	DiscountRuleColumns = []string{"id", "tenant", "name", "type", "value", "sku", "keyword", "categories", "conditions", "priority", "stackable", "starts_at", "ends_at", "created_at", "updated_at"}
+	// DiscountRuleAttributes hold string format of all discount rules table columns
+	DiscountRuleAttributes = strings.Join(DiscountRuleColumns, ", ")
+
+	// DiscountRuleCreationColumns list all columns used for create discount rule
+	DiscountRuleCreationColumns = DiscountRuleColumns[1:]
+	// DiscountRuleCreationAttributes hold string format of all creation discount rule columns
+	DiscountRuleCreationAttributes = strings.Join(DiscountRuleCreationColumns, ", ")
+
+	// discountRuleOrder order discount rules by the evaluation order
+	discountRuleOrder = "priority DESC, id ASC"
+)
+
+// NewDiscountRuleRepository create initiate discount rule repository with given database
+func NewDiscountRuleRepository(db *sqlx.DB) *DiscountRuleRepository {
+	return &DiscountRuleRepository{db: db}
+}
+
+func (r *DiscountRuleRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]*entity.DiscountRule, error) {
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]*entity.DiscountRule, 0)
+
+	for rows.Next() {
+		tmpEntity := dbentity.DiscountRule{}
+		if err := rows.StructScan(&tmpEntity); err != nil {
+			return nil, errors.Wrap(err, "fetch")
+		}
+
+		result = append(result, tmpEntity.ToEntity())
+	}
+
+	return result, nil
+}
+
+// CreateDiscountRule insert discount rule data into database
+func (r *DiscountRuleRepository) CreateDiscountRule(ctx context.Context, discountRule *entity.DiscountRule) error {
+	functionName := "DiscountRuleRepository.CreateDiscountRule"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	now := time.Now()
+	discountRule.CreatedAt = now
+	discountRule.UpdatedAt = now
+
+	query := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s) RETURNING id`, DiscountRuleTableName, DiscountRuleCreationAttributes, EnumeratedBindvars(DiscountRuleCreationColumns))
+
+	err := r.db.QueryRowContext(ctx, query, discountRuleValues(discountRule)...).Scan(&discountRule.ID)
+	if err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// GetDiscountRuleByID return discount rule by id
+func (r *DiscountRuleRepository) GetDiscountRuleByID(ctx context.Context, discountRuleID int) (*entity.DiscountRule, error) {
+	functionName := "DiscountRuleRepository.GetDiscountRuleByID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id = $1 LIMIT 1", DiscountRuleAttributes, DiscountRuleTableName)
+	rows, err := r.fetch(ctx, query, discountRuleID)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if len(rows) == 0 {
+		return nil, response.ErrNotFound
+	}
+
+	return rows[0], nil
+}
+
+// GetDiscountRules return discount rules of a tenant in the evaluation order
+func (r *DiscountRuleRepository) GetDiscountRules(ctx context.Context, tenant types.TenantType) ([]*entity.DiscountRule, error) {
+	functionName := "DiscountRuleRepository.GetDiscountRules"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE tenant = $1 ORDER BY %s", DiscountRuleAttributes, DiscountRuleTableName, discountRuleOrder)
+	rows, err := r.fetch(ctx, query, tenant)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return rows, nil
+}
+
+// GetActiveDiscountRules return discount rules of a tenant which are running at the given time in the evaluation order
+func (r *DiscountRuleRepository) GetActiveDiscountRules(ctx context.Context, tenant types.TenantType, now time.Time) ([]*entity.DiscountRule, error) {
+	functionName := "DiscountRuleRepository.GetActiveDiscountRules"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE tenant = $1 AND (starts_at IS NULL OR starts_at <= $2) AND (ends_at IS NULL OR ends_at > $2) ORDER BY %s",
+		DiscountRuleAttributes,
+		DiscountRuleTableName,
+		discountRuleOrder,
+	)
+	rows, err := r.fetch(ctx, query, tenant, now)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return rows, nil
+}
+
+// UpdateDiscountRule update a discount rule
+func (r *DiscountRuleRepository) UpdateDiscountRule(ctx context.Context, discountRule *entity.DiscountRule) error {
+	functionName := "DiscountRuleRepository.UpdateDiscountRule"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	discountRule.UpdatedAt = time.Now()
+
+	query := fmt.Sprintf(`UPDATE %s SET %s WHERE id = $%d`, DiscountRuleTableName, UpdateColumnsValues(DiscountRuleCreationColumns), len(DiscountRuleColumns))
+
+	args := append(discountRuleValues(discountRule), discountRule.ID)
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// DeleteDiscountRule delete a discount rule
+func (r *DiscountRuleRepository) DeleteDiscountRule(ctx context.Context, discountRuleID int) error {
+	functionName := "DiscountRuleRepository.DeleteDiscountRule"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, DiscountRuleTableName)
+	if _, err := r.db.ExecContext(ctx, query, discountRuleID); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// discountRuleValues list values of discount rule in the order of creation columns
+func discountRuleValues(discountRule *entity.DiscountRule) []interface{} {
+	return []interface{}{
+		discountRule.Tenant,
+		discountRule.Name,
+		discountRule.Type,
+		discountRule.Value,
+		discountRule.SKU,
+		discountRule.Keyword,
+		categoryValues(discountRule.Categories),
+		conditionValues(discountRule.Conditions),
+		discountRule.Priority,
+		discountRule.Stackable,
+		discountRule.StartsAt,
+		discountRule.EndsAt,
+		discountRule.CreatedAt,
+		discountRule.UpdatedAt,
+	}
+}
+
+// categoryValues hold the categories as a smallint array, no categories are written as null
+func categoryValues(categories []types.CategoryType) pq.Int64Array {
+	if len(categories) == 0 {
+		return nil
+	}
+
+	values := make(pq.Int64Array, 0, len(categories))
+	for _, category := range categories {
+		values = append(values, int64(category))
+	}
+
+	return values
+}
+
+// conditionValues hold the conditions as a smallint array, no conditions are written as null
+func conditionValues(conditions []types.ConditionType) pq.Int64Array {
+	if len(conditions) == 0 {
+		return nil
+	}
+
+	values := make(pq.Int64Array, 0, len(conditions))
+	for _, condition := range conditions {
+		values = append(values, int64(condition))
+	}
+
+	return values
+}
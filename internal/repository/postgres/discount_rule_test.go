@@ -0,0 +1,373 @@
+package postgres_test
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	"github.com/stretchr/testify/assert"
+)
+
+func discountRuleRow(rows *sqlmock.Rows, d *entity.DiscountRule) *sqlmock.Rows {
+	var categories, conditions []int
+	for _, category := range d.Categories {
+		categories = append(categories, int(category))
+	}
+	for _, condition := range d.Conditions {
+		conditions = append(conditions, int(condition))
+	}
+
+	return rows.AddRow(
+		d.ID,
+		d.Tenant,
+		d.Name,
+		d.Type,
+		d.Value,
+		d.SKU,
+		d.Keyword,
+		smallintArray(categories),
+		smallintArray(conditions),
+		d.Priority,
+		d.Stackable,
+		d.StartsAt,
+		d.EndsAt,
+		d.CreatedAt,
+		d.UpdatedAt,
+	)
+}
+
+// smallintArray return the text of the smallint array read from the database, null when there is no value
+func smallintArray(values []int) interface{} {
+	if len(values) == 0 {
+		return nil
+	}
+
+	texts := make([]string, 0, len(values))
+	for _, value := range values {
+		texts = append(texts, strconv.Itoa(value))
+	}
+
+	return []byte("{" + strings.Join(texts, ",") + "}")
+}
+
+func TestCreateDiscountRule(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		createErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			createErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.createErr != nil {
+				mock.ExpectQuery("^INSERT INTO discount_rules(.+)").WillReturnError(tc.createErr)
+			} else {
+				mock.ExpectQuery("^INSERT INTO discount_rules(.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewDiscountRuleRepository(dbx)
+
+			discountRule := &entity.DiscountRule{}
+			err = repo.CreateDiscountRule(tc.ctx, discountRule)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, 1, discountRule.ID)
+			}
+		})
+	}
+}
+
+func TestGetDiscountRuleByID(t *testing.T) {
+
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		fetchErr  error
+		fetchRows []string
+		expected  *entity.DiscountRule
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "fail fetch return error rows",
+			ctx:       context.Background(),
+			fetchRows: []string{"unknown_column"},
+			wantErr:   true,
+		},
+		{
+			name:      "record not found",
+			ctx:       context.Background(),
+			fetchRows: postgres.DiscountRuleColumns,
+			wantErr:   true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: postgres.DiscountRuleColumns,
+			expected:  &entity.DiscountRule{ID: 1, Tenant: types.TenantLoremType, Type: types.DiscountPercentageType, Value: 2000, Categories: []types.CategoryType{types.CategoryBookType, types.CategoryBagType}, Conditions: []types.ConditionType{types.ConditionNewType}},
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery("^SELECT (.+) FROM discount_rules WHERE id = \\$1(.+)").WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				if tc.expected != nil {
+					rows = discountRuleRow(rows, tc.expected)
+				} else if len(tc.fetchRows) == 1 {
+					rows = rows.AddRow(1)
+				}
+				mock.ExpectQuery("^SELECT (.+) FROM discount_rules WHERE id = \\$1(.+)").WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewDiscountRuleRepository(dbx)
+
+			res, err := repo.GetDiscountRuleByID(tc.ctx, 1)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, tc.expected, res)
+			}
+		})
+	}
+}
+
+func TestGetDiscountRules(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		active    bool
+		fetchErr  error
+		fetchRows []string
+		expected  []*entity.DiscountRule
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: postgres.DiscountRuleColumns,
+			expected:  []*entity.DiscountRule{{ID: 1, Tenant: types.TenantLoremType, Type: types.DiscountFixedAmountType}},
+			wantErr:   false,
+		},
+		{
+			name:    "deadline context of active rules",
+			ctx:     fixture.CtxEnded(),
+			active:  true,
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error of active rules",
+			ctx:      context.Background(),
+			active:   true,
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "success of active rules",
+			ctx:       context.Background(),
+			active:    true,
+			fetchRows: postgres.DiscountRuleColumns,
+			expected:  []*entity.DiscountRule{{ID: 1, Tenant: types.TenantLoremType, Type: types.DiscountFixedAmountType}},
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			query := "^SELECT (.+) FROM discount_rules WHERE tenant = \\$1 ORDER BY priority DESC, id ASC"
+			if tc.active {
+				query = "^SELECT (.+) FROM discount_rules WHERE tenant = \\$1 AND (.+)starts_at <= \\$2(.+)ends_at > \\$2(.+) ORDER BY priority DESC, id ASC"
+			}
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery(query).WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				for _, discountRule := range tc.expected {
+					rows = discountRuleRow(rows, discountRule)
+				}
+				mock.ExpectQuery(query).WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewDiscountRuleRepository(dbx)
+
+			var res []*entity.DiscountRule
+			if tc.active {
+				res, err = repo.GetActiveDiscountRules(tc.ctx, types.TenantLoremType, time.Now())
+			} else {
+				res, err = repo.GetDiscountRules(tc.ctx, types.TenantLoremType)
+			}
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, tc.expected, res)
+			}
+		})
+	}
+}
+
+func TestUpdateDiscountRule(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		updateErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			updateErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.updateErr != nil {
+				mock.ExpectExec("^UPDATE discount_rules SET (.+) WHERE id = \\$15").WillReturnError(tc.updateErr)
+			} else {
+				mock.ExpectExec("^UPDATE discount_rules SET (.+) WHERE id = \\$15").WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewDiscountRuleRepository(dbx)
+
+			err = repo.UpdateDiscountRule(tc.ctx, &entity.DiscountRule{ID: 1})
+			assert.Equal(t, tc.wantErr, err != nil)
+		})
+	}
+}
+
+func TestDeleteDiscountRule(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		deleteErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			deleteErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.deleteErr != nil {
+				mock.ExpectExec("^DELETE FROM discount_rules WHERE id = \\$1").WillReturnError(tc.deleteErr)
+			} else {
+				mock.ExpectExec("^DELETE FROM discount_rules WHERE id = \\$1").WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewDiscountRuleRepository(dbx)
+
+			err = repo.DeleteDiscountRule(tc.ctx, 1)
+			assert.Equal(t, tc.wantErr, err != nil)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+)
+
+// CycleCount struct holds cycle count database representative
+type CycleCount struct {
+	ID        int                        `db:"id"`
+	Tenant    types.TenantType           `db:"tenant"`
+	Status    types.CycleCountStatusType `db:"status"`
+	CreatedAt time.Time                  `db:"created_at"`
+	UpdatedAt time.Time                  `db:"updated_at"`
+}
+
+// ToEntity to convert cycle count from database to entity contract
+func (c *CycleCount) ToEntity() *entity.CycleCount {
+	return &entity.CycleCount{
+		ID:        c.ID,
+		Tenant:    c.Tenant,
+		Status:    c.Status,
+		Items:     []*entity.CycleCountItem{},
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+// CycleCountItem struct holds cycle count item database representative joined with its product
+type CycleCountItem struct {
+	ID           int    `db:"id"`
+	CycleCountID int    `db:"cycle_count_id"`
+	ProductID    int    `db:"product_id"`
+	SKU          string `db:"sku"`
+	SystemQty    int    `db:"system_qty"`
+	CountedQty   int    `db:"counted_qty"`
+	DiffQty      int    `db:"diff_qty"`
+}
+
+// ToEntity to convert cycle count item from database to entity contract
+func (c *CycleCountItem) ToEntity() *entity.CycleCountItem {
+	return &entity.CycleCountItem{
+		ID:           c.ID,
+		CycleCountID: c.CycleCountID,
+		ProductID:    c.ProductID,
+		SKU:          c.SKU,
+		SystemQty:    c.SystemQty,
+		CountedQty:   c.CountedQty,
+		DiffQty:      c.DiffQty,
+	}
+}
@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+)
+
+// DiscountRule struct holds discount rule database representative
+type DiscountRule struct {
+	ID         int                `db:"id"`
+	Tenant     types.TenantType   `db:"tenant"`
+	Name       string             `db:"name"`
+	Type       types.DiscountType `db:"type"`
+	Value      int                `db:"value"`
+	SKU        *string            `db:"sku"`
+	Keyword    *string            `db:"keyword"`
+	Categories pq.Int64Array      `db:"categories"`
+	Conditions pq.Int64Array      `db:"conditions"`
+	Priority   int                `db:"priority"`
+	Stackable  bool               `db:"stackable"`
+	StartsAt   *time.Time         `db:"starts_at"`
+	EndsAt     *time.Time         `db:"ends_at"`
+	CreatedAt  time.Time          `db:"created_at"`
+	UpdatedAt  time.Time          `db:"updated_at"`
+}
+
+// ToEntity to convert discount rule from database to entity contract
+func (d *DiscountRule) ToEntity() *entity.DiscountRule {
+	var categories []types.CategoryType
+	for _, category := range d.Categories {
+		categories = append(categories, types.CategoryType(category))
+	}
+
+	var conditions []types.ConditionType
+	for _, condition := range d.Conditions {
+		conditions = append(conditions, types.ConditionType(condition))
+	}
+
+	return &entity.DiscountRule{
+		ID:         d.ID,
+		Tenant:     d.Tenant,
+		Name:       d.Name,
+		Type:       d.Type,
+		Value:      d.Value,
+		SKU:        d.SKU,
+		Keyword:    d.Keyword,
+		Categories: categories,
+		Conditions: conditions,
+		Priority:   d.Priority,
+		Stackable:  d.Stackable,
+		StartsAt:   d.StartsAt,
+		EndsAt:     d.EndsAt,
+		CreatedAt:  d.CreatedAt,
+		UpdatedAt:  d.UpdatedAt,
+	}
+}
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+)
+
+// IdempotencyKey struct holds idempotency key database representative
+type IdempotencyKey struct {
+	Tenant          types.TenantType `db:"tenant"`
+	Key             string           `db:"key"`
+	RequestHash     string           `db:"request_hash"`
+	StatusCode      int              `db:"status_code"`
+	ResponseHeaders ResponseHeaders  `db:"response_headers"`
+	ResponseBody    []byte           `db:"response_body"`
+	CreatedAt       time.Time        `db:"created_at"`
+	ExpiresAt       time.Time        `db:"expires_at"`
+}
+
+// ToEntity to convert idempotency key from database to entity contract
+func (k *IdempotencyKey) ToEntity() *entity.IdempotencyKey {
+	return &entity.IdempotencyKey{
+		Tenant:          k.Tenant,
+		Key:             k.Key,
+		RequestHash:     k.RequestHash,
+		StatusCode:      k.StatusCode,
+		ResponseHeaders: k.ResponseHeaders,
+		ResponseBody:    k.ResponseBody,
+		CreatedAt:       k.CreatedAt,
+		ExpiresAt:       k.ExpiresAt,
+	}
+}
+
+// ResponseHeaders holds the replayed headers of the stored response as jsonb
+type ResponseHeaders map[string]string
+
+// Value is used to write the headers as json text, no headers are written as an empty object
+func (h ResponseHeaders) Value() (driver.Value, error) {
+	if h == nil {
+		return "{}", nil
+	}
+
+	data, err := json.Marshal(map[string]string(h))
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+// Scan is used to read the headers from json
+func (h *ResponseHeaders) Scan(value interface{}) error {
+	if value == nil {
+		*h = nil
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported response headers type %T", value)
+	}
+
+	return json.Unmarshal(data, h)
+}
@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+)
+
+// Location struct holds location database representative
+type Location struct {
+	ID        int              `db:"id"`
+	Tenant    types.TenantType `db:"tenant"`
+	Code      string           `db:"code"`
+	Name      string           `db:"name"`
+	Priority  int              `db:"priority"`
+	IsDefault bool             `db:"is_default"`
+	CreatedAt time.Time        `db:"created_at"`
+	UpdatedAt time.Time        `db:"updated_at"`
+}
+
+// ToEntity to convert location from database to entity contract
+func (l *Location) ToEntity() *entity.Location {
+	return &entity.Location{
+		ID:        l.ID,
+		Tenant:    l.Tenant,
+		Code:      l.Code,
+		Name:      l.Name,
+		Priority:  l.Priority,
+		IsDefault: l.IsDefault,
+		CreatedAt: l.CreatedAt,
+		UpdatedAt: l.UpdatedAt,
+	}
+}
+
+// ProductStock struct holds product stock database representative joined with its location
+type ProductStock struct {
+	ProductID        int       `db:"product_id"`
+	LocationID       int       `db:"location_id"`
+	Qty              int       `db:"qty"`
+	UpdatedAt        time.Time `db:"updated_at"`
+	LocationCode     string    `db:"location_code"`
+	LocationName     string    `db:"location_name"`
+	LocationPriority int       `db:"location_priority"`
+}
+
+// ToEntity to convert product stock from database to entity contract
+func (p *ProductStock) ToEntity() *entity.ProductStock {
+	return &entity.ProductStock{
+		ProductID:        p.ProductID,
+		LocationID:       p.LocationID,
+		LocationCode:     p.LocationCode,
+		LocationName:     p.LocationName,
+		LocationPriority: p.LocationPriority,
+		Qty:              p.Qty,
+		UpdatedAt:        p.UpdatedAt,
+	}
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+)
+
+// LowStockThreshold struct holds low stock threshold database representative
+type LowStockThreshold struct {
+	Tenant    types.TenantType `db:"tenant"`
+	Threshold int              `db:"threshold"`
+	UpdatedAt time.Time        `db:"updated_at"`
+}
+
+// ToEntity to convert low stock threshold from database to entity contract
+func (t *LowStockThreshold) ToEntity() *entity.LowStockThreshold {
+	return &entity.LowStockThreshold{
+		Tenant:    t.Tenant,
+		Threshold: t.Threshold,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+// LowStockAlert struct holds low stock alert database representative
+type LowStockAlert struct {
+	ID        int              `db:"id"`
+	ProductID int              `db:"product_id"`
+	Tenant    types.TenantType `db:"tenant"`
+	SKU       string           `db:"sku"`
+	Title     string           `db:"title"`
+	Qty       int              `db:"qty"`
+	Threshold int              `db:"threshold"`
+	CreatedAt time.Time        `db:"created_at"`
+	SentAt    *time.Time       `db:"sent_at"`
+}
+
+// ToEntity to convert low stock alert from database to entity contract
+func (a *LowStockAlert) ToEntity() *entity.LowStockAlert {
+	return &entity.LowStockAlert{
+		ID:        a.ID,
+		ProductID: a.ProductID,
+		Tenant:    a.Tenant,
+		SKU:       a.SKU,
+		Title:     a.Title,
+		Qty:       a.Qty,
+		Threshold: a.Threshold,
+		CreatedAt: a.CreatedAt,
+		SentAt:    a.SentAt,
+	}
+}
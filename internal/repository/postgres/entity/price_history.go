@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+)
+
+// PriceHistory struct holds price history database representative
+type PriceHistory struct {
+	ID             int              `db:"id"`
+	ProductID      int              `db:"product_id"`
+	Tenant         types.TenantType `db:"tenant"`
+	Price          int              `db:"price"`
+	PromotionPrice *int             `db:"promotion_price"`
+	EffectivePrice int              `db:"effective_price"`
+	RecordedAt     time.Time        `db:"recorded_at"`
+}
+
+// ToEntity to convert price history from database to entity contract
+func (p *PriceHistory) ToEntity() *entity.PriceHistory {
+	return &entity.PriceHistory{
+		ID:             p.ID,
+		ProductID:      p.ProductID,
+		Tenant:         p.Tenant,
+		Price:          p.Price,
+		PromotionPrice: p.PromotionPrice,
+		EffectivePrice: p.EffectivePrice,
+		RecordedAt:     p.RecordedAt,
+	}
+}
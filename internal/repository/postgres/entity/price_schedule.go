@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+)
+
+// PriceSchedule struct holds price schedule database representative
+type PriceSchedule struct {
+	ID        int                           `db:"id"`
+	ProductID int                           `db:"product_id"`
+	Tenant    types.TenantType              `db:"tenant"`
+	Type      types.PriceScheduleType       `db:"type"`
+	Status    types.PriceScheduleStatusType `db:"status"`
+	Price     int                           `db:"price"`
+	StartsAt  time.Time                     `db:"starts_at"`
+	EndsAt    *time.Time                    `db:"ends_at"`
+	CreatedAt time.Time                     `db:"created_at"`
+	UpdatedAt time.Time                     `db:"updated_at"`
+}
+
+// ToEntity to convert price schedule from database to entity contract
+func (p *PriceSchedule) ToEntity() *entity.PriceSchedule {
+	return &entity.PriceSchedule{
+		ID:        p.ID,
+		ProductID: p.ProductID,
+		Tenant:    p.Tenant,
+		Type:      p.Type,
+		Status:    p.Status,
+		Price:     p.Price,
+		StartsAt:  p.StartsAt,
+		EndsAt:    p.EndsAt,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
@@ -1,6 +1,9 @@
 package entity
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/satriowisnugroho/catalog/internal/entity"
@@ -9,30 +12,101 @@ import (
 
 // Product struct holds attachment database representative
 type Product struct {
-	ID        int                 `db:"id"`
-	SKU       string              `db:"sku"`
-	Title     string              `db:"title"`
-	Category  types.CategoryType  `db:"category"`
-	Condition types.ConditionType `db:"condition"`
-	Tenant    types.TenantType    `db:"tenant"`
-	Qty       int                 `db:"qty"`
-	Price     int                 `db:"price"`
-	CreatedAt time.Time           `db:"created_at"`
-	UpdatedAt time.Time           `db:"updated_at"`
+	ID                int                       `db:"id"`
+	SKU               string                    `db:"sku"`
+	Title             string                    `db:"title"`
+	Description       string                    `db:"description"`
+	Attributes        ProductAttributes         `db:"attributes"`
+	Category          types.CategoryType        `db:"category"`
+	Condition         types.ConditionType       `db:"condition"`
+	Tenant            types.TenantType          `db:"tenant"`
+	Qty               int                       `db:"qty"`
+	ReservedQty       int                       `db:"reserved_qty"`
+	BackorderedQty    int                       `db:"backordered_qty"`
+	InTransitQty      int                       `db:"in_transit_qty"`
+	Price             int                       `db:"price"`
+	PromotionPrice    *int                      `db:"promotion_price"`
+	TaxClassID        *int                      `db:"tax_class_id"`
+	CostPrice         *int                      `db:"cost_price"`
+	LowStockThreshold *int                      `db:"low_stock_threshold"`
+	LowStockAlerted   bool                      `db:"low_stock_alerted"`
+	BackorderPolicy   types.BackorderPolicyType `db:"backorder_policy"`
+	BackorderLimit    *int                      `db:"backorder_limit"`
+	RestockDate       *time.Time                `db:"restock_date"`
+	Serialized        bool                      `db:"serialized"`
+	Version           int                       `db:"version"`
+	Relevance         float64                   `db:"relevance"`
+	Highlight         string                    `db:"highlight"`
+	CreatedAt         time.Time                 `db:"created_at"`
+	UpdatedAt         time.Time                 `db:"updated_at"`
 }
 
 // ToEntity to convert product from database to entity contract
 func (p *Product) ToEntity() *entity.Product {
 	return &entity.Product{
-		ID:        p.ID,
-		SKU:       p.SKU,
-		Title:     p.Title,
-		Category:  p.Category,
-		Condition: p.Condition,
-		Tenant:    p.Tenant,
-		Qty:       p.Qty,
-		Price:     p.Price,
-		CreatedAt: p.CreatedAt,
-		UpdatedAt: p.UpdatedAt,
+		ID:                p.ID,
+		SKU:               p.SKU,
+		Title:             p.Title,
+		Description:       p.Description,
+		Attributes:        p.Attributes,
+		Category:          p.Category,
+		Condition:         p.Condition,
+		Tenant:            p.Tenant,
+		Qty:               p.Qty,
+		ReservedQty:       p.ReservedQty,
+		BackorderedQty:    p.BackorderedQty,
+		InTransitQty:      p.InTransitQty,
+		Price:             p.Price,
+		PromotionPrice:    p.PromotionPrice,
+		TaxClassID:        p.TaxClassID,
+		CostPrice:         p.CostPrice,
+		LowStockThreshold: p.LowStockThreshold,
+		LowStockAlerted:   p.LowStockAlerted,
+		BackorderPolicy:   p.BackorderPolicy,
+		BackorderLimit:    p.BackorderLimit,
+		RestockDate:       p.RestockDate,
+		Serialized:        p.Serialized,
+		Version:           p.Version,
+		Relevance:         p.Relevance,
+		Highlight:         p.Highlight,
+		CreatedAt:         p.CreatedAt,
+		UpdatedAt:         p.UpdatedAt,
 	}
 }
+
+// ProductAttributes holds the attributes of the product stored as jsonb
+type ProductAttributes map[string]string
+
+// Value is used to write the attributes as json text, no attributes are written as an empty object
+func (a ProductAttributes) Value() (driver.Value, error) {
+	if a == nil {
+		return "{}", nil
+	}
+
+	data, err := json.Marshal(map[string]string(a))
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+// Scan is used to read the attributes from json
+func (a *ProductAttributes) Scan(value interface{}) error {
+	if value == nil {
+		*a = nil
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported attributes type %T", value)
+	}
+
+	return json.Unmarshal(data, a)
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+)
+
+// ProductLot struct holds product lot database representative
+type ProductLot struct {
+	ID         int       `db:"id"`
+	ProductID  int       `db:"product_id"`
+	LotNumber  string    `db:"lot_number"`
+	ExpiryDate time.Time `db:"expiry_date"`
+	Qty        int       `db:"qty"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+// ToEntity to convert product lot from database to entity contract
+func (p *ProductLot) ToEntity() *entity.ProductLot {
+	return &entity.ProductLot{
+		ID:         p.ID,
+		ProductID:  p.ProductID,
+		LotNumber:  p.LotNumber,
+		ExpiryDate: p.ExpiryDate,
+		Qty:        p.Qty,
+		CreatedAt:  p.CreatedAt,
+		UpdatedAt:  p.UpdatedAt,
+	}
+}
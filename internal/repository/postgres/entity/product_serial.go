@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+)
+
+// ProductSerial struct holds product serial database representative
+type ProductSerial struct {
+	ID           int                    `db:"id"`
+	ProductID    int                    `db:"product_id"`
+	Tenant       types.TenantType       `db:"tenant"`
+	SerialNumber string                 `db:"serial_number"`
+	Status       types.SerialStatusType `db:"status"`
+	ReferenceID  string                 `db:"reference_id"`
+	Actor        string                 `db:"actor"`
+	DeductedAt   *time.Time             `db:"deducted_at"`
+	CreatedAt    time.Time              `db:"created_at"`
+	UpdatedAt    time.Time              `db:"updated_at"`
+}
+
+// ToEntity to convert product serial from database to entity contract
+func (p *ProductSerial) ToEntity() *entity.ProductSerial {
+	return &entity.ProductSerial{
+		ID:           p.ID,
+		ProductID:    p.ProductID,
+		Tenant:       p.Tenant,
+		SerialNumber: p.SerialNumber,
+		Status:       p.Status,
+		ReferenceID:  p.ReferenceID,
+		Actor:        p.Actor,
+		DeductedAt:   p.DeductedAt,
+		CreatedAt:    p.CreatedAt,
+		UpdatedAt:    p.UpdatedAt,
+	}
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+)
+
+// Reservation struct holds reservation database representative
+type Reservation struct {
+	ID        int                         `db:"id"`
+	Tenant    types.TenantType            `db:"tenant"`
+	Status    types.ReservationStatusType `db:"status"`
+	ExpiresAt time.Time                   `db:"expires_at"`
+	CreatedAt time.Time                   `db:"created_at"`
+	UpdatedAt time.Time                   `db:"updated_at"`
+}
+
+// ToEntity to convert reservation from database to entity contract
+func (r *Reservation) ToEntity() *entity.Reservation {
+	return &entity.Reservation{
+		ID:        r.ID,
+		Tenant:    r.Tenant,
+		Status:    r.Status,
+		Items:     []*entity.ReservationItem{},
+		ExpiresAt: r.ExpiresAt,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+// ReservationItem struct holds reservation item database representative joined with its product
+type ReservationItem struct {
+	ID            int    `db:"id"`
+	ReservationID int    `db:"reservation_id"`
+	ProductID     int    `db:"product_id"`
+	SKU           string `db:"sku"`
+	Qty           int    `db:"qty"`
+}
+
+// ToEntity to convert reservation item from database to entity contract
+func (r *ReservationItem) ToEntity() *entity.ReservationItem {
+	return &entity.ReservationItem{
+		ID:            r.ID,
+		ReservationID: r.ReservationID,
+		ProductID:     r.ProductID,
+		SKU:           r.SKU,
+		Qty:           r.Qty,
+	}
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+)
+
+// StockMovement struct holds stock movement database representative
+type StockMovement struct {
+	ID           int                           `db:"id"`
+	ProductID    int                           `db:"product_id"`
+	Tenant       types.TenantType              `db:"tenant"`
+	Delta        int                           `db:"delta"`
+	ResultingQty int                           `db:"resulting_qty"`
+	Reason       types.StockMovementReasonType `db:"reason"`
+	Actor        string                        `db:"actor"`
+	ReferenceID  string                        `db:"reference_id"`
+	CreatedAt    time.Time                     `db:"created_at"`
+}
+
+// ToEntity to convert stock movement from database to entity contract
+func (s *StockMovement) ToEntity() *entity.StockMovement {
+	return &entity.StockMovement{
+		ID:           s.ID,
+		ProductID:    s.ProductID,
+		Tenant:       s.Tenant,
+		Delta:        s.Delta,
+		ResultingQty: s.ResultingQty,
+		Reason:       s.Reason,
+		Actor:        s.Actor,
+		ReferenceID:  s.ReferenceID,
+		CreatedAt:    s.CreatedAt,
+	}
+}
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+)
+
+// TaxClass struct holds tax class database representative
+type TaxClass struct {
+	ID               int              `db:"id"`
+	Tenant           types.TenantType `db:"tenant"`
+	Name             string           `db:"name"`
+	PriceIncludesTax bool             `db:"price_includes_tax"`
+	CreatedAt        time.Time        `db:"created_at"`
+	UpdatedAt        time.Time        `db:"updated_at"`
+}
+
+// ToEntity to convert tax class from database to entity contract
+func (t *TaxClass) ToEntity() *entity.TaxClass {
+	return &entity.TaxClass{
+		ID:               t.ID,
+		Tenant:           t.Tenant,
+		Name:             t.Name,
+		PriceIncludesTax: t.PriceIncludesTax,
+		Rates:            []*entity.TaxRate{},
+		Categories:       []types.CategoryType{},
+		CreatedAt:        t.CreatedAt,
+		UpdatedAt:        t.UpdatedAt,
+	}
+}
+
+// TaxRate struct holds tax rate database representative
+type TaxRate struct {
+	ID         int       `db:"id"`
+	TaxClassID int       `db:"tax_class_id"`
+	Region     string    `db:"region"`
+	Rate       int       `db:"rate"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+// ToEntity to convert tax rate from database to entity contract
+func (t *TaxRate) ToEntity() *entity.TaxRate {
+	return &entity.TaxRate{
+		ID:         t.ID,
+		TaxClassID: t.TaxClassID,
+		Region:     t.Region,
+		Rate:       t.Rate,
+		CreatedAt:  t.CreatedAt,
+		UpdatedAt:  t.UpdatedAt,
+	}
+}
+
+// CategoryTaxClass struct holds category tax class database representative
+type CategoryTaxClass struct {
+	Tenant     types.TenantType   `db:"tenant"`
+	Category   types.CategoryType `db:"category"`
+	TaxClassID int                `db:"tax_class_id"`
+}
+
+// ToEntity to convert category tax class from database to entity contract
+func (c *CategoryTaxClass) ToEntity() *entity.CategoryTaxClass {
+	return &entity.CategoryTaxClass{
+		Tenant:     c.Tenant,
+		Category:   c.Category,
+		TaxClassID: c.TaxClassID,
+	}
+}
@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+)
+
+// Transfer struct holds transfer database representative
+type Transfer struct {
+	ID             int                      `db:"id"`
+	Tenant         types.TenantType         `db:"tenant"`
+	FromLocationID int                      `db:"from_location_id"`
+	ToLocationID   int                      `db:"to_location_id"`
+	Status         types.TransferStatusType `db:"status"`
+	ReferenceID    string                   `db:"reference_id"`
+	DispatchedAt   *time.Time               `db:"dispatched_at"`
+	ReceivedAt     *time.Time               `db:"received_at"`
+	CreatedAt      time.Time                `db:"created_at"`
+	UpdatedAt      time.Time                `db:"updated_at"`
+}
+
+// ToEntity to convert transfer from database to entity contract
+func (t *Transfer) ToEntity() *entity.Transfer {
+	return &entity.Transfer{
+		ID:             t.ID,
+		Tenant:         t.Tenant,
+		FromLocationID: t.FromLocationID,
+		ToLocationID:   t.ToLocationID,
+		Status:         t.Status,
+		ReferenceID:    t.ReferenceID,
+		Items:          []*entity.TransferItem{},
+		DispatchedAt:   t.DispatchedAt,
+		ReceivedAt:     t.ReceivedAt,
+		CreatedAt:      t.CreatedAt,
+		UpdatedAt:      t.UpdatedAt,
+	}
+}
+
+// TransferItem struct holds transfer item database representative joined with its product
+type TransferItem struct {
+	ID         int    `db:"id"`
+	TransferID int    `db:"transfer_id"`
+	ProductID  int    `db:"product_id"`
+	SKU        string `db:"sku"`
+	Qty        int    `db:"qty"`
+}
+
+// ToEntity to convert transfer item from database to entity contract
+func (t *TransferItem) ToEntity() *entity.TransferItem {
+	return &entity.TransferItem{
+		ID:         t.ID,
+		TransferID: t.TransferID,
+		ProductID:  t.ProductID,
+		SKU:        t.SKU,
+		Qty:        t.Qty,
+	}
+}
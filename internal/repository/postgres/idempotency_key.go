@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	dbentity "github.com/satriowisnugroho/catalog/internal/repository/postgres/entity"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// IdempotencyKeyRepositoryInterface define contract for idempotency key related functions to repository
+type IdempotencyKeyRepositoryInterface interface {
+	CreateIdempotencyKey(ctx context.Context, key *entity.IdempotencyKey, inProgressBefore time.Time) (bool, error)
+	GetIdempotencyKey(ctx context.Context, tenant types.TenantType, key string) (*entity.IdempotencyKey, error)
+	UpdateIdempotencyKeyResponse(ctx context.Context, key *entity.IdempotencyKey) error
+	DeleteIdempotencyKey(ctx context.Context, key *entity.IdempotencyKey) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) error
+}
+
+// IdempotencyKeyRepository holds database connection
+type IdempotencyKeyRepository struct {
+	db *sqlx.DB
+}
+
+var (
+	// IdempotencyKeyTableName hold table name for idempotency keys
+	IdempotencyKeyTableName = "idempotency_keys"
+	// IdempotencyKeyColumns list all columns on idempotency keys table
+	IdempotencyKeyColumns = []string{"tenant", "key", "request_hash", "status_code", "response_headers", "response_body", "created_at", "expires_at"}
+	// IdempotencyKeyAttributes hold string format of all idempotency keys table columns
+	IdempotencyKeyAttributes = strings.Join(IdempotencyKeyColumns, ", ")
+)
+
+// NewIdempotencyKeyRepository create initiate idempotency key repository with given database
+func NewIdempotencyKeyRepository(db *sqlx.DB) *IdempotencyKeyRepository {
+	return &IdempotencyKeyRepository{db: db}
+}
+
+func (r *IdempotencyKeyRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]*entity.IdempotencyKey, error) {
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]*entity.IdempotencyKey, 0)
+
+	for rows.Next() {
+		tmpEntity := dbentity.IdempotencyKey{}
+		if err := rows.StructScan(&tmpEntity); err != nil {
+			return nil, errors.Wrap(err, "fetch")
+		}
+
+		result = append(result, tmpEntity.ToEntity())
+	}
+
+	return result, nil
+}
+
+// CreateIdempotencyKey claim the key for the request, it returns false when the key is already claimed
+// by another request and not expired yet. An expired key, or a key still in progress which was claimed
+// before inProgressBefore, is taken over by the request. The claim is identified by its created at
+func (r *IdempotencyKeyRepository) CreateIdempotencyKey(ctx context.Context, key *entity.IdempotencyKey, inProgressBefore time.Time) (bool, error) {
+	functionName := "IdempotencyKeyRepository.CreateIdempotencyKey"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return false, errors.Wrap(err, functionName)
+	}
+
+	// The created at is stored with the precision of the column so that the claim can be matched by it
+	key.CreatedAt = time.Now().Truncate(time.Microsecond)
+	key.StatusCode = 0
+	key.ResponseHeaders = nil
+	key.ResponseBody = nil
+
+	// The claim is done on the same statement so that only one of the concurrent requests can get the key
+	query := fmt.Sprintf(
+		`INSERT INTO %[1]s (%[2]s) VALUES (%[3]s) ON CONFLICT (tenant, key) DO UPDATE SET request_hash = EXCLUDED.request_hash, status_code = EXCLUDED.status_code, response_headers = EXCLUDED.response_headers, response_body = EXCLUDED.response_body, created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at WHERE %[1]s.expires_at <= EXCLUDED.created_at OR (%[1]s.status_code = 0 AND %[1]s.created_at <= $%[4]d)`,
+		IdempotencyKeyTableName,
+		IdempotencyKeyAttributes,
+		EnumeratedBindvars(IdempotencyKeyColumns),
+		len(IdempotencyKeyColumns)+1,
+	)
+
+	result, err := r.db.ExecContext(ctx, query,
+		key.Tenant,
+		key.Key,
+		key.RequestHash,
+		key.StatusCode,
+		dbentity.ResponseHeaders(key.ResponseHeaders),
+		key.ResponseBody,
+		key.CreatedAt,
+		key.ExpiresAt,
+		inProgressBefore,
+	)
+	if err != nil {
+		return false, errors.Wrap(err, functionName)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, functionName)
+	}
+
+	return affected > 0, nil
+}
+
+// GetIdempotencyKey return idempotency key of the tenant
+func (r *IdempotencyKeyRepository) GetIdempotencyKey(ctx context.Context, tenant types.TenantType, key string) (*entity.IdempotencyKey, error) {
+	functionName := "IdempotencyKeyRepository.GetIdempotencyKey"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE tenant = $1 AND key = $2 LIMIT 1", IdempotencyKeyAttributes, IdempotencyKeyTableName)
+	rows, err := r.fetch(ctx, query, tenant, key)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if len(rows) == 0 {
+		return nil, response.ErrNotFound
+	}
+
+	return rows[0], nil
+}
+
+// UpdateIdempotencyKeyResponse store the response of the request which claimed the key,
+// nothing is stored when the key has been taken over by another request
+func (r *IdempotencyKeyRepository) UpdateIdempotencyKeyResponse(ctx context.Context, key *entity.IdempotencyKey) error {
+	functionName := "IdempotencyKeyRepository.UpdateIdempotencyKeyResponse"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET status_code = $1, response_headers = $2, response_body = $3 WHERE tenant = $4 AND key = $5 AND created_at = $6 AND status_code = 0", IdempotencyKeyTableName)
+	if _, err := r.db.ExecContext(ctx, query, key.StatusCode, dbentity.ResponseHeaders(key.ResponseHeaders), key.ResponseBody, key.Tenant, key.Key, key.CreatedAt); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// DeleteIdempotencyKey release the key claimed by the request whose response is not stored yet so that the request can be retried,
+// the key is kept when it has been taken over by another request
+func (r *IdempotencyKeyRepository) DeleteIdempotencyKey(ctx context.Context, key *entity.IdempotencyKey) error {
+	functionName := "IdempotencyKeyRepository.DeleteIdempotencyKey"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE tenant = $1 AND key = $2 AND created_at = $3 AND status_code = 0", IdempotencyKeyTableName)
+	if _, err := r.db.ExecContext(ctx, query, key.Tenant, key.Key, key.CreatedAt); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// DeleteExpiredIdempotencyKeys remove the keys which are no longer replayed
+func (r *IdempotencyKeyRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) error {
+	functionName := "IdempotencyKeyRepository.DeleteExpiredIdempotencyKeys"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE expires_at <= $1", IdempotencyKeyTableName)
+	if _, err := r.db.ExecContext(ctx, query, now); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
@@ -0,0 +1,309 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateIdempotencyKey(t *testing.T) {
+	testcases := []struct {
+		name        string
+		ctx         context.Context
+		createErr   error
+		affected    int64
+		wantCreated bool
+		wantErr     bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			createErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:        "key is already claimed",
+			ctx:         context.Background(),
+			affected:    0,
+			wantCreated: false,
+			wantErr:     false,
+		},
+		{
+			name:        "success",
+			ctx:         context.Background(),
+			affected:    1,
+			wantCreated: true,
+			wantErr:     false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			query := mock.ExpectExec("^INSERT INTO idempotency_keys (.+) ON CONFLICT \\(tenant, key\\) DO UPDATE (.+) WHERE idempotency_keys.expires_at <= EXCLUDED.created_at OR \\(idempotency_keys.status_code = 0 AND idempotency_keys.created_at <= \\$9\\)")
+			if tc.createErr != nil {
+				query.WillReturnError(tc.createErr)
+			} else {
+				query.WillReturnResult(sqlmock.NewResult(0, tc.affected))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewIdempotencyKeyRepository(dbx)
+			key := &entity.IdempotencyKey{Tenant: types.TenantLoremType, Key: "key", ExpiresAt: time.Now().Add(time.Hour)}
+			created, err := repo.CreateIdempotencyKey(tc.ctx, key, time.Now().Add(-time.Minute))
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			assert.Equal(t, tc.wantCreated, created)
+			if !tc.wantErr {
+				// The claim is matched by its created at as stored by the column
+				assert.Equal(t, key.CreatedAt.Truncate(time.Microsecond), key.CreatedAt)
+			}
+		})
+	}
+}
+
+func TestGetIdempotencyKey(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		fetchErr  error
+		fetchRows []string
+		expected  *entity.IdempotencyKey
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "fail fetch return error rows",
+			ctx:       context.Background(),
+			fetchRows: []string{"unknown_column"},
+			wantErr:   true,
+		},
+		{
+			name:      "record not found",
+			ctx:       context.Background(),
+			fetchRows: postgres.IdempotencyKeyColumns,
+			wantErr:   true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: postgres.IdempotencyKeyColumns,
+			expected:  &entity.IdempotencyKey{Tenant: types.TenantLoremType, Key: "key", RequestHash: "hash", StatusCode: 200, ResponseHeaders: map[string]string{"ETag": `"1"`}, ResponseBody: []byte(`{}`)},
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery("^SELECT (.+) FROM idempotency_keys WHERE tenant = \\$1 AND key = \\$2(.+)").WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				if tc.expected != nil {
+					rows = rows.AddRow(
+						tc.expected.Tenant,
+						tc.expected.Key,
+						tc.expected.RequestHash,
+						tc.expected.StatusCode,
+						[]byte(`{"ETag":"\"1\""}`),
+						tc.expected.ResponseBody,
+						tc.expected.CreatedAt,
+						tc.expected.ExpiresAt,
+					)
+				} else if len(tc.fetchRows) == 1 {
+					rows = rows.AddRow(1)
+				}
+
+				mock.ExpectQuery("^SELECT (.+) FROM idempotency_keys WHERE tenant = \\$1 AND key = \\$2(.+)").WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewIdempotencyKeyRepository(dbx)
+			result, err := repo.GetIdempotencyKey(tc.ctx, types.TenantLoremType, "key")
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			if !tc.wantErr {
+				assert.EqualValues(t, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestUpdateIdempotencyKeyResponse(t *testing.T) {
+	createdAt := time.Now()
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		updateErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			updateErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			query := mock.ExpectExec("^UPDATE idempotency_keys SET status_code = \\$1, response_headers = \\$2, response_body = \\$3 WHERE tenant = \\$4 AND key = \\$5 AND created_at = \\$6 AND status_code = 0").WithArgs(200, sqlmock.AnyArg(), sqlmock.AnyArg(), types.TenantLoremType, "key", createdAt)
+			if tc.updateErr != nil {
+				query.WillReturnError(tc.updateErr)
+			} else {
+				query.WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewIdempotencyKeyRepository(dbx)
+			err = repo.UpdateIdempotencyKeyResponse(tc.ctx, &entity.IdempotencyKey{Tenant: types.TenantLoremType, Key: "key", StatusCode: 200, CreatedAt: createdAt})
+			assert.Equal(t, tc.wantErr, err != nil, err)
+		})
+	}
+}
+
+func TestDeleteIdempotencyKey(t *testing.T) {
+	createdAt := time.Now()
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		deleteErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			deleteErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			query := mock.ExpectExec("^DELETE FROM idempotency_keys WHERE tenant = \\$1 AND key = \\$2 AND created_at = \\$3 AND status_code = 0").WithArgs(types.TenantLoremType, "key", createdAt)
+			if tc.deleteErr != nil {
+				query.WillReturnError(tc.deleteErr)
+			} else {
+				query.WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewIdempotencyKeyRepository(dbx)
+			err = repo.DeleteIdempotencyKey(tc.ctx, &entity.IdempotencyKey{Tenant: types.TenantLoremType, Key: "key", CreatedAt: createdAt})
+			assert.Equal(t, tc.wantErr, err != nil, err)
+		})
+	}
+}
+
+func TestDeleteExpiredIdempotencyKeys(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		deleteErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			deleteErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			query := mock.ExpectExec("^DELETE FROM idempotency_keys WHERE expires_at <= \\$1")
+			if tc.deleteErr != nil {
+				query.WillReturnError(tc.deleteErr)
+			} else {
+				query.WillReturnResult(sqlmock.NewResult(0, 3))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewIdempotencyKeyRepository(dbx)
+			err = repo.DeleteExpiredIdempotencyKeys(tc.ctx, time.Now())
+			assert.Equal(t, tc.wantErr, err != nil, err)
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/config"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	dbentity "github.com/satriowisnugroho/catalog/internal/repository/postgres/entity"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+const (
+	// DefaultLocationCode is the code of the default location created for a tenant without one
+	DefaultLocationCode = "default"
+)
+
+// LocationRepositoryInterface define contract for location related functions to repository
+type LocationRepositoryInterface interface {
+	CreateLocation(ctx context.Context, dbTrx interface{}, location *entity.Location) error
+	GetLocationByID(ctx context.Context, locationID int) (*entity.Location, error)
+	GetLocations(ctx context.Context, tenant types.TenantType) ([]*entity.Location, error)
+	GetOrCreateDefaultLocation(ctx context.Context, dbTrx interface{}, tenant types.TenantType) (*entity.Location, error)
+	UpdateLocation(ctx context.Context, dbTrx interface{}, location *entity.Location) error
+	UnsetDefaultLocation(ctx context.Context, dbTrx interface{}, tenant types.TenantType) error
+}
+
+// LocationRepository holds database connection
+type LocationRepository struct {
+	db *sqlx.DB
+}
+
+var (
+	// LocationTableName hold table name for locations
+	LocationTableName = "locations"
+	// LocationColumns list all columns on locations table
+	LocationColumns = []string{"id", "tenant", "code", "name", "priority", "is_default", "created_at", "updated_at"}
+	// LocationAttributes hold string format of all locations table columns
+	LocationAttributes = strings.Join(LocationColumns, ", ")
+
+	// LocationCreationColumns list all columns used for create location
+	LocationCreationColumns = LocationColumns[1:]
+	// LocationCreationAttributes hold string format of all creation location columns
+	LocationCreationAttributes = strings.Join(LocationCreationColumns, ", ")
+)
+
+// NewLocationRepository create initiate location repository with given database
+func NewLocationRepository(db *sqlx.DB) *LocationRepository {
+	return &LocationRepository{db: db}
+}
+
+func (r *LocationRepository) fetch(ctx context.Context, tx sqlx.QueryerContext, query string, args ...interface{}) ([]*entity.Location, error) {
+	rows, err := tx.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]*entity.Location, 0)
+
+	for rows.Next() {
+		tmpEntity := dbentity.Location{}
+		if err := rows.StructScan(&tmpEntity); err != nil {
+			return nil, errors.Wrap(err, "fetch")
+		}
+
+		result = append(result, tmpEntity.ToEntity())
+	}
+
+	return result, nil
+}
+
+// CreateLocation insert location data into database
+func (r *LocationRepository) CreateLocation(ctx context.Context, dbTrx interface{}, location *entity.Location) error {
+	functionName := "LocationRepository.CreateLocation"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	now := time.Now()
+	location.CreatedAt = now
+	location.UpdatedAt = now
+
+	query := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s) RETURNING id`, LocationTableName, LocationCreationAttributes, EnumeratedBindvars(LocationCreationColumns))
+
+	tx := Tx(r.db, dbTrx)
+	err := tx.QueryRowxContext(ctx, query,
+		location.Tenant,
+		location.Code,
+		location.Name,
+		location.Priority,
+		location.IsDefault,
+		location.CreatedAt,
+		location.UpdatedAt,
+	).Scan(&location.ID)
+	if err != nil {
+		if isLocationCodeTenantViolation(err) {
+			return response.ErrDuplicateLocationCode
+		}
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// GetLocationByID return location by id
+func (r *LocationRepository) GetLocationByID(ctx context.Context, locationID int) (*entity.Location, error) {
+	functionName := "LocationRepository.GetLocationByID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id = $1 LIMIT 1", LocationAttributes, LocationTableName)
+	rows, err := r.fetch(ctx, r.db, query, locationID)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if len(rows) == 0 {
+		return nil, response.ErrNotFound
+	}
+
+	return rows[0], nil
+}
+
+// GetLocations return locations of a tenant from the highest priority
+func (r *LocationRepository) GetLocations(ctx context.Context, tenant types.TenantType) ([]*entity.Location, error) {
+	functionName := "LocationRepository.GetLocations"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE tenant = $1 ORDER BY priority DESC, id ASC", LocationAttributes, LocationTableName)
+	rows, err := r.fetch(ctx, r.db, query, tenant)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return rows, nil
+}
+
+// GetOrCreateDefaultLocation return the default location of a tenant, it is created when the tenant has none
+func (r *LocationRepository) GetOrCreateDefaultLocation(ctx context.Context, dbTrx interface{}, tenant types.TenantType) (*entity.Location, error) {
+	functionName := "LocationRepository.GetOrCreateDefaultLocation"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	// The inserted row is not visible to the second select of the same statement,
+	// so exactly one of them returns the default location
+	now := time.Now()
+	query := fmt.Sprintf(
+		`WITH inserted AS (INSERT INTO %[1]s (%[2]s) VALUES (%[3]s) ON CONFLICT (tenant) WHERE is_default DO NOTHING RETURNING %[4]s)
+		SELECT %[4]s FROM inserted UNION ALL SELECT %[4]s FROM %[1]s WHERE tenant = $1 AND is_default LIMIT 1`,
+		LocationTableName,
+		LocationCreationAttributes,
+		EnumeratedBindvars(LocationCreationColumns),
+		LocationAttributes,
+	)
+
+	rows, err := r.fetch(ctx, Tx(r.db, dbTrx), query, tenant, DefaultLocationCode, "Default", 0, true, now, now)
+	if err != nil {
+		if isLocationCodeTenantViolation(err) {
+			return nil, response.ErrDuplicateLocationCode
+		}
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if len(rows) == 0 {
+		return nil, response.ErrNotFound
+	}
+
+	return rows[0], nil
+}
+
+// UpdateLocation update a location
+func (r *LocationRepository) UpdateLocation(ctx context.Context, dbTrx interface{}, location *entity.Location) error {
+	functionName := "LocationRepository.UpdateLocation"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	location.UpdatedAt = time.Now()
+
+	query := fmt.Sprintf(`UPDATE %s SET %s WHERE id = $%d`, LocationTableName, UpdateColumnsValues(LocationCreationColumns), len(LocationColumns))
+
+	tx := Tx(r.db, dbTrx)
+	_, err := tx.ExecContext(
+		ctx,
+		query,
+		location.Tenant,
+		location.Code,
+		location.Name,
+		location.Priority,
+		location.IsDefault,
+		location.CreatedAt,
+		location.UpdatedAt,
+		location.ID,
+	)
+	if err != nil {
+		if isLocationCodeTenantViolation(err) {
+			return response.ErrDuplicateLocationCode
+		}
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// UnsetDefaultLocation make the current default location of a tenant a regular one
+func (r *LocationRepository) UnsetDefaultLocation(ctx context.Context, dbTrx interface{}, tenant types.TenantType) error {
+	functionName := "LocationRepository.UnsetDefaultLocation"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET is_default = false, updated_at = $2 WHERE tenant = $1 AND is_default`, LocationTableName)
+
+	tx := Tx(r.db, dbTrx)
+	if _, err := tx.ExecContext(ctx, query, tenant, time.Now()); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+func isLocationCodeTenantViolation(err error) bool {
+	postgresError, ok := err.(*pq.Error)
+	if !ok {
+		return false
+	}
+
+	return postgresError.Code == pq.ErrorCode(config.UniqueConstraintViolationCode) && postgresError.Constraint == config.LocationCodeTenantUniqueConstraint
+}
@@ -0,0 +1,395 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/config"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	"github.com/stretchr/testify/assert"
+)
+
+func locationRow(rows *sqlmock.Rows, l *entity.Location) *sqlmock.Rows {
+	return rows.AddRow(
+		l.ID,
+		l.Tenant,
+		l.Code,
+		l.Name,
+		l.Priority,
+		l.IsDefault,
+		time.Now(),
+		time.Now(),
+	)
+}
+
+func TestCreateLocation(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		createErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "duplicate code",
+			ctx:       context.Background(),
+			createErr: &pq.Error{Code: pq.ErrorCode(config.UniqueConstraintViolationCode), Constraint: config.LocationCodeTenantUniqueConstraint},
+			wantErr:   true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			createErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.createErr != nil {
+				mock.ExpectQuery("^INSERT INTO locations(.+)").WillReturnError(tc.createErr)
+			} else {
+				mock.ExpectQuery("^INSERT INTO locations(.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewLocationRepository(dbx)
+
+			location := &entity.Location{}
+			err = repo.CreateLocation(tc.ctx, nil, location)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, 1, location.ID)
+			}
+		})
+	}
+}
+
+func TestGetLocationByID(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		fetchErr  error
+		fetchRows []string
+		expected  *entity.Location
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "fail fetch return error rows",
+			ctx:       context.Background(),
+			fetchRows: []string{"unknown_column"},
+			wantErr:   true,
+		},
+		{
+			name:      "record not found",
+			ctx:       context.Background(),
+			fetchRows: postgres.LocationColumns,
+			wantErr:   true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: postgres.LocationColumns,
+			expected:  &entity.Location{ID: 1, Tenant: types.TenantLoremType, Code: "jkt-1", Name: "Jakarta"},
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery("^SELECT (.+) FROM locations WHERE id = \\$1(.+)").WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				if tc.expected != nil {
+					rows = locationRow(rows, tc.expected)
+				} else if len(tc.fetchRows) == 1 {
+					rows = rows.AddRow(1)
+				}
+				mock.ExpectQuery("^SELECT (.+) FROM locations WHERE id = \\$1(.+)").WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewLocationRepository(dbx)
+
+			res, err := repo.GetLocationByID(tc.ctx, 1)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, tc.expected.Code, res.Code)
+			}
+		})
+	}
+}
+
+func TestGetLocations(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		fetchErr  error
+		fetchRows []string
+		expected  []*entity.Location
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: postgres.LocationColumns,
+			expected:  []*entity.Location{{ID: 1, Tenant: types.TenantLoremType, Code: "jkt-1"}, {ID: 2, Tenant: types.TenantLoremType, Code: "default"}},
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery("^SELECT (.+) FROM locations WHERE tenant = \\$1 ORDER BY priority DESC(.+)").WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				for _, location := range tc.expected {
+					rows = locationRow(rows, location)
+				}
+				mock.ExpectQuery("^SELECT (.+) FROM locations WHERE tenant = \\$1 ORDER BY priority DESC(.+)").WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewLocationRepository(dbx)
+
+			res, err := repo.GetLocations(tc.ctx, types.TenantLoremType)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, len(tc.expected), len(res))
+			}
+		})
+	}
+}
+
+func TestGetOrCreateDefaultLocation(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		fetchErr  error
+		fetchRows []string
+		expected  *entity.Location
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "code is used by another location",
+			ctx:      context.Background(),
+			fetchErr: &pq.Error{Code: pq.ErrorCode(config.UniqueConstraintViolationCode), Constraint: config.LocationCodeTenantUniqueConstraint},
+			wantErr:  true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "record not found",
+			ctx:       context.Background(),
+			fetchRows: postgres.LocationColumns,
+			wantErr:   true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: postgres.LocationColumns,
+			expected:  &entity.Location{ID: 1, Tenant: types.TenantLoremType, Code: postgres.DefaultLocationCode, IsDefault: true},
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery("^WITH inserted AS \\(INSERT INTO locations (.+) ON CONFLICT \\(tenant\\) WHERE is_default DO NOTHING(.+)").WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				if tc.expected != nil {
+					rows = locationRow(rows, tc.expected)
+				}
+				mock.ExpectQuery("^WITH inserted AS \\(INSERT INTO locations (.+) ON CONFLICT \\(tenant\\) WHERE is_default DO NOTHING(.+)").WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewLocationRepository(dbx)
+
+			res, err := repo.GetOrCreateDefaultLocation(tc.ctx, nil, types.TenantLoremType)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, tc.expected.ID, res.ID)
+				assert.True(t, res.IsDefault)
+			}
+		})
+	}
+}
+
+func TestUpdateLocation(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		updateErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "duplicate code",
+			ctx:       context.Background(),
+			updateErr: &pq.Error{Code: pq.ErrorCode(config.UniqueConstraintViolationCode), Constraint: config.LocationCodeTenantUniqueConstraint},
+			wantErr:   true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			updateErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.updateErr != nil {
+				mock.ExpectExec("^UPDATE locations SET (.+)").WillReturnError(tc.updateErr)
+			} else {
+				mock.ExpectExec("^UPDATE locations SET (.+)").WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewLocationRepository(dbx)
+
+			err = repo.UpdateLocation(tc.ctx, nil, &entity.Location{ID: 1})
+			assert.Equal(t, tc.wantErr, err != nil)
+		})
+	}
+}
+
+func TestUnsetDefaultLocation(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		updateErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			updateErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.updateErr != nil {
+				mock.ExpectExec("^UPDATE locations SET is_default = false(.+)").WillReturnError(tc.updateErr)
+			} else {
+				mock.ExpectExec("^UPDATE locations SET is_default = false(.+)").WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewLocationRepository(dbx)
+
+			err = repo.UnsetDefaultLocation(tc.ctx, nil, types.TenantLoremType)
+			assert.Equal(t, tc.wantErr, err != nil)
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	dbentity "github.com/satriowisnugroho/catalog/internal/repository/postgres/entity"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// LowStockRepositoryInterface define contract for low stock related functions to repository
+type LowStockRepositoryInterface interface {
+	GetLowStockThreshold(ctx context.Context, tenant types.TenantType) (*entity.LowStockThreshold, error)
+	UpsertLowStockThreshold(ctx context.Context, threshold *entity.LowStockThreshold) error
+	CreateLowStockAlert(ctx context.Context, dbTrx interface{}, alert *entity.LowStockAlert) error
+	GetPendingLowStockAlertsForUpdate(ctx context.Context, dbTrx interface{}, limit int) ([]*entity.LowStockAlert, error)
+	MarkLowStockAlertsSent(ctx context.Context, dbTrx interface{}, alertIDs []int, sentAt time.Time) error
+}
+
+// LowStockRepository holds database connection
+type LowStockRepository struct {
+	db *sqlx.DB
+}
+
+var (
+	// LowStockThresholdTableName hold table name for low stock thresholds
+	LowStockThresholdTableName = "low_stock_thresholds"
+	// LowStockThresholdColumns list all columns on low stock thresholds table
+	LowStockThresholdColumns = []string{"tenant", "threshold", "updated_at"}
+	// LowStockThresholdAttributes hold string format of all low stock thresholds table columns
+	LowStockThresholdAttributes = strings.Join(LowStockThresholdColumns, ", ")
+
+	// LowStockAlertTableName hold table name for low stock alerts
+	LowStockAlertTableName = "low_stock_alerts"
+	// LowStockAlertColumns list all columns on low stock alerts table
+	LowStockAlertColumns = []string{"id", "product_id", "tenant", "sku", "title", "qty", "threshold", "created_at", "sent_at"}
+	// LowStockAlertAttributes hold string format of all low stock alerts table columns
+	LowStockAlertAttributes = strings.Join(LowStockAlertColumns, ", ")
+
+	// LowStockAlertCreationColumns list all columns used for create low stock alert
+	LowStockAlertCreationColumns = LowStockAlertColumns[1:8]
+	// LowStockAlertCreationAttributes hold string format of all creation low stock alert columns
+	LowStockAlertCreationAttributes = strings.Join(LowStockAlertCreationColumns, ", ")
+)
+
+// NewLowStockRepository create initiate low stock repository with given database
+func NewLowStockRepository(db *sqlx.DB) *LowStockRepository {
+	return &LowStockRepository{db: db}
+}
+
+// GetLowStockThreshold return low stock threshold of the tenant
+func (r *LowStockRepository) GetLowStockThreshold(ctx context.Context, tenant types.TenantType) (*entity.LowStockThreshold, error) {
+	functionName := "LowStockRepository.GetLowStockThreshold"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE tenant = $1 LIMIT 1", LowStockThresholdAttributes, LowStockThresholdTableName)
+	rows, err := r.db.QueryxContext(ctx, query, tenant)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, errors.Wrap(err, functionName)
+		}
+
+		return nil, response.ErrNotFound
+	}
+
+	tmpEntity := dbentity.LowStockThreshold{}
+	if err := rows.StructScan(&tmpEntity); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return tmpEntity.ToEntity(), nil
+}
+
+// UpsertLowStockThreshold create or replace low stock threshold of the tenant
+func (r *LowStockRepository) UpsertLowStockThreshold(ctx context.Context, threshold *entity.LowStockThreshold) error {
+	functionName := "LowStockRepository.UpsertLowStockThreshold"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	threshold.UpdatedAt = time.Now()
+
+	query := fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (tenant) DO UPDATE SET threshold = EXCLUDED.threshold, updated_at = EXCLUDED.updated_at",
+		LowStockThresholdTableName,
+		LowStockThresholdAttributes,
+		EnumeratedBindvars(LowStockThresholdColumns),
+	)
+	if _, err := r.db.ExecContext(ctx, query, threshold.Tenant, threshold.Threshold, threshold.UpdatedAt); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// CreateLowStockAlert insert the alert to be sent by the dispatcher
+func (r *LowStockRepository) CreateLowStockAlert(ctx context.Context, dbTrx interface{}, alert *entity.LowStockAlert) error {
+	functionName := "LowStockRepository.CreateLowStockAlert"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	alert.CreatedAt = time.Now()
+
+	query := fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s) RETURNING id",
+		LowStockAlertTableName,
+		LowStockAlertCreationAttributes,
+		EnumeratedBindvars(LowStockAlertCreationColumns),
+	)
+
+	tx := Tx(r.db, dbTrx)
+	err := tx.QueryRowxContext(ctx, query,
+		alert.ProductID,
+		alert.Tenant,
+		alert.SKU,
+		alert.Title,
+		alert.Qty,
+		alert.Threshold,
+		alert.CreatedAt,
+	).Scan(&alert.ID)
+	if err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// GetPendingLowStockAlertsForUpdate return the oldest alerts which are not sent yet and lock them,
+// the alerts locked by another dispatcher are skipped
+func (r *LowStockRepository) GetPendingLowStockAlertsForUpdate(ctx context.Context, dbTrx interface{}, limit int) ([]*entity.LowStockAlert, error) {
+	functionName := "LowStockRepository.GetPendingLowStockAlertsForUpdate"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE sent_at IS NULL ORDER BY id ASC LIMIT $1 FOR UPDATE SKIP LOCKED", LowStockAlertAttributes, LowStockAlertTableName)
+
+	tx := Tx(r.db, dbTrx)
+	rows, err := tx.QueryxContext(ctx, query, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	defer rows.Close()
+
+	result := make([]*entity.LowStockAlert, 0)
+
+	for rows.Next() {
+		tmpEntity := dbentity.LowStockAlert{}
+		if err := rows.StructScan(&tmpEntity); err != nil {
+			return nil, errors.Wrap(err, functionName)
+		}
+
+		result = append(result, tmpEntity.ToEntity())
+	}
+
+	return result, nil
+}
+
+// MarkLowStockAlertsSent set the sent time of the given alerts
+func (r *LowStockRepository) MarkLowStockAlertsSent(ctx context.Context, dbTrx interface{}, alertIDs []int, sentAt time.Time) error {
+	functionName := "LowStockRepository.MarkLowStockAlertsSent"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	if len(alertIDs) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET sent_at = $1 WHERE id = ANY($2)", LowStockAlertTableName)
+
+	tx := Tx(r.db, dbTrx)
+	if _, err := tx.ExecContext(ctx, query, sentAt, pq.Array(alertIDs)); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
@@ -0,0 +1,328 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLowStockThreshold(t *testing.T) {
+	testcases := []struct {
+		name         string
+		ctx          context.Context
+		fetchErr     error
+		fetchRows    []string
+		expected     *entity.LowStockThreshold
+		wantErr      bool
+		wantNotFound bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "fail fetch return error rows",
+			ctx:       context.Background(),
+			fetchRows: []string{"unknown_column"},
+			wantErr:   true,
+		},
+		{
+			name:         "record not found",
+			ctx:          context.Background(),
+			fetchRows:    postgres.LowStockThresholdColumns,
+			wantErr:      true,
+			wantNotFound: true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: postgres.LowStockThresholdColumns,
+			expected:  &entity.LowStockThreshold{Tenant: types.TenantLoremType, Threshold: 5},
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery("^SELECT (.+) FROM low_stock_thresholds WHERE tenant = \\$1(.+)").WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				if tc.expected != nil {
+					rows = rows.AddRow(tc.expected.Tenant, tc.expected.Threshold, tc.expected.UpdatedAt)
+				} else if len(tc.fetchRows) == 1 {
+					rows = rows.AddRow(1)
+				}
+
+				mock.ExpectQuery("^SELECT (.+) FROM low_stock_thresholds WHERE tenant = \\$1(.+)").WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewLowStockRepository(dbx)
+			result, err := repo.GetLowStockThreshold(tc.ctx, types.TenantLoremType)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			if tc.wantNotFound {
+				assert.Equal(t, response.ErrNotFound, err)
+			}
+			if !tc.wantErr {
+				assert.EqualValues(t, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestUpsertLowStockThreshold(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		upsertErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			upsertErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			query := mock.ExpectExec("^INSERT INTO low_stock_thresholds (.+) ON CONFLICT \\(tenant\\) DO UPDATE (.+)")
+			if tc.upsertErr != nil {
+				query.WillReturnError(tc.upsertErr)
+			} else {
+				query.WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewLowStockRepository(dbx)
+			err = repo.UpsertLowStockThreshold(tc.ctx, &entity.LowStockThreshold{Tenant: types.TenantLoremType, Threshold: 5})
+			assert.Equal(t, tc.wantErr, err != nil, err)
+		})
+	}
+}
+
+func TestCreateLowStockAlert(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		createErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			createErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			query := mock.ExpectQuery("^INSERT INTO low_stock_alerts (.+) RETURNING id")
+			if tc.createErr != nil {
+				query.WillReturnError(tc.createErr)
+			} else {
+				query.WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewLowStockRepository(dbx)
+			alert := &entity.LowStockAlert{ProductID: 123, Tenant: types.TenantLoremType, Qty: 2, Threshold: 5}
+			err = repo.CreateLowStockAlert(tc.ctx, nil, alert)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			if !tc.wantErr {
+				assert.Equal(t, 1, alert.ID)
+			}
+		})
+	}
+}
+
+func TestGetPendingLowStockAlertsForUpdate(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		fetchErr  error
+		fetchRows []string
+		expected  []*entity.LowStockAlert
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "fail fetch return error rows",
+			ctx:       context.Background(),
+			fetchRows: []string{"unknown_column"},
+			wantErr:   true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: postgres.LowStockAlertColumns,
+			expected:  []*entity.LowStockAlert{{ID: 1, ProductID: 123, Tenant: types.TenantLoremType, SKU: "SKU-123", Title: "Product", Qty: 2, Threshold: 5}},
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery("^SELECT (.+) FROM low_stock_alerts WHERE sent_at IS NULL ORDER BY id ASC LIMIT \\$1 FOR UPDATE SKIP LOCKED").WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				if tc.expected != nil {
+					rows = rows.AddRow(
+						tc.expected[0].ID,
+						tc.expected[0].ProductID,
+						tc.expected[0].Tenant,
+						tc.expected[0].SKU,
+						tc.expected[0].Title,
+						tc.expected[0].Qty,
+						tc.expected[0].Threshold,
+						tc.expected[0].CreatedAt,
+						tc.expected[0].SentAt,
+					)
+				} else if len(tc.fetchRows) == 1 {
+					rows = rows.AddRow(1)
+				}
+
+				mock.ExpectQuery("^SELECT (.+) FROM low_stock_alerts WHERE sent_at IS NULL ORDER BY id ASC LIMIT \\$1 FOR UPDATE SKIP LOCKED").WithArgs(100).WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewLowStockRepository(dbx)
+			result, err := repo.GetPendingLowStockAlertsForUpdate(tc.ctx, nil, 100)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			if !tc.wantErr {
+				assert.EqualValues(t, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestMarkLowStockAlertsSent(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		alertIDs  []int
+		updateErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:    "no alert",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			alertIDs:  []int{1, 2},
+			updateErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:     "success",
+			ctx:      context.Background(),
+			alertIDs: []int{1, 2},
+			wantErr:  false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			query := mock.ExpectExec("^UPDATE low_stock_alerts SET sent_at = \\$1 WHERE id = ANY\\(\\$2\\)")
+			if tc.updateErr != nil {
+				query.WillReturnError(tc.updateErr)
+			} else {
+				query.WillReturnResult(sqlmock.NewResult(0, int64(len(tc.alertIDs))))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewLowStockRepository(dbx)
+			err = repo.MarkLowStockAlertsSent(tc.ctx, nil, tc.alertIDs, time.Now())
+			assert.Equal(t, tc.wantErr, err != nil, err)
+		})
+	}
+}
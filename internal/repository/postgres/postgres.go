@@ -35,3 +35,13 @@ func Tx(db *sqlx.DB, iTrx interface{}) sqlx.ExtContext {
 
 	return iTrx.(*sqlx.Tx)
 }
+
+// EnumeratedBindvarsFrom is func to build the given count of bindvars starting from the given index
+func EnumeratedBindvarsFrom(start int, count int) string {
+	var values []string
+	for i := 0; i < count; i++ {
+		values = append(values, fmt.Sprintf("$%d", start+i))
+	}
+
+	return strings.Join(values, ", ")
+}
@@ -5,7 +5,9 @@ import (
 	"database/sql"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/config"
 	"github.com/satriowisnugroho/catalog/internal/response"
 )
 
@@ -55,3 +57,14 @@ func (r *PostgresTransactionRepository) RollbackTransactionQuery(ctx context.Con
 	err := tx.Rollback()
 	return errors.Wrap(err, functionName)
 }
+
+// IsTransactionConflict check whether the error is caused by a concurrent transaction,
+// the transaction can be retried from the beginning when it is
+func IsTransactionConflict(err error) bool {
+	var postgresError *pq.Error
+	if !errors.As(err, &postgresError) {
+		return false
+	}
+
+	return postgresError.Code == pq.ErrorCode(config.SerializationFailureCode) || postgresError.Code == pq.ErrorCode(config.DeadlockDetectedCode)
+}
@@ -2,11 +2,14 @@ package postgres_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/config"
 	"github.com/satriowisnugroho/catalog/internal/repository/postgres"
 	"github.com/stretchr/testify/assert"
 )
@@ -169,3 +172,38 @@ func TestRollbackTransactionQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestIsTransactionConflict(t *testing.T) {
+	testcases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "not a postgres error",
+			err:  errors.New("error commit"),
+			want: false,
+		},
+		{
+			name: "other postgres error",
+			err:  &pq.Error{Code: pq.ErrorCode(config.UniqueConstraintViolationCode)},
+			want: false,
+		},
+		{
+			name: "wrapped serialization failure",
+			err:  errors.Wrap(fmt.Errorf("commit: %w", &pq.Error{Code: pq.ErrorCode(config.SerializationFailureCode)}), "usecase"),
+			want: true,
+		},
+		{
+			name: "deadlock detected",
+			err:  &pq.Error{Code: pq.ErrorCode(config.DeadlockDetectedCode)},
+			want: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, postgres.IsTransactionConflict(tc.err))
+		})
+	}
+}
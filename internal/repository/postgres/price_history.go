@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	dbentity "github.com/satriowisnugroho/catalog/internal/repository/postgres/entity"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// PriceHistoryRepositoryInterface define contract for price history related functions to repository
+type PriceHistoryRepositoryInterface interface {
+	GetPriceHistories(ctx context.Context, payload *entity.GetPriceHistoryPayload) ([]*entity.PriceHistory, error)
+	GetLowestPriceSince(ctx context.Context, productID int, since time.Time) (int, error)
+}
+
+// PriceHistoryRepository holds database connection
+type PriceHistoryRepository struct {
+	db *sqlx.DB
+}
+
+var (
+	// PriceHistoryTableName hold table name for price histories
+	PriceHistoryTableName = "price_histories"
+	// PriceHistoryColumns list all columns on price histories table
+	PriceHistoryColumns = []string{"id", "product_id", "tenant", "price", "promotion_price", "effective_price", "recorded_at"}
+	// PriceHistoryAttributes hold string format of all price histories table columns
+	PriceHistoryAttributes = strings.Join(PriceHistoryColumns, ", ")
+
+	// PriceHistoryCreationColumns list all columns used for create price history
+	PriceHistoryCreationColumns = PriceHistoryColumns[1:]
+	// PriceHistoryCreationAttributes hold string format of all creation price history columns
+	PriceHistoryCreationAttributes = strings.Join(PriceHistoryCreationColumns, ", ")
+)
+
+// NewPriceHistoryRepository create initiate price history repository with given database
+func NewPriceHistoryRepository(db *sqlx.DB) *PriceHistoryRepository {
+	return &PriceHistoryRepository{db: db}
+}
+
+func (r *PriceHistoryRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]*entity.PriceHistory, error) {
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]*entity.PriceHistory, 0)
+
+	for rows.Next() {
+		tmpEntity := dbentity.PriceHistory{}
+		if err := rows.StructScan(&tmpEntity); err != nil {
+			return nil, errors.Wrap(err, "fetch")
+		}
+
+		result = append(result, tmpEntity.ToEntity())
+	}
+
+	return result, nil
+}
+
+// GetPriceHistories return price histories of a product within the given period
+func (r *PriceHistoryRepository) GetPriceHistories(ctx context.Context, payload *entity.GetPriceHistoryPayload) ([]*entity.PriceHistory, error) {
+	functionName := "PriceHistoryRepository.GetPriceHistories"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	wheres := []string{"product_id = $1"}
+	params := []interface{}{payload.ProductID}
+
+	if payload.From != nil {
+		params = append(params, *payload.From)
+		wheres = append(wheres, fmt.Sprintf("recorded_at >= $%d", len(params)))
+	}
+
+	if payload.To != nil {
+		params = append(params, *payload.To)
+		wheres = append(wheres, fmt.Sprintf("recorded_at <= $%d", len(params)))
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s ORDER BY recorded_at DESC, id DESC", PriceHistoryAttributes, PriceHistoryTableName, strings.Join(wheres, " AND "))
+	rows, err := r.fetch(ctx, query, params...)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return rows, nil
+}
+
+// GetLowestPriceSince return the lowest effective price of a product since the given time,
+// including the price which was already effective at that time
+func (r *PriceHistoryRepository) GetLowestPriceSince(ctx context.Context, productID int, since time.Time) (int, error) {
+	functionName := "PriceHistoryRepository.GetLowestPriceSince"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return 0, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf(
+		`SELECT MIN(effective_price) FROM %[1]s WHERE product_id = $1 AND (recorded_at >= $2 OR id = (SELECT id FROM %[1]s WHERE product_id = $1 AND recorded_at < $2 ORDER BY recorded_at DESC, id DESC LIMIT 1))`,
+		PriceHistoryTableName,
+	)
+
+	var lowestPrice sql.NullInt64
+	row := r.db.QueryRowxContext(ctx, query, productID, since)
+	if err := row.Scan(&lowestPrice); err != nil {
+		return 0, errors.Wrap(err, functionName)
+	}
+
+	if !lowestPrice.Valid {
+		return 0, response.ErrNotFound
+	}
+
+	return int(lowestPrice.Int64), nil
+}
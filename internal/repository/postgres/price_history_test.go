@@ -0,0 +1,158 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPriceHistories(t *testing.T) {
+	from := time.Now().Add(-time.Hour)
+	to := time.Now()
+
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		payload   *entity.GetPriceHistoryPayload
+		fetchErr  error
+		fetchRows []string
+		expected  []*entity.PriceHistory
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			payload:  &entity.GetPriceHistoryPayload{ProductID: 123},
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "fail fetch return error rows",
+			ctx:       context.Background(),
+			payload:   &entity.GetPriceHistoryPayload{ProductID: 123},
+			fetchRows: []string{"unknown_column"},
+			wantErr:   true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			payload:   &entity.GetPriceHistoryPayload{ProductID: 123, From: &from, To: &to},
+			fetchRows: postgres.PriceHistoryColumns,
+			expected:  []*entity.PriceHistory{{ID: 1, ProductID: 123, Tenant: types.TenantLoremType, Price: 100, EffectivePrice: 100}},
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery("^SELECT(.+)").WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				if tc.expected != nil {
+					rows = rows.AddRow(
+						tc.expected[0].ID,
+						tc.expected[0].ProductID,
+						tc.expected[0].Tenant,
+						tc.expected[0].Price,
+						tc.expected[0].PromotionPrice,
+						tc.expected[0].EffectivePrice,
+						tc.expected[0].RecordedAt,
+					)
+				} else if len(tc.fetchRows) == 1 {
+					rows = rows.AddRow(1)
+				}
+
+				mock.ExpectQuery("^SELECT (.+) FROM price_histories WHERE product_id = \\$1(.+)").WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewPriceHistoryRepository(dbx)
+			result, err := repo.GetPriceHistories(tc.ctx, tc.payload)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			if !tc.wantErr {
+				assert.EqualValues(t, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetLowestPriceSince(t *testing.T) {
+	testcases := []struct {
+		name     string
+		ctx      context.Context
+		fetchErr error
+		fetchRes interface{}
+		expected int
+		wantErr  bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:     "price has never been recorded",
+			ctx:      context.Background(),
+			fetchRes: nil,
+			wantErr:  true,
+		},
+		{
+			name:     "success",
+			ctx:      context.Background(),
+			fetchRes: 90,
+			expected: 90,
+			wantErr:  false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery("^SELECT MIN\\(effective_price\\) (.+)").WillReturnError(tc.fetchErr)
+			} else {
+				mock.ExpectQuery("^SELECT MIN\\(effective_price\\) (.+)").WillReturnRows(sqlmock.NewRows([]string{"min"}).AddRow(tc.fetchRes))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewPriceHistoryRepository(dbx)
+			result, err := repo.GetLowestPriceSince(tc.ctx, 123, time.Now())
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			if !tc.wantErr {
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	dbentity "github.com/satriowisnugroho/catalog/internal/repository/postgres/entity"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// PriceScheduleRepositoryInterface define contract for price schedule related functions to repository
+type PriceScheduleRepositoryInterface interface {
+	CreatePriceSchedule(ctx context.Context, priceSchedule *entity.PriceSchedule) error
+	GetPriceScheduleByID(ctx context.Context, priceScheduleID int) (*entity.PriceSchedule, error)
+	GetPriceSchedulesByProductID(ctx context.Context, productID int) ([]*entity.PriceSchedule, error)
+	GetDuePriceSchedules(ctx context.Context, now time.Time, limit int) ([]*entity.PriceSchedule, error)
+	GetDuePriceSchedulesByProductIDs(ctx context.Context, productIDs []int, now time.Time) ([]*entity.PriceSchedule, error)
+	GetOverlappingPromotionsCount(ctx context.Context, productID int, startsAt time.Time, endsAt time.Time) (int, error)
+	UpdatePriceScheduleStatus(ctx context.Context, dbTrx interface{}, priceSchedule *entity.PriceSchedule, fromStatus types.PriceScheduleStatusType) error
+}
+
+// PriceScheduleRepository holds database connection
+type PriceScheduleRepository struct {
+	db *sqlx.DB
+}
+
+var (
+	// PriceScheduleTableName hold table name for price schedules
+	PriceScheduleTableName = "price_schedules"
+	// PriceScheduleColumns list all columns on price schedules table
+	PriceScheduleColumns = []string{"id", "product_id", "tenant", "type", "status", "price", "starts_at", "ends_at", "created_at", "updated_at"}
+	// PriceScheduleAttributes hold string format of all price schedules table columns
+	PriceScheduleAttributes = strings.Join(PriceScheduleColumns, ", ")
+
+	// PriceScheduleCreationColumns list all columns used for create price schedule
+	PriceScheduleCreationColumns = PriceScheduleColumns[1:]
+	// PriceScheduleCreationAttributes hold string format of all creation price schedule columns
+	PriceScheduleCreationAttributes = strings.Join(PriceScheduleCreationColumns, ", ")
+
+	// duePriceScheduleCondition filter pending schedules which already started and active promotions which already ended
+	duePriceScheduleCondition = fmt.Sprintf(
+		"((status = %d AND starts_at <= $1) OR (status = %d AND ends_at <= $1))",
+		types.PriceScheduleStatusPendingType,
+		types.PriceScheduleStatusActiveType,
+	)
+	// duePriceScheduleOrder order due schedules by the time they are due
+	duePriceScheduleOrder = fmt.Sprintf("CASE WHEN status = %d THEN starts_at ELSE ends_at END ASC, id ASC", types.PriceScheduleStatusPendingType)
+)
+
+// NewPriceScheduleRepository create initiate price schedule repository with given database
+func NewPriceScheduleRepository(db *sqlx.DB) *PriceScheduleRepository {
+	return &PriceScheduleRepository{db: db}
+}
+
+func (r *PriceScheduleRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]*entity.PriceSchedule, error) {
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]*entity.PriceSchedule, 0)
+
+	for rows.Next() {
+		tmpEntity := dbentity.PriceSchedule{}
+		if err := rows.StructScan(&tmpEntity); err != nil {
+			return nil, errors.Wrap(err, "fetch")
+		}
+
+		result = append(result, tmpEntity.ToEntity())
+	}
+
+	return result, nil
+}
+
+// CreatePriceSchedule insert price schedule data into database
+func (r *PriceScheduleRepository) CreatePriceSchedule(ctx context.Context, priceSchedule *entity.PriceSchedule) error {
+	functionName := "PriceScheduleRepository.CreatePriceSchedule"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	now := time.Now()
+	priceSchedule.CreatedAt = now
+	priceSchedule.UpdatedAt = now
+
+	query := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s) RETURNING id`, PriceScheduleTableName, PriceScheduleCreationAttributes, EnumeratedBindvars(PriceScheduleCreationColumns))
+
+	err := r.db.QueryRowContext(ctx, query,
+		priceSchedule.ProductID,
+		priceSchedule.Tenant,
+		priceSchedule.Type,
+		priceSchedule.Status,
+		priceSchedule.Price,
+		priceSchedule.StartsAt,
+		priceSchedule.EndsAt,
+		priceSchedule.CreatedAt,
+		priceSchedule.UpdatedAt,
+	).Scan(&priceSchedule.ID)
+	if err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// GetPriceScheduleByID return price schedule by id
+func (r *PriceScheduleRepository) GetPriceScheduleByID(ctx context.Context, priceScheduleID int) (*entity.PriceSchedule, error) {
+	functionName := "PriceScheduleRepository.GetPriceScheduleByID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id = $1 LIMIT 1", PriceScheduleAttributes, PriceScheduleTableName)
+	rows, err := r.fetch(ctx, query, priceScheduleID)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if len(rows) == 0 {
+		return nil, response.ErrNotFound
+	}
+
+	return rows[0], nil
+}
+
+// GetPriceSchedulesByProductID return price schedules of a product
+func (r *PriceScheduleRepository) GetPriceSchedulesByProductID(ctx context.Context, productID int) ([]*entity.PriceSchedule, error) {
+	functionName := "PriceScheduleRepository.GetPriceSchedulesByProductID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE product_id = $1 ORDER BY starts_at DESC, id DESC", PriceScheduleAttributes, PriceScheduleTableName)
+	rows, err := r.fetch(ctx, query, productID)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return rows, nil
+}
+
+// GetDuePriceSchedules return price schedules which are due to be applied or reverted
+func (r *PriceScheduleRepository) GetDuePriceSchedules(ctx context.Context, now time.Time, limit int) ([]*entity.PriceSchedule, error) {
+	functionName := "PriceScheduleRepository.GetDuePriceSchedules"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s ORDER BY %s LIMIT %d", PriceScheduleAttributes, PriceScheduleTableName, duePriceScheduleCondition, duePriceScheduleOrder, limit)
+	rows, err := r.fetch(ctx, query, now)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return rows, nil
+}
+
+// GetDuePriceSchedulesByProductIDs return due price schedules of the given products
+func (r *PriceScheduleRepository) GetDuePriceSchedulesByProductIDs(ctx context.Context, productIDs []int, now time.Time) ([]*entity.PriceSchedule, error) {
+	functionName := "PriceScheduleRepository.GetDuePriceSchedulesByProductIDs"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s AND product_id = ANY($2) ORDER BY %s", PriceScheduleAttributes, PriceScheduleTableName, duePriceScheduleCondition, duePriceScheduleOrder)
+	rows, err := r.fetch(ctx, query, now, pq.Array(productIDs))
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return rows, nil
+}
+
+// GetOverlappingPromotionsCount return count of pending or active promotions of a product overlapping the given period
+func (r *PriceScheduleRepository) GetOverlappingPromotionsCount(ctx context.Context, productID int, startsAt time.Time, endsAt time.Time) (int, error) {
+	functionName := "PriceScheduleRepository.GetOverlappingPromotionsCount"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return 0, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf(
+		"SELECT COUNT(*) FROM %s WHERE product_id = $1 AND type = %d AND status IN (%d, %d) AND starts_at < $3 AND ends_at > $2",
+		PriceScheduleTableName,
+		types.PriceSchedulePromotionType,
+		types.PriceScheduleStatusPendingType,
+		types.PriceScheduleStatusActiveType,
+	)
+
+	count := 0
+	row := r.db.QueryRowxContext(ctx, query, productID, startsAt, endsAt)
+	if err := row.Scan(&count); err != nil {
+		return count, errors.Wrap(err, functionName)
+	}
+
+	return count, nil
+}
+
+// UpdatePriceScheduleStatus update status of a price schedule which is still in the given status
+func (r *PriceScheduleRepository) UpdatePriceScheduleStatus(ctx context.Context, dbTrx interface{}, priceSchedule *entity.PriceSchedule, fromStatus types.PriceScheduleStatusType) error {
+	functionName := "PriceScheduleRepository.UpdatePriceScheduleStatus"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	priceSchedule.UpdatedAt = time.Now()
+
+	query := fmt.Sprintf("UPDATE %s SET status = $1, updated_at = $2 WHERE id = $3 AND status = $4", PriceScheduleTableName)
+
+	tx := Tx(r.db, dbTrx)
+	result, err := tx.ExecContext(ctx, query, priceSchedule.Status, priceSchedule.UpdatedAt, priceSchedule.ID, fromStatus)
+	if err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	// The schedule has been processed by someone else
+	if affected == 0 {
+		return response.ErrNotFound
+	}
+
+	return nil
+}
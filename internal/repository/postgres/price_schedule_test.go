@@ -0,0 +1,338 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	"github.com/stretchr/testify/assert"
+)
+
+func priceScheduleRow(rows *sqlmock.Rows, p *entity.PriceSchedule) *sqlmock.Rows {
+	return rows.AddRow(
+		p.ID,
+		p.ProductID,
+		p.Tenant,
+		p.Type,
+		p.Status,
+		p.Price,
+		p.StartsAt,
+		p.EndsAt,
+		p.CreatedAt,
+		p.UpdatedAt,
+	)
+}
+
+func TestCreatePriceSchedule(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		input     *entity.PriceSchedule
+		createErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			input:     &entity.PriceSchedule{},
+			createErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			input:   &entity.PriceSchedule{},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.createErr != nil {
+				mock.ExpectQuery("^INSERT INTO price_schedules(.+)").WillReturnError(tc.createErr)
+			} else {
+				mock.ExpectQuery("^INSERT INTO price_schedules(.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewPriceScheduleRepository(dbx)
+
+			err = repo.CreatePriceSchedule(tc.ctx, tc.input)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, 1, tc.input.ID)
+			}
+		})
+	}
+}
+
+func TestGetPriceScheduleByID(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		fetchErr  error
+		fetchRows []string
+		expected  *entity.PriceSchedule
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "fail fetch return error rows",
+			ctx:       context.Background(),
+			fetchRows: []string{"unknown_column"},
+			wantErr:   true,
+		},
+		{
+			name:      "record not found",
+			ctx:       context.Background(),
+			fetchRows: postgres.PriceScheduleColumns,
+			wantErr:   true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: postgres.PriceScheduleColumns,
+			expected:  &entity.PriceSchedule{ID: 1, Tenant: types.TenantLoremType, Type: types.PriceSchedulePriceChangeType, Status: types.PriceScheduleStatusPendingType},
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery("^SELECT(.+)").WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				if tc.expected != nil {
+					rows = priceScheduleRow(rows, tc.expected)
+				} else if len(tc.fetchRows) == 1 {
+					rows = rows.AddRow(1)
+				}
+
+				mock.ExpectQuery("^SELECT(.+)").WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewPriceScheduleRepository(dbx)
+			result, err := repo.GetPriceScheduleByID(tc.ctx, 1)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			if !tc.wantErr {
+				assert.EqualValues(t, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetPriceScheduleList(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		fetchErr  error
+		fetchRows []string
+		expected  []*entity.PriceSchedule
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: postgres.PriceScheduleColumns,
+			expected:  []*entity.PriceSchedule{{ID: 1, Tenant: types.TenantLoremType, Type: types.PriceSchedulePromotionType, Status: types.PriceScheduleStatusActiveType}},
+			wantErr:   false,
+		},
+	}
+
+	functions := map[string]func(repo *postgres.PriceScheduleRepository, ctx context.Context) ([]*entity.PriceSchedule, error){
+		"GetPriceSchedulesByProductID": func(repo *postgres.PriceScheduleRepository, ctx context.Context) ([]*entity.PriceSchedule, error) {
+			return repo.GetPriceSchedulesByProductID(ctx, 123)
+		},
+		"GetDuePriceSchedules": func(repo *postgres.PriceScheduleRepository, ctx context.Context) ([]*entity.PriceSchedule, error) {
+			return repo.GetDuePriceSchedules(ctx, time.Now(), 100)
+		},
+		"GetDuePriceSchedulesByProductIDs": func(repo *postgres.PriceScheduleRepository, ctx context.Context) ([]*entity.PriceSchedule, error) {
+			return repo.GetDuePriceSchedulesByProductIDs(ctx, []int{123}, time.Now())
+		},
+	}
+
+	for functionName, function := range functions {
+		for _, tc := range testcases {
+			t.Run(functionName+" "+tc.name, func(t *testing.T) {
+				db, mock, err := sqlmock.New()
+				if err != nil {
+					t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+				}
+				defer db.Close()
+
+				if tc.fetchErr != nil {
+					mock.ExpectQuery("^SELECT(.+)").WillReturnError(tc.fetchErr)
+				} else {
+					rows := sqlmock.NewRows(tc.fetchRows)
+					for _, expected := range tc.expected {
+						rows = priceScheduleRow(rows, expected)
+					}
+
+					mock.ExpectQuery("^SELECT(.+)").WillReturnRows(rows)
+				}
+
+				dbx := sqlx.NewDb(db, "mock")
+				repo := postgres.NewPriceScheduleRepository(dbx)
+				result, err := function(repo, tc.ctx)
+				assert.Equal(t, tc.wantErr, err != nil, err)
+				if !tc.wantErr {
+					assert.EqualValues(t, tc.expected, result)
+				}
+			})
+		}
+	}
+}
+
+func TestGetOverlappingPromotionsCount(t *testing.T) {
+	testcases := []struct {
+		name     string
+		ctx      context.Context
+		fetchErr error
+		expected int
+		wantErr  bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:     "success",
+			ctx:      context.Background(),
+			expected: 1,
+			wantErr:  false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery("^SELECT COUNT\\(\\*\\) (.+)").WillReturnError(tc.fetchErr)
+			} else {
+				mock.ExpectQuery("^SELECT COUNT\\(\\*\\) (.+)").WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(tc.expected))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewPriceScheduleRepository(dbx)
+			result, err := repo.GetOverlappingPromotionsCount(tc.ctx, 123, time.Now(), time.Now().Add(time.Hour))
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			if !tc.wantErr {
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestUpdatePriceScheduleStatus(t *testing.T) {
+	testcases := []struct {
+		name         string
+		ctx          context.Context
+		updateErr    error
+		rowsAffected int64
+		wantErr      bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			updateErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:         "status has been changed",
+			ctx:          context.Background(),
+			rowsAffected: 0,
+			wantErr:      true,
+		},
+		{
+			name:         "success",
+			ctx:          context.Background(),
+			rowsAffected: 1,
+			wantErr:      false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.updateErr != nil {
+				mock.ExpectExec("^UPDATE price_schedules(.+)").WillReturnError(tc.updateErr)
+			} else {
+				mock.ExpectExec("^UPDATE price_schedules(.+)").WillReturnResult(sqlmock.NewResult(1, tc.rowsAffected))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewPriceScheduleRepository(dbx)
+			err = repo.UpdatePriceScheduleStatus(tc.ctx, nil, &entity.PriceSchedule{Status: types.PriceScheduleStatusActiveType}, types.PriceScheduleStatusPendingType)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+		})
+	}
+}
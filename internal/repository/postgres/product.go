@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"strconv"
 	"strings"
@@ -20,12 +21,19 @@ import (
 
 // ProductRepositoryInterface define contract for product related functions to repository
 type ProductRepositoryInterface interface {
-	CreateProduct(ctx context.Context, product *entity.Product) error
+	CreateProduct(ctx context.Context, dbTrx interface{}, product *entity.Product) error
 	GetProductByID(ctx context.Context, productID int) (*entity.Product, error)
+	GetPartialProductByID(ctx context.Context, productID int, columns []string) (*entity.Product, error)
 	GetProductBySKU(ctx context.Context, productSKU string) (*entity.Product, error)
+	GetProductsForUpdate(ctx context.Context, dbTrx interface{}, tenant types.TenantType, productIDs []int, productSKUs []string) ([]*entity.Product, error)
 	GetProducts(ctx context.Context, payload *entity.GetProductPayload) ([]*entity.Product, error)
 	GetProductsCount(ctx context.Context, payload *entity.GetProductPayload) (int, error)
-	UpdateProduct(ctx context.Context, dbTrx interface{}, product *entity.Product) error
+	UpdateProduct(ctx context.Context, dbTrx interface{}, product *entity.Product, columns []string) error
+	UpdateProductQty(ctx context.Context, dbTrx interface{}, product *entity.Product) error
+	ReserveProductQty(ctx context.Context, dbTrx interface{}, productID int, qty int) error
+	ReleaseProductQty(ctx context.Context, dbTrx interface{}, productID int, qty int) error
+	SetProductLowStockAlerted(ctx context.Context, dbTrx interface{}, productID int, alerted bool) (bool, error)
+	GetInventoryValuations(ctx context.Context, tenant types.TenantType) ([]*entity.InventoryValuation, error)
 }
 
 // ProductRepository holds database connection
@@ -37,26 +45,40 @@ var (
 	// ProductTableName hold table name for products
 	ProductTableName = "products"
 	// ProductColumns list all columns on products table
-	ProductColumns = []string{"id", "sku", "title", "category", "condition", "tenant", "qty", "price", "created_at", "updated_at"}
+	ProductColumns = []string{"id", "sku", "title", "description", "attributes", "category", "condition", "tenant", "qty", "price", "promotion_price", "tax_class_id", "cost_price", "low_stock_threshold", "backorder_policy", "backorder_limit", "restock_date", "serialized", "created_at", "updated_at"}
+	// ProductReservedQtyColumn hold column of qty held by active reservations,
+	// it is only changed by reserve and release queries so that updating a product does not overwrite it
+	ProductReservedQtyColumn = "reserved_qty"
+	// ProductBackorderedQtyColumn hold column of qty ordered beyond the available qty, it is only changed with the qty
+	ProductBackorderedQtyColumn = "backordered_qty"
+	// ProductInTransitQtyColumn hold column of qty dispatched by transfers which is not received yet, it is only changed with the qty
+	ProductInTransitQtyColumn = "in_transit_qty"
+	// ProductLowStockAlertedColumn hold column of the low stock alert state, it is only changed by SetProductLowStockAlerted
+	ProductLowStockAlertedColumn = "low_stock_alerted"
+	// ProductVersionColumn hold column of the version of the product, it is only incremented by UpdateProduct
+	ProductVersionColumn = "version"
 	// ProductAttributes hold string format of all products table columns
-	ProductAttributes = strings.Join(ProductColumns, ", ")
+	ProductAttributes = fmt.Sprintf("%s, %s, %s, %s, %s, %s", strings.Join(ProductColumns, ", "), ProductReservedQtyColumn, ProductBackorderedQtyColumn, ProductInTransitQtyColumn, ProductLowStockAlertedColumn, ProductVersionColumn)
 
 	// ProductCreationColumns list all columns used for create product
 	ProductCreationColumns = ProductColumns[1:]
 	// ProductCreationAttributes hold string format of all creation product columns
 	ProductCreationAttributes = strings.Join(ProductCreationColumns, ", ")
 
-	// eligibleOrderByFields list all eligible order by field
-	eligibleOrderByFields = []string{"created_at"}
+	// TenantSearchConfigTableName hold table name for the text search configs of the tenants
+	TenantSearchConfigTableName = "tenant_search_configs"
 )
 
+// productHighlightOptions mark the words matching the keyword and cut the highlight to the fragments around them
+const productHighlightOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5"
+
 // NewProductRepository create initiate product repository with given database
 func NewProductRepository(db *sqlx.DB) *ProductRepository {
 	return &ProductRepository{db: db}
 }
 
-func (r *ProductRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]*entity.Product, error) {
-	rows, err := r.db.QueryxContext(ctx, query, args...)
+func (r *ProductRepository) fetch(ctx context.Context, tx sqlx.QueryerContext, query string, args ...interface{}) ([]*entity.Product, error) {
+	rows, err := tx.QueryxContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +100,7 @@ func (r *ProductRepository) fetch(ctx context.Context, query string, args ...int
 }
 
 // CreateProduct insert product data into database
-func (r *ProductRepository) CreateProduct(ctx context.Context, product *entity.Product) error {
+func (r *ProductRepository) CreateProduct(ctx context.Context, dbTrx interface{}, product *entity.Product) error {
 	functionName := "ProductRepository.CreateProduct"
 
 	if err := helper.CheckDeadline(ctx); err != nil {
@@ -90,19 +112,39 @@ func (r *ProductRepository) CreateProduct(ctx context.Context, product *entity.P
 	product.UpdatedAt = now
 	product.SKU = helper.GenerateSKU()
 
-	query := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s) RETURNING id`, ProductTableName, ProductCreationAttributes, EnumeratedBindvars(ProductCreationColumns))
+	// Record the initial price on the same statement
+	query := fmt.Sprintf(
+		`WITH inserted AS (INSERT INTO %s (%s) VALUES (%s) RETURNING %s), history AS (%s) SELECT id, %s FROM inserted`,
+		ProductTableName,
+		ProductCreationAttributes,
+		EnumeratedBindvars(ProductCreationColumns),
+		ProductAttributes,
+		recordPriceHistoryQuery("inserted", ""),
+		ProductVersionColumn,
+	)
 
-	err := r.db.QueryRowContext(ctx, query,
+	tx := Tx(r.db, dbTrx)
+	err := tx.QueryRowxContext(ctx, query,
 		product.SKU,
 		product.Title,
+		product.Description,
+		dbentity.ProductAttributes(product.Attributes),
 		product.Category,
 		product.Condition,
 		product.Tenant,
 		product.Qty,
 		product.Price,
+		product.PromotionPrice,
+		product.TaxClassID,
+		product.CostPrice,
+		product.LowStockThreshold,
+		product.BackorderPolicy,
+		product.BackorderLimit,
+		product.RestockDate,
+		product.Serialized,
 		product.CreatedAt,
 		product.UpdatedAt,
-	).Scan(&product.ID)
+	).Scan(&product.ID, &product.Version)
 	if err != nil {
 		if postgresError, ok := err.(*pq.Error); ok {
 			if postgresError.Code == pq.ErrorCode(config.UniqueConstraintViolationCode) && postgresError.Constraint == config.SKUTenantUniqueConstraint {
@@ -123,12 +165,37 @@ func (r *ProductRepository) GetProductByID(ctx context.Context, productID int) (
 		return nil, errors.Wrap(err, functionName)
 	}
 
-	query := fmt.Sprintf("SELECT %s FROM %s WHERE id = $1 LIMIT 1", ProductAttributes, ProductTableName)
-	rows, err := r.fetch(ctx, query, productID)
-	if err != nil {
+	product, err := r.getProductByID(ctx, productID, nil)
+	if err != nil && err != response.ErrNotFound {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return product, err
+}
+
+// GetPartialProductByID return product by id with only the given columns read, all columns are read when none are given
+func (r *ProductRepository) GetPartialProductByID(ctx context.Context, productID int, columns []string) (*entity.Product, error) {
+	functionName := "ProductRepository.GetPartialProductByID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	product, err := r.getProductByID(ctx, productID, columns)
+	if err != nil && err != response.ErrNotFound {
 		return nil, errors.Wrap(err, functionName)
 	}
 
+	return product, err
+}
+
+func (r *ProductRepository) getProductByID(ctx context.Context, productID int, columns []string) (*entity.Product, error) {
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id = $1 LIMIT 1", productSelectAttributes(columns), ProductTableName)
+	rows, err := r.fetch(ctx, r.db, query, productID)
+	if err != nil {
+		return nil, err
+	}
+
 	if len(rows) == 0 {
 		return nil, response.ErrNotFound
 	}
@@ -145,7 +212,7 @@ func (r *ProductRepository) GetProductBySKU(ctx context.Context, productSKU stri
 	}
 
 	query := fmt.Sprintf("SELECT %s FROM %s WHERE sku = $1 LIMIT 1", ProductAttributes, ProductTableName)
-	rows, err := r.fetch(ctx, query, productSKU)
+	rows, err := r.fetch(ctx, r.db, query, productSKU)
 	if err != nil {
 		return nil, errors.Wrap(err, functionName)
 	}
@@ -157,6 +224,24 @@ func (r *ProductRepository) GetProductBySKU(ctx context.Context, productSKU stri
 	return rows[0], nil
 }
 
+// GetProductsForUpdate return products of the tenant by id or sku and lock them until the transaction ends.
+// The rows are locked in the order of their id so that concurrent transactions wait for each other instead of deadlocking
+func (r *ProductRepository) GetProductsForUpdate(ctx context.Context, dbTrx interface{}, tenant types.TenantType, productIDs []int, productSKUs []string) ([]*entity.Product, error) {
+	functionName := "ProductRepository.GetProductsForUpdate"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE (id = ANY($1) OR sku = ANY($2)) AND tenant = $3 ORDER BY id ASC FOR UPDATE", ProductAttributes, ProductTableName)
+	rows, err := r.fetch(ctx, Tx(r.db, dbTrx), query, pq.Array(productIDs), pq.Array(productSKUs), tenant)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return rows, nil
+}
+
 // GetProducts query to get product list
 func (r *ProductRepository) GetProducts(ctx context.Context, payload *entity.GetProductPayload) ([]*entity.Product, error) {
 	functionName := "ProductRepository.GetProducts"
@@ -170,28 +255,87 @@ func (r *ProductRepository) GetProducts(ctx context.Context, payload *entity.Get
 		payload.Limit = 100
 	}
 
-	orderBy := "id DESC"
-	if len(payload.OrderBy) > 0 {
-		parts := strings.Split(payload.OrderBy, " ")
-		orderByField := parts[0]
-		orderByType := parts[1]
+	sortKeys := payload.SortKeys()
+	filterQuery, params := r.constructSearchQuery(payload)
+
+	// Products are equally relevant without keyword, so the relevance key is skipped
+	relevance := ""
+	highlight := ""
+	if payload.Keyword != "" {
+		tenantIndex, keywordIndex := len(params)+1, len(params)+2
+		searchQuery := productSearchQuery(tenantIndex, keywordIndex)
+		relevance = fmt.Sprintf("ts_rank(search_vector, %s)", searchQuery)
+		highlight = fmt.Sprintf(
+			", ts_headline(%s, title || ' ' || description, %s, '%s') AS highlight",
+			productSearchConfig(tenantIndex),
+			searchQuery,
+			productHighlightOptions,
+		)
+		params = append(params, strconv.FormatInt(int64(payload.Tenant), 10), payload.Keyword)
+	}
 
-		if helper.StringInArray(orderByField, eligibleOrderByFields) {
-			if orderByType != "ASC" {
-				orderByType = "DESC"
+	// The page of a cursor is sorted in the reverse direction when it is backward and the rows are reversed back after the fetch
+	backward := payload.Position != nil && payload.Position.Backward
+	keys := []productSortExpression{}
+	for i, key := range sortKeys {
+		expression := key.Field
+		if key.Field == entity.RelevanceSortField {
+			if relevance == "" {
+				continue
 			}
-			orderBy = fmt.Sprintf("%s %s", orderByField, orderByType)
+			expression = relevance
+		}
+
+		keys = append(keys, productSortExpression{expression: expression, desc: key.Desc != backward, valueIndex: i})
+	}
+	keys = append(keys, productSortExpression{expression: "id", desc: sortKeys.IDDesc() != backward, valueIndex: len(sortKeys)})
+
+	if payload.Position != nil {
+		payload.Offset = 0
+
+		values := make([]string, 0, len(payload.Position.Values)+1)
+		values = append(values, payload.Position.Values...)
+		values = append(values, strconv.Itoa(payload.Position.ID))
+		var keysetQuery string
+		keysetQuery, params = constructKeysetQuery(keys, values, params)
+		filterQuery = fmt.Sprintf("%s AND %s", filterQuery, keysetQuery)
+	}
+
+	orderBy := make([]string, 0, len(keys))
+	for _, key := range keys {
+		direction := "ASC"
+		if key.desc {
+			direction = "DESC"
 		}
+		orderBy = append(orderBy, fmt.Sprintf("%s %s", key.expression, direction))
 	}
 
-	filterQuery, params := r.constructSearchQuery(payload)
-	query := fmt.Sprintf("SELECT %s FROM %s %s ORDER BY %s OFFSET %d LIMIT %d", ProductAttributes, ProductTableName, filterQuery, orderBy, payload.Offset, payload.Limit)
+	if relevance == "" {
+		relevance = "0"
+	}
+	query := fmt.Sprintf(
+		"SELECT %s, %s AS relevance%s FROM %s %s ORDER BY %s OFFSET %d LIMIT %d",
+		productSelectAttributes(payload.Fieldset.Columns(sortKeys)),
+		relevance,
+		highlight,
+		ProductTableName,
+		filterQuery,
+		strings.Join(orderBy, ", "),
+		payload.Offset,
+		payload.Limit,
+	)
 
-	rows, err := r.fetch(ctx, query, params...)
+	rows, err := r.fetch(ctx, r.db, query, params...)
 	if err != nil {
 		return rows, errors.Wrap(err, functionName)
 	}
 
+	if payload.Position != nil && payload.Position.Backward {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
 	return rows, nil
 }
 
@@ -214,8 +358,9 @@ func (r *ProductRepository) GetProductsCount(ctx context.Context, payload *entit
 	return count, nil
 }
 
-// UpdateProduct update a product
-func (r *ProductRepository) UpdateProduct(ctx context.Context, dbTrx interface{}, product *entity.Product) error {
+// UpdateProduct update the given columns of a product, all of the columns when no columns are given.
+// The update is rejected when the version of the product is not the stored one, otherwise the version is incremented
+func (r *ProductRepository) UpdateProduct(ctx context.Context, dbTrx interface{}, product *entity.Product, columns []string) error {
 	functionName := "ProductRepository.UpdateProduct"
 
 	if err := helper.CheckDeadline(ctx); err != nil {
@@ -225,30 +370,292 @@ func (r *ProductRepository) UpdateProduct(ctx context.Context, dbTrx interface{}
 	now := time.Now()
 	product.UpdatedAt = now
 
-	query := fmt.Sprintf("UPDATE %s SET %s WHERE id = $%d", ProductTableName, UpdateColumnsValues(ProductCreationColumns), len(ProductColumns))
+	valueByColumn := map[string]interface{}{
+		"sku":                 product.SKU,
+		"title":               product.Title,
+		"description":         product.Description,
+		"attributes":          dbentity.ProductAttributes(product.Attributes),
+		"category":            product.Category,
+		"condition":           product.Condition,
+		"tenant":              product.Tenant,
+		"qty":                 product.Qty,
+		"price":               product.Price,
+		"promotion_price":     product.PromotionPrice,
+		"tax_class_id":        product.TaxClassID,
+		"cost_price":          product.CostPrice,
+		"low_stock_threshold": product.LowStockThreshold,
+		"backorder_policy":    product.BackorderPolicy,
+		"backorder_limit":     product.BackorderLimit,
+		"restock_date":        product.RestockDate,
+		"serialized":          product.Serialized,
+		"created_at":          product.CreatedAt,
+		"updated_at":          product.UpdatedAt,
+	}
+
+	updatedColumns := ProductCreationColumns
+	if columns != nil {
+		updatedColumns = make([]string, 0, len(columns)+1)
+		for _, column := range columns {
+			if _, ok := valueByColumn[column]; !ok {
+				return errors.Wrap(fmt.Errorf("unknown column %s", column), functionName)
+			}
+
+			if column != "updated_at" {
+				updatedColumns = append(updatedColumns, column)
+			}
+		}
+		updatedColumns = append(updatedColumns, "updated_at")
+	}
+
+	args := make([]interface{}, 0, len(updatedColumns)+2)
+	for _, column := range updatedColumns {
+		args = append(args, valueByColumn[column])
+	}
+	args = append(args, product.ID, product.Version)
+
+	// Lock the current price and record the new one on the same statement when it is changed
+	query := fmt.Sprintf(
+		`WITH old AS (SELECT price, promotion_price FROM %[1]s WHERE id = $%[3]d FOR UPDATE), updated AS (UPDATE %[1]s SET %[2]s, %[4]s = %[4]s + 1 WHERE id = $%[3]d AND %[4]s = $%[5]d RETURNING %[6]s), history AS (%[7]s) SELECT %[4]s FROM updated`,
+		ProductTableName,
+		UpdateColumnsValues(updatedColumns),
+		len(args)-1,
+		ProductVersionColumn,
+		len(args),
+		ProductAttributes,
+		recordPriceHistoryQuery(
+			"updated",
+			"WHERE EXISTS (SELECT 1 FROM old WHERE old.price IS DISTINCT FROM updated.price OR old.promotion_price IS DISTINCT FROM updated.promotion_price)",
+		),
+	)
 
 	tx := Tx(r.db, dbTrx)
-	_, err := tx.ExecContext(
-		ctx,
-		query,
-		product.SKU,
-		product.Title,
-		product.Category,
-		product.Condition,
-		product.Tenant,
-		product.Qty,
-		product.Price,
-		product.CreatedAt,
-		product.UpdatedAt,
-		product.ID,
+	if err := tx.QueryRowxContext(ctx, query, args...).Scan(&product.Version); err != nil {
+		if err == sql.ErrNoRows {
+			return response.ErrStaleVersion
+		}
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// UpdateProductQty update only the qty of a product so that the other fields changed concurrently are kept
+func (r *ProductRepository) UpdateProductQty(ctx context.Context, dbTrx interface{}, product *entity.Product) error {
+	functionName := "ProductRepository.UpdateProductQty"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	product.UpdatedAt = time.Now()
+
+	query := fmt.Sprintf("UPDATE %s SET qty = $1, %s = $2, %s = $3, updated_at = $4 WHERE id = $5", ProductTableName, ProductBackorderedQtyColumn, ProductInTransitQtyColumn)
+
+	tx := Tx(r.db, dbTrx)
+	if _, err := tx.ExecContext(ctx, query, product.Qty, product.BackorderedQty, product.InTransitQty, product.UpdatedAt, product.ID); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// ReserveProductQty hold qty of a product when the product has enough qty which is not reserved yet
+func (r *ProductRepository) ReserveProductQty(ctx context.Context, dbTrx interface{}, productID int, qty int) error {
+	functionName := "ProductRepository.ReserveProductQty"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	// The availability check and the increment are done on the same statement
+	// so that concurrent reservations can not hold more than the qty on hand
+	// The qty of the lots which are expired by now is not available
+	query := fmt.Sprintf(
+		`UPDATE %[1]s SET %[2]s = %[2]s + $1, updated_at = $2 WHERE id = $3
+		AND qty - %[2]s - %[3]s - COALESCE((SELECT SUM(%[4]s.qty) FROM %[4]s WHERE %[4]s.product_id = $3 AND %[4]s.expiry_date <= $2), 0) >= $1`,
+		ProductTableName,
+		ProductReservedQtyColumn,
+		ProductBackorderedQtyColumn,
+		ProductLotTableName,
 	)
+
+	tx := Tx(r.db, dbTrx)
+	result, err := tx.ExecContext(ctx, query, qty, time.Now(), productID)
 	if err != nil {
 		return errors.Wrap(err, functionName)
 	}
 
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	if affected == 0 {
+		return response.ErrInsufficientStock
+	}
+
+	return nil
+}
+
+// ReleaseProductQty stop holding qty of a product
+func (r *ProductRepository) ReleaseProductQty(ctx context.Context, dbTrx interface{}, productID int, qty int) error {
+	functionName := "ProductRepository.ReleaseProductQty"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf(
+		"UPDATE %[1]s SET %[2]s = GREATEST(%[2]s - $1, 0), updated_at = $2 WHERE id = $3",
+		ProductTableName,
+		ProductReservedQtyColumn,
+	)
+
+	tx := Tx(r.db, dbTrx)
+	if _, err := tx.ExecContext(ctx, query, qty, time.Now(), productID); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
 	return nil
 }
 
+// SetProductLowStockAlerted change the low stock alert state of a product and return whether it is changed,
+// so that only one of concurrent changes creates the alert
+func (r *ProductRepository) SetProductLowStockAlerted(ctx context.Context, dbTrx interface{}, productID int, alerted bool) (bool, error) {
+	functionName := "ProductRepository.SetProductLowStockAlerted"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return false, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("UPDATE %[1]s SET %[2]s = $1 WHERE id = $2 AND %[2]s <> $1", ProductTableName, ProductLowStockAlertedColumn)
+
+	tx := Tx(r.db, dbTrx)
+	result, err := tx.ExecContext(ctx, query, alerted, productID)
+	if err != nil {
+		return false, errors.Wrap(err, functionName)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, functionName)
+	}
+
+	return affected > 0, nil
+}
+
+// GetInventoryValuations return value of the stock on hand of the tenant grouped by category and condition
+func (r *ProductRepository) GetInventoryValuations(ctx context.Context, tenant types.TenantType) ([]*entity.InventoryValuation, error) {
+	functionName := "ProductRepository.GetInventoryValuations"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf(
+		`SELECT category, condition, COUNT(*), COALESCE(SUM(qty), 0), COALESCE(SUM(qty::bigint * cost_price), 0)::bigint, COALESCE(SUM(qty::bigint * price), 0)::bigint, COUNT(*) FILTER (WHERE cost_price IS NULL)
+		FROM %s WHERE tenant = $1 GROUP BY category, condition ORDER BY category, condition`,
+		ProductTableName,
+	)
+
+	rows, err := r.db.QueryxContext(ctx, query, tenant)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	defer rows.Close()
+
+	result := make([]*entity.InventoryValuation, 0)
+	for rows.Next() {
+		valuation := &entity.InventoryValuation{}
+		if err := rows.Scan(
+			&valuation.Category,
+			&valuation.Condition,
+			&valuation.ProductCount,
+			&valuation.Qty,
+			&valuation.CostValue,
+			&valuation.RetailValue,
+			&valuation.UncostedProductCount,
+		); err != nil {
+			return nil, errors.Wrap(err, functionName)
+		}
+
+		result = append(result, valuation)
+	}
+
+	return result, nil
+}
+
+// recordPriceHistoryQuery build query to insert prices of the products returned by the given source into price histories
+func recordPriceHistoryQuery(source string, condition string) string {
+	return fmt.Sprintf(
+		"INSERT INTO %[1]s (%[2]s) SELECT %[3]s.id, %[3]s.tenant, %[3]s.price, %[3]s.promotion_price, COALESCE(%[3]s.promotion_price, %[3]s.price), %[3]s.updated_at FROM %[3]s %[4]s",
+		PriceHistoryTableName,
+		PriceHistoryCreationAttributes,
+		source,
+		condition,
+	)
+}
+
+// productSearchConfig return the text search config of the tenant of the given param, the simple config is used when the tenant has none
+func productSearchConfig(tenantIndex int) string {
+	return fmt.Sprintf("COALESCE((SELECT config FROM %s WHERE tenant = $%d), 'simple')", TenantSearchConfigTableName, tenantIndex)
+}
+
+// productSearchQuery return the text search query of the keyword of the given param, the keyword is parsed with the config of the tenant
+// once for the query so the search vector can be matched with its index. The keyword supports the web search syntax, e.g. "red -shirt"
+func productSearchQuery(tenantIndex int, keywordIndex int) string {
+	return fmt.Sprintf("websearch_to_tsquery(%s, $%d)", productSearchConfig(tenantIndex), keywordIndex)
+}
+
+// productSelectAttributes return the select list of the given columns, it is all of the product attributes when no column is given
+func productSelectAttributes(columns []string) string {
+	if len(columns) == 0 {
+		return ProductAttributes
+	}
+
+	return strings.Join(columns, ", ")
+}
+
+// productSortExpression holds a sort key of the product list query, the value of the key is at the value index of the cursor
+type productSortExpression struct {
+	expression string
+	desc       bool
+	valueIndex int
+}
+
+// constructKeysetQuery construct the condition of the products after the values of the cursor on the given sort keys,
+// a product is after the cursor when its first differing key is after the value of the cursor
+func constructKeysetQuery(keys []productSortExpression, values []string, params []interface{}) (string, []interface{}) {
+	paramIndexes := make([]int, len(keys))
+	for i, key := range keys {
+		params = append(params, values[key.valueIndex])
+		paramIndexes[i] = len(params)
+	}
+
+	conditions := make([]string, 0, len(keys))
+	for i, key := range keys {
+		parts := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			parts = append(parts, fmt.Sprintf("%s = $%d", keys[j].expression, paramIndexes[j]))
+		}
+
+		operator := ">"
+		if key.desc {
+			operator = "<"
+		}
+		parts = append(parts, fmt.Sprintf("%s %s $%d", key.expression, operator, paramIndexes[i]))
+
+		conditions = append(conditions, strings.Join(parts, " AND "))
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0], params
+	}
+
+	return fmt.Sprintf("((%s))", strings.Join(conditions, ") OR (")), params
+}
+
 // constructSearchQuery construct search query
 func (r *ProductRepository) constructSearchQuery(payload *entity.GetProductPayload) (string, []interface{}) {
 	var params []interface{}
@@ -256,27 +663,29 @@ func (r *ProductRepository) constructSearchQuery(payload *entity.GetProductPaylo
 	wheres := []string{}
 	paramIndex := 1
 
-	if len(payload.SKU) > 0 {
-		wheres = append(wheres, fmt.Sprintf("sku = $%v", paramIndex))
-		params = append(params, payload.SKU)
-		paramIndex++
-	}
-
-	if len(payload.TitleKeyword) >= 3 {
-		wheres = append(wheres, fmt.Sprintf("title ILIKE $%v", paramIndex))
-		params = append(params, fmt.Sprintf("%%%s%%", payload.TitleKeyword))
+	if len(payload.SKUs) > 0 {
+		wheres = append(wheres, fmt.Sprintf("sku = ANY($%v)", paramIndex))
+		params = append(params, pq.Array(payload.SKUs))
 		paramIndex++
 	}
 
-	if payload.Category != types.CategoryEmptyType {
-		wheres = append(wheres, fmt.Sprintf("category = $%v", paramIndex))
-		params = append(params, strconv.FormatInt(int64(payload.Category), 10))
+	if len(payload.Categories) > 0 {
+		categories := make([]int64, 0, len(payload.Categories))
+		for _, category := range payload.Categories {
+			categories = append(categories, int64(category))
+		}
+		wheres = append(wheres, fmt.Sprintf("category = ANY($%v)", paramIndex))
+		params = append(params, pq.Array(categories))
 		paramIndex++
 	}
 
-	if payload.Condition != types.ConditionEmptyType {
-		wheres = append(wheres, fmt.Sprintf("condition = $%v", paramIndex))
-		params = append(params, strconv.FormatInt(int64(payload.Condition), 10))
+	if len(payload.Conditions) > 0 {
+		conditions := make([]int64, 0, len(payload.Conditions))
+		for _, condition := range payload.Conditions {
+			conditions = append(conditions, int64(condition))
+		}
+		wheres = append(wheres, fmt.Sprintf("condition = ANY($%v)", paramIndex))
+		params = append(params, pq.Array(conditions))
 		paramIndex++
 	}
 
@@ -284,6 +693,49 @@ func (r *ProductRepository) constructSearchQuery(payload *entity.GetProductPaylo
 	params = append(params, strconv.FormatInt(int64(payload.Tenant), 10))
 	paramIndex++
 
+	if payload.Keyword != "" {
+		wheres = append(wheres, fmt.Sprintf("search_vector @@ %s", productSearchQuery(paramIndex-1, paramIndex)))
+		params = append(params, payload.Keyword)
+		paramIndex++
+	}
+
+	ranges := []struct {
+		column   string
+		operator string
+		value    interface{}
+		given    bool
+	}{
+		{"price", ">=", payload.MinPrice, payload.MinPrice != nil},
+		{"price", "<=", payload.MaxPrice, payload.MaxPrice != nil},
+		{"qty", ">=", payload.MinQty, payload.MinQty != nil},
+		{"qty", "<=", payload.MaxQty, payload.MaxQty != nil},
+		{"created_at", ">=", payload.CreatedFrom, payload.CreatedFrom != nil},
+		{"created_at", "<=", payload.CreatedTo, payload.CreatedTo != nil},
+		{"updated_at", ">=", payload.UpdatedFrom, payload.UpdatedFrom != nil},
+		{"updated_at", "<=", payload.UpdatedTo, payload.UpdatedTo != nil},
+	}
+	for _, r := range ranges {
+		if r.given {
+			wheres = append(wheres, fmt.Sprintf("%s %s $%v", r.column, r.operator, paramIndex))
+			params = append(params, r.value)
+			paramIndex++
+		}
+	}
+
+	switch payload.Stock {
+	case entity.LowStockFilter:
+		// Products without their own threshold use the threshold of the tenant, products without any threshold are never low
+		wheres = append(wheres, fmt.Sprintf(
+			"qty <= COALESCE(low_stock_threshold, (SELECT threshold FROM %[1]s WHERE %[1]s.tenant = %[2]s.tenant))",
+			LowStockThresholdTableName,
+			ProductTableName,
+		))
+	case entity.InStockFilter:
+		wheres = append(wheres, "qty - reserved_qty - backordered_qty > 0")
+	case entity.OutOfStockFilter:
+		wheres = append(wheres, "qty - reserved_qty - backordered_qty <= 0")
+	}
+
 	if len(wheres) > 0 {
 		filterQuery = fmt.Sprintf("WHERE %s", strings.Join(wheres, " AND "))
 	}
@@ -0,0 +1,650 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	dbentity "github.com/satriowisnugroho/catalog/internal/repository/postgres/entity"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// ProductStockRepositoryInterface define contract for product stock related functions to repository
+type ProductStockRepositoryInterface interface {
+	GetProductStocksByProductIDs(ctx context.Context, productIDs []int) ([]*entity.ProductStock, error)
+	GetProductStocksForUpdate(ctx context.Context, dbTrx interface{}, productID int) ([]*entity.ProductStock, error)
+	UpsertProductStocks(ctx context.Context, dbTrx interface{}, stocks []*entity.ProductStock) error
+	ReplaceProductStocks(ctx context.Context, dbTrx interface{}, productID int, stocks []*entity.ProductStock) error
+	GetProductLotsByProductIDs(ctx context.Context, productIDs []int) ([]*entity.ProductLot, error)
+	GetProductLotsForUpdate(ctx context.Context, dbTrx interface{}, productID int) ([]*entity.ProductLot, error)
+	AddProductLotQty(ctx context.Context, dbTrx interface{}, lot *entity.ProductLot) error
+	UpdateProductLots(ctx context.Context, dbTrx interface{}, lots []*entity.ProductLot) error
+	GetExpiringProductLots(ctx context.Context, tenant types.TenantType, expiresBefore time.Time) ([]*entity.ExpiringLot, error)
+	CreateProductSerials(ctx context.Context, dbTrx interface{}, serials []*entity.ProductSerial) error
+	GetInStockProductSerialsForUpdate(ctx context.Context, dbTrx interface{}, productID int, serialNumbers []string, limit int) ([]*entity.ProductSerial, error)
+	DeductProductSerials(ctx context.Context, dbTrx interface{}, serials []*entity.ProductSerial, status types.SerialStatusType, actor string, referenceID string) error
+	GetProductSerialBySerialNumber(ctx context.Context, tenant types.TenantType, serialNumber string) (*entity.ProductSerial, error)
+	GetProductSerials(ctx context.Context, payload *entity.GetProductSerialPayload) ([]*entity.ProductSerial, error)
+	GetProductSerialsCount(ctx context.Context, payload *entity.GetProductSerialPayload) (int, error)
+}
+
+// ProductStockRepository holds database connection
+type ProductStockRepository struct {
+	db *sqlx.DB
+}
+
+var (
+	// ProductStockTableName hold table name for product stocks
+	ProductStockTableName = "product_stocks"
+	// ProductStockColumns list all columns on product stocks table
+	ProductStockColumns = []string{"product_id", "location_id", "qty", "updated_at"}
+	// ProductStockAttributes hold string format of all product stocks table columns
+	ProductStockAttributes = strings.Join(ProductStockColumns, ", ")
+
+	// productStockWithLocationAttributes hold string format of product stocks columns joined with their location
+	productStockWithLocationAttributes = fmt.Sprintf(
+		"%[1]s.product_id, %[1]s.location_id, %[1]s.qty, %[1]s.updated_at, %[2]s.code AS location_code, %[2]s.name AS location_name, %[2]s.priority AS location_priority",
+		ProductStockTableName,
+		LocationTableName,
+	)
+	// productStockWithLocationTables hold string format of product stocks table joined with locations table
+	productStockWithLocationTables = fmt.Sprintf("%[1]s JOIN %[2]s ON %[2]s.id = %[1]s.location_id", ProductStockTableName, LocationTableName)
+
+	// ProductLotTableName hold table name for product lots
+	ProductLotTableName = "product_lots"
+	// ProductLotColumns list all columns on product lots table
+	ProductLotColumns = []string{"id", "product_id", "lot_number", "expiry_date", "qty", "created_at", "updated_at"}
+	// ProductLotAttributes hold string format of all product lots table columns
+	ProductLotAttributes = strings.Join(ProductLotColumns, ", ")
+
+	// ProductLotCreationColumns list all columns used for create product lot
+	ProductLotCreationColumns = ProductLotColumns[1:]
+	// ProductLotCreationAttributes hold string format of all creation product lot columns
+	ProductLotCreationAttributes = strings.Join(ProductLotCreationColumns, ", ")
+
+	// ProductSerialTableName hold table name for product serials
+	ProductSerialTableName = "product_serials"
+	// ProductSerialColumns list all columns on product serials table
+	ProductSerialColumns = []string{"id", "product_id", "tenant", "serial_number", "status", "reference_id", "actor", "deducted_at", "created_at", "updated_at"}
+	// ProductSerialAttributes hold string format of all product serials table columns
+	ProductSerialAttributes = strings.Join(ProductSerialColumns, ", ")
+
+	// ProductSerialCreationColumns list all columns used for create product serial
+	ProductSerialCreationColumns = ProductSerialColumns[1:]
+	// ProductSerialCreationAttributes hold string format of all creation product serial columns
+	ProductSerialCreationAttributes = strings.Join(ProductSerialCreationColumns, ", ")
+)
+
+// NewProductStockRepository create initiate product stock repository with given database
+func NewProductStockRepository(db *sqlx.DB) *ProductStockRepository {
+	return &ProductStockRepository{db: db}
+}
+
+func (r *ProductStockRepository) fetch(ctx context.Context, tx sqlx.QueryerContext, query string, args ...interface{}) ([]*entity.ProductStock, error) {
+	rows, err := tx.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]*entity.ProductStock, 0)
+
+	for rows.Next() {
+		tmpEntity := dbentity.ProductStock{}
+		if err := rows.StructScan(&tmpEntity); err != nil {
+			return nil, errors.Wrap(err, "fetch")
+		}
+
+		result = append(result, tmpEntity.ToEntity())
+	}
+
+	return result, nil
+}
+
+// GetProductStocksByProductIDs return stocks of products from the location with the highest priority
+func (r *ProductStockRepository) GetProductStocksByProductIDs(ctx context.Context, productIDs []int) ([]*entity.ProductStock, error) {
+	functionName := "ProductStockRepository.GetProductStocksByProductIDs"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE %s.product_id = ANY($1) ORDER BY location_priority DESC, location_id ASC",
+		productStockWithLocationAttributes,
+		productStockWithLocationTables,
+		ProductStockTableName,
+	)
+	rows, err := r.fetch(ctx, r.db, query, pq.Array(productIDs))
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return rows, nil
+}
+
+// GetProductStocksForUpdate return stocks of a product and lock them until the transaction ends
+func (r *ProductStockRepository) GetProductStocksForUpdate(ctx context.Context, dbTrx interface{}, productID int) ([]*entity.ProductStock, error) {
+	functionName := "ProductStockRepository.GetProductStocksForUpdate"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE %s.product_id = $1 ORDER BY location_priority DESC, location_id ASC FOR UPDATE OF %s",
+		productStockWithLocationAttributes,
+		productStockWithLocationTables,
+		ProductStockTableName,
+		ProductStockTableName,
+	)
+	rows, err := r.fetch(ctx, Tx(r.db, dbTrx), query, productID)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return rows, nil
+}
+
+// UpsertProductStocks set qty of the given product stocks
+func (r *ProductStockRepository) UpsertProductStocks(ctx context.Context, dbTrx interface{}, stocks []*entity.ProductStock) error {
+	functionName := "ProductStockRepository.UpsertProductStocks"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	if len(stocks) == 0 {
+		return nil
+	}
+
+	query, args := insertProductStocksQuery(stocks)
+	query = fmt.Sprintf("%s ON CONFLICT (product_id, location_id) DO UPDATE SET qty = EXCLUDED.qty, updated_at = EXCLUDED.updated_at", query)
+
+	tx := Tx(r.db, dbTrx)
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// ReplaceProductStocks replace all stocks of a product with the given ones
+func (r *ProductStockRepository) ReplaceProductStocks(ctx context.Context, dbTrx interface{}, productID int, stocks []*entity.ProductStock) error {
+	functionName := "ProductStockRepository.ReplaceProductStocks"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	tx := Tx(r.db, dbTrx)
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE product_id = $1", ProductStockTableName), productID); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	if len(stocks) == 0 {
+		return nil
+	}
+
+	for _, stock := range stocks {
+		stock.ProductID = productID
+	}
+
+	query, args := insertProductStocksQuery(stocks)
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// insertProductStocksQuery build query to insert the given product stocks
+func insertProductStocksQuery(stocks []*entity.ProductStock) (string, []interface{}) {
+	now := time.Now()
+	values := make([]string, 0, len(stocks))
+	args := make([]interface{}, 0, len(stocks)*len(ProductStockColumns))
+	for _, stock := range stocks {
+		stock.UpdatedAt = now
+
+		values = append(values, fmt.Sprintf("(%s)", EnumeratedBindvarsFrom(len(args)+1, len(ProductStockColumns))))
+		args = append(args, stock.ProductID, stock.LocationID, stock.Qty, stock.UpdatedAt)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", ProductStockTableName, ProductStockAttributes, strings.Join(values, ", "))
+
+	return query, args
+}
+
+func (r *ProductStockRepository) fetchProductLots(ctx context.Context, tx sqlx.QueryerContext, query string, args ...interface{}) ([]*entity.ProductLot, error) {
+	rows, err := tx.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]*entity.ProductLot, 0)
+
+	for rows.Next() {
+		tmpEntity := dbentity.ProductLot{}
+		if err := rows.StructScan(&tmpEntity); err != nil {
+			return nil, errors.Wrap(err, "fetchProductLots")
+		}
+
+		result = append(result, tmpEntity.ToEntity())
+	}
+
+	return result, nil
+}
+
+// GetProductLotsByProductIDs return lots in stock of products, the first expiring first
+func (r *ProductStockRepository) GetProductLotsByProductIDs(ctx context.Context, productIDs []int) ([]*entity.ProductLot, error) {
+	functionName := "ProductStockRepository.GetProductLotsByProductIDs"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE product_id = ANY($1) AND qty > 0 ORDER BY product_id ASC, expiry_date ASC, id ASC",
+		ProductLotAttributes,
+		ProductLotTableName,
+	)
+	rows, err := r.fetchProductLots(ctx, r.db, query, pq.Array(productIDs))
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return rows, nil
+}
+
+// GetProductLotsForUpdate return lots in stock of a product, the first expiring first, and lock them until the transaction ends
+func (r *ProductStockRepository) GetProductLotsForUpdate(ctx context.Context, dbTrx interface{}, productID int) ([]*entity.ProductLot, error) {
+	functionName := "ProductStockRepository.GetProductLotsForUpdate"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE product_id = $1 AND qty > 0 ORDER BY expiry_date ASC, id ASC FOR UPDATE",
+		ProductLotAttributes,
+		ProductLotTableName,
+	)
+	rows, err := r.fetchProductLots(ctx, Tx(r.db, dbTrx), query, productID)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return rows, nil
+}
+
+// AddProductLotQty add qty of the lot to the lot with the same lot number of the product, or create the lot when it does not exist.
+// The expiry date of an existing lot is replaced with the given one
+func (r *ProductStockRepository) AddProductLotQty(ctx context.Context, dbTrx interface{}, lot *entity.ProductLot) error {
+	functionName := "ProductStockRepository.AddProductLotQty"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	now := time.Now()
+	lot.CreatedAt = now
+	lot.UpdatedAt = now
+
+	query := fmt.Sprintf(
+		`INSERT INTO %[1]s (%[2]s) VALUES (%[3]s) ON CONFLICT (product_id, lot_number)
+		DO UPDATE SET qty = %[1]s.qty + EXCLUDED.qty, expiry_date = EXCLUDED.expiry_date, updated_at = EXCLUDED.updated_at RETURNING id, qty`,
+		ProductLotTableName,
+		ProductLotCreationAttributes,
+		EnumeratedBindvars(ProductLotCreationColumns),
+	)
+
+	tx := Tx(r.db, dbTrx)
+	err := tx.QueryRowxContext(ctx, query,
+		lot.ProductID,
+		lot.LotNumber,
+		lot.ExpiryDate,
+		lot.Qty,
+		lot.CreatedAt,
+		lot.UpdatedAt,
+	).Scan(&lot.ID, &lot.Qty)
+	if err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// UpdateProductLots set qty of the given lots
+func (r *ProductStockRepository) UpdateProductLots(ctx context.Context, dbTrx interface{}, lots []*entity.ProductLot) error {
+	functionName := "ProductStockRepository.UpdateProductLots"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	if len(lots) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	values := make([]string, 0, len(lots))
+	args := []interface{}{now}
+	for _, lot := range lots {
+		lot.UpdatedAt = now
+
+		values = append(values, fmt.Sprintf("($%d::integer, $%d::integer)", len(args)+1, len(args)+2))
+		args = append(args, lot.ID, lot.Qty)
+	}
+
+	query := fmt.Sprintf(
+		"UPDATE %[1]s SET qty = v.qty, updated_at = $1 FROM (VALUES %[2]s) AS v (id, qty) WHERE %[1]s.id = v.id",
+		ProductLotTableName,
+		strings.Join(values, ", "),
+	)
+
+	tx := Tx(r.db, dbTrx)
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// GetExpiringProductLots return lots in stock of the tenant which expire before the given time, including the expired ones,
+// the first expiring first
+func (r *ProductStockRepository) GetExpiringProductLots(ctx context.Context, tenant types.TenantType, expiresBefore time.Time) ([]*entity.ExpiringLot, error) {
+	functionName := "ProductStockRepository.GetExpiringProductLots"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf(
+		`SELECT %[1]s.product_id, %[2]s.sku, %[2]s.title, %[1]s.lot_number, %[1]s.expiry_date, %[1]s.qty
+		FROM %[1]s JOIN %[2]s ON %[2]s.id = %[1]s.product_id
+		WHERE %[2]s.tenant = $1 AND %[1]s.qty > 0 AND %[1]s.expiry_date < $2 ORDER BY %[1]s.expiry_date ASC, %[1]s.id ASC`,
+		ProductLotTableName,
+		ProductTableName,
+	)
+
+	rows, err := r.db.QueryxContext(ctx, query, tenant, expiresBefore)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	defer rows.Close()
+
+	result := make([]*entity.ExpiringLot, 0)
+	for rows.Next() {
+		lot := &entity.ExpiringLot{}
+		if err := rows.Scan(
+			&lot.ProductID,
+			&lot.SKU,
+			&lot.Title,
+			&lot.LotNumber,
+			&lot.ExpiryDate,
+			&lot.Qty,
+		); err != nil {
+			return nil, errors.Wrap(err, functionName)
+		}
+
+		result = append(result, lot)
+	}
+
+	return result, nil
+}
+
+func (r *ProductStockRepository) fetchProductSerials(ctx context.Context, tx sqlx.QueryerContext, query string, args ...interface{}) ([]*entity.ProductSerial, error) {
+	rows, err := tx.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]*entity.ProductSerial, 0)
+
+	for rows.Next() {
+		tmpEntity := dbentity.ProductSerial{}
+		if err := rows.StructScan(&tmpEntity); err != nil {
+			return nil, errors.Wrap(err, "fetchProductSerials")
+		}
+
+		result = append(result, tmpEntity.ToEntity())
+	}
+
+	return result, nil
+}
+
+// CreateProductSerials insert the in stock serial numbers into database. A serial number which is out of stock
+// is put back in stock when it is of the same product, e.g. a returned unit. It fails with conflict
+// when a serial number is already in stock or registered to another product of the tenant
+func (r *ProductStockRepository) CreateProductSerials(ctx context.Context, dbTrx interface{}, serials []*entity.ProductSerial) error {
+	functionName := "ProductStockRepository.CreateProductSerials"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	if len(serials) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	values := make([]string, 0, len(serials))
+	args := make([]interface{}, 0, len(serials)*len(ProductSerialCreationColumns))
+	serialByNumber := make(map[string]*entity.ProductSerial, len(serials))
+	for _, serial := range serials {
+		serial.DeductedAt = nil
+		serial.CreatedAt = now
+		serial.UpdatedAt = now
+		serialByNumber[serial.SerialNumber] = serial
+
+		values = append(values, fmt.Sprintf("(%s)", EnumeratedBindvarsFrom(len(args)+1, len(ProductSerialCreationColumns))))
+		args = append(args,
+			serial.ProductID,
+			serial.Tenant,
+			serial.SerialNumber,
+			serial.Status,
+			serial.ReferenceID,
+			serial.Actor,
+			serial.DeductedAt,
+			serial.CreatedAt,
+			serial.UpdatedAt,
+		)
+	}
+
+	query := fmt.Sprintf(
+		`INSERT INTO %[1]s (%[2]s) VALUES %[3]s ON CONFLICT (tenant, serial_number)
+		DO UPDATE SET status = EXCLUDED.status, reference_id = EXCLUDED.reference_id, actor = EXCLUDED.actor, deducted_at = EXCLUDED.deducted_at, updated_at = EXCLUDED.updated_at
+		WHERE %[1]s.product_id = EXCLUDED.product_id AND %[1]s.status <> EXCLUDED.status RETURNING id, serial_number`,
+		ProductSerialTableName,
+		ProductSerialCreationAttributes,
+		strings.Join(values, ", "),
+	)
+
+	tx := Tx(r.db, dbTrx)
+	rows, err := tx.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	defer rows.Close()
+
+	created := 0
+	for rows.Next() {
+		var id int
+		var serialNumber string
+		if err := rows.Scan(&id, &serialNumber); err != nil {
+			return errors.Wrap(err, functionName)
+		}
+
+		if serial, ok := serialByNumber[serialNumber]; ok {
+			serial.ID = id
+			created++
+		}
+	}
+
+	// The conflicting serial numbers are skipped by the upsert
+	if created < len(serials) {
+		return response.ErrSerialNumberConflict
+	}
+
+	return nil
+}
+
+// GetInStockProductSerialsForUpdate return the given serial numbers of a product which are in stock,
+// or the first limit serial numbers in stock when none is given, and lock them until the transaction ends
+func (r *ProductStockRepository) GetInStockProductSerialsForUpdate(ctx context.Context, dbTrx interface{}, productID int, serialNumbers []string, limit int) ([]*entity.ProductSerial, error) {
+	functionName := "ProductStockRepository.GetInStockProductSerialsForUpdate"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE product_id = $1 AND status = $2 AND serial_number = ANY($3) ORDER BY id ASC FOR UPDATE",
+		ProductSerialAttributes,
+		ProductSerialTableName,
+	)
+	args := []interface{}{productID, types.SerialStatusInStockType, pq.Array(serialNumbers)}
+	if len(serialNumbers) == 0 {
+		query = fmt.Sprintf(
+			"SELECT %s FROM %s WHERE product_id = $1 AND status = $2 ORDER BY id ASC LIMIT $3 FOR UPDATE",
+			ProductSerialAttributes,
+			ProductSerialTableName,
+		)
+		args = []interface{}{productID, types.SerialStatusInStockType, limit}
+	}
+
+	rows, err := r.fetchProductSerials(ctx, Tx(r.db, dbTrx), query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return rows, nil
+}
+
+// DeductProductSerials take the given serial numbers out of stock with the given status,
+// the actor and reference id of the deduction are kept on them
+func (r *ProductStockRepository) DeductProductSerials(ctx context.Context, dbTrx interface{}, serials []*entity.ProductSerial, status types.SerialStatusType, actor string, referenceID string) error {
+	functionName := "ProductStockRepository.DeductProductSerials"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	if len(serials) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	serialIDs := make([]int, 0, len(serials))
+	for _, serial := range serials {
+		serial.Status = status
+		serial.Actor = actor
+		serial.ReferenceID = referenceID
+		serial.DeductedAt = &now
+		serial.UpdatedAt = now
+		serialIDs = append(serialIDs, serial.ID)
+	}
+
+	query := fmt.Sprintf(
+		"UPDATE %s SET status = $1, actor = $2, reference_id = $3, deducted_at = $4, updated_at = $4 WHERE id = ANY($5)",
+		ProductSerialTableName,
+	)
+
+	tx := Tx(r.db, dbTrx)
+	if _, err := tx.ExecContext(ctx, query, status, actor, referenceID, now, pq.Array(serialIDs)); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// GetProductSerialBySerialNumber return serial number of the tenant
+func (r *ProductStockRepository) GetProductSerialBySerialNumber(ctx context.Context, tenant types.TenantType, serialNumber string) (*entity.ProductSerial, error) {
+	functionName := "ProductStockRepository.GetProductSerialBySerialNumber"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE tenant = $1 AND serial_number = $2 LIMIT 1", ProductSerialAttributes, ProductSerialTableName)
+	rows, err := r.fetchProductSerials(ctx, r.db, query, tenant, serialNumber)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if len(rows) == 0 {
+		return nil, response.ErrNotFound
+	}
+
+	return rows[0], nil
+}
+
+// GetProductSerials return serial numbers of a product in the order they were registered
+func (r *ProductStockRepository) GetProductSerials(ctx context.Context, payload *entity.GetProductSerialPayload) ([]*entity.ProductSerial, error) {
+	functionName := "ProductStockRepository.GetProductSerials"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if payload.Limit == 0 {
+		payload.Limit = 10
+	} else if payload.Limit > 100 {
+		payload.Limit = 100
+	}
+
+	filterQuery, params := r.constructSerialSearchQuery(payload)
+	query := fmt.Sprintf("SELECT %s FROM %s %s ORDER BY id ASC OFFSET %d LIMIT %d", ProductSerialAttributes, ProductSerialTableName, filterQuery, payload.Offset, payload.Limit)
+	rows, err := r.fetchProductSerials(ctx, r.db, query, params...)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return rows, nil
+}
+
+// GetProductSerialsCount return count of serial numbers of a product
+func (r *ProductStockRepository) GetProductSerialsCount(ctx context.Context, payload *entity.GetProductSerialPayload) (int, error) {
+	functionName := "ProductStockRepository.GetProductSerialsCount"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return 0, errors.Wrap(err, functionName)
+	}
+
+	filterQuery, params := r.constructSerialSearchQuery(payload)
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s %s", ProductSerialTableName, filterQuery)
+
+	count := 0
+	row := r.db.QueryRowxContext(ctx, query, params...)
+	if err := row.Scan(&count); err != nil {
+		return count, errors.Wrap(err, functionName)
+	}
+
+	return count, nil
+}
+
+func (r *ProductStockRepository) constructSerialSearchQuery(payload *entity.GetProductSerialPayload) (string, []interface{}) {
+	wheres := []string{"product_id = $1"}
+	params := []interface{}{payload.ProductID}
+
+	if payload.Status != types.SerialStatusEmptyType {
+		params = append(params, payload.Status)
+		wheres = append(wheres, fmt.Sprintf("status = $%d", len(params)))
+	}
+
+	return fmt.Sprintf("WHERE %s", strings.Join(wheres, " AND ")), params
+}
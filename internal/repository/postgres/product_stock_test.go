@@ -0,0 +1,926 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	"github.com/stretchr/testify/assert"
+)
+
+var productStockWithLocationColumns = []string{"product_id", "location_id", "qty", "updated_at", "location_code", "location_name", "location_priority"}
+
+var productLotColumns = []string{"id", "product_id", "lot_number", "expiry_date", "qty", "created_at", "updated_at"}
+
+var productSerialColumns = []string{"id", "product_id", "tenant", "serial_number", "status", "reference_id", "actor", "deducted_at", "created_at", "updated_at"}
+
+func TestGetProductStocks(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		forUpdate bool
+		fetchErr  error
+		fetchRows []string
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "fail fetch return error rows",
+			ctx:       context.Background(),
+			fetchRows: []string{"unknown_column"},
+			wantErr:   true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: productStockWithLocationColumns,
+			wantErr:   false,
+		},
+		{
+			name:      "deadline context for update",
+			ctx:       fixture.CtxEnded(),
+			forUpdate: true,
+			wantErr:   true,
+		},
+		{
+			name:      "fail fetch query error for update",
+			ctx:       context.Background(),
+			forUpdate: true,
+			fetchErr:  errors.New("fail fetch"),
+			wantErr:   true,
+		},
+		{
+			name:      "success for update",
+			ctx:       context.Background(),
+			forUpdate: true,
+			fetchRows: productStockWithLocationColumns,
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			query := "^SELECT (.+) FROM product_stocks JOIN locations (.+) WHERE product_stocks.product_id = ANY(.+)"
+			if tc.forUpdate {
+				query = "^SELECT (.+) FROM product_stocks JOIN locations (.+) WHERE product_stocks.product_id = \\$1 (.+) FOR UPDATE OF product_stocks"
+			}
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery(query).WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				if len(tc.fetchRows) == 1 {
+					rows = rows.AddRow(1)
+				} else if len(tc.fetchRows) > 1 {
+					rows = rows.AddRow(1, 1, 10, time.Now(), "jkt-1", "Jakarta", 10)
+				}
+				mock.ExpectQuery(query).WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewProductStockRepository(dbx)
+
+			var res []*entity.ProductStock
+			if tc.forUpdate {
+				res, err = repo.GetProductStocksForUpdate(tc.ctx, nil, 1)
+			} else {
+				res, err = repo.GetProductStocksByProductIDs(tc.ctx, []int{1})
+			}
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, "jkt-1", res[0].LocationCode)
+				assert.Equal(t, 10, res[0].LocationPriority)
+				assert.Equal(t, 10, res[0].Qty)
+			}
+		})
+	}
+}
+
+func TestUpsertProductStocks(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		stocks    []*entity.ProductStock
+		upsertErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:    "success without stocks",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			stocks:    []*entity.ProductStock{{ProductID: 1, LocationID: 1, Qty: 1}, {ProductID: 1, LocationID: 2, Qty: 0}},
+			upsertErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			stocks:  []*entity.ProductStock{{ProductID: 1, LocationID: 1, Qty: 1}, {ProductID: 1, LocationID: 2, Qty: 0}},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			query := "^INSERT INTO product_stocks (.+) VALUES \\((.+)\\), \\((.+)\\) ON CONFLICT \\(product_id, location_id\\) DO UPDATE (.+)"
+			if tc.upsertErr != nil {
+				mock.ExpectExec(query).WillReturnError(tc.upsertErr)
+			} else {
+				mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 2))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewProductStockRepository(dbx)
+
+			err = repo.UpsertProductStocks(tc.ctx, nil, tc.stocks)
+			assert.Equal(t, tc.wantErr, err != nil)
+		})
+	}
+}
+
+func TestReplaceProductStocks(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		stocks    []*entity.ProductStock
+		deleteErr error
+		insertErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "fail delete query",
+			ctx:       context.Background(),
+			deleteErr: errors.New("fail delete"),
+			wantErr:   true,
+		},
+		{
+			name:    "success without stocks",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+		{
+			name:      "fail insert query",
+			ctx:       context.Background(),
+			stocks:    []*entity.ProductStock{{LocationID: 1, Qty: 1}, {LocationID: 2, Qty: 2}},
+			insertErr: errors.New("fail insert"),
+			wantErr:   true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			stocks:  []*entity.ProductStock{{LocationID: 1, Qty: 1}, {LocationID: 2, Qty: 2}},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.deleteErr != nil {
+				mock.ExpectExec("^DELETE FROM product_stocks WHERE product_id = \\$1").WillReturnError(tc.deleteErr)
+			} else {
+				mock.ExpectExec("^DELETE FROM product_stocks WHERE product_id = \\$1").WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			if tc.insertErr != nil {
+				mock.ExpectExec("^INSERT INTO product_stocks (.+) VALUES \\((.+)\\), \\((.+)\\)").WillReturnError(tc.insertErr)
+			} else {
+				mock.ExpectExec("^INSERT INTO product_stocks (.+) VALUES \\((.+)\\), \\((.+)\\)").WillReturnResult(sqlmock.NewResult(0, 2))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewProductStockRepository(dbx)
+
+			err = repo.ReplaceProductStocks(tc.ctx, nil, 1, tc.stocks)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				for _, stock := range tc.stocks {
+					assert.Equal(t, 1, stock.ProductID)
+				}
+			}
+		})
+	}
+}
+
+func TestGetProductLots(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		forUpdate bool
+		fetchErr  error
+		fetchRows []string
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "fail fetch return error rows",
+			ctx:       context.Background(),
+			fetchRows: []string{"unknown_column"},
+			wantErr:   true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: productLotColumns,
+			wantErr:   false,
+		},
+		{
+			name:      "deadline context for update",
+			ctx:       fixture.CtxEnded(),
+			forUpdate: true,
+			wantErr:   true,
+		},
+		{
+			name:      "fail fetch query error for update",
+			ctx:       context.Background(),
+			forUpdate: true,
+			fetchErr:  errors.New("fail fetch"),
+			wantErr:   true,
+		},
+		{
+			name:      "success for update",
+			ctx:       context.Background(),
+			forUpdate: true,
+			fetchRows: productLotColumns,
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			query := "^SELECT (.+) FROM product_lots WHERE product_id = ANY(.+) AND qty > 0 ORDER BY product_id ASC, expiry_date ASC"
+			if tc.forUpdate {
+				query = "^SELECT (.+) FROM product_lots WHERE product_id = \\$1 AND qty > 0 ORDER BY expiry_date ASC, id ASC FOR UPDATE"
+			}
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery(query).WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				if len(tc.fetchRows) == 1 {
+					rows = rows.AddRow(1)
+				} else if len(tc.fetchRows) > 1 {
+					rows = rows.AddRow(1, 1, "LOT1", time.Now(), 10, time.Now(), time.Now())
+				}
+				mock.ExpectQuery(query).WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewProductStockRepository(dbx)
+
+			var res []*entity.ProductLot
+			if tc.forUpdate {
+				res, err = repo.GetProductLotsForUpdate(tc.ctx, nil, 1)
+			} else {
+				res, err = repo.GetProductLotsByProductIDs(tc.ctx, []int{1})
+			}
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, "LOT1", res[0].LotNumber)
+				assert.Equal(t, 10, res[0].Qty)
+			}
+		})
+	}
+}
+
+func TestAddProductLotQty(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		upsertErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			upsertErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			query := "^INSERT INTO product_lots (.+) VALUES (.+) ON CONFLICT \\(product_id, lot_number\\)(.+) SET qty = product_lots.qty \\+ EXCLUDED.qty(.+) RETURNING id, qty"
+			if tc.upsertErr != nil {
+				mock.ExpectQuery(query).WillReturnError(tc.upsertErr)
+			} else {
+				mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"id", "qty"}).AddRow(1, 15))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewProductStockRepository(dbx)
+
+			lot := &entity.ProductLot{ProductID: 1, LotNumber: "LOT1", ExpiryDate: time.Now(), Qty: 5}
+			err = repo.AddProductLotQty(tc.ctx, nil, lot)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, 1, lot.ID)
+				assert.Equal(t, 15, lot.Qty)
+			}
+		})
+	}
+}
+
+func TestUpdateProductLots(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		lots      []*entity.ProductLot
+		updateErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:    "success without lots",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			lots:      []*entity.ProductLot{{ID: 1, Qty: 0}, {ID: 2, Qty: 3}},
+			updateErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			lots:    []*entity.ProductLot{{ID: 1, Qty: 0}, {ID: 2, Qty: 3}},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			query := "^UPDATE product_lots SET qty = v.qty, updated_at = \\$1 FROM \\(VALUES \\((.+)\\), \\((.+)\\)\\) AS v (.+) WHERE product_lots.id = v.id"
+			if tc.updateErr != nil {
+				mock.ExpectExec(query).WillReturnError(tc.updateErr)
+			} else {
+				mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 2))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewProductStockRepository(dbx)
+
+			err = repo.UpdateProductLots(tc.ctx, nil, tc.lots)
+			assert.Equal(t, tc.wantErr, err != nil)
+		})
+	}
+}
+
+func TestGetExpiringProductLots(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		fetchErr  error
+		fetchRows []string
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "fail scan rows",
+			ctx:       context.Background(),
+			fetchRows: []string{"unknown_column"},
+			wantErr:   true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: []string{"product_id", "sku", "title", "lot_number", "expiry_date", "qty"},
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			query := "^SELECT (.+) FROM product_lots JOIN products (.+) WHERE products.tenant = \\$1 AND product_lots.qty > 0 AND product_lots.expiry_date < \\$2"
+			if tc.fetchErr != nil {
+				mock.ExpectQuery(query).WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				if len(tc.fetchRows) == 1 {
+					rows = rows.AddRow(1)
+				} else if len(tc.fetchRows) > 1 {
+					rows = rows.AddRow(1, "SKU1", "Milk", "LOT1", time.Now(), 10)
+				}
+				mock.ExpectQuery(query).WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewProductStockRepository(dbx)
+
+			res, err := repo.GetExpiringProductLots(tc.ctx, types.TenantLoremType, time.Now())
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, "SKU1", res[0].SKU)
+				assert.Equal(t, "LOT1", res[0].LotNumber)
+				assert.Equal(t, 10, res[0].Qty)
+			}
+		})
+	}
+}
+
+func TestCreateProductSerials(t *testing.T) {
+	testcases := []struct {
+		name       string
+		ctx        context.Context
+		serials    []*entity.ProductSerial
+		insertErr  error
+		insertRows [][]driver.Value
+		wantErr    bool
+		wantCustom error
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			serials: []*entity.ProductSerial{{SerialNumber: "SN-1"}},
+			wantErr: true,
+		},
+		{
+			name: "success without serials",
+			ctx:  context.Background(),
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			serials:   []*entity.ProductSerial{{SerialNumber: "SN-1"}, {SerialNumber: "SN-2"}},
+			insertErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:       "serial number is already registered",
+			ctx:        context.Background(),
+			serials:    []*entity.ProductSerial{{SerialNumber: "SN-1"}, {SerialNumber: "SN-2"}},
+			insertRows: [][]driver.Value{{1, "SN-1"}},
+			wantErr:    true,
+			wantCustom: response.ErrSerialNumberConflict,
+		},
+		{
+			name:       "success",
+			ctx:        context.Background(),
+			serials:    []*entity.ProductSerial{{SerialNumber: "SN-1"}, {SerialNumber: "SN-2"}},
+			insertRows: [][]driver.Value{{2, "SN-2"}, {1, "SN-1"}},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			query := "^INSERT INTO product_serials (.+) VALUES \\((.+)\\), \\((.+)\\) ON CONFLICT \\(tenant, serial_number\\)(.+) WHERE product_serials.product_id = EXCLUDED.product_id (.+) RETURNING id, serial_number"
+			if tc.insertErr != nil {
+				mock.ExpectQuery(query).WillReturnError(tc.insertErr)
+			} else {
+				rows := sqlmock.NewRows([]string{"id", "serial_number"})
+				for _, row := range tc.insertRows {
+					rows = rows.AddRow(row...)
+				}
+				mock.ExpectQuery(query).WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewProductStockRepository(dbx)
+
+			err = repo.CreateProductSerials(tc.ctx, nil, tc.serials)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustom != nil {
+				assert.Equal(t, tc.wantCustom, err)
+			}
+			if !tc.wantErr {
+				for i, serial := range tc.serials {
+					assert.Equal(t, i+1, serial.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestGetInStockProductSerialsForUpdate(t *testing.T) {
+	testcases := []struct {
+		name          string
+		ctx           context.Context
+		serialNumbers []string
+		fetchErr      error
+		fetchRows     []string
+		wantErr       bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "fail fetch return error rows",
+			ctx:       context.Background(),
+			fetchRows: []string{"unknown_column"},
+			wantErr:   true,
+		},
+		{
+			name:      "success in stock first",
+			ctx:       context.Background(),
+			fetchRows: productSerialColumns,
+			wantErr:   false,
+		},
+		{
+			name:          "success given serial numbers",
+			ctx:           context.Background(),
+			serialNumbers: []string{"SN-1"},
+			fetchRows:     productSerialColumns,
+			wantErr:       false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			query := "^SELECT (.+) FROM product_serials WHERE product_id = \\$1 AND status = \\$2 ORDER BY id ASC LIMIT \\$3 FOR UPDATE"
+			if len(tc.serialNumbers) > 0 {
+				query = "^SELECT (.+) FROM product_serials WHERE product_id = \\$1 AND status = \\$2 AND serial_number = ANY(.+) ORDER BY id ASC FOR UPDATE"
+			}
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery(query).WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				if len(tc.fetchRows) == 1 {
+					rows = rows.AddRow(1)
+				} else if len(tc.fetchRows) > 1 {
+					rows = rows.AddRow(1, 1, 1, "SN-1", 1, "", "", nil, time.Now(), time.Now())
+				}
+				mock.ExpectQuery(query).WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewProductStockRepository(dbx)
+
+			res, err := repo.GetInStockProductSerialsForUpdate(tc.ctx, nil, 1, tc.serialNumbers, 2)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, "SN-1", res[0].SerialNumber)
+				assert.Equal(t, types.SerialStatusInStockType, res[0].Status)
+			}
+		})
+	}
+}
+
+func TestDeductProductSerials(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		serials   []*entity.ProductSerial
+		updateErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:    "success without serials",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			serials:   []*entity.ProductSerial{{ID: 1}, {ID: 2}},
+			updateErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			serials: []*entity.ProductSerial{{ID: 1}, {ID: 2}},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			query := "^UPDATE product_serials SET status = \\$1, actor = \\$2, reference_id = \\$3, deducted_at = \\$4, updated_at = \\$4 WHERE id = ANY(.+)"
+			if tc.updateErr != nil {
+				mock.ExpectExec(query).WillReturnError(tc.updateErr)
+			} else {
+				mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 2))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewProductStockRepository(dbx)
+
+			err = repo.DeductProductSerials(tc.ctx, nil, tc.serials, types.SerialStatusSoldType, "jane", "ORDER-1")
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				for _, serial := range tc.serials {
+					assert.Equal(t, types.SerialStatusSoldType, serial.Status)
+					assert.Equal(t, "ORDER-1", serial.ReferenceID)
+					assert.NotNil(t, serial.DeductedAt)
+				}
+			}
+		})
+	}
+}
+
+func TestGetProductSerialBySerialNumber(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		fetchErr  error
+		fetchRows []string
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "not found",
+			ctx:       context.Background(),
+			fetchRows: productSerialColumns,
+			wantErr:   true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: productSerialColumns,
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			query := "^SELECT (.+) FROM product_serials WHERE tenant = \\$1 AND serial_number = \\$2 LIMIT 1"
+			if tc.fetchErr != nil {
+				mock.ExpectQuery(query).WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				if tc.name == "success" {
+					rows = rows.AddRow(1, 1, 1, "SN-1", 2, "ORDER-1", "jane", time.Now(), time.Now(), time.Now())
+				}
+				mock.ExpectQuery(query).WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewProductStockRepository(dbx)
+
+			res, err := repo.GetProductSerialBySerialNumber(tc.ctx, types.TenantLoremType, "SN-1")
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, types.SerialStatusSoldType, res.Status)
+				assert.Equal(t, "ORDER-1", res.ReferenceID)
+			}
+		})
+	}
+}
+
+func TestGetProductSerials(t *testing.T) {
+	testcases := []struct {
+		name     string
+		ctx      context.Context
+		payload  *entity.GetProductSerialPayload
+		fetchErr error
+		wantErr  bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			payload: &entity.GetProductSerialPayload{},
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			payload:  &entity.GetProductSerialPayload{},
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			payload: &entity.GetProductSerialPayload{ProductID: 1, Status: types.SerialStatusInStockType, Limit: 1000},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			query := "^SELECT (.+) FROM product_serials WHERE product_id = \\$1(.*) ORDER BY id ASC OFFSET 0 LIMIT"
+			if tc.fetchErr != nil {
+				mock.ExpectQuery(query).WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(productSerialColumns).AddRow(1, 1, 1, "SN-1", 1, "", "", nil, time.Now(), time.Now())
+				mock.ExpectQuery(query).WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewProductStockRepository(dbx)
+
+			res, err := repo.GetProductSerials(tc.ctx, tc.payload)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Len(t, res, 1)
+				assert.Equal(t, 100, tc.payload.Limit)
+			}
+		})
+	}
+}
+
+func TestGetProductSerialsCount(t *testing.T) {
+	testcases := []struct {
+		name     string
+		ctx      context.Context
+		fetchErr error
+		wantErr  bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			query := "^SELECT COUNT\\(\\*\\) FROM product_serials WHERE product_id = \\$1 AND status = \\$2"
+			if tc.fetchErr != nil {
+				mock.ExpectQuery(query).WillReturnError(tc.fetchErr)
+			} else {
+				mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewProductStockRepository(dbx)
+
+			count, err := repo.GetProductSerialsCount(tc.ctx, &entity.GetProductSerialPayload{ProductID: 1, Status: types.SerialStatusSoldType})
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, 3, count)
+			}
+		})
+	}
+}
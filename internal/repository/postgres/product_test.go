@@ -2,7 +2,9 @@ package postgres_test
 
 import (
 	"context"
+	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jmoiron/sqlx"
@@ -12,6 +14,7 @@ import (
 	"github.com/satriowisnugroho/catalog/internal/entity"
 	"github.com/satriowisnugroho/catalog/internal/entity/types"
 	"github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/internal/response"
 	"github.com/satriowisnugroho/catalog/test/fixture"
 	"github.com/stretchr/testify/assert"
 )
@@ -60,20 +63,21 @@ func TestCreateProduct(t *testing.T) {
 			defer db.Close()
 
 			if tc.createErr != nil {
-				mock.ExpectQuery("^INSERT INTO(.+)").WillReturnError(tc.createErr)
+				mock.ExpectQuery("^WITH inserted AS \\(INSERT INTO products (.+)INSERT INTO price_histories (.+)").WillReturnError(tc.createErr)
 			} else {
-				row := sqlmock.NewRows([]string{"id"})
-				result := row.AddRow(1)
-				mock.ExpectQuery("^INSERT INTO(.+)").WillReturnRows(result)
+				row := sqlmock.NewRows([]string{"id", "version"})
+				result := row.AddRow(1, 1)
+				mock.ExpectQuery("^WITH inserted AS \\(INSERT INTO products (.+)INSERT INTO price_histories (.+)").WillReturnRows(result)
 			}
 
 			dbx := sqlx.NewDb(db, "mock")
 			repo := postgres.NewProductRepository(dbx)
 
-			err = repo.CreateProduct(tc.ctx, tc.input)
+			err = repo.CreateProduct(tc.ctx, nil, tc.input)
 			assert.Equal(t, tc.wantErr, err != nil)
 			if !tc.wantErr {
 				assert.Equal(t, 1, tc.input.ID)
+				assert.Equal(t, 1, tc.input.Version)
 			}
 		})
 	}
@@ -115,7 +119,7 @@ func TestGetProductByID(t *testing.T) {
 			name:      "success",
 			ctx:       context.Background(),
 			fetchRows: postgres.ProductColumns,
-			expected:  &entity.Product{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType},
+			expected:  &entity.Product{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, BackorderPolicy: types.BackorderPolicyDenyType},
 			wantErr:   false,
 		},
 	}
@@ -137,11 +141,21 @@ func TestGetProductByID(t *testing.T) {
 						tc.expected.ID,
 						tc.expected.SKU,
 						tc.expected.Title,
+						tc.expected.Description,
+						nil,
 						tc.expected.Category,
 						tc.expected.Condition,
 						tc.expected.Tenant,
 						tc.expected.Qty,
 						tc.expected.Price,
+						tc.expected.PromotionPrice,
+						tc.expected.TaxClassID,
+						tc.expected.CostPrice,
+						tc.expected.LowStockThreshold,
+						tc.expected.BackorderPolicy,
+						tc.expected.BackorderLimit,
+						tc.expected.RestockDate,
+						tc.expected.Serialized,
 						tc.expected.CreatedAt,
 						tc.expected.UpdatedAt,
 					)
@@ -163,6 +177,81 @@ func TestGetProductByID(t *testing.T) {
 	}
 }
 
+func TestGetPartialProductByID(t *testing.T) {
+	testcases := []struct {
+		name     string
+		ctx      context.Context
+		columns  []string
+		query    string
+		fetchErr error
+		rows     *sqlmock.Rows
+		expected *entity.Product
+		wantErr  bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			columns:  []string{"id", "tenant", "version", "title"},
+			query:    "^SELECT id, tenant, version, title FROM products WHERE id = \\$1 LIMIT 1$",
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:    "record not found",
+			ctx:     context.Background(),
+			columns: []string{"id", "tenant", "version", "title"},
+			query:   "^SELECT id, tenant, version, title FROM products WHERE id = \\$1 LIMIT 1$",
+			rows:    sqlmock.NewRows([]string{"id", "tenant", "version", "title"}),
+			wantErr: true,
+		},
+		{
+			name:     "success with columns",
+			ctx:      context.Background(),
+			columns:  []string{"id", "tenant", "version", "title"},
+			query:    "^SELECT id, tenant, version, title FROM products WHERE id = \\$1 LIMIT 1$",
+			rows:     sqlmock.NewRows([]string{"id", "tenant", "version", "title"}).AddRow(123, types.TenantLoremType, 2, "New Product"),
+			expected: &entity.Product{ID: 123, Tenant: types.TenantLoremType, Version: 2, Title: "New Product"},
+			wantErr:  false,
+		},
+		{
+			name:     "success without columns",
+			ctx:      context.Background(),
+			query:    "^SELECT " + postgres.ProductAttributes + " FROM products WHERE id = \\$1 LIMIT 1$",
+			rows:     sqlmock.NewRows([]string{"id", "tenant", "version", "title"}).AddRow(123, types.TenantLoremType, 2, "New Product"),
+			expected: &entity.Product{ID: 123, Tenant: types.TenantLoremType, Version: 2, Title: "New Product"},
+			wantErr:  false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery(tc.query).WillReturnError(tc.fetchErr)
+			} else if tc.rows != nil {
+				mock.ExpectQuery(tc.query).WillReturnRows(tc.rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewProductRepository(dbx)
+			result, err := repo.GetPartialProductByID(tc.ctx, 123, tc.columns)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			if !tc.wantErr {
+				assert.EqualValues(t, tc.expected, result)
+			}
+		})
+	}
+}
 func TestGetProductBySKU(t *testing.T) {
 	testcases := []struct {
 		name      string
@@ -199,7 +288,7 @@ func TestGetProductBySKU(t *testing.T) {
 			name:      "success",
 			ctx:       context.Background(),
 			fetchRows: postgres.ProductColumns,
-			expected:  &entity.Product{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType},
+			expected:  &entity.Product{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, BackorderPolicy: types.BackorderPolicyDenyType},
 			wantErr:   false,
 		},
 	}
@@ -221,11 +310,21 @@ func TestGetProductBySKU(t *testing.T) {
 						tc.expected.ID,
 						tc.expected.SKU,
 						tc.expected.Title,
+						tc.expected.Description,
+						nil,
 						tc.expected.Category,
 						tc.expected.Condition,
 						tc.expected.Tenant,
 						tc.expected.Qty,
 						tc.expected.Price,
+						tc.expected.PromotionPrice,
+						tc.expected.TaxClassID,
+						tc.expected.CostPrice,
+						tc.expected.LowStockThreshold,
+						tc.expected.BackorderPolicy,
+						tc.expected.BackorderLimit,
+						tc.expected.RestockDate,
+						tc.expected.Serialized,
 						tc.expected.CreatedAt,
 						tc.expected.UpdatedAt,
 					)
@@ -247,13 +346,106 @@ func TestGetProductBySKU(t *testing.T) {
 	}
 }
 
+func TestGetProductsForUpdate(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		fetchErr  error
+		fetchRows []string
+		expected  []*entity.Product
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "fail fetch return error rows",
+			ctx:       context.Background(),
+			fetchRows: []string{"unknown_column"},
+			wantErr:   true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: postgres.ProductColumns,
+			expected:  []*entity.Product{{ID: 1, SKU: "SKU-123", Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Qty: 10, BackorderPolicy: types.BackorderPolicyDenyType}},
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery("^SELECT(.+)").WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				if tc.expected != nil {
+					rows = rows.AddRow(
+						tc.expected[0].ID,
+						tc.expected[0].SKU,
+						tc.expected[0].Title,
+						tc.expected[0].Description,
+						nil,
+						tc.expected[0].Category,
+						tc.expected[0].Condition,
+						tc.expected[0].Tenant,
+						tc.expected[0].Qty,
+						tc.expected[0].Price,
+						tc.expected[0].PromotionPrice,
+						tc.expected[0].TaxClassID,
+						tc.expected[0].CostPrice,
+						tc.expected[0].LowStockThreshold,
+						tc.expected[0].BackorderPolicy,
+						tc.expected[0].BackorderLimit,
+						tc.expected[0].RestockDate,
+						tc.expected[0].Serialized,
+						tc.expected[0].CreatedAt,
+						tc.expected[0].UpdatedAt,
+					)
+				} else if len(tc.fetchRows) == 1 {
+					rows = rows.AddRow(1)
+				}
+
+				mock.ExpectQuery("^SELECT (.+) FROM products WHERE \\(id = ANY\\(\\$1\\) OR sku = ANY\\(\\$2\\)\\) AND tenant = \\$3 ORDER BY id ASC FOR UPDATE").WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), types.TenantLoremType).WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewProductRepository(dbx)
+			result, err := repo.GetProductsForUpdate(tc.ctx, nil, types.TenantLoremType, []int{1}, []string{"SKU-123"})
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			if !tc.wantErr {
+				assert.EqualValues(t, tc.expected, result)
+			}
+		})
+	}
+}
+
 func TestGetProducts(t *testing.T) {
+	minPrice, maxPrice, minQty := 100, 200, 1
+	createdFrom := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+	updatedTo := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+
 	testcases := []struct {
 		name      string
 		ctx       context.Context
 		payload   *entity.GetProductPayload
 		fetchErr  error
 		fetchRows []string
+		query     string
 		expected  []*entity.Product
 		wantErr   bool
 	}{
@@ -281,24 +473,99 @@ func TestGetProducts(t *testing.T) {
 			ctx:       context.Background(),
 			payload:   &entity.GetProductPayload{Limit: 99999},
 			fetchRows: postgres.ProductColumns,
-			expected:  []*entity.Product{{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType}},
+			expected:  []*entity.Product{{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, BackorderPolicy: types.BackorderPolicyDenyType}},
 			wantErr:   false,
 		},
 		{
 			name: "success",
 			ctx:  context.Background(),
 			payload: &entity.GetProductPayload{
-				SKU:          "SKU123",
-				TitleKeyword: "Product",
-				Category:     types.CategoryBookType,
-				Condition:    types.ConditionNewType,
-				Tenant:       types.TenantLoremType,
-				OrderBy:      "created_at DESC",
-				Offset:       0,
-				Limit:        10,
+				SKUs:       []string{"SKU123"},
+				Keyword:    "Product",
+				Categories: []types.CategoryType{types.CategoryBookType},
+				Conditions: []types.ConditionType{types.ConditionNewType},
+				Tenant:     types.TenantLoremType,
+				Sort:       entity.ProductSort{{Field: entity.RelevanceSortField, Desc: true}, {Field: "created_at"}},
+				Offset:     0,
+				Limit:      10,
+			},
+			fetchRows: postgres.ProductColumns,
+			query:     `^SELECT (.+), ts_rank\(search_vector, websearch_to_tsquery\(COALESCE\(\(SELECT config FROM tenant_search_configs WHERE tenant = \$6\), 'simple'\), \$7\)\) AS relevance, ts_headline\((.+)\) AS highlight FROM products WHERE sku = ANY\(\$1\) AND category = ANY\(\$2\) AND condition = ANY\(\$3\) AND tenant = \$4 AND search_vector @@ websearch_to_tsquery\(COALESCE\(\(SELECT config FROM tenant_search_configs WHERE tenant = \$4\), 'simple'\), \$5\) ORDER BY ts_rank\((.+)\) DESC, created_at ASC, id ASC OFFSET 0 LIMIT 10$`,
+			expected:  []*entity.Product{{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, BackorderPolicy: types.BackorderPolicyDenyType}},
+			wantErr:   false,
+		},
+		{
+			name:      "success sorted by relevance by default with keyword",
+			ctx:       context.Background(),
+			payload:   &entity.GetProductPayload{Keyword: "red shirt", Tenant: types.TenantLoremType},
+			fetchRows: postgres.ProductColumns,
+			query:     `^SELECT (.+) WHERE tenant = \$1 AND search_vector @@ (.+) ORDER BY ts_rank\(search_vector, (.+)\) DESC, id DESC OFFSET 0 LIMIT 10$`,
+			expected:  []*entity.Product{{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, BackorderPolicy: types.BackorderPolicyDenyType}},
+			wantErr:   false,
+		},
+		{
+			name:      "success with low stock filter",
+			ctx:       context.Background(),
+			payload:   &entity.GetProductPayload{Tenant: types.TenantLoremType, Stock: entity.LowStockFilter},
+			fetchRows: postgres.ProductColumns,
+			expected:  []*entity.Product{{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Qty: 2, BackorderPolicy: types.BackorderPolicyDenyType}},
+			wantErr:   false,
+		},
+		{
+			name: "success with range and set filters",
+			ctx:  context.Background(),
+			payload: &entity.GetProductPayload{
+				SKUs:        []string{"SKU123", "SKU456"},
+				Categories:  []types.CategoryType{types.CategoryBookType, types.CategoryBagType},
+				Tenant:      types.TenantLoremType,
+				MinPrice:    &minPrice,
+				MaxPrice:    &maxPrice,
+				MinQty:      &minQty,
+				CreatedFrom: &createdFrom,
+				UpdatedTo:   &updatedTo,
+				Stock:       entity.InStockFilter,
 			},
 			fetchRows: postgres.ProductColumns,
-			expected:  []*entity.Product{{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType}},
+			query:     `^SELECT (.+) WHERE sku = ANY\(\$1\) AND category = ANY\(\$2\) AND tenant = \$3 AND price >= \$4 AND price <= \$5 AND qty >= \$6 AND created_at >= \$7 AND updated_at <= \$8 AND qty - reserved_qty - backordered_qty > 0 ORDER BY id DESC OFFSET 0 LIMIT 10$`,
+			expected:  []*entity.Product{{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, BackorderPolicy: types.BackorderPolicyDenyType}},
+			wantErr:   false,
+		},
+		{
+			name: "success with fieldset",
+			ctx:  context.Background(),
+			payload: &entity.GetProductPayload{
+				Tenant:   types.TenantLoremType,
+				Sort:     entity.ProductSort{{Field: "price", Desc: true}},
+				Fieldset: &entity.ProductFieldset{Fields: []string{"id", "title"}},
+			},
+			fetchRows: postgres.ProductColumns,
+			query:     `^SELECT id, tenant, version, price, title, 0 AS relevance FROM products WHERE tenant = \$1 ORDER BY price DESC, id DESC OFFSET 0 LIMIT 10$`,
+			expected:  []*entity.Product{{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, BackorderPolicy: types.BackorderPolicyDenyType}},
+			wantErr:   false,
+		},
+		{
+			name: "success with cursor",
+			ctx:  context.Background(),
+			payload: &entity.GetProductPayload{
+				Tenant:   types.TenantLoremType,
+				Offset:   20,
+				Position: &entity.ProductCursor{Sort: entity.ProductSort{{Field: "price", Desc: true}, {Field: "title"}}, Values: []string{"100", "Book"}, ID: 5},
+			},
+			fetchRows: postgres.ProductColumns,
+			query:     `^SELECT (.+), 0 AS relevance FROM products WHERE tenant = \$1 AND \(\(price < \$2\) OR \(price = \$2 AND title > \$3\) OR \(price = \$2 AND title = \$3 AND id > \$4\)\) ORDER BY price DESC, title ASC, id ASC OFFSET 0 LIMIT 10$`,
+			expected:  []*entity.Product{{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, BackorderPolicy: types.BackorderPolicyDenyType}},
+			wantErr:   false,
+		},
+		{
+			name: "success with backward cursor sorted by relevance without keyword",
+			ctx:  context.Background(),
+			payload: &entity.GetProductPayload{
+				Tenant:   types.TenantLoremType,
+				Position: &entity.ProductCursor{Sort: entity.ProductSort{{Field: entity.RelevanceSortField}}, Values: []string{"0"}, ID: 5, Backward: true},
+			},
+			fetchRows: postgres.ProductColumns,
+			query:     `^SELECT (.+) WHERE tenant = \$1 AND id < \$2 ORDER BY id DESC OFFSET 0 LIMIT 10$`,
+			expected:  []*entity.Product{{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, BackorderPolicy: types.BackorderPolicyDenyType}},
 			wantErr:   false,
 		},
 	}
@@ -320,11 +587,21 @@ func TestGetProducts(t *testing.T) {
 						tc.expected[0].ID,
 						tc.expected[0].SKU,
 						tc.expected[0].Title,
+						tc.expected[0].Description,
+						nil,
 						tc.expected[0].Category,
 						tc.expected[0].Condition,
 						tc.expected[0].Tenant,
 						tc.expected[0].Qty,
 						tc.expected[0].Price,
+						tc.expected[0].PromotionPrice,
+						tc.expected[0].TaxClassID,
+						tc.expected[0].CostPrice,
+						tc.expected[0].LowStockThreshold,
+						tc.expected[0].BackorderPolicy,
+						tc.expected[0].BackorderLimit,
+						tc.expected[0].RestockDate,
+						tc.expected[0].Serialized,
 						tc.expected[0].CreatedAt,
 						tc.expected[0].UpdatedAt,
 					)
@@ -332,7 +609,11 @@ func TestGetProducts(t *testing.T) {
 					rows = rows.AddRow(1)
 				}
 
-				mock.ExpectQuery("^SELECT(.+)").WillReturnRows(rows)
+				query := tc.query
+				if query == "" {
+					query = "^SELECT(.+)"
+				}
+				mock.ExpectQuery(query).WillReturnRows(rows)
 			}
 
 			dbx := sqlx.NewDb(db, "mock")
@@ -407,6 +688,156 @@ func TestGetProductsCount(t *testing.T) {
 }
 
 func TestUpdateProduct(t *testing.T) {
+	testcases := []struct {
+		name        string
+		ctx         context.Context
+		columns     []string
+		wantQuery   string
+		updateErr   error
+		wantErr     error
+		wantVersion int
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: context.Canceled,
+		},
+		{
+			name:    "unknown column",
+			ctx:     context.Background(),
+			columns: []string{"reserved_qty"},
+			wantErr: errors.New("unknown column reserved_qty"),
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			updateErr: errors.New("fail exec"),
+			wantErr:   errors.New("fail exec"),
+		},
+		{
+			name:      "stale version",
+			ctx:       context.Background(),
+			wantQuery: "^WITH old AS (.+)UPDATE products SET (.+) WHERE id = \\$20 AND version = \\$21 (.+)",
+			updateErr: sql.ErrNoRows,
+			wantErr:   response.ErrStaleVersion,
+		},
+		{
+			name:        "success",
+			ctx:         context.Background(),
+			wantQuery:   "^WITH old AS (.+)UPDATE products SET sku = \\$1, (.+) updated_at = \\$19, version = version \\+ 1 WHERE id = \\$20 AND version = \\$21 (.+)INSERT INTO price_histories (.+)",
+			wantVersion: 3,
+		},
+		{
+			name:        "success update the given columns",
+			ctx:         context.Background(),
+			columns:     []string{"price", "title"},
+			wantQuery:   "^WITH old AS (.+)UPDATE products SET price = \\$1, title = \\$2, updated_at = \\$3, version = version \\+ 1 WHERE id = \\$4 AND version = \\$5 (.+)INSERT INTO price_histories (.+)",
+			wantVersion: 3,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.wantQuery == "" {
+				tc.wantQuery = "^WITH old AS (.+)UPDATE products SET (.+)INSERT INTO price_histories (.+)"
+			}
+			if tc.updateErr != nil {
+				mock.ExpectQuery(tc.wantQuery).WillReturnError(tc.updateErr)
+			} else if tc.wantErr == nil {
+				mock.ExpectQuery(tc.wantQuery).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewProductRepository(dbx)
+			product := &entity.Product{Version: 2}
+			err = repo.UpdateProduct(tc.ctx, nil, product, tc.columns)
+			if tc.wantErr != nil {
+				assert.EqualError(t, errors.Cause(err), tc.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantVersion, product.Version)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetInventoryValuations(t *testing.T) {
+	columns := []string{"category", "condition", "count", "qty", "cost_value", "retail_value", "uncosted_count"}
+
+	testcases := []struct {
+		name     string
+		ctx      context.Context
+		fetchErr error
+		expected []*entity.InventoryValuation
+		wantErr  bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name: "success",
+			ctx:  context.Background(),
+			expected: []*entity.InventoryValuation{
+				{Category: types.CategoryBookType, Condition: types.ConditionNewType, ProductCount: 2, Qty: 15, CostValue: 10500, RetailValue: 15000, UncostedProductCount: 1},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery("^SELECT(.+)").WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(columns)
+				for _, valuation := range tc.expected {
+					rows = rows.AddRow(
+						valuation.Category,
+						valuation.Condition,
+						valuation.ProductCount,
+						valuation.Qty,
+						valuation.CostValue,
+						valuation.RetailValue,
+						valuation.UncostedProductCount,
+					)
+				}
+
+				mock.ExpectQuery("^SELECT category, condition, (.+) FROM products WHERE tenant = \\$1 GROUP BY category, condition(.+)").WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewProductRepository(dbx)
+			result, err := repo.GetInventoryValuations(tc.ctx, types.TenantLoremType)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			if !tc.wantErr {
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestUpdateProductQty(t *testing.T) {
 	testcases := []struct {
 		name      string
 		ctx       context.Context
@@ -440,15 +871,181 @@ func TestUpdateProduct(t *testing.T) {
 			defer db.Close()
 
 			if tc.updateErr != nil {
-				mock.ExpectExec("^UPDATE products(.+)").WillReturnError(tc.updateErr)
+				mock.ExpectExec("^UPDATE products SET qty = \\$1, backordered_qty = \\$2, in_transit_qty = \\$3, updated_at = \\$4 WHERE id = \\$5").WillReturnError(tc.updateErr)
 			} else {
-				mock.ExpectExec("^UPDATE products(.+)").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("^UPDATE products SET qty = \\$1, backordered_qty = \\$2, in_transit_qty = \\$3, updated_at = \\$4 WHERE id = \\$5").WithArgs(7, 2, 3, sqlmock.AnyArg(), 1).WillReturnResult(sqlmock.NewResult(1, 1))
 			}
 
 			dbx := sqlx.NewDb(db, "mock")
 			repo := postgres.NewProductRepository(dbx)
-			err = repo.UpdateProduct(tc.ctx, nil, &entity.Product{})
+			err = repo.UpdateProductQty(tc.ctx, nil, &entity.Product{ID: 1, Qty: 7, BackorderedQty: 2, InTransitQty: 3})
 			assert.Equal(t, tc.wantErr, err != nil, err)
 		})
 	}
 }
+
+func TestReserveProductQty(t *testing.T) {
+	testcases := []struct {
+		name         string
+		ctx          context.Context
+		updateErr    error
+		rowsAffected int64
+		wantErr      error
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: errors.New("ProductRepository.ReserveProductQty: context canceled"),
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			updateErr: errors.New("fail exec"),
+			wantErr:   errors.New("ProductRepository.ReserveProductQty: fail exec"),
+		},
+		{
+			name:         "insufficient available qty",
+			ctx:          context.Background(),
+			rowsAffected: 0,
+			wantErr:      response.ErrInsufficientStock,
+		},
+		{
+			name:         "success",
+			ctx:          context.Background(),
+			rowsAffected: 1,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.updateErr != nil {
+				mock.ExpectExec("^UPDATE products SET reserved_qty = reserved_qty \\+ \\$1(.+) AND qty - reserved_qty - backordered_qty - COALESCE(.+) >= \\$1").WillReturnError(tc.updateErr)
+			} else {
+				mock.ExpectExec("^UPDATE products SET reserved_qty = reserved_qty \\+ \\$1(.+) AND qty - reserved_qty - backordered_qty - COALESCE(.+) >= \\$1").WillReturnResult(sqlmock.NewResult(1, tc.rowsAffected))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewProductRepository(dbx)
+			err = repo.ReserveProductQty(tc.ctx, nil, 1, 5)
+			if tc.wantErr != nil {
+				assert.EqualError(t, err, tc.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestReleaseProductQty(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		updateErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			updateErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.updateErr != nil {
+				mock.ExpectExec("^UPDATE products SET reserved_qty = GREATEST(.+)").WillReturnError(tc.updateErr)
+			} else {
+				mock.ExpectExec("^UPDATE products SET reserved_qty = GREATEST(.+)").WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewProductRepository(dbx)
+			err = repo.ReleaseProductQty(tc.ctx, nil, 1, 5)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+		})
+	}
+}
+
+func TestSetProductLowStockAlerted(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		updateErr error
+		affected  int64
+		expected  bool
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			updateErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:     "already in the same state",
+			ctx:      context.Background(),
+			affected: 0,
+			expected: false,
+			wantErr:  false,
+		},
+		{
+			name:     "success",
+			ctx:      context.Background(),
+			affected: 1,
+			expected: true,
+			wantErr:  false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.updateErr != nil {
+				mock.ExpectExec("^UPDATE products SET low_stock_alerted = \\$1 WHERE id = \\$2 AND low_stock_alerted <> \\$1").WillReturnError(tc.updateErr)
+			} else {
+				mock.ExpectExec("^UPDATE products SET low_stock_alerted = \\$1 WHERE id = \\$2 AND low_stock_alerted <> \\$1").WithArgs(true, 1).WillReturnResult(sqlmock.NewResult(0, tc.affected))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewProductRepository(dbx)
+			result, err := repo.SetProductLowStockAlerted(tc.ctx, nil, 1, true)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			if !tc.wantErr {
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
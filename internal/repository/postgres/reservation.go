@@ -0,0 +1,269 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	dbentity "github.com/satriowisnugroho/catalog/internal/repository/postgres/entity"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// ReservationRepositoryInterface define contract for reservation related functions to repository
+type ReservationRepositoryInterface interface {
+	CreateReservation(ctx context.Context, dbTrx interface{}, reservation *entity.Reservation) error
+	CreateReservationItems(ctx context.Context, dbTrx interface{}, reservation *entity.Reservation) error
+	GetReservationByID(ctx context.Context, reservationID int) (*entity.Reservation, error)
+	GetExpiredReservations(ctx context.Context, now time.Time, limit int) ([]*entity.Reservation, error)
+	GetReservationItemsByReservationIDs(ctx context.Context, reservationIDs []int) ([]*entity.ReservationItem, error)
+	UpdateReservationStatus(ctx context.Context, dbTrx interface{}, reservation *entity.Reservation, fromStatus types.ReservationStatusType) error
+}
+
+// ReservationRepository holds database connection
+type ReservationRepository struct {
+	db *sqlx.DB
+}
+
+var (
+	// ReservationTableName hold table name for reservations
+	ReservationTableName = "reservations"
+	// ReservationColumns list all columns on reservations table
+	ReservationColumns = []string{"id", "tenant", "status", "expires_at", "created_at", "updated_at"}
+	// ReservationAttributes hold string format of all reservations table columns
+	ReservationAttributes = strings.Join(ReservationColumns, ", ")
+
+	// ReservationCreationColumns list all columns used for create reservation
+	ReservationCreationColumns = ReservationColumns[1:]
+	// ReservationCreationAttributes hold string format of all creation reservation columns
+	ReservationCreationAttributes = strings.Join(ReservationCreationColumns, ", ")
+
+	// ReservationItemTableName hold table name for reservation items
+	ReservationItemTableName = "reservation_items"
+	// ReservationItemColumns list all columns on reservation items table
+	ReservationItemColumns = []string{"id", "reservation_id", "product_id", "qty"}
+
+	// ReservationItemCreationColumns list all columns used for create reservation item
+	ReservationItemCreationColumns = ReservationItemColumns[1:]
+	// ReservationItemCreationAttributes hold string format of all creation reservation item columns
+	ReservationItemCreationAttributes = strings.Join(ReservationItemCreationColumns, ", ")
+
+	// reservationItemWithSKUAttributes hold string format of reservation items columns joined with the sku of their product
+	reservationItemWithSKUAttributes = fmt.Sprintf(
+		"%[1]s.id, %[1]s.reservation_id, %[1]s.product_id, %[1]s.qty, %[2]s.sku",
+		ReservationItemTableName,
+		ProductTableName,
+	)
+)
+
+// NewReservationRepository create initiate reservation repository with given database
+func NewReservationRepository(db *sqlx.DB) *ReservationRepository {
+	return &ReservationRepository{db: db}
+}
+
+func (r *ReservationRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]*entity.Reservation, error) {
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]*entity.Reservation, 0)
+
+	for rows.Next() {
+		tmpEntity := dbentity.Reservation{}
+		if err := rows.StructScan(&tmpEntity); err != nil {
+			return nil, errors.Wrap(err, "fetch")
+		}
+
+		result = append(result, tmpEntity.ToEntity())
+	}
+
+	return result, nil
+}
+
+func (r *ReservationRepository) fetchReservationItems(ctx context.Context, query string, args ...interface{}) ([]*entity.ReservationItem, error) {
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]*entity.ReservationItem, 0)
+
+	for rows.Next() {
+		tmpEntity := dbentity.ReservationItem{}
+		if err := rows.StructScan(&tmpEntity); err != nil {
+			return nil, errors.Wrap(err, "fetchReservationItems")
+		}
+
+		result = append(result, tmpEntity.ToEntity())
+	}
+
+	return result, nil
+}
+
+// CreateReservation insert reservation data into database
+func (r *ReservationRepository) CreateReservation(ctx context.Context, dbTrx interface{}, reservation *entity.Reservation) error {
+	functionName := "ReservationRepository.CreateReservation"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	now := time.Now()
+	reservation.CreatedAt = now
+	reservation.UpdatedAt = now
+
+	query := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s) RETURNING id`, ReservationTableName, ReservationCreationAttributes, EnumeratedBindvars(ReservationCreationColumns))
+
+	tx := Tx(r.db, dbTrx)
+	err := tx.QueryRowxContext(ctx, query,
+		reservation.Tenant,
+		reservation.Status,
+		reservation.ExpiresAt,
+		reservation.CreatedAt,
+		reservation.UpdatedAt,
+	).Scan(&reservation.ID)
+	if err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// CreateReservationItems insert items of a reservation into database
+func (r *ReservationRepository) CreateReservationItems(ctx context.Context, dbTrx interface{}, reservation *entity.Reservation) error {
+	functionName := "ReservationRepository.CreateReservationItems"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	if len(reservation.Items) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(reservation.Items))
+	args := make([]interface{}, 0, len(reservation.Items)*len(ReservationItemCreationColumns))
+	for _, item := range reservation.Items {
+		item.ReservationID = reservation.ID
+
+		values = append(values, fmt.Sprintf("(%s)", EnumeratedBindvarsFrom(len(args)+1, len(ReservationItemCreationColumns))))
+		args = append(args, item.ReservationID, item.ProductID, item.Qty)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", ReservationItemTableName, ReservationItemCreationAttributes, strings.Join(values, ", "))
+
+	tx := Tx(r.db, dbTrx)
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// GetReservationByID return reservation by id
+func (r *ReservationRepository) GetReservationByID(ctx context.Context, reservationID int) (*entity.Reservation, error) {
+	functionName := "ReservationRepository.GetReservationByID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id = $1 LIMIT 1", ReservationAttributes, ReservationTableName)
+	rows, err := r.fetch(ctx, query, reservationID)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if len(rows) == 0 {
+		return nil, response.ErrNotFound
+	}
+
+	return rows[0], nil
+}
+
+// GetExpiredReservations return active reservations which already expired, from the oldest expiry
+func (r *ReservationRepository) GetExpiredReservations(ctx context.Context, now time.Time, limit int) ([]*entity.Reservation, error) {
+	functionName := "ReservationRepository.GetExpiredReservations"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE status = %d AND expires_at <= $1 ORDER BY expires_at ASC, id ASC LIMIT %d",
+		ReservationAttributes,
+		ReservationTableName,
+		types.ReservationStatusActiveType,
+		limit,
+	)
+	rows, err := r.fetch(ctx, query, now)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return rows, nil
+}
+
+// GetReservationItemsByReservationIDs return items of the given reservations
+func (r *ReservationRepository) GetReservationItemsByReservationIDs(ctx context.Context, reservationIDs []int) ([]*entity.ReservationItem, error) {
+	functionName := "ReservationRepository.GetReservationItemsByReservationIDs"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf(
+		"SELECT %[1]s FROM %[2]s JOIN %[3]s ON %[3]s.id = %[2]s.product_id WHERE %[2]s.reservation_id = ANY($1) ORDER BY %[2]s.id ASC",
+		reservationItemWithSKUAttributes,
+		ReservationItemTableName,
+		ProductTableName,
+	)
+	rows, err := r.fetchReservationItems(ctx, query, pq.Array(reservationIDs))
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return rows, nil
+}
+
+// UpdateReservationStatus update status of a reservation which is still in the given status
+func (r *ReservationRepository) UpdateReservationStatus(ctx context.Context, dbTrx interface{}, reservation *entity.Reservation, fromStatus types.ReservationStatusType) error {
+	functionName := "ReservationRepository.UpdateReservationStatus"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	reservation.UpdatedAt = time.Now()
+
+	query := fmt.Sprintf("UPDATE %s SET status = $1, updated_at = $2 WHERE id = $3 AND status = $4", ReservationTableName)
+
+	tx := Tx(r.db, dbTrx)
+	result, err := tx.ExecContext(ctx, query, reservation.Status, reservation.UpdatedAt, reservation.ID, fromStatus)
+	if err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	// The reservation has been processed by someone else
+	if affected == 0 {
+		return response.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,402 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	"github.com/stretchr/testify/assert"
+)
+
+func reservationRow(rows *sqlmock.Rows, r *entity.Reservation) *sqlmock.Rows {
+	return rows.AddRow(
+		r.ID,
+		r.Tenant,
+		r.Status,
+		r.ExpiresAt,
+		time.Now(),
+		time.Now(),
+	)
+}
+
+func TestCreateReservation(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		createErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			createErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.createErr != nil {
+				mock.ExpectQuery("^INSERT INTO reservations(.+)").WillReturnError(tc.createErr)
+			} else {
+				mock.ExpectQuery("^INSERT INTO reservations(.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewReservationRepository(dbx)
+
+			reservation := &entity.Reservation{}
+			err = repo.CreateReservation(tc.ctx, nil, reservation)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, 1, reservation.ID)
+			}
+		})
+	}
+}
+
+func TestCreateReservationItems(t *testing.T) {
+	testcases := []struct {
+		name        string
+		ctx         context.Context
+		reservation *entity.Reservation
+		createErr   error
+		expectQuery bool
+		wantErr     bool
+	}{
+		{
+			name:        "deadline context",
+			ctx:         fixture.CtxEnded(),
+			reservation: &entity.Reservation{},
+			wantErr:     true,
+		},
+		{
+			name:        "without items",
+			ctx:         context.Background(),
+			reservation: &entity.Reservation{ID: 1},
+			wantErr:     false,
+		},
+		{
+			name:        "fail exec query",
+			ctx:         context.Background(),
+			reservation: &entity.Reservation{ID: 1, Items: []*entity.ReservationItem{{ProductID: 1, Qty: 2}}},
+			createErr:   errors.New("fail exec"),
+			expectQuery: true,
+			wantErr:     true,
+		},
+		{
+			name:        "success",
+			ctx:         context.Background(),
+			reservation: &entity.Reservation{ID: 1, Items: []*entity.ReservationItem{{ProductID: 1, Qty: 2}, {ProductID: 2, Qty: 1}}},
+			expectQuery: true,
+			wantErr:     false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.expectQuery {
+				query := mock.ExpectExec("^INSERT INTO reservation_items \\(reservation_id, product_id, qty\\) VALUES (.+)")
+				if tc.createErr != nil {
+					query.WillReturnError(tc.createErr)
+				} else {
+					query.WillReturnResult(sqlmock.NewResult(1, int64(len(tc.reservation.Items))))
+				}
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewReservationRepository(dbx)
+			err = repo.CreateReservationItems(tc.ctx, nil, tc.reservation)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+			if !tc.wantErr {
+				for _, item := range tc.reservation.Items {
+					assert.Equal(t, tc.reservation.ID, item.ReservationID)
+				}
+			}
+		})
+	}
+}
+
+func TestGetReservationByID(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		fetchErr  error
+		fetchRows []string
+		expected  *entity.Reservation
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "fail fetch return error rows",
+			ctx:       context.Background(),
+			fetchRows: []string{"unknown_column"},
+			wantErr:   true,
+		},
+		{
+			name:      "record not found",
+			ctx:       context.Background(),
+			fetchRows: postgres.ReservationColumns,
+			wantErr:   true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: postgres.ReservationColumns,
+			expected:  &entity.Reservation{ID: 1, Tenant: types.TenantLoremType, Status: types.ReservationStatusActiveType, Items: []*entity.ReservationItem{}},
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery("^SELECT (.+) FROM reservations WHERE id = \\$1(.+)").WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				if tc.expected != nil {
+					rows = reservationRow(rows, tc.expected)
+				} else if len(tc.fetchRows) == 1 {
+					rows = rows.AddRow(1)
+				}
+				mock.ExpectQuery("^SELECT (.+) FROM reservations WHERE id = \\$1(.+)").WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewReservationRepository(dbx)
+			result, err := repo.GetReservationByID(tc.ctx, 1)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			if tc.expected != nil {
+				assert.Equal(t, tc.expected.ID, result.ID)
+				assert.Equal(t, tc.expected.Status, result.Status)
+				assert.Equal(t, tc.expected.Items, result.Items)
+			}
+		})
+	}
+}
+
+func TestGetExpiredReservations(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		fetchErr  error
+		fetchRows []string
+		expected  []*entity.Reservation
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: postgres.ReservationColumns,
+			expected:  []*entity.Reservation{{ID: 1, Tenant: types.TenantLoremType, Status: types.ReservationStatusActiveType}, {ID: 2, Tenant: types.TenantLoremType, Status: types.ReservationStatusActiveType}},
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			query := "^SELECT (.+) FROM reservations WHERE status = 1 AND expires_at <= \\$1 ORDER BY expires_at ASC, id ASC LIMIT 100"
+			if tc.fetchErr != nil {
+				mock.ExpectQuery(query).WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				for _, reservation := range tc.expected {
+					rows = reservationRow(rows, reservation)
+				}
+				mock.ExpectQuery(query).WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewReservationRepository(dbx)
+			result, err := repo.GetExpiredReservations(tc.ctx, time.Now(), 100)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			assert.Equal(t, len(tc.expected), len(result))
+		})
+	}
+}
+
+func TestGetReservationItemsByReservationIDs(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		fetchErr  error
+		fetchRows []string
+		expected  []*entity.ReservationItem
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "fail fetch return error rows",
+			ctx:       context.Background(),
+			fetchRows: []string{"unknown_column"},
+			wantErr:   true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: []string{"id", "reservation_id", "product_id", "qty", "sku"},
+			expected:  []*entity.ReservationItem{{ID: 1, ReservationID: 1, ProductID: 1, SKU: "SKU-1", Qty: 2}},
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			query := "^SELECT (.+) FROM reservation_items JOIN products (.+) WHERE reservation_items.reservation_id = ANY\\(\\$1\\)(.+)"
+			if tc.fetchErr != nil {
+				mock.ExpectQuery(query).WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				for _, item := range tc.expected {
+					rows = rows.AddRow(item.ID, item.ReservationID, item.ProductID, item.Qty, item.SKU)
+				}
+				if len(tc.fetchRows) == 1 {
+					rows = rows.AddRow(1)
+				}
+				mock.ExpectQuery(query).WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewReservationRepository(dbx)
+			result, err := repo.GetReservationItemsByReservationIDs(tc.ctx, []int{1})
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			if !tc.wantErr {
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestUpdateReservationStatus(t *testing.T) {
+	testcases := []struct {
+		name         string
+		ctx          context.Context
+		updateErr    error
+		rowsAffected int64
+		wantErr      bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			updateErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:         "status has been changed",
+			ctx:          context.Background(),
+			rowsAffected: 0,
+			wantErr:      true,
+		},
+		{
+			name:         "success",
+			ctx:          context.Background(),
+			rowsAffected: 1,
+			wantErr:      false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.updateErr != nil {
+				mock.ExpectExec("^UPDATE reservations(.+)").WillReturnError(tc.updateErr)
+			} else {
+				mock.ExpectExec("^UPDATE reservations(.+)").WillReturnResult(sqlmock.NewResult(1, tc.rowsAffected))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewReservationRepository(dbx)
+			err = repo.UpdateReservationStatus(tc.ctx, nil, &entity.Reservation{Status: types.ReservationStatusConfirmedType}, types.ReservationStatusActiveType)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+		})
+	}
+}
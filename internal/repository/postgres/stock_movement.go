@@ -0,0 +1,175 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	dbentity "github.com/satriowisnugroho/catalog/internal/repository/postgres/entity"
+)
+
+// StockMovementRepositoryInterface define contract for stock movement related functions to repository
+type StockMovementRepositoryInterface interface {
+	CreateStockMovements(ctx context.Context, dbTrx interface{}, movements []*entity.StockMovement) error
+	GetStockMovements(ctx context.Context, payload *entity.GetStockMovementPayload) ([]*entity.StockMovement, error)
+	GetStockMovementsCount(ctx context.Context, payload *entity.GetStockMovementPayload) (int, error)
+}
+
+// StockMovementRepository holds database connection
+type StockMovementRepository struct {
+	db *sqlx.DB
+}
+
+var (
+	// StockMovementTableName hold table name for stock movements
+	StockMovementTableName = "stock_movements"
+	// StockMovementColumns list all columns on stock movements table
+	StockMovementColumns = []string{"id", "product_id", "tenant", "delta", "resulting_qty", "reason", "actor", "reference_id", "created_at"}
+	// StockMovementAttributes hold string format of all stock movements table columns
+	StockMovementAttributes = strings.Join(StockMovementColumns, ", ")
+
+	// StockMovementCreationColumns list all columns used for create stock movement
+	StockMovementCreationColumns = StockMovementColumns[1:]
+	// StockMovementCreationAttributes hold string format of all creation stock movement columns
+	StockMovementCreationAttributes = strings.Join(StockMovementCreationColumns, ", ")
+)
+
+// NewStockMovementRepository create initiate stock movement repository with given database
+func NewStockMovementRepository(db *sqlx.DB) *StockMovementRepository {
+	return &StockMovementRepository{db: db}
+}
+
+func (r *StockMovementRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]*entity.StockMovement, error) {
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]*entity.StockMovement, 0)
+
+	for rows.Next() {
+		tmpEntity := dbentity.StockMovement{}
+		if err := rows.StructScan(&tmpEntity); err != nil {
+			return nil, errors.Wrap(err, "fetch")
+		}
+
+		result = append(result, tmpEntity.ToEntity())
+	}
+
+	return result, nil
+}
+
+// CreateStockMovements append the given movements into the ledger
+func (r *StockMovementRepository) CreateStockMovements(ctx context.Context, dbTrx interface{}, movements []*entity.StockMovement) error {
+	functionName := "StockMovementRepository.CreateStockMovements"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	if len(movements) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	values := make([]string, 0, len(movements))
+	args := make([]interface{}, 0, len(movements)*len(StockMovementCreationColumns))
+	for _, movement := range movements {
+		movement.CreatedAt = now
+
+		values = append(values, fmt.Sprintf("(%s)", EnumeratedBindvarsFrom(len(args)+1, len(StockMovementCreationColumns))))
+		args = append(args,
+			movement.ProductID,
+			movement.Tenant,
+			movement.Delta,
+			movement.ResultingQty,
+			movement.Reason,
+			movement.Actor,
+			movement.ReferenceID,
+			movement.CreatedAt,
+		)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", StockMovementTableName, StockMovementCreationAttributes, strings.Join(values, ", "))
+
+	tx := Tx(r.db, dbTrx)
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// GetStockMovements return stock movements of a product from the latest one
+func (r *StockMovementRepository) GetStockMovements(ctx context.Context, payload *entity.GetStockMovementPayload) ([]*entity.StockMovement, error) {
+	functionName := "StockMovementRepository.GetStockMovements"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if payload.Limit == 0 {
+		payload.Limit = 10
+	} else if payload.Limit > 100 {
+		payload.Limit = 100
+	}
+
+	filterQuery, params := r.constructSearchQuery(payload)
+	query := fmt.Sprintf("SELECT %s FROM %s %s ORDER BY created_at DESC, id DESC OFFSET %d LIMIT %d", StockMovementAttributes, StockMovementTableName, filterQuery, payload.Offset, payload.Limit)
+	rows, err := r.fetch(ctx, query, params...)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return rows, nil
+}
+
+// GetStockMovementsCount return count of stock movements of a product
+func (r *StockMovementRepository) GetStockMovementsCount(ctx context.Context, payload *entity.GetStockMovementPayload) (int, error) {
+	functionName := "StockMovementRepository.GetStockMovementsCount"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return 0, errors.Wrap(err, functionName)
+	}
+
+	filterQuery, params := r.constructSearchQuery(payload)
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s %s", StockMovementTableName, filterQuery)
+
+	count := 0
+	row := r.db.QueryRowxContext(ctx, query, params...)
+	if err := row.Scan(&count); err != nil {
+		return count, errors.Wrap(err, functionName)
+	}
+
+	return count, nil
+}
+
+func (r *StockMovementRepository) constructSearchQuery(payload *entity.GetStockMovementPayload) (string, []interface{}) {
+	wheres := []string{"product_id = $1"}
+	params := []interface{}{payload.ProductID}
+
+	if payload.Reason != types.StockMovementReasonEmptyType {
+		params = append(params, payload.Reason)
+		wheres = append(wheres, fmt.Sprintf("reason = $%d", len(params)))
+	}
+
+	if payload.From != nil {
+		params = append(params, *payload.From)
+		wheres = append(wheres, fmt.Sprintf("created_at >= $%d", len(params)))
+	}
+
+	if payload.To != nil {
+		params = append(params, *payload.To)
+		wheres = append(wheres, fmt.Sprintf("created_at <= $%d", len(params)))
+	}
+
+	return fmt.Sprintf("WHERE %s", strings.Join(wheres, " AND ")), params
+}
@@ -0,0 +1,228 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateStockMovements(t *testing.T) {
+	testcases := []struct {
+		name        string
+		ctx         context.Context
+		movements   []*entity.StockMovement
+		createErr   error
+		expectQuery bool
+		wantErr     bool
+	}{
+		{
+			name:      "deadline context",
+			ctx:       fixture.CtxEnded(),
+			movements: []*entity.StockMovement{{ProductID: 1, Delta: -1}},
+			wantErr:   true,
+		},
+		{
+			name:    "without movements",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+		{
+			name:        "fail exec query",
+			ctx:         context.Background(),
+			movements:   []*entity.StockMovement{{ProductID: 1, Delta: -1}},
+			createErr:   errors.New("fail exec"),
+			expectQuery: true,
+			wantErr:     true,
+		},
+		{
+			name:        "success",
+			ctx:         context.Background(),
+			movements:   []*entity.StockMovement{{ProductID: 1, Delta: -1}, {ProductID: 2, Delta: 3}},
+			expectQuery: true,
+			wantErr:     false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.expectQuery {
+				query := mock.ExpectExec("^INSERT INTO stock_movements \\(product_id, tenant, delta, resulting_qty, reason, actor, reference_id, created_at\\) VALUES (.+)")
+				if tc.createErr != nil {
+					query.WillReturnError(tc.createErr)
+				} else {
+					query.WillReturnResult(sqlmock.NewResult(1, int64(len(tc.movements))))
+				}
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewStockMovementRepository(dbx)
+			err = repo.CreateStockMovements(tc.ctx, nil, tc.movements)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+			if !tc.wantErr {
+				for _, movement := range tc.movements {
+					assert.False(t, movement.CreatedAt.IsZero())
+				}
+			}
+		})
+	}
+}
+
+func TestGetStockMovements(t *testing.T) {
+	from := time.Now().Add(-time.Hour)
+	to := time.Now()
+
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		payload   *entity.GetStockMovementPayload
+		fetchErr  error
+		fetchRows []string
+		expected  []*entity.StockMovement
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			payload:  &entity.GetStockMovementPayload{ProductID: 123},
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "fail fetch return error rows",
+			ctx:       context.Background(),
+			payload:   &entity.GetStockMovementPayload{ProductID: 123},
+			fetchRows: []string{"unknown_column"},
+			wantErr:   true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			payload:   &entity.GetStockMovementPayload{ProductID: 123, Reason: types.StockMovementReasonSaleType, From: &from, To: &to},
+			fetchRows: postgres.StockMovementColumns,
+			expected:  []*entity.StockMovement{{ID: 1, ProductID: 123, Tenant: types.TenantLoremType, Delta: -2, ResultingQty: 8, Reason: types.StockMovementReasonSaleType, Actor: "jane", ReferenceID: "ORDER-1"}},
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery("^SELECT(.+)").WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				if tc.expected != nil {
+					rows = rows.AddRow(
+						tc.expected[0].ID,
+						tc.expected[0].ProductID,
+						tc.expected[0].Tenant,
+						tc.expected[0].Delta,
+						tc.expected[0].ResultingQty,
+						tc.expected[0].Reason,
+						tc.expected[0].Actor,
+						tc.expected[0].ReferenceID,
+						tc.expected[0].CreatedAt,
+					)
+				} else if len(tc.fetchRows) == 1 {
+					rows = rows.AddRow(1)
+				}
+
+				mock.ExpectQuery("^SELECT (.+) FROM stock_movements WHERE product_id = \\$1(.+)").WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewStockMovementRepository(dbx)
+			result, err := repo.GetStockMovements(tc.ctx, tc.payload)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			if !tc.wantErr {
+				assert.EqualValues(t, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetStockMovementsCount(t *testing.T) {
+	countColumn := []string{"COUNT(*)"}
+
+	testcases := []struct {
+		name     string
+		ctx      context.Context
+		payload  *entity.GetStockMovementPayload
+		fetchErr error
+		expected int
+		wantErr  bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			payload:  &entity.GetStockMovementPayload{ProductID: 123},
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:     "success",
+			ctx:      context.Background(),
+			payload:  &entity.GetStockMovementPayload{ProductID: 123},
+			expected: 1,
+			wantErr:  false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery("^SELECT(.+)").WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(countColumn)
+				rows = rows.AddRow(tc.expected)
+
+				mock.ExpectQuery("^SELECT COUNT\\(\\*\\) FROM stock_movements WHERE product_id = \\$1").WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewStockMovementRepository(dbx)
+			result, err := repo.GetStockMovementsCount(tc.ctx, tc.payload)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			if !tc.wantErr {
+				assert.EqualValues(t, tc.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,379 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/config"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	dbentity "github.com/satriowisnugroho/catalog/internal/repository/postgres/entity"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// TaxClassRepositoryInterface define contract for tax class related functions to repository
+type TaxClassRepositoryInterface interface {
+	CreateTaxClass(ctx context.Context, dbTrx interface{}, taxClass *entity.TaxClass) error
+	GetTaxClassByID(ctx context.Context, taxClassID int) (*entity.TaxClass, error)
+	GetTaxClasses(ctx context.Context, tenant types.TenantType) ([]*entity.TaxClass, error)
+	GetTaxClassesByIDs(ctx context.Context, taxClassIDs []int) ([]*entity.TaxClass, error)
+	GetTaxRatesByTaxClassIDs(ctx context.Context, taxClassIDs []int) ([]*entity.TaxRate, error)
+	GetCategoryTaxClasses(ctx context.Context, tenant types.TenantType) ([]*entity.CategoryTaxClass, error)
+	UpdateTaxClass(ctx context.Context, dbTrx interface{}, taxClass *entity.TaxClass) error
+	ReplaceTaxRates(ctx context.Context, dbTrx interface{}, taxClass *entity.TaxClass) error
+	ReplaceCategoryTaxClasses(ctx context.Context, dbTrx interface{}, taxClass *entity.TaxClass) error
+}
+
+// TaxClassRepository holds database connection
+type TaxClassRepository struct {
+	db *sqlx.DB
+}
+
+var (
+	// TaxClassTableName hold table name for tax classes
+	TaxClassTableName = "tax_classes"
+	// TaxClassColumns list all columns on tax classes table
+	TaxClassColumns = []string{"id", "tenant", "name", "price_includes_tax", "created_at", "updated_at"}
+	// TaxClassAttributes hold string format of all tax classes table columns
+	TaxClassAttributes = strings.Join(TaxClassColumns, ", ")
+
+	// TaxClassCreationColumns list all columns used for create tax class
+	TaxClassCreationColumns = TaxClassColumns[1:]
+	// TaxClassCreationAttributes hold string format of all creation tax class columns
+	TaxClassCreationAttributes = strings.Join(TaxClassCreationColumns, ", ")
+
+	// TaxRateTableName hold table name for tax rates
+	TaxRateTableName = "tax_rates"
+	// TaxRateColumns list all columns on tax rates table
+	TaxRateColumns = []string{"id", "tax_class_id", "region", "rate", "created_at", "updated_at"}
+	// TaxRateAttributes hold string format of all tax rates table columns
+	TaxRateAttributes = strings.Join(TaxRateColumns, ", ")
+
+	// TaxRateCreationColumns list all columns used for create tax rate
+	TaxRateCreationColumns = TaxRateColumns[1:]
+	// TaxRateCreationAttributes hold string format of all creation tax rate columns
+	TaxRateCreationAttributes = strings.Join(TaxRateCreationColumns, ", ")
+
+	// CategoryTaxClassTableName hold table name for default tax class of categories
+	CategoryTaxClassTableName = "category_tax_classes"
+	// CategoryTaxClassColumns list all columns on category tax classes table
+	CategoryTaxClassColumns = []string{"tenant", "category", "tax_class_id"}
+	// CategoryTaxClassAttributes hold string format of all category tax classes table columns
+	CategoryTaxClassAttributes = strings.Join(CategoryTaxClassColumns, ", ")
+)
+
+// NewTaxClassRepository create initiate tax class repository with given database
+func NewTaxClassRepository(db *sqlx.DB) *TaxClassRepository {
+	return &TaxClassRepository{db: db}
+}
+
+func (r *TaxClassRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]*entity.TaxClass, error) {
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]*entity.TaxClass, 0)
+
+	for rows.Next() {
+		tmpEntity := dbentity.TaxClass{}
+		if err := rows.StructScan(&tmpEntity); err != nil {
+			return nil, errors.Wrap(err, "fetch")
+		}
+
+		result = append(result, tmpEntity.ToEntity())
+	}
+
+	return result, nil
+}
+
+func (r *TaxClassRepository) fetchTaxRates(ctx context.Context, query string, args ...interface{}) ([]*entity.TaxRate, error) {
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]*entity.TaxRate, 0)
+
+	for rows.Next() {
+		tmpEntity := dbentity.TaxRate{}
+		if err := rows.StructScan(&tmpEntity); err != nil {
+			return nil, errors.Wrap(err, "fetchTaxRates")
+		}
+
+		result = append(result, tmpEntity.ToEntity())
+	}
+
+	return result, nil
+}
+
+func (r *TaxClassRepository) fetchCategoryTaxClasses(ctx context.Context, query string, args ...interface{}) ([]*entity.CategoryTaxClass, error) {
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]*entity.CategoryTaxClass, 0)
+
+	for rows.Next() {
+		tmpEntity := dbentity.CategoryTaxClass{}
+		if err := rows.StructScan(&tmpEntity); err != nil {
+			return nil, errors.Wrap(err, "fetchCategoryTaxClasses")
+		}
+
+		result = append(result, tmpEntity.ToEntity())
+	}
+
+	return result, nil
+}
+
+// CreateTaxClass insert tax class data into database
+func (r *TaxClassRepository) CreateTaxClass(ctx context.Context, dbTrx interface{}, taxClass *entity.TaxClass) error {
+	functionName := "TaxClassRepository.CreateTaxClass"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	now := time.Now()
+	taxClass.CreatedAt = now
+	taxClass.UpdatedAt = now
+
+	query := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s) RETURNING id`, TaxClassTableName, TaxClassCreationAttributes, EnumeratedBindvars(TaxClassCreationColumns))
+
+	tx := Tx(r.db, dbTrx)
+	err := tx.QueryRowxContext(ctx, query,
+		taxClass.Tenant,
+		taxClass.Name,
+		taxClass.PriceIncludesTax,
+		taxClass.CreatedAt,
+		taxClass.UpdatedAt,
+	).Scan(&taxClass.ID)
+	if err != nil {
+		if isTaxClassNameTenantViolation(err) {
+			return response.ErrDuplicateTaxClassName
+		}
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// GetTaxClassByID return tax class by id
+func (r *TaxClassRepository) GetTaxClassByID(ctx context.Context, taxClassID int) (*entity.TaxClass, error) {
+	functionName := "TaxClassRepository.GetTaxClassByID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id = $1 LIMIT 1", TaxClassAttributes, TaxClassTableName)
+	rows, err := r.fetch(ctx, query, taxClassID)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if len(rows) == 0 {
+		return nil, response.ErrNotFound
+	}
+
+	return rows[0], nil
+}
+
+// GetTaxClasses return tax classes of a tenant
+func (r *TaxClassRepository) GetTaxClasses(ctx context.Context, tenant types.TenantType) ([]*entity.TaxClass, error) {
+	functionName := "TaxClassRepository.GetTaxClasses"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE tenant = $1 ORDER BY id ASC", TaxClassAttributes, TaxClassTableName)
+	rows, err := r.fetch(ctx, query, tenant)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return rows, nil
+}
+
+// GetTaxClassesByIDs return tax classes of the given ids
+func (r *TaxClassRepository) GetTaxClassesByIDs(ctx context.Context, taxClassIDs []int) ([]*entity.TaxClass, error) {
+	functionName := "TaxClassRepository.GetTaxClassesByIDs"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id = ANY($1) ORDER BY id ASC", TaxClassAttributes, TaxClassTableName)
+	rows, err := r.fetch(ctx, query, pq.Array(taxClassIDs))
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return rows, nil
+}
+
+// GetTaxRatesByTaxClassIDs return tax rates of the given tax classes
+func (r *TaxClassRepository) GetTaxRatesByTaxClassIDs(ctx context.Context, taxClassIDs []int) ([]*entity.TaxRate, error) {
+	functionName := "TaxClassRepository.GetTaxRatesByTaxClassIDs"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE tax_class_id = ANY($1) ORDER BY tax_class_id ASC, region ASC", TaxRateAttributes, TaxRateTableName)
+	rows, err := r.fetchTaxRates(ctx, query, pq.Array(taxClassIDs))
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return rows, nil
+}
+
+// GetCategoryTaxClasses return default tax class of categories of a tenant
+func (r *TaxClassRepository) GetCategoryTaxClasses(ctx context.Context, tenant types.TenantType) ([]*entity.CategoryTaxClass, error) {
+	functionName := "TaxClassRepository.GetCategoryTaxClasses"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE tenant = $1 ORDER BY category ASC", CategoryTaxClassAttributes, CategoryTaxClassTableName)
+	rows, err := r.fetchCategoryTaxClasses(ctx, query, tenant)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return rows, nil
+}
+
+// UpdateTaxClass update a tax class
+func (r *TaxClassRepository) UpdateTaxClass(ctx context.Context, dbTrx interface{}, taxClass *entity.TaxClass) error {
+	functionName := "TaxClassRepository.UpdateTaxClass"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	taxClass.UpdatedAt = time.Now()
+
+	query := fmt.Sprintf(`UPDATE %s SET %s WHERE id = $%d`, TaxClassTableName, UpdateColumnsValues(TaxClassCreationColumns), len(TaxClassColumns))
+
+	tx := Tx(r.db, dbTrx)
+	_, err := tx.ExecContext(
+		ctx,
+		query,
+		taxClass.Tenant,
+		taxClass.Name,
+		taxClass.PriceIncludesTax,
+		taxClass.CreatedAt,
+		taxClass.UpdatedAt,
+		taxClass.ID,
+	)
+	if err != nil {
+		if isTaxClassNameTenantViolation(err) {
+			return response.ErrDuplicateTaxClassName
+		}
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// ReplaceTaxRates replace all rates of a tax class with the given ones
+func (r *TaxClassRepository) ReplaceTaxRates(ctx context.Context, dbTrx interface{}, taxClass *entity.TaxClass) error {
+	functionName := "TaxClassRepository.ReplaceTaxRates"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	tx := Tx(r.db, dbTrx)
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE tax_class_id = $1", TaxRateTableName), taxClass.ID); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	if len(taxClass.Rates) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	values := make([]string, 0, len(taxClass.Rates))
+	args := make([]interface{}, 0, len(taxClass.Rates)*len(TaxRateCreationColumns))
+	for _, rate := range taxClass.Rates {
+		rate.TaxClassID = taxClass.ID
+		rate.CreatedAt = now
+		rate.UpdatedAt = now
+
+		values = append(values, fmt.Sprintf("(%s)", EnumeratedBindvarsFrom(len(args)+1, len(TaxRateCreationColumns))))
+		args = append(args, rate.TaxClassID, rate.Region, rate.Rate, rate.CreatedAt, rate.UpdatedAt)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", TaxRateTableName, TaxRateCreationAttributes, strings.Join(values, ", "))
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// ReplaceCategoryTaxClasses make the tax class the default of exactly the given categories,
+// the categories are taken over from their previous default tax class
+func (r *TaxClassRepository) ReplaceCategoryTaxClasses(ctx context.Context, dbTrx interface{}, taxClass *entity.TaxClass) error {
+	functionName := "TaxClassRepository.ReplaceCategoryTaxClasses"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	tx := Tx(r.db, dbTrx)
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE tax_class_id = $1", CategoryTaxClassTableName), taxClass.ID); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	if len(taxClass.Categories) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(taxClass.Categories))
+	args := make([]interface{}, 0, len(taxClass.Categories)*len(CategoryTaxClassColumns))
+	for _, category := range taxClass.Categories {
+		values = append(values, fmt.Sprintf("(%s)", EnumeratedBindvarsFrom(len(args)+1, len(CategoryTaxClassColumns))))
+		args = append(args, taxClass.Tenant, category, taxClass.ID)
+	}
+
+	query := fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES %s ON CONFLICT (tenant, category) DO UPDATE SET tax_class_id = EXCLUDED.tax_class_id",
+		CategoryTaxClassTableName,
+		CategoryTaxClassAttributes,
+		strings.Join(values, ", "),
+	)
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// isTaxClassNameTenantViolation check whether the error is caused by duplicate tax class name on a tenant
+func isTaxClassNameTenantViolation(err error) bool {
+	postgresError, ok := err.(*pq.Error)
+	if !ok {
+		return false
+	}
+
+	return postgresError.Code == pq.ErrorCode(config.UniqueConstraintViolationCode) && postgresError.Constraint == config.TaxClassNameTenantUniqueConstraint
+}
@@ -0,0 +1,576 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/config"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	"github.com/stretchr/testify/assert"
+)
+
+func taxClassRow(rows *sqlmock.Rows, t *entity.TaxClass) *sqlmock.Rows {
+	return rows.AddRow(
+		t.ID,
+		t.Tenant,
+		t.Name,
+		t.PriceIncludesTax,
+		t.CreatedAt,
+		t.UpdatedAt,
+	)
+}
+
+func TestCreateTaxClass(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		createErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "duplicate name",
+			ctx:       context.Background(),
+			createErr: &pq.Error{Code: pq.ErrorCode(config.UniqueConstraintViolationCode), Constraint: config.TaxClassNameTenantUniqueConstraint},
+			wantErr:   true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			createErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.createErr != nil {
+				mock.ExpectQuery("^INSERT INTO tax_classes(.+)").WillReturnError(tc.createErr)
+			} else {
+				mock.ExpectQuery("^INSERT INTO tax_classes(.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewTaxClassRepository(dbx)
+
+			taxClass := &entity.TaxClass{}
+			err = repo.CreateTaxClass(tc.ctx, nil, taxClass)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, 1, taxClass.ID)
+			}
+		})
+	}
+}
+
+func TestGetTaxClassByID(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		fetchErr  error
+		fetchRows []string
+		expected  *entity.TaxClass
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "fail fetch return error rows",
+			ctx:       context.Background(),
+			fetchRows: []string{"unknown_column"},
+			wantErr:   true,
+		},
+		{
+			name:      "record not found",
+			ctx:       context.Background(),
+			fetchRows: postgres.TaxClassColumns,
+			wantErr:   true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: postgres.TaxClassColumns,
+			expected:  &entity.TaxClass{ID: 1, Tenant: types.TenantLoremType, Name: "standard"},
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery("^SELECT (.+) FROM tax_classes WHERE id = \\$1(.+)").WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				if tc.expected != nil {
+					rows = taxClassRow(rows, tc.expected)
+				} else if len(tc.fetchRows) == 1 {
+					rows = rows.AddRow(1)
+				}
+				mock.ExpectQuery("^SELECT (.+) FROM tax_classes WHERE id = \\$1(.+)").WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewTaxClassRepository(dbx)
+
+			res, err := repo.GetTaxClassByID(tc.ctx, 1)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, tc.expected.Name, res.Name)
+			}
+		})
+	}
+}
+
+func TestGetTaxClasses(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		byIDs     bool
+		fetchErr  error
+		fetchRows []string
+		expected  []*entity.TaxClass
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: postgres.TaxClassColumns,
+			expected:  []*entity.TaxClass{{ID: 1, Tenant: types.TenantLoremType, Name: "standard"}},
+			wantErr:   false,
+		},
+		{
+			name:    "deadline context by ids",
+			ctx:     fixture.CtxEnded(),
+			byIDs:   true,
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error by ids",
+			ctx:      context.Background(),
+			byIDs:    true,
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "success by ids",
+			ctx:       context.Background(),
+			byIDs:     true,
+			fetchRows: postgres.TaxClassColumns,
+			expected:  []*entity.TaxClass{{ID: 1, Tenant: types.TenantLoremType, Name: "standard"}},
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery("^SELECT (.+) FROM tax_classes WHERE (.+)").WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				for _, taxClass := range tc.expected {
+					rows = taxClassRow(rows, taxClass)
+				}
+				mock.ExpectQuery("^SELECT (.+) FROM tax_classes WHERE (.+)").WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewTaxClassRepository(dbx)
+
+			var res []*entity.TaxClass
+			if tc.byIDs {
+				res, err = repo.GetTaxClassesByIDs(tc.ctx, []int{1})
+			} else {
+				res, err = repo.GetTaxClasses(tc.ctx, types.TenantLoremType)
+			}
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, len(tc.expected), len(res))
+			}
+		})
+	}
+}
+
+func TestGetTaxRatesByTaxClassIDs(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		fetchErr  error
+		fetchRows []string
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "fail fetch return error rows",
+			ctx:       context.Background(),
+			fetchRows: []string{"unknown_column"},
+			wantErr:   true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: postgres.TaxRateColumns,
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery("^SELECT (.+) FROM tax_rates WHERE tax_class_id = ANY(.+)").WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				if len(tc.fetchRows) == 1 {
+					rows = rows.AddRow(1)
+				} else if len(tc.fetchRows) > 1 {
+					rows = rows.AddRow(1, 1, "ID", 1100, time.Now(), time.Now())
+				}
+				mock.ExpectQuery("^SELECT (.+) FROM tax_rates WHERE tax_class_id = ANY(.+)").WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewTaxClassRepository(dbx)
+
+			res, err := repo.GetTaxRatesByTaxClassIDs(tc.ctx, []int{1})
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, "ID", res[0].Region)
+				assert.Equal(t, 1100, res[0].Rate)
+			}
+		})
+	}
+}
+
+func TestGetCategoryTaxClasses(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		fetchErr  error
+		fetchRows []string
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "fail fetch return error rows",
+			ctx:       context.Background(),
+			fetchRows: []string{"unknown_column"},
+			wantErr:   true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: postgres.CategoryTaxClassColumns,
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery("^SELECT (.+) FROM category_tax_classes WHERE tenant = \\$1(.+)").WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				if len(tc.fetchRows) == 1 {
+					rows = rows.AddRow(1)
+				} else if len(tc.fetchRows) > 1 {
+					rows = rows.AddRow(types.TenantLoremType, types.CategoryBookType, 1)
+				}
+				mock.ExpectQuery("^SELECT (.+) FROM category_tax_classes WHERE tenant = \\$1(.+)").WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewTaxClassRepository(dbx)
+
+			res, err := repo.GetCategoryTaxClasses(tc.ctx, types.TenantLoremType)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, types.CategoryBookType, res[0].Category)
+				assert.Equal(t, 1, res[0].TaxClassID)
+			}
+		})
+	}
+}
+
+func TestUpdateTaxClass(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		updateErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "duplicate name",
+			ctx:       context.Background(),
+			updateErr: &pq.Error{Code: pq.ErrorCode(config.UniqueConstraintViolationCode), Constraint: config.TaxClassNameTenantUniqueConstraint},
+			wantErr:   true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			updateErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.updateErr != nil {
+				mock.ExpectExec("^UPDATE tax_classes SET (.+)").WillReturnError(tc.updateErr)
+			} else {
+				mock.ExpectExec("^UPDATE tax_classes SET (.+)").WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewTaxClassRepository(dbx)
+
+			err = repo.UpdateTaxClass(tc.ctx, nil, &entity.TaxClass{ID: 1})
+			assert.Equal(t, tc.wantErr, err != nil)
+		})
+	}
+}
+
+func TestReplaceTaxRates(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		rates     []*entity.TaxRate
+		deleteErr error
+		insertErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "fail delete query",
+			ctx:       context.Background(),
+			deleteErr: errors.New("fail delete"),
+			wantErr:   true,
+		},
+		{
+			name:    "success without rates",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+		{
+			name:      "fail insert query",
+			ctx:       context.Background(),
+			rates:     []*entity.TaxRate{{Region: "", Rate: 1000}, {Region: "ID", Rate: 1100}},
+			insertErr: errors.New("fail insert"),
+			wantErr:   true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			rates:   []*entity.TaxRate{{Region: "", Rate: 1000}, {Region: "ID", Rate: 1100}},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.deleteErr != nil {
+				mock.ExpectExec("^DELETE FROM tax_rates WHERE tax_class_id = \\$1").WillReturnError(tc.deleteErr)
+			} else {
+				mock.ExpectExec("^DELETE FROM tax_rates WHERE tax_class_id = \\$1").WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			if tc.insertErr != nil {
+				mock.ExpectExec("^INSERT INTO tax_rates (.+) VALUES \\((.+)\\), \\((.+)\\)").WillReturnError(tc.insertErr)
+			} else {
+				mock.ExpectExec("^INSERT INTO tax_rates (.+) VALUES \\((.+)\\), \\((.+)\\)").WillReturnResult(sqlmock.NewResult(0, 2))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewTaxClassRepository(dbx)
+
+			taxClass := &entity.TaxClass{ID: 1, Rates: tc.rates}
+			err = repo.ReplaceTaxRates(tc.ctx, nil, taxClass)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				for _, rate := range tc.rates {
+					assert.Equal(t, 1, rate.TaxClassID)
+				}
+			}
+		})
+	}
+}
+
+func TestReplaceCategoryTaxClasses(t *testing.T) {
+	testcases := []struct {
+		name       string
+		ctx        context.Context
+		categories []types.CategoryType
+		deleteErr  error
+		insertErr  error
+		wantErr    bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "fail delete query",
+			ctx:       context.Background(),
+			deleteErr: errors.New("fail delete"),
+			wantErr:   true,
+		},
+		{
+			name:    "success without categories",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+		{
+			name:       "fail insert query",
+			ctx:        context.Background(),
+			categories: []types.CategoryType{types.CategoryBookType},
+			insertErr:  errors.New("fail insert"),
+			wantErr:    true,
+		},
+		{
+			name:       "success",
+			ctx:        context.Background(),
+			categories: []types.CategoryType{types.CategoryBookType},
+			wantErr:    false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.deleteErr != nil {
+				mock.ExpectExec("^DELETE FROM category_tax_classes WHERE tax_class_id = \\$1").WillReturnError(tc.deleteErr)
+			} else {
+				mock.ExpectExec("^DELETE FROM category_tax_classes WHERE tax_class_id = \\$1").WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			if tc.insertErr != nil {
+				mock.ExpectExec("^INSERT INTO category_tax_classes (.+) ON CONFLICT (.+)").WillReturnError(tc.insertErr)
+			} else {
+				mock.ExpectExec("^INSERT INTO category_tax_classes (.+) ON CONFLICT (.+)").WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewTaxClassRepository(dbx)
+
+			err = repo.ReplaceCategoryTaxClasses(tc.ctx, nil, &entity.TaxClass{ID: 1, Tenant: types.TenantLoremType, Categories: tc.categories})
+			assert.Equal(t, tc.wantErr, err != nil)
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	dbentity "github.com/satriowisnugroho/catalog/internal/repository/postgres/entity"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// TransferRepositoryInterface define contract for transfer related functions to repository
+type TransferRepositoryInterface interface {
+	CreateTransfer(ctx context.Context, dbTrx interface{}, transfer *entity.Transfer) error
+	CreateTransferItems(ctx context.Context, dbTrx interface{}, transfer *entity.Transfer) error
+	GetTransferByID(ctx context.Context, transferID int) (*entity.Transfer, error)
+	GetTransferItemsByTransferIDs(ctx context.Context, transferIDs []int) ([]*entity.TransferItem, error)
+	UpdateTransferStatus(ctx context.Context, dbTrx interface{}, transfer *entity.Transfer, fromStatus types.TransferStatusType) error
+}
+
+// TransferRepository holds database connection
+type TransferRepository struct {
+	db *sqlx.DB
+}
+
+var (
+	// TransferTableName hold table name for transfers
+	TransferTableName = "transfers"
+	// TransferColumns list all columns on transfers table
+	TransferColumns = []string{"id", "tenant", "from_location_id", "to_location_id", "status", "reference_id", "dispatched_at", "received_at", "created_at", "updated_at"}
+	// TransferAttributes hold string format of all transfers table columns
+	TransferAttributes = strings.Join(TransferColumns, ", ")
+
+	// TransferCreationColumns list all columns used for create transfer
+	TransferCreationColumns = TransferColumns[1:]
+	// TransferCreationAttributes hold string format of all creation transfer columns
+	TransferCreationAttributes = strings.Join(TransferCreationColumns, ", ")
+
+	// TransferItemTableName hold table name for transfer items
+	TransferItemTableName = "transfer_items"
+	// TransferItemColumns list all columns on transfer items table
+	TransferItemColumns = []string{"id", "transfer_id", "product_id", "qty"}
+
+	// TransferItemCreationColumns list all columns used for create transfer item
+	TransferItemCreationColumns = TransferItemColumns[1:]
+	// TransferItemCreationAttributes hold string format of all creation transfer item columns
+	TransferItemCreationAttributes = strings.Join(TransferItemCreationColumns, ", ")
+
+	// transferItemWithSKUAttributes hold string format of transfer items columns joined with the sku of their product
+	transferItemWithSKUAttributes = fmt.Sprintf(
+		"%[1]s.id, %[1]s.transfer_id, %[1]s.product_id, %[1]s.qty, %[2]s.sku",
+		TransferItemTableName,
+		ProductTableName,
+	)
+)
+
+// NewTransferRepository create initiate transfer repository with given database
+func NewTransferRepository(db *sqlx.DB) *TransferRepository {
+	return &TransferRepository{db: db}
+}
+
+func (r *TransferRepository) fetch(ctx context.Context, query string, args ...interface{}) ([]*entity.Transfer, error) {
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]*entity.Transfer, 0)
+
+	for rows.Next() {
+		tmpEntity := dbentity.Transfer{}
+		if err := rows.StructScan(&tmpEntity); err != nil {
+			return nil, errors.Wrap(err, "fetch")
+		}
+
+		result = append(result, tmpEntity.ToEntity())
+	}
+
+	return result, nil
+}
+
+func (r *TransferRepository) fetchTransferItems(ctx context.Context, query string, args ...interface{}) ([]*entity.TransferItem, error) {
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result := make([]*entity.TransferItem, 0)
+
+	for rows.Next() {
+		tmpEntity := dbentity.TransferItem{}
+		if err := rows.StructScan(&tmpEntity); err != nil {
+			return nil, errors.Wrap(err, "fetchTransferItems")
+		}
+
+		result = append(result, tmpEntity.ToEntity())
+	}
+
+	return result, nil
+}
+
+// CreateTransfer insert transfer data into database
+func (r *TransferRepository) CreateTransfer(ctx context.Context, dbTrx interface{}, transfer *entity.Transfer) error {
+	functionName := "TransferRepository.CreateTransfer"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	now := time.Now()
+	transfer.CreatedAt = now
+	transfer.UpdatedAt = now
+
+	query := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s) RETURNING id`, TransferTableName, TransferCreationAttributes, EnumeratedBindvars(TransferCreationColumns))
+
+	tx := Tx(r.db, dbTrx)
+	err := tx.QueryRowxContext(ctx, query,
+		transfer.Tenant,
+		transfer.FromLocationID,
+		transfer.ToLocationID,
+		transfer.Status,
+		transfer.ReferenceID,
+		transfer.DispatchedAt,
+		transfer.ReceivedAt,
+		transfer.CreatedAt,
+		transfer.UpdatedAt,
+	).Scan(&transfer.ID)
+	if err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// CreateTransferItems insert items of a transfer into database
+func (r *TransferRepository) CreateTransferItems(ctx context.Context, dbTrx interface{}, transfer *entity.Transfer) error {
+	functionName := "TransferRepository.CreateTransferItems"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	if len(transfer.Items) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(transfer.Items))
+	args := make([]interface{}, 0, len(transfer.Items)*len(TransferItemCreationColumns))
+	for _, item := range transfer.Items {
+		item.TransferID = transfer.ID
+
+		values = append(values, fmt.Sprintf("(%s)", EnumeratedBindvarsFrom(len(args)+1, len(TransferItemCreationColumns))))
+		args = append(args, item.TransferID, item.ProductID, item.Qty)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", TransferItemTableName, TransferItemCreationAttributes, strings.Join(values, ", "))
+
+	tx := Tx(r.db, dbTrx)
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// GetTransferByID return transfer by id
+func (r *TransferRepository) GetTransferByID(ctx context.Context, transferID int) (*entity.Transfer, error) {
+	functionName := "TransferRepository.GetTransferByID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id = $1 LIMIT 1", TransferAttributes, TransferTableName)
+	rows, err := r.fetch(ctx, query, transferID)
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if len(rows) == 0 {
+		return nil, response.ErrNotFound
+	}
+
+	return rows[0], nil
+}
+
+// GetTransferItemsByTransferIDs return items of the given transfers
+func (r *TransferRepository) GetTransferItemsByTransferIDs(ctx context.Context, transferIDs []int) ([]*entity.TransferItem, error) {
+	functionName := "TransferRepository.GetTransferItemsByTransferIDs"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	query := fmt.Sprintf(
+		"SELECT %[1]s FROM %[2]s JOIN %[3]s ON %[3]s.id = %[2]s.product_id WHERE %[2]s.transfer_id = ANY($1) ORDER BY %[2]s.id ASC",
+		transferItemWithSKUAttributes,
+		TransferItemTableName,
+		ProductTableName,
+	)
+	rows, err := r.fetchTransferItems(ctx, query, pq.Array(transferIDs))
+	if err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return rows, nil
+}
+
+// UpdateTransferStatus update status, dispatched at and received at of a transfer which is still in the given status
+func (r *TransferRepository) UpdateTransferStatus(ctx context.Context, dbTrx interface{}, transfer *entity.Transfer, fromStatus types.TransferStatusType) error {
+	functionName := "TransferRepository.UpdateTransferStatus"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	transfer.UpdatedAt = time.Now()
+
+	query := fmt.Sprintf("UPDATE %s SET status = $1, dispatched_at = $2, received_at = $3, updated_at = $4 WHERE id = $5 AND status = $6", TransferTableName)
+
+	tx := Tx(r.db, dbTrx)
+	result, err := tx.ExecContext(ctx, query, transfer.Status, transfer.DispatchedAt, transfer.ReceivedAt, transfer.UpdatedAt, transfer.ID, fromStatus)
+	if err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	// The transfer has been dispatched or received by someone else
+	if affected == 0 {
+		return response.ErrNotFound
+	}
+
+	return nil
+}
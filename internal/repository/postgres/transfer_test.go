@@ -0,0 +1,349 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	"github.com/stretchr/testify/assert"
+)
+
+func transferRow(rows *sqlmock.Rows, t *entity.Transfer) *sqlmock.Rows {
+	return rows.AddRow(
+		t.ID,
+		t.Tenant,
+		t.FromLocationID,
+		t.ToLocationID,
+		t.Status,
+		t.ReferenceID,
+		t.DispatchedAt,
+		t.ReceivedAt,
+		time.Now(),
+		time.Now(),
+	)
+}
+
+func TestCreateTransfer(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		createErr error
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			createErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.createErr != nil {
+				mock.ExpectQuery("^INSERT INTO transfers(.+)").WillReturnError(tc.createErr)
+			} else {
+				mock.ExpectQuery("^INSERT INTO transfers(.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewTransferRepository(dbx)
+
+			transfer := &entity.Transfer{}
+			err = repo.CreateTransfer(tc.ctx, nil, transfer)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, 1, transfer.ID)
+			}
+		})
+	}
+}
+
+func TestCreateTransferItems(t *testing.T) {
+	testcases := []struct {
+		name        string
+		ctx         context.Context
+		transfer    *entity.Transfer
+		createErr   error
+		expectQuery bool
+		wantErr     bool
+	}{
+		{
+			name:     "deadline context",
+			ctx:      fixture.CtxEnded(),
+			transfer: &entity.Transfer{},
+			wantErr:  true,
+		},
+		{
+			name:     "without items",
+			ctx:      context.Background(),
+			transfer: &entity.Transfer{ID: 1},
+			wantErr:  false,
+		},
+		{
+			name:        "fail exec query",
+			ctx:         context.Background(),
+			transfer:    &entity.Transfer{ID: 1, Items: []*entity.TransferItem{{ProductID: 1, Qty: 2}}},
+			createErr:   errors.New("fail exec"),
+			expectQuery: true,
+			wantErr:     true,
+		},
+		{
+			name:        "success",
+			ctx:         context.Background(),
+			transfer:    &entity.Transfer{ID: 1, Items: []*entity.TransferItem{{ProductID: 1, Qty: 2}, {ProductID: 2, Qty: 3}}},
+			expectQuery: true,
+			wantErr:     false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.expectQuery {
+				query := mock.ExpectExec("^INSERT INTO transfer_items \\(transfer_id, product_id, qty\\) VALUES (.+)")
+				if tc.createErr != nil {
+					query.WillReturnError(tc.createErr)
+				} else {
+					query.WillReturnResult(sqlmock.NewResult(1, int64(len(tc.transfer.Items))))
+				}
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewTransferRepository(dbx)
+			err = repo.CreateTransferItems(tc.ctx, nil, tc.transfer)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+			if !tc.wantErr {
+				for _, item := range tc.transfer.Items {
+					assert.Equal(t, tc.transfer.ID, item.TransferID)
+				}
+			}
+		})
+	}
+}
+
+func TestGetTransferByID(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		fetchErr  error
+		fetchRows []string
+		expected  *entity.Transfer
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "fail fetch return error rows",
+			ctx:       context.Background(),
+			fetchRows: []string{"unknown_column"},
+			wantErr:   true,
+		},
+		{
+			name:      "record not found",
+			ctx:       context.Background(),
+			fetchRows: postgres.TransferColumns,
+			wantErr:   true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: postgres.TransferColumns,
+			expected:  &entity.Transfer{ID: 1, Tenant: types.TenantLoremType, FromLocationID: 1, ToLocationID: 2, Status: types.TransferStatusDraftType, Items: []*entity.TransferItem{}},
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.fetchErr != nil {
+				mock.ExpectQuery("^SELECT (.+) FROM transfers WHERE id = \\$1(.+)").WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				if tc.expected != nil {
+					rows = transferRow(rows, tc.expected)
+				} else if len(tc.fetchRows) == 1 {
+					rows = rows.AddRow(1)
+				}
+				mock.ExpectQuery("^SELECT (.+) FROM transfers WHERE id = \\$1(.+)").WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewTransferRepository(dbx)
+			result, err := repo.GetTransferByID(tc.ctx, 1)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			if tc.expected != nil {
+				assert.Equal(t, tc.expected.ID, result.ID)
+				assert.Equal(t, tc.expected.Status, result.Status)
+				assert.Equal(t, tc.expected.Items, result.Items)
+			}
+		})
+	}
+}
+
+func TestGetTransferItemsByTransferIDs(t *testing.T) {
+	testcases := []struct {
+		name      string
+		ctx       context.Context
+		fetchErr  error
+		fetchRows []string
+		expected  []*entity.TransferItem
+		wantErr   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:     "fail fetch query error",
+			ctx:      context.Background(),
+			fetchErr: errors.New("fail fetch"),
+			wantErr:  true,
+		},
+		{
+			name:      "fail fetch return error rows",
+			ctx:       context.Background(),
+			fetchRows: []string{"unknown_column"},
+			wantErr:   true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			fetchRows: []string{"id", "transfer_id", "product_id", "qty", "sku"},
+			expected:  []*entity.TransferItem{{ID: 1, TransferID: 1, ProductID: 1, SKU: "SKU-1", Qty: 2}},
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			query := "^SELECT (.+) FROM transfer_items JOIN products (.+) WHERE transfer_items.transfer_id = ANY\\(\\$1\\)(.+)"
+			if tc.fetchErr != nil {
+				mock.ExpectQuery(query).WillReturnError(tc.fetchErr)
+			} else {
+				rows := sqlmock.NewRows(tc.fetchRows)
+				for _, item := range tc.expected {
+					rows = rows.AddRow(item.ID, item.TransferID, item.ProductID, item.Qty, item.SKU)
+				}
+				if len(tc.fetchRows) == 1 {
+					rows = rows.AddRow(1)
+				}
+				mock.ExpectQuery(query).WillReturnRows(rows)
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewTransferRepository(dbx)
+			result, err := repo.GetTransferItemsByTransferIDs(tc.ctx, []int{1})
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			if !tc.wantErr {
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestUpdateTransferStatus(t *testing.T) {
+	testcases := []struct {
+		name         string
+		ctx          context.Context
+		updateErr    error
+		rowsAffected int64
+		wantErr      bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:      "fail exec query",
+			ctx:       context.Background(),
+			updateErr: errors.New("fail exec"),
+			wantErr:   true,
+		},
+		{
+			name:         "status has been changed",
+			ctx:          context.Background(),
+			rowsAffected: 0,
+			wantErr:      true,
+		},
+		{
+			name:         "success",
+			ctx:          context.Background(),
+			rowsAffected: 1,
+			wantErr:      false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			if tc.updateErr != nil {
+				mock.ExpectExec("^UPDATE transfers(.+)").WillReturnError(tc.updateErr)
+			} else {
+				mock.ExpectExec("^UPDATE transfers(.+)").WillReturnResult(sqlmock.NewResult(1, tc.rowsAffected))
+			}
+
+			dbx := sqlx.NewDb(db, "mock")
+			repo := postgres.NewTransferRepository(dbx)
+			err = repo.UpdateTransferStatus(tc.ctx, nil, &entity.Transfer{Status: types.TransferStatusInTransitType}, types.TransferStatusDraftType)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+		})
+	}
+}
@@ -81,10 +81,12 @@ func (e ErrorResponse) Error() string {
 
 // MetaInfo holds meta data
 type MetaInfo struct {
-	HTTPStatus int `json:"http_status,omitempty"`
-	Offset     int `json:"offset,omitempty"`
-	Limit      int `json:"limit,omitempty"`
-	Total      int `json:"total,omitempty"`
+	HTTPStatus int    `json:"http_status,omitempty"`
+	Offset     int    `json:"offset,omitempty"`
+	Limit      int    `json:"limit,omitempty"`
+	Total      int    `json:"total,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 const (
@@ -104,6 +106,102 @@ const (
 	ErrorCodeInvalidEnum = 10006
 	// ErrorCodeDuplicateSKUTenant Error code for duplicate sku & tenant
 	ErrorCodeDuplicateSKUTenant = 10006
+	// ErrorCodeInvalidPriceScheduleType Error code for invalid price schedule type
+	ErrorCodeInvalidPriceScheduleType = 10007
+	// ErrorCodeInvalidPrice Error code for invalid price
+	ErrorCodeInvalidPrice = 10008
+	// ErrorCodeInvalidSchedulePeriod Error code for invalid schedule period
+	ErrorCodeInvalidSchedulePeriod = 10009
+	// ErrorCodeOverlappingPromotion Error code for overlapping promotion
+	ErrorCodeOverlappingPromotion = 10010
+	// ErrorCodePriceScheduleNotCancellable Error code for price schedule which can not be cancelled
+	ErrorCodePriceScheduleNotCancellable = 10011
+	// ErrorCodeInvalidDateRange Error code for invalid date range
+	ErrorCodeInvalidDateRange = 10012
+	// ErrorCodeInvalidTaxClassName Error code for invalid tax class name
+	ErrorCodeInvalidTaxClassName = 10013
+	// ErrorCodeInvalidTaxRate Error code for invalid tax rate
+	ErrorCodeInvalidTaxRate = 10014
+	// ErrorCodeInvalidRegion Error code for invalid region
+	ErrorCodeInvalidRegion = 10015
+	// ErrorCodeDuplicateTaxRateRegion Error code for duplicate region on tax rates
+	ErrorCodeDuplicateTaxRateRegion = 10016
+	// ErrorCodeDuplicateTaxClassName Error code for duplicate tax class name & tenant
+	ErrorCodeDuplicateTaxClassName = 10017
+	// ErrorCodeInvalidTaxClass Error code for invalid tax class
+	ErrorCodeInvalidTaxClass = 10018
+	// ErrorCodeInvalidDiscountRuleName Error code for invalid discount rule name
+	ErrorCodeInvalidDiscountRuleName = 10019
+	// ErrorCodeInvalidDiscountType Error code for invalid discount type
+	ErrorCodeInvalidDiscountType = 10020
+	// ErrorCodeInvalidDiscountValue Error code for invalid discount value
+	ErrorCodeInvalidDiscountValue = 10021
+	// ErrorCodeInvalidKeyword Error code for invalid keyword
+	ErrorCodeInvalidKeyword = 10022
+	// ErrorCodeInvalidCostPrice Error code for invalid cost price
+	ErrorCodeInvalidCostPrice = 10023
+	// ErrorCodeInvalidLocationCode Error code for invalid location code
+	ErrorCodeInvalidLocationCode = 10024
+	// ErrorCodeInvalidLocationName Error code for invalid location name
+	ErrorCodeInvalidLocationName = 10025
+	// ErrorCodeDuplicateLocationCode Error code for duplicate location code & tenant
+	ErrorCodeDuplicateLocationCode = 10026
+	// ErrorCodeInvalidLocation Error code for invalid location
+	ErrorCodeInvalidLocation = 10027
+	// ErrorCodeInvalidQty Error code for invalid quantity
+	ErrorCodeInvalidQty = 10028
+	// ErrorCodeInvalidReservationItems Error code for invalid reservation items
+	ErrorCodeInvalidReservationItems = 10029
+	// ErrorCodeInvalidReservationTTL Error code for invalid reservation ttl
+	ErrorCodeInvalidReservationTTL = 10030
+	// ErrorCodeReservationNotActive Error code for reservation which is no longer active
+	ErrorCodeReservationNotActive = 10031
+	// ErrorCodeInvalidStockMovementReason Error code for invalid stock movement reason
+	ErrorCodeInvalidStockMovementReason = 10032
+	// ErrorCodeInvalidIdempotencyKey Error code for invalid idempotency key
+	ErrorCodeInvalidIdempotencyKey = 10033
+	// ErrorCodeIdempotencyKeyMismatch Error code for idempotency key reused with another request
+	ErrorCodeIdempotencyKeyMismatch = 10034
+	// ErrorCodeIdempotencyKeyInProgress Error code for idempotency key whose first request is still processed
+	ErrorCodeIdempotencyKeyInProgress = 10035
+	// ErrorCodeInvalidLowStockThreshold Error code for invalid low stock threshold
+	ErrorCodeInvalidLowStockThreshold = 10036
+	// ErrorCodeInvalidBackorderLimit Error code for invalid backorder limit
+	ErrorCodeInvalidBackorderLimit = 10037
+	// ErrorCodeInvalidCycleCountItems Error code for invalid cycle count items
+	ErrorCodeInvalidCycleCountItems = 10038
+	// ErrorCodeInvalidCycleCountSheet Error code for invalid cycle count sheet
+	ErrorCodeInvalidCycleCountSheet = 10039
+	// ErrorCodeCycleCountNotPending Error code for cycle count which is no longer pending
+	ErrorCodeCycleCountNotPending = 10040
+	// ErrorCodeInvalidLot Error code for invalid lot
+	ErrorCodeInvalidLot = 10041
+	// ErrorCodeInvalidSerialNumbers Error code for invalid serial numbers
+	ErrorCodeInvalidSerialNumbers = 10042
+	// ErrorCodeSerialNumbersRequired Error code for serial numbers required
+	ErrorCodeSerialNumbersRequired = 10043
+	// ErrorCodeSerialNumberConflict Error code for serial number conflict
+	ErrorCodeSerialNumberConflict = 10044
+	// ErrorCodeInvalidSerialStatus Error code for invalid serial status
+	ErrorCodeInvalidSerialStatus = 10045
+	// ErrorCodeInvalidTransferItems Error code for invalid transfer items
+	ErrorCodeInvalidTransferItems = 10046
+	// ErrorCodeInvalidTransferStatus Error code for invalid transfer status
+	ErrorCodeInvalidTransferStatus = 10047
+	// ErrorCodeInvalidPatch Error code for invalid patch
+	ErrorCodeInvalidPatch = 10048
+	// ErrorCodeStaleVersion Error code for stale version
+	ErrorCodeStaleVersion = 10049
+	// ErrorCodeInvalidCursor Error code for invalid cursor
+	ErrorCodeInvalidCursor = 10050
+	// ErrorCodeInvalidStockFilter Error code for invalid stock filter
+	ErrorCodeInvalidStockFilter = 10051
+	// ErrorCodeInvalidSort Error code for invalid sort
+	ErrorCodeInvalidSort = 10052
+	// ErrorCodeInvalidFields Error code for invalid fields
+	ErrorCodeInvalidFields = 10053
+	// ErrorCodeInvalidInclude Error code for invalid include
+	ErrorCodeInvalidInclude = 10054
 
 	// ErrorCodeNoSQLTransactionFound Error code for no sql transaction found
 	ErrorCodeNoSQLTransactionFound = 9000
@@ -160,6 +258,295 @@ var (
 		Code:     ErrorCodeDuplicateSKUTenant,
 		HTTPCode: http.StatusUnprocessableEntity,
 	}
+	// ErrInvalidPriceScheduleType define error when invalid price schedule type
+	ErrInvalidPriceScheduleType = CustomError{
+		Message:  "Invalid price schedule type",
+		Code:     ErrorCodeInvalidPriceScheduleType,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidPrice define error when invalid price
+	ErrInvalidPrice = CustomError{
+		Message:  "Invalid price",
+		Code:     ErrorCodeInvalidPrice,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidSchedulePeriod define error when invalid schedule period
+	ErrInvalidSchedulePeriod = CustomError{
+		Message:  "Invalid schedule period",
+		Code:     ErrorCodeInvalidSchedulePeriod,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrOverlappingPromotion define error when promotion period overlaps another promotion
+	ErrOverlappingPromotion = CustomError{
+		Message:  "Promotion period overlaps another promotion",
+		Code:     ErrorCodeOverlappingPromotion,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrPriceScheduleNotCancellable define error when price schedule is already completed or cancelled
+	ErrPriceScheduleNotCancellable = CustomError{
+		Message:  "Price schedule can not be cancelled",
+		Code:     ErrorCodePriceScheduleNotCancellable,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidDateRange define error when invalid date range
+	ErrInvalidDateRange = CustomError{
+		Message:  "Invalid date range",
+		Code:     ErrorCodeInvalidDateRange,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidTaxClassName define error when invalid tax class name
+	ErrInvalidTaxClassName = CustomError{
+		Message:  "Invalid tax class name",
+		Code:     ErrorCodeInvalidTaxClassName,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidTaxRate define error when invalid tax rate
+	ErrInvalidTaxRate = CustomError{
+		Message:  "Invalid tax rate",
+		Code:     ErrorCodeInvalidTaxRate,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidRegion define error when invalid region
+	ErrInvalidRegion = CustomError{
+		Message:  "Invalid region",
+		Code:     ErrorCodeInvalidRegion,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrDuplicateTaxRateRegion define error when a tax class has more than one rate for a region
+	ErrDuplicateTaxRateRegion = CustomError{
+		Message:  "Duplicate tax rate region",
+		Code:     ErrorCodeDuplicateTaxRateRegion,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrDuplicateTaxClassName define error when tax class name & tenant is duplicate
+	ErrDuplicateTaxClassName = CustomError{
+		Message:  "Duplicate tax class name and tenant",
+		Code:     ErrorCodeDuplicateTaxClassName,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidTaxClass define error when tax class does not exist on the tenant
+	ErrInvalidTaxClass = CustomError{
+		Message:  "Invalid tax class",
+		Code:     ErrorCodeInvalidTaxClass,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidDiscountRuleName define error when invalid discount rule name
+	ErrInvalidDiscountRuleName = CustomError{
+		Message:  "Invalid discount rule name",
+		Code:     ErrorCodeInvalidDiscountRuleName,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidDiscountType define error when invalid discount type
+	ErrInvalidDiscountType = CustomError{
+		Message:  "Invalid discount type",
+		Code:     ErrorCodeInvalidDiscountType,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidDiscountValue define error when invalid discount value
+	ErrInvalidDiscountValue = CustomError{
+		Message:  "Invalid discount value",
+		Code:     ErrorCodeInvalidDiscountValue,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidKeyword define error when keyword is too short
+	ErrInvalidKeyword = CustomError{
+		Message:  "Invalid keyword",
+		Code:     ErrorCodeInvalidKeyword,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidCostPrice define error when cost price is missing or negative
+	ErrInvalidCostPrice = CustomError{
+		Message:  "Invalid cost price",
+		Code:     ErrorCodeInvalidCostPrice,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidLocationCode define error when invalid location code
+	ErrInvalidLocationCode = CustomError{
+		Message:  "Invalid location code",
+		Code:     ErrorCodeInvalidLocationCode,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidLocationName define error when location name is empty
+	ErrInvalidLocationName = CustomError{
+		Message:  "Invalid location name",
+		Code:     ErrorCodeInvalidLocationName,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrDuplicateLocationCode define error when duplicate location code & tenant
+	ErrDuplicateLocationCode = CustomError{
+		Message:  "Duplicate location code",
+		Code:     ErrorCodeDuplicateLocationCode,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidLocation define error when location is not found, belongs to another tenant or given twice
+	ErrInvalidLocation = CustomError{
+		Message:  "Invalid location",
+		Code:     ErrorCodeInvalidLocation,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidQty define error when invalid quantity
+	ErrInvalidQty = CustomError{
+		Message:  "Invalid quantity",
+		Code:     ErrorCodeInvalidQty,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidReservationItems define error when reservation has no item or item without sku
+	ErrInvalidReservationItems = CustomError{
+		Message:  "Invalid reservation items",
+		Code:     ErrorCodeInvalidReservationItems,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidReservationTTL define error when reservation ttl is negative or too long
+	ErrInvalidReservationTTL = CustomError{
+		Message:  "Invalid reservation ttl",
+		Code:     ErrorCodeInvalidReservationTTL,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrReservationNotActive define error when reservation is already confirmed, released or expired
+	ErrReservationNotActive = CustomError{
+		Message:  "Reservation is not active",
+		Code:     ErrorCodeReservationNotActive,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidStockMovementReason define error when stock movement reason is unknown or does not match the qty change
+	ErrInvalidStockMovementReason = CustomError{
+		Message:  "Invalid stock movement reason",
+		Code:     ErrorCodeInvalidStockMovementReason,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidIdempotencyKey define error when idempotency key is blank or too long
+	ErrInvalidIdempotencyKey = CustomError{
+		Message:  "Invalid idempotency key",
+		Code:     ErrorCodeInvalidIdempotencyKey,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrIdempotencyKeyMismatch define error when idempotency key is already used by a request with another body
+	ErrIdempotencyKeyMismatch = CustomError{
+		Message:  "Idempotency key is already used by another request",
+		Code:     ErrorCodeIdempotencyKeyMismatch,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrIdempotencyKeyInProgress define error when the first request of the idempotency key is still processed
+	ErrIdempotencyKeyInProgress = CustomError{
+		Message:  "Request with the same idempotency key is still in progress",
+		Code:     ErrorCodeIdempotencyKeyInProgress,
+		HTTPCode: http.StatusConflict,
+	}
+	// ErrInvalidLowStockThreshold define error when low stock threshold is negative
+	ErrInvalidLowStockThreshold = CustomError{
+		Message:  "Invalid low stock threshold",
+		Code:     ErrorCodeInvalidLowStockThreshold,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidBackorderLimit define error when backorder limit does not match the backorder policy
+	ErrInvalidBackorderLimit = CustomError{
+		Message:  "Invalid backorder limit",
+		Code:     ErrorCodeInvalidBackorderLimit,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidCycleCountItems define error when cycle count has no items, an item without sku or a sku counted twice
+	ErrInvalidCycleCountItems = CustomError{
+		Message:  "Invalid cycle count items",
+		Code:     ErrorCodeInvalidCycleCountItems,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidCycleCountSheet define error when the uploaded cycle count sheet can not be read
+	ErrInvalidCycleCountSheet = CustomError{
+		Message:  "Invalid cycle count sheet",
+		Code:     ErrorCodeInvalidCycleCountSheet,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrCycleCountNotPending define error when cycle count is already approved or rejected
+	ErrCycleCountNotPending = CustomError{
+		Message:  "Cycle count is not pending",
+		Code:     ErrorCodeCycleCountNotPending,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidLot define error when lot number is given without expiry date or the other way around
+	ErrInvalidLot = CustomError{
+		Message:  "Invalid lot",
+		Code:     ErrorCodeInvalidLot,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidSerialNumbers define error when the serial numbers don't match the qty or the product,
+	// or some of them are not in stock
+	ErrInvalidSerialNumbers = CustomError{
+		Message:  "Invalid serial numbers",
+		Code:     ErrorCodeInvalidSerialNumbers,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrSerialNumbersRequired define error when the qty of a serialized product is increased without serial numbers
+	ErrSerialNumbersRequired = CustomError{
+		Message:  "Serial numbers are required",
+		Code:     ErrorCodeSerialNumbersRequired,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrSerialNumberConflict define error when the serial number is already in stock or registered to another product
+	ErrSerialNumberConflict = CustomError{
+		Message:  "Serial number is already registered",
+		Code:     ErrorCodeSerialNumberConflict,
+		HTTPCode: http.StatusConflict,
+	}
+	// ErrInvalidSerialStatus define error when serial status is not one of in_stock, sold or written_off
+	ErrInvalidSerialStatus = CustomError{
+		Message:  "Invalid serial status",
+		Code:     ErrorCodeInvalidSerialStatus,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidTransferItems define error when transfer has no items, an item without sku or a sku transferred twice
+	ErrInvalidTransferItems = CustomError{
+		Message:  "Invalid transfer items",
+		Code:     ErrorCodeInvalidTransferItems,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidTransferStatus define error when transfer is dispatched out of draft or received out of in transit
+	ErrInvalidTransferStatus = CustomError{
+		Message:  "Invalid transfer status",
+		Code:     ErrorCodeInvalidTransferStatus,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidPatch define error when a patch is not a json object, or it changes a field which can not be patched or removes a required field
+	ErrInvalidPatch = CustomError{
+		Message:  "Invalid patch",
+		Code:     ErrorCodeInvalidPatch,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrStaleVersion define error when the product has been changed since the version the change is based on
+	ErrStaleVersion = CustomError{
+		Message:  "The product has been changed by another request, get the latest version and apply the change again",
+		Code:     ErrorCodeStaleVersion,
+		HTTPCode: http.StatusPreconditionFailed,
+	}
+	// ErrInvalidCursor define error when a cursor is not one given by the product list
+	ErrInvalidCursor = CustomError{
+		Message:  "Invalid cursor",
+		Code:     ErrorCodeInvalidCursor,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidStockFilter define error when the stock filter of the product list is not low, in or out
+	ErrInvalidStockFilter = CustomError{
+		Message:  "Invalid stock filter",
+		Code:     ErrorCodeInvalidStockFilter,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidSort define error when the product list is sorted by a field which can not be sorted by, or a field is sorted twice
+	ErrInvalidSort = CustomError{
+		Message:  "Invalid sort",
+		Code:     ErrorCodeInvalidSort,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidFields define error when a selected field is not a field of the product response
+	ErrInvalidFields = CustomError{
+		Message:  "Invalid fields",
+		Code:     ErrorCodeInvalidFields,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
+	// ErrInvalidInclude define error when an included relation can not be embedded in the product response
+	ErrInvalidInclude = CustomError{
+		Message:  "Invalid include",
+		Code:     ErrorCodeInvalidInclude,
+		HTTPCode: http.StatusUnprocessableEntity,
+	}
 
 	// ErrNoSQLTransactionFound defines no sql transaction when do the db transaction
 	ErrNoSQLTransactionFound = CustomError{
@@ -195,6 +582,19 @@ func OKWithPagination(c *gin.Context, data interface{}, message string, total, o
 	c.JSON(http.StatusOK, successResponse)
 }
 
+// OKWithCursors wrap success response with pagination meta and the cursors of the pages around it
+func OKWithCursors(c *gin.Context, data interface{}, message string, total, offset, limit int, nextCursor, prevCursor string) {
+	successResponse := BuildSuccess(data, message, MetaInfo{
+		HTTPStatus: http.StatusOK,
+		Total:      total,
+		Offset:     offset,
+		Limit:      limit,
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
+	})
+	c.JSON(http.StatusOK, successResponse)
+}
+
 // InternalServerErrorBody for default internal server error
 func InternalServerErrorBody() ErrorBody {
 	return ErrorBody{
@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+)
+
+// batchError hold the errors of the items of a batch which failed to be processed,
+// the failed items do not hold back the other items of the batch
+type batchError []error
+
+func (e batchError) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return fmt.Sprintf("%d items of the batch failed: %s", len(e), strings.Join(messages, "; "))
+}
+
+// errOrNil return the batch error when any of the items failed
+func (e batchError) errOrNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+
+	return e
+}
@@ -0,0 +1,326 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	repo "github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// CycleCountUsecaseInterface define contract for cycle count related functions to usecase
+type CycleCountUsecaseInterface interface {
+	CreateCycleCount(ctx context.Context, payload *entity.CycleCountPayload) (*entity.CycleCount, error)
+	GetCycleCountByID(ctx context.Context, tenant types.TenantType, cycleCountID int) (*entity.CycleCount, error)
+	ApproveCycleCount(ctx context.Context, tenant types.TenantType, cycleCountID int, actor string) (*entity.CycleCount, error)
+	RejectCycleCount(ctx context.Context, tenant types.TenantType, cycleCountID int) (*entity.CycleCount, error)
+}
+
+type CycleCountUsecase struct {
+	repo               repo.CycleCountRepositoryInterface
+	productRepo        repo.ProductRepositoryInterface
+	locationRepo       repo.LocationRepositoryInterface
+	productStockRepo   repo.ProductStockRepositoryInterface
+	stockMovementRepo  repo.StockMovementRepositoryInterface
+	lowStockRepo       repo.LowStockRepositoryInterface
+	dbTransactionRepo  repo.PostgresTransactionRepositoryInterface
+	allocationStrategy types.AllocationStrategyType
+}
+
+func NewCycleCountUsecase(
+	r repo.CycleCountRepositoryInterface,
+	rProduct repo.ProductRepositoryInterface,
+	rLocation repo.LocationRepositoryInterface,
+	rProductStock repo.ProductStockRepositoryInterface,
+	rStockMovement repo.StockMovementRepositoryInterface,
+	rLowStock repo.LowStockRepositoryInterface,
+	rPgTrx repo.PostgresTransactionRepositoryInterface,
+	allocationStrategy types.AllocationStrategyType,
+) *CycleCountUsecase {
+	return &CycleCountUsecase{
+		repo:               r,
+		productRepo:        rProduct,
+		locationRepo:       rLocation,
+		productStockRepo:   rProductStock,
+		stockMovementRepo:  rStockMovement,
+		lowStockRepo:       rLowStock,
+		dbTransactionRepo:  rPgTrx,
+		allocationStrategy: allocationStrategy,
+	}
+}
+
+// CreateCycleCount save the counted qty of the products as a pending cycle count
+// with the diff of each counted qty against the current qty of the product
+func (uc *CycleCountUsecase) CreateCycleCount(ctx context.Context, payload *entity.CycleCountPayload) (*entity.CycleCount, error) {
+	functionName := "CycleCountUsecase.CreateCycleCount"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	cycleCount := payload.ToEntity()
+	for _, item := range cycleCount.Items {
+		product, err := uc.productRepo.GetProductBySKU(ctx, item.SKU)
+		if err != nil {
+			if err == response.ErrNotFound {
+				return nil, err
+			}
+
+			return nil, errors.Wrap(fmt.Errorf("uc.productRepo.GetProductBySKU: %w", err), functionName)
+		}
+
+		if product.Tenant != cycleCount.Tenant {
+			return nil, response.ErrForbidden
+		}
+
+		item.ProductID = product.ID
+		item.SetSystemQty(product.Qty)
+	}
+
+	// Begin transaction
+	tx, err := uc.dbTransactionRepo.StartTransactionQuery(ctx)
+	if err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.dbTransactionRepo.StartTransactionQuery: %w", err), functionName)
+	}
+
+	// Create flag and defer rollback when flag is true
+	rollbackProcess := true
+	defer func() {
+		if rollbackProcess {
+			uc.dbTransactionRepo.RollbackTransactionQuery(ctx, tx)
+		}
+	}()
+
+	if err := uc.repo.CreateCycleCount(ctx, tx, cycleCount); err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.CreateCycleCount: %w", err), functionName)
+	}
+
+	if err := uc.repo.CreateCycleCountItems(ctx, tx, cycleCount); err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.CreateCycleCountItems: %w", err), functionName)
+	}
+
+	// Commit transaction
+	if err = uc.dbTransactionRepo.CommitTransactionQuery(ctx, tx); err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.dbTransactionRepo.CommitTransactionQuery: %w", err), functionName)
+	}
+	rollbackProcess = false
+
+	return cycleCount, nil
+}
+
+func (uc *CycleCountUsecase) GetCycleCountByID(ctx context.Context, tenant types.TenantType, cycleCountID int) (*entity.CycleCount, error) {
+	functionName := "CycleCountUsecase.GetCycleCountByID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return uc.getCycleCount(ctx, tenant, cycleCountID)
+}
+
+// ApproveCycleCount apply the diff of each item of a pending cycle count to the qty of its product,
+// the adjustments are recorded on the stock movements referencing the cycle count.
+// The diff against the qty when the products were counted is applied, so the qty changes since the count are kept
+func (uc *CycleCountUsecase) ApproveCycleCount(ctx context.Context, tenant types.TenantType, cycleCountID int, actor string) (*entity.CycleCount, error) {
+	functionName := "CycleCountUsecase.ApproveCycleCount"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	cycleCount, err := uc.getCycleCount(ctx, tenant, cycleCountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cycleCount.Status != types.CycleCountStatusPendingType {
+		return nil, response.ErrCycleCountNotPending
+	}
+
+	if err := retryTransaction(ctx, func() error { return uc.approveCycleCount(ctx, cycleCount, actor) }); err != nil {
+		if err == response.ErrNotFound {
+			return nil, response.ErrCycleCountNotPending
+		}
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return cycleCount, nil
+}
+
+// approveCycleCount move a pending cycle count to approved, apply the diff of its items to the stock
+// and record them on the stock movements in one transaction
+func (uc *CycleCountUsecase) approveCycleCount(ctx context.Context, cycleCount *entity.CycleCount, actor string) error {
+	// Begin transaction
+	tx, err := uc.dbTransactionRepo.StartTransactionQuery(ctx)
+	if err != nil {
+		return fmt.Errorf("uc.dbTransactionRepo.StartTransactionQuery: %w", err)
+	}
+
+	// Create flag and defer rollback when flag is true
+	rollbackProcess := true
+	defer func() {
+		if rollbackProcess {
+			uc.dbTransactionRepo.RollbackTransactionQuery(ctx, tx)
+		}
+	}()
+
+	cycleCount.Status = types.CycleCountStatusApprovedType
+	if err := uc.repo.UpdateCycleCountStatus(ctx, tx, cycleCount, types.CycleCountStatusPendingType); err != nil {
+		if err == response.ErrNotFound {
+			return err
+		}
+
+		return fmt.Errorf("uc.repo.UpdateCycleCountStatus: %w", err)
+	}
+
+	items := cycleCount.ItemsByProductID()
+	productIDs := make([]int, 0, len(items))
+	for _, item := range items {
+		if item.DiffQty != 0 {
+			productIDs = append(productIDs, item.ProductID)
+		}
+	}
+
+	// The products are read after they are locked so that the diff is applied on their latest qty
+	products, err := uc.productRepo.GetProductsForUpdate(ctx, tx, cycleCount.Tenant, productIDs, nil)
+	if err != nil {
+		return fmt.Errorf("uc.productRepo.GetProductsForUpdate: %w", err)
+	}
+
+	productByID := make(map[int]*entity.Product, len(products))
+	for _, product := range products {
+		productByID[product.ID] = product
+	}
+
+	referenceID := fmt.Sprintf("cycle-count:%d", cycleCount.ID)
+	movements := make([]*entity.StockMovement, 0, len(productIDs))
+	for _, item := range items {
+		if item.DiffQty == 0 {
+			continue
+		}
+
+		product, ok := productByID[item.ProductID]
+		if !ok {
+			return fmt.Errorf("product %d of the cycle count is not found", item.ProductID)
+		}
+
+		if item.DiffQty < 0 {
+			if err := deductStocks(ctx, uc.productStockRepo, tx, product, -item.DiffQty, nil, uc.allocationStrategy, true); err != nil {
+				return err
+			}
+
+			if err := deductSerials(ctx, uc.productStockRepo, tx, product, -item.DiffQty, nil, types.SerialStatusWrittenOffType, actor, referenceID); err != nil {
+				return err
+			}
+		} else {
+			// The found units of a serialized product are added without serial numbers as they are not known from the count
+			stocks, err := uc.productStockRepo.GetProductStocksForUpdate(ctx, tx, product.ID)
+			if err != nil {
+				return fmt.Errorf("uc.productStockRepo.GetProductStocksForUpdate: %w", err)
+			}
+
+			stocks, err = addStocks(ctx, uc.locationRepo, uc.productStockRepo, tx, product, stocks, item.DiffQty, nil)
+			if err != nil {
+				return err
+			}
+			product.Qty = entity.TotalStock(stocks)
+		}
+
+		movements = append(movements, entity.NewStockMovement(product, item.DiffQty, types.StockMovementReasonAdjustmentType, actor, referenceID))
+
+		if item.DiffQty > 0 {
+			movement, err := fillBackorders(ctx, uc.productStockRepo, tx, product, item.DiffQty, nil, uc.allocationStrategy, actor, referenceID)
+			if err != nil {
+				return err
+			}
+			if movement != nil {
+				movements = append(movements, movement)
+			}
+		}
+
+		if err := uc.productRepo.UpdateProductQty(ctx, tx, product); err != nil {
+			return fmt.Errorf("uc.productRepo.UpdateProductQty: %w", err)
+		}
+
+		if err := recordLowStock(ctx, uc.productRepo, uc.lowStockRepo, tx, product); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.stockMovementRepo.CreateStockMovements(ctx, tx, movements); err != nil {
+		return fmt.Errorf("uc.stockMovementRepo.CreateStockMovements: %w", err)
+	}
+
+	// Commit transaction
+	if err = uc.dbTransactionRepo.CommitTransactionQuery(ctx, tx); err != nil {
+		return fmt.Errorf("uc.dbTransactionRepo.CommitTransactionQuery: %w", err)
+	}
+	rollbackProcess = false
+
+	return nil
+}
+
+// RejectCycleCount move a pending cycle count to rejected without changing the qty of its products
+func (uc *CycleCountUsecase) RejectCycleCount(ctx context.Context, tenant types.TenantType, cycleCountID int) (*entity.CycleCount, error) {
+	functionName := "CycleCountUsecase.RejectCycleCount"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	cycleCount, err := uc.getCycleCount(ctx, tenant, cycleCountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cycleCount.Status != types.CycleCountStatusPendingType {
+		return nil, response.ErrCycleCountNotPending
+	}
+
+	cycleCount.Status = types.CycleCountStatusRejectedType
+	if err := uc.repo.UpdateCycleCountStatus(ctx, nil, cycleCount, types.CycleCountStatusPendingType); err != nil {
+		if err == response.ErrNotFound {
+			return nil, response.ErrCycleCountNotPending
+		}
+
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.UpdateCycleCountStatus: %w", err), functionName)
+	}
+
+	return cycleCount, nil
+}
+
+func (uc *CycleCountUsecase) getCycleCount(ctx context.Context, tenant types.TenantType, cycleCountID int) (*entity.CycleCount, error) {
+	functionName := "CycleCountUsecase.getCycleCount"
+
+	cycleCount, err := uc.repo.GetCycleCountByID(ctx, cycleCountID)
+	if err != nil {
+		if err == response.ErrNotFound {
+			return nil, err
+		}
+
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.GetCycleCountByID: %w", err), functionName)
+	}
+
+	if cycleCount.Tenant != tenant {
+		return nil, response.ErrForbidden
+	}
+
+	items, err := uc.repo.GetCycleCountItemsByCycleCountIDs(ctx, []int{cycleCount.ID})
+	if err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.GetCycleCountItemsByCycleCountIDs: %w", err), functionName)
+	}
+	cycleCount.Items = items
+
+	return cycleCount, nil
+}
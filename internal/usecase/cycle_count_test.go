@@ -0,0 +1,496 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func validCycleCountPayload() *entity.CycleCountPayload {
+	return &entity.CycleCountPayload{
+		Items:  []entity.CycleCountItemPayload{{SKU: "SKU-1", CountedQty: 7}},
+		Tenant: types.TenantLoremType,
+	}
+}
+
+func pendingCycleCount() *entity.CycleCount {
+	return &entity.CycleCount{
+		ID:     1,
+		Tenant: types.TenantLoremType,
+		Status: types.CycleCountStatusPendingType,
+		Items:  []*entity.CycleCountItem{},
+	}
+}
+
+func TestCreateCycleCount(t *testing.T) {
+	testcases := []struct {
+		name           string
+		ctx            context.Context
+		payload        *entity.CycleCountPayload
+		rGetProductRes *entity.Product
+		rGetProductErr error
+		rStartTrxErr   error
+		rCreateErr     error
+		rCreateItemErr error
+		rCommitTrxErr  error
+		wantErr        bool
+		wantCustomErr  error
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "invalid payload",
+			ctx:           context.Background(),
+			payload:       &entity.CycleCountPayload{Tenant: types.TenantLoremType},
+			wantErr:       true,
+			wantCustomErr: response.ErrInvalidCycleCountItems,
+		},
+		{
+			name:           "product not found",
+			ctx:            context.Background(),
+			payload:        validCycleCountPayload(),
+			rGetProductErr: response.ErrNotFound,
+			wantErr:        true,
+			wantCustomErr:  response.ErrNotFound,
+		},
+		{
+			name:           "failed to get product",
+			ctx:            context.Background(),
+			payload:        validCycleCountPayload(),
+			rGetProductErr: errors.New("error get product"),
+			wantErr:        true,
+		},
+		{
+			name:           "product of other tenant",
+			ctx:            context.Background(),
+			payload:        validCycleCountPayload(),
+			rGetProductRes: &entity.Product{ID: 1, Tenant: types.TenantIpsumType, Qty: 10},
+			wantErr:        true,
+			wantCustomErr:  response.ErrForbidden,
+		},
+		{
+			name:           "failed to start transaction",
+			ctx:            context.Background(),
+			payload:        validCycleCountPayload(),
+			rGetProductRes: &entity.Product{ID: 1, Tenant: types.TenantLoremType, Qty: 10},
+			rStartTrxErr:   response.ErrNoSQLTransactionFound,
+			wantErr:        true,
+		},
+		{
+			name:           "failed to create cycle count",
+			ctx:            context.Background(),
+			payload:        validCycleCountPayload(),
+			rGetProductRes: &entity.Product{ID: 1, Tenant: types.TenantLoremType, Qty: 10},
+			rCreateErr:     errors.New("error create cycle count"),
+			wantErr:        true,
+		},
+		{
+			name:           "failed to create cycle count items",
+			ctx:            context.Background(),
+			payload:        validCycleCountPayload(),
+			rGetProductRes: &entity.Product{ID: 1, Tenant: types.TenantLoremType, Qty: 10},
+			rCreateItemErr: errors.New("error create cycle count items"),
+			wantErr:        true,
+		},
+		{
+			name:           "failed to commit transaction",
+			ctx:            context.Background(),
+			payload:        validCycleCountPayload(),
+			rGetProductRes: &entity.Product{ID: 1, Tenant: types.TenantLoremType, Qty: 10},
+			rCommitTrxErr:  response.ErrNoSQLTransactionFound,
+			wantErr:        true,
+		},
+		{
+			name:           "success",
+			ctx:            context.Background(),
+			payload:        validCycleCountPayload(),
+			rGetProductRes: &entity.Product{ID: 1, Tenant: types.TenantLoremType, Qty: 10},
+			wantErr:        false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cycleCountRepo := &testmock.CycleCountRepositoryInterface{}
+			cycleCountRepo.On("CreateCycleCount", mock.Anything, mock.Anything, mock.Anything).Return(tc.rCreateErr)
+			cycleCountRepo.On("CreateCycleCountItems", mock.Anything, mock.Anything, mock.Anything).Return(tc.rCreateItemErr)
+
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("GetProductBySKU", mock.Anything, "SKU-1").Return(tc.rGetProductRes, tc.rGetProductErr)
+
+			dbTransactionRepo := &testmock.PostgresTransactionRepositoryInterface{}
+			dbTransactionRepo.On("StartTransactionQuery", mock.Anything).Return(&sqlx.Tx{}, tc.rStartTrxErr)
+			dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(tc.rCommitTrxErr)
+			dbTransactionRepo.On("RollbackTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+
+			uc := usecase.NewCycleCountUsecase(cycleCountRepo, productRepo, &testmock.LocationRepositoryInterface{}, &testmock.ProductStockRepositoryInterface{}, &testmock.StockMovementRepositoryInterface{}, &testmock.LowStockRepositoryInterface{}, dbTransactionRepo, types.AllocationStrategyPriorityType)
+			res, err := uc.CreateCycleCount(tc.ctx, tc.payload)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, tc.wantCustomErr, err)
+			}
+			if !tc.wantErr {
+				assert.Equal(t, types.CycleCountStatusPendingType, res.Status)
+				assert.Equal(t, []*entity.CycleCountItem{{ProductID: 1, SKU: "SKU-1", SystemQty: 10, CountedQty: 7, DiffQty: -3}}, res.Items)
+			}
+		})
+	}
+}
+
+func TestGetCycleCountByID(t *testing.T) {
+	testcases := []struct {
+		name          string
+		ctx           context.Context
+		rGetRes       *entity.CycleCount
+		rGetErr       error
+		rGetItemsErr  error
+		wantErr       bool
+		wantCustomErr error
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "cycle count not found",
+			ctx:           context.Background(),
+			rGetErr:       response.ErrNotFound,
+			wantErr:       true,
+			wantCustomErr: response.ErrNotFound,
+		},
+		{
+			name:    "failed to get cycle count",
+			ctx:     context.Background(),
+			rGetErr: errors.New("error get cycle count"),
+			wantErr: true,
+		},
+		{
+			name:          "cycle count of other tenant",
+			ctx:           context.Background(),
+			rGetRes:       &entity.CycleCount{ID: 1, Tenant: types.TenantIpsumType},
+			wantErr:       true,
+			wantCustomErr: response.ErrForbidden,
+		},
+		{
+			name:         "failed to get cycle count items",
+			ctx:          context.Background(),
+			rGetRes:      pendingCycleCount(),
+			rGetItemsErr: errors.New("error get cycle count items"),
+			wantErr:      true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			rGetRes: pendingCycleCount(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			items := []*entity.CycleCountItem{{CycleCountID: 1, ProductID: 1, SKU: "SKU-1", SystemQty: 10, CountedQty: 7, DiffQty: -3}}
+
+			cycleCountRepo := &testmock.CycleCountRepositoryInterface{}
+			cycleCountRepo.On("GetCycleCountByID", mock.Anything, 1).Return(tc.rGetRes, tc.rGetErr)
+			cycleCountRepo.On("GetCycleCountItemsByCycleCountIDs", mock.Anything, []int{1}).Return(items, tc.rGetItemsErr)
+
+			uc := usecase.NewCycleCountUsecase(cycleCountRepo, &testmock.ProductRepositoryInterface{}, &testmock.LocationRepositoryInterface{}, &testmock.ProductStockRepositoryInterface{}, &testmock.StockMovementRepositoryInterface{}, &testmock.LowStockRepositoryInterface{}, &testmock.PostgresTransactionRepositoryInterface{}, types.AllocationStrategyPriorityType)
+			res, err := uc.GetCycleCountByID(tc.ctx, types.TenantLoremType, 1)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, tc.wantCustomErr, err)
+			}
+			if !tc.wantErr {
+				assert.Equal(t, items, res.Items)
+			}
+		})
+	}
+}
+
+func TestApproveCycleCount(t *testing.T) {
+	testcases := []struct {
+		name              string
+		ctx               context.Context
+		rGetRes           *entity.CycleCount
+		rGetErr           error
+		rStartTrxErr      error
+		rUpdateStatusErr  error
+		rGetProductErr    error
+		rStocksRes        []*entity.ProductStock
+		rStocksErr        error
+		rDefaultLocErr    error
+		rUpsertStocksErr  error
+		rUpdateProductErr error
+		rStockMovementErr error
+		rCommitTrxErr     error
+		wantErr           bool
+		wantCustomErr     error
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "cycle count not found",
+			ctx:           context.Background(),
+			rGetErr:       response.ErrNotFound,
+			wantErr:       true,
+			wantCustomErr: response.ErrNotFound,
+		},
+		{
+			name:          "cycle count already rejected",
+			ctx:           context.Background(),
+			rGetRes:       &entity.CycleCount{ID: 1, Tenant: types.TenantLoremType, Status: types.CycleCountStatusRejectedType},
+			wantErr:       true,
+			wantCustomErr: response.ErrCycleCountNotPending,
+		},
+		{
+			name:         "failed to start transaction",
+			ctx:          context.Background(),
+			rGetRes:      pendingCycleCount(),
+			rStartTrxErr: response.ErrNoSQLTransactionFound,
+			wantErr:      true,
+		},
+		{
+			name:             "cycle count reviewed by other request",
+			ctx:              context.Background(),
+			rGetRes:          pendingCycleCount(),
+			rUpdateStatusErr: response.ErrNotFound,
+			wantErr:          true,
+			wantCustomErr:    response.ErrCycleCountNotPending,
+		},
+		{
+			name:             "failed to update cycle count status",
+			ctx:              context.Background(),
+			rGetRes:          pendingCycleCount(),
+			rUpdateStatusErr: errors.New("error update cycle count status"),
+			wantErr:          true,
+		},
+		{
+			name:           "failed to get products",
+			ctx:            context.Background(),
+			rGetRes:        pendingCycleCount(),
+			rGetProductErr: errors.New("error get products"),
+			wantErr:        true,
+		},
+		{
+			name:       "failed to get product stocks",
+			ctx:        context.Background(),
+			rGetRes:    pendingCycleCount(),
+			rStocksErr: errors.New("error get product stocks"),
+			wantErr:    true,
+		},
+		{
+			name:          "insufficient stock",
+			ctx:           context.Background(),
+			rGetRes:       pendingCycleCount(),
+			rStocksRes:    []*entity.ProductStock{{LocationID: 1, Qty: 1}},
+			wantErr:       true,
+			wantCustomErr: response.ErrInsufficientStock,
+		},
+		{
+			name:           "failed to get default location",
+			ctx:            context.Background(),
+			rGetRes:        pendingCycleCount(),
+			rStocksRes:     []*entity.ProductStock{{LocationID: 1, Qty: 5}},
+			rDefaultLocErr: errors.New("error get default location"),
+			wantErr:        true,
+		},
+		{
+			name:             "failed to upsert product stocks",
+			ctx:              context.Background(),
+			rGetRes:          pendingCycleCount(),
+			rStocksRes:       []*entity.ProductStock{{LocationID: 1, Qty: 5}},
+			rUpsertStocksErr: errors.New("error upsert product stocks"),
+			wantErr:          true,
+		},
+		{
+			name:              "failed to update product",
+			ctx:               context.Background(),
+			rGetRes:           pendingCycleCount(),
+			rStocksRes:        []*entity.ProductStock{{LocationID: 1, Qty: 5}},
+			rUpdateProductErr: errors.New("error update product"),
+			wantErr:           true,
+		},
+		{
+			name:              "failed to create stock movements",
+			ctx:               context.Background(),
+			rGetRes:           pendingCycleCount(),
+			rStocksRes:        []*entity.ProductStock{{LocationID: 1, Qty: 5}},
+			rStockMovementErr: errors.New("error create stock movements"),
+			wantErr:           true,
+		},
+		{
+			name:          "failed to commit transaction",
+			ctx:           context.Background(),
+			rGetRes:       pendingCycleCount(),
+			rStocksRes:    []*entity.ProductStock{{LocationID: 1, Qty: 5}},
+			rCommitTrxErr: response.ErrNoSQLTransactionFound,
+			wantErr:       true,
+		},
+		{
+			name:       "success",
+			ctx:        context.Background(),
+			rGetRes:    pendingCycleCount(),
+			rStocksRes: []*entity.ProductStock{{LocationID: 1, Qty: 5}},
+			wantErr:    false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// The first product is counted short, the second is counted over with a backorder waiting and the third matches
+			items := []*entity.CycleCountItem{
+				{CycleCountID: 1, ProductID: 2, SKU: "SKU-2", SystemQty: 1, CountedQty: 4, DiffQty: 3},
+				{CycleCountID: 1, ProductID: 1, SKU: "SKU-1", SystemQty: 5, CountedQty: 3, DiffQty: -2},
+				{CycleCountID: 1, ProductID: 3, SKU: "SKU-3", SystemQty: 2, CountedQty: 2, DiffQty: 0},
+			}
+			shortProduct := &entity.Product{ID: 1, Tenant: types.TenantLoremType, Qty: 5}
+			overProduct := &entity.Product{ID: 2, Tenant: types.TenantLoremType, Qty: 1, BackorderedQty: 1, BackorderPolicy: types.BackorderPolicyUnlimitedType}
+
+			cycleCountRepo := &testmock.CycleCountRepositoryInterface{}
+			cycleCountRepo.On("GetCycleCountByID", mock.Anything, 1).Return(tc.rGetRes, tc.rGetErr)
+			cycleCountRepo.On("GetCycleCountItemsByCycleCountIDs", mock.Anything, []int{1}).Return(items, nil)
+			cycleCountRepo.On("UpdateCycleCountStatus", mock.Anything, mock.Anything, mock.Anything, types.CycleCountStatusPendingType).Return(tc.rUpdateStatusErr)
+
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("GetProductsForUpdate", mock.Anything, mock.Anything, mock.Anything, []int{1, 2}, []string(nil)).Return([]*entity.Product{shortProduct, overProduct}, tc.rGetProductErr)
+			productRepo.On("UpdateProductQty", mock.Anything, mock.Anything, mock.Anything).Return(tc.rUpdateProductErr)
+
+			locationRepo := &testmock.LocationRepositoryInterface{}
+			locationRepo.On("GetOrCreateDefaultLocation", mock.Anything, mock.Anything, types.TenantLoremType).Return(&entity.Location{ID: 1}, tc.rDefaultLocErr)
+
+			productStockRepo := &testmock.ProductStockRepositoryInterface{}
+			productStockRepo.On("GetProductLotsByProductIDs", mock.Anything, mock.Anything).Return([]*entity.ProductLot{}, nil)
+			productStockRepo.On("GetProductLotsForUpdate", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.ProductLot{}, nil)
+			productStockRepo.On("UpdateProductLots", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			productStockRepo.On("GetProductStocksForUpdate", mock.Anything, mock.Anything, 1).Return(tc.rStocksRes, tc.rStocksErr)
+			productStockRepo.On("GetProductStocksForUpdate", mock.Anything, mock.Anything, 2).Return([]*entity.ProductStock{{LocationID: 1, Qty: 1}}, nil)
+			productStockRepo.On("UpsertProductStocks", mock.Anything, mock.Anything, mock.Anything).Return(tc.rUpsertStocksErr)
+
+			dbTransactionRepo := &testmock.PostgresTransactionRepositoryInterface{}
+			dbTransactionRepo.On("StartTransactionQuery", mock.Anything).Return(&sqlx.Tx{}, tc.rStartTrxErr)
+			dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(tc.rCommitTrxErr)
+			dbTransactionRepo.On("RollbackTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+
+			stockMovementRepo := &testmock.StockMovementRepositoryInterface{}
+			stockMovementRepo.On("CreateStockMovements", mock.Anything, mock.Anything, mock.Anything).Return(tc.rStockMovementErr)
+
+			lowStockRepo := &testmock.LowStockRepositoryInterface{}
+			lowStockRepo.On("GetLowStockThreshold", mock.Anything, mock.Anything).Return(nil, response.ErrNotFound)
+
+			uc := usecase.NewCycleCountUsecase(cycleCountRepo, productRepo, locationRepo, productStockRepo, stockMovementRepo, lowStockRepo, dbTransactionRepo, types.AllocationStrategyPriorityType)
+			res, err := uc.ApproveCycleCount(tc.ctx, types.TenantLoremType, 1, "jane")
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, tc.wantCustomErr, err)
+			}
+			if !tc.wantErr {
+				assert.Equal(t, types.CycleCountStatusApprovedType, res.Status)
+				assert.Equal(t, 3, shortProduct.Qty)
+				assert.Equal(t, 3, overProduct.Qty)
+				assert.Equal(t, 0, overProduct.BackorderedQty)
+				stockMovementRepo.AssertCalled(t, "CreateStockMovements", mock.Anything, mock.Anything, mock.MatchedBy(func(movements []*entity.StockMovement) bool {
+					if len(movements) != 3 {
+						return false
+					}
+					for _, movement := range movements {
+						if movement.Actor != "jane" || movement.ReferenceID != "cycle-count:1" {
+							return false
+						}
+					}
+					return movements[0].ProductID == 1 && movements[0].Delta == -2 && movements[0].ResultingQty == 3 && movements[0].Reason == types.StockMovementReasonAdjustmentType &&
+						movements[1].ProductID == 2 && movements[1].Delta == 3 && movements[1].ResultingQty == 4 && movements[1].Reason == types.StockMovementReasonAdjustmentType &&
+						movements[2].ProductID == 2 && movements[2].Delta == -1 && movements[2].ResultingQty == 3 && movements[2].Reason == types.StockMovementReasonSaleType
+				}))
+			}
+		})
+	}
+}
+
+func TestRejectCycleCount(t *testing.T) {
+	testcases := []struct {
+		name             string
+		ctx              context.Context
+		rGetRes          *entity.CycleCount
+		rGetErr          error
+		rUpdateStatusErr error
+		wantErr          bool
+		wantCustomErr    error
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "cycle count not found",
+			ctx:           context.Background(),
+			rGetErr:       response.ErrNotFound,
+			wantErr:       true,
+			wantCustomErr: response.ErrNotFound,
+		},
+		{
+			name:          "cycle count already approved",
+			ctx:           context.Background(),
+			rGetRes:       &entity.CycleCount{ID: 1, Tenant: types.TenantLoremType, Status: types.CycleCountStatusApprovedType},
+			wantErr:       true,
+			wantCustomErr: response.ErrCycleCountNotPending,
+		},
+		{
+			name:             "cycle count reviewed by other request",
+			ctx:              context.Background(),
+			rGetRes:          pendingCycleCount(),
+			rUpdateStatusErr: response.ErrNotFound,
+			wantErr:          true,
+			wantCustomErr:    response.ErrCycleCountNotPending,
+		},
+		{
+			name:             "failed to update cycle count status",
+			ctx:              context.Background(),
+			rGetRes:          pendingCycleCount(),
+			rUpdateStatusErr: errors.New("error update cycle count status"),
+			wantErr:          true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			rGetRes: pendingCycleCount(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cycleCountRepo := &testmock.CycleCountRepositoryInterface{}
+			cycleCountRepo.On("GetCycleCountByID", mock.Anything, 1).Return(tc.rGetRes, tc.rGetErr)
+			cycleCountRepo.On("GetCycleCountItemsByCycleCountIDs", mock.Anything, []int{1}).Return([]*entity.CycleCountItem{}, nil)
+			cycleCountRepo.On("UpdateCycleCountStatus", mock.Anything, nil, mock.Anything, types.CycleCountStatusPendingType).Return(tc.rUpdateStatusErr)
+
+			uc := usecase.NewCycleCountUsecase(cycleCountRepo, &testmock.ProductRepositoryInterface{}, &testmock.LocationRepositoryInterface{}, &testmock.ProductStockRepositoryInterface{}, &testmock.StockMovementRepositoryInterface{}, &testmock.LowStockRepositoryInterface{}, &testmock.PostgresTransactionRepositoryInterface{}, types.AllocationStrategyPriorityType)
+			res, err := uc.RejectCycleCount(tc.ctx, types.TenantLoremType, 1)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, tc.wantCustomErr, err)
+			}
+			if !tc.wantErr {
+				assert.Equal(t, types.CycleCountStatusRejectedType, res.Status)
+			}
+		})
+	}
+}
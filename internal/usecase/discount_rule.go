@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	repo "github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// DiscountRuleUsecaseInterface define contract for discount rule related functions to usecase
+type DiscountRuleUsecaseInterface interface {
+	CreateDiscountRule(ctx context.Context, payload *entity.DiscountRulePayload) (*entity.DiscountRule, error)
+	GetDiscountRules(ctx context.Context, tenant types.TenantType) ([]*entity.DiscountRule, error)
+	UpdateDiscountRule(ctx context.Context, discountRuleID int, payload *entity.DiscountRulePayload) (*entity.DiscountRule, error)
+	DeleteDiscountRule(ctx context.Context, tenant types.TenantType, discountRuleID int) error
+}
+
+type DiscountRuleUsecase struct {
+	repo repo.DiscountRuleRepositoryInterface
+}
+
+func NewDiscountRuleUsecase(r repo.DiscountRuleRepositoryInterface) *DiscountRuleUsecase {
+	return &DiscountRuleUsecase{
+		repo: r,
+	}
+}
+
+func (uc *DiscountRuleUsecase) CreateDiscountRule(ctx context.Context, payload *entity.DiscountRulePayload) (*entity.DiscountRule, error) {
+	functionName := "DiscountRuleUsecase.CreateDiscountRule"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	discountRule := payload.ToEntity()
+	if err := uc.repo.CreateDiscountRule(ctx, discountRule); err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.CreateDiscountRule: %w", err), functionName)
+	}
+
+	return discountRule, nil
+}
+
+func (uc *DiscountRuleUsecase) GetDiscountRules(ctx context.Context, tenant types.TenantType) ([]*entity.DiscountRule, error) {
+	functionName := "DiscountRuleUsecase.GetDiscountRules"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	discountRules, err := uc.repo.GetDiscountRules(ctx, tenant)
+	if err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.GetDiscountRules: %w", err), functionName)
+	}
+
+	return discountRules, nil
+}
+
+func (uc *DiscountRuleUsecase) UpdateDiscountRule(ctx context.Context, discountRuleID int, payload *entity.DiscountRulePayload) (*entity.DiscountRule, error) {
+	functionName := "DiscountRuleUsecase.UpdateDiscountRule"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	currentDiscountRule, err := uc.getDiscountRule(ctx, payload.Tenant, discountRuleID)
+	if err != nil {
+		return nil, err
+	}
+
+	discountRule := payload.ToEntity()
+	discountRule.ID = currentDiscountRule.ID
+	discountRule.CreatedAt = currentDiscountRule.CreatedAt
+	if err := uc.repo.UpdateDiscountRule(ctx, discountRule); err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.UpdateDiscountRule: %w", err), functionName)
+	}
+
+	return discountRule, nil
+}
+
+func (uc *DiscountRuleUsecase) DeleteDiscountRule(ctx context.Context, tenant types.TenantType, discountRuleID int) error {
+	functionName := "DiscountRuleUsecase.DeleteDiscountRule"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	if _, err := uc.getDiscountRule(ctx, tenant, discountRuleID); err != nil {
+		return err
+	}
+
+	if err := uc.repo.DeleteDiscountRule(ctx, discountRuleID); err != nil {
+		return errors.Wrap(fmt.Errorf("uc.repo.DeleteDiscountRule: %w", err), functionName)
+	}
+
+	return nil
+}
+
+// getDiscountRule return discount rule which belongs to the tenant
+func (uc *DiscountRuleUsecase) getDiscountRule(ctx context.Context, tenant types.TenantType, discountRuleID int) (*entity.DiscountRule, error) {
+	discountRule, err := uc.repo.GetDiscountRuleByID(ctx, discountRuleID)
+	if err != nil {
+		if err == response.ErrNotFound {
+			return nil, err
+		}
+
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.GetDiscountRuleByID: %w", err), "DiscountRuleUsecase.getDiscountRule")
+	}
+
+	if discountRule.Tenant != tenant {
+		return nil, response.ErrForbidden
+	}
+
+	return discountRule, nil
+}
@@ -0,0 +1,249 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func validDiscountRulePayload() *entity.DiscountRulePayload {
+	return &entity.DiscountRulePayload{
+		Name:   "Book sale",
+		Type:   types.DiscountPercentageType,
+		Value:  1000,
+		Tenant: types.TenantLoremType,
+	}
+}
+
+func TestCreateDiscountRule(t *testing.T) {
+	testcases := []struct {
+		name          string
+		ctx           context.Context
+		payload       *entity.DiscountRulePayload
+		rCreateErr    error
+		wantErr       bool
+		wantCustomErr error
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "invalid value",
+			ctx:           context.Background(),
+			payload:       &entity.DiscountRulePayload{Name: "Book sale", Type: types.DiscountPercentageType, Value: 10001, Tenant: types.TenantLoremType},
+			wantErr:       true,
+			wantCustomErr: response.ErrInvalidDiscountValue,
+		},
+		{
+			name:       "failed to create discount rule",
+			ctx:        context.Background(),
+			payload:    validDiscountRulePayload(),
+			rCreateErr: errors.New("error create discount rule"),
+			wantErr:    true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			payload: validDiscountRulePayload(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			discountRuleRepo := &testmock.DiscountRuleRepositoryInterface{}
+			discountRuleRepo.On("CreateDiscountRule", mock.Anything, mock.Anything).Return(tc.rCreateErr)
+
+			uc := usecase.NewDiscountRuleUsecase(discountRuleRepo)
+			_, err := uc.CreateDiscountRule(tc.ctx, tc.payload)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, tc.wantCustomErr, err)
+			}
+		})
+	}
+}
+
+func TestGetDiscountRules(t *testing.T) {
+	testcases := []struct {
+		name    string
+		ctx     context.Context
+		rErr    error
+		wantErr bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:    "failed to get discount rules",
+			ctx:     context.Background(),
+			rErr:    errors.New("error get discount rules"),
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			discountRuleRepo := &testmock.DiscountRuleRepositoryInterface{}
+			discountRuleRepo.On("GetDiscountRules", mock.Anything, mock.Anything).Return([]*entity.DiscountRule{}, tc.rErr)
+
+			uc := usecase.NewDiscountRuleUsecase(discountRuleRepo)
+			_, err := uc.GetDiscountRules(tc.ctx, types.TenantLoremType)
+			assert.Equal(t, tc.wantErr, err != nil)
+		})
+	}
+}
+
+func TestUpdateDiscountRule(t *testing.T) {
+	testcases := []struct {
+		name          string
+		ctx           context.Context
+		payload       *entity.DiscountRulePayload
+		rGetRes       *entity.DiscountRule
+		rGetErr       error
+		rUpdateErr    error
+		wantErr       bool
+		wantCustomErr error
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "invalid type",
+			ctx:           context.Background(),
+			payload:       &entity.DiscountRulePayload{Name: "Book sale", Value: 1000, Tenant: types.TenantLoremType},
+			wantErr:       true,
+			wantCustomErr: response.ErrInvalidDiscountType,
+		},
+		{
+			name:          "discount rule is not found",
+			ctx:           context.Background(),
+			payload:       validDiscountRulePayload(),
+			rGetErr:       response.ErrNotFound,
+			wantErr:       true,
+			wantCustomErr: response.ErrNotFound,
+		},
+		{
+			name:    "failed to get discount rule",
+			ctx:     context.Background(),
+			payload: validDiscountRulePayload(),
+			rGetErr: errors.New("error get discount rule"),
+			wantErr: true,
+		},
+		{
+			name:          "discount rule of another tenant",
+			ctx:           context.Background(),
+			payload:       validDiscountRulePayload(),
+			rGetRes:       &entity.DiscountRule{ID: 1, Tenant: types.TenantIpsumType},
+			wantErr:       true,
+			wantCustomErr: response.ErrForbidden,
+		},
+		{
+			name:       "failed to update discount rule",
+			ctx:        context.Background(),
+			payload:    validDiscountRulePayload(),
+			rGetRes:    &entity.DiscountRule{ID: 1, Tenant: types.TenantLoremType},
+			rUpdateErr: errors.New("error update discount rule"),
+			wantErr:    true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			payload: validDiscountRulePayload(),
+			rGetRes: &entity.DiscountRule{ID: 1, Tenant: types.TenantLoremType},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			discountRuleRepo := &testmock.DiscountRuleRepositoryInterface{}
+			discountRuleRepo.On("GetDiscountRuleByID", mock.Anything, mock.Anything).Return(tc.rGetRes, tc.rGetErr)
+			discountRuleRepo.On("UpdateDiscountRule", mock.Anything, mock.Anything).Return(tc.rUpdateErr)
+
+			uc := usecase.NewDiscountRuleUsecase(discountRuleRepo)
+			res, err := uc.UpdateDiscountRule(tc.ctx, 1, tc.payload)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, tc.wantCustomErr, err)
+			}
+			if !tc.wantErr {
+				assert.Equal(t, tc.rGetRes.ID, res.ID)
+			}
+		})
+	}
+}
+
+func TestDeleteDiscountRule(t *testing.T) {
+	testcases := []struct {
+		name       string
+		ctx        context.Context
+		rGetRes    *entity.DiscountRule
+		rGetErr    error
+		rDeleteErr error
+		wantErr    bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:    "discount rule is not found",
+			ctx:     context.Background(),
+			rGetErr: response.ErrNotFound,
+			wantErr: true,
+		},
+		{
+			name:    "discount rule of another tenant",
+			ctx:     context.Background(),
+			rGetRes: &entity.DiscountRule{ID: 1, Tenant: types.TenantIpsumType},
+			wantErr: true,
+		},
+		{
+			name:       "failed to delete discount rule",
+			ctx:        context.Background(),
+			rGetRes:    &entity.DiscountRule{ID: 1, Tenant: types.TenantLoremType},
+			rDeleteErr: errors.New("error delete discount rule"),
+			wantErr:    true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			rGetRes: &entity.DiscountRule{ID: 1, Tenant: types.TenantLoremType},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			discountRuleRepo := &testmock.DiscountRuleRepositoryInterface{}
+			discountRuleRepo.On("GetDiscountRuleByID", mock.Anything, mock.Anything).Return(tc.rGetRes, tc.rGetErr)
+			discountRuleRepo.On("DeleteDiscountRule", mock.Anything, mock.Anything).Return(tc.rDeleteErr)
+
+			uc := usecase.NewDiscountRuleUsecase(discountRuleRepo)
+			err := uc.DeleteDiscountRule(tc.ctx, types.TenantLoremType, 1)
+			assert.Equal(t, tc.wantErr, err != nil)
+		})
+	}
+}
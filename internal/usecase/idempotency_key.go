@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	repo "github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// IdempotencyKeyUsecaseInterface define contract for idempotency key related functions to usecase
+type IdempotencyKeyUsecaseInterface interface {
+	BeginIdempotentRequest(ctx context.Context, key *entity.IdempotencyKey) (*entity.IdempotencyKey, error)
+	CompleteIdempotentRequest(ctx context.Context, key *entity.IdempotencyKey) error
+	AbortIdempotentRequest(ctx context.Context, key *entity.IdempotencyKey) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context) error
+}
+
+type IdempotencyKeyUsecase struct {
+	repo repo.IdempotencyKeyRepositoryInterface
+	// ttl is how long the stored response is replayed
+	ttl time.Duration
+	// inProgressTTL is how long the key blocks the retries before its response is stored
+	inProgressTTL time.Duration
+}
+
+func NewIdempotencyKeyUsecase(r repo.IdempotencyKeyRepositoryInterface, ttl time.Duration, inProgressTTL time.Duration) *IdempotencyKeyUsecase {
+	return &IdempotencyKeyUsecase{
+		repo:          r,
+		ttl:           ttl,
+		inProgressTTL: inProgressTTL,
+	}
+}
+
+// BeginIdempotentRequest claim the key for the request. It returns nil when the request should be processed
+// until the in progress deadline of the key, or the stored key whose response should be replayed when the key
+// was already used by the same request
+func (uc *IdempotencyKeyUsecase) BeginIdempotentRequest(ctx context.Context, key *entity.IdempotencyKey) (*entity.IdempotencyKey, error) {
+	functionName := "IdempotencyKeyUsecase.BeginIdempotentRequest"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if err := key.Validate(); err != nil {
+		return nil, err
+	}
+
+	// The first request may stop before it stores or releases the key, e.g. when the process is killed,
+	// so its key is taken over once it is in progress for longer than inProgressTTL.
+	// The request is cancelled before then, the claim is created after now
+	now := time.Now()
+	key.ExpiresAt = now.Add(uc.ttl)
+	key.InProgressUntil = now.Add(uc.inProgressTTL)
+	created, err := uc.repo.CreateIdempotencyKey(ctx, key, now.Add(-uc.inProgressTTL))
+	if err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.CreateIdempotencyKey: %w", err), functionName)
+	}
+
+	if created {
+		return nil, nil
+	}
+
+	storedKey, err := uc.repo.GetIdempotencyKey(ctx, key.Tenant, key.Key)
+	if err != nil {
+		// The key is released by the first request between the claim and the lookup
+		if err == response.ErrNotFound {
+			return nil, response.ErrIdempotencyKeyInProgress
+		}
+
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.GetIdempotencyKey: %w", err), functionName)
+	}
+
+	if storedKey.RequestHash != key.RequestHash {
+		return nil, response.ErrIdempotencyKeyMismatch
+	}
+
+	if !storedKey.IsCompleted() {
+		return nil, response.ErrIdempotencyKeyInProgress
+	}
+
+	return storedKey, nil
+}
+
+// CompleteIdempotentRequest store the response of the request which claimed the key, unless it has been taken over
+func (uc *IdempotencyKeyUsecase) CompleteIdempotentRequest(ctx context.Context, key *entity.IdempotencyKey) error {
+	functionName := "IdempotencyKeyUsecase.CompleteIdempotentRequest"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	if err := uc.repo.UpdateIdempotencyKeyResponse(ctx, key); err != nil {
+		return errors.Wrap(fmt.Errorf("uc.repo.UpdateIdempotencyKeyResponse: %w", err), functionName)
+	}
+
+	return nil
+}
+
+// AbortIdempotentRequest release the key claimed by the request without storing the response so that the request can be retried
+func (uc *IdempotencyKeyUsecase) AbortIdempotentRequest(ctx context.Context, key *entity.IdempotencyKey) error {
+	functionName := "IdempotencyKeyUsecase.AbortIdempotentRequest"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	if err := uc.repo.DeleteIdempotencyKey(ctx, key); err != nil {
+		return errors.Wrap(fmt.Errorf("uc.repo.DeleteIdempotencyKey: %w", err), functionName)
+	}
+
+	return nil
+}
+
+// DeleteExpiredIdempotencyKeys remove the keys which are no longer replayed
+func (uc *IdempotencyKeyUsecase) DeleteExpiredIdempotencyKeys(ctx context.Context) error {
+	functionName := "IdempotencyKeyUsecase.DeleteExpiredIdempotencyKeys"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	if err := uc.repo.DeleteExpiredIdempotencyKeys(ctx, time.Now()); err != nil {
+		return errors.Wrap(fmt.Errorf("uc.repo.DeleteExpiredIdempotencyKeys: %w", err), functionName)
+	}
+
+	return nil
+}
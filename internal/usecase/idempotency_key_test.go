@@ -0,0 +1,238 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBeginIdempotentRequest(t *testing.T) {
+	testcases := []struct {
+		name          string
+		ctx           context.Context
+		key           *entity.IdempotencyKey
+		rCreateRes    bool
+		rCreateErr    error
+		rGetRes       *entity.IdempotencyKey
+		rGetErr       error
+		wantReplay    bool
+		wantErr       bool
+		wantCustomErr error
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "blank key",
+			ctx:           context.Background(),
+			key:           &entity.IdempotencyKey{Tenant: types.TenantLoremType, RequestHash: "hash"},
+			wantErr:       true,
+			wantCustomErr: response.ErrInvalidIdempotencyKey,
+		},
+		{
+			name:       "failed to create idempotency key",
+			ctx:        context.Background(),
+			key:        &entity.IdempotencyKey{Tenant: types.TenantLoremType, Key: "key", RequestHash: "hash"},
+			rCreateErr: errors.New("error create idempotency key"),
+			wantErr:    true,
+		},
+		{
+			name:       "first request",
+			ctx:        context.Background(),
+			key:        &entity.IdempotencyKey{Tenant: types.TenantLoremType, Key: "key", RequestHash: "hash"},
+			rCreateRes: true,
+			wantReplay: false,
+			wantErr:    false,
+		},
+		{
+			name:          "key is released before the lookup",
+			ctx:           context.Background(),
+			key:           &entity.IdempotencyKey{Tenant: types.TenantLoremType, Key: "key", RequestHash: "hash"},
+			rGetErr:       response.ErrNotFound,
+			wantErr:       true,
+			wantCustomErr: response.ErrIdempotencyKeyInProgress,
+		},
+		{
+			name:    "failed to get idempotency key",
+			ctx:     context.Background(),
+			key:     &entity.IdempotencyKey{Tenant: types.TenantLoremType, Key: "key", RequestHash: "hash"},
+			rGetErr: errors.New("error get idempotency key"),
+			wantErr: true,
+		},
+		{
+			name:          "key is used by another request",
+			ctx:           context.Background(),
+			key:           &entity.IdempotencyKey{Tenant: types.TenantLoremType, Key: "key", RequestHash: "hash"},
+			rGetRes:       &entity.IdempotencyKey{RequestHash: "other-hash", StatusCode: 200},
+			wantErr:       true,
+			wantCustomErr: response.ErrIdempotencyKeyMismatch,
+		},
+		{
+			name:          "first request is still in progress",
+			ctx:           context.Background(),
+			key:           &entity.IdempotencyKey{Tenant: types.TenantLoremType, Key: "key", RequestHash: "hash"},
+			rGetRes:       &entity.IdempotencyKey{RequestHash: "hash"},
+			wantErr:       true,
+			wantCustomErr: response.ErrIdempotencyKeyInProgress,
+		},
+		{
+			name:       "retry",
+			ctx:        context.Background(),
+			key:        &entity.IdempotencyKey{Tenant: types.TenantLoremType, Key: "key", RequestHash: "hash"},
+			rGetRes:    &entity.IdempotencyKey{RequestHash: "hash", StatusCode: 200, ResponseBody: []byte(`{}`)},
+			wantReplay: true,
+			wantErr:    false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			idempotencyKeyRepo := &testmock.IdempotencyKeyRepositoryInterface{}
+			// The key in progress is taken over once it is claimed before the in progress ttl
+			idempotencyKeyRepo.On("CreateIdempotencyKey", mock.Anything, mock.Anything, mock.MatchedBy(func(inProgressBefore time.Time) bool {
+				return time.Since(inProgressBefore) >= time.Minute
+			})).Return(tc.rCreateRes, tc.rCreateErr)
+			idempotencyKeyRepo.On("GetIdempotencyKey", mock.Anything, types.TenantLoremType, "key").Return(tc.rGetRes, tc.rGetErr)
+
+			uc := usecase.NewIdempotencyKeyUsecase(idempotencyKeyRepo, time.Hour, time.Minute)
+			res, err := uc.BeginIdempotentRequest(tc.ctx, tc.key)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, tc.wantCustomErr, err)
+			}
+			if !tc.wantErr {
+				assert.Equal(t, tc.wantReplay, res != nil)
+				assert.WithinDuration(t, time.Now().Add(time.Hour), tc.key.ExpiresAt, time.Minute)
+				// The request is cancelled before the key can be taken over by a retry
+				assert.False(t, tc.key.InProgressUntil.After(time.Now().Add(time.Minute)))
+				assert.WithinDuration(t, time.Now().Add(time.Minute), tc.key.InProgressUntil, time.Second)
+			}
+		})
+	}
+}
+
+func TestCompleteIdempotentRequest(t *testing.T) {
+	testcases := []struct {
+		name       string
+		ctx        context.Context
+		rUpdateErr error
+		wantErr    bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:       "failed to update idempotency key response",
+			ctx:        context.Background(),
+			rUpdateErr: errors.New("error update idempotency key response"),
+			wantErr:    true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			idempotencyKeyRepo := &testmock.IdempotencyKeyRepositoryInterface{}
+			idempotencyKeyRepo.On("UpdateIdempotencyKeyResponse", mock.Anything, mock.Anything).Return(tc.rUpdateErr)
+
+			uc := usecase.NewIdempotencyKeyUsecase(idempotencyKeyRepo, time.Hour, time.Minute)
+			err := uc.CompleteIdempotentRequest(tc.ctx, &entity.IdempotencyKey{Tenant: types.TenantLoremType, Key: "key", StatusCode: 200})
+			assert.Equal(t, tc.wantErr, err != nil)
+		})
+	}
+}
+
+func TestAbortIdempotentRequest(t *testing.T) {
+	createdAt := time.Now()
+	testcases := []struct {
+		name       string
+		ctx        context.Context
+		rDeleteErr error
+		wantErr    bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:       "failed to delete idempotency key",
+			ctx:        context.Background(),
+			rDeleteErr: errors.New("error delete idempotency key"),
+			wantErr:    true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			idempotencyKeyRepo := &testmock.IdempotencyKeyRepositoryInterface{}
+			idempotencyKeyRepo.On("DeleteIdempotencyKey", mock.Anything, mock.MatchedBy(func(key *entity.IdempotencyKey) bool {
+				return key.Tenant == types.TenantLoremType && key.Key == "key" && key.CreatedAt.Equal(createdAt)
+			})).Return(tc.rDeleteErr)
+
+			uc := usecase.NewIdempotencyKeyUsecase(idempotencyKeyRepo, time.Hour, time.Minute)
+			err := uc.AbortIdempotentRequest(tc.ctx, &entity.IdempotencyKey{Tenant: types.TenantLoremType, Key: "key", CreatedAt: createdAt})
+			assert.Equal(t, tc.wantErr, err != nil)
+		})
+	}
+}
+
+func TestDeleteExpiredIdempotencyKeys(t *testing.T) {
+	testcases := []struct {
+		name       string
+		ctx        context.Context
+		rDeleteErr error
+		wantErr    bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:       "failed to delete expired idempotency keys",
+			ctx:        context.Background(),
+			rDeleteErr: errors.New("error delete expired idempotency keys"),
+			wantErr:    true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			idempotencyKeyRepo := &testmock.IdempotencyKeyRepositoryInterface{}
+			idempotencyKeyRepo.On("DeleteExpiredIdempotencyKeys", mock.Anything, mock.Anything).Return(tc.rDeleteErr)
+
+			uc := usecase.NewIdempotencyKeyUsecase(idempotencyKeyRepo, time.Hour, time.Minute)
+			err := uc.DeleteExpiredIdempotencyKeys(tc.ctx)
+			assert.Equal(t, tc.wantErr, err != nil)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	repo "github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// LocationUsecaseInterface define contract for location related functions to usecase
+type LocationUsecaseInterface interface {
+	CreateLocation(ctx context.Context, payload *entity.LocationPayload) (*entity.Location, error)
+	GetLocations(ctx context.Context, tenant types.TenantType) ([]*entity.Location, error)
+	UpdateLocation(ctx context.Context, locationID int, payload *entity.LocationPayload) (*entity.Location, error)
+}
+
+type LocationUsecase struct {
+	repo              repo.LocationRepositoryInterface
+	dbTransactionRepo repo.PostgresTransactionRepositoryInterface
+}
+
+func NewLocationUsecase(r repo.LocationRepositoryInterface, rPgTrx repo.PostgresTransactionRepositoryInterface) *LocationUsecase {
+	return &LocationUsecase{
+		repo:              r,
+		dbTransactionRepo: rPgTrx,
+	}
+}
+
+func (uc *LocationUsecase) CreateLocation(ctx context.Context, payload *entity.LocationPayload) (*entity.Location, error) {
+	functionName := "LocationUsecase.CreateLocation"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	location := payload.ToEntity()
+	if err := uc.saveLocation(ctx, location, uc.repo.CreateLocation); err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return location, nil
+}
+
+func (uc *LocationUsecase) GetLocations(ctx context.Context, tenant types.TenantType) ([]*entity.Location, error) {
+	functionName := "LocationUsecase.GetLocations"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	locations, err := uc.repo.GetLocations(ctx, tenant)
+	if err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.GetLocations: %w", err), functionName)
+	}
+
+	return locations, nil
+}
+
+func (uc *LocationUsecase) UpdateLocation(ctx context.Context, locationID int, payload *entity.LocationPayload) (*entity.Location, error) {
+	functionName := "LocationUsecase.UpdateLocation"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	currentLocation, err := uc.repo.GetLocationByID(ctx, locationID)
+	if err != nil {
+		if err == response.ErrNotFound {
+			return nil, err
+		}
+
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.GetLocationByID: %w", err), functionName)
+	}
+
+	if currentLocation.Tenant != payload.Tenant {
+		return nil, response.ErrForbidden
+	}
+
+	location := payload.ToEntity()
+	location.ID = currentLocation.ID
+	location.CreatedAt = currentLocation.CreatedAt
+	// The default location is only moved by making another location the default one,
+	// so that stock given without location always has a place to go
+	location.IsDefault = location.IsDefault || currentLocation.IsDefault
+	if err := uc.saveLocation(ctx, location, uc.repo.UpdateLocation); err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return location, nil
+}
+
+// saveLocation save the location with the given function in a transaction,
+// the current default location of the tenant is unset when the location becomes the default one
+func (uc *LocationUsecase) saveLocation(ctx context.Context, location *entity.Location, save func(context.Context, interface{}, *entity.Location) error) error {
+	// Begin transaction
+	tx, err := uc.dbTransactionRepo.StartTransactionQuery(ctx)
+	if err != nil {
+		return fmt.Errorf("uc.dbTransactionRepo.StartTransactionQuery: %w", err)
+	}
+
+	// Create flag and defer rollback when flag is true
+	rollbackProcess := true
+	defer func() {
+		if rollbackProcess {
+			uc.dbTransactionRepo.RollbackTransactionQuery(ctx, tx)
+		}
+	}()
+
+	if location.IsDefault {
+		if err := uc.repo.UnsetDefaultLocation(ctx, tx, location.Tenant); err != nil {
+			return fmt.Errorf("uc.repo.UnsetDefaultLocation: %w", err)
+		}
+	}
+
+	if err := save(ctx, tx, location); err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return customErr
+		}
+		return fmt.Errorf("save: %w", err)
+	}
+
+	// Commit transaction
+	if err = uc.dbTransactionRepo.CommitTransactionQuery(ctx, tx); err != nil {
+		return fmt.Errorf("uc.dbTransactionRepo.CommitTransactionQuery: %w", err)
+	}
+	rollbackProcess = false
+
+	return nil
+}
@@ -0,0 +1,259 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func validLocationPayload() *entity.LocationPayload {
+	return &entity.LocationPayload{
+		Code:     "JKT-1",
+		Name:     "Jakarta Warehouse",
+		Priority: 10,
+		Tenant:   types.TenantLoremType,
+	}
+}
+
+func TestCreateLocation(t *testing.T) {
+	defaultPayload := validLocationPayload()
+	defaultPayload.IsDefault = true
+
+	testcases := []struct {
+		name          string
+		ctx           context.Context
+		payload       *entity.LocationPayload
+		rStartTrxErr  error
+		rUnsetErr     error
+		rCreateErr    error
+		rCommitTrxErr error
+		wantErr       bool
+		wantCustomErr error
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "invalid payload",
+			ctx:           context.Background(),
+			payload:       &entity.LocationPayload{Code: "jkt 1", Name: "Jakarta", Tenant: types.TenantLoremType},
+			wantErr:       true,
+			wantCustomErr: response.ErrInvalidLocationCode,
+		},
+		{
+			name:         "failed to start transaction",
+			ctx:          context.Background(),
+			payload:      validLocationPayload(),
+			rStartTrxErr: response.ErrNoSQLTransactionFound,
+			wantErr:      true,
+		},
+		{
+			name:      "failed to unset default location",
+			ctx:       context.Background(),
+			payload:   defaultPayload,
+			rUnsetErr: errors.New("error unset default location"),
+			wantErr:   true,
+		},
+		{
+			name:          "duplicate code",
+			ctx:           context.Background(),
+			payload:       validLocationPayload(),
+			rCreateErr:    response.ErrDuplicateLocationCode,
+			wantErr:       true,
+			wantCustomErr: response.ErrDuplicateLocationCode,
+		},
+		{
+			name:       "failed to create location",
+			ctx:        context.Background(),
+			payload:    validLocationPayload(),
+			rCreateErr: errors.New("error create location"),
+			wantErr:    true,
+		},
+		{
+			name:          "failed to commit transaction",
+			ctx:           context.Background(),
+			payload:       validLocationPayload(),
+			rCommitTrxErr: response.ErrNoSQLTransactionFound,
+			wantErr:       true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			payload: validLocationPayload(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			locationRepo := &testmock.LocationRepositoryInterface{}
+			locationRepo.On("UnsetDefaultLocation", mock.Anything, mock.Anything, mock.Anything).Return(tc.rUnsetErr)
+			locationRepo.On("CreateLocation", mock.Anything, mock.Anything, mock.Anything).Return(tc.rCreateErr)
+
+			dbTransactionRepo := &testmock.PostgresTransactionRepositoryInterface{}
+			dbTransactionRepo.On("StartTransactionQuery", mock.Anything).Return(&sqlx.Tx{}, tc.rStartTrxErr)
+			dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(tc.rCommitTrxErr)
+			dbTransactionRepo.On("RollbackTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+
+			uc := usecase.NewLocationUsecase(locationRepo, dbTransactionRepo)
+			res, err := uc.CreateLocation(tc.ctx, tc.payload)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, tc.wantCustomErr, err)
+			}
+			if !tc.wantErr {
+				assert.Equal(t, "jkt-1", res.Code)
+			}
+		})
+	}
+}
+
+func TestGetLocations(t *testing.T) {
+	testcases := []struct {
+		name          string
+		ctx           context.Context
+		rLocationsErr error
+		wantErr       bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "failed to get locations",
+			ctx:           context.Background(),
+			rLocationsErr: errors.New("error get locations"),
+			wantErr:       true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			locationRepo := &testmock.LocationRepositoryInterface{}
+			locationRepo.On("GetLocations", mock.Anything, mock.Anything).Return([]*entity.Location{{ID: 1}, {ID: 2}}, tc.rLocationsErr)
+
+			uc := usecase.NewLocationUsecase(locationRepo, &testmock.PostgresTransactionRepositoryInterface{})
+			res, err := uc.GetLocations(tc.ctx, types.TenantLoremType)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, 2, len(res))
+			}
+		})
+	}
+}
+
+func TestUpdateLocation(t *testing.T) {
+	testcases := []struct {
+		name            string
+		ctx             context.Context
+		payload         *entity.LocationPayload
+		rLocationRes    *entity.Location
+		rLocationErr    error
+		rUpdateErr      error
+		wantErr         bool
+		wantCustomError error
+		wantIsDefault   bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:            "invalid payload",
+			ctx:             context.Background(),
+			payload:         &entity.LocationPayload{Code: "jkt-1"},
+			wantErr:         true,
+			wantCustomError: response.ErrInvalidLocationName,
+		},
+		{
+			name:            "location is not found",
+			ctx:             context.Background(),
+			payload:         validLocationPayload(),
+			rLocationErr:    response.ErrNotFound,
+			wantErr:         true,
+			wantCustomError: response.ErrNotFound,
+		},
+		{
+			name:         "failed to get location",
+			ctx:          context.Background(),
+			payload:      validLocationPayload(),
+			rLocationErr: errors.New("error get location"),
+			wantErr:      true,
+		},
+		{
+			name:            "forbidden",
+			ctx:             context.Background(),
+			payload:         validLocationPayload(),
+			rLocationRes:    &entity.Location{ID: 1, Tenant: types.TenantIpsumType},
+			wantErr:         true,
+			wantCustomError: response.ErrForbidden,
+		},
+		{
+			name:         "failed to update location",
+			ctx:          context.Background(),
+			payload:      validLocationPayload(),
+			rLocationRes: &entity.Location{ID: 1, Tenant: types.TenantLoremType},
+			rUpdateErr:   errors.New("error update location"),
+			wantErr:      true,
+		},
+		{
+			name:         "success",
+			ctx:          context.Background(),
+			payload:      validLocationPayload(),
+			rLocationRes: &entity.Location{ID: 1, Tenant: types.TenantLoremType},
+			wantErr:      false,
+		},
+		{
+			name:          "success keeps the default location",
+			ctx:           context.Background(),
+			payload:       validLocationPayload(),
+			rLocationRes:  &entity.Location{ID: 1, Tenant: types.TenantLoremType, IsDefault: true},
+			wantErr:       false,
+			wantIsDefault: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			locationRepo := &testmock.LocationRepositoryInterface{}
+			locationRepo.On("GetLocationByID", mock.Anything, mock.Anything).Return(tc.rLocationRes, tc.rLocationErr)
+			locationRepo.On("UnsetDefaultLocation", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			locationRepo.On("UpdateLocation", mock.Anything, mock.Anything, mock.Anything).Return(tc.rUpdateErr)
+
+			dbTransactionRepo := &testmock.PostgresTransactionRepositoryInterface{}
+			dbTransactionRepo.On("StartTransactionQuery", mock.Anything).Return(&sqlx.Tx{}, nil)
+			dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+			dbTransactionRepo.On("RollbackTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+
+			uc := usecase.NewLocationUsecase(locationRepo, dbTransactionRepo)
+			res, err := uc.UpdateLocation(tc.ctx, 1, tc.payload)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustomError != nil {
+				assert.Equal(t, tc.wantCustomError, err)
+			}
+			if !tc.wantErr {
+				assert.Equal(t, 1, res.ID)
+				assert.Equal(t, tc.wantIsDefault, res.IsDefault)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	repo "github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/pkg/notifier"
+)
+
+const (
+	// lowStockAlertsBatchSize is the max number of alerts sent on each run of the dispatcher
+	lowStockAlertsBatchSize = 100
+)
+
+// LowStockUsecaseInterface define contract for low stock related functions to usecase
+type LowStockUsecaseInterface interface {
+	GetLowStockThreshold(ctx context.Context, tenant types.TenantType) (*entity.LowStockThreshold, error)
+	UpdateLowStockThreshold(ctx context.Context, payload *entity.LowStockThresholdPayload) (*entity.LowStockThreshold, error)
+	DispatchLowStockAlerts(ctx context.Context) error
+}
+
+type LowStockUsecase struct {
+	repo              repo.LowStockRepositoryInterface
+	dbTransactionRepo repo.PostgresTransactionRepositoryInterface
+	notifier          notifier.NotifierInterface
+}
+
+func NewLowStockUsecase(r repo.LowStockRepositoryInterface, rPgTrx repo.PostgresTransactionRepositoryInterface, n notifier.NotifierInterface) *LowStockUsecase {
+	return &LowStockUsecase{
+		repo:              r,
+		dbTransactionRepo: rPgTrx,
+		notifier:          n,
+	}
+}
+
+func (uc *LowStockUsecase) GetLowStockThreshold(ctx context.Context, tenant types.TenantType) (*entity.LowStockThreshold, error) {
+	functionName := "LowStockUsecase.GetLowStockThreshold"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	threshold, err := uc.repo.GetLowStockThreshold(ctx, tenant)
+	if err != nil {
+		if err == response.ErrNotFound {
+			return nil, err
+		}
+
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.GetLowStockThreshold: %w", err), functionName)
+	}
+
+	return threshold, nil
+}
+
+// UpdateLowStockThreshold set the threshold used by the products of the tenant which have no threshold of their own.
+// The new threshold is checked on the next stock change of each product
+func (uc *LowStockUsecase) UpdateLowStockThreshold(ctx context.Context, payload *entity.LowStockThresholdPayload) (*entity.LowStockThreshold, error) {
+	functionName := "LowStockUsecase.UpdateLowStockThreshold"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	threshold := payload.ToEntity()
+	if err := uc.repo.UpsertLowStockThreshold(ctx, threshold); err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.UpsertLowStockThreshold: %w", err), functionName)
+	}
+
+	return threshold, nil
+}
+
+// DispatchLowStockAlerts send the pending alerts through the notifier from the oldest one.
+// The alerts are kept pending from the first one which failed to be sent, so that they are sent in order on the next run
+func (uc *LowStockUsecase) DispatchLowStockAlerts(ctx context.Context) error {
+	functionName := "LowStockUsecase.DispatchLowStockAlerts"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	// Begin transaction
+	tx, err := uc.dbTransactionRepo.StartTransactionQuery(ctx)
+	if err != nil {
+		return errors.Wrap(fmt.Errorf("uc.dbTransactionRepo.StartTransactionQuery: %w", err), functionName)
+	}
+
+	// Create flag and defer rollback when flag is true
+	rollbackProcess := true
+	defer func() {
+		if rollbackProcess {
+			uc.dbTransactionRepo.RollbackTransactionQuery(ctx, tx)
+		}
+	}()
+
+	alerts, err := uc.repo.GetPendingLowStockAlertsForUpdate(ctx, tx, lowStockAlertsBatchSize)
+	if err != nil {
+		return errors.Wrap(fmt.Errorf("uc.repo.GetPendingLowStockAlertsForUpdate: %w", err), functionName)
+	}
+
+	var notifyErr error
+	sentAlertIDs := make([]int, 0, len(alerts))
+	for _, alert := range alerts {
+		event := &notifier.Event{Type: entity.LowStockAlertEventType, OccurredAt: alert.CreatedAt, Data: alert}
+		if notifyErr = uc.notifier.Notify(ctx, event); notifyErr != nil {
+			break
+		}
+
+		sentAlertIDs = append(sentAlertIDs, alert.ID)
+	}
+
+	if err := uc.repo.MarkLowStockAlertsSent(ctx, tx, sentAlertIDs, time.Now()); err != nil {
+		return errors.Wrap(fmt.Errorf("uc.repo.MarkLowStockAlertsSent: %w", err), functionName)
+	}
+
+	// Commit transaction
+	if err = uc.dbTransactionRepo.CommitTransactionQuery(ctx, tx); err != nil {
+		return errors.Wrap(fmt.Errorf("uc.dbTransactionRepo.CommitTransactionQuery: %w", err), functionName)
+	}
+	rollbackProcess = false
+
+	if notifyErr != nil {
+		return errors.Wrap(fmt.Errorf("uc.notifier.Notify: %w", notifyErr), functionName)
+	}
+
+	return nil
+}
+
+// recordLowStock keep the low stock alert state of the product in sync with its qty on the given transaction.
+// An alert is created only when the product falls to its threshold, it is not created again until the qty recovers
+func recordLowStock(ctx context.Context, productRepo repo.ProductRepositoryInterface, lowStockRepo repo.LowStockRepositoryInterface, tx interface{}, product *entity.Product) error {
+	var tenantThreshold *entity.LowStockThreshold
+	if product.LowStockThreshold == nil {
+		var err error
+		tenantThreshold, err = lowStockRepo.GetLowStockThreshold(ctx, product.Tenant)
+		if err != nil && err != response.ErrNotFound {
+			return fmt.Errorf("lowStockRepo.GetLowStockThreshold: %w", err)
+		}
+	}
+
+	threshold := product.GetLowStockThreshold(tenantThreshold)
+	isLowStock := product.IsLowStock(threshold)
+	if isLowStock == product.LowStockAlerted {
+		return nil
+	}
+
+	changed, err := productRepo.SetProductLowStockAlerted(ctx, tx, product.ID, isLowStock)
+	if err != nil {
+		return fmt.Errorf("productRepo.SetProductLowStockAlerted: %w", err)
+	}
+	product.LowStockAlerted = isLowStock
+
+	// The state has been changed by a concurrent transaction which created the alert
+	if !changed || !isLowStock {
+		return nil
+	}
+
+	if err := lowStockRepo.CreateLowStockAlert(ctx, tx, entity.NewLowStockAlert(product, *threshold)); err != nil {
+		return fmt.Errorf("lowStockRepo.CreateLowStockAlert: %w", err)
+	}
+
+	return nil
+}
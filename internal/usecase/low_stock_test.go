@@ -0,0 +1,378 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/pkg/notifier"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAdjustQtyProductLowStockAlert(t *testing.T) {
+	threshold := 5
+
+	testcases := []struct {
+		name            string
+		delta           int
+		rGetProductRes  *entity.Product
+		rThresholdRes   *entity.LowStockThreshold
+		rThresholdErr   error
+		rSetAlertedRes  bool
+		rSetAlertedErr  error
+		rCreateAlertErr error
+		wantSetAlerted  bool
+		wantAlerted     bool
+		wantAlert       *entity.LowStockAlert
+		wantErr         bool
+	}{
+		{
+			name:           "no threshold",
+			delta:          -8,
+			rGetProductRes: &entity.Product{ID: 123, Tenant: types.TenantLoremType, Qty: 10},
+			rThresholdErr:  response.ErrNotFound,
+			wantErr:        false,
+		},
+		{
+			name:           "failed to get tenant threshold",
+			delta:          -8,
+			rGetProductRes: &entity.Product{ID: 123, Tenant: types.TenantLoremType, Qty: 10},
+			rThresholdErr:  errors.New("error get threshold"),
+			wantErr:        true,
+		},
+		{
+			name:           "still above product threshold",
+			delta:          -4,
+			rGetProductRes: &entity.Product{ID: 123, Tenant: types.TenantLoremType, Qty: 10, LowStockThreshold: &threshold},
+			wantErr:        false,
+		},
+		{
+			name:           "falls to product threshold",
+			delta:          -5,
+			rGetProductRes: &entity.Product{ID: 123, SKU: "SKU-123", Tenant: types.TenantLoremType, Qty: 10, LowStockThreshold: &threshold},
+			rSetAlertedRes: true,
+			wantSetAlerted: true,
+			wantAlerted:    true,
+			wantAlert:      &entity.LowStockAlert{ProductID: 123, SKU: "SKU-123", Tenant: types.TenantLoremType, Qty: 5, Threshold: 5},
+			wantErr:        false,
+		},
+		{
+			name:           "falls below tenant threshold",
+			delta:          -8,
+			rGetProductRes: &entity.Product{ID: 123, SKU: "SKU-123", Tenant: types.TenantLoremType, Qty: 10},
+			rThresholdRes:  &entity.LowStockThreshold{Tenant: types.TenantLoremType, Threshold: 3},
+			rSetAlertedRes: true,
+			wantSetAlerted: true,
+			wantAlerted:    true,
+			wantAlert:      &entity.LowStockAlert{ProductID: 123, SKU: "SKU-123", Tenant: types.TenantLoremType, Qty: 2, Threshold: 3},
+			wantErr:        false,
+		},
+		{
+			name:           "already alerted",
+			delta:          -1,
+			rGetProductRes: &entity.Product{ID: 123, Tenant: types.TenantLoremType, Qty: 4, LowStockThreshold: &threshold, LowStockAlerted: true},
+			wantErr:        false,
+		},
+		{
+			name:           "alerted by concurrent change",
+			delta:          -5,
+			rGetProductRes: &entity.Product{ID: 123, Tenant: types.TenantLoremType, Qty: 10, LowStockThreshold: &threshold},
+			rSetAlertedRes: false,
+			wantSetAlerted: true,
+			wantAlerted:    true,
+			wantErr:        false,
+		},
+		{
+			name:           "recovers above threshold",
+			delta:          6,
+			rGetProductRes: &entity.Product{ID: 123, Tenant: types.TenantLoremType, Qty: 4, LowStockThreshold: &threshold, LowStockAlerted: true},
+			rSetAlertedRes: true,
+			wantSetAlerted: true,
+			wantErr:        false,
+		},
+		{
+			name:           "failed to set alerted",
+			delta:          -5,
+			rGetProductRes: &entity.Product{ID: 123, Tenant: types.TenantLoremType, Qty: 10, LowStockThreshold: &threshold},
+			rSetAlertedErr: errors.New("error set alerted"),
+			wantErr:        true,
+		},
+		{
+			name:            "failed to create alert",
+			delta:           -5,
+			rGetProductRes:  &entity.Product{ID: 123, Tenant: types.TenantLoremType, Qty: 10, LowStockThreshold: &threshold},
+			rSetAlertedRes:  true,
+			rCreateAlertErr: errors.New("error create alert"),
+			wantErr:         true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("GetProductsForUpdate", mock.Anything, mock.Anything, mock.Anything, []int{123}, mock.Anything).Return(lockedProducts(tc.rGetProductRes), nil)
+			productRepo.On("UpdateProductQty", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			productRepo.On("SetProductLowStockAlerted", mock.Anything, mock.Anything, 123, mock.Anything).Return(tc.rSetAlertedRes, tc.rSetAlertedErr)
+
+			dbTransactionRepo := &testmock.PostgresTransactionRepositoryInterface{}
+			dbTransactionRepo.On("StartTransactionQuery", mock.Anything).Return(&sqlx.Tx{}, nil)
+			dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+			dbTransactionRepo.On("RollbackTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+
+			locationRepo := &testmock.LocationRepositoryInterface{}
+			locationRepo.On("GetOrCreateDefaultLocation", mock.Anything, mock.Anything, mock.Anything).Return(&entity.Location{ID: 1}, nil)
+
+			productStockRepo := &testmock.ProductStockRepositoryInterface{}
+			productStockRepo.On("GetProductLotsByProductIDs", mock.Anything, mock.Anything).Return([]*entity.ProductLot{}, nil)
+			productStockRepo.On("GetProductLotsForUpdate", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.ProductLot{}, nil)
+			productStockRepo.On("UpdateProductLots", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			productStockRepo.On("GetProductStocksForUpdate", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.ProductStock{{LocationID: 1, Qty: tc.rGetProductRes.Qty}}, nil)
+			productStockRepo.On("UpsertProductStocks", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			stockMovementRepo := &testmock.StockMovementRepositoryInterface{}
+			stockMovementRepo.On("CreateStockMovements", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			lowStockRepo := &testmock.LowStockRepositoryInterface{}
+			lowStockRepo.On("GetLowStockThreshold", mock.Anything, types.TenantLoremType).Return(tc.rThresholdRes, tc.rThresholdErr)
+			lowStockRepo.On("CreateLowStockAlert", mock.Anything, mock.Anything, mock.Anything).Return(tc.rCreateAlertErr)
+
+			uc := usecase.NewProductUsecase(productRepo, dbTransactionRepo, &testmock.PriceScheduleRepositoryInterface{}, &testmock.TaxClassRepositoryInterface{}, &testmock.DiscountRuleRepositoryInterface{}, locationRepo, productStockRepo, stockMovementRepo, lowStockRepo, types.AllocationStrategyPriorityType)
+			_, err := uc.AdjustQtyProduct(context.Background(), 123, &entity.AdjustQtyProductPayload{Delta: tc.delta, Tenant: types.TenantLoremType})
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantErr {
+				return
+			}
+
+			if tc.wantSetAlerted {
+				productRepo.AssertCalled(t, "SetProductLowStockAlerted", mock.Anything, mock.Anything, 123, tc.wantAlerted)
+			} else {
+				productRepo.AssertNotCalled(t, "SetProductLowStockAlerted", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			if tc.wantAlert != nil {
+				lowStockRepo.AssertCalled(t, "CreateLowStockAlert", mock.Anything, mock.Anything, tc.wantAlert)
+			} else {
+				lowStockRepo.AssertNotCalled(t, "CreateLowStockAlert", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestGetLowStockThreshold(t *testing.T) {
+	testcases := []struct {
+		name          string
+		ctx           context.Context
+		rThresholdRes *entity.LowStockThreshold
+		rThresholdErr error
+		wantErr       bool
+		wantCustomErr error
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "threshold is not found",
+			ctx:           context.Background(),
+			rThresholdErr: response.ErrNotFound,
+			wantErr:       true,
+			wantCustomErr: response.ErrNotFound,
+		},
+		{
+			name:          "failed to get threshold",
+			ctx:           context.Background(),
+			rThresholdErr: errors.New("error get threshold"),
+			wantErr:       true,
+		},
+		{
+			name:          "success",
+			ctx:           context.Background(),
+			rThresholdRes: &entity.LowStockThreshold{Tenant: types.TenantLoremType, Threshold: 5},
+			wantErr:       false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			lowStockRepo := &testmock.LowStockRepositoryInterface{}
+			lowStockRepo.On("GetLowStockThreshold", mock.Anything, types.TenantLoremType).Return(tc.rThresholdRes, tc.rThresholdErr)
+
+			uc := usecase.NewLowStockUsecase(lowStockRepo, &testmock.PostgresTransactionRepositoryInterface{}, &testmock.NotifierInterface{})
+			res, err := uc.GetLowStockThreshold(tc.ctx, types.TenantLoremType)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, tc.wantCustomErr, err)
+			}
+			if !tc.wantErr {
+				assert.Equal(t, tc.rThresholdRes, res)
+			}
+		})
+	}
+}
+
+func TestUpdateLowStockThreshold(t *testing.T) {
+	testcases := []struct {
+		name          string
+		ctx           context.Context
+		payload       *entity.LowStockThresholdPayload
+		rUpsertErr    error
+		wantErr       bool
+		wantCustomErr error
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "negative threshold",
+			ctx:           context.Background(),
+			payload:       &entity.LowStockThresholdPayload{Threshold: -1, Tenant: types.TenantLoremType},
+			wantErr:       true,
+			wantCustomErr: response.ErrInvalidLowStockThreshold,
+		},
+		{
+			name:       "failed to upsert threshold",
+			ctx:        context.Background(),
+			payload:    &entity.LowStockThresholdPayload{Threshold: 5, Tenant: types.TenantLoremType},
+			rUpsertErr: errors.New("error upsert threshold"),
+			wantErr:    true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			payload: &entity.LowStockThresholdPayload{Threshold: 5, Tenant: types.TenantLoremType},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			lowStockRepo := &testmock.LowStockRepositoryInterface{}
+			lowStockRepo.On("UpsertLowStockThreshold", mock.Anything, mock.Anything).Return(tc.rUpsertErr)
+
+			uc := usecase.NewLowStockUsecase(lowStockRepo, &testmock.PostgresTransactionRepositoryInterface{}, &testmock.NotifierInterface{})
+			res, err := uc.UpdateLowStockThreshold(tc.ctx, tc.payload)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, tc.wantCustomErr, err)
+			}
+			if !tc.wantErr {
+				assert.Equal(t, tc.payload.Threshold, res.Threshold)
+				assert.Equal(t, tc.payload.Tenant, res.Tenant)
+			}
+		})
+	}
+}
+
+func TestDispatchLowStockAlerts(t *testing.T) {
+	alerts := []*entity.LowStockAlert{{ID: 1, ProductID: 123, Qty: 2, Threshold: 5}, {ID: 2, ProductID: 456, Qty: 0, Threshold: 5}}
+
+	testcases := []struct {
+		name          string
+		ctx           context.Context
+		rStartTrxErr  error
+		rGetAlertsRes []*entity.LowStockAlert
+		rGetAlertsErr error
+		rNotifyErrs   []error
+		rMarkSentErr  error
+		rCommitErr    error
+		wantSentIDs   []int
+		wantErr       bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:         "failed to start transaction",
+			ctx:          context.Background(),
+			rStartTrxErr: errors.New("error start transaction"),
+			wantErr:      true,
+		},
+		{
+			name:          "failed to get pending alerts",
+			ctx:           context.Background(),
+			rGetAlertsErr: errors.New("error get alerts"),
+			wantErr:       true,
+		},
+		{
+			name:          "no pending alerts",
+			ctx:           context.Background(),
+			rGetAlertsRes: []*entity.LowStockAlert{},
+			wantSentIDs:   []int{},
+			wantErr:       false,
+		},
+		{
+			name:          "failed to notify",
+			ctx:           context.Background(),
+			rGetAlertsRes: alerts,
+			rNotifyErrs:   []error{nil, errors.New("error notify")},
+			wantSentIDs:   []int{1},
+			wantErr:       true,
+		},
+		{
+			name:          "failed to mark alerts sent",
+			ctx:           context.Background(),
+			rGetAlertsRes: alerts,
+			rNotifyErrs:   []error{nil, nil},
+			rMarkSentErr:  errors.New("error mark sent"),
+			wantErr:       true,
+		},
+		{
+			name:          "failed to commit transaction",
+			ctx:           context.Background(),
+			rGetAlertsRes: alerts,
+			rNotifyErrs:   []error{nil, nil},
+			rCommitErr:    errors.New("error commit"),
+			wantErr:       true,
+		},
+		{
+			name:          "success",
+			ctx:           context.Background(),
+			rGetAlertsRes: alerts,
+			rNotifyErrs:   []error{nil, nil},
+			wantSentIDs:   []int{1, 2},
+			wantErr:       false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			dbTransactionRepo := &testmock.PostgresTransactionRepositoryInterface{}
+			dbTransactionRepo.On("StartTransactionQuery", mock.Anything).Return(&sqlx.Tx{}, tc.rStartTrxErr)
+			dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(tc.rCommitErr)
+			dbTransactionRepo.On("RollbackTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+
+			lowStockRepo := &testmock.LowStockRepositoryInterface{}
+			lowStockRepo.On("GetPendingLowStockAlertsForUpdate", mock.Anything, mock.Anything, 100).Return(tc.rGetAlertsRes, tc.rGetAlertsErr)
+			lowStockRepo.On("MarkLowStockAlertsSent", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.rMarkSentErr)
+
+			n := &testmock.NotifierInterface{}
+			for i, notifyErr := range tc.rNotifyErrs {
+				alert := tc.rGetAlertsRes[i]
+				n.On("Notify", mock.Anything, mock.MatchedBy(func(event *notifier.Event) bool {
+					return event.Type == entity.LowStockAlertEventType && event.Data == alert
+				})).Return(notifyErr)
+			}
+
+			uc := usecase.NewLowStockUsecase(lowStockRepo, dbTransactionRepo, n)
+			err := uc.DispatchLowStockAlerts(tc.ctx)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantSentIDs != nil {
+				lowStockRepo.AssertCalled(t, "MarkLowStockAlertsSent", mock.Anything, mock.Anything, tc.wantSentIDs, mock.Anything)
+				dbTransactionRepo.AssertCalled(t, "CommitTransactionQuery", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
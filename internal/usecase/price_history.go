@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	repo "github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+const (
+	// lowestPriceWindow is the period used to compute the lowest price of a product
+	lowestPriceWindow = 30 * 24 * time.Hour
+)
+
+// PriceHistoryUsecaseInterface define contract for price history related functions to usecase
+type PriceHistoryUsecaseInterface interface {
+	GetProductPriceHistory(ctx context.Context, payload *entity.GetPriceHistoryPayload) (*entity.ProductPriceHistory, error)
+}
+
+type PriceHistoryUsecase struct {
+	repo        repo.PriceHistoryRepositoryInterface
+	productRepo repo.ProductRepositoryInterface
+}
+
+func NewPriceHistoryUsecase(r repo.PriceHistoryRepositoryInterface, rProduct repo.ProductRepositoryInterface) *PriceHistoryUsecase {
+	return &PriceHistoryUsecase{
+		repo:        r,
+		productRepo: rProduct,
+	}
+}
+
+func (uc *PriceHistoryUsecase) GetProductPriceHistory(ctx context.Context, payload *entity.GetPriceHistoryPayload) (*entity.ProductPriceHistory, error) {
+	functionName := "PriceHistoryUsecase.GetProductPriceHistory"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	product, err := uc.productRepo.GetProductByID(ctx, payload.ProductID)
+	if err != nil {
+		if err == response.ErrNotFound {
+			return nil, err
+		}
+
+		return nil, errors.Wrap(fmt.Errorf("uc.productRepo.GetProductByID: %w", err), functionName)
+	}
+
+	if product.Tenant != payload.Tenant {
+		return nil, response.ErrForbidden
+	}
+
+	priceHistories, err := uc.repo.GetPriceHistories(ctx, payload)
+	if err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.GetPriceHistories: %w", err), functionName)
+	}
+
+	lowestPrice, err := uc.repo.GetLowestPriceSince(ctx, product.ID, time.Now().Add(-lowestPriceWindow))
+	if err != nil {
+		if err != response.ErrNotFound {
+			return nil, errors.Wrap(fmt.Errorf("uc.repo.GetLowestPriceSince: %w", err), functionName)
+		}
+
+		// The price has never been recorded, so the current price is the lowest one
+		lowestPrice = product.Price
+		if product.PromotionPrice != nil {
+			lowestPrice = *product.PromotionPrice
+		}
+	}
+
+	return &entity.ProductPriceHistory{
+		ProductID:      product.ID,
+		LowestPrice30d: lowestPrice,
+		Entries:        priceHistories,
+	}, nil
+}
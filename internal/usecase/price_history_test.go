@@ -0,0 +1,121 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetProductPriceHistory(t *testing.T) {
+	from := time.Now()
+	to := from.Add(-time.Hour)
+	promotionPrice := 80
+
+	testcases := []struct {
+		name            string
+		ctx             context.Context
+		payload         *entity.GetPriceHistoryPayload
+		rGetProductRes  *entity.Product
+		rGetProductErr  error
+		rHistoriesErr   error
+		rLowestPriceRes int
+		rLowestPriceErr error
+		wantLowestPrice int
+		wantErr         bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:    "invalid date range",
+			ctx:     context.Background(),
+			payload: &entity.GetPriceHistoryPayload{From: &from, To: &to},
+			wantErr: true,
+		},
+		{
+			name:           "product is not found",
+			ctx:            context.Background(),
+			payload:        &entity.GetPriceHistoryPayload{},
+			rGetProductErr: response.ErrNotFound,
+			wantErr:        true,
+		},
+		{
+			name:           "failed to get product",
+			ctx:            context.Background(),
+			payload:        &entity.GetPriceHistoryPayload{},
+			rGetProductErr: errors.New("error get product"),
+			wantErr:        true,
+		},
+		{
+			name:           "forbidden",
+			ctx:            context.Background(),
+			payload:        &entity.GetPriceHistoryPayload{Tenant: types.TenantIpsumType},
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType},
+			wantErr:        true,
+		},
+		{
+			name:           "failed to get price histories",
+			ctx:            context.Background(),
+			payload:        &entity.GetPriceHistoryPayload{},
+			rGetProductRes: &entity.Product{},
+			rHistoriesErr:  errors.New("error get price histories"),
+			wantErr:        true,
+		},
+		{
+			name:            "failed to get lowest price",
+			ctx:             context.Background(),
+			payload:         &entity.GetPriceHistoryPayload{},
+			rGetProductRes:  &entity.Product{},
+			rLowestPriceErr: errors.New("error get lowest price"),
+			wantErr:         true,
+		},
+		{
+			name:            "success without recorded price",
+			ctx:             context.Background(),
+			payload:         &entity.GetPriceHistoryPayload{},
+			rGetProductRes:  &entity.Product{Price: 100, PromotionPrice: &promotionPrice},
+			rLowestPriceErr: response.ErrNotFound,
+			wantLowestPrice: 80,
+			wantErr:         false,
+		},
+		{
+			name:            "success",
+			ctx:             context.Background(),
+			payload:         &entity.GetPriceHistoryPayload{},
+			rGetProductRes:  &entity.Product{Price: 100},
+			rLowestPriceRes: 90,
+			wantLowestPrice: 90,
+			wantErr:         false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("GetProductByID", mock.Anything, mock.Anything).Return(tc.rGetProductRes, tc.rGetProductErr)
+
+			priceHistoryRepo := &testmock.PriceHistoryRepositoryInterface{}
+			priceHistoryRepo.On("GetPriceHistories", mock.Anything, mock.Anything).Return([]*entity.PriceHistory{}, tc.rHistoriesErr)
+			priceHistoryRepo.On("GetLowestPriceSince", mock.Anything, mock.Anything, mock.Anything).Return(tc.rLowestPriceRes, tc.rLowestPriceErr)
+
+			uc := usecase.NewPriceHistoryUsecase(priceHistoryRepo, productRepo)
+			res, err := uc.GetProductPriceHistory(tc.ctx, tc.payload)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, tc.wantLowestPrice, res.LowestPrice30d)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	repo "github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+const (
+	// duePriceSchedulesBatchSize is the max number of price schedules processed on each scheduler run
+	duePriceSchedulesBatchSize = 100
+)
+
+// priceColumns list the product columns changed by the price schedules, so that the other attributes changed
+// since the product was read are not overwritten
+var priceColumns = []string{"price", "promotion_price"}
+
+// PriceScheduleUsecaseInterface define contract for price schedule related functions to usecase
+type PriceScheduleUsecaseInterface interface {
+	CreatePriceSchedule(ctx context.Context, payload *entity.PriceSchedulePayload) (*entity.PriceSchedule, error)
+	GetPriceSchedules(ctx context.Context, tenant types.TenantType, productID int) ([]*entity.PriceSchedule, error)
+	CancelPriceSchedule(ctx context.Context, tenant types.TenantType, productID int, priceScheduleID int) (*entity.PriceSchedule, error)
+	ApplyDuePriceSchedules(ctx context.Context) error
+}
+
+type PriceScheduleUsecase struct {
+	repo              repo.PriceScheduleRepositoryInterface
+	productRepo       repo.ProductRepositoryInterface
+	dbTransactionRepo repo.PostgresTransactionRepositoryInterface
+}
+
+func NewPriceScheduleUsecase(r repo.PriceScheduleRepositoryInterface, rProduct repo.ProductRepositoryInterface, rPgTrx repo.PostgresTransactionRepositoryInterface) *PriceScheduleUsecase {
+	return &PriceScheduleUsecase{
+		repo:              r,
+		productRepo:       rProduct,
+		dbTransactionRepo: rPgTrx,
+	}
+}
+
+func (uc *PriceScheduleUsecase) CreatePriceSchedule(ctx context.Context, payload *entity.PriceSchedulePayload) (*entity.PriceSchedule, error) {
+	functionName := "PriceScheduleUsecase.CreatePriceSchedule"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.getProduct(ctx, payload.Tenant, payload.ProductID); err != nil {
+		return nil, err
+	}
+
+	if payload.Type == types.PriceSchedulePromotionType {
+		count, err := uc.repo.GetOverlappingPromotionsCount(ctx, payload.ProductID, payload.StartsAt, *payload.EndsAt)
+		if err != nil {
+			return nil, errors.Wrap(fmt.Errorf("uc.repo.GetOverlappingPromotionsCount: %w", err), functionName)
+		}
+
+		if count > 0 {
+			return nil, response.ErrOverlappingPromotion
+		}
+	}
+
+	priceSchedule := payload.ToEntity()
+	if err := uc.repo.CreatePriceSchedule(ctx, priceSchedule); err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.CreatePriceSchedule: %w", err), functionName)
+	}
+
+	return priceSchedule, nil
+}
+
+func (uc *PriceScheduleUsecase) GetPriceSchedules(ctx context.Context, tenant types.TenantType, productID int) ([]*entity.PriceSchedule, error) {
+	functionName := "PriceScheduleUsecase.GetPriceSchedules"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if _, err := uc.getProduct(ctx, tenant, productID); err != nil {
+		return nil, err
+	}
+
+	priceSchedules, err := uc.repo.GetPriceSchedulesByProductID(ctx, productID)
+	if err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.GetPriceSchedulesByProductID: %w", err), functionName)
+	}
+
+	return priceSchedules, nil
+}
+
+func (uc *PriceScheduleUsecase) CancelPriceSchedule(ctx context.Context, tenant types.TenantType, productID int, priceScheduleID int) (*entity.PriceSchedule, error) {
+	functionName := "PriceScheduleUsecase.CancelPriceSchedule"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	product, err := uc.getProduct(ctx, tenant, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	priceSchedule, err := uc.repo.GetPriceScheduleByID(ctx, priceScheduleID)
+	if err != nil {
+		if err == response.ErrNotFound {
+			return nil, err
+		}
+
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.GetPriceScheduleByID: %w", err), functionName)
+	}
+
+	if priceSchedule.ProductID != product.ID {
+		return nil, response.ErrNotFound
+	}
+
+	fromStatus := priceSchedule.Status
+	if fromStatus != types.PriceScheduleStatusPendingType && fromStatus != types.PriceScheduleStatusActiveType {
+		return nil, response.ErrPriceScheduleNotCancellable
+	}
+
+	// Begin transaction
+	tx, err := uc.dbTransactionRepo.StartTransactionQuery(ctx)
+	if err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.dbTransactionRepo.StartTransactionQuery: %w", err), functionName)
+	}
+
+	// Create flag and defer rollback when flag is true
+	rollbackProcess := true
+	defer func() {
+		if rollbackProcess {
+			uc.dbTransactionRepo.RollbackTransactionQuery(ctx, tx)
+		}
+	}()
+
+	priceSchedule.Status = types.PriceScheduleStatusCancelledType
+	if err := uc.repo.UpdatePriceScheduleStatus(ctx, tx, priceSchedule, fromStatus); err != nil {
+		if err == response.ErrNotFound {
+			return nil, response.ErrPriceScheduleNotCancellable
+		}
+
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.UpdatePriceScheduleStatus: %w", err), functionName)
+	}
+
+	// Revert the running promotion right away, on the locked product so the changes since it was read are kept
+	if fromStatus == types.PriceScheduleStatusActiveType {
+		lockedProduct, err := uc.lockProduct(ctx, tx, priceSchedule.Tenant, product.ID)
+		if err != nil {
+			if err == response.ErrNotFound {
+				return nil, err
+			}
+
+			return nil, errors.Wrap(err, functionName)
+		}
+
+		lockedProduct.PromotionPrice = nil
+		if err := uc.productRepo.UpdateProduct(ctx, tx, lockedProduct, priceColumns); err != nil {
+			if err == response.ErrStaleVersion {
+				return nil, err
+			}
+
+			return nil, errors.Wrap(fmt.Errorf("uc.productRepo.UpdateProduct: %w", err), functionName)
+		}
+	}
+
+	// Commit transaction
+	if err = uc.dbTransactionRepo.CommitTransactionQuery(ctx, tx); err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.dbTransactionRepo.CommitTransactionQuery: %w", err), functionName)
+	}
+	rollbackProcess = false
+
+	return priceSchedule, nil
+}
+
+// ApplyDuePriceSchedules apply the price schedules which already started and revert the promotions which already ended,
+// the errors of the price schedules which failed are returned together once the batch is done
+func (uc *PriceScheduleUsecase) ApplyDuePriceSchedules(ctx context.Context) error {
+	functionName := "PriceScheduleUsecase.ApplyDuePriceSchedules"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	now := time.Now()
+	priceSchedules, err := uc.repo.GetDuePriceSchedules(ctx, now, duePriceSchedulesBatchSize)
+	if err != nil {
+		return errors.Wrap(fmt.Errorf("uc.repo.GetDuePriceSchedules: %w", err), functionName)
+	}
+
+	// A failed price schedule is tried again on the next run, the other price schedules of the batch are still applied
+	var failed batchError
+	for _, priceSchedule := range priceSchedules {
+		if err := uc.applyPriceSchedule(ctx, priceSchedule, now); err != nil {
+			failed = append(failed, fmt.Errorf("price schedule %d: %w", priceSchedule.ID, err))
+		}
+	}
+
+	if err := failed.errOrNil(); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// applyPriceSchedule apply the price schedule on the product locked in the transaction,
+// so the price is changed from the current row and only the price columns are written
+func (uc *PriceScheduleUsecase) applyPriceSchedule(ctx context.Context, priceSchedule *entity.PriceSchedule, now time.Time) error {
+	// Begin transaction
+	tx, err := uc.dbTransactionRepo.StartTransactionQuery(ctx)
+	if err != nil {
+		return fmt.Errorf("uc.dbTransactionRepo.StartTransactionQuery: %w", err)
+	}
+
+	// Create flag and defer rollback when flag is true
+	rollbackProcess := true
+	defer func() {
+		if rollbackProcess {
+			uc.dbTransactionRepo.RollbackTransactionQuery(ctx, tx)
+		}
+	}()
+
+	product, err := uc.lockProduct(ctx, tx, priceSchedule.Tenant, priceSchedule.ProductID)
+	if err != nil {
+		return err
+	}
+
+	fromStatus := priceSchedule.Status
+	if !priceSchedule.Apply(product, now) {
+		return nil
+	}
+
+	if err := uc.repo.UpdatePriceScheduleStatus(ctx, tx, priceSchedule, fromStatus); err != nil {
+		// The schedule has been processed by another scheduler instance
+		if err == response.ErrNotFound {
+			return nil
+		}
+
+		return fmt.Errorf("uc.repo.UpdatePriceScheduleStatus: %w", err)
+	}
+
+	if err := uc.productRepo.UpdateProduct(ctx, tx, product, priceColumns); err != nil {
+		return fmt.Errorf("uc.productRepo.UpdateProduct: %w", err)
+	}
+
+	// Commit transaction
+	if err = uc.dbTransactionRepo.CommitTransactionQuery(ctx, tx); err != nil {
+		return fmt.Errorf("uc.dbTransactionRepo.CommitTransactionQuery: %w", err)
+	}
+	rollbackProcess = false
+
+	return nil
+}
+
+// lockProduct lock the product in the transaction until it is committed
+func (uc *PriceScheduleUsecase) lockProduct(ctx context.Context, tx interface{}, tenant types.TenantType, productID int) (*entity.Product, error) {
+	products, err := uc.productRepo.GetProductsForUpdate(ctx, tx, tenant, []int{productID}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("uc.productRepo.GetProductsForUpdate: %w", err)
+	}
+
+	if len(products) == 0 {
+		return nil, response.ErrNotFound
+	}
+
+	return products[0], nil
+}
+
+func (uc *PriceScheduleUsecase) getProduct(ctx context.Context, tenant types.TenantType, productID int) (*entity.Product, error) {
+	product, err := uc.productRepo.GetProductByID(ctx, productID)
+	if err != nil {
+		if err == response.ErrNotFound {
+			return nil, err
+		}
+
+		return nil, errors.Wrap(fmt.Errorf("uc.productRepo.GetProductByID: %w", err), "PriceScheduleUsecase.getProduct")
+	}
+
+	if product.Tenant != tenant {
+		return nil, response.ErrForbidden
+	}
+
+	return product, nil
+}
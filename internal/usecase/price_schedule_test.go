@@ -0,0 +1,511 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreatePriceSchedule(t *testing.T) {
+	startsAt := time.Now().Add(time.Hour)
+	endsAt := startsAt.Add(24 * time.Hour)
+
+	testcases := []struct {
+		name           string
+		ctx            context.Context
+		payload        *entity.PriceSchedulePayload
+		rGetProductRes *entity.Product
+		rGetProductErr error
+		rOverlapRes    int
+		rOverlapErr    error
+		rCreateErr     error
+		wantErr        bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:    "invalid payload",
+			ctx:     context.Background(),
+			payload: &entity.PriceSchedulePayload{Type: types.PriceSchedulePromotionType, Price: 100, StartsAt: startsAt, Tenant: types.TenantLoremType},
+			wantErr: true,
+		},
+		{
+			name:           "product is not found",
+			ctx:            context.Background(),
+			payload:        &entity.PriceSchedulePayload{Type: types.PriceSchedulePriceChangeType, Price: 100, StartsAt: startsAt, Tenant: types.TenantLoremType},
+			rGetProductErr: response.ErrNotFound,
+			wantErr:        true,
+		},
+		{
+			name:           "failed to get product",
+			ctx:            context.Background(),
+			payload:        &entity.PriceSchedulePayload{Type: types.PriceSchedulePriceChangeType, Price: 100, StartsAt: startsAt, Tenant: types.TenantLoremType},
+			rGetProductErr: errors.New("error get product"),
+			wantErr:        true,
+		},
+		{
+			name:           "forbidden",
+			ctx:            context.Background(),
+			payload:        &entity.PriceSchedulePayload{Type: types.PriceSchedulePriceChangeType, Price: 100, StartsAt: startsAt, Tenant: types.TenantIpsumType},
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType},
+			wantErr:        true,
+		},
+		{
+			name:           "failed to count overlapping promotions",
+			ctx:            context.Background(),
+			payload:        &entity.PriceSchedulePayload{Type: types.PriceSchedulePromotionType, Price: 100, StartsAt: startsAt, EndsAt: &endsAt, Tenant: types.TenantLoremType},
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType},
+			rOverlapErr:    errors.New("error count overlapping promotions"),
+			wantErr:        true,
+		},
+		{
+			name:           "overlapping promotion",
+			ctx:            context.Background(),
+			payload:        &entity.PriceSchedulePayload{Type: types.PriceSchedulePromotionType, Price: 100, StartsAt: startsAt, EndsAt: &endsAt, Tenant: types.TenantLoremType},
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType},
+			rOverlapRes:    1,
+			wantErr:        true,
+		},
+		{
+			name:           "failed to create price schedule",
+			ctx:            context.Background(),
+			payload:        &entity.PriceSchedulePayload{Type: types.PriceSchedulePriceChangeType, Price: 100, StartsAt: startsAt, Tenant: types.TenantLoremType},
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType},
+			rCreateErr:     errors.New("error create price schedule"),
+			wantErr:        true,
+		},
+		{
+			name:           "success",
+			ctx:            context.Background(),
+			payload:        &entity.PriceSchedulePayload{Type: types.PriceSchedulePromotionType, Price: 100, StartsAt: startsAt, EndsAt: &endsAt, Tenant: types.TenantLoremType},
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType},
+			wantErr:        false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("GetProductByID", mock.Anything, mock.Anything).Return(tc.rGetProductRes, tc.rGetProductErr)
+
+			priceScheduleRepo := &testmock.PriceScheduleRepositoryInterface{}
+			priceScheduleRepo.On("GetOverlappingPromotionsCount", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.rOverlapRes, tc.rOverlapErr)
+			priceScheduleRepo.On("CreatePriceSchedule", mock.Anything, mock.Anything).Return(tc.rCreateErr)
+
+			uc := usecase.NewPriceScheduleUsecase(priceScheduleRepo, productRepo, &testmock.PostgresTransactionRepositoryInterface{})
+			res, err := uc.CreatePriceSchedule(tc.ctx, tc.payload)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, types.PriceScheduleStatusPendingType, res.Status)
+			}
+		})
+	}
+}
+
+func TestGetPriceSchedules(t *testing.T) {
+	testcases := []struct {
+		name           string
+		ctx            context.Context
+		tenant         types.TenantType
+		rGetProductRes *entity.Product
+		rGetProductErr error
+		rSchedulesErr  error
+		wantErr        bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:           "product is not found",
+			ctx:            context.Background(),
+			rGetProductErr: response.ErrNotFound,
+			wantErr:        true,
+		},
+		{
+			name:           "forbidden",
+			ctx:            context.Background(),
+			tenant:         types.TenantIpsumType,
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType},
+			wantErr:        true,
+		},
+		{
+			name:           "failed to get price schedules",
+			ctx:            context.Background(),
+			rGetProductRes: &entity.Product{},
+			rSchedulesErr:  errors.New("error get price schedules"),
+			wantErr:        true,
+		},
+		{
+			name:           "success",
+			ctx:            context.Background(),
+			rGetProductRes: &entity.Product{},
+			wantErr:        false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("GetProductByID", mock.Anything, mock.Anything).Return(tc.rGetProductRes, tc.rGetProductErr)
+
+			priceScheduleRepo := &testmock.PriceScheduleRepositoryInterface{}
+			priceScheduleRepo.On("GetPriceSchedulesByProductID", mock.Anything, mock.Anything).Return([]*entity.PriceSchedule{}, tc.rSchedulesErr)
+
+			uc := usecase.NewPriceScheduleUsecase(priceScheduleRepo, productRepo, &testmock.PostgresTransactionRepositoryInterface{})
+			_, err := uc.GetPriceSchedules(tc.ctx, tc.tenant, 123)
+			assert.Equal(t, tc.wantErr, err != nil)
+		})
+	}
+}
+
+func TestCancelPriceSchedule(t *testing.T) {
+	promotionPrice := 80
+
+	testcases := []struct {
+		name              string
+		ctx               context.Context
+		rGetProductRes    *entity.Product
+		rGetProductErr    error
+		rScheduleRes      *entity.PriceSchedule
+		rScheduleErr      error
+		rStartTrxErr      error
+		rLockProductRes   *entity.Product
+		rLockProductErr   error
+		rUpdateStatusErr  error
+		rUpdateProductErr error
+		rCommitTrxErr     error
+		wantPromotion     *int
+		wantErr           bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:           "product is not found",
+			ctx:            context.Background(),
+			rGetProductErr: response.ErrNotFound,
+			wantErr:        true,
+		},
+		{
+			name:           "price schedule is not found",
+			ctx:            context.Background(),
+			rGetProductRes: &entity.Product{ID: 123},
+			rScheduleErr:   response.ErrNotFound,
+			wantErr:        true,
+		},
+		{
+			name:           "failed to get price schedule",
+			ctx:            context.Background(),
+			rGetProductRes: &entity.Product{ID: 123},
+			rScheduleErr:   errors.New("error get price schedule"),
+			wantErr:        true,
+		},
+		{
+			name:           "price schedule belongs to other product",
+			ctx:            context.Background(),
+			rGetProductRes: &entity.Product{ID: 123},
+			rScheduleRes:   &entity.PriceSchedule{ProductID: 456, Status: types.PriceScheduleStatusPendingType},
+			wantErr:        true,
+		},
+		{
+			name:           "price schedule is already completed",
+			ctx:            context.Background(),
+			rGetProductRes: &entity.Product{ID: 123},
+			rScheduleRes:   &entity.PriceSchedule{ProductID: 123, Status: types.PriceScheduleStatusCompletedType},
+			wantErr:        true,
+		},
+		{
+			name:           "failed to start transaction",
+			ctx:            context.Background(),
+			rGetProductRes: &entity.Product{ID: 123},
+			rScheduleRes:   &entity.PriceSchedule{ProductID: 123, Status: types.PriceScheduleStatusPendingType},
+			rStartTrxErr:   response.ErrNoSQLTransactionFound,
+			wantErr:        true,
+		},
+		{
+			name:             "price schedule is processed concurrently",
+			ctx:              context.Background(),
+			rGetProductRes:   &entity.Product{ID: 123},
+			rScheduleRes:     &entity.PriceSchedule{ProductID: 123, Status: types.PriceScheduleStatusPendingType},
+			rUpdateStatusErr: response.ErrNotFound,
+			wantErr:          true,
+		},
+		{
+			name:             "failed to update price schedule status",
+			ctx:              context.Background(),
+			rGetProductRes:   &entity.Product{ID: 123},
+			rScheduleRes:     &entity.PriceSchedule{ProductID: 123, Status: types.PriceScheduleStatusPendingType},
+			rUpdateStatusErr: errors.New("error update price schedule status"),
+			wantErr:          true,
+		},
+		{
+			name:            "failed to lock product",
+			ctx:             context.Background(),
+			rGetProductRes:  &entity.Product{ID: 123, PromotionPrice: &promotionPrice},
+			rScheduleRes:    &entity.PriceSchedule{ProductID: 123, Type: types.PriceSchedulePromotionType, Status: types.PriceScheduleStatusActiveType},
+			rLockProductErr: errors.New("error lock product"),
+			wantErr:         true,
+		},
+		{
+			name:           "product is deleted before it is locked",
+			ctx:            context.Background(),
+			rGetProductRes: &entity.Product{ID: 123, PromotionPrice: &promotionPrice},
+			rScheduleRes:   &entity.PriceSchedule{ProductID: 123, Type: types.PriceSchedulePromotionType, Status: types.PriceScheduleStatusActiveType},
+			wantErr:        true,
+		},
+		{
+			name:              "failed to revert running promotion",
+			ctx:               context.Background(),
+			rGetProductRes:    &entity.Product{ID: 123, PromotionPrice: &promotionPrice},
+			rScheduleRes:      &entity.PriceSchedule{ProductID: 123, Type: types.PriceSchedulePromotionType, Status: types.PriceScheduleStatusActiveType},
+			rLockProductRes:   &entity.Product{ID: 123, PromotionPrice: &promotionPrice},
+			rUpdateProductErr: errors.New("error update product"),
+			wantErr:           true,
+		},
+		{
+			name:           "failed to commit transaction",
+			ctx:            context.Background(),
+			rGetProductRes: &entity.Product{ID: 123},
+			rScheduleRes:   &entity.PriceSchedule{ProductID: 123, Status: types.PriceScheduleStatusPendingType},
+			rCommitTrxErr:  response.ErrNoSQLTransactionFound,
+			wantErr:        true,
+		},
+		{
+			name:            "success cancel running promotion",
+			ctx:             context.Background(),
+			rGetProductRes:  &entity.Product{ID: 123, Qty: 10, PromotionPrice: &promotionPrice},
+			rScheduleRes:    &entity.PriceSchedule{ProductID: 123, Type: types.PriceSchedulePromotionType, Status: types.PriceScheduleStatusActiveType},
+			rLockProductRes: &entity.Product{ID: 123, Qty: 7, PromotionPrice: &promotionPrice},
+			wantPromotion:   nil,
+			wantErr:         false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("GetProductByID", mock.Anything, mock.Anything).Return(tc.rGetProductRes, tc.rGetProductErr)
+			productRepo.On("GetProductsForUpdate", mock.Anything, mock.Anything, mock.Anything, []int{123}, []string(nil)).Return(lockedProducts(tc.rLockProductRes), tc.rLockProductErr)
+			productRepo.On("UpdateProduct", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.rUpdateProductErr)
+
+			priceScheduleRepo := &testmock.PriceScheduleRepositoryInterface{}
+			priceScheduleRepo.On("GetPriceScheduleByID", mock.Anything, mock.Anything).Return(tc.rScheduleRes, tc.rScheduleErr)
+			priceScheduleRepo.On("UpdatePriceScheduleStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.rUpdateStatusErr)
+
+			dbTransactionRepo := &testmock.PostgresTransactionRepositoryInterface{}
+			dbTransactionRepo.On("StartTransactionQuery", mock.Anything).Return(&sqlx.Tx{}, tc.rStartTrxErr)
+			dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(tc.rCommitTrxErr)
+			dbTransactionRepo.On("RollbackTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+
+			uc := usecase.NewPriceScheduleUsecase(priceScheduleRepo, productRepo, dbTransactionRepo)
+			res, err := uc.CancelPriceSchedule(tc.ctx, types.TenantEmptyType, 123, 1)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, types.PriceScheduleStatusCancelledType, res.Status)
+				if tc.rLockProductRes != nil {
+					// The locked product is written, so the qty changed since the product was read is kept
+					productRepo.AssertCalled(t, "UpdateProduct", mock.Anything, mock.Anything, mock.MatchedBy(func(product *entity.Product) bool {
+						return product.Qty == tc.rLockProductRes.Qty && product.PromotionPrice == tc.wantPromotion
+					}), []string{"price", "promotion_price"})
+				}
+			}
+		})
+	}
+}
+
+func TestApplyDuePriceSchedules(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	promotionPrice := 80
+	deletedProductID := 404
+
+	testcases := []struct {
+		name              string
+		ctx               context.Context
+		rSchedulesRes     []*entity.PriceSchedule
+		rSchedulesErr     error
+		rGetProductRes    *entity.Product
+		rGetProductErr    error
+		rStartTrxErr      error
+		rUpdateStatusErr  error
+		rUpdateProductErr error
+		rCommitTrxErr     error
+		wantProduct       *entity.Product
+		wantStatus        types.PriceScheduleStatusType
+		wantErr           bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "failed to get due price schedules",
+			ctx:           context.Background(),
+			rSchedulesErr: errors.New("error get due price schedules"),
+			wantErr:       true,
+		},
+		{
+			name:           "failed to lock product",
+			ctx:            context.Background(),
+			rSchedulesRes:  []*entity.PriceSchedule{{Type: types.PriceSchedulePriceChangeType, Status: types.PriceScheduleStatusPendingType, Price: 90, StartsAt: past}},
+			rGetProductErr: errors.New("error lock product"),
+			wantErr:        true,
+		},
+		{
+			name:          "product is not found",
+			ctx:           context.Background(),
+			rSchedulesRes: []*entity.PriceSchedule{{Type: types.PriceSchedulePriceChangeType, Status: types.PriceScheduleStatusPendingType, Price: 90, StartsAt: past}},
+			wantErr:       true,
+		},
+		{
+			name:           "failed to start transaction",
+			ctx:            context.Background(),
+			rSchedulesRes:  []*entity.PriceSchedule{{Type: types.PriceSchedulePriceChangeType, Status: types.PriceScheduleStatusPendingType, Price: 90, StartsAt: past}},
+			rGetProductRes: &entity.Product{Price: 100},
+			rStartTrxErr:   response.ErrNoSQLTransactionFound,
+			wantErr:        true,
+		},
+		{
+			name:             "failed to update price schedule status",
+			ctx:              context.Background(),
+			rSchedulesRes:    []*entity.PriceSchedule{{Type: types.PriceSchedulePriceChangeType, Status: types.PriceScheduleStatusPendingType, Price: 90, StartsAt: past}},
+			rGetProductRes:   &entity.Product{Price: 100},
+			rUpdateStatusErr: errors.New("error update price schedule status"),
+			wantErr:          true,
+		},
+		{
+			name:              "failed to update product",
+			ctx:               context.Background(),
+			rSchedulesRes:     []*entity.PriceSchedule{{Type: types.PriceSchedulePriceChangeType, Status: types.PriceScheduleStatusPendingType, Price: 90, StartsAt: past}},
+			rGetProductRes:    &entity.Product{Price: 100},
+			rUpdateProductErr: errors.New("error update product"),
+			wantErr:           true,
+		},
+		{
+			name:           "failed to commit transaction",
+			ctx:            context.Background(),
+			rSchedulesRes:  []*entity.PriceSchedule{{Type: types.PriceSchedulePriceChangeType, Status: types.PriceScheduleStatusPendingType, Price: 90, StartsAt: past}},
+			rGetProductRes: &entity.Product{Price: 100},
+			rCommitTrxErr:  response.ErrNoSQLTransactionFound,
+			wantErr:        true,
+		},
+		{
+			name:             "skip price schedule processed by other scheduler",
+			ctx:              context.Background(),
+			rSchedulesRes:    []*entity.PriceSchedule{{Type: types.PriceSchedulePriceChangeType, Status: types.PriceScheduleStatusPendingType, Price: 90, StartsAt: past}},
+			rGetProductRes:   &entity.Product{Price: 100},
+			rUpdateStatusErr: response.ErrNotFound,
+			wantErr:          false,
+		},
+		{
+			name:           "skip price schedule which is not due",
+			ctx:            context.Background(),
+			rSchedulesRes:  []*entity.PriceSchedule{{Type: types.PriceSchedulePriceChangeType, Status: types.PriceScheduleStatusPendingType, Price: 90, StartsAt: future}},
+			rGetProductRes: &entity.Product{Price: 100},
+			wantProduct:    &entity.Product{Price: 100},
+			wantStatus:     types.PriceScheduleStatusPendingType,
+			wantErr:        false,
+		},
+		{
+			name:           "success apply price change",
+			ctx:            context.Background(),
+			rSchedulesRes:  []*entity.PriceSchedule{{Type: types.PriceSchedulePriceChangeType, Status: types.PriceScheduleStatusPendingType, Price: 90, StartsAt: past}},
+			rGetProductRes: &entity.Product{Price: 100},
+			wantProduct:    &entity.Product{Price: 90},
+			wantStatus:     types.PriceScheduleStatusCompletedType,
+			wantErr:        false,
+		},
+		{
+			name:           "success start promotion",
+			ctx:            context.Background(),
+			rSchedulesRes:  []*entity.PriceSchedule{{Type: types.PriceSchedulePromotionType, Status: types.PriceScheduleStatusPendingType, Price: 80, StartsAt: past, EndsAt: &future}},
+			rGetProductRes: &entity.Product{Price: 100},
+			wantProduct:    &entity.Product{Price: 100, PromotionPrice: &promotionPrice},
+			wantStatus:     types.PriceScheduleStatusActiveType,
+			wantErr:        false,
+		},
+		{
+			name:           "success skip promotion which already ended",
+			ctx:            context.Background(),
+			rSchedulesRes:  []*entity.PriceSchedule{{Type: types.PriceSchedulePromotionType, Status: types.PriceScheduleStatusPendingType, Price: 80, StartsAt: past, EndsAt: &past}},
+			rGetProductRes: &entity.Product{Price: 100},
+			wantProduct:    &entity.Product{Price: 100},
+			wantStatus:     types.PriceScheduleStatusCompletedType,
+			wantErr:        false,
+		},
+		{
+			name:           "success revert promotion",
+			ctx:            context.Background(),
+			rSchedulesRes:  []*entity.PriceSchedule{{Type: types.PriceSchedulePromotionType, Status: types.PriceScheduleStatusActiveType, Price: 80, StartsAt: past, EndsAt: &past}},
+			rGetProductRes: &entity.Product{Price: 100, PromotionPrice: &promotionPrice},
+			wantProduct:    &entity.Product{Price: 100},
+			wantStatus:     types.PriceScheduleStatusCompletedType,
+			wantErr:        false,
+		},
+		{
+			name: "failed price schedule does not hold back the others",
+			ctx:  context.Background(),
+			rSchedulesRes: []*entity.PriceSchedule{
+				{ID: 1, ProductID: deletedProductID, Type: types.PriceSchedulePriceChangeType, Status: types.PriceScheduleStatusPendingType, Price: 90, StartsAt: past},
+				{ID: 2, Type: types.PriceSchedulePriceChangeType, Status: types.PriceScheduleStatusPendingType, Price: 90, StartsAt: past},
+			},
+			rGetProductRes: &entity.Product{Price: 100},
+			wantProduct:    &entity.Product{Price: 90},
+			wantStatus:     types.PriceScheduleStatusCompletedType,
+			wantErr:        true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("GetProductsForUpdate", mock.Anything, mock.Anything, mock.Anything, []int{deletedProductID}, []string(nil)).Return([]*entity.Product{}, nil)
+			productRepo.On("GetProductsForUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, []string(nil)).Return(func(context.Context, interface{}, types.TenantType, []int, []string) []*entity.Product {
+				if tc.rGetProductRes == nil {
+					return []*entity.Product{}
+				}
+				return []*entity.Product{tc.rGetProductRes}
+			}, tc.rGetProductErr)
+			productRepo.On("UpdateProduct", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.rUpdateProductErr)
+
+			priceScheduleRepo := &testmock.PriceScheduleRepositoryInterface{}
+			priceScheduleRepo.On("GetDuePriceSchedules", mock.Anything, mock.Anything, mock.Anything).Return(tc.rSchedulesRes, tc.rSchedulesErr)
+			priceScheduleRepo.On("UpdatePriceScheduleStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.rUpdateStatusErr)
+
+			dbTransactionRepo := &testmock.PostgresTransactionRepositoryInterface{}
+			dbTransactionRepo.On("StartTransactionQuery", mock.Anything).Return(&sqlx.Tx{}, tc.rStartTrxErr)
+			dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(tc.rCommitTrxErr)
+			dbTransactionRepo.On("RollbackTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+
+			uc := usecase.NewPriceScheduleUsecase(priceScheduleRepo, productRepo, dbTransactionRepo)
+			err := uc.ApplyDuePriceSchedules(tc.ctx)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantProduct != nil {
+				assert.Equal(t, tc.wantProduct, tc.rGetProductRes)
+				assert.Equal(t, tc.wantStatus, tc.rSchedulesRes[len(tc.rSchedulesRes)-1].Status)
+				if tc.wantStatus != types.PriceScheduleStatusPendingType {
+					productRepo.AssertCalled(t, "UpdateProduct", mock.Anything, mock.Anything, tc.rGetProductRes, []string{"price", "promotion_price"})
+				}
+			}
+		})
+	}
+}
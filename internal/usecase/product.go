@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/satriowisnugroho/catalog/internal/entity"
@@ -15,21 +16,52 @@ import (
 // ProductUsecaseInterface define contract for product related functions to usecase
 type ProductUsecaseInterface interface {
 	CreateProduct(ctx context.Context, payload *entity.ProductPayload) (*entity.Product, error)
-	BulkReduceQtyProduct(ctx context.Context, tenant types.TenantType, payload *entity.BulkReduceQtyProductPayload) ([]*entity.Product, error)
-	GetProductByID(ctx context.Context, tenant types.TenantType, productID int) (*entity.Product, error)
-	GetProducts(ctx context.Context, payload *entity.GetProductPayload) ([]*entity.Product, int, error)
+	BulkReduceQtyProduct(ctx context.Context, tenant types.TenantType, payload *entity.BulkReduceQtyProductPayload) (*entity.BulkReduceQtyProductResult, error)
+	BulkIncreaseQtyProduct(ctx context.Context, tenant types.TenantType, payload *entity.BulkIncreaseQtyProductPayload) ([]*entity.Product, error)
+	AdjustQtyProduct(ctx context.Context, productID int, payload *entity.AdjustQtyProductPayload) (*entity.Product, error)
+	GetProductByID(ctx context.Context, payload *entity.GetProductByIDPayload) (*entity.Product, error)
+	GetProducts(ctx context.Context, payload *entity.GetProductPayload) (*entity.ProductPage, error)
 	UpdateProduct(ctx context.Context, productID int, payload *entity.ProductPayload) (*entity.Product, error)
+	PatchProduct(ctx context.Context, productID int, payload *entity.ProductPatchPayload) (*entity.Product, error)
 }
 
 type ProductUsecase struct {
 	repo              repo.ProductRepositoryInterface
 	dbTransactionRepo repo.PostgresTransactionRepositoryInterface
+	priceScheduleRepo repo.PriceScheduleRepositoryInterface
+	taxClassRepo      repo.TaxClassRepositoryInterface
+	discountRuleRepo  repo.DiscountRuleRepositoryInterface
+	locationRepo      repo.LocationRepositoryInterface
+	productStockRepo  repo.ProductStockRepositoryInterface
+	stockMovementRepo repo.StockMovementRepositoryInterface
+	lowStockRepo      repo.LowStockRepositoryInterface
+	// allocationStrategy pick the locations to reduce stock from when no location is given
+	allocationStrategy types.AllocationStrategyType
 }
 
-func NewProductUsecase(r repo.ProductRepositoryInterface, rPgTrx repo.PostgresTransactionRepositoryInterface) *ProductUsecase {
+func NewProductUsecase(
+	r repo.ProductRepositoryInterface,
+	rPgTrx repo.PostgresTransactionRepositoryInterface,
+	rPriceSchedule repo.PriceScheduleRepositoryInterface,
+	rTaxClass repo.TaxClassRepositoryInterface,
+	rDiscountRule repo.DiscountRuleRepositoryInterface,
+	rLocation repo.LocationRepositoryInterface,
+	rProductStock repo.ProductStockRepositoryInterface,
+	rStockMovement repo.StockMovementRepositoryInterface,
+	rLowStock repo.LowStockRepositoryInterface,
+	allocationStrategy types.AllocationStrategyType,
+) *ProductUsecase {
 	return &ProductUsecase{
-		repo:              r,
-		dbTransactionRepo: rPgTrx,
+		repo:               r,
+		dbTransactionRepo:  rPgTrx,
+		priceScheduleRepo:  rPriceSchedule,
+		taxClassRepo:       rTaxClass,
+		discountRuleRepo:   rDiscountRule,
+		locationRepo:       rLocation,
+		productStockRepo:   rProductStock,
+		stockMovementRepo:  rStockMovement,
+		lowStockRepo:       rLowStock,
+		allocationStrategy: allocationStrategy,
 	}
 }
 
@@ -44,28 +76,353 @@ func (uc *ProductUsecase) CreateProduct(ctx context.Context, payload *entity.Pro
 		return nil, err
 	}
 
+	// The units of a serialized product are only added with their serial numbers
+	if payload.Serialized && payload.Qty > 0 {
+		return nil, response.ErrSerialNumbersRequired
+	}
+
+	if err := uc.validateTaxClass(ctx, payload.Tenant, payload.TaxClassID); err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	stocks, err := uc.getPayloadStocks(ctx, payload)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	// Begin transaction
+	tx, err := uc.dbTransactionRepo.StartTransactionQuery(ctx)
+	if err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.dbTransactionRepo.StartTransactionQuery: %w", err), functionName)
+	}
+
+	// Create flag and defer rollback when flag is true
+	rollbackProcess := true
+	defer func() {
+		if rollbackProcess {
+			uc.dbTransactionRepo.RollbackTransactionQuery(ctx, tx)
+		}
+	}()
+
 	product := payload.ToEntity()
-	if err := uc.repo.CreateProduct(ctx, product); err != nil {
+	if err := uc.repo.CreateProduct(ctx, tx, product); err != nil {
 		if customErr, ok := err.(response.CustomError); ok {
 			return nil, customErr
 		}
 		return nil, errors.Wrap(fmt.Errorf("uc.repo.CreateProduct: %w", err), functionName)
 	}
 
+	// Stock given without location is kept on the default location
+	if stocks == nil && product.Qty > 0 {
+		location, err := uc.locationRepo.GetOrCreateDefaultLocation(ctx, tx, product.Tenant)
+		if err != nil {
+			return nil, errors.Wrap(fmt.Errorf("uc.locationRepo.GetOrCreateDefaultLocation: %w", err), functionName)
+		}
+
+		stocks = []*entity.ProductStock{{LocationID: location.ID, Qty: product.Qty}}
+	}
+
+	if len(stocks) > 0 {
+		if err := uc.productStockRepo.ReplaceProductStocks(ctx, tx, product.ID, stocks); err != nil {
+			return nil, errors.Wrap(fmt.Errorf("uc.productStockRepo.ReplaceProductStocks: %w", err), functionName)
+		}
+	}
+
+	if product.Qty != 0 {
+		reason := payload.StockReason
+		if reason == types.StockMovementReasonEmptyType {
+			reason = types.StockMovementReasonRestockType
+		}
+
+		movement := entity.NewStockMovement(product, product.Qty, reason, payload.Actor, payload.ReferenceID)
+		if err := uc.stockMovementRepo.CreateStockMovements(ctx, tx, []*entity.StockMovement{movement}); err != nil {
+			return nil, errors.Wrap(fmt.Errorf("uc.stockMovementRepo.CreateStockMovements: %w", err), functionName)
+		}
+	}
+
+	if err := recordLowStock(ctx, uc.repo, uc.lowStockRepo, tx, product); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	// Commit transaction
+	if err = uc.dbTransactionRepo.CommitTransactionQuery(ctx, tx); err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.dbTransactionRepo.CommitTransactionQuery: %w", err), functionName)
+	}
+	rollbackProcess = false
+
+	if err := uc.decorateProducts(ctx, payload.Region, product); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if !payload.FinanceScope {
+		product.HideCost()
+	}
+
 	return product, nil
 }
 
-func (uc *ProductUsecase) BulkReduceQtyProduct(ctx context.Context, tenant types.TenantType, payload *entity.BulkReduceQtyProductPayload) ([]*entity.Product, error) {
+// BulkReduceQtyProduct reduce the qty of the items as sales, the qty beyond the available qty of a product
+// is backordered when its backorder policy allows it
+func (uc *ProductUsecase) BulkReduceQtyProduct(ctx context.Context, tenant types.TenantType, payload *entity.BulkReduceQtyProductPayload) (*entity.BulkReduceQtyProductResult, error) {
 	functionName := "ProductUsecase.BulkReduceQtyProduct"
 
 	if err := helper.CheckDeadline(ctx); err != nil {
 		return nil, errors.Wrap(err, functionName)
 	}
 
+	partial := payload.Mode == types.BulkModePartialType
+	itemResults := make([]*entity.BulkReduceQtyProductItemResult, len(payload.Items))
+	itemIndexes := make([]int, 0, len(payload.Items))
+	changes := make([]qtyChange, 0, len(payload.Items))
+	for i, item := range payload.Items {
+		if err := item.Validate(); err != nil {
+			customErr, ok := err.(response.CustomError)
+			if !ok {
+				return nil, err
+			}
+			if !partial {
+				return nil, (&qtyChangeError{index: i, err: customErr}).itemError()
+			}
+
+			itemResults[i] = entity.NewFailedBulkReduceQtyProductItemResult(item, customErr)
+			continue
+		}
+
+		itemIndexes = append(itemIndexes, i)
+		changes = append(changes, qtyChange{
+			sku:           item.SKU,
+			delta:         -item.ReqQty,
+			locationID:    item.LocationID,
+			reason:        types.StockMovementReasonSaleType,
+			serialNumbers: item.SerialNumbers,
+		})
+	}
+
+	results, err := uc.changeQtyProducts(ctx, tenant, changes, payload.Actor, payload.ReferenceID, partial)
+	if err != nil {
+		if changeErr, ok := err.(*qtyChangeError); ok {
+			return nil, changeErr.itemError()
+		}
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	products := make([]*entity.Product, 0, len(results))
+	updated := make(map[int]bool, len(results))
+	for i, result := range results {
+		item := payload.Items[itemIndexes[i]]
+		if result.err != nil {
+			itemResults[itemIndexes[i]] = entity.NewFailedBulkReduceQtyProductItemResult(item, *result.err)
+			continue
+		}
+
+		itemResults[itemIndexes[i]] = &entity.BulkReduceQtyProductItemResult{
+			SKU:            item.SKU,
+			Status:         types.BulkItemStatusAppliedType,
+			ReqQty:         item.ReqQty,
+			FulfilledQty:   item.ReqQty - result.backorderedQty,
+			BackorderedQty: result.backorderedQty,
+			RestockDate:    result.product.RestockDate,
+		}
+
+		// A product reduced by several items is returned once
+		if !updated[result.product.ID] {
+			updated[result.product.ID] = true
+			products = append(products, result.product)
+		}
+	}
+
+	// The updated products are shown like the product list, with their running price and without cost for callers without finance scope
+	if err := uc.decorateProducts(ctx, payload.Region, products...); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if !payload.FinanceScope {
+		for _, product := range products {
+			product.HideCost()
+		}
+	}
+
+	return &entity.BulkReduceQtyProductResult{Items: itemResults, Products: products}, nil
+}
+
+func (uc *ProductUsecase) BulkIncreaseQtyProduct(ctx context.Context, tenant types.TenantType, payload *entity.BulkIncreaseQtyProductPayload) ([]*entity.Product, error) {
+	functionName := "ProductUsecase.BulkIncreaseQtyProduct"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if payload.Reason == types.StockMovementReasonEmptyType {
+		payload.Reason = types.StockMovementReasonRestockType
+	}
+
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	changes := make([]qtyChange, 0, len(payload.Items))
+	for _, item := range payload.Items {
+		changes = append(changes, qtyChange{
+			sku:           item.SKU,
+			delta:         item.ReqQty,
+			locationID:    item.LocationID,
+			reason:        payload.Reason,
+			lot:           item.ToLot(),
+			serialNumbers: item.SerialNumbers,
+		})
+	}
+
+	results, err := uc.changeQtyProducts(ctx, tenant, changes, payload.Actor, payload.ReferenceID, false)
+	if err != nil {
+		if changeErr, ok := err.(*qtyChangeError); ok {
+			return nil, changeErr.itemError()
+		}
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	products := make([]*entity.Product, 0, len(results))
+	for _, result := range results {
+		products = append(products, result.product)
+	}
+
+	return products, nil
+}
+
+func (uc *ProductUsecase) AdjustQtyProduct(ctx context.Context, productID int, payload *entity.AdjustQtyProductPayload) (*entity.Product, error) {
+	functionName := "ProductUsecase.AdjustQtyProduct"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if payload.Reason == types.StockMovementReasonEmptyType {
+		payload.Reason = types.StockMovementReasonAdjustmentType
+	}
+
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	changes := []qtyChange{{
+		productID:     productID,
+		delta:         payload.Delta,
+		locationID:    payload.LocationID,
+		reason:        payload.Reason,
+		serialNumbers: payload.SerialNumbers,
+	}}
+	results, err := uc.changeQtyProducts(ctx, payload.Tenant, changes, payload.Actor, payload.ReferenceID, false)
+	if err != nil {
+		if changeErr, ok := err.(*qtyChangeError); ok {
+			return nil, changeErr.err
+		}
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return results[0].product, nil
+}
+
+// qtyChange holds a change of the qty of a product found by id or sku
+type qtyChange struct {
+	productID  int
+	sku        string
+	delta      int
+	locationID *int
+	reason     types.StockMovementReasonType
+	// lot is the lot of the added qty, it is nil when the qty is not added to a lot
+	lot *entity.ProductLot
+	// serialNumbers are the added or deducted units of a serialized product
+	serialNumbers []string
+}
+
+// qtyChangeResult holds the product after a qty change and the qty of the change which is backordered,
+// or the error of the change when it is failed in partial mode
+type qtyChangeResult struct {
+	product        *entity.Product
+	backorderedQty int
+	err            *response.CustomError
+}
+
+// qtyChangeError holds the error of the change which fails the qty changes
+type qtyChangeError struct {
+	index int
+	err   response.CustomError
+}
+
+// Error is a function to convert error to string.
+// It exists to satisfy error interface
+func (e *qtyChangeError) Error() string {
+	return e.err.Error()
+}
+
+// itemError return the error with the field of the failed item of the bulk payload
+func (e *qtyChangeError) itemError() response.CustomError {
+	err := e.err
+	err.Field = fmt.Sprintf("items[%d]", e.index)
+
+	return err
+}
+
+// changeQtyProducts apply the changes on the given location, or on the locations picked by the allocation strategy,
+// and record them on the stock movements in one transaction. Only the qty of the products is updated.
+// Sales beyond the available qty are backordered as allowed by the backorder policy of the product,
+// and the open backorders are filled first from the added qty which is recorded as sales.
+// Reduced qty is taken from the lots first-expiring-first-out, sales skip the expired lots.
+// The serial numbers of the added units of a serialized product are registered and the deducted ones are taken out of stock.
+// The transaction is run again when it conflicts with a concurrent change.
+// A change which fails all of them is returned as a qtyChangeError, in partial mode the failed change is left out
+// of the transaction which is run again, so the changes which can be applied are applied
+func (uc *ProductUsecase) changeQtyProducts(ctx context.Context, tenant types.TenantType, changes []qtyChange, actor string, referenceID string, partial bool) ([]*qtyChangeResult, error) {
+	results := make([]*qtyChangeResult, len(changes))
+	pending := make([]int, 0, len(changes))
+	for i := range changes {
+		pending = append(pending, i)
+	}
+
+	for len(pending) > 0 {
+		pendingChanges := make([]qtyChange, 0, len(pending))
+		for _, i := range pending {
+			pendingChanges = append(pendingChanges, changes[i])
+		}
+
+		var applied []*qtyChangeResult
+		err := retryTransaction(ctx, func() error {
+			var err error
+			applied, err = uc.applyQtyChanges(ctx, tenant, pendingChanges, actor, referenceID)
+			return err
+		})
+
+		changeErr, ok := err.(*qtyChangeError)
+		if ok && partial {
+			failed := pending[changeErr.index]
+			results[failed] = &qtyChangeResult{err: &changeErr.err}
+			pending = append(pending[:changeErr.index], pending[changeErr.index+1:]...)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for j, i := range pending {
+			results[i] = applied[j]
+		}
+		break
+	}
+
+	return results, nil
+}
+
+// applyQtyChanges run one attempt of the qty changes transaction
+func (uc *ProductUsecase) applyQtyChanges(ctx context.Context, tenant types.TenantType, changes []qtyChange, actor string, referenceID string) ([]*qtyChangeResult, error) {
 	// Begin transaction
 	tx, err := uc.dbTransactionRepo.StartTransactionQuery(ctx)
 	if err != nil {
-		return nil, errors.Wrap(fmt.Errorf("uc.dbTransactionRepo.StartTransactionQuery: %w", err), functionName)
+		return nil, fmt.Errorf("uc.dbTransactionRepo.StartTransactionQuery: %w", err)
 	}
 
 	// Create flag and defer rollback when flag is true
@@ -76,81 +433,276 @@ func (uc *ProductUsecase) BulkReduceQtyProduct(ctx context.Context, tenant types
 		}
 	}()
 
-	products := make([]*entity.Product, 0)
-	for _, item := range payload.Items {
-		product, err := uc.repo.GetProductBySKU(ctx, item.SKU)
+	// The products are read after they are locked so that the availability check can not use a stale qty
+	products, err := uc.lockQtyChangeProducts(ctx, tx, tenant, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.showExpiredQty(ctx, products); err != nil {
+		return nil, err
+	}
+
+	results := make([]*qtyChangeResult, 0, len(changes))
+	movements := make([]*entity.StockMovement, 0, len(changes))
+	for i, change := range changes {
+		result, changeMovements, err := uc.applyQtyChange(ctx, tx, products[i], change, actor, referenceID)
 		if err != nil {
-			if err == response.ErrNotFound {
-				return nil, err
+			if customErr, ok := err.(response.CustomError); ok {
+				return nil, &qtyChangeError{index: i, err: customErr}
+			}
+			return nil, err
+		}
+
+		results = append(results, result)
+		movements = append(movements, changeMovements...)
+	}
+
+	if err := uc.stockMovementRepo.CreateStockMovements(ctx, tx, movements); err != nil {
+		return nil, fmt.Errorf("uc.stockMovementRepo.CreateStockMovements: %w", err)
+	}
+
+	// Commit transaction
+	if err = uc.dbTransactionRepo.CommitTransactionQuery(ctx, tx); err != nil {
+		return nil, fmt.Errorf("uc.dbTransactionRepo.CommitTransactionQuery: %w", err)
+	}
+	rollbackProcess = false
+
+	return results, nil
+}
+
+// applyQtyChange apply a change of the qty of the locked product and return the stock movements of the change
+func (uc *ProductUsecase) applyQtyChange(ctx context.Context, tx interface{}, product *entity.Product, change qtyChange, actor string, referenceID string) (*qtyChangeResult, []*entity.StockMovement, error) {
+	result := &qtyChangeResult{product: product}
+	if err := product.CheckSerialNumbers(change.serialNumbers, change.delta > 0); err != nil {
+		return nil, nil, err
+	}
+
+	movements := make([]*entity.StockMovement, 0, 2)
+	if change.delta < 0 {
+		// Qty held by active reservations can only be deducted by confirming the reservation,
+		// only sales can be backordered and the expired qty can only be written off
+		isSale := change.reason == types.StockMovementReasonSaleType
+		fulfilledQty := -change.delta
+		if isSale {
+			var err error
+			fulfilledQty, result.backorderedQty, err = product.SplitBackorder(-change.delta)
+			if err != nil {
+				return nil, nil, err
+			}
+		} else if product.ShowAvailableQty(); product.AvailableQty+product.ExpiredQty < fulfilledQty {
+			return nil, nil, response.ErrInsufficientStock
+		}
+
+		if fulfilledQty > 0 {
+			if err := uc.reduceStocks(ctx, tx, product, fulfilledQty, change.locationID, !isSale); err != nil {
+				return nil, nil, err
+			}
+
+			status := entity.SerialStatusOfReason(change.reason)
+			if err := deductSerials(ctx, uc.productStockRepo, tx, product, fulfilledQty, change.serialNumbers, status, actor, referenceID); err != nil {
+				return nil, nil, err
 			}
 
-			return nil, errors.Wrap(fmt.Errorf("uc.repo.GetProductBySKU: %w", err), functionName)
+			movements = append(movements, entity.NewStockMovement(product, -fulfilledQty, change.reason, actor, referenceID))
+		}
+		product.BackorderedQty += result.backorderedQty
+	} else {
+		if err := uc.increaseStocks(ctx, tx, product, change.delta, change.locationID); err != nil {
+			return nil, nil, err
 		}
 
-		if product.Tenant != tenant {
-			return nil, response.ErrForbidden
+		if change.lot != nil {
+			if err := uc.addLotQty(ctx, tx, product, change.lot); err != nil {
+				return nil, nil, err
+			}
 		}
 
-		product.Qty = product.Qty - item.ReqQty
-		if product.Qty < 0 {
-			return nil, response.ErrInsufficientStock
+		if err := registerSerials(ctx, uc.productStockRepo, tx, product, change.serialNumbers); err != nil {
+			return nil, nil, err
 		}
 
-		if err := uc.repo.UpdateProduct(ctx, tx, product); err != nil {
-			return nil, errors.Wrap(fmt.Errorf("uc.repo.UpdateProduct: %w", err), functionName)
+		movements = append(movements, entity.NewStockMovement(product, change.delta, change.reason, actor, referenceID))
+
+		movement, err := fillBackorders(ctx, uc.productStockRepo, tx, product, change.delta, change.locationID, uc.allocationStrategy, actor, referenceID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if movement != nil {
+			movements = append(movements, movement)
 		}
 	}
 
-	// Commit transaction
-	if err = uc.dbTransactionRepo.CommitTransactionQuery(ctx, tx); err != nil {
-		return nil, errors.Wrap(fmt.Errorf("uc.dbTransactionRepo.CommitTransactionQuery: %w", err), functionName)
+	if err := uc.repo.UpdateProductQty(ctx, tx, product); err != nil {
+		return nil, nil, fmt.Errorf("uc.repo.UpdateProductQty: %w", err)
+	}
+
+	if err := recordLowStock(ctx, uc.repo, uc.lowStockRepo, tx, product); err != nil {
+		return nil, nil, err
+	}
+
+	return result, movements, nil
+}
+
+// showExpiredQty set the qty of the expired lots of the locked products,
+// the lots are only locked when they are consumed so that they are locked after the stocks
+func (uc *ProductUsecase) showExpiredQty(ctx context.Context, products []*entity.Product) error {
+	productIDs := make([]int, 0, len(products))
+	for _, product := range products {
+		productIDs = append(productIDs, product.ID)
+	}
+
+	lots, err := uc.productStockRepo.GetProductLotsByProductIDs(ctx, productIDs)
+	if err != nil {
+		return fmt.Errorf("uc.productStockRepo.GetProductLotsByProductIDs: %w", err)
+	}
+
+	lotsByProductID := make(map[int][]*entity.ProductLot, len(products))
+	for _, lot := range lots {
+		lotsByProductID[lot.ProductID] = append(lotsByProductID[lot.ProductID], lot)
+	}
+
+	now := time.Now()
+	for _, product := range products {
+		product.ExpiredQty = entity.ExpiredLotQty(lotsByProductID[product.ID], now)
+	}
+
+	return nil
+}
+
+// addLotQty add the added qty of the product to the given lot, the given lot is kept as is
+// so that it can be added again when the transaction is retried
+func (uc *ProductUsecase) addLotQty(ctx context.Context, tx interface{}, product *entity.Product, lot *entity.ProductLot) error {
+	productLot := *lot
+	productLot.ProductID = product.ID
+	if err := uc.productStockRepo.AddProductLotQty(ctx, tx, &productLot); err != nil {
+		return fmt.Errorf("uc.productStockRepo.AddProductLotQty: %w", err)
+	}
+
+	// A lot can be received after it has expired
+	if lot.IsExpired(time.Now()) {
+		product.ExpiredQty += lot.Qty
+	}
+
+	return nil
+}
+
+// lockQtyChangeProducts lock the products of the changes in one query and return the product of each change,
+// found by its id or by its sku when no id is given, the products of other tenants are not locked so they are not found
+func (uc *ProductUsecase) lockQtyChangeProducts(ctx context.Context, tx interface{}, tenant types.TenantType, changes []qtyChange) ([]*entity.Product, error) {
+	var productIDs []int
+	var productSKUs []string
+	for _, change := range changes {
+		if change.productID != 0 {
+			productIDs = append(productIDs, change.productID)
+		} else {
+			productSKUs = append(productSKUs, change.sku)
+		}
+	}
+
+	lockedProducts, err := uc.repo.GetProductsForUpdate(ctx, tx, tenant, productIDs, productSKUs)
+	if err != nil {
+		return nil, fmt.Errorf("uc.repo.GetProductsForUpdate: %w", err)
+	}
+
+	productByID := make(map[int]*entity.Product, len(lockedProducts))
+	productBySKU := make(map[string]*entity.Product, len(lockedProducts))
+	for _, product := range lockedProducts {
+		productByID[product.ID] = product
+		productBySKU[product.SKU] = product
+	}
+
+	products := make([]*entity.Product, 0, len(changes))
+	for i, change := range changes {
+		product, ok := productByID[change.productID]
+		if change.productID == 0 {
+			product, ok = productBySKU[change.sku]
+		}
+		if !ok {
+			return nil, &qtyChangeError{index: i, err: response.ErrNotFound}
+		}
+
+		products = append(products, product)
 	}
-	rollbackProcess = false
 
 	return products, nil
 }
 
-func (uc *ProductUsecase) GetProductByID(ctx context.Context, tenant types.TenantType, productID int) (*entity.Product, error) {
+func (uc *ProductUsecase) GetProductByID(ctx context.Context, payload *entity.GetProductByIDPayload) (*entity.Product, error) {
 	functionName := "ProductUsecase.GetProductByID"
 
 	if err := helper.CheckDeadline(ctx); err != nil {
 		return nil, errors.Wrap(err, functionName)
 	}
 
-	product, err := uc.repo.GetProductByID(ctx, productID)
+	product, err := uc.repo.GetPartialProductByID(ctx, payload.ID, payload.Fieldset.Columns(nil))
 	if err != nil {
 		if err == response.ErrNotFound {
 			return nil, err
 		}
 
-		return nil, errors.Wrap(fmt.Errorf("uc.repo.GetProductByID: %w", err), functionName)
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.GetPartialProductByID: %w", err), functionName)
 	}
 
-	if product.Tenant != tenant {
+	if product.Tenant != payload.Tenant {
 		return nil, response.ErrForbidden
 	}
 
+	if err := uc.decorateProductFields(ctx, payload.Region, payload.Fieldset, product); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if !payload.FinanceScope {
+		product.HideCost()
+	}
+
 	return product, nil
 }
 
-func (uc *ProductUsecase) GetProducts(ctx context.Context, payload *entity.GetProductPayload) ([]*entity.Product, int, error) {
+func (uc *ProductUsecase) GetProducts(ctx context.Context, payload *entity.GetProductPayload) (*entity.ProductPage, error) {
 	functionName := "ProductUsecase.GetProducts"
 
 	if err := helper.CheckDeadline(ctx); err != nil {
-		return nil, 0, errors.Wrap(err, functionName)
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	if len(payload.Cursor) > 0 {
+		position, err := entity.DecodeProductCursor(payload.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		payload.Position = position
 	}
 
 	products, err := uc.repo.GetProducts(ctx, payload)
 	if err != nil {
-		return nil, 0, errors.Wrap(fmt.Errorf("uc.repo.GetProducts: %w", err), functionName)
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.GetProducts: %w", err), functionName)
 	}
 
 	count, err := uc.repo.GetProductsCount(ctx, payload)
 	if err != nil {
-		return nil, 0, errors.Wrap(fmt.Errorf("uc.repo.GetProductsCount: %w", err), functionName)
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.GetProductsCount: %w", err), functionName)
+	}
+
+	// The cursors hold the stored values the query is sorted by, so they are set before the shown price is computed
+	page := &entity.ProductPage{Products: products, Total: count}
+	page.SetCursors(payload.SortKeys(), payload.Position, payload.Limit)
+
+	if err := uc.decorateProductFields(ctx, payload.Region, payload.Fieldset, products...); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if !payload.FinanceScope {
+		for _, product := range products {
+			product.HideCost()
+		}
 	}
 
-	return products, count, nil
+	return page, nil
 }
 
 func (uc *ProductUsecase) UpdateProduct(ctx context.Context, productID int, payload *entity.ProductPayload) (*entity.Product, error) {
@@ -177,14 +729,724 @@ func (uc *ProductUsecase) UpdateProduct(ctx context.Context, productID int, payl
 		return nil, response.ErrForbidden
 	}
 
-	product.Title = payload.Title
-	product.Category = payload.Category
-	product.Condition = payload.Condition
-	product.Qty = payload.Qty
-	product.Price = payload.Price
-	if err := uc.repo.UpdateProduct(ctx, nil, product); err != nil {
-		return nil, errors.Wrap(fmt.Errorf("uc.repo.UpdateProduct: %w", err), functionName)
+	if err := product.CheckVersion(payload.Versions); err != nil {
+		return nil, err
+	}
+
+	product, err = uc.updateProduct(ctx, product, payload, nil)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return product, nil
+}
+
+// PatchProduct apply a json merge patch on the product, only the fields of the patch are validated and written
+func (uc *ProductUsecase) PatchProduct(ctx context.Context, productID int, payload *entity.ProductPatchPayload) (*entity.Product, error) {
+	functionName := "ProductUsecase.PatchProduct"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	product, err := uc.repo.GetProductByID(ctx, productID)
+	if err != nil {
+		if err == response.ErrNotFound {
+			return nil, err
+		}
+
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.GetProductByID: %w", err), functionName)
+	}
+
+	if product.Tenant != payload.Tenant {
+		return nil, response.ErrForbidden
+	}
+
+	if err := product.CheckVersion(payload.Versions); err != nil {
+		return nil, err
+	}
+
+	productPayload, fields, err := payload.Apply(product)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+		return nil, errors.Wrap(fmt.Errorf("payload.Apply: %w", err), functionName)
+	}
+
+	if err := productPayload.ValidateFields(fields); err != nil {
+		return nil, err
+	}
+
+	// A removed cost price is cleared instead of kept like a cost price which is not given
+	if fields["cost_price"] && productPayload.CostPrice == nil {
+		product.CostPrice = nil
+	}
+
+	product, err = uc.updateProduct(ctx, product, productPayload, payload.Columns())
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+		return nil, errors.Wrap(err, functionName)
 	}
 
 	return product, nil
 }
+
+// updateProduct write the attributes of the payload on the given columns of the product, all of them when no columns are given.
+// The qty is only changed when its column is written. The transaction is run again when it conflicts with a concurrent change
+func (uc *ProductUsecase) updateProduct(ctx context.Context, product *entity.Product, payload *entity.ProductPayload, columns []string) (*entity.Product, error) {
+	functionName := "ProductUsecase.updateProduct"
+
+	writes := func(column string) bool {
+		return columns == nil || helper.StringInArray(column, columns)
+	}
+
+	if writes("tax_class_id") {
+		if err := uc.validateTaxClass(ctx, payload.Tenant, payload.TaxClassID); err != nil {
+			if customErr, ok := err.(response.CustomError); ok {
+				return nil, customErr
+			}
+			return nil, errors.Wrap(err, functionName)
+		}
+	}
+
+	stocks, err := uc.getPayloadStocks(ctx, payload)
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	// Each attempt starts from the product as it was read, so a conflicted attempt does not leave its changes on the product
+	var updated *entity.Product
+	err = retryTransaction(ctx, func() error {
+		attempt := *product
+		if err := uc.writeProduct(ctx, &attempt, payload, columns, stocks); err != nil {
+			return err
+		}
+
+		updated = &attempt
+		return nil
+	})
+	if err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+		return nil, errors.Wrap(err, functionName)
+	}
+	product = updated
+
+	if err := uc.decorateProducts(ctx, payload.Region, product); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if !payload.FinanceScope {
+		product.HideCost()
+	}
+
+	return product, nil
+}
+
+// writeProduct run one attempt of the update product transaction on the given columns,
+// the qty is changed from the locked product
+func (uc *ProductUsecase) writeProduct(ctx context.Context, product *entity.Product, payload *entity.ProductPayload, columns []string, stocks []*entity.ProductStock) error {
+	writes := func(column string) bool {
+		return columns == nil || helper.StringInArray(column, columns)
+	}
+
+	// Begin transaction
+	tx, err := uc.dbTransactionRepo.StartTransactionQuery(ctx)
+	if err != nil {
+		return fmt.Errorf("uc.dbTransactionRepo.StartTransactionQuery: %w", err)
+	}
+
+	// Create flag and defer rollback when flag is true
+	rollbackProcess := true
+	defer func() {
+		if rollbackProcess {
+			uc.dbTransactionRepo.RollbackTransactionQuery(ctx, tx)
+		}
+	}()
+
+	// The qty is changed from the locked product, so the qty changed by sales and transfers since the product was read
+	// is neither overwritten nor counted in the delta recorded on the stock movement
+	lockedProducts, err := uc.repo.GetProductsForUpdate(ctx, tx, product.Tenant, []int{product.ID}, nil)
+	if err != nil {
+		return fmt.Errorf("uc.repo.GetProductsForUpdate: %w", err)
+	}
+
+	if len(lockedProducts) == 0 {
+		return response.ErrNotFound
+	}
+
+	// The version is checked on the locked product, which can not be changed until the commit,
+	// so a change which is not based on given versions is written on the latest version instead of being rejected as stale
+	if err := lockedProducts[0].CheckVersion(payload.Versions); err != nil {
+		return err
+	}
+	product.Version = lockedProducts[0].Version
+
+	product.SyncQty(lockedProducts[0])
+	if !writes("qty") {
+		payload.Qty = product.Qty
+	}
+
+	// The units of a serialized product are only added with their serial numbers
+	if payload.Serialized && payload.Qty > product.Qty {
+		return response.ErrSerialNumbersRequired
+	}
+
+	if stocks != nil {
+		if err := uc.productStockRepo.ReplaceProductStocks(ctx, tx, product.ID, stocks); err != nil {
+			return fmt.Errorf("uc.productStockRepo.ReplaceProductStocks: %w", err)
+		}
+	} else if writes("qty") {
+		if err := uc.adjustStocks(ctx, tx, product, payload.Qty); err != nil {
+			return err
+		}
+	}
+
+	reason := payload.StockReason
+	if reason == types.StockMovementReasonEmptyType {
+		reason = types.StockMovementReasonAdjustmentType
+	}
+
+	// The reduced qty is taken from the lots, the expired ones first, and from the serial numbers in stock first
+	delta := payload.Qty - product.Qty
+	if delta < 0 {
+		if err := consumeLots(ctx, uc.productStockRepo, tx, product, product.Qty, -delta, true); err != nil {
+			return err
+		}
+
+		status := entity.SerialStatusOfReason(reason)
+		if err := deductSerials(ctx, uc.productStockRepo, tx, product, -delta, nil, status, payload.Actor, payload.ReferenceID); err != nil {
+			return err
+		}
+	}
+
+	product.ApplyCostPrice(payload)
+	product.Title = payload.Title
+	product.Description = payload.Description
+	product.Attributes = payload.Attributes
+	product.Category = payload.Category
+	product.Condition = payload.Condition
+	product.Qty = payload.Qty
+	product.Price = payload.Price
+	product.TaxClassID = payload.TaxClassID
+	product.LowStockThreshold = payload.LowStockThreshold
+	product.Serialized = payload.Serialized
+	product.ApplyBackorderPolicy(payload)
+	if err := uc.repo.UpdateProduct(ctx, tx, product, columns); err != nil {
+		// The product has been changed since it was read
+		if err == response.ErrStaleVersion {
+			return err
+		}
+
+		return fmt.Errorf("uc.repo.UpdateProduct: %w", err)
+	}
+
+	if delta != 0 {
+		movement := entity.NewStockMovement(product, delta, reason, payload.Actor, payload.ReferenceID)
+		if err := uc.stockMovementRepo.CreateStockMovements(ctx, tx, []*entity.StockMovement{movement}); err != nil {
+			return fmt.Errorf("uc.stockMovementRepo.CreateStockMovements: %w", err)
+		}
+	}
+
+	if err := recordLowStock(ctx, uc.repo, uc.lowStockRepo, tx, product); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	if err = uc.dbTransactionRepo.CommitTransactionQuery(ctx, tx); err != nil {
+		return fmt.Errorf("uc.dbTransactionRepo.CommitTransactionQuery: %w", err)
+	}
+	rollbackProcess = false
+
+	return nil
+}
+
+// decorateProducts set the read-only attributes of products based on the current time and the given region
+func (uc *ProductUsecase) decorateProducts(ctx context.Context, region string, products ...*entity.Product) error {
+	return uc.decorateProductFields(ctx, region, nil, products...)
+}
+
+// decorateProductFields compute the fields and attach the relations of products which are in the given fieldset,
+// the steps for the fields and the relations which are not in the fieldset are skipped
+func (uc *ProductUsecase) decorateProductFields(ctx context.Context, region string, fieldset *entity.ProductFieldset, products ...*entity.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	productIDs := make([]int, 0, len(products))
+	productByID := make(map[int]*entity.Product, len(products))
+	for _, product := range products {
+		productIDs = append(productIDs, product.ID)
+		productByID[product.ID] = product
+	}
+
+	now := time.Now()
+	if fieldset.HasPrice() {
+		// Apply the price schedules which are due but not yet processed by the scheduler,
+		// so that the price shown does not depend on the scheduler interval
+		priceSchedules, err := uc.priceScheduleRepo.GetDuePriceSchedulesByProductIDs(ctx, productIDs, now)
+		if err != nil {
+			return fmt.Errorf("uc.priceScheduleRepo.GetDuePriceSchedulesByProductIDs: %w", err)
+		}
+
+		for _, priceSchedule := range priceSchedules {
+			if product, ok := productByID[priceSchedule.ProductID]; ok {
+				priceSchedule.Apply(product, now)
+			}
+		}
+	}
+
+	// The expired qty is known from the lots before the available qty is computed
+	if fieldset.HasRelation(entity.LotsRelation) || fieldset.HasField("expired_qty") || fieldset.HasField("available_qty") {
+		if err := uc.showLots(ctx, now, productIDs, productByID); err != nil {
+			return err
+		}
+	}
+
+	for _, product := range products {
+		product.ShowPromotionPrice()
+		product.ShowAvailableQty()
+	}
+
+	if fieldset.HasPrice() {
+		// Discount rules are applied on top of the running promotion price,
+		// then the tax is computed from the final price
+		if err := uc.showDiscounts(ctx, now, products); err != nil {
+			return err
+		}
+
+		if err := uc.showTaxes(ctx, region, products); err != nil {
+			return err
+		}
+	}
+
+	if !fieldset.HasRelation(entity.StocksRelation) {
+		return nil
+	}
+
+	return uc.showStocks(ctx, productIDs, productByID)
+}
+
+// showStocks attach the stock per location of products
+func (uc *ProductUsecase) showStocks(ctx context.Context, productIDs []int, productByID map[int]*entity.Product) error {
+	stocks, err := uc.productStockRepo.GetProductStocksByProductIDs(ctx, productIDs)
+	if err != nil {
+		return fmt.Errorf("uc.productStockRepo.GetProductStocksByProductIDs: %w", err)
+	}
+
+	for _, product := range productByID {
+		product.Stocks = []*entity.ProductStock{}
+	}
+
+	for _, stock := range stocks {
+		if product, ok := productByID[stock.ProductID]; ok {
+			product.Stocks = append(product.Stocks, stock)
+		}
+	}
+
+	return nil
+}
+
+// showLots attach the lots in stock of products
+func (uc *ProductUsecase) showLots(ctx context.Context, now time.Time, productIDs []int, productByID map[int]*entity.Product) error {
+	lots, err := uc.productStockRepo.GetProductLotsByProductIDs(ctx, productIDs)
+	if err != nil {
+		return fmt.Errorf("uc.productStockRepo.GetProductLotsByProductIDs: %w", err)
+	}
+
+	lotsByProductID := make(map[int][]*entity.ProductLot, len(productByID))
+	for _, lot := range lots {
+		lotsByProductID[lot.ProductID] = append(lotsByProductID[lot.ProductID], lot)
+	}
+
+	for productID, product := range productByID {
+		productLots, ok := lotsByProductID[productID]
+		if !ok {
+			productLots = []*entity.ProductLot{}
+		}
+
+		product.ShowLots(productLots, now)
+	}
+
+	return nil
+}
+
+// showDiscounts apply the running discount rules of the tenant of products
+func (uc *ProductUsecase) showDiscounts(ctx context.Context, now time.Time, products []*entity.Product) error {
+	discountRulesByTenant := make(map[types.TenantType][]*entity.DiscountRule)
+	for _, product := range products {
+		discountRules, ok := discountRulesByTenant[product.Tenant]
+		if !ok {
+			var err error
+			discountRules, err = uc.discountRuleRepo.GetActiveDiscountRules(ctx, product.Tenant, now)
+			if err != nil {
+				return fmt.Errorf("uc.discountRuleRepo.GetActiveDiscountRules: %w", err)
+			}
+
+			discountRulesByTenant[product.Tenant] = discountRules
+		}
+
+		product.ShowDiscounts(discountRules, now)
+	}
+
+	return nil
+}
+
+// showTaxes compute the tax of the shown price of products with the tax class assigned to the product,
+// or with the default tax class of its category when the product has none
+func (uc *ProductUsecase) showTaxes(ctx context.Context, region string, products []*entity.Product) error {
+	categoryTaxClassIDs := make(map[types.TenantType]map[types.CategoryType]int)
+	for _, product := range products {
+		if product.TaxClassID != nil {
+			continue
+		}
+
+		if _, ok := categoryTaxClassIDs[product.Tenant]; ok {
+			continue
+		}
+
+		categoryTaxClasses, err := uc.taxClassRepo.GetCategoryTaxClasses(ctx, product.Tenant)
+		if err != nil {
+			return fmt.Errorf("uc.taxClassRepo.GetCategoryTaxClasses: %w", err)
+		}
+
+		categoryTaxClassIDs[product.Tenant] = make(map[types.CategoryType]int, len(categoryTaxClasses))
+		for _, categoryTaxClass := range categoryTaxClasses {
+			categoryTaxClassIDs[product.Tenant][categoryTaxClass.Category] = categoryTaxClass.TaxClassID
+		}
+	}
+
+	taxClassIDs := make([]int, 0)
+	taxClassByID := make(map[int]*entity.TaxClass)
+	productTaxClassIDs := make(map[int]int, len(products))
+	for _, product := range products {
+		taxClassID, ok := categoryTaxClassIDs[product.Tenant][product.Category]
+		if product.TaxClassID != nil {
+			taxClassID, ok = *product.TaxClassID, true
+		}
+
+		if !ok {
+			continue
+		}
+
+		if _, ok := taxClassByID[taxClassID]; !ok {
+			taxClassIDs = append(taxClassIDs, taxClassID)
+			taxClassByID[taxClassID] = nil
+		}
+		productTaxClassIDs[product.ID] = taxClassID
+	}
+
+	if len(taxClassIDs) > 0 {
+		taxClasses, err := uc.taxClassRepo.GetTaxClassesByIDs(ctx, taxClassIDs)
+		if err != nil {
+			return fmt.Errorf("uc.taxClassRepo.GetTaxClassesByIDs: %w", err)
+		}
+
+		if err := attachTaxRates(ctx, uc.taxClassRepo, taxClasses); err != nil {
+			return err
+		}
+
+		for _, taxClass := range taxClasses {
+			taxClassByID[taxClass.ID] = taxClass
+		}
+	}
+
+	for _, product := range products {
+		var taxClass *entity.TaxClass
+		if taxClassID, ok := productTaxClassIDs[product.ID]; ok {
+			taxClass = taxClassByID[taxClassID]
+		}
+
+		product.ShowTax(taxClass, region)
+	}
+
+	return nil
+}
+
+// validateTaxClass make sure the tax class to be assigned exists on the tenant
+func (uc *ProductUsecase) validateTaxClass(ctx context.Context, tenant types.TenantType, taxClassID *int) error {
+	if taxClassID == nil {
+		return nil
+	}
+
+	taxClass, err := uc.taxClassRepo.GetTaxClassByID(ctx, *taxClassID)
+	if err != nil {
+		if err == response.ErrNotFound {
+			return response.ErrInvalidTaxClass
+		}
+
+		return fmt.Errorf("uc.taxClassRepo.GetTaxClassByID: %w", err)
+	}
+
+	if taxClass.Tenant != tenant {
+		return response.ErrInvalidTaxClass
+	}
+
+	return nil
+}
+
+// getPayloadStocks return the stocks of the payload after making sure their locations exist on the tenant,
+// the qty of the payload becomes the total of the stocks. It returns nil when the payload has no stocks
+func (uc *ProductUsecase) getPayloadStocks(ctx context.Context, payload *entity.ProductPayload) ([]*entity.ProductStock, error) {
+	if payload.Stocks == nil {
+		return nil, nil
+	}
+
+	stocks := make([]*entity.ProductStock, 0, len(payload.Stocks))
+	for _, stockPayload := range payload.Stocks {
+		if err := validateLocation(ctx, uc.locationRepo, payload.Tenant, stockPayload.LocationID); err != nil {
+			return nil, err
+		}
+
+		stocks = append(stocks, &entity.ProductStock{LocationID: stockPayload.LocationID, Qty: stockPayload.Qty})
+	}
+
+	payload.Qty = entity.TotalStock(stocks)
+
+	return stocks, nil
+}
+
+// adjustStocks change the stock of the product on locations to reach the given total qty,
+// added qty goes to the default location and reduced qty is allocated with the allocation strategy
+func (uc *ProductUsecase) adjustStocks(ctx context.Context, tx interface{}, product *entity.Product, qty int) error {
+	stocks, err := uc.productStockRepo.GetProductStocksForUpdate(ctx, tx, product.ID)
+	if err != nil {
+		return fmt.Errorf("uc.productStockRepo.GetProductStocksForUpdate: %w", err)
+	}
+
+	delta := qty - entity.TotalStock(stocks)
+	if delta == 0 {
+		return nil
+	}
+
+	if delta < 0 {
+		return allocateStocks(ctx, uc.productStockRepo, tx, stocks, -delta, nil, uc.allocationStrategy)
+	}
+
+	_, err = addStocks(ctx, uc.locationRepo, uc.productStockRepo, tx, product, stocks, delta, nil)
+	return err
+}
+
+// increaseStocks add qty of the product to the given location, or to the default location when no location is given,
+// then set the qty of the product to the stock on hand
+func (uc *ProductUsecase) increaseStocks(ctx context.Context, tx interface{}, product *entity.Product, qty int, locationID *int) error {
+	if locationID != nil {
+		if err := validateLocation(ctx, uc.locationRepo, product.Tenant, *locationID); err != nil {
+			return err
+		}
+	}
+
+	stocks, err := uc.productStockRepo.GetProductStocksForUpdate(ctx, tx, product.ID)
+	if err != nil {
+		return fmt.Errorf("uc.productStockRepo.GetProductStocksForUpdate: %w", err)
+	}
+
+	stocks, err = addStocks(ctx, uc.locationRepo, uc.productStockRepo, tx, product, stocks, qty, locationID)
+	if err != nil {
+		return err
+	}
+
+	product.Qty = entity.TotalStock(stocks)
+
+	return nil
+}
+
+// reduceStocks reduce qty of the product from the given location,
+// or from the locations picked by the allocation strategy when no location is given
+func (uc *ProductUsecase) reduceStocks(ctx context.Context, tx interface{}, product *entity.Product, qty int, locationID *int, withExpired bool) error {
+	if locationID != nil {
+		if err := validateLocation(ctx, uc.locationRepo, product.Tenant, *locationID); err != nil {
+			return err
+		}
+	}
+
+	return deductStocks(ctx, uc.productStockRepo, tx, product, qty, locationID, uc.allocationStrategy, withExpired)
+}
+
+// validateLocation make sure the location exists on the tenant
+func validateLocation(ctx context.Context, rLocation repo.LocationRepositoryInterface, tenant types.TenantType, locationID int) error {
+	location, err := rLocation.GetLocationByID(ctx, locationID)
+	if err != nil {
+		if err == response.ErrNotFound {
+			return response.ErrInvalidLocation
+		}
+
+		return fmt.Errorf("rLocation.GetLocationByID: %w", err)
+	}
+
+	if location.Tenant != tenant {
+		return response.ErrInvalidLocation
+	}
+
+	return nil
+}
+
+// addStocks add qty to the stock of the given location, or of the default location when no location is given,
+// and return the stocks including the added one
+func addStocks(ctx context.Context, rLocation repo.LocationRepositoryInterface, rProductStock repo.ProductStockRepositoryInterface, tx interface{}, product *entity.Product, stocks []*entity.ProductStock, qty int, locationID *int) ([]*entity.ProductStock, error) {
+	if locationID == nil {
+		location, err := rLocation.GetOrCreateDefaultLocation(ctx, tx, product.Tenant)
+		if err != nil {
+			return nil, fmt.Errorf("rLocation.GetOrCreateDefaultLocation: %w", err)
+		}
+		locationID = &location.ID
+	}
+
+	var stock *entity.ProductStock
+	for _, currentStock := range stocks {
+		if currentStock.LocationID == *locationID {
+			stock = currentStock
+		}
+	}
+	if stock == nil {
+		stock = &entity.ProductStock{ProductID: product.ID, LocationID: *locationID}
+		stocks = append(stocks, stock)
+	}
+	stock.Qty += qty
+
+	if err := rProductStock.UpsertProductStocks(ctx, tx, []*entity.ProductStock{stock}); err != nil {
+		return nil, fmt.Errorf("rProductStock.UpsertProductStocks: %w", err)
+	}
+
+	return stocks, nil
+}
+
+// fillBackorders fill the open backorders of the product from the added qty, the filled qty is reduced
+// from the location of the added qty and returned as a sale movement, or nil when there is no backorder
+func fillBackorders(ctx context.Context, r repo.ProductStockRepositoryInterface, tx interface{}, product *entity.Product, addedQty int, locationID *int, strategy types.AllocationStrategyType, actor string, referenceID string) (*entity.StockMovement, error) {
+	filledQty := product.FillableBackorderQty(addedQty)
+	if filledQty == 0 {
+		return nil, nil
+	}
+
+	if err := deductStocks(ctx, r, tx, product, filledQty, locationID, strategy, false); err != nil {
+		return nil, err
+	}
+
+	if err := deductSerials(ctx, r, tx, product, filledQty, nil, types.SerialStatusSoldType, actor, referenceID); err != nil {
+		return nil, err
+	}
+	product.BackorderedQty -= filledQty
+
+	return entity.NewStockMovement(product, -filledQty, types.StockMovementReasonSaleType, actor, referenceID), nil
+}
+
+// deductStocks reduce qty of the product from the given location, or from the locations picked by the given
+// allocation strategy when no location is given, then set the qty of the product to the stock left.
+// The qty is taken from the lots of the product as well, the expired lots are only taken when withExpired is set
+func deductStocks(ctx context.Context, r repo.ProductStockRepositoryInterface, tx interface{}, product *entity.Product, qty int, locationID *int, strategy types.AllocationStrategyType, withExpired bool) error {
+	stocks, err := r.GetProductStocksForUpdate(ctx, tx, product.ID)
+	if err != nil {
+		return fmt.Errorf("r.GetProductStocksForUpdate: %w", err)
+	}
+
+	onHandQty := entity.TotalStock(stocks)
+	if err := allocateStocks(ctx, r, tx, stocks, qty, locationID, strategy); err != nil {
+		return err
+	}
+
+	if err := consumeLots(ctx, r, tx, product, onHandQty, qty, withExpired); err != nil {
+		return err
+	}
+
+	product.Qty = entity.TotalStock(stocks)
+
+	return nil
+}
+
+// consumeLots take the reduced qty of the product from its lots first-expiring-first-out,
+// then set the qty of the expired lots left on the product
+func consumeLots(ctx context.Context, r repo.ProductStockRepositoryInterface, tx interface{}, product *entity.Product, onHandQty int, qty int, withExpired bool) error {
+	lots, err := r.GetProductLotsForUpdate(ctx, tx, product.ID)
+	if err != nil {
+		return fmt.Errorf("r.GetProductLotsForUpdate: %w", err)
+	}
+
+	now := time.Now()
+	reduced, err := entity.ConsumeLots(lots, onHandQty, qty, now, withExpired)
+	if err != nil {
+		return err
+	}
+
+	if err := r.UpdateProductLots(ctx, tx, reduced); err != nil {
+		return fmt.Errorf("r.UpdateProductLots: %w", err)
+	}
+
+	product.ExpiredQty = entity.ExpiredLotQty(lots, now)
+
+	return nil
+}
+
+// registerSerials register the serial numbers of the added units of the product as in stock
+func registerSerials(ctx context.Context, r repo.ProductStockRepositoryInterface, tx interface{}, product *entity.Product, serialNumbers []string) error {
+	if len(serialNumbers) == 0 {
+		return nil
+	}
+
+	if err := r.CreateProductSerials(ctx, tx, entity.NewProductSerials(product, serialNumbers)); err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return customErr
+		}
+		return fmt.Errorf("r.CreateProductSerials: %w", err)
+	}
+
+	return nil
+}
+
+// deductSerials take the deducted units of a serialized product out of stock with the given status, the given serial numbers
+// must all be in stock. Without serial numbers the units in stock first are taken, the units added before the product
+// was serialized have no serial number so fewer serial numbers than the qty may be taken
+func deductSerials(ctx context.Context, r repo.ProductStockRepositoryInterface, tx interface{}, product *entity.Product, qty int, serialNumbers []string, status types.SerialStatusType, actor string, referenceID string) error {
+	if !product.Serialized {
+		return nil
+	}
+
+	serials, err := r.GetInStockProductSerialsForUpdate(ctx, tx, product.ID, serialNumbers, qty)
+	if err != nil {
+		return fmt.Errorf("r.GetInStockProductSerialsForUpdate: %w", err)
+	}
+
+	if len(serialNumbers) > 0 && (len(serialNumbers) != qty || len(serials) != len(serialNumbers)) {
+		return response.ErrInvalidSerialNumbers
+	}
+
+	if err := r.DeductProductSerials(ctx, tx, serials, status, actor, referenceID); err != nil {
+		return fmt.Errorf("r.DeductProductSerials: %w", err)
+	}
+
+	return nil
+}
+
+// allocateStocks reduce qty from the stocks and save the reduced ones
+func allocateStocks(ctx context.Context, r repo.ProductStockRepositoryInterface, tx interface{}, stocks []*entity.ProductStock, qty int, locationID *int, strategy types.AllocationStrategyType) error {
+	var reduced []*entity.ProductStock
+	if locationID != nil {
+		stock, err := entity.ReduceStockAtLocation(stocks, *locationID, qty)
+		if err != nil {
+			return err
+		}
+		reduced = []*entity.ProductStock{stock}
+	} else {
+		var err error
+		reduced, err = entity.AllocateStock(stocks, qty, strategy)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := r.UpsertProductStocks(ctx, tx, reduced); err != nil {
+		return fmt.Errorf("r.UpsertProductStocks: %w", err)
+	}
+
+	return nil
+}
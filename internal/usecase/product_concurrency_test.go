@@ -0,0 +1,183 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+// testDatabase connect to the database of TEST_DATABASE_URL and migrate a new schema which is dropped when the test ends.
+// The test is skipped when TEST_DATABASE_URL is not set
+func testDatabase(t *testing.T) *sqlx.DB {
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	adminDB, err := sqlx.Connect("postgres", databaseURL)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when connecting to the test database", err)
+	}
+
+	schema := fmt.Sprintf("catalog_test_%d", time.Now().UnixNano())
+	if _, err := adminDB.Exec(fmt.Sprintf("CREATE SCHEMA %s", schema)); err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the test schema", err)
+	}
+	t.Cleanup(func() {
+		adminDB.Exec(fmt.Sprintf("DROP SCHEMA %s CASCADE", schema))
+		adminDB.Close()
+	})
+
+	// Unknown connection parameters are sent as runtime parameters, so every connection of the pool uses the schema
+	schemaURL, err := url.Parse(databaseURL)
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when parsing TEST_DATABASE_URL", err)
+	}
+	query := schemaURL.Query()
+	query.Set("search_path", schema)
+	schemaURL.RawQuery = query.Encode()
+
+	db, err := sqlx.Connect("postgres", schemaURL.String())
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when connecting to the test schema", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrations, err := filepath.Glob("../../db/migration/*.up.sql")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when listing the migrations", err)
+	}
+	sort.Strings(migrations)
+
+	for _, migration := range migrations {
+		statements, err := os.ReadFile(migration)
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when reading %s", err, migration)
+		}
+
+		if _, err := db.Exec(string(statements)); err != nil {
+			t.Fatalf("an error '%s' was not expected when running %s", err, migration)
+		}
+	}
+
+	return db
+}
+
+func TestBulkReduceQtyProductConcurrently(t *testing.T) {
+	db := testDatabase(t)
+
+	const (
+		stock  = 10
+		orders = 20
+	)
+
+	productRepo := postgres.NewProductRepository(db)
+	productStockRepo := postgres.NewProductStockRepository(db)
+	stockMovementRepo := postgres.NewStockMovementRepository(db)
+	uc := usecase.NewProductUsecase(
+		productRepo,
+		postgres.NewPostgresTransactionRepository(db),
+		postgres.NewPriceScheduleRepository(db),
+		postgres.NewTaxClassRepository(db),
+		postgres.NewDiscountRuleRepository(db),
+		postgres.NewLocationRepository(db),
+		productStockRepo,
+		stockMovementRepo,
+		postgres.NewLowStockRepository(db),
+		types.AllocationStrategyPriorityType,
+	)
+
+	ctx := context.Background()
+	products := make([]*entity.Product, 0, 2)
+	for i := 0; i < 2; i++ {
+		product, err := uc.CreateProduct(ctx, &entity.ProductPayload{
+			Title:     fmt.Sprintf("Product %d", i),
+			Category:  types.CategoryBookType,
+			Condition: types.ConditionNewType,
+			Tenant:    types.TenantLoremType,
+			Qty:       stock,
+			Price:     1000,
+		})
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when creating the product", err)
+		}
+		products = append(products, product)
+	}
+
+	var (
+		wg           sync.WaitGroup
+		mu           sync.Mutex
+		succeeded    int
+		insufficient int
+		unexpected   []error
+	)
+	for i := 0; i < orders; i++ {
+		// Half of the orders list the products in the opposite order, they must not deadlock each other
+		first, second := products[0], products[1]
+		if i%2 == 1 {
+			first, second = second, first
+		}
+
+		wg.Add(1)
+		go func(first, second *entity.Product) {
+			defer wg.Done()
+
+			_, err := uc.BulkReduceQtyProduct(ctx, types.TenantLoremType, &entity.BulkReduceQtyProductPayload{
+				Items: []entity.BulkReduceQtyProductItemPayload{{SKU: first.SKU, ReqQty: 1}, {SKU: second.SKU, ReqQty: 1}},
+			})
+
+			// The error names the failed item, so it is matched by its code
+			customErr, isCustomErr := err.(response.CustomError)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				succeeded++
+			case isCustomErr && customErr.Code == response.ErrInsufficientStock.Code:
+				insufficient++
+			default:
+				unexpected = append(unexpected, err)
+			}
+		}(first, second)
+	}
+	wg.Wait()
+
+	assert.Empty(t, unexpected)
+	assert.Equal(t, stock, succeeded, "every unit is sold exactly once")
+	assert.Equal(t, orders-stock, insufficient)
+
+	for _, product := range products {
+		result, err := productRepo.GetProductByID(ctx, product.ID)
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when getting the product", err)
+		}
+		assert.Equal(t, stock-succeeded, result.Qty)
+
+		stocks, err := productStockRepo.GetProductStocksByProductIDs(ctx, []int{product.ID})
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when getting the product stocks", err)
+		}
+		assert.Equal(t, result.Qty, entity.TotalStock(stocks))
+
+		sales, err := stockMovementRepo.GetStockMovementsCount(ctx, &entity.GetStockMovementPayload{ProductID: product.ID, Reason: types.StockMovementReasonSaleType})
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when counting the stock movements", err)
+		}
+		assert.Equal(t, succeeded, sales)
+	}
+}
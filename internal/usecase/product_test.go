@@ -2,12 +2,17 @@ package usecase_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/satriowisnugroho/catalog/internal/config"
 	"github.com/satriowisnugroho/catalog/internal/entity"
 	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/helper"
 	"github.com/satriowisnugroho/catalog/internal/response"
 	"github.com/satriowisnugroho/catalog/internal/usecase"
 	"github.com/satriowisnugroho/catalog/test/fixture"
@@ -17,12 +22,23 @@ import (
 )
 
 func TestCreateProduct(t *testing.T) {
+	taxClassID := 1
+
 	testcases := []struct {
-		name        string
-		ctx         context.Context
-		payload     *entity.ProductPayload
-		rProductErr error
-		wantErr     bool
+		name                string
+		ctx                 context.Context
+		payload             *entity.ProductPayload
+		rTaxClassRes        *entity.TaxClass
+		rTaxClassErr        error
+		rLocationRes        *entity.Location
+		rLocationErr        error
+		rDefaultLocationErr error
+		rProductErr         error
+		rReplaceStocksErr   error
+		rCategoryTaxErr     error
+		rDiscountErr        error
+		rStockMovementErr   error
+		wantErr             bool
 	}{
 		{
 			name:    "deadline context",
@@ -35,6 +51,33 @@ func TestCreateProduct(t *testing.T) {
 			payload: &entity.ProductPayload{Tenant: types.TenantEmptyType},
 			wantErr: true,
 		},
+		{
+			name:    "serialized product with qty",
+			ctx:     context.Background(),
+			payload: &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Qty: 1, Serialized: true},
+			wantErr: true,
+		},
+		{
+			name:         "tax class is not found",
+			ctx:          context.Background(),
+			payload:      &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, TaxClassID: &taxClassID},
+			rTaxClassErr: response.ErrNotFound,
+			wantErr:      true,
+		},
+		{
+			name:         "failed to get tax class",
+			ctx:          context.Background(),
+			payload:      &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, TaxClassID: &taxClassID},
+			rTaxClassErr: errors.New("error get tax class"),
+			wantErr:      true,
+		},
+		{
+			name:         "tax class of another tenant",
+			ctx:          context.Background(),
+			payload:      &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, TaxClassID: &taxClassID},
+			rTaxClassRes: &entity.TaxClass{ID: taxClassID, Tenant: types.TenantIpsumType},
+			wantErr:      true,
+		},
 		{
 			name:        "duplicate sku & tenant",
 			ctx:         context.Background(),
@@ -49,10 +92,73 @@ func TestCreateProduct(t *testing.T) {
 			rProductErr: errors.New("error create product"),
 			wantErr:     true,
 		},
+		{
+			name:         "location is not found",
+			ctx:          context.Background(),
+			payload:      &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Stocks: []entity.ProductStockPayload{{LocationID: 2, Qty: 5}}},
+			rLocationErr: response.ErrNotFound,
+			wantErr:      true,
+		},
+		{
+			name:         "location of another tenant",
+			ctx:          context.Background(),
+			payload:      &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Stocks: []entity.ProductStockPayload{{LocationID: 2, Qty: 5}}},
+			rLocationRes: &entity.Location{ID: 2, Tenant: types.TenantIpsumType},
+			wantErr:      true,
+		},
+		{
+			name:                "failed to get default location",
+			ctx:                 context.Background(),
+			payload:             &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Qty: 5},
+			rDefaultLocationErr: errors.New("error get default location"),
+			wantErr:             true,
+		},
+		{
+			name:              "failed to replace product stocks",
+			ctx:               context.Background(),
+			payload:           &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Qty: 5},
+			rReplaceStocksErr: errors.New("error replace product stocks"),
+			wantErr:           true,
+		},
+		{
+			name:            "failed to get category tax classes",
+			ctx:             context.Background(),
+			payload:         &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType},
+			rCategoryTaxErr: errors.New("error get category tax classes"),
+			wantErr:         true,
+		},
+		{
+			name:         "failed to get active discount rules",
+			ctx:          context.Background(),
+			payload:      &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType},
+			rDiscountErr: errors.New("error get active discount rules"),
+			wantErr:      true,
+		},
+		{
+			name:         "success with tax class",
+			ctx:          context.Background(),
+			payload:      &entity.ProductPayload{Title: "New Product", Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, TaxClassID: &taxClassID},
+			rTaxClassRes: &entity.TaxClass{ID: taxClassID, Tenant: types.TenantLoremType},
+			wantErr:      false,
+		},
+		{
+			name:         "success with stocks",
+			ctx:          context.Background(),
+			payload:      &entity.ProductPayload{Title: "New Product", Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Stocks: []entity.ProductStockPayload{{LocationID: 2, Qty: 5}}},
+			rLocationRes: &entity.Location{ID: 2, Tenant: types.TenantLoremType},
+			wantErr:      false,
+		},
+		{
+			name:              "failed to create stock movement",
+			ctx:               context.Background(),
+			payload:           &entity.ProductPayload{Title: "New Product", Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Qty: 5},
+			rStockMovementErr: errors.New("error create stock movement"),
+			wantErr:           true,
+		},
 		{
 			name:    "success",
 			ctx:     context.Background(),
-			payload: &entity.ProductPayload{Title: "New Product", Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType},
+			payload: &entity.ProductPayload{Title: "New Product", Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Qty: 5},
 			wantErr: false,
 		},
 	}
@@ -60,16 +166,77 @@ func TestCreateProduct(t *testing.T) {
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
 			productRepo := &testmock.ProductRepositoryInterface{}
-			productRepo.On("CreateProduct", mock.Anything, mock.Anything).Return(tc.rProductErr)
+			productRepo.On("CreateProduct", mock.Anything, mock.Anything, mock.Anything).Return(tc.rProductErr)
+
+			dbTransactionRepo := &testmock.PostgresTransactionRepositoryInterface{}
+			dbTransactionRepo.On("StartTransactionQuery", mock.Anything).Return(&sqlx.Tx{}, nil)
+			dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+			dbTransactionRepo.On("RollbackTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+
+			locationRepo := &testmock.LocationRepositoryInterface{}
+			locationRepo.On("GetLocationByID", mock.Anything, mock.Anything).Return(tc.rLocationRes, tc.rLocationErr)
+			locationRepo.On("GetOrCreateDefaultLocation", mock.Anything, mock.Anything, mock.Anything).Return(&entity.Location{ID: 1, Tenant: types.TenantLoremType, IsDefault: true}, tc.rDefaultLocationErr)
+
+			productStockRepo := &testmock.ProductStockRepositoryInterface{}
+			productStockRepo.On("GetProductLotsByProductIDs", mock.Anything, mock.Anything).Return([]*entity.ProductLot{}, nil)
+			productStockRepo.On("GetProductLotsForUpdate", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.ProductLot{}, nil)
+			productStockRepo.On("UpdateProductLots", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			productStockRepo.On("ReplaceProductStocks", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.rReplaceStocksErr)
+			productStockRepo.On("GetProductStocksByProductIDs", mock.Anything, mock.Anything).Return([]*entity.ProductStock{}, nil)
+
+			priceScheduleRepo := &testmock.PriceScheduleRepositoryInterface{}
+			priceScheduleRepo.On("GetDuePriceSchedulesByProductIDs", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+
+			taxClassRepo := &testmock.TaxClassRepositoryInterface{}
+			taxClassRepo.On("GetTaxClassByID", mock.Anything, mock.Anything).Return(tc.rTaxClassRes, tc.rTaxClassErr)
+			taxClassRepo.On("GetCategoryTaxClasses", mock.Anything, mock.Anything).Return([]*entity.CategoryTaxClass{}, tc.rCategoryTaxErr)
+			taxClassRepo.On("GetTaxClassesByIDs", mock.Anything, mock.Anything).Return([]*entity.TaxClass{tc.rTaxClassRes}, nil)
+			taxClassRepo.On("GetTaxRatesByTaxClassIDs", mock.Anything, mock.Anything).Return([]*entity.TaxRate{}, nil)
 
-			uc := usecase.NewProductUsecase(productRepo, &testmock.PostgresTransactionRepositoryInterface{})
+			discountRuleRepo := &testmock.DiscountRuleRepositoryInterface{}
+			discountRuleRepo.On("GetActiveDiscountRules", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.DiscountRule{}, tc.rDiscountErr)
+
+			stockMovementRepo := &testmock.StockMovementRepositoryInterface{}
+			stockMovementRepo.On("CreateStockMovements", mock.Anything, mock.Anything, mock.Anything).Return(tc.rStockMovementErr)
+
+			lowStockRepo := &testmock.LowStockRepositoryInterface{}
+			lowStockRepo.On("GetLowStockThreshold", mock.Anything, mock.Anything).Return(nil, response.ErrNotFound)
+
+			uc := usecase.NewProductUsecase(productRepo, dbTransactionRepo, priceScheduleRepo, taxClassRepo, discountRuleRepo, locationRepo, productStockRepo, stockMovementRepo, lowStockRepo, types.AllocationStrategyPriorityType)
 			_, err := uc.CreateProduct(tc.ctx, tc.payload)
 			assert.Equal(t, tc.wantErr, err != nil)
 		})
 	}
 }
 
+// lockedProducts return a copy of the product as the products locked on each transaction attempt, none when it is nil
+func lockedProducts(product *entity.Product) func(context.Context, interface{}, types.TenantType, []int, []string) []*entity.Product {
+	return func(_ context.Context, _ interface{}, tenant types.TenantType, _ []int, _ []string) []*entity.Product {
+		// The products of other tenants are not locked
+		if product == nil || product.Tenant != tenant {
+			return []*entity.Product{}
+		}
+
+		locked := *product
+		return []*entity.Product{&locked}
+	}
+}
+
 func TestBulkReduceQtyProduct(t *testing.T) {
+	defaultLocationID := 1
+	locationID := 2
+	backorderLimit := 5
+	restockDate := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+	promotionPrice := 800
+	costPrice := 500
+	newLots := func() []*entity.ProductLot {
+		return []*entity.ProductLot{
+			{ID: 1, ExpiryDate: time.Now().AddDate(0, 0, 10), Qty: 4},
+			{ID: 2, ExpiryDate: time.Now().AddDate(0, 0, 5), Qty: 3},
+			{ID: 3, ExpiryDate: time.Now().AddDate(0, 0, -1), Qty: 2},
+		}
+	}
+
 	testcases := []struct {
 		name              string
 		ctx               context.Context
@@ -79,8 +246,25 @@ func TestBulkReduceQtyProduct(t *testing.T) {
 		rCommitTrxErr     error
 		rGetProductRes    *entity.Product
 		rGetProductErr    error
+		rLocationRes      *entity.Location
+		rLocationErr      error
+		rGetStocksErr     error
+		rUpsertStocksErr  error
+		rLotsRes          []*entity.ProductLot
+		rUpdateLotsErr    error
+		rSerialsRes       []*entity.ProductSerial
+		rDeductSerialsErr error
 		rUpdateProductErr error
+		rStockMovementErr error
+		rCommitConflicts  int
+		wantAttempts      int
+		wantRes           []*entity.BulkReduceQtyProductItemResult
+		wantPrice         int
+		wantCostPrice     *int
+		wantLotQty        map[int]int
+		wantSerialIDs     []int
 		wantErr           bool
+		wantCustomErr     error
 	}{
 		{
 			name:    "deadline context",
@@ -90,15 +274,15 @@ func TestBulkReduceQtyProduct(t *testing.T) {
 		{
 			name:         "failed to start transaction",
 			ctx:          context.Background(),
+			payload:      &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
 			rStartTrxErr: response.ErrNoSQLTransactionFound,
 			wantErr:      true,
 		},
 		{
-			name:           "product is not found",
-			ctx:            context.Background(),
-			payload:        &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
-			rGetProductErr: response.ErrNotFound,
-			wantErr:        true,
+			name:    "product is not found",
+			ctx:     context.Background(),
+			payload: &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
+			wantErr: true,
 		},
 		{
 			name:           "failed to get product",
@@ -108,41 +292,237 @@ func TestBulkReduceQtyProduct(t *testing.T) {
 			wantErr:        true,
 		},
 		{
-			name:           "forbidden",
+			name:           "product of another tenant is not found",
 			ctx:            context.Background(),
 			tenant:         types.TenantIpsumType,
 			payload:        &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 11}}},
-			rGetProductRes: &entity.Product{Qty: 10, Tenant: types.TenantLoremType},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10, Tenant: types.TenantLoremType},
+			wantErr:        true,
+		},
+		{
+			name:           "invalid qty",
+			ctx:            context.Background(),
+			payload:        &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 0}}},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10},
+			wantErr:        true,
+		},
+		{
+			name:           "location of another tenant",
+			ctx:            context.Background(),
+			payload:        &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1, LocationID: &locationID}}},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10},
+			rLocationRes:   &entity.Location{ID: locationID, Tenant: types.TenantIpsumType},
+			wantErr:        true,
+		},
+		{
+			name:           "failed to get product stocks",
+			ctx:            context.Background(),
+			payload:        &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10},
+			rGetStocksErr:  errors.New("error get product stocks"),
 			wantErr:        true,
 		},
 		{
 			name:           "insufficient stock",
 			ctx:            context.Background(),
 			payload:        &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 11}}},
-			rGetProductRes: &entity.Product{Qty: 10},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10},
+			wantErr:        true,
+			wantCustomErr:  response.CustomError{Message: response.ErrInsufficientStock.Message, Field: "items[0]", Code: response.ErrInsufficientStock.Code, HTTPCode: response.ErrInsufficientStock.HTTPCode},
+		},
+		{
+			name:           "backorder over the limit",
+			ctx:            context.Background(),
+			payload:        &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 14}}},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10, BackorderedQty: 2, BackorderPolicy: types.BackorderPolicyLimitedType, BackorderLimit: &backorderLimit},
+			wantErr:        true,
+		},
+		{
+			name:           "insufficient stock which is not reserved",
+			ctx:            context.Background(),
+			payload:        &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 6}}},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10, ReservedQty: 5},
+			wantErr:        true,
+		},
+		{
+			name:           "insufficient stock which is not expired",
+			ctx:            context.Background(),
+			payload:        &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 9}}},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10},
+			rLotsRes:       newLots(),
+			wantErr:        true,
+		},
+		{
+			name:           "insufficient stock at location",
+			ctx:            context.Background(),
+			payload:        &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1, LocationID: &locationID}}},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10},
+			rLocationRes:   &entity.Location{ID: locationID},
+			wantErr:        true,
+		},
+		{
+			name:             "failed to upsert product stocks",
+			ctx:              context.Background(),
+			payload:          &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
+			rGetProductRes:   &entity.Product{SKU: "SKU-123", Qty: 10},
+			rUpsertStocksErr: errors.New("error upsert product stocks"),
+			wantErr:          true,
+		},
+		{
+			name:           "failed to update product lots",
+			ctx:            context.Background(),
+			payload:        &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10},
+			rLotsRes:       newLots(),
+			rUpdateLotsErr: errors.New("error update product lots"),
+			wantErr:        true,
+		},
+		{
+			name:           "serial numbers do not match qty",
+			ctx:            context.Background(),
+			payload:        &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 2, SerialNumbers: []string{"SN-1"}}}},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10, Serialized: true},
+			wantErr:        true,
+		},
+		{
+			name:           "serial numbers of product which is not serialized",
+			ctx:            context.Background(),
+			payload:        &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1, SerialNumbers: []string{"SN-1"}}}},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10},
+			wantErr:        true,
+		},
+		{
+			name:           "serial numbers which are not in stock",
+			ctx:            context.Background(),
+			payload:        &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 2, SerialNumbers: []string{"SN-1", "SN-2"}}}},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10, Serialized: true},
+			rSerialsRes:    []*entity.ProductSerial{{ID: 1, SerialNumber: "SN-1"}},
 			wantErr:        true,
 		},
 		{
-			name:              "failed to update product",
+			name:              "failed to deduct serial numbers",
+			ctx:               context.Background(),
+			payload:           &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
+			rGetProductRes:    &entity.Product{SKU: "SKU-123", Qty: 10, Serialized: true},
+			rSerialsRes:       []*entity.ProductSerial{{ID: 1, SerialNumber: "SN-1"}},
+			rDeductSerialsErr: errors.New("error deduct serial numbers"),
+			wantErr:           true,
+		},
+		{
+			name:              "failed to update product qty",
 			ctx:               context.Background(),
 			payload:           &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
-			rGetProductRes:    &entity.Product{Qty: 10},
+			rGetProductRes:    &entity.Product{SKU: "SKU-123", Qty: 10},
 			rUpdateProductErr: errors.New("error update product"),
 			wantErr:           true,
 		},
+		{
+			name:              "failed to create stock movements",
+			ctx:               context.Background(),
+			payload:           &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
+			rGetProductRes:    &entity.Product{SKU: "SKU-123", Qty: 10},
+			rStockMovementErr: errors.New("error create stock movements"),
+			wantErr:           true,
+		},
 		{
 			name:           "failed to commit transaction",
 			ctx:            context.Background(),
 			payload:        &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
-			rGetProductRes: &entity.Product{Qty: 10},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10},
 			rCommitTrxErr:  response.ErrNoSQLTransactionFound,
 			wantErr:        true,
 		},
+		{
+			name:             "conflicted on every attempt",
+			ctx:              context.Background(),
+			payload:          &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
+			rGetProductRes:   &entity.Product{SKU: "SKU-123", Qty: 10},
+			rCommitConflicts: 10,
+			wantAttempts:     10,
+			wantErr:          true,
+		},
+		{
+			name:             "success after conflicted attempt",
+			ctx:              context.Background(),
+			payload:          &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
+			rGetProductRes:   &entity.Product{SKU: "SKU-123", Qty: 10},
+			rCommitConflicts: 1,
+			wantAttempts:     2,
+			wantErr:          false,
+		},
+		{
+			name:           "success at location",
+			ctx:            context.Background(),
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10},
+			rLocationRes:   &entity.Location{ID: 1},
+			payload:        &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 10, LocationID: &defaultLocationID}}},
+			wantErr:        false,
+		},
+		{
+			name:           "success with limited backorder",
+			ctx:            context.Background(),
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10, BackorderPolicy: types.BackorderPolicyLimitedType, BackorderLimit: &backorderLimit, RestockDate: &restockDate},
+			payload:        &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 15}}},
+			wantRes:        []*entity.BulkReduceQtyProductItemResult{{SKU: "SKU-123", Status: types.BulkItemStatusAppliedType, ReqQty: 15, FulfilledQty: 10, BackorderedQty: 5, RestockDate: &restockDate}},
+			wantErr:        false,
+		},
+		{
+			name:           "success with unlimited backorder",
+			ctx:            context.Background(),
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10, ReservedQty: 10, BackorderPolicy: types.BackorderPolicyUnlimitedType},
+			payload:        &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 100}}},
+			wantRes:        []*entity.BulkReduceQtyProductItemResult{{SKU: "SKU-123", Status: types.BulkItemStatusAppliedType, ReqQty: 100, FulfilledQty: 0, BackorderedQty: 100}},
+			wantErr:        false,
+		},
+		{
+			name:           "success with lots first-expiring-first-out",
+			ctx:            context.Background(),
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10},
+			rLotsRes:       newLots(),
+			payload:        &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 5}}},
+			wantLotQty:     map[int]int{2: 0, 1: 2},
+			wantErr:        false,
+		},
+		{
+			name:           "success with serial numbers",
+			ctx:            context.Background(),
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10, Serialized: true},
+			rSerialsRes:    []*entity.ProductSerial{{ID: 1, SerialNumber: "SN-1"}, {ID: 2, SerialNumber: "SN-2"}},
+			payload:        &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 2, SerialNumbers: []string{"SN-2", "SN-1"}}}},
+			wantSerialIDs:  []int{1, 2},
+			wantErr:        false,
+		},
+		{
+			name:           "success with serial numbers in stock first",
+			ctx:            context.Background(),
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10, Serialized: true},
+			rSerialsRes:    []*entity.ProductSerial{{ID: 1, SerialNumber: "SN-1"}},
+			payload:        &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 2}}},
+			wantSerialIDs:  []int{1},
+			wantErr:        false,
+		},
 		{
 			name:           "success",
 			ctx:            context.Background(),
-			rGetProductRes: &entity.Product{Qty: 10},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10},
+			payload:        &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
+			wantRes:        []*entity.BulkReduceQtyProductItemResult{{SKU: "SKU-123", Status: types.BulkItemStatusAppliedType, ReqQty: 1, FulfilledQty: 1}},
+			wantErr:        false,
+		}, {
+			name:           "success shows running price and hides cost without finance scope",
+			ctx:            context.Background(),
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10, Price: 1000, PromotionPrice: &promotionPrice, CostPrice: &costPrice},
 			payload:        &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
+			wantPrice:      promotionPrice,
+			wantErr:        false,
+		},
+		{
+			name:           "success shows cost with finance scope",
+			ctx:            context.Background(),
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10, Price: 1000, CostPrice: &costPrice},
+			payload:        &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}, FinanceScope: true},
+			wantPrice:      1000,
+			wantCostPrice:  &costPrice,
 			wantErr:        false,
 		},
 	}
@@ -150,84 +530,259 @@ func TestBulkReduceQtyProduct(t *testing.T) {
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
 			productRepo := &testmock.ProductRepositoryInterface{}
-			productRepo.On("GetProductBySKU", mock.Anything, mock.Anything).Return(tc.rGetProductRes, tc.rGetProductErr)
-			productRepo.On("UpdateProduct", mock.Anything, mock.Anything, mock.Anything).Return(tc.rUpdateProductErr)
+			productRepo.On("GetProductsForUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, []string{"SKU-123"}).Return(lockedProducts(tc.rGetProductRes), tc.rGetProductErr)
+			productRepo.On("UpdateProductQty", mock.Anything, mock.Anything, mock.Anything).Return(tc.rUpdateProductErr)
 
 			dbTransactionRepo := &testmock.PostgresTransactionRepositoryInterface{}
 			dbTransactionRepo.On("StartTransactionQuery", mock.Anything).Return(&sqlx.Tx{}, tc.rStartTrxErr)
+			if tc.rCommitConflicts > 0 {
+				dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(&pq.Error{Code: pq.ErrorCode(config.SerializationFailureCode)}).Times(tc.rCommitConflicts)
+			}
 			dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(tc.rCommitTrxErr)
 			dbTransactionRepo.On("RollbackTransactionQuery", mock.Anything, mock.Anything).Return(nil)
 
-			uc := usecase.NewProductUsecase(productRepo, dbTransactionRepo)
-			_, err := uc.BulkReduceQtyProduct(tc.ctx, tc.tenant, tc.payload)
+			locationRepo := &testmock.LocationRepositoryInterface{}
+			locationRepo.On("GetLocationByID", mock.Anything, mock.Anything).Return(tc.rLocationRes, tc.rLocationErr)
+
+			productStockRepo := &testmock.ProductStockRepositoryInterface{}
+			productStockRepo.On("GetProductLotsByProductIDs", mock.Anything, mock.Anything).Return(tc.rLotsRes, nil)
+			productStockRepo.On("GetProductLotsForUpdate", mock.Anything, mock.Anything, mock.Anything).Return(tc.rLotsRes, nil)
+			productStockRepo.On("UpdateProductLots", mock.Anything, mock.Anything, mock.Anything).Return(tc.rUpdateLotsErr)
+			productStockRepo.On("GetProductStocksForUpdate", mock.Anything, mock.Anything, mock.Anything).Return(func(context.Context, interface{}, int) []*entity.ProductStock {
+				return []*entity.ProductStock{{LocationID: defaultLocationID, Qty: 10}}
+			}, tc.rGetStocksErr)
+			productStockRepo.On("UpsertProductStocks", mock.Anything, mock.Anything, mock.Anything).Return(tc.rUpsertStocksErr)
+			productStockRepo.On("GetInStockProductSerialsForUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.rSerialsRes, nil)
+			productStockRepo.On("DeductProductSerials", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.rDeductSerialsErr)
+
+			stockMovementRepo := &testmock.StockMovementRepositoryInterface{}
+			stockMovementRepo.On("CreateStockMovements", mock.Anything, mock.Anything, mock.Anything).Return(tc.rStockMovementErr)
+
+			lowStockRepo := &testmock.LowStockRepositoryInterface{}
+			lowStockRepo.On("GetLowStockThreshold", mock.Anything, mock.Anything).Return(nil, response.ErrNotFound)
+
+			priceScheduleRepo := &testmock.PriceScheduleRepositoryInterface{}
+			priceScheduleRepo.On("GetDuePriceSchedulesByProductIDs", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+
+			taxClassRepo := &testmock.TaxClassRepositoryInterface{}
+			taxClassRepo.On("GetCategoryTaxClasses", mock.Anything, mock.Anything).Return([]*entity.CategoryTaxClass{}, nil)
+
+			discountRuleRepo := &testmock.DiscountRuleRepositoryInterface{}
+			discountRuleRepo.On("GetActiveDiscountRules", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.DiscountRule{}, nil)
+			productStockRepo.On("GetProductStocksByProductIDs", mock.Anything, mock.Anything).Return([]*entity.ProductStock{}, nil)
+
+			uc := usecase.NewProductUsecase(productRepo, dbTransactionRepo, priceScheduleRepo, taxClassRepo, discountRuleRepo, locationRepo, productStockRepo, stockMovementRepo, lowStockRepo, types.AllocationStrategyPriorityType)
+			res, err := uc.BulkReduceQtyProduct(tc.ctx, tc.tenant, tc.payload)
 			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, tc.wantCustomErr, err)
+			}
+			if tc.wantAttempts > 0 {
+				dbTransactionRepo.AssertNumberOfCalls(t, "StartTransactionQuery", tc.wantAttempts)
+			}
+			if tc.wantRes != nil {
+				assert.Equal(t, tc.wantRes, res.Items)
+			}
+			if tc.wantLotQty != nil {
+				productStockRepo.AssertCalled(t, "UpdateProductLots", mock.Anything, mock.Anything, mock.MatchedBy(func(lots []*entity.ProductLot) bool {
+					gotLotQty := make(map[int]int, len(lots))
+					for _, lot := range lots {
+						gotLotQty[lot.ID] = lot.Qty
+					}
+					return assert.ObjectsAreEqual(tc.wantLotQty, gotLotQty)
+				}))
+			}
+			if tc.wantSerialIDs != nil {
+				productStockRepo.AssertCalled(t, "DeductProductSerials", mock.Anything, mock.Anything, mock.MatchedBy(func(serials []*entity.ProductSerial) bool {
+					gotSerialIDs := make([]int, 0, len(serials))
+					for _, serial := range serials {
+						gotSerialIDs = append(gotSerialIDs, serial.ID)
+					}
+					return assert.ObjectsAreEqual(tc.wantSerialIDs, gotSerialIDs)
+				}), types.SerialStatusSoldType, mock.Anything, mock.Anything)
+			}
+			if !tc.wantErr {
+				item := tc.payload.Items[0]
+				backorderedQty := res.Items[0].BackorderedQty
+				assert.Len(t, res.Products, 1)
+				if tc.wantPrice > 0 {
+					assert.Equal(t, tc.wantPrice, res.Products[0].Price)
+					assert.Equal(t, tc.wantCostPrice, res.Products[0].CostPrice)
+				}
+				fulfilledQty := item.ReqQty - backorderedQty
+				stockMovementRepo.AssertCalled(t, "CreateStockMovements", mock.Anything, mock.Anything, mock.MatchedBy(func(movements []*entity.StockMovement) bool {
+					if fulfilledQty == 0 {
+						return len(movements) == 0
+					}
+					return movements[0].Delta == -fulfilledQty && movements[0].ResultingQty == 10-fulfilledQty && movements[0].Reason == types.StockMovementReasonSaleType
+				}))
+				productRepo.AssertCalled(t, "UpdateProductQty", mock.Anything, mock.Anything, mock.MatchedBy(func(product *entity.Product) bool {
+					return product.BackorderedQty == backorderedQty
+				}))
+			}
 		})
 	}
 }
 
-func TestGetProductByID(t *testing.T) {
+func TestBulkReduceQtyProductPartialMode(t *testing.T) {
+	insufficientItemErr := response.ErrInsufficientStock
+	insufficientItemErr.Field = "items[1]"
+
 	testcases := []struct {
-		name        string
-		ctx         context.Context
-		tenant      types.TenantType
-		rProductRes *entity.Product
-		rProductErr error
-		wantErr     bool
+		name          string
+		payload       *entity.BulkReduceQtyProductPayload
+		wantRes       *entity.BulkReduceQtyProductResult
+		wantAttempts  int
+		wantErr       bool
+		wantCustomErr error
 	}{
 		{
-			name:    "deadline context",
-			ctx:     fixture.CtxEnded(),
-			wantErr: true,
-		},
-		{
-			name:        "product is not found",
-			ctx:         context.Background(),
-			rProductErr: response.ErrNotFound,
-			wantErr:     true,
-		},
-		{
-			name:        "failed to get product",
-			ctx:         context.Background(),
-			rProductErr: errors.New("error get product"),
-			wantErr:     true,
+			name: "atomic mode fails every item",
+			payload: &entity.BulkReduceQtyProductPayload{Items: []entity.BulkReduceQtyProductItemPayload{
+				{SKU: "SKU-1", ReqQty: 2},
+				{SKU: "SKU-2", ReqQty: 5},
+			}},
+			wantAttempts:  1,
+			wantErr:       true,
+			wantCustomErr: insufficientItemErr,
 		},
 		{
-			name:        "forbidden",
-			ctx:         context.Background(),
-			tenant:      types.TenantIpsumType,
-			rProductRes: &entity.Product{ID: 123, Title: "New Product", Tenant: types.TenantLoremType},
-			wantErr:     true,
+			name: "partial mode reduces the items which can be fulfilled",
+			payload: &entity.BulkReduceQtyProductPayload{Mode: types.BulkModePartialType, Items: []entity.BulkReduceQtyProductItemPayload{
+				{SKU: "SKU-1", ReqQty: 2},
+				{SKU: "SKU-2", ReqQty: 5},
+				{SKU: "SKU-1", ReqQty: 0},
+				{SKU: "SKU-1", ReqQty: 3},
+			}},
+			wantRes: &entity.BulkReduceQtyProductResult{
+				Items: []*entity.BulkReduceQtyProductItemResult{
+					{SKU: "SKU-1", Status: types.BulkItemStatusAppliedType, ReqQty: 2, FulfilledQty: 2},
+					{SKU: "SKU-2", Status: types.BulkItemStatusFailedType, ErrorCode: response.ErrorCodeInsufficientStock, ReqQty: 5},
+					{SKU: "SKU-1", Status: types.BulkItemStatusFailedType, ErrorCode: response.ErrorCodeInvalidQty, ReqQty: 0},
+					{SKU: "SKU-1", Status: types.BulkItemStatusAppliedType, ReqQty: 3, FulfilledQty: 3},
+				},
+				Products: []*entity.Product{{
+					ID:           1,
+					SKU:          "SKU-1",
+					Qty:          5,
+					AvailableQty: 5,
+					Stocks:       []*entity.ProductStock{},
+					Lots:         []*entity.ProductLot{},
+					Promotions:   []*entity.AppliedPromotion{},
+					Tax:          &entity.TaxAmount{},
+				}},
+			},
+			wantAttempts: 2,
 		},
 		{
-			name:        "success",
-			ctx:         context.Background(),
-			rProductRes: &entity.Product{ID: 123, Title: "New Product"},
-			wantErr:     false,
+			name: "partial mode with every item failed",
+			payload: &entity.BulkReduceQtyProductPayload{Mode: types.BulkModePartialType, Items: []entity.BulkReduceQtyProductItemPayload{
+				{SKU: "SKU-2", ReqQty: 5},
+			}},
+			wantRes: &entity.BulkReduceQtyProductResult{
+				Items: []*entity.BulkReduceQtyProductItemResult{
+					{SKU: "SKU-2", Status: types.BulkItemStatusFailedType, ErrorCode: response.ErrorCodeInsufficientStock, ReqQty: 5},
+				},
+				Products: []*entity.Product{},
+			},
+			wantAttempts: 1,
 		},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
+			// The products and their stocks are read again on each transaction attempt
+			var stocksByProductID map[int][]*entity.ProductStock
 			productRepo := &testmock.ProductRepositoryInterface{}
-			productRepo.On("GetProductByID", mock.Anything, mock.Anything).Return(tc.rProductRes, tc.rProductErr)
+			productRepo.On("GetProductsForUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, _ interface{}, _ types.TenantType, _ []int, skus []string) []*entity.Product {
+				qtyBySKU := map[string]int{"SKU-1": 10, "SKU-2": 1}
+				stocksByProductID = make(map[int][]*entity.ProductStock)
+				products := make([]*entity.Product, 0, len(skus))
+				for i, sku := range []string{"SKU-1", "SKU-2"} {
+					if helper.StringInArray(sku, skus) {
+						products = append(products, &entity.Product{ID: i + 1, SKU: sku, Qty: qtyBySKU[sku]})
+						stocksByProductID[i+1] = []*entity.ProductStock{{LocationID: 1, Qty: qtyBySKU[sku]}}
+					}
+				}
+				return products
+			}, nil)
+			productRepo.On("UpdateProductQty", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-			uc := usecase.NewProductUsecase(productRepo, &testmock.PostgresTransactionRepositoryInterface{})
-			_, err := uc.GetProductByID(tc.ctx, tc.tenant, 123)
-			assert.Equal(t, tc.wantErr, err != nil)
+			dbTransactionRepo := &testmock.PostgresTransactionRepositoryInterface{}
+			dbTransactionRepo.On("StartTransactionQuery", mock.Anything).Return(&sqlx.Tx{}, nil)
+			dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+			dbTransactionRepo.On("RollbackTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+
+			productStockRepo := &testmock.ProductStockRepositoryInterface{}
+			productStockRepo.On("GetProductLotsByProductIDs", mock.Anything, mock.Anything).Return([]*entity.ProductLot{}, nil)
+			productStockRepo.On("GetProductLotsForUpdate", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.ProductLot{}, nil)
+			productStockRepo.On("UpdateProductLots", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			productStockRepo.On("GetProductStocksForUpdate", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, _ interface{}, productID int) []*entity.ProductStock {
+				return stocksByProductID[productID]
+			}, nil)
+			productStockRepo.On("UpsertProductStocks", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			stockMovementRepo := &testmock.StockMovementRepositoryInterface{}
+			stockMovementRepo.On("CreateStockMovements", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			lowStockRepo := &testmock.LowStockRepositoryInterface{}
+			lowStockRepo.On("GetLowStockThreshold", mock.Anything, mock.Anything).Return(nil, response.ErrNotFound)
+
+			priceScheduleRepo := &testmock.PriceScheduleRepositoryInterface{}
+			priceScheduleRepo.On("GetDuePriceSchedulesByProductIDs", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+
+			taxClassRepo := &testmock.TaxClassRepositoryInterface{}
+			taxClassRepo.On("GetCategoryTaxClasses", mock.Anything, mock.Anything).Return([]*entity.CategoryTaxClass{}, nil)
+
+			discountRuleRepo := &testmock.DiscountRuleRepositoryInterface{}
+			discountRuleRepo.On("GetActiveDiscountRules", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.DiscountRule{}, nil)
+			productStockRepo.On("GetProductStocksByProductIDs", mock.Anything, mock.Anything).Return([]*entity.ProductStock{}, nil)
+
+			uc := usecase.NewProductUsecase(productRepo, dbTransactionRepo, priceScheduleRepo, taxClassRepo, discountRuleRepo, &testmock.LocationRepositoryInterface{}, productStockRepo, stockMovementRepo, lowStockRepo, types.AllocationStrategyPriorityType)
+			res, err := uc.BulkReduceQtyProduct(context.Background(), types.TenantEmptyType, tc.payload)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, tc.wantCustomErr, err)
+			}
+			dbTransactionRepo.AssertNumberOfCalls(t, "StartTransactionQuery", tc.wantAttempts)
+			if !tc.wantErr {
+				for _, product := range res.Products {
+					product.UpdatedAt = time.Time{}
+				}
+				assert.Equal(t, tc.wantRes, res)
+			}
 		})
 	}
 }
 
-func TestGetProducts(t *testing.T) {
+func TestBulkIncreaseQtyProduct(t *testing.T) {
+	defaultLocationID := 1
+	locationID := 2
+	expiryDate := time.Now().AddDate(0, 1, 0)
+
 	testcases := []struct {
-		name                 string
-		ctx                  context.Context
-		payload              *entity.GetProductPayload
-		rGetProductsRes      []*entity.Product
-		rGetProductsErr      error
-		rGetProductsCountRes int
-		rGetProductsCountErr error
-		wantErr              bool
+		name              string
+		ctx               context.Context
+		tenant            types.TenantType
+		payload           *entity.BulkIncreaseQtyProductPayload
+		rStartTrxErr      error
+		rCommitTrxErr     error
+		rGetProductRes    *entity.Product
+		rGetProductErr    error
+		rLocationRes      *entity.Location
+		rDefaultLocErr    error
+		rGetStocksErr     error
+		rUpsertStocksErr  error
+		rAddLotErr        error
+		rAddSerialsErr    error
+		rUpdateProductErr error
+		rStockMovementErr error
+		wantQty           int
+		wantFilledQty     int
+		wantReason        types.StockMovementReasonType
+		wantLot           *entity.ProductLot
+		wantSerials       []string
+		wantErr           bool
 	}{
 		{
 			name:    "deadline context",
@@ -235,48 +790,769 @@ func TestGetProducts(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name:            "failed to get products",
-			ctx:             context.Background(),
-			rGetProductsErr: errors.New("error get products"),
-			wantErr:         true,
+			name:    "invalid qty",
+			ctx:     context.Background(),
+			payload: &entity.BulkIncreaseQtyProductPayload{Items: []entity.BulkIncreaseQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 0}}},
+			wantErr: true,
 		},
 		{
-			name:                 "failed to get products count",
-			ctx:                  context.Background(),
-			rGetProductsCountErr: errors.New("error get products count"),
-			wantErr:              true,
+			name:    "reason reducing qty",
+			ctx:     context.Background(),
+			payload: &entity.BulkIncreaseQtyProductPayload{Items: []entity.BulkIncreaseQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}, Reason: types.StockMovementReasonSaleType},
+			wantErr: true,
 		},
 		{
-			name:    "success",
+			name:         "failed to start transaction",
+			ctx:          context.Background(),
+			payload:      &entity.BulkIncreaseQtyProductPayload{Items: []entity.BulkIncreaseQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
+			rStartTrxErr: response.ErrNoSQLTransactionFound,
+			wantErr:      true,
+		},
+		{
+			name:    "product is not found",
 			ctx:     context.Background(),
-			payload: &entity.GetProductPayload{Tenant: types.TenantLoremType},
-			wantErr: false,
+			payload: &entity.BulkIncreaseQtyProductPayload{Items: []entity.BulkIncreaseQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
+			wantErr: true,
 		},
-	}
-
-	for _, tc := range testcases {
-		t.Run(tc.name, func(t *testing.T) {
-			productRepo := &testmock.ProductRepositoryInterface{}
-			productRepo.On("GetProducts", mock.Anything, mock.Anything).Return(tc.rGetProductsRes, tc.rGetProductsErr)
-			productRepo.On("GetProductsCount", mock.Anything, mock.Anything).Return(tc.rGetProductsCountRes, tc.rGetProductsCountErr)
+		{
+			name:           "failed to get product",
+			ctx:            context.Background(),
+			payload:        &entity.BulkIncreaseQtyProductPayload{Items: []entity.BulkIncreaseQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
+			rGetProductErr: errors.New("error get product"),
+			wantErr:        true,
+		},
+		{
+			name:           "product of another tenant is not found",
+			ctx:            context.Background(),
+			tenant:         types.TenantIpsumType,
+			payload:        &entity.BulkIncreaseQtyProductPayload{Items: []entity.BulkIncreaseQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10, Tenant: types.TenantLoremType},
+			wantErr:        true,
+		},
+		{
+			name:           "location of another tenant",
+			ctx:            context.Background(),
+			payload:        &entity.BulkIncreaseQtyProductPayload{Items: []entity.BulkIncreaseQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1, LocationID: &locationID}}},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10},
+			rLocationRes:   &entity.Location{ID: locationID, Tenant: types.TenantIpsumType},
+			wantErr:        true,
+		},
+		{
+			name:           "failed to get product stocks",
+			ctx:            context.Background(),
+			payload:        &entity.BulkIncreaseQtyProductPayload{Items: []entity.BulkIncreaseQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10},
+			rGetStocksErr:  errors.New("error get product stocks"),
+			wantErr:        true,
+		},
+		{
+			name:           "failed to get default location",
+			ctx:            context.Background(),
+			payload:        &entity.BulkIncreaseQtyProductPayload{Items: []entity.BulkIncreaseQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10},
+			rDefaultLocErr: errors.New("error get default location"),
+			wantErr:        true,
+		},
+		{
+			name:             "failed to upsert product stocks",
+			ctx:              context.Background(),
+			payload:          &entity.BulkIncreaseQtyProductPayload{Items: []entity.BulkIncreaseQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
+			rGetProductRes:   &entity.Product{SKU: "SKU-123", Qty: 10},
+			rUpsertStocksErr: errors.New("error upsert product stocks"),
+			wantErr:          true,
+		},
+		{
+			name:    "lot without expiry date",
+			ctx:     context.Background(),
+			payload: &entity.BulkIncreaseQtyProductPayload{Items: []entity.BulkIncreaseQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1, LotNumber: "LOT-1"}}},
+			wantErr: true,
+		},
+		{
+			name:           "failed to add lot qty",
+			ctx:            context.Background(),
+			payload:        &entity.BulkIncreaseQtyProductPayload{Items: []entity.BulkIncreaseQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1, LotNumber: "LOT-1", ExpiryDate: &expiryDate}}},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10},
+			rAddLotErr:     errors.New("error add lot qty"),
+			wantErr:        true,
+		},
+		{
+			name:              "failed to update product qty",
+			ctx:               context.Background(),
+			payload:           &entity.BulkIncreaseQtyProductPayload{Items: []entity.BulkIncreaseQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
+			rGetProductRes:    &entity.Product{SKU: "SKU-123", Qty: 10},
+			rUpdateProductErr: errors.New("error update product qty"),
+			wantErr:           true,
+		},
+		{
+			name:              "failed to create stock movements",
+			ctx:               context.Background(),
+			payload:           &entity.BulkIncreaseQtyProductPayload{Items: []entity.BulkIncreaseQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
+			rGetProductRes:    &entity.Product{SKU: "SKU-123", Qty: 10},
+			rStockMovementErr: errors.New("error create stock movements"),
+			wantErr:           true,
+		},
+		{
+			name:           "failed to commit transaction",
+			ctx:            context.Background(),
+			payload:        &entity.BulkIncreaseQtyProductPayload{Items: []entity.BulkIncreaseQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10},
+			rCommitTrxErr:  response.ErrNoSQLTransactionFound,
+			wantErr:        true,
+		},
+		{
+			name:           "success at new location",
+			ctx:            context.Background(),
+			payload:        &entity.BulkIncreaseQtyProductPayload{Items: []entity.BulkIncreaseQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 5, LocationID: &locationID}}, Reason: types.StockMovementReasonReturnType},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10},
+			rLocationRes:   &entity.Location{ID: locationID},
+			wantQty:        15,
+			wantReason:     types.StockMovementReasonReturnType,
+			wantErr:        false,
+		},
+		{
+			name:           "success with filled backorder",
+			ctx:            context.Background(),
+			payload:        &entity.BulkIncreaseQtyProductPayload{Items: []entity.BulkIncreaseQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 3}}},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10, ReservedQty: 10, BackorderedQty: 2, BackorderPolicy: types.BackorderPolicyUnlimitedType},
+			wantQty:        11,
+			wantFilledQty:  2,
+			wantReason:     types.StockMovementReasonRestockType,
+			wantErr:        false,
+		},
+		{
+			name:           "invalid serial numbers",
+			ctx:            context.Background(),
+			payload:        &entity.BulkIncreaseQtyProductPayload{Items: []entity.BulkIncreaseQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 2, SerialNumbers: []string{"SN-1", "SN-1"}}}},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10, Serialized: true},
+			wantErr:        true,
+		},
+		{
+			name:           "serialized product without serial numbers",
+			ctx:            context.Background(),
+			payload:        &entity.BulkIncreaseQtyProductPayload{Items: []entity.BulkIncreaseQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1}}},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10, Serialized: true},
+			wantErr:        true,
+		},
+		{
+			name:           "serial number is already registered",
+			ctx:            context.Background(),
+			payload:        &entity.BulkIncreaseQtyProductPayload{Items: []entity.BulkIncreaseQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 1, SerialNumbers: []string{"SN-1"}}}},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10, Serialized: true},
+			rAddSerialsErr: response.ErrSerialNumberConflict,
+			wantErr:        true,
+		},
+		{
+			name:           "success with serial numbers",
+			ctx:            context.Background(),
+			payload:        &entity.BulkIncreaseQtyProductPayload{Items: []entity.BulkIncreaseQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 2, SerialNumbers: []string{"SN-1", "SN-2"}}}},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10, Serialized: true},
+			wantQty:        12,
+			wantReason:     types.StockMovementReasonRestockType,
+			wantSerials:    []string{"SN-1", "SN-2"},
+			wantErr:        false,
+		},
+		{
+			name:           "success with lot",
+			ctx:            context.Background(),
+			payload:        &entity.BulkIncreaseQtyProductPayload{Items: []entity.BulkIncreaseQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 3, LotNumber: "LOT-1", ExpiryDate: &expiryDate}}},
+			rGetProductRes: &entity.Product{ID: 123, SKU: "SKU-123", Qty: 10},
+			wantQty:        13,
+			wantReason:     types.StockMovementReasonRestockType,
+			wantLot:        &entity.ProductLot{ProductID: 123, LotNumber: "LOT-1", ExpiryDate: expiryDate, Qty: 3},
+			wantErr:        false,
+		},
+		{
+			name:           "success",
+			ctx:            context.Background(),
+			payload:        &entity.BulkIncreaseQtyProductPayload{Items: []entity.BulkIncreaseQtyProductItemPayload{{SKU: "SKU-123", ReqQty: 3}}},
+			rGetProductRes: &entity.Product{SKU: "SKU-123", Qty: 10},
+			wantQty:        13,
+			wantReason:     types.StockMovementReasonRestockType,
+			wantErr:        false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("GetProductsForUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, []string{"SKU-123"}).Return(lockedProducts(tc.rGetProductRes), tc.rGetProductErr)
+			productRepo.On("UpdateProductQty", mock.Anything, mock.Anything, mock.Anything).Return(tc.rUpdateProductErr)
+
+			dbTransactionRepo := &testmock.PostgresTransactionRepositoryInterface{}
+			dbTransactionRepo.On("StartTransactionQuery", mock.Anything).Return(&sqlx.Tx{}, tc.rStartTrxErr)
+			dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(tc.rCommitTrxErr)
+			dbTransactionRepo.On("RollbackTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+
+			locationRepo := &testmock.LocationRepositoryInterface{}
+			locationRepo.On("GetLocationByID", mock.Anything, mock.Anything).Return(tc.rLocationRes, nil)
+			locationRepo.On("GetOrCreateDefaultLocation", mock.Anything, mock.Anything, mock.Anything).Return(&entity.Location{ID: defaultLocationID}, tc.rDefaultLocErr)
+
+			productStockRepo := &testmock.ProductStockRepositoryInterface{}
+			productStockRepo.On("GetProductLotsByProductIDs", mock.Anything, mock.Anything).Return([]*entity.ProductLot{}, nil)
+			productStockRepo.On("GetProductLotsForUpdate", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.ProductLot{}, nil)
+			productStockRepo.On("UpdateProductLots", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			productStockRepo.On("AddProductLotQty", mock.Anything, mock.Anything, mock.Anything).Return(tc.rAddLotErr)
+			productStockRepo.On("GetProductStocksForUpdate", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.ProductStock{{LocationID: defaultLocationID, Qty: 10}}, tc.rGetStocksErr)
+			productStockRepo.On("UpsertProductStocks", mock.Anything, mock.Anything, mock.Anything).Return(tc.rUpsertStocksErr)
+			productStockRepo.On("CreateProductSerials", mock.Anything, mock.Anything, mock.Anything).Return(tc.rAddSerialsErr)
+
+			stockMovementRepo := &testmock.StockMovementRepositoryInterface{}
+			stockMovementRepo.On("CreateStockMovements", mock.Anything, mock.Anything, mock.Anything).Return(tc.rStockMovementErr)
+
+			lowStockRepo := &testmock.LowStockRepositoryInterface{}
+			lowStockRepo.On("GetLowStockThreshold", mock.Anything, mock.Anything).Return(nil, response.ErrNotFound)
+
+			uc := usecase.NewProductUsecase(productRepo, dbTransactionRepo, &testmock.PriceScheduleRepositoryInterface{}, &testmock.TaxClassRepositoryInterface{}, &testmock.DiscountRuleRepositoryInterface{}, locationRepo, productStockRepo, stockMovementRepo, lowStockRepo, types.AllocationStrategyPriorityType)
+			res, err := uc.BulkIncreaseQtyProduct(tc.ctx, tc.tenant, tc.payload)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantLot != nil {
+				productStockRepo.AssertCalled(t, "AddProductLotQty", mock.Anything, mock.Anything, tc.wantLot)
+			}
+			if tc.wantSerials != nil {
+				productStockRepo.AssertCalled(t, "CreateProductSerials", mock.Anything, mock.Anything, mock.MatchedBy(func(serials []*entity.ProductSerial) bool {
+					gotSerials := make([]string, 0, len(serials))
+					for _, serial := range serials {
+						gotSerials = append(gotSerials, serial.SerialNumber)
+					}
+					return assert.ObjectsAreEqual(tc.wantSerials, gotSerials)
+				}))
+			}
+			if !tc.wantErr {
+				assert.Equal(t, tc.wantQty, res[0].Qty)
+				productRepo.AssertCalled(t, "UpdateProductQty", mock.Anything, mock.Anything, res[0])
+				assert.Equal(t, 0, res[0].BackorderedQty)
+				stockMovementRepo.AssertCalled(t, "CreateStockMovements", mock.Anything, mock.Anything, mock.MatchedBy(func(movements []*entity.StockMovement) bool {
+					if movements[0].Delta != tc.payload.Items[0].ReqQty || movements[0].ResultingQty != tc.wantQty+tc.wantFilledQty || movements[0].Reason != tc.wantReason {
+						return false
+					}
+					if tc.wantFilledQty == 0 {
+						return len(movements) == 1
+					}
+					return len(movements) == 2 && movements[1].Delta == -tc.wantFilledQty && movements[1].ResultingQty == tc.wantQty && movements[1].Reason == types.StockMovementReasonSaleType
+				}))
+			}
+		})
+	}
+}
+
+func TestAdjustQtyProduct(t *testing.T) {
+	testcases := []struct {
+		name              string
+		ctx               context.Context
+		payload           *entity.AdjustQtyProductPayload
+		rGetProductRes    *entity.Product
+		rGetProductErr    error
+		rStockMovementErr error
+		wantQty           int
+		wantReason        types.StockMovementReasonType
+		wantErr           bool
+		wantCustomErr     error
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "zero delta",
+			ctx:           context.Background(),
+			payload:       &entity.AdjustQtyProductPayload{Tenant: types.TenantLoremType},
+			wantErr:       true,
+			wantCustomErr: response.ErrInvalidQty,
+		},
+		{
+			name:          "damage increasing qty",
+			ctx:           context.Background(),
+			payload:       &entity.AdjustQtyProductPayload{Delta: 2, Reason: types.StockMovementReasonDamageType, Tenant: types.TenantLoremType},
+			wantErr:       true,
+			wantCustomErr: response.ErrInvalidStockMovementReason,
+		},
+		{
+			name:          "product is not found",
+			ctx:           context.Background(),
+			payload:       &entity.AdjustQtyProductPayload{Delta: -2, Tenant: types.TenantLoremType},
+			wantErr:       true,
+			wantCustomErr: response.ErrNotFound,
+		},
+		{
+			name:           "failed to get product",
+			ctx:            context.Background(),
+			payload:        &entity.AdjustQtyProductPayload{Delta: -2, Tenant: types.TenantLoremType},
+			rGetProductErr: errors.New("error get product"),
+			wantErr:        true,
+		},
+		{
+			name:           "product of another tenant is not found",
+			ctx:            context.Background(),
+			payload:        &entity.AdjustQtyProductPayload{Delta: -2, Tenant: types.TenantIpsumType},
+			rGetProductRes: &entity.Product{ID: 123, Tenant: types.TenantLoremType, Qty: 10},
+			wantErr:        true,
+			wantCustomErr:  response.ErrNotFound,
+		},
+		{
+			name:           "insufficient stock which is not reserved",
+			ctx:            context.Background(),
+			payload:        &entity.AdjustQtyProductPayload{Delta: -6, Tenant: types.TenantLoremType},
+			rGetProductRes: &entity.Product{ID: 123, Tenant: types.TenantLoremType, Qty: 10, ReservedQty: 5},
+			wantErr:        true,
+			wantCustomErr:  response.ErrInsufficientStock,
+		},
+		{
+			name:              "failed to create stock movements",
+			ctx:               context.Background(),
+			payload:           &entity.AdjustQtyProductPayload{Delta: -2, Tenant: types.TenantLoremType},
+			rGetProductRes:    &entity.Product{ID: 123, Tenant: types.TenantLoremType, Qty: 10},
+			rStockMovementErr: errors.New("error create stock movements"),
+			wantErr:           true,
+		},
+		{
+			name:           "success reducing qty",
+			ctx:            context.Background(),
+			payload:        &entity.AdjustQtyProductPayload{Delta: -2, Reason: types.StockMovementReasonDamageType, ReferenceID: "DMG-1", Tenant: types.TenantLoremType},
+			rGetProductRes: &entity.Product{ID: 123, Tenant: types.TenantLoremType, Qty: 10},
+			wantQty:        8,
+			wantReason:     types.StockMovementReasonDamageType,
+			wantErr:        false,
+		},
+		{
+			name:           "success increasing qty",
+			ctx:            context.Background(),
+			payload:        &entity.AdjustQtyProductPayload{Delta: 4, Tenant: types.TenantLoremType},
+			rGetProductRes: &entity.Product{ID: 123, Tenant: types.TenantLoremType, Qty: 10},
+			wantQty:        14,
+			wantReason:     types.StockMovementReasonAdjustmentType,
+			wantErr:        false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("GetProductsForUpdate", mock.Anything, mock.Anything, mock.Anything, []int{123}, mock.Anything).Return(lockedProducts(tc.rGetProductRes), tc.rGetProductErr)
+			productRepo.On("UpdateProductQty", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			dbTransactionRepo := &testmock.PostgresTransactionRepositoryInterface{}
+			dbTransactionRepo.On("StartTransactionQuery", mock.Anything).Return(&sqlx.Tx{}, nil)
+			dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+			dbTransactionRepo.On("RollbackTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+
+			locationRepo := &testmock.LocationRepositoryInterface{}
+			locationRepo.On("GetOrCreateDefaultLocation", mock.Anything, mock.Anything, mock.Anything).Return(&entity.Location{ID: 1}, nil)
+
+			productStockRepo := &testmock.ProductStockRepositoryInterface{}
+			productStockRepo.On("GetProductLotsByProductIDs", mock.Anything, mock.Anything).Return([]*entity.ProductLot{}, nil)
+			productStockRepo.On("GetProductLotsForUpdate", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.ProductLot{}, nil)
+			productStockRepo.On("UpdateProductLots", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			productStockRepo.On("GetProductStocksForUpdate", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.ProductStock{{LocationID: 1, Qty: 10}}, nil)
+			productStockRepo.On("UpsertProductStocks", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			stockMovementRepo := &testmock.StockMovementRepositoryInterface{}
+			stockMovementRepo.On("CreateStockMovements", mock.Anything, mock.Anything, mock.Anything).Return(tc.rStockMovementErr)
+
+			lowStockRepo := &testmock.LowStockRepositoryInterface{}
+			lowStockRepo.On("GetLowStockThreshold", mock.Anything, mock.Anything).Return(nil, response.ErrNotFound)
+
+			uc := usecase.NewProductUsecase(productRepo, dbTransactionRepo, &testmock.PriceScheduleRepositoryInterface{}, &testmock.TaxClassRepositoryInterface{}, &testmock.DiscountRuleRepositoryInterface{}, locationRepo, productStockRepo, stockMovementRepo, lowStockRepo, types.AllocationStrategyPriorityType)
+			res, err := uc.AdjustQtyProduct(tc.ctx, 123, tc.payload)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, tc.wantCustomErr, err)
+			}
+			if !tc.wantErr {
+				assert.Equal(t, tc.wantQty, res.Qty)
+				stockMovementRepo.AssertCalled(t, "CreateStockMovements", mock.Anything, mock.Anything, mock.MatchedBy(func(movements []*entity.StockMovement) bool {
+					return movements[0].Delta == tc.payload.Delta && movements[0].ResultingQty == tc.wantQty &&
+						movements[0].Reason == tc.wantReason && movements[0].ReferenceID == tc.payload.ReferenceID
+				}))
+			}
+		})
+	}
+}
+
+func TestGetProductByID(t *testing.T) {
+	taxClassID := 1
+	costPrice := 700
+
+	testcases := []struct {
+		name            string
+		ctx             context.Context
+		tenant          types.TenantType
+		region          string
+		financeScope    bool
+		fieldset        *entity.ProductFieldset
+		wantColumns     []string
+		rProductRes     *entity.Product
+		rProductErr     error
+		rDueErr         error
+		rDiscountRes    []*entity.DiscountRule
+		rDiscountErr    error
+		rCategoryTaxRes []*entity.CategoryTaxClass
+		rCategoryTaxErr error
+		rTaxClassesErr  error
+		rTaxRatesErr    error
+		rStocksRes      []*entity.ProductStock
+		rStocksErr      error
+		wantTaxClassID  *int
+		wantPrice       int
+		wantGross       int
+		wantCostPrice   *int
+		wantErr         bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:        "product is not found",
+			ctx:         context.Background(),
+			rProductErr: response.ErrNotFound,
+			wantErr:     true,
+		},
+		{
+			name:        "failed to get product",
+			ctx:         context.Background(),
+			rProductErr: errors.New("error get product"),
+			wantErr:     true,
+		},
+		{
+			name:        "forbidden",
+			ctx:         context.Background(),
+			tenant:      types.TenantIpsumType,
+			rProductRes: &entity.Product{ID: 123, Title: "New Product", Tenant: types.TenantLoremType},
+			wantErr:     true,
+		},
+		{
+			name:        "failed to get due price schedules",
+			ctx:         context.Background(),
+			rProductRes: &entity.Product{ID: 123, Title: "New Product"},
+			rDueErr:     errors.New("error get due price schedules"),
+			wantErr:     true,
+		},
+		{
+			name:         "failed to get active discount rules",
+			ctx:          context.Background(),
+			rProductRes:  &entity.Product{ID: 123, Title: "New Product"},
+			rDiscountErr: errors.New("error get active discount rules"),
+			wantErr:      true,
+		},
+		{
+			name:        "failed to get product stocks",
+			ctx:         context.Background(),
+			rProductRes: &entity.Product{ID: 123, Title: "New Product"},
+			rStocksErr:  errors.New("error get product stocks"),
+			wantErr:     true,
+		},
+		{
+			name:            "failed to get category tax classes",
+			ctx:             context.Background(),
+			rProductRes:     &entity.Product{ID: 123, Title: "New Product"},
+			rCategoryTaxErr: errors.New("error get category tax classes"),
+			wantErr:         true,
+		},
+		{
+			name:           "failed to get tax classes",
+			ctx:            context.Background(),
+			rProductRes:    &entity.Product{ID: 123, Title: "New Product", TaxClassID: &taxClassID},
+			rTaxClassesErr: errors.New("error get tax classes"),
+			wantErr:        true,
+		},
+		{
+			name:         "failed to get tax rates",
+			ctx:          context.Background(),
+			rProductRes:  &entity.Product{ID: 123, Title: "New Product", TaxClassID: &taxClassID},
+			rTaxRatesErr: errors.New("error get tax rates"),
+			wantErr:      true,
+		},
+		{
+			name:        "success without tax class",
+			ctx:         context.Background(),
+			region:      "ID",
+			rProductRes: &entity.Product{ID: 123, Title: "New Product", Price: 1000, Qty: 10},
+			rStocksRes:  []*entity.ProductStock{{ProductID: 123, LocationID: 1, Qty: 6}, {ProductID: 123, LocationID: 2, Qty: 4}},
+			wantPrice:   1000,
+			wantGross:   1000,
+			wantErr:     false,
+		},
+		{
+			name:         "success with discount rule",
+			ctx:          context.Background(),
+			region:       "ID",
+			rProductRes:  &entity.Product{ID: 123, Title: "New Product", Price: 1000},
+			rDiscountRes: []*entity.DiscountRule{{ID: 1, Name: "Sale", Type: types.DiscountFixedAmountType, Value: 100}},
+			wantPrice:    900,
+			wantGross:    900,
+			wantErr:      false,
+		},
+		{
+			name:            "success with default tax class of category",
+			ctx:             context.Background(),
+			region:          "ID",
+			rProductRes:     &entity.Product{ID: 123, Title: "New Product", Category: types.CategoryBookType, Price: 1000},
+			rCategoryTaxRes: []*entity.CategoryTaxClass{{Category: types.CategoryBookType, TaxClassID: taxClassID}},
+			wantTaxClassID:  &taxClassID,
+			wantPrice:       1000,
+			wantGross:       1110,
+			wantErr:         false,
+		},
+		{
+			name:        "cost price is hidden without finance scope",
+			ctx:         context.Background(),
+			rProductRes: &entity.Product{ID: 123, Title: "New Product", Price: 1000, CostPrice: &costPrice},
+			wantPrice:   1000,
+			wantGross:   1000,
+			wantErr:     false,
+		},
+		{
+			name:          "cost price is shown with finance scope",
+			ctx:           context.Background(),
+			financeScope:  true,
+			rProductRes:   &entity.Product{ID: 123, Title: "New Product", Price: 1000, CostPrice: &costPrice},
+			wantPrice:     1000,
+			wantGross:     1000,
+			wantCostPrice: &costPrice,
+			wantErr:       false,
+		},
+		{
+			name:           "success with tax class of product",
+			ctx:            context.Background(),
+			region:         "ID",
+			rProductRes:    &entity.Product{ID: 123, Title: "New Product", Price: 1000, TaxClassID: &taxClassID},
+			wantTaxClassID: &taxClassID,
+			wantPrice:      1000,
+			wantGross:      1110,
+			wantErr:        false,
+		},
+		{
+			name:        "sparse fieldset skips the price and the relations",
+			ctx:         context.Background(),
+			fieldset:    &entity.ProductFieldset{Fields: []string{"id", "title"}},
+			wantColumns: []string{"id", "tenant", "version", "title"},
+			rProductRes: &entity.Product{ID: 123, Title: "New Product", Price: 1000},
+			rDueErr:     errors.New("error get due price schedules"),
+			rStocksErr:  errors.New("error get product stocks"),
+			wantErr:     false,
+		},
+		{
+			name:        "included stocks are attached",
+			ctx:         context.Background(),
+			fieldset:    &entity.ProductFieldset{Fields: []string{"id"}, Relations: []string{entity.StocksRelation}},
+			wantColumns: []string{"id", "tenant", "version"},
+			rProductRes: &entity.Product{ID: 123, Title: "New Product", Price: 1000},
+			rDueErr:     errors.New("error get due price schedules"),
+			rStocksRes:  []*entity.ProductStock{{ProductID: 123, LocationID: 1, Qty: 6}},
+			wantErr:     false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("GetPartialProductByID", mock.Anything, mock.Anything, tc.wantColumns).Return(tc.rProductRes, tc.rProductErr)
+
+			priceScheduleRepo := &testmock.PriceScheduleRepositoryInterface{}
+			priceScheduleRepo.On("GetDuePriceSchedulesByProductIDs", mock.Anything, mock.Anything, mock.Anything).Return(nil, tc.rDueErr)
+
+			taxClassRepo := &testmock.TaxClassRepositoryInterface{}
+			taxClassRepo.On("GetCategoryTaxClasses", mock.Anything, mock.Anything).Return(tc.rCategoryTaxRes, tc.rCategoryTaxErr)
+			taxClassRepo.On("GetTaxClassesByIDs", mock.Anything, mock.Anything).Return([]*entity.TaxClass{{ID: taxClassID}}, tc.rTaxClassesErr)
+			taxClassRepo.On("GetTaxRatesByTaxClassIDs", mock.Anything, mock.Anything).Return([]*entity.TaxRate{{TaxClassID: taxClassID, Region: "ID", Rate: 1100}}, tc.rTaxRatesErr)
+
+			discountRuleRepo := &testmock.DiscountRuleRepositoryInterface{}
+			discountRuleRepo.On("GetActiveDiscountRules", mock.Anything, mock.Anything, mock.Anything).Return(tc.rDiscountRes, tc.rDiscountErr)
+
+			productStockRepo := &testmock.ProductStockRepositoryInterface{}
+			productStockRepo.On("GetProductLotsByProductIDs", mock.Anything, mock.Anything).Return([]*entity.ProductLot{}, nil)
+			productStockRepo.On("GetProductLotsForUpdate", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.ProductLot{}, nil)
+			productStockRepo.On("UpdateProductLots", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			productStockRepo.On("GetProductStocksByProductIDs", mock.Anything, mock.Anything).Return(tc.rStocksRes, tc.rStocksErr)
+
+			uc := usecase.NewProductUsecase(productRepo, &testmock.PostgresTransactionRepositoryInterface{}, priceScheduleRepo, taxClassRepo, discountRuleRepo, &testmock.LocationRepositoryInterface{}, productStockRepo, &testmock.StockMovementRepositoryInterface{}, &testmock.LowStockRepositoryInterface{}, types.AllocationStrategyPriorityType)
+			res, err := uc.GetProductByID(tc.ctx, &entity.GetProductByIDPayload{ID: 123, Tenant: tc.tenant, Region: tc.region, FinanceScope: tc.financeScope, Fieldset: tc.fieldset})
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr && tc.fieldset != nil {
+				assert.Nil(t, res.Tax)
+				assert.Equal(t, len(tc.rStocksRes), len(res.Stocks))
+			} else if !tc.wantErr {
+				assert.Equal(t, tc.wantPrice, res.Price)
+				assert.Equal(t, tc.wantTaxClassID, res.Tax.TaxClassID)
+				assert.Equal(t, tc.wantGross, res.Tax.Gross)
+				assert.Equal(t, tc.wantCostPrice, res.CostPrice)
+				assert.Equal(t, len(tc.rStocksRes), len(res.Stocks))
+			}
+		})
+	}
+}
+
+func TestGetProducts(t *testing.T) {
+	minPrice, maxPrice := 100, 200
+	cursor := (&entity.ProductCursor{ID: 130}).Encode()
+	backwardCursor := (&entity.ProductCursor{ID: 120, Backward: true}).Encode()
+	promotionPrice := 800
+	priceSort := entity.ProductSort{{Field: "price"}}
+	priceCursor := (&entity.ProductCursor{Sort: priceSort, Values: []string{"1000"}, ID: 130}).Encode()
+
+	testcases := []struct {
+		name                 string
+		ctx                  context.Context
+		payload              *entity.GetProductPayload
+		rGetProductsRes      []*entity.Product
+		rGetProductsErr      error
+		rGetProductsCountRes int
+		rGetProductsCountErr error
+		rDueErr              error
+		rCategoryTaxErr      error
+		rDiscountErr         error
+		wantNextCursor       string
+		wantPrevCursor       string
+		wantErr              bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:            "failed to get products",
+			ctx:             context.Background(),
+			payload:         &entity.GetProductPayload{Tenant: types.TenantLoremType},
+			rGetProductsErr: errors.New("error get products"),
+			wantErr:         true,
+		},
+		{
+			name:                 "failed to get products count",
+			ctx:                  context.Background(),
+			payload:              &entity.GetProductPayload{Tenant: types.TenantLoremType},
+			rGetProductsCountErr: errors.New("error get products count"),
+			wantErr:              true,
+		},
+		{
+			name:            "failed to get due price schedules",
+			ctx:             context.Background(),
+			payload:         &entity.GetProductPayload{Tenant: types.TenantLoremType},
+			rGetProductsRes: []*entity.Product{{ID: 123}},
+			rDueErr:         errors.New("error get due price schedules"),
+			wantErr:         true,
+		},
+		{
+			name:            "failed to get active discount rules",
+			ctx:             context.Background(),
+			payload:         &entity.GetProductPayload{Tenant: types.TenantLoremType},
+			rGetProductsRes: []*entity.Product{{ID: 123}},
+			rDiscountErr:    errors.New("error get active discount rules"),
+			wantErr:         true,
+		},
+		{
+			name:            "failed to get category tax classes",
+			ctx:             context.Background(),
+			payload:         &entity.GetProductPayload{Tenant: types.TenantLoremType},
+			rGetProductsRes: []*entity.Product{{ID: 123}},
+			rCategoryTaxErr: errors.New("error get category tax classes"),
+			wantErr:         true,
+		},
+		{
+			name:    "invalid price range",
+			ctx:     context.Background(),
+			payload: &entity.GetProductPayload{Tenant: types.TenantLoremType, MinPrice: &maxPrice, MaxPrice: &minPrice},
+			wantErr: true,
+		},
+		{
+			name:    "invalid cursor",
+			ctx:     context.Background(),
+			payload: &entity.GetProductPayload{Tenant: types.TenantLoremType, Cursor: "invalid"},
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			payload: &entity.GetProductPayload{Tenant: types.TenantLoremType},
+			wantErr: false,
+		},
+		{
+			name:            "success first full page",
+			ctx:             context.Background(),
+			payload:         &entity.GetProductPayload{Tenant: types.TenantLoremType, Limit: 2},
+			rGetProductsRes: []*entity.Product{{ID: 140}, {ID: 130}},
+			wantNextCursor:  cursor,
+			wantErr:         false,
+		},
+		{
+			name:            "success page of cursor",
+			ctx:             context.Background(),
+			payload:         &entity.GetProductPayload{Tenant: types.TenantLoremType, Limit: 2, Cursor: cursor},
+			rGetProductsRes: []*entity.Product{{ID: 120}},
+			wantPrevCursor:  backwardCursor,
+			wantErr:         false,
+		},
+		{
+			name:            "success cursor of promoted product holds the stored price",
+			ctx:             context.Background(),
+			payload:         &entity.GetProductPayload{Tenant: types.TenantLoremType, Limit: 1, Sort: priceSort},
+			rGetProductsRes: []*entity.Product{{ID: 130, Price: 1000, PromotionPrice: &promotionPrice}},
+			wantNextCursor:  priceCursor,
+			wantErr:         false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("GetProducts", mock.Anything, mock.Anything).Return(tc.rGetProductsRes, tc.rGetProductsErr)
+			productRepo.On("GetProductsCount", mock.Anything, mock.Anything).Return(tc.rGetProductsCountRes, tc.rGetProductsCountErr)
+
+			priceScheduleRepo := &testmock.PriceScheduleRepositoryInterface{}
+			priceScheduleRepo.On("GetDuePriceSchedulesByProductIDs", mock.Anything, mock.Anything, mock.Anything).Return(nil, tc.rDueErr)
+
+			taxClassRepo := &testmock.TaxClassRepositoryInterface{}
+			taxClassRepo.On("GetCategoryTaxClasses", mock.Anything, mock.Anything).Return([]*entity.CategoryTaxClass{}, tc.rCategoryTaxErr)
 
-			uc := usecase.NewProductUsecase(productRepo, &testmock.PostgresTransactionRepositoryInterface{})
-			_, _, err := uc.GetProducts(tc.ctx, tc.payload)
+			discountRuleRepo := &testmock.DiscountRuleRepositoryInterface{}
+			discountRuleRepo.On("GetActiveDiscountRules", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.DiscountRule{}, tc.rDiscountErr)
+
+			productStockRepo := &testmock.ProductStockRepositoryInterface{}
+			productStockRepo.On("GetProductLotsByProductIDs", mock.Anything, mock.Anything).Return([]*entity.ProductLot{}, nil)
+			productStockRepo.On("GetProductLotsForUpdate", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.ProductLot{}, nil)
+			productStockRepo.On("UpdateProductLots", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			productStockRepo.On("GetProductStocksByProductIDs", mock.Anything, mock.Anything).Return([]*entity.ProductStock{}, nil)
+
+			uc := usecase.NewProductUsecase(productRepo, &testmock.PostgresTransactionRepositoryInterface{}, priceScheduleRepo, taxClassRepo, discountRuleRepo, &testmock.LocationRepositoryInterface{}, productStockRepo, &testmock.StockMovementRepositoryInterface{}, &testmock.LowStockRepositoryInterface{}, types.AllocationStrategyPriorityType)
+			res, err := uc.GetProducts(tc.ctx, tc.payload)
 			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, tc.wantNextCursor, res.NextCursor)
+				assert.Equal(t, tc.wantPrevCursor, res.PrevCursor)
+			}
 		})
 	}
 }
 
 func TestUpdateProduct(t *testing.T) {
+	taxClassID := 1
+	costPrice := 700
+	currentCostPrice := 500
+	averageCostPrice := 600
+
 	testcases := []struct {
-		name           string
-		ctx            context.Context
-		productID      int
-		payload        *entity.ProductPayload
-		rGetProductRes *entity.Product
-		rGetProductErr error
-		rProductErr    error
-		wantErr        bool
+		name              string
+		ctx               context.Context
+		productID         int
+		payload           *entity.ProductPayload
+		rGetProductRes    *entity.Product
+		rGetProductErr    error
+		rLockedProductRes *entity.Product
+		rLockProductErr   error
+		rLocationRes      *entity.Location
+		rStocksRes        []*entity.ProductStock
+		rStocksErr        error
+		rUpsertStocksErr  error
+		rReplaceStocksErr error
+		rProductErr       error
+		rStockMovementErr error
+		rCommitConflicts  int
+		wantCostPrice     *int
+		wantVersion       int
+		wantMovement      *entity.StockMovement
+		wantErr           bool
 	}{
 		{
 			name:    "deadline context",
@@ -311,6 +1587,92 @@ func TestUpdateProduct(t *testing.T) {
 			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType},
 			wantErr:        true,
 		},
+		{
+			name:           "stale version",
+			ctx:            context.Background(),
+			productID:      123,
+			payload:        &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Versions: []int{1}},
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType, Version: 2},
+			wantErr:        true,
+		},
+		{
+			name:           "product is changed since it was read",
+			ctx:            context.Background(),
+			productID:      123,
+			payload:        &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Versions: []int{1}},
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType, Version: 1},
+			rProductErr:    response.ErrStaleVersion,
+			wantErr:        true,
+		},
+		{
+			name:              "product is changed before it is locked",
+			ctx:               context.Background(),
+			productID:         123,
+			payload:           &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Versions: []int{1}},
+			rGetProductRes:    &entity.Product{Tenant: types.TenantLoremType, Version: 1},
+			rLockedProductRes: &entity.Product{Tenant: types.TenantLoremType, Version: 2},
+			wantErr:           true,
+		},
+		{
+			name:    "cost price without finance scope",
+			ctx:     context.Background(),
+			payload: &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, CostPrice: &costPrice},
+			wantErr: true,
+		},
+		{
+			name:           "invalid tax class",
+			ctx:            context.Background(),
+			productID:      123,
+			payload:        &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, TaxClassID: &taxClassID},
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType},
+			wantErr:        true,
+		},
+		{
+			name:           "location of another tenant",
+			ctx:            context.Background(),
+			productID:      123,
+			payload:        &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Stocks: []entity.ProductStockPayload{{LocationID: 2, Qty: 5}}},
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType},
+			rLocationRes:   &entity.Location{ID: 2, Tenant: types.TenantIpsumType},
+			wantErr:        true,
+		},
+		{
+			name:            "failed to lock product",
+			ctx:             context.Background(),
+			productID:       123,
+			payload:         &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType},
+			rGetProductRes:  &entity.Product{Tenant: types.TenantLoremType},
+			rLockProductErr: errors.New("error lock product"),
+			wantErr:         true,
+		},
+		{
+			name:              "failed to replace product stocks",
+			ctx:               context.Background(),
+			productID:         123,
+			payload:           &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Stocks: []entity.ProductStockPayload{{LocationID: 2, Qty: 5}}},
+			rGetProductRes:    &entity.Product{Tenant: types.TenantLoremType},
+			rLocationRes:      &entity.Location{ID: 2, Tenant: types.TenantLoremType},
+			rReplaceStocksErr: errors.New("error replace product stocks"),
+			wantErr:           true,
+		},
+		{
+			name:           "failed to get product stocks",
+			ctx:            context.Background(),
+			productID:      123,
+			payload:        &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Qty: 5},
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType},
+			rStocksErr:     errors.New("error get product stocks"),
+			wantErr:        true,
+		},
+		{
+			name:             "failed to upsert product stocks",
+			ctx:              context.Background(),
+			productID:        123,
+			payload:          &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Qty: 5},
+			rGetProductRes:   &entity.Product{Tenant: types.TenantLoremType},
+			rUpsertStocksErr: errors.New("error upsert product stocks"),
+			wantErr:          true,
+		},
 		{
 			name:           "failed to update product",
 			ctx:            context.Background(),
@@ -320,6 +1682,16 @@ func TestUpdateProduct(t *testing.T) {
 			rProductErr:    errors.New("error update product"),
 			wantErr:        true,
 		},
+		{
+			name:              "failed to create stock movement",
+			ctx:               context.Background(),
+			productID:         123,
+			payload:           &entity.ProductPayload{Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Qty: 5},
+			rGetProductRes:    &entity.Product{Tenant: types.TenantLoremType, Qty: 10},
+			rStocksRes:        []*entity.ProductStock{{LocationID: 1, Qty: 10}},
+			rStockMovementErr: errors.New("error create stock movement"),
+			wantErr:           true,
+		},
 		{
 			name:           "success",
 			ctx:            context.Background(),
@@ -328,17 +1700,405 @@ func TestUpdateProduct(t *testing.T) {
 			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType},
 			wantErr:        false,
 		},
+		{
+			name:           "success with stocks",
+			ctx:            context.Background(),
+			productID:      123,
+			payload:        &entity.ProductPayload{Title: "New Product", Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Stocks: []entity.ProductStockPayload{{LocationID: 2, Qty: 5}}},
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType, Qty: 10},
+			rLocationRes:   &entity.Location{ID: 2, Tenant: types.TenantLoremType},
+			wantErr:        false,
+		},
+		{
+			name:           "success reducing qty",
+			ctx:            context.Background(),
+			productID:      123,
+			payload:        &entity.ProductPayload{Title: "New Product", Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Qty: 5},
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType, Qty: 10},
+			rStocksRes:     []*entity.ProductStock{{LocationID: 1, LocationPriority: 1, Qty: 4}, {LocationID: 2, Qty: 6}},
+			wantMovement:   &entity.StockMovement{Delta: -5, ResultingQty: 5, Reason: types.StockMovementReasonAdjustmentType},
+			wantErr:        false,
+		},
+		{
+			name:              "success on the latest version without versions",
+			ctx:               context.Background(),
+			productID:         123,
+			payload:           &entity.ProductPayload{Title: "New Product", Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType},
+			rGetProductRes:    &entity.Product{Tenant: types.TenantLoremType, Version: 1},
+			rLockedProductRes: &entity.Product{Tenant: types.TenantLoremType, Version: 2},
+			wantVersion:       2,
+			wantErr:           false,
+		},
+		{
+			name:              "success on one of the versions",
+			ctx:               context.Background(),
+			productID:         123,
+			payload:           &entity.ProductPayload{Title: "New Product", Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Versions: []int{1, 2}},
+			rGetProductRes:    &entity.Product{Tenant: types.TenantLoremType, Version: 1},
+			rLockedProductRes: &entity.Product{Tenant: types.TenantLoremType, Version: 2},
+			wantVersion:       2,
+			wantErr:           false,
+		},
+		{
+			name:              "success reducing qty sold since the product was read",
+			ctx:               context.Background(),
+			productID:         123,
+			payload:           &entity.ProductPayload{Title: "New Product", Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Qty: 5},
+			rGetProductRes:    &entity.Product{Tenant: types.TenantLoremType, Qty: 10},
+			rLockedProductRes: &entity.Product{Tenant: types.TenantLoremType, Qty: 8},
+			rStocksRes:        []*entity.ProductStock{{LocationID: 1, Qty: 8}},
+			wantMovement:      &entity.StockMovement{Delta: -3, ResultingQty: 5, Reason: types.StockMovementReasonAdjustmentType},
+			wantErr:           false,
+		},
+		{
+			name:           "success with stock reason",
+			ctx:            context.Background(),
+			productID:      123,
+			payload:        &entity.ProductPayload{Title: "New Product", Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Qty: 12, StockReason: types.StockMovementReasonReturnType, ReferenceID: "RMA-1"},
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType, Qty: 10},
+			rStocksRes:     []*entity.ProductStock{{LocationID: 1, Qty: 10}},
+			wantMovement:   &entity.StockMovement{Delta: 2, ResultingQty: 12, Reason: types.StockMovementReasonReturnType, ReferenceID: "RMA-1"},
+			wantErr:        false,
+		},
+		{
+			name:           "success with weighted average cost",
+			ctx:            context.Background(),
+			productID:      123,
+			payload:        &entity.ProductPayload{Title: "New Product", Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Qty: 20, CostPrice: &costPrice, WeightedAverageCost: true, FinanceScope: true},
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType, Qty: 10, CostPrice: &currentCostPrice},
+			rStocksRes:     []*entity.ProductStock{{LocationID: 1, Qty: 10}},
+			wantCostPrice:  &averageCostPrice,
+			wantErr:        false,
+		},
+		{
+			name:              "success after the transaction conflicts with a concurrent change",
+			ctx:               context.Background(),
+			productID:         123,
+			payload:           &entity.ProductPayload{Title: "New Product", Category: types.CategoryBookType, Condition: types.ConditionNewType, Tenant: types.TenantLoremType, Qty: 20, CostPrice: &costPrice, WeightedAverageCost: true, FinanceScope: true},
+			rGetProductRes:    &entity.Product{Tenant: types.TenantLoremType, Qty: 10, CostPrice: &currentCostPrice},
+			rLockedProductRes: &entity.Product{Tenant: types.TenantLoremType, Qty: 10},
+			rStocksRes:        []*entity.ProductStock{{LocationID: 1, Qty: 10}},
+			rCommitConflicts:  2,
+			wantCostPrice:     &averageCostPrice,
+			wantMovement:      &entity.StockMovement{Delta: 10, ResultingQty: 20, Reason: types.StockMovementReasonAdjustmentType},
+			wantErr:           false,
+		},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
 			productRepo := &testmock.ProductRepositoryInterface{}
 			productRepo.On("GetProductByID", mock.Anything, mock.Anything).Return(tc.rGetProductRes, tc.rGetProductErr)
-			productRepo.On("UpdateProduct", mock.Anything, mock.Anything, mock.Anything).Return(tc.rProductErr)
+			lockedProduct := tc.rLockedProductRes
+			if lockedProduct == nil {
+				lockedProduct = tc.rGetProductRes
+			}
+			productRepo.On("GetProductsForUpdate", mock.Anything, mock.Anything, mock.Anything, []int{0}, []string(nil)).Return(lockedProducts(lockedProduct), tc.rLockProductErr)
+			productRepo.On("UpdateProduct", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.rProductErr)
+
+			priceScheduleRepo := &testmock.PriceScheduleRepositoryInterface{}
+			priceScheduleRepo.On("GetDuePriceSchedulesByProductIDs", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+
+			taxClassRepo := &testmock.TaxClassRepositoryInterface{}
+			taxClassRepo.On("GetTaxClassByID", mock.Anything, mock.Anything).Return(nil, response.ErrNotFound)
+			taxClassRepo.On("GetCategoryTaxClasses", mock.Anything, mock.Anything).Return([]*entity.CategoryTaxClass{}, nil)
+
+			discountRuleRepo := &testmock.DiscountRuleRepositoryInterface{}
+			discountRuleRepo.On("GetActiveDiscountRules", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.DiscountRule{}, nil)
+
+			dbTransactionRepo := &testmock.PostgresTransactionRepositoryInterface{}
+			dbTransactionRepo.On("StartTransactionQuery", mock.Anything).Return(&sqlx.Tx{}, nil)
+			if tc.rCommitConflicts > 0 {
+				dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(&pq.Error{Code: pq.ErrorCode(config.SerializationFailureCode)}).Times(tc.rCommitConflicts)
+			}
+			dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+			dbTransactionRepo.On("RollbackTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+
+			locationRepo := &testmock.LocationRepositoryInterface{}
+			locationRepo.On("GetLocationByID", mock.Anything, mock.Anything).Return(tc.rLocationRes, nil)
+			locationRepo.On("GetOrCreateDefaultLocation", mock.Anything, mock.Anything, mock.Anything).Return(&entity.Location{ID: 1, Tenant: types.TenantLoremType, IsDefault: true}, nil)
+
+			productStockRepo := &testmock.ProductStockRepositoryInterface{}
+			productStockRepo.On("GetProductLotsByProductIDs", mock.Anything, mock.Anything).Return([]*entity.ProductLot{}, nil)
+			productStockRepo.On("GetProductLotsForUpdate", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.ProductLot{}, nil)
+			productStockRepo.On("UpdateProductLots", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			productStockRepo.On("GetProductStocksForUpdate", mock.Anything, mock.Anything, mock.Anything).Return(tc.rStocksRes, tc.rStocksErr)
+			productStockRepo.On("UpsertProductStocks", mock.Anything, mock.Anything, mock.Anything).Return(tc.rUpsertStocksErr)
+			productStockRepo.On("ReplaceProductStocks", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.rReplaceStocksErr)
+			productStockRepo.On("GetProductStocksByProductIDs", mock.Anything, mock.Anything).Return([]*entity.ProductStock{}, nil)
 
-			uc := usecase.NewProductUsecase(productRepo, &testmock.PostgresTransactionRepositoryInterface{})
-			_, err := uc.UpdateProduct(tc.ctx, tc.productID, tc.payload)
+			stockMovementRepo := &testmock.StockMovementRepositoryInterface{}
+			stockMovementRepo.On("CreateStockMovements", mock.Anything, mock.Anything, mock.Anything).Return(tc.rStockMovementErr)
+
+			lowStockRepo := &testmock.LowStockRepositoryInterface{}
+			lowStockRepo.On("GetLowStockThreshold", mock.Anything, mock.Anything).Return(nil, response.ErrNotFound)
+
+			uc := usecase.NewProductUsecase(productRepo, dbTransactionRepo, priceScheduleRepo, taxClassRepo, discountRuleRepo, locationRepo, productStockRepo, stockMovementRepo, lowStockRepo, types.AllocationStrategyPriorityType)
+			res, err := uc.UpdateProduct(tc.ctx, tc.productID, tc.payload)
 			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, tc.wantCostPrice, res.CostPrice)
+				assert.Equal(t, tc.wantVersion, res.Version)
+				dbTransactionRepo.AssertNumberOfCalls(t, "CommitTransactionQuery", tc.rCommitConflicts+1)
+			}
+			if tc.wantMovement != nil {
+				stockMovementRepo.AssertCalled(t, "CreateStockMovements", mock.Anything, mock.Anything, mock.MatchedBy(func(movements []*entity.StockMovement) bool {
+					movement := movements[0]
+					return movement.Delta == tc.wantMovement.Delta && movement.ResultingQty == tc.wantMovement.ResultingQty &&
+						movement.Reason == tc.wantMovement.Reason && movement.ReferenceID == tc.wantMovement.ReferenceID
+				}))
+			}
+		})
+	}
+}
+
+func TestPatchProduct(t *testing.T) {
+	threshold := 5
+	taxClassID := 1
+	costPrice := 500
+	testcases := []struct {
+		name           string
+		ctx            context.Context
+		patch          string
+		versions       []int
+		financeScope   bool
+		rGetProductRes *entity.Product
+		rGetProductErr error
+		rLockedProduct *entity.Product
+		rStocksRes     []*entity.ProductStock
+		rProductErr    error
+		wantColumns    []string
+		wantProduct    *entity.Product
+		wantMovement   *entity.StockMovement
+		wantCustomErr  *response.CustomError
+		wantErr        bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			patch:   `{}`,
+			wantErr: true,
+		},
+		{
+			name:           "product is not found",
+			ctx:            context.Background(),
+			patch:          `{"price": 1500}`,
+			rGetProductErr: response.ErrNotFound,
+			wantCustomErr:  &response.ErrNotFound,
+			wantErr:        true,
+		},
+		{
+			name:           "failed to get product",
+			ctx:            context.Background(),
+			patch:          `{"price": 1500}`,
+			rGetProductErr: errors.New("error get product"),
+			wantErr:        true,
+		},
+		{
+			name:           "forbidden",
+			ctx:            context.Background(),
+			patch:          `{"price": 1500}`,
+			rGetProductRes: &entity.Product{Tenant: types.TenantIpsumType},
+			wantCustomErr:  &response.ErrForbidden,
+			wantErr:        true,
+		},
+		{
+			name:           "stale version",
+			ctx:            context.Background(),
+			patch:          `{"price": 1500}`,
+			versions:       []int{1},
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType, Version: 2},
+			wantCustomErr:  &response.ErrStaleVersion,
+			wantErr:        true,
+		},
+		{
+			name:           "product is changed since it was read",
+			ctx:            context.Background(),
+			patch:          `{"price": 1500}`,
+			versions:       []int{1},
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType, Version: 1},
+			rProductErr:    response.ErrStaleVersion,
+			wantCustomErr:  &response.ErrStaleVersion,
+			wantErr:        true,
+		},
+		{
+			name:           "field which can not be patched",
+			ctx:            context.Background(),
+			patch:          `{"sku": "SKU-2"}`,
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType},
+			wantCustomErr:  &response.CustomError{Message: response.ErrInvalidPatch.Message, Field: "sku", Code: response.ErrorCodeInvalidPatch, HTTPCode: response.ErrInvalidPatch.HTTPCode},
+			wantErr:        true,
+		},
+		{
+			name:           "removing a required field",
+			ctx:            context.Background(),
+			patch:          `{"category": null}`,
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType},
+			wantCustomErr:  &response.CustomError{Message: response.ErrInvalidPatch.Message, Field: "category", Code: response.ErrorCodeInvalidPatch, HTTPCode: response.ErrInvalidPatch.HTTPCode},
+			wantErr:        true,
+		},
+		{
+			name:           "field of another type",
+			ctx:            context.Background(),
+			patch:          `{"price": "cheap"}`,
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType},
+			wantCustomErr:  &response.CustomError{Message: response.ErrInvalidPatch.Message, Field: "price", Code: response.ErrorCodeInvalidPatch, HTTPCode: response.ErrInvalidPatch.HTTPCode},
+			wantErr:        true,
+		},
+		{
+			name:           "invalid changed field",
+			ctx:            context.Background(),
+			patch:          `{"qty": -1}`,
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType},
+			wantCustomErr:  &response.ErrInvalidQty,
+			wantErr:        true,
+		},
+		{
+			name:           "cost price without finance scope",
+			ctx:            context.Background(),
+			patch:          `{"cost_price": null}`,
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType, CostPrice: &costPrice},
+			wantCustomErr:  &response.ErrForbidden,
+			wantErr:        true,
+		},
+		{
+			name:           "failed to update product",
+			ctx:            context.Background(),
+			patch:          `{"price": 1500}`,
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType},
+			rProductErr:    errors.New("error update product"),
+			wantErr:        true,
+		},
+		{
+			name:           "success without validating the fields which are not changed",
+			ctx:            context.Background(),
+			patch:          `{"price": 1500}`,
+			rGetProductRes: &entity.Product{ID: 123, Title: "Product", Tenant: types.TenantLoremType, Qty: 10, Price: 1000, BackorderPolicy: types.BackorderPolicyDenyType},
+			wantColumns:    []string{"price"},
+			wantProduct:    &entity.Product{ID: 123, Title: "Product", Tenant: types.TenantLoremType, Qty: 10, AvailableQty: 10, Price: 1500, BackorderPolicy: types.BackorderPolicyDenyType},
+			wantErr:        false,
+		},
+		{
+			name:           "product is changed before it is locked",
+			ctx:            context.Background(),
+			patch:          `{"price": 1500}`,
+			versions:       []int{1},
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType, Version: 1},
+			rLockedProduct: &entity.Product{Tenant: types.TenantLoremType, Version: 2},
+			wantCustomErr:  &response.ErrStaleVersion,
+			wantErr:        true,
+		},
+		{
+			name:           "success on the latest version without versions",
+			ctx:            context.Background(),
+			patch:          `{"price": 1500}`,
+			rGetProductRes: &entity.Product{ID: 123, Tenant: types.TenantLoremType, Price: 1000, Version: 1, BackorderPolicy: types.BackorderPolicyDenyType},
+			rLockedProduct: &entity.Product{ID: 123, Tenant: types.TenantLoremType, Price: 1000, Version: 2, BackorderPolicy: types.BackorderPolicyDenyType},
+			wantColumns:    []string{"price"},
+			wantProduct:    &entity.Product{ID: 123, Tenant: types.TenantLoremType, Price: 1500, Version: 2, BackorderPolicy: types.BackorderPolicyDenyType},
+			wantErr:        false,
+		},
+		{
+			name:           "success keeping the qty sold since the product was read",
+			ctx:            context.Background(),
+			patch:          `{"price": 1500}`,
+			rGetProductRes: &entity.Product{ID: 123, Tenant: types.TenantLoremType, Qty: 10, Price: 1000, BackorderPolicy: types.BackorderPolicyDenyType},
+			rLockedProduct: &entity.Product{ID: 123, Tenant: types.TenantLoremType, Qty: 8, Price: 1000, BackorderPolicy: types.BackorderPolicyDenyType},
+			wantColumns:    []string{"price"},
+			wantProduct:    &entity.Product{ID: 123, Tenant: types.TenantLoremType, Qty: 8, AvailableQty: 8, Price: 1500, BackorderPolicy: types.BackorderPolicyDenyType},
+			wantErr:        false,
+		},
+		{
+			name:           "success removing nullable fields",
+			ctx:            context.Background(),
+			patch:          `{"low_stock_threshold": null, "tax_class_id": null, "cost_price": null, "title": "New Product"}`,
+			financeScope:   true,
+			rGetProductRes: &entity.Product{ID: 123, Title: "Product", Category: types.CategoryBookType, Tenant: types.TenantLoremType, LowStockThreshold: &threshold, TaxClassID: &taxClassID, CostPrice: &costPrice, BackorderPolicy: types.BackorderPolicyDenyType},
+			wantColumns:    []string{"cost_price", "low_stock_threshold", "tax_class_id", "title"},
+			wantProduct:    &entity.Product{ID: 123, Title: "New Product", Category: types.CategoryBookType, Tenant: types.TenantLoremType, BackorderPolicy: types.BackorderPolicyDenyType},
+			wantErr:        false,
+		},
+		{
+			name:           "success changing qty",
+			ctx:            context.Background(),
+			patch:          `{"qty": 5, "stock_reason": "damage"}`,
+			rGetProductRes: &entity.Product{ID: 123, Tenant: types.TenantLoremType, Qty: 10, BackorderPolicy: types.BackorderPolicyDenyType},
+			rStocksRes:     []*entity.ProductStock{{LocationID: 1, Qty: 10}},
+			wantColumns:    []string{"qty"},
+			wantProduct:    &entity.Product{ID: 123, Tenant: types.TenantLoremType, Qty: 5, AvailableQty: 5, BackorderPolicy: types.BackorderPolicyDenyType},
+			wantMovement:   &entity.StockMovement{Delta: -5, ResultingQty: 5, Reason: types.StockMovementReasonDamageType},
+			wantErr:        false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("GetProductByID", mock.Anything, mock.Anything).Return(tc.rGetProductRes, tc.rGetProductErr)
+			lockedProduct := tc.rLockedProduct
+			if lockedProduct == nil {
+				lockedProduct = tc.rGetProductRes
+			}
+			productRepo.On("GetProductsForUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, []string(nil)).Return(lockedProducts(lockedProduct), nil)
+			productRepo.On("UpdateProduct", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.rProductErr)
+
+			priceScheduleRepo := &testmock.PriceScheduleRepositoryInterface{}
+			priceScheduleRepo.On("GetDuePriceSchedulesByProductIDs", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+
+			taxClassRepo := &testmock.TaxClassRepositoryInterface{}
+			taxClassRepo.On("GetCategoryTaxClasses", mock.Anything, mock.Anything).Return([]*entity.CategoryTaxClass{}, nil)
+
+			discountRuleRepo := &testmock.DiscountRuleRepositoryInterface{}
+			discountRuleRepo.On("GetActiveDiscountRules", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.DiscountRule{}, nil)
+
+			dbTransactionRepo := &testmock.PostgresTransactionRepositoryInterface{}
+			dbTransactionRepo.On("StartTransactionQuery", mock.Anything).Return(&sqlx.Tx{}, nil)
+			dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+			dbTransactionRepo.On("RollbackTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+
+			productStockRepo := &testmock.ProductStockRepositoryInterface{}
+			productStockRepo.On("GetProductLotsByProductIDs", mock.Anything, mock.Anything).Return([]*entity.ProductLot{}, nil)
+			productStockRepo.On("GetProductLotsForUpdate", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.ProductLot{}, nil)
+			productStockRepo.On("UpdateProductLots", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			productStockRepo.On("GetProductStocksForUpdate", mock.Anything, mock.Anything, mock.Anything).Return(tc.rStocksRes, nil)
+			productStockRepo.On("UpsertProductStocks", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			productStockRepo.On("GetProductStocksByProductIDs", mock.Anything, mock.Anything).Return([]*entity.ProductStock{}, nil)
+
+			stockMovementRepo := &testmock.StockMovementRepositoryInterface{}
+			stockMovementRepo.On("CreateStockMovements", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			lowStockRepo := &testmock.LowStockRepositoryInterface{}
+			lowStockRepo.On("GetLowStockThreshold", mock.Anything, mock.Anything).Return(nil, response.ErrNotFound)
+
+			payload := &entity.ProductPatchPayload{Tenant: types.TenantLoremType, FinanceScope: tc.financeScope, Versions: tc.versions}
+			assert.NoError(t, json.Unmarshal([]byte(tc.patch), &payload.Fields))
+
+			uc := usecase.NewProductUsecase(productRepo, dbTransactionRepo, priceScheduleRepo, taxClassRepo, discountRuleRepo, &testmock.LocationRepositoryInterface{}, productStockRepo, stockMovementRepo, lowStockRepo, types.AllocationStrategyPriorityType)
+			res, err := uc.PatchProduct(tc.ctx, 123, payload)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, *tc.wantCustomErr, err)
+			}
+			if tc.wantColumns != nil {
+				productRepo.AssertCalled(t, "UpdateProduct", mock.Anything, mock.Anything, mock.Anything, tc.wantColumns)
+			}
+			if tc.wantProduct != nil {
+				res.UpdatedAt = time.Time{}
+				res.Promotions = nil
+				res.Tax = nil
+				res.Stocks = nil
+				res.Lots = nil
+				assert.Equal(t, tc.wantProduct, res)
+			}
+			if tc.wantMovement != nil {
+				stockMovementRepo.AssertCalled(t, "CreateStockMovements", mock.Anything, mock.Anything, mock.MatchedBy(func(movements []*entity.StockMovement) bool {
+					movement := movements[0]
+					return movement.Delta == tc.wantMovement.Delta && movement.ResultingQty == tc.wantMovement.ResultingQty && movement.Reason == tc.wantMovement.Reason
+				}))
+			} else {
+				stockMovementRepo.AssertNotCalled(t, "CreateStockMovements", mock.Anything, mock.Anything, mock.Anything)
+				productStockRepo.AssertNotCalled(t, "GetProductStocksForUpdate", mock.Anything, mock.Anything, mock.Anything)
+			}
 		})
 	}
 }
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	repo "github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// ReportUsecaseInterface define contract for report related functions to usecase
+type ReportUsecaseInterface interface {
+	GetProductMargins(ctx context.Context, payload *entity.GetProductPayload) ([]*entity.ProductMargin, int, error)
+	GetInventoryValuations(ctx context.Context, payload *entity.GetInventoryValuationPayload) ([]*entity.InventoryValuation, error)
+	GetExpiringLots(ctx context.Context, payload *entity.GetExpiringLotPayload) ([]*entity.ExpiringLot, error)
+}
+
+type ReportUsecase struct {
+	productRepo      repo.ProductRepositoryInterface
+	productStockRepo repo.ProductStockRepositoryInterface
+}
+
+func NewReportUsecase(rProduct repo.ProductRepositoryInterface, rProductStock repo.ProductStockRepositoryInterface) *ReportUsecase {
+	return &ReportUsecase{
+		productRepo:      rProduct,
+		productStockRepo: rProductStock,
+	}
+}
+
+func (uc *ReportUsecase) GetProductMargins(ctx context.Context, payload *entity.GetProductPayload) ([]*entity.ProductMargin, int, error) {
+	functionName := "ReportUsecase.GetProductMargins"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, 0, errors.Wrap(err, functionName)
+	}
+
+	if !payload.FinanceScope {
+		return nil, 0, response.ErrForbidden
+	}
+
+	if err := payload.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	// The margins are computed from the prices and the cost of the full products
+	payload.Fieldset = nil
+
+	products, err := uc.productRepo.GetProducts(ctx, payload)
+	if err != nil {
+		return nil, 0, errors.Wrap(fmt.Errorf("uc.productRepo.GetProducts: %w", err), functionName)
+	}
+
+	count, err := uc.productRepo.GetProductsCount(ctx, payload)
+	if err != nil {
+		return nil, 0, errors.Wrap(fmt.Errorf("uc.productRepo.GetProductsCount: %w", err), functionName)
+	}
+
+	productMargins := make([]*entity.ProductMargin, 0, len(products))
+	for _, product := range products {
+		productMargins = append(productMargins, entity.NewProductMargin(product))
+	}
+
+	return productMargins, count, nil
+}
+
+func (uc *ReportUsecase) GetInventoryValuations(ctx context.Context, payload *entity.GetInventoryValuationPayload) ([]*entity.InventoryValuation, error) {
+	functionName := "ReportUsecase.GetInventoryValuations"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if !payload.FinanceScope {
+		return nil, response.ErrForbidden
+	}
+
+	valuations, err := uc.productRepo.GetInventoryValuations(ctx, payload.Tenant)
+	if err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.productRepo.GetInventoryValuations: %w", err), functionName)
+	}
+
+	return valuations, nil
+}
+
+// GetExpiringLots return lots in stock which expire within the given days, the expired lots are included
+// since their qty is still on hand but not available
+func (uc *ReportUsecase) GetExpiringLots(ctx context.Context, payload *entity.GetExpiringLotPayload) ([]*entity.ExpiringLot, error) {
+	functionName := "ReportUsecase.GetExpiringLots"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	now := time.Now()
+	lots, err := uc.productStockRepo.GetExpiringProductLots(ctx, payload.Tenant, payload.ExpiresBefore(now))
+	if err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.productStockRepo.GetExpiringProductLots: %w", err), functionName)
+	}
+
+	for _, lot := range lots {
+		lot.Expired = !lot.ExpiryDate.After(now)
+	}
+
+	return lots, nil
+}
@@ -0,0 +1,184 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetProductMargins(t *testing.T) {
+	costPrice := 700
+
+	testcases := []struct {
+		name                 string
+		ctx                  context.Context
+		payload              *entity.GetProductPayload
+		rGetProductsRes      []*entity.Product
+		rGetProductsErr      error
+		rGetProductsCountErr error
+		wantErr              bool
+		wantCustomErr        error
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "without finance scope",
+			ctx:           context.Background(),
+			payload:       &entity.GetProductPayload{Tenant: types.TenantLoremType},
+			wantErr:       true,
+			wantCustomErr: response.ErrForbidden,
+		},
+		{
+			name:            "failed to get products",
+			ctx:             context.Background(),
+			payload:         &entity.GetProductPayload{Tenant: types.TenantLoremType, FinanceScope: true},
+			rGetProductsErr: errors.New("error get products"),
+			wantErr:         true,
+		},
+		{
+			name:                 "failed to get products count",
+			ctx:                  context.Background(),
+			payload:              &entity.GetProductPayload{Tenant: types.TenantLoremType, FinanceScope: true},
+			rGetProductsCountErr: errors.New("error get products count"),
+			wantErr:              true,
+		},
+		{
+			name:            "success",
+			ctx:             context.Background(),
+			payload:         &entity.GetProductPayload{Tenant: types.TenantLoremType, FinanceScope: true},
+			rGetProductsRes: []*entity.Product{{ID: 123, Price: 1000, CostPrice: &costPrice}},
+			wantErr:         false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("GetProducts", mock.Anything, mock.Anything).Return(tc.rGetProductsRes, tc.rGetProductsErr)
+			productRepo.On("GetProductsCount", mock.Anything, mock.Anything).Return(len(tc.rGetProductsRes), tc.rGetProductsCountErr)
+
+			uc := usecase.NewReportUsecase(productRepo, &testmock.ProductStockRepositoryInterface{})
+			res, _, err := uc.GetProductMargins(tc.ctx, tc.payload)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, tc.wantCustomErr, err)
+			}
+			if !tc.wantErr {
+				assert.Equal(t, len(tc.rGetProductsRes), len(res))
+			}
+		})
+	}
+}
+
+func TestGetInventoryValuations(t *testing.T) {
+	testcases := []struct {
+		name          string
+		ctx           context.Context
+		payload       *entity.GetInventoryValuationPayload
+		rErr          error
+		wantErr       bool
+		wantCustomErr error
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "without finance scope",
+			ctx:           context.Background(),
+			payload:       &entity.GetInventoryValuationPayload{Tenant: types.TenantLoremType},
+			wantErr:       true,
+			wantCustomErr: response.ErrForbidden,
+		},
+		{
+			name:    "failed to get inventory valuations",
+			ctx:     context.Background(),
+			payload: &entity.GetInventoryValuationPayload{Tenant: types.TenantLoremType, FinanceScope: true},
+			rErr:    errors.New("error get inventory valuations"),
+			wantErr: true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			payload: &entity.GetInventoryValuationPayload{Tenant: types.TenantLoremType, FinanceScope: true},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("GetInventoryValuations", mock.Anything, mock.Anything).Return([]*entity.InventoryValuation{}, tc.rErr)
+
+			uc := usecase.NewReportUsecase(productRepo, &testmock.ProductStockRepositoryInterface{})
+			_, err := uc.GetInventoryValuations(tc.ctx, tc.payload)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, tc.wantCustomErr, err)
+			}
+		})
+	}
+}
+
+func TestGetExpiringLots(t *testing.T) {
+	now := time.Now()
+
+	testcases := []struct {
+		name        string
+		ctx         context.Context
+		rRes        []*entity.ExpiringLot
+		rErr        error
+		wantExpired []bool
+		wantErr     bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:    "failed to get expiring lots",
+			ctx:     context.Background(),
+			rErr:    errors.New("error get expiring lots"),
+			wantErr: true,
+		},
+		{
+			name: "success",
+			ctx:  context.Background(),
+			rRes: []*entity.ExpiringLot{
+				{ProductID: 123, LotNumber: "A1", ExpiryDate: now.AddDate(0, 0, -1), Qty: 2},
+				{ProductID: 123, LotNumber: "A2", ExpiryDate: now.AddDate(0, 0, 3), Qty: 5},
+			},
+			wantExpired: []bool{true, false},
+			wantErr:     false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			productStockRepo := &testmock.ProductStockRepositoryInterface{}
+			productStockRepo.On("GetExpiringProductLots", mock.Anything, mock.Anything, mock.Anything).Return(tc.rRes, tc.rErr)
+
+			uc := usecase.NewReportUsecase(&testmock.ProductRepositoryInterface{}, productStockRepo)
+			res, err := uc.GetExpiringLots(tc.ctx, &entity.GetExpiringLotPayload{Tenant: types.TenantLoremType})
+			assert.Equal(t, tc.wantErr, err != nil)
+			for i, lot := range res {
+				assert.Equal(t, tc.wantExpired[i], lot.Expired)
+			}
+		})
+	}
+}
@@ -0,0 +1,426 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	repo "github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+const (
+	// expiredReservationsBatchSize is the max number of reservations released on each sweeper run
+	expiredReservationsBatchSize = 100
+)
+
+// ReservationUsecaseInterface define contract for reservation related functions to usecase
+type ReservationUsecaseInterface interface {
+	CreateReservation(ctx context.Context, payload *entity.ReservationPayload) (*entity.Reservation, error)
+	GetReservationByID(ctx context.Context, tenant types.TenantType, reservationID int) (*entity.Reservation, error)
+	ConfirmReservation(ctx context.Context, tenant types.TenantType, reservationID int, actor string) (*entity.Reservation, error)
+	ReleaseReservation(ctx context.Context, tenant types.TenantType, reservationID int) (*entity.Reservation, error)
+	ReleaseExpiredReservations(ctx context.Context) error
+}
+
+type ReservationUsecase struct {
+	repo               repo.ReservationRepositoryInterface
+	productRepo        repo.ProductRepositoryInterface
+	productStockRepo   repo.ProductStockRepositoryInterface
+	stockMovementRepo  repo.StockMovementRepositoryInterface
+	lowStockRepo       repo.LowStockRepositoryInterface
+	dbTransactionRepo  repo.PostgresTransactionRepositoryInterface
+	allocationStrategy types.AllocationStrategyType
+	defaultTTL         time.Duration
+}
+
+func NewReservationUsecase(
+	r repo.ReservationRepositoryInterface,
+	rProduct repo.ProductRepositoryInterface,
+	rProductStock repo.ProductStockRepositoryInterface,
+	rStockMovement repo.StockMovementRepositoryInterface,
+	rLowStock repo.LowStockRepositoryInterface,
+	rPgTrx repo.PostgresTransactionRepositoryInterface,
+	allocationStrategy types.AllocationStrategyType,
+	defaultTTL time.Duration,
+) *ReservationUsecase {
+	return &ReservationUsecase{
+		repo:               r,
+		productRepo:        rProduct,
+		productStockRepo:   rProductStock,
+		stockMovementRepo:  rStockMovement,
+		lowStockRepo:       rLowStock,
+		dbTransactionRepo:  rPgTrx,
+		allocationStrategy: allocationStrategy,
+		defaultTTL:         defaultTTL,
+	}
+}
+
+func (uc *ReservationUsecase) CreateReservation(ctx context.Context, payload *entity.ReservationPayload) (*entity.Reservation, error) {
+	functionName := "ReservationUsecase.CreateReservation"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	reservation := payload.ToEntity(time.Now(), uc.defaultTTL)
+	for _, item := range reservation.Items {
+		product, err := uc.productRepo.GetProductBySKU(ctx, item.SKU)
+		if err != nil {
+			if err == response.ErrNotFound {
+				return nil, err
+			}
+
+			return nil, errors.Wrap(fmt.Errorf("uc.productRepo.GetProductBySKU: %w", err), functionName)
+		}
+
+		if product.Tenant != reservation.Tenant {
+			return nil, response.ErrForbidden
+		}
+
+		item.ProductID = product.ID
+	}
+
+	if err := retryTransaction(ctx, func() error { return uc.holdReservation(ctx, reservation) }); err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return reservation, nil
+}
+
+// holdReservation hold qty of the reservation items and save the reservation in one transaction
+func (uc *ReservationUsecase) holdReservation(ctx context.Context, reservation *entity.Reservation) error {
+	// Begin transaction
+	tx, err := uc.dbTransactionRepo.StartTransactionQuery(ctx)
+	if err != nil {
+		return fmt.Errorf("uc.dbTransactionRepo.StartTransactionQuery: %w", err)
+	}
+
+	// Create flag and defer rollback when flag is true
+	rollbackProcess := true
+	defer func() {
+		if rollbackProcess {
+			uc.dbTransactionRepo.RollbackTransactionQuery(ctx, tx)
+		}
+	}()
+
+	for _, item := range reservation.ItemsByProductID() {
+		if err := uc.productRepo.ReserveProductQty(ctx, tx, item.ProductID, item.Qty); err != nil {
+			if customErr, ok := err.(response.CustomError); ok {
+				return customErr
+			}
+			return fmt.Errorf("uc.productRepo.ReserveProductQty: %w", err)
+		}
+	}
+
+	if err := uc.repo.CreateReservation(ctx, tx, reservation); err != nil {
+		return fmt.Errorf("uc.repo.CreateReservation: %w", err)
+	}
+
+	if err := uc.repo.CreateReservationItems(ctx, tx, reservation); err != nil {
+		return fmt.Errorf("uc.repo.CreateReservationItems: %w", err)
+	}
+
+	// Commit transaction
+	if err = uc.dbTransactionRepo.CommitTransactionQuery(ctx, tx); err != nil {
+		return fmt.Errorf("uc.dbTransactionRepo.CommitTransactionQuery: %w", err)
+	}
+	rollbackProcess = false
+
+	return nil
+}
+
+func (uc *ReservationUsecase) GetReservationByID(ctx context.Context, tenant types.TenantType, reservationID int) (*entity.Reservation, error) {
+	functionName := "ReservationUsecase.GetReservationByID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return uc.getReservation(ctx, tenant, reservationID)
+}
+
+// ConfirmReservation turn the qty held by an active reservation into a deduction of the stock,
+// the deduction is recorded as a sale referencing the reservation
+func (uc *ReservationUsecase) ConfirmReservation(ctx context.Context, tenant types.TenantType, reservationID int, actor string) (*entity.Reservation, error) {
+	functionName := "ReservationUsecase.ConfirmReservation"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	reservation, err := uc.getReservation(ctx, tenant, reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// An expired reservation can not be confirmed even before it is released by the sweeper
+	if !reservation.IsActive(time.Now()) {
+		return nil, response.ErrReservationNotActive
+	}
+
+	if err := retryTransaction(ctx, func() error { return uc.confirmReservation(ctx, reservation, actor) }); err != nil {
+		if err == response.ErrNotFound {
+			return nil, response.ErrReservationNotActive
+		}
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return reservation, nil
+}
+
+// confirmReservation move an active reservation to confirmed, reduce its held qty from the stock
+// and record it on the stock movements in one transaction
+func (uc *ReservationUsecase) confirmReservation(ctx context.Context, reservation *entity.Reservation, actor string) error {
+	// Begin transaction
+	tx, err := uc.dbTransactionRepo.StartTransactionQuery(ctx)
+	if err != nil {
+		return fmt.Errorf("uc.dbTransactionRepo.StartTransactionQuery: %w", err)
+	}
+
+	// Create flag and defer rollback when flag is true
+	rollbackProcess := true
+	defer func() {
+		if rollbackProcess {
+			uc.dbTransactionRepo.RollbackTransactionQuery(ctx, tx)
+		}
+	}()
+
+	reservation.Status = types.ReservationStatusConfirmedType
+	if err := uc.repo.UpdateReservationStatus(ctx, tx, reservation, types.ReservationStatusActiveType); err != nil {
+		if err == response.ErrNotFound {
+			return err
+		}
+
+		return fmt.Errorf("uc.repo.UpdateReservationStatus: %w", err)
+	}
+
+	items := reservation.ItemsByProductID()
+	productIDs := make([]int, 0, len(items))
+	for _, item := range items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+
+	// The products are read after they are locked so that the stock is reduced from their latest qty
+	products, err := uc.productRepo.GetProductsForUpdate(ctx, tx, reservation.Tenant, productIDs, nil)
+	if err != nil {
+		return fmt.Errorf("uc.productRepo.GetProductsForUpdate: %w", err)
+	}
+
+	productByID := make(map[int]*entity.Product, len(products))
+	for _, product := range products {
+		productByID[product.ID] = product
+	}
+
+	referenceID := fmt.Sprintf("reservation:%d", reservation.ID)
+	movements := make([]*entity.StockMovement, 0, len(items))
+	for _, item := range items {
+		product, ok := productByID[item.ProductID]
+		if !ok {
+			return fmt.Errorf("product %d of the reservation is not found", item.ProductID)
+		}
+
+		if err := uc.productRepo.ReleaseProductQty(ctx, tx, item.ProductID, item.Qty); err != nil {
+			return fmt.Errorf("uc.productRepo.ReleaseProductQty: %w", err)
+		}
+
+		if err := deductStocks(ctx, uc.productStockRepo, tx, product, item.Qty, nil, uc.allocationStrategy, false); err != nil {
+			return err
+		}
+
+		if err := deductSerials(ctx, uc.productStockRepo, tx, product, item.Qty, nil, types.SerialStatusSoldType, actor, referenceID); err != nil {
+			return err
+		}
+
+		if err := uc.productRepo.UpdateProductQty(ctx, tx, product); err != nil {
+			return fmt.Errorf("uc.productRepo.UpdateProductQty: %w", err)
+		}
+
+		if err := recordLowStock(ctx, uc.productRepo, uc.lowStockRepo, tx, product); err != nil {
+			return err
+		}
+
+		movements = append(movements, entity.NewStockMovement(product, -item.Qty, types.StockMovementReasonSaleType, actor, referenceID))
+	}
+
+	if err := uc.stockMovementRepo.CreateStockMovements(ctx, tx, movements); err != nil {
+		return fmt.Errorf("uc.stockMovementRepo.CreateStockMovements: %w", err)
+	}
+
+	// Commit transaction
+	if err = uc.dbTransactionRepo.CommitTransactionQuery(ctx, tx); err != nil {
+		return fmt.Errorf("uc.dbTransactionRepo.CommitTransactionQuery: %w", err)
+	}
+	rollbackProcess = false
+
+	return nil
+}
+
+func (uc *ReservationUsecase) ReleaseReservation(ctx context.Context, tenant types.TenantType, reservationID int) (*entity.Reservation, error) {
+	functionName := "ReservationUsecase.ReleaseReservation"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	reservation, err := uc.getReservation(ctx, tenant, reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reservation.Status != types.ReservationStatusActiveType {
+		return nil, response.ErrReservationNotActive
+	}
+
+	if err := retryTransaction(ctx, func() error { return uc.releaseReservation(ctx, reservation, types.ReservationStatusReleasedType) }); err != nil {
+		if err == response.ErrNotFound {
+			return nil, response.ErrReservationNotActive
+		}
+
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return reservation, nil
+}
+
+// ReleaseExpiredReservations release the active reservations which already expired,
+// the errors of the reservations which failed are returned together once the batch is done
+func (uc *ReservationUsecase) ReleaseExpiredReservations(ctx context.Context) error {
+	functionName := "ReservationUsecase.ReleaseExpiredReservations"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	reservations, err := uc.repo.GetExpiredReservations(ctx, time.Now(), expiredReservationsBatchSize)
+	if err != nil {
+		return errors.Wrap(fmt.Errorf("uc.repo.GetExpiredReservations: %w", err), functionName)
+	}
+
+	if err := uc.attachReservationItems(ctx, reservations); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	// A failed reservation is released again on the next run, the other reservations of the batch are still released
+	var failed batchError
+	for _, reservation := range reservations {
+		if err := retryTransaction(ctx, func() error { return uc.releaseReservation(ctx, reservation, types.ReservationStatusExpiredType) }); err != nil {
+			// The reservation has been processed by another request or sweeper instance
+			if err == response.ErrNotFound {
+				continue
+			}
+
+			failed = append(failed, fmt.Errorf("reservation %d: %w", reservation.ID, err))
+		}
+	}
+
+	if err := failed.errOrNil(); err != nil {
+		return errors.Wrap(err, functionName)
+	}
+
+	return nil
+}
+
+// releaseReservation move an active reservation to the given status and stop holding its qty
+func (uc *ReservationUsecase) releaseReservation(ctx context.Context, reservation *entity.Reservation, status types.ReservationStatusType) error {
+	// Begin transaction
+	tx, err := uc.dbTransactionRepo.StartTransactionQuery(ctx)
+	if err != nil {
+		return fmt.Errorf("uc.dbTransactionRepo.StartTransactionQuery: %w", err)
+	}
+
+	// Create flag and defer rollback when flag is true
+	rollbackProcess := true
+	defer func() {
+		if rollbackProcess {
+			uc.dbTransactionRepo.RollbackTransactionQuery(ctx, tx)
+		}
+	}()
+
+	reservation.Status = status
+	if err := uc.repo.UpdateReservationStatus(ctx, tx, reservation, types.ReservationStatusActiveType); err != nil {
+		if err == response.ErrNotFound {
+			return err
+		}
+
+		return fmt.Errorf("uc.repo.UpdateReservationStatus: %w", err)
+	}
+
+	for _, item := range reservation.ItemsByProductID() {
+		if err := uc.productRepo.ReleaseProductQty(ctx, tx, item.ProductID, item.Qty); err != nil {
+			return fmt.Errorf("uc.productRepo.ReleaseProductQty: %w", err)
+		}
+	}
+
+	// Commit transaction
+	if err = uc.dbTransactionRepo.CommitTransactionQuery(ctx, tx); err != nil {
+		return fmt.Errorf("uc.dbTransactionRepo.CommitTransactionQuery: %w", err)
+	}
+	rollbackProcess = false
+
+	return nil
+}
+
+func (uc *ReservationUsecase) getReservation(ctx context.Context, tenant types.TenantType, reservationID int) (*entity.Reservation, error) {
+	functionName := "ReservationUsecase.getReservation"
+
+	reservation, err := uc.repo.GetReservationByID(ctx, reservationID)
+	if err != nil {
+		if err == response.ErrNotFound {
+			return nil, err
+		}
+
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.GetReservationByID: %w", err), functionName)
+	}
+
+	if reservation.Tenant != tenant {
+		return nil, response.ErrForbidden
+	}
+
+	if err := uc.attachReservationItems(ctx, []*entity.Reservation{reservation}); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return reservation, nil
+}
+
+// attachReservationItems load the items of the given reservations
+func (uc *ReservationUsecase) attachReservationItems(ctx context.Context, reservations []*entity.Reservation) error {
+	if len(reservations) == 0 {
+		return nil
+	}
+
+	reservationIDs := make([]int, 0, len(reservations))
+	reservationByID := make(map[int]*entity.Reservation, len(reservations))
+	for _, reservation := range reservations {
+		reservationIDs = append(reservationIDs, reservation.ID)
+		reservationByID[reservation.ID] = reservation
+	}
+
+	items, err := uc.repo.GetReservationItemsByReservationIDs(ctx, reservationIDs)
+	if err != nil {
+		return fmt.Errorf("uc.repo.GetReservationItemsByReservationIDs: %w", err)
+	}
+
+	for _, item := range items {
+		if reservation, ok := reservationByID[item.ReservationID]; ok {
+			reservation.Items = append(reservation.Items, item)
+		}
+	}
+
+	return nil
+}
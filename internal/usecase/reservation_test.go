@@ -0,0 +1,643 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func validReservationPayload() *entity.ReservationPayload {
+	return &entity.ReservationPayload{
+		Items:  []entity.ReservationItemPayload{{SKU: "SKU-1", Qty: 2}},
+		Tenant: types.TenantLoremType,
+	}
+}
+
+func activeReservation(expiresAt time.Time) *entity.Reservation {
+	return &entity.Reservation{
+		ID:        1,
+		Tenant:    types.TenantLoremType,
+		Status:    types.ReservationStatusActiveType,
+		Items:     []*entity.ReservationItem{},
+		ExpiresAt: expiresAt,
+	}
+}
+
+func TestCreateReservation(t *testing.T) {
+	testcases := []struct {
+		name           string
+		ctx            context.Context
+		payload        *entity.ReservationPayload
+		rStartTrxErr   error
+		rGetProductRes *entity.Product
+		rGetProductErr error
+		rReserveErr    error
+		rCreateErr     error
+		rCreateItemErr error
+		rCommitTrxErr  error
+		wantErr        bool
+		wantCustomErr  error
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "invalid payload",
+			ctx:           context.Background(),
+			payload:       &entity.ReservationPayload{Tenant: types.TenantLoremType},
+			wantErr:       true,
+			wantCustomErr: response.ErrInvalidReservationItems,
+		},
+		{
+			name:           "failed to start transaction",
+			ctx:            context.Background(),
+			payload:        validReservationPayload(),
+			rGetProductRes: &entity.Product{ID: 1, Tenant: types.TenantLoremType},
+			rStartTrxErr:   response.ErrNoSQLTransactionFound,
+			wantErr:        true,
+		},
+		{
+			name:           "product not found",
+			ctx:            context.Background(),
+			payload:        validReservationPayload(),
+			rGetProductErr: response.ErrNotFound,
+			wantErr:        true,
+			wantCustomErr:  response.ErrNotFound,
+		},
+		{
+			name:           "failed to get product",
+			ctx:            context.Background(),
+			payload:        validReservationPayload(),
+			rGetProductErr: errors.New("error get product"),
+			wantErr:        true,
+		},
+		{
+			name:           "product of other tenant",
+			ctx:            context.Background(),
+			payload:        validReservationPayload(),
+			rGetProductRes: &entity.Product{ID: 1, Tenant: types.TenantIpsumType},
+			wantErr:        true,
+			wantCustomErr:  response.ErrForbidden,
+		},
+		{
+			name:           "insufficient available qty",
+			ctx:            context.Background(),
+			payload:        validReservationPayload(),
+			rGetProductRes: &entity.Product{ID: 1, Tenant: types.TenantLoremType},
+			rReserveErr:    response.ErrInsufficientStock,
+			wantErr:        true,
+			wantCustomErr:  response.ErrInsufficientStock,
+		},
+		{
+			name:           "failed to reserve qty",
+			ctx:            context.Background(),
+			payload:        validReservationPayload(),
+			rGetProductRes: &entity.Product{ID: 1, Tenant: types.TenantLoremType},
+			rReserveErr:    errors.New("error reserve qty"),
+			wantErr:        true,
+		},
+		{
+			name:           "failed to create reservation",
+			ctx:            context.Background(),
+			payload:        validReservationPayload(),
+			rGetProductRes: &entity.Product{ID: 1, Tenant: types.TenantLoremType},
+			rCreateErr:     errors.New("error create reservation"),
+			wantErr:        true,
+		},
+		{
+			name:           "failed to create reservation items",
+			ctx:            context.Background(),
+			payload:        validReservationPayload(),
+			rGetProductRes: &entity.Product{ID: 1, Tenant: types.TenantLoremType},
+			rCreateItemErr: errors.New("error create reservation items"),
+			wantErr:        true,
+		},
+		{
+			name:           "failed to commit transaction",
+			ctx:            context.Background(),
+			payload:        validReservationPayload(),
+			rGetProductRes: &entity.Product{ID: 1, Tenant: types.TenantLoremType},
+			rCommitTrxErr:  response.ErrNoSQLTransactionFound,
+			wantErr:        true,
+		},
+		{
+			name:           "success",
+			ctx:            context.Background(),
+			payload:        validReservationPayload(),
+			rGetProductRes: &entity.Product{ID: 1, Tenant: types.TenantLoremType},
+			wantErr:        false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			reservationRepo := &testmock.ReservationRepositoryInterface{}
+			reservationRepo.On("CreateReservation", mock.Anything, mock.Anything, mock.Anything).Return(tc.rCreateErr)
+			reservationRepo.On("CreateReservationItems", mock.Anything, mock.Anything, mock.Anything).Return(tc.rCreateItemErr)
+
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("GetProductBySKU", mock.Anything, mock.Anything).Return(tc.rGetProductRes, tc.rGetProductErr)
+			productRepo.On("ReserveProductQty", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.rReserveErr)
+
+			dbTransactionRepo := &testmock.PostgresTransactionRepositoryInterface{}
+			dbTransactionRepo.On("StartTransactionQuery", mock.Anything).Return(&sqlx.Tx{}, tc.rStartTrxErr)
+			dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(tc.rCommitTrxErr)
+			dbTransactionRepo.On("RollbackTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+
+			uc := usecase.NewReservationUsecase(reservationRepo, productRepo, &testmock.ProductStockRepositoryInterface{}, &testmock.StockMovementRepositoryInterface{}, &testmock.LowStockRepositoryInterface{}, dbTransactionRepo, types.AllocationStrategyPriorityType, 15*time.Minute)
+			res, err := uc.CreateReservation(tc.ctx, tc.payload)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, tc.wantCustomErr, err)
+			}
+			if !tc.wantErr {
+				assert.Equal(t, types.ReservationStatusActiveType, res.Status)
+				assert.Equal(t, 1, res.Items[0].ProductID)
+				assert.WithinDuration(t, time.Now().Add(15*time.Minute), res.ExpiresAt, time.Minute)
+			}
+		})
+	}
+}
+
+func TestGetReservationByID(t *testing.T) {
+	testcases := []struct {
+		name          string
+		ctx           context.Context
+		rGetRes       *entity.Reservation
+		rGetErr       error
+		rItemsRes     []*entity.ReservationItem
+		rItemsErr     error
+		wantErr       bool
+		wantCustomErr error
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "reservation not found",
+			ctx:           context.Background(),
+			rGetErr:       response.ErrNotFound,
+			wantErr:       true,
+			wantCustomErr: response.ErrNotFound,
+		},
+		{
+			name:    "failed to get reservation",
+			ctx:     context.Background(),
+			rGetErr: errors.New("error get reservation"),
+			wantErr: true,
+		},
+		{
+			name:          "reservation of other tenant",
+			ctx:           context.Background(),
+			rGetRes:       &entity.Reservation{ID: 1, Tenant: types.TenantIpsumType},
+			wantErr:       true,
+			wantCustomErr: response.ErrForbidden,
+		},
+		{
+			name:      "failed to get reservation items",
+			ctx:       context.Background(),
+			rGetRes:   activeReservation(time.Now().Add(time.Hour)),
+			rItemsErr: errors.New("error get reservation items"),
+			wantErr:   true,
+		},
+		{
+			name:      "success",
+			ctx:       context.Background(),
+			rGetRes:   activeReservation(time.Now().Add(time.Hour)),
+			rItemsRes: []*entity.ReservationItem{{ReservationID: 1, ProductID: 1, SKU: "SKU-1", Qty: 2}},
+			wantErr:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			reservationRepo := &testmock.ReservationRepositoryInterface{}
+			reservationRepo.On("GetReservationByID", mock.Anything, mock.Anything).Return(tc.rGetRes, tc.rGetErr)
+			reservationRepo.On("GetReservationItemsByReservationIDs", mock.Anything, mock.Anything).Return(tc.rItemsRes, tc.rItemsErr)
+
+			uc := usecase.NewReservationUsecase(reservationRepo, &testmock.ProductRepositoryInterface{}, &testmock.ProductStockRepositoryInterface{}, &testmock.StockMovementRepositoryInterface{}, &testmock.LowStockRepositoryInterface{}, &testmock.PostgresTransactionRepositoryInterface{}, types.AllocationStrategyPriorityType, 15*time.Minute)
+			res, err := uc.GetReservationByID(tc.ctx, types.TenantLoremType, 1)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, tc.wantCustomErr, err)
+			}
+			if !tc.wantErr {
+				assert.Equal(t, tc.rItemsRes, res.Items)
+			}
+		})
+	}
+}
+
+func TestConfirmReservation(t *testing.T) {
+	items := []*entity.ReservationItem{{ReservationID: 1, ProductID: 1, SKU: "SKU-1", Qty: 2}}
+
+	testcases := []struct {
+		name              string
+		ctx               context.Context
+		rGetRes           *entity.Reservation
+		rGetErr           error
+		rStartTrxErr      error
+		rUpdateStatusErr  error
+		rReleaseErr       error
+		rGetProductErr    error
+		rStocksRes        []*entity.ProductStock
+		rStocksErr        error
+		rUpsertStocksErr  error
+		rUpdateProductErr error
+		rStockMovementErr error
+		rCommitTrxErr     error
+		wantQty           int
+		wantErr           bool
+		wantCustomErr     error
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "reservation not found",
+			ctx:           context.Background(),
+			rGetErr:       response.ErrNotFound,
+			wantErr:       true,
+			wantCustomErr: response.ErrNotFound,
+		},
+		{
+			name:          "reservation already expired",
+			ctx:           context.Background(),
+			rGetRes:       activeReservation(time.Now().Add(-time.Minute)),
+			wantErr:       true,
+			wantCustomErr: response.ErrReservationNotActive,
+		},
+		{
+			name:          "reservation already released",
+			ctx:           context.Background(),
+			rGetRes:       &entity.Reservation{ID: 1, Tenant: types.TenantLoremType, Status: types.ReservationStatusReleasedType, ExpiresAt: time.Now().Add(time.Hour)},
+			wantErr:       true,
+			wantCustomErr: response.ErrReservationNotActive,
+		},
+		{
+			name:         "failed to start transaction",
+			ctx:          context.Background(),
+			rGetRes:      activeReservation(time.Now().Add(time.Hour)),
+			rStartTrxErr: response.ErrNoSQLTransactionFound,
+			wantErr:      true,
+		},
+		{
+			name:             "reservation processed by other request",
+			ctx:              context.Background(),
+			rGetRes:          activeReservation(time.Now().Add(time.Hour)),
+			rUpdateStatusErr: response.ErrNotFound,
+			wantErr:          true,
+			wantCustomErr:    response.ErrReservationNotActive,
+		},
+		{
+			name:             "failed to update reservation status",
+			ctx:              context.Background(),
+			rGetRes:          activeReservation(time.Now().Add(time.Hour)),
+			rUpdateStatusErr: errors.New("error update reservation status"),
+			wantErr:          true,
+		},
+		{
+			name:        "failed to release qty",
+			ctx:         context.Background(),
+			rGetRes:     activeReservation(time.Now().Add(time.Hour)),
+			rReleaseErr: errors.New("error release qty"),
+			wantErr:     true,
+		},
+		{
+			name:           "failed to get product",
+			ctx:            context.Background(),
+			rGetRes:        activeReservation(time.Now().Add(time.Hour)),
+			rGetProductErr: errors.New("error get product"),
+			wantErr:        true,
+		},
+		{
+			name:       "failed to get product stocks",
+			ctx:        context.Background(),
+			rGetRes:    activeReservation(time.Now().Add(time.Hour)),
+			rStocksErr: errors.New("error get product stocks"),
+			wantErr:    true,
+		},
+		{
+			name:          "insufficient stock",
+			ctx:           context.Background(),
+			rGetRes:       activeReservation(time.Now().Add(time.Hour)),
+			rStocksRes:    []*entity.ProductStock{{LocationID: 1, Qty: 1}},
+			wantErr:       true,
+			wantCustomErr: response.ErrInsufficientStock,
+		},
+		{
+			name:             "failed to upsert product stocks",
+			ctx:              context.Background(),
+			rGetRes:          activeReservation(time.Now().Add(time.Hour)),
+			rStocksRes:       []*entity.ProductStock{{LocationID: 1, Qty: 5}},
+			rUpsertStocksErr: errors.New("error upsert product stocks"),
+			wantErr:          true,
+		},
+		{
+			name:              "failed to update product",
+			ctx:               context.Background(),
+			rGetRes:           activeReservation(time.Now().Add(time.Hour)),
+			rStocksRes:        []*entity.ProductStock{{LocationID: 1, Qty: 5}},
+			rUpdateProductErr: errors.New("error update product"),
+			wantErr:           true,
+		},
+		{
+			name:              "failed to create stock movements",
+			ctx:               context.Background(),
+			rGetRes:           activeReservation(time.Now().Add(time.Hour)),
+			rStocksRes:        []*entity.ProductStock{{LocationID: 1, Qty: 5}},
+			rStockMovementErr: errors.New("error create stock movements"),
+			wantErr:           true,
+		},
+		{
+			name:          "failed to commit transaction",
+			ctx:           context.Background(),
+			rGetRes:       activeReservation(time.Now().Add(time.Hour)),
+			rStocksRes:    []*entity.ProductStock{{LocationID: 1, Qty: 5}},
+			rCommitTrxErr: response.ErrNoSQLTransactionFound,
+			wantErr:       true,
+		},
+		{
+			name:       "success",
+			ctx:        context.Background(),
+			rGetRes:    activeReservation(time.Now().Add(time.Hour)),
+			rStocksRes: []*entity.ProductStock{{LocationID: 1, Qty: 5}},
+			wantQty:    3,
+			wantErr:    false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			product := &entity.Product{ID: 1, Tenant: types.TenantLoremType, Qty: 5, ReservedQty: 2}
+
+			reservationRepo := &testmock.ReservationRepositoryInterface{}
+			reservationRepo.On("GetReservationByID", mock.Anything, mock.Anything).Return(tc.rGetRes, tc.rGetErr)
+			reservationRepo.On("GetReservationItemsByReservationIDs", mock.Anything, mock.Anything).Return(items, nil)
+			reservationRepo.On("UpdateReservationStatus", mock.Anything, mock.Anything, mock.Anything, types.ReservationStatusActiveType).Return(tc.rUpdateStatusErr)
+
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("ReleaseProductQty", mock.Anything, mock.Anything, 1, 2).Return(tc.rReleaseErr)
+			productRepo.On("GetProductsForUpdate", mock.Anything, mock.Anything, mock.Anything, []int{1}, []string(nil)).Return([]*entity.Product{product}, tc.rGetProductErr)
+			productRepo.On("UpdateProductQty", mock.Anything, mock.Anything, mock.Anything).Return(tc.rUpdateProductErr)
+
+			productStockRepo := &testmock.ProductStockRepositoryInterface{}
+			productStockRepo.On("GetProductLotsByProductIDs", mock.Anything, mock.Anything).Return([]*entity.ProductLot{}, nil)
+			productStockRepo.On("GetProductLotsForUpdate", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.ProductLot{}, nil)
+			productStockRepo.On("UpdateProductLots", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			productStockRepo.On("GetProductStocksForUpdate", mock.Anything, mock.Anything, 1).Return(tc.rStocksRes, tc.rStocksErr)
+			productStockRepo.On("UpsertProductStocks", mock.Anything, mock.Anything, mock.Anything).Return(tc.rUpsertStocksErr)
+
+			dbTransactionRepo := &testmock.PostgresTransactionRepositoryInterface{}
+			dbTransactionRepo.On("StartTransactionQuery", mock.Anything).Return(&sqlx.Tx{}, tc.rStartTrxErr)
+			dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(tc.rCommitTrxErr)
+			dbTransactionRepo.On("RollbackTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+
+			stockMovementRepo := &testmock.StockMovementRepositoryInterface{}
+			stockMovementRepo.On("CreateStockMovements", mock.Anything, mock.Anything, mock.Anything).Return(tc.rStockMovementErr)
+
+			lowStockRepo := &testmock.LowStockRepositoryInterface{}
+			lowStockRepo.On("GetLowStockThreshold", mock.Anything, mock.Anything).Return(nil, response.ErrNotFound)
+
+			uc := usecase.NewReservationUsecase(reservationRepo, productRepo, productStockRepo, stockMovementRepo, lowStockRepo, dbTransactionRepo, types.AllocationStrategyPriorityType, 15*time.Minute)
+			res, err := uc.ConfirmReservation(tc.ctx, types.TenantLoremType, 1, "jane")
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, tc.wantCustomErr, err)
+			}
+			if !tc.wantErr {
+				assert.Equal(t, types.ReservationStatusConfirmedType, res.Status)
+				assert.Equal(t, tc.wantQty, product.Qty)
+				stockMovementRepo.AssertCalled(t, "CreateStockMovements", mock.Anything, mock.Anything, mock.MatchedBy(func(movements []*entity.StockMovement) bool {
+					return movements[0].Delta == -2 && movements[0].ResultingQty == tc.wantQty && movements[0].Reason == types.StockMovementReasonSaleType &&
+						movements[0].Actor == "jane" && movements[0].ReferenceID == "reservation:1"
+				}))
+			}
+		})
+	}
+}
+
+func TestReleaseReservation(t *testing.T) {
+	items := []*entity.ReservationItem{{ReservationID: 1, ProductID: 1, SKU: "SKU-1", Qty: 2}}
+
+	testcases := []struct {
+		name             string
+		ctx              context.Context
+		rGetRes          *entity.Reservation
+		rGetErr          error
+		rStartTrxErr     error
+		rUpdateStatusErr error
+		rReleaseErr      error
+		rCommitTrxErr    error
+		wantErr          bool
+		wantCustomErr    error
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "reservation of other tenant",
+			ctx:           context.Background(),
+			rGetRes:       &entity.Reservation{ID: 1, Tenant: types.TenantIpsumType},
+			wantErr:       true,
+			wantCustomErr: response.ErrForbidden,
+		},
+		{
+			name:          "reservation already confirmed",
+			ctx:           context.Background(),
+			rGetRes:       &entity.Reservation{ID: 1, Tenant: types.TenantLoremType, Status: types.ReservationStatusConfirmedType},
+			wantErr:       true,
+			wantCustomErr: response.ErrReservationNotActive,
+		},
+		{
+			name:         "failed to start transaction",
+			ctx:          context.Background(),
+			rGetRes:      activeReservation(time.Now().Add(time.Hour)),
+			rStartTrxErr: response.ErrNoSQLTransactionFound,
+			wantErr:      true,
+		},
+		{
+			name:             "reservation processed by other request",
+			ctx:              context.Background(),
+			rGetRes:          activeReservation(time.Now().Add(time.Hour)),
+			rUpdateStatusErr: response.ErrNotFound,
+			wantErr:          true,
+			wantCustomErr:    response.ErrReservationNotActive,
+		},
+		{
+			name:        "failed to release qty",
+			ctx:         context.Background(),
+			rGetRes:     activeReservation(time.Now().Add(time.Hour)),
+			rReleaseErr: errors.New("error release qty"),
+			wantErr:     true,
+		},
+		{
+			name:          "failed to commit transaction",
+			ctx:           context.Background(),
+			rGetRes:       activeReservation(time.Now().Add(time.Hour)),
+			rCommitTrxErr: response.ErrNoSQLTransactionFound,
+			wantErr:       true,
+		},
+		{
+			name:    "success on expired reservation which has not been swept",
+			ctx:     context.Background(),
+			rGetRes: activeReservation(time.Now().Add(-time.Minute)),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			reservationRepo := &testmock.ReservationRepositoryInterface{}
+			reservationRepo.On("GetReservationByID", mock.Anything, mock.Anything).Return(tc.rGetRes, tc.rGetErr)
+			reservationRepo.On("GetReservationItemsByReservationIDs", mock.Anything, mock.Anything).Return(items, nil)
+			reservationRepo.On("UpdateReservationStatus", mock.Anything, mock.Anything, mock.Anything, types.ReservationStatusActiveType).Return(tc.rUpdateStatusErr)
+
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("ReleaseProductQty", mock.Anything, mock.Anything, 1, 2).Return(tc.rReleaseErr)
+
+			dbTransactionRepo := &testmock.PostgresTransactionRepositoryInterface{}
+			dbTransactionRepo.On("StartTransactionQuery", mock.Anything).Return(&sqlx.Tx{}, tc.rStartTrxErr)
+			dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(tc.rCommitTrxErr)
+			dbTransactionRepo.On("RollbackTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+
+			uc := usecase.NewReservationUsecase(reservationRepo, productRepo, &testmock.ProductStockRepositoryInterface{}, &testmock.StockMovementRepositoryInterface{}, &testmock.LowStockRepositoryInterface{}, dbTransactionRepo, types.AllocationStrategyPriorityType, 15*time.Minute)
+			res, err := uc.ReleaseReservation(tc.ctx, types.TenantLoremType, 1)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, tc.wantCustomErr, err)
+			}
+			if !tc.wantErr {
+				assert.Equal(t, types.ReservationStatusReleasedType, res.Status)
+				productRepo.AssertCalled(t, "ReleaseProductQty", mock.Anything, mock.Anything, 1, 2)
+			}
+		})
+	}
+}
+
+func TestReleaseExpiredReservations(t *testing.T) {
+	testcases := []struct {
+		name             string
+		ctx              context.Context
+		rExpiredRes      []*entity.Reservation
+		rExpiredErr      error
+		rItemsErr        error
+		rUpdateStatusErr error
+		rUpdateFailures  int
+		rReleaseErr      error
+		wantReleased     bool
+		wantErr          bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:        "failed to get expired reservations",
+			ctx:         context.Background(),
+			rExpiredErr: errors.New("error get expired reservations"),
+			wantErr:     true,
+		},
+		{
+			name:        "failed to get reservation items",
+			ctx:         context.Background(),
+			rExpiredRes: []*entity.Reservation{activeReservation(time.Now().Add(-time.Minute))},
+			rItemsErr:   errors.New("error get reservation items"),
+			wantErr:     true,
+		},
+		{
+			name:             "failed to update reservation status",
+			ctx:              context.Background(),
+			rExpiredRes:      []*entity.Reservation{activeReservation(time.Now().Add(-time.Minute))},
+			rUpdateStatusErr: errors.New("error update reservation status"),
+			wantErr:          true,
+		},
+		{
+			name:        "failed to release qty",
+			ctx:         context.Background(),
+			rExpiredRes: []*entity.Reservation{activeReservation(time.Now().Add(-time.Minute))},
+			rReleaseErr: errors.New("error release qty"),
+			wantErr:     true,
+		},
+		{
+			name:             "skip reservation processed by other request",
+			ctx:              context.Background(),
+			rExpiredRes:      []*entity.Reservation{activeReservation(time.Now().Add(-time.Minute))},
+			rUpdateStatusErr: response.ErrNotFound,
+			wantErr:          false,
+		},
+		{
+			name:    "without expired reservation",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+		{
+			name:             "failed reservation does not hold back the others",
+			ctx:              context.Background(),
+			rExpiredRes:      []*entity.Reservation{activeReservation(time.Now().Add(-time.Minute)), activeReservation(time.Now().Add(-time.Minute))},
+			rUpdateStatusErr: errors.New("error update reservation status"),
+			rUpdateFailures:  1,
+			wantReleased:     true,
+			wantErr:          true,
+		},
+		{
+			name:         "success",
+			ctx:          context.Background(),
+			rExpiredRes:  []*entity.Reservation{activeReservation(time.Now().Add(-time.Minute))},
+			wantReleased: true,
+			wantErr:      false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			items := []*entity.ReservationItem{{ReservationID: 1, ProductID: 1, SKU: "SKU-1", Qty: 2}}
+
+			reservationRepo := &testmock.ReservationRepositoryInterface{}
+			reservationRepo.On("GetExpiredReservations", mock.Anything, mock.Anything, 100).Return(tc.rExpiredRes, tc.rExpiredErr)
+			reservationRepo.On("GetReservationItemsByReservationIDs", mock.Anything, mock.Anything).Return(items, tc.rItemsErr)
+			if tc.rUpdateFailures > 0 {
+				reservationRepo.On("UpdateReservationStatus", mock.Anything, mock.Anything, mock.Anything, types.ReservationStatusActiveType).Return(tc.rUpdateStatusErr).Times(tc.rUpdateFailures)
+				reservationRepo.On("UpdateReservationStatus", mock.Anything, mock.Anything, mock.Anything, types.ReservationStatusActiveType).Return(nil)
+			} else {
+				reservationRepo.On("UpdateReservationStatus", mock.Anything, mock.Anything, mock.Anything, types.ReservationStatusActiveType).Return(tc.rUpdateStatusErr)
+			}
+
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("ReleaseProductQty", mock.Anything, mock.Anything, 1, 2).Return(tc.rReleaseErr)
+
+			dbTransactionRepo := &testmock.PostgresTransactionRepositoryInterface{}
+			dbTransactionRepo.On("StartTransactionQuery", mock.Anything).Return(&sqlx.Tx{}, nil)
+			dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+			dbTransactionRepo.On("RollbackTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+
+			uc := usecase.NewReservationUsecase(reservationRepo, productRepo, &testmock.ProductStockRepositoryInterface{}, &testmock.StockMovementRepositoryInterface{}, &testmock.LowStockRepositoryInterface{}, dbTransactionRepo, types.AllocationStrategyPriorityType, 15*time.Minute)
+			err := uc.ReleaseExpiredReservations(tc.ctx)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantReleased {
+				assert.Equal(t, types.ReservationStatusExpiredType, tc.rExpiredRes[len(tc.rExpiredRes)-1].Status)
+				productRepo.AssertCalled(t, "ReleaseProductQty", mock.Anything, mock.Anything, 1, 2)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	repo "github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// SerialUsecaseInterface define contract for serial number related functions to usecase
+type SerialUsecaseInterface interface {
+	GetProductSerialBySerialNumber(ctx context.Context, tenant types.TenantType, serialNumber string) (*entity.ProductSerial, error)
+	GetProductSerials(ctx context.Context, payload *entity.GetProductSerialPayload) ([]*entity.ProductSerial, int, error)
+}
+
+type SerialUsecase struct {
+	productRepo      repo.ProductRepositoryInterface
+	productStockRepo repo.ProductStockRepositoryInterface
+}
+
+func NewSerialUsecase(rProduct repo.ProductRepositoryInterface, rProductStock repo.ProductStockRepositoryInterface) *SerialUsecase {
+	return &SerialUsecase{
+		productRepo:      rProduct,
+		productStockRepo: rProductStock,
+	}
+}
+
+// GetProductSerialBySerialNumber return the serial number with its product,
+// a serial number which is out of stock has the reference id of the deduction which took it, e.g. the sale
+func (uc *SerialUsecase) GetProductSerialBySerialNumber(ctx context.Context, tenant types.TenantType, serialNumber string) (*entity.ProductSerial, error) {
+	functionName := "SerialUsecase.GetProductSerialBySerialNumber"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	serial, err := uc.productStockRepo.GetProductSerialBySerialNumber(ctx, tenant, serialNumber)
+	if err != nil {
+		if err == response.ErrNotFound {
+			return nil, err
+		}
+
+		return nil, errors.Wrap(fmt.Errorf("uc.productStockRepo.GetProductSerialBySerialNumber: %w", err), functionName)
+	}
+
+	product, err := uc.productRepo.GetProductByID(ctx, serial.ProductID)
+	if err != nil {
+		if err == response.ErrNotFound {
+			return nil, err
+		}
+
+		return nil, errors.Wrap(fmt.Errorf("uc.productRepo.GetProductByID: %w", err), functionName)
+	}
+
+	product.HideCost()
+	serial.Product = product
+
+	return serial, nil
+}
+
+func (uc *SerialUsecase) GetProductSerials(ctx context.Context, payload *entity.GetProductSerialPayload) ([]*entity.ProductSerial, int, error) {
+	functionName := "SerialUsecase.GetProductSerials"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, 0, errors.Wrap(err, functionName)
+	}
+
+	product, err := uc.productRepo.GetProductByID(ctx, payload.ProductID)
+	if err != nil {
+		if err == response.ErrNotFound {
+			return nil, 0, err
+		}
+
+		return nil, 0, errors.Wrap(fmt.Errorf("uc.productRepo.GetProductByID: %w", err), functionName)
+	}
+
+	if product.Tenant != payload.Tenant {
+		return nil, 0, response.ErrForbidden
+	}
+
+	serials, err := uc.productStockRepo.GetProductSerials(ctx, payload)
+	if err != nil {
+		return nil, 0, errors.Wrap(fmt.Errorf("uc.productStockRepo.GetProductSerials: %w", err), functionName)
+	}
+
+	count, err := uc.productStockRepo.GetProductSerialsCount(ctx, payload)
+	if err != nil {
+		return nil, 0, errors.Wrap(fmt.Errorf("uc.productStockRepo.GetProductSerialsCount: %w", err), functionName)
+	}
+
+	return serials, count, nil
+}
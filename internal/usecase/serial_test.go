@@ -0,0 +1,165 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetProductSerialBySerialNumber(t *testing.T) {
+	costPrice := 100
+
+	testcases := []struct {
+		name           string
+		ctx            context.Context
+		rGetSerialRes  *entity.ProductSerial
+		rGetSerialErr  error
+		rGetProductRes *entity.Product
+		rGetProductErr error
+		wantErr        bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "serial number is not found",
+			ctx:           context.Background(),
+			rGetSerialErr: response.ErrNotFound,
+			wantErr:       true,
+		},
+		{
+			name:          "failed to get serial number",
+			ctx:           context.Background(),
+			rGetSerialErr: errors.New("error get serial number"),
+			wantErr:       true,
+		},
+		{
+			name:           "failed to get product",
+			ctx:            context.Background(),
+			rGetSerialRes:  &entity.ProductSerial{ProductID: 1},
+			rGetProductErr: errors.New("error get product"),
+			wantErr:        true,
+		},
+		{
+			name:           "success",
+			ctx:            context.Background(),
+			rGetSerialRes:  &entity.ProductSerial{ProductID: 1, Status: types.SerialStatusSoldType, ReferenceID: "ORDER-1"},
+			rGetProductRes: &entity.Product{ID: 1, CostPrice: &costPrice},
+			wantErr:        false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("GetProductByID", mock.Anything, mock.Anything).Return(tc.rGetProductRes, tc.rGetProductErr)
+
+			productStockRepo := &testmock.ProductStockRepositoryInterface{}
+			productStockRepo.On("GetProductSerialBySerialNumber", mock.Anything, mock.Anything, mock.Anything).Return(tc.rGetSerialRes, tc.rGetSerialErr)
+
+			uc := usecase.NewSerialUsecase(productRepo, productStockRepo)
+			serial, err := uc.GetProductSerialBySerialNumber(tc.ctx, types.TenantLoremType, "SN-1")
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, tc.rGetProductRes, serial.Product)
+				assert.Nil(t, serial.Product.CostPrice)
+			}
+		})
+	}
+}
+
+func TestGetProductSerials(t *testing.T) {
+	testcases := []struct {
+		name           string
+		ctx            context.Context
+		payload        *entity.GetProductSerialPayload
+		rGetProductRes *entity.Product
+		rGetProductErr error
+		rSerialsErr    error
+		rCountRes      int
+		rCountErr      error
+		wantCount      int
+		wantErr        bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:           "product is not found",
+			ctx:            context.Background(),
+			payload:        &entity.GetProductSerialPayload{},
+			rGetProductErr: response.ErrNotFound,
+			wantErr:        true,
+		},
+		{
+			name:           "failed to get product",
+			ctx:            context.Background(),
+			payload:        &entity.GetProductSerialPayload{},
+			rGetProductErr: errors.New("error get product"),
+			wantErr:        true,
+		},
+		{
+			name:           "forbidden",
+			ctx:            context.Background(),
+			payload:        &entity.GetProductSerialPayload{Tenant: types.TenantIpsumType},
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType},
+			wantErr:        true,
+		},
+		{
+			name:           "failed to get serial numbers",
+			ctx:            context.Background(),
+			payload:        &entity.GetProductSerialPayload{},
+			rGetProductRes: &entity.Product{},
+			rSerialsErr:    errors.New("error get serial numbers"),
+			wantErr:        true,
+		},
+		{
+			name:           "failed to get serial numbers count",
+			ctx:            context.Background(),
+			payload:        &entity.GetProductSerialPayload{},
+			rGetProductRes: &entity.Product{},
+			rCountErr:      errors.New("error get serial numbers count"),
+			wantErr:        true,
+		},
+		{
+			name:           "success",
+			ctx:            context.Background(),
+			payload:        &entity.GetProductSerialPayload{},
+			rGetProductRes: &entity.Product{},
+			rCountRes:      3,
+			wantCount:      3,
+			wantErr:        false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("GetProductByID", mock.Anything, mock.Anything).Return(tc.rGetProductRes, tc.rGetProductErr)
+
+			productStockRepo := &testmock.ProductStockRepositoryInterface{}
+			productStockRepo.On("GetProductSerials", mock.Anything, mock.Anything).Return([]*entity.ProductSerial{}, tc.rSerialsErr)
+			productStockRepo.On("GetProductSerialsCount", mock.Anything, mock.Anything).Return(tc.rCountRes, tc.rCountErr)
+
+			uc := usecase.NewSerialUsecase(productRepo, productStockRepo)
+			_, count, err := uc.GetProductSerials(tc.ctx, tc.payload)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, tc.wantCount, count)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	repo "github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// StockMovementUsecaseInterface define contract for stock movement related functions to usecase
+type StockMovementUsecaseInterface interface {
+	GetStockMovements(ctx context.Context, payload *entity.GetStockMovementPayload) ([]*entity.StockMovement, int, error)
+}
+
+type StockMovementUsecase struct {
+	repo        repo.StockMovementRepositoryInterface
+	productRepo repo.ProductRepositoryInterface
+}
+
+func NewStockMovementUsecase(r repo.StockMovementRepositoryInterface, rProduct repo.ProductRepositoryInterface) *StockMovementUsecase {
+	return &StockMovementUsecase{
+		repo:        r,
+		productRepo: rProduct,
+	}
+}
+
+func (uc *StockMovementUsecase) GetStockMovements(ctx context.Context, payload *entity.GetStockMovementPayload) ([]*entity.StockMovement, int, error) {
+	functionName := "StockMovementUsecase.GetStockMovements"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, 0, errors.Wrap(err, functionName)
+	}
+
+	if err := payload.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	product, err := uc.productRepo.GetProductByID(ctx, payload.ProductID)
+	if err != nil {
+		if err == response.ErrNotFound {
+			return nil, 0, err
+		}
+
+		return nil, 0, errors.Wrap(fmt.Errorf("uc.productRepo.GetProductByID: %w", err), functionName)
+	}
+
+	if product.Tenant != payload.Tenant {
+		return nil, 0, response.ErrForbidden
+	}
+
+	movements, err := uc.repo.GetStockMovements(ctx, payload)
+	if err != nil {
+		return nil, 0, errors.Wrap(fmt.Errorf("uc.repo.GetStockMovements: %w", err), functionName)
+	}
+
+	count, err := uc.repo.GetStockMovementsCount(ctx, payload)
+	if err != nil {
+		return nil, 0, errors.Wrap(fmt.Errorf("uc.repo.GetStockMovementsCount: %w", err), functionName)
+	}
+
+	return movements, count, nil
+}
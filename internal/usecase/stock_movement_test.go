@@ -0,0 +1,111 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetStockMovements(t *testing.T) {
+	from := time.Now()
+	to := from.Add(-time.Hour)
+
+	testcases := []struct {
+		name           string
+		ctx            context.Context
+		payload        *entity.GetStockMovementPayload
+		rGetProductRes *entity.Product
+		rGetProductErr error
+		rMovementsErr  error
+		rCountRes      int
+		rCountErr      error
+		wantCount      int
+		wantErr        bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:    "invalid date range",
+			ctx:     context.Background(),
+			payload: &entity.GetStockMovementPayload{From: &from, To: &to},
+			wantErr: true,
+		},
+		{
+			name:           "product is not found",
+			ctx:            context.Background(),
+			payload:        &entity.GetStockMovementPayload{},
+			rGetProductErr: response.ErrNotFound,
+			wantErr:        true,
+		},
+		{
+			name:           "failed to get product",
+			ctx:            context.Background(),
+			payload:        &entity.GetStockMovementPayload{},
+			rGetProductErr: errors.New("error get product"),
+			wantErr:        true,
+		},
+		{
+			name:           "forbidden",
+			ctx:            context.Background(),
+			payload:        &entity.GetStockMovementPayload{Tenant: types.TenantIpsumType},
+			rGetProductRes: &entity.Product{Tenant: types.TenantLoremType},
+			wantErr:        true,
+		},
+		{
+			name:           "failed to get stock movements",
+			ctx:            context.Background(),
+			payload:        &entity.GetStockMovementPayload{},
+			rGetProductRes: &entity.Product{},
+			rMovementsErr:  errors.New("error get stock movements"),
+			wantErr:        true,
+		},
+		{
+			name:           "failed to get stock movements count",
+			ctx:            context.Background(),
+			payload:        &entity.GetStockMovementPayload{},
+			rGetProductRes: &entity.Product{},
+			rCountErr:      errors.New("error get stock movements count"),
+			wantErr:        true,
+		},
+		{
+			name:           "success",
+			ctx:            context.Background(),
+			payload:        &entity.GetStockMovementPayload{},
+			rGetProductRes: &entity.Product{},
+			rCountRes:      3,
+			wantCount:      3,
+			wantErr:        false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("GetProductByID", mock.Anything, mock.Anything).Return(tc.rGetProductRes, tc.rGetProductErr)
+
+			stockMovementRepo := &testmock.StockMovementRepositoryInterface{}
+			stockMovementRepo.On("GetStockMovements", mock.Anything, mock.Anything).Return([]*entity.StockMovement{}, tc.rMovementsErr)
+			stockMovementRepo.On("GetStockMovementsCount", mock.Anything, mock.Anything).Return(tc.rCountRes, tc.rCountErr)
+
+			uc := usecase.NewStockMovementUsecase(stockMovementRepo, productRepo)
+			_, count, err := uc.GetStockMovements(tc.ctx, tc.payload)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, tc.wantCount, count)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	repo "github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// TaxClassUsecaseInterface define contract for tax class related functions to usecase
+type TaxClassUsecaseInterface interface {
+	CreateTaxClass(ctx context.Context, payload *entity.TaxClassPayload) (*entity.TaxClass, error)
+	GetTaxClasses(ctx context.Context, tenant types.TenantType) ([]*entity.TaxClass, error)
+	UpdateTaxClass(ctx context.Context, taxClassID int, payload *entity.TaxClassPayload) (*entity.TaxClass, error)
+}
+
+type TaxClassUsecase struct {
+	repo              repo.TaxClassRepositoryInterface
+	dbTransactionRepo repo.PostgresTransactionRepositoryInterface
+}
+
+func NewTaxClassUsecase(r repo.TaxClassRepositoryInterface, rPgTrx repo.PostgresTransactionRepositoryInterface) *TaxClassUsecase {
+	return &TaxClassUsecase{
+		repo:              r,
+		dbTransactionRepo: rPgTrx,
+	}
+}
+
+func (uc *TaxClassUsecase) CreateTaxClass(ctx context.Context, payload *entity.TaxClassPayload) (*entity.TaxClass, error) {
+	functionName := "TaxClassUsecase.CreateTaxClass"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	taxClass := payload.ToEntity()
+	if err := uc.saveTaxClass(ctx, taxClass, uc.repo.CreateTaxClass); err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return taxClass, nil
+}
+
+func (uc *TaxClassUsecase) GetTaxClasses(ctx context.Context, tenant types.TenantType) ([]*entity.TaxClass, error) {
+	functionName := "TaxClassUsecase.GetTaxClasses"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	taxClasses, err := uc.repo.GetTaxClasses(ctx, tenant)
+	if err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.GetTaxClasses: %w", err), functionName)
+	}
+
+	if err := attachTaxRates(ctx, uc.repo, taxClasses); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	categoryTaxClasses, err := uc.repo.GetCategoryTaxClasses(ctx, tenant)
+	if err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.GetCategoryTaxClasses: %w", err), functionName)
+	}
+
+	taxClassByID := make(map[int]*entity.TaxClass, len(taxClasses))
+	for _, taxClass := range taxClasses {
+		taxClassByID[taxClass.ID] = taxClass
+	}
+
+	for _, categoryTaxClass := range categoryTaxClasses {
+		if taxClass, ok := taxClassByID[categoryTaxClass.TaxClassID]; ok {
+			taxClass.Categories = append(taxClass.Categories, categoryTaxClass.Category)
+		}
+	}
+
+	return taxClasses, nil
+}
+
+func (uc *TaxClassUsecase) UpdateTaxClass(ctx context.Context, taxClassID int, payload *entity.TaxClassPayload) (*entity.TaxClass, error) {
+	functionName := "TaxClassUsecase.UpdateTaxClass"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	currentTaxClass, err := uc.repo.GetTaxClassByID(ctx, taxClassID)
+	if err != nil {
+		if err == response.ErrNotFound {
+			return nil, err
+		}
+
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.GetTaxClassByID: %w", err), functionName)
+	}
+
+	if currentTaxClass.Tenant != payload.Tenant {
+		return nil, response.ErrForbidden
+	}
+
+	taxClass := payload.ToEntity()
+	taxClass.ID = currentTaxClass.ID
+	taxClass.CreatedAt = currentTaxClass.CreatedAt
+	if err := uc.saveTaxClass(ctx, taxClass, uc.repo.UpdateTaxClass); err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return taxClass, nil
+}
+
+// saveTaxClass save the tax class with the given function along with its rates and categories in a transaction
+func (uc *TaxClassUsecase) saveTaxClass(ctx context.Context, taxClass *entity.TaxClass, save func(context.Context, interface{}, *entity.TaxClass) error) error {
+	// Begin transaction
+	tx, err := uc.dbTransactionRepo.StartTransactionQuery(ctx)
+	if err != nil {
+		return fmt.Errorf("uc.dbTransactionRepo.StartTransactionQuery: %w", err)
+	}
+
+	// Create flag and defer rollback when flag is true
+	rollbackProcess := true
+	defer func() {
+		if rollbackProcess {
+			uc.dbTransactionRepo.RollbackTransactionQuery(ctx, tx)
+		}
+	}()
+
+	if err := save(ctx, tx, taxClass); err != nil {
+		if customErr, ok := err.(response.CustomError); ok {
+			return customErr
+		}
+		return fmt.Errorf("save: %w", err)
+	}
+
+	if err := uc.repo.ReplaceTaxRates(ctx, tx, taxClass); err != nil {
+		return fmt.Errorf("uc.repo.ReplaceTaxRates: %w", err)
+	}
+
+	if err := uc.repo.ReplaceCategoryTaxClasses(ctx, tx, taxClass); err != nil {
+		return fmt.Errorf("uc.repo.ReplaceCategoryTaxClasses: %w", err)
+	}
+
+	// Commit transaction
+	if err = uc.dbTransactionRepo.CommitTransactionQuery(ctx, tx); err != nil {
+		return fmt.Errorf("uc.dbTransactionRepo.CommitTransactionQuery: %w", err)
+	}
+	rollbackProcess = false
+
+	return nil
+}
+
+// attachTaxRates load the rates of the given tax classes
+func attachTaxRates(ctx context.Context, r repo.TaxClassRepositoryInterface, taxClasses []*entity.TaxClass) error {
+	if len(taxClasses) == 0 {
+		return nil
+	}
+
+	taxClassIDs := make([]int, 0, len(taxClasses))
+	taxClassByID := make(map[int]*entity.TaxClass, len(taxClasses))
+	for _, taxClass := range taxClasses {
+		taxClassIDs = append(taxClassIDs, taxClass.ID)
+		taxClassByID[taxClass.ID] = taxClass
+	}
+
+	taxRates, err := r.GetTaxRatesByTaxClassIDs(ctx, taxClassIDs)
+	if err != nil {
+		return fmt.Errorf("r.GetTaxRatesByTaxClassIDs: %w", err)
+	}
+
+	for _, taxRate := range taxRates {
+		if taxClass, ok := taxClassByID[taxRate.TaxClassID]; ok {
+			taxClass.Rates = append(taxClass.Rates, taxRate)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,305 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func validTaxClassPayload() *entity.TaxClassPayload {
+	return &entity.TaxClassPayload{
+		Name:       "standard",
+		Rates:      []entity.TaxRatePayload{{Region: "", Rate: 1000}, {Region: "id", Rate: 1100}},
+		Categories: []types.CategoryType{types.CategoryBookType},
+		Tenant:     types.TenantLoremType,
+	}
+}
+
+func TestCreateTaxClass(t *testing.T) {
+	testcases := []struct {
+		name             string
+		ctx              context.Context
+		payload          *entity.TaxClassPayload
+		rStartTrxErr     error
+		rCreateErr       error
+		rRatesErr        error
+		rCategoriesErr   error
+		rCommitTrxErr    error
+		wantErr          bool
+		wantCustomErr    error
+		wantRegionOfRate string
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "empty name",
+			ctx:           context.Background(),
+			payload:       &entity.TaxClassPayload{Rates: []entity.TaxRatePayload{{Rate: 1000}}, Tenant: types.TenantLoremType},
+			wantErr:       true,
+			wantCustomErr: response.ErrInvalidTaxClassName,
+		},
+		{
+			name:          "without rate",
+			ctx:           context.Background(),
+			payload:       &entity.TaxClassPayload{Name: "standard", Tenant: types.TenantLoremType},
+			wantErr:       true,
+			wantCustomErr: response.ErrInvalidTaxRate,
+		},
+		{
+			name:          "negative rate",
+			ctx:           context.Background(),
+			payload:       &entity.TaxClassPayload{Name: "standard", Rates: []entity.TaxRatePayload{{Rate: -1}}, Tenant: types.TenantLoremType},
+			wantErr:       true,
+			wantCustomErr: response.ErrInvalidTaxRate,
+		},
+		{
+			name:          "invalid region",
+			ctx:           context.Background(),
+			payload:       &entity.TaxClassPayload{Name: "standard", Rates: []entity.TaxRatePayload{{Region: "I D", Rate: 1000}}, Tenant: types.TenantLoremType},
+			wantErr:       true,
+			wantCustomErr: response.ErrInvalidRegion,
+		},
+		{
+			name:          "duplicate region",
+			ctx:           context.Background(),
+			payload:       &entity.TaxClassPayload{Name: "standard", Rates: []entity.TaxRatePayload{{Region: "ID", Rate: 1000}, {Region: "id", Rate: 1100}}, Tenant: types.TenantLoremType},
+			wantErr:       true,
+			wantCustomErr: response.ErrDuplicateTaxRateRegion,
+		},
+		{
+			name:         "failed to start transaction",
+			ctx:          context.Background(),
+			payload:      validTaxClassPayload(),
+			rStartTrxErr: response.ErrNoSQLTransactionFound,
+			wantErr:      true,
+		},
+		{
+			name:          "duplicate name",
+			ctx:           context.Background(),
+			payload:       validTaxClassPayload(),
+			rCreateErr:    response.ErrDuplicateTaxClassName,
+			wantErr:       true,
+			wantCustomErr: response.ErrDuplicateTaxClassName,
+		},
+		{
+			name:       "failed to create tax class",
+			ctx:        context.Background(),
+			payload:    validTaxClassPayload(),
+			rCreateErr: errors.New("error create tax class"),
+			wantErr:    true,
+		},
+		{
+			name:      "failed to replace tax rates",
+			ctx:       context.Background(),
+			payload:   validTaxClassPayload(),
+			rRatesErr: errors.New("error replace tax rates"),
+			wantErr:   true,
+		},
+		{
+			name:           "failed to replace category tax classes",
+			ctx:            context.Background(),
+			payload:        validTaxClassPayload(),
+			rCategoriesErr: errors.New("error replace category tax classes"),
+			wantErr:        true,
+		},
+		{
+			name:          "failed to commit transaction",
+			ctx:           context.Background(),
+			payload:       validTaxClassPayload(),
+			rCommitTrxErr: response.ErrNoSQLTransactionFound,
+			wantErr:       true,
+		},
+		{
+			name:             "success",
+			ctx:              context.Background(),
+			payload:          validTaxClassPayload(),
+			wantErr:          false,
+			wantRegionOfRate: "ID",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			taxClassRepo := &testmock.TaxClassRepositoryInterface{}
+			taxClassRepo.On("CreateTaxClass", mock.Anything, mock.Anything, mock.Anything).Return(tc.rCreateErr)
+			taxClassRepo.On("ReplaceTaxRates", mock.Anything, mock.Anything, mock.Anything).Return(tc.rRatesErr)
+			taxClassRepo.On("ReplaceCategoryTaxClasses", mock.Anything, mock.Anything, mock.Anything).Return(tc.rCategoriesErr)
+
+			dbTransactionRepo := &testmock.PostgresTransactionRepositoryInterface{}
+			dbTransactionRepo.On("StartTransactionQuery", mock.Anything).Return(&sqlx.Tx{}, tc.rStartTrxErr)
+			dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(tc.rCommitTrxErr)
+			dbTransactionRepo.On("RollbackTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+
+			uc := usecase.NewTaxClassUsecase(taxClassRepo, dbTransactionRepo)
+			res, err := uc.CreateTaxClass(tc.ctx, tc.payload)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, tc.wantCustomErr, err)
+			}
+			if !tc.wantErr {
+				assert.Equal(t, tc.wantRegionOfRate, res.Rates[1].Region)
+			}
+		})
+	}
+}
+
+func TestGetTaxClasses(t *testing.T) {
+	testcases := []struct {
+		name            string
+		ctx             context.Context
+		rTaxClassesErr  error
+		rTaxRatesErr    error
+		rCategoryTaxErr error
+		wantErr         bool
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:           "failed to get tax classes",
+			ctx:            context.Background(),
+			rTaxClassesErr: errors.New("error get tax classes"),
+			wantErr:        true,
+		},
+		{
+			name:         "failed to get tax rates",
+			ctx:          context.Background(),
+			rTaxRatesErr: errors.New("error get tax rates"),
+			wantErr:      true,
+		},
+		{
+			name:            "failed to get category tax classes",
+			ctx:             context.Background(),
+			rCategoryTaxErr: errors.New("error get category tax classes"),
+			wantErr:         true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			taxClassRepo := &testmock.TaxClassRepositoryInterface{}
+			taxClassRepo.On("GetTaxClasses", mock.Anything, mock.Anything).Return([]*entity.TaxClass{{ID: 1}, {ID: 2}}, tc.rTaxClassesErr)
+			taxClassRepo.On("GetTaxRatesByTaxClassIDs", mock.Anything, mock.Anything).Return([]*entity.TaxRate{{TaxClassID: 1, Rate: 1100}}, tc.rTaxRatesErr)
+			taxClassRepo.On("GetCategoryTaxClasses", mock.Anything, mock.Anything).Return([]*entity.CategoryTaxClass{{Category: types.CategoryBookType, TaxClassID: 2}}, tc.rCategoryTaxErr)
+
+			uc := usecase.NewTaxClassUsecase(taxClassRepo, &testmock.PostgresTransactionRepositoryInterface{})
+			res, err := uc.GetTaxClasses(tc.ctx, types.TenantLoremType)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if !tc.wantErr {
+				assert.Equal(t, 1, len(res[0].Rates))
+				assert.Equal(t, 0, len(res[1].Rates))
+				assert.Equal(t, 0, len(res[0].Categories))
+				assert.Equal(t, []types.CategoryType{types.CategoryBookType}, res[1].Categories)
+			}
+		})
+	}
+}
+
+func TestUpdateTaxClass(t *testing.T) {
+	testcases := []struct {
+		name            string
+		ctx             context.Context
+		payload         *entity.TaxClassPayload
+		rTaxClassRes    *entity.TaxClass
+		rTaxClassErr    error
+		rUpdateErr      error
+		wantErr         bool
+		wantCustomError error
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:            "invalid payload",
+			ctx:             context.Background(),
+			payload:         &entity.TaxClassPayload{},
+			wantErr:         true,
+			wantCustomError: response.ErrInvalidTaxClassName,
+		},
+		{
+			name:            "tax class is not found",
+			ctx:             context.Background(),
+			payload:         validTaxClassPayload(),
+			rTaxClassErr:    response.ErrNotFound,
+			wantErr:         true,
+			wantCustomError: response.ErrNotFound,
+		},
+		{
+			name:         "failed to get tax class",
+			ctx:          context.Background(),
+			payload:      validTaxClassPayload(),
+			rTaxClassErr: errors.New("error get tax class"),
+			wantErr:      true,
+		},
+		{
+			name:            "forbidden",
+			ctx:             context.Background(),
+			payload:         validTaxClassPayload(),
+			rTaxClassRes:    &entity.TaxClass{ID: 1, Tenant: types.TenantIpsumType},
+			wantErr:         true,
+			wantCustomError: response.ErrForbidden,
+		},
+		{
+			name:         "failed to update tax class",
+			ctx:          context.Background(),
+			payload:      validTaxClassPayload(),
+			rTaxClassRes: &entity.TaxClass{ID: 1, Tenant: types.TenantLoremType},
+			rUpdateErr:   errors.New("error update tax class"),
+			wantErr:      true,
+		},
+		{
+			name:         "success",
+			ctx:          context.Background(),
+			payload:      validTaxClassPayload(),
+			rTaxClassRes: &entity.TaxClass{ID: 1, Tenant: types.TenantLoremType},
+			wantErr:      false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			taxClassRepo := &testmock.TaxClassRepositoryInterface{}
+			taxClassRepo.On("GetTaxClassByID", mock.Anything, mock.Anything).Return(tc.rTaxClassRes, tc.rTaxClassErr)
+			taxClassRepo.On("UpdateTaxClass", mock.Anything, mock.Anything, mock.Anything).Return(tc.rUpdateErr)
+			taxClassRepo.On("ReplaceTaxRates", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			taxClassRepo.On("ReplaceCategoryTaxClasses", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			dbTransactionRepo := &testmock.PostgresTransactionRepositoryInterface{}
+			dbTransactionRepo.On("StartTransactionQuery", mock.Anything).Return(&sqlx.Tx{}, nil)
+			dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+			dbTransactionRepo.On("RollbackTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+
+			uc := usecase.NewTaxClassUsecase(taxClassRepo, dbTransactionRepo)
+			res, err := uc.UpdateTaxClass(tc.ctx, 1, tc.payload)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustomError != nil {
+				assert.Equal(t, tc.wantCustomError, err)
+			}
+			if !tc.wantErr {
+				assert.Equal(t, 1, res.ID)
+			}
+		})
+	}
+}
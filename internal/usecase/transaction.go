@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	repo "github.com/satriowisnugroho/catalog/internal/repository/postgres"
+)
+
+const (
+	// maxTransactionAttempts is the max number of times a transaction is run when it keeps conflicting with concurrent ones
+	maxTransactionAttempts = 10
+	// transactionRetryDelay is the base of the random delay before a conflicted transaction is run again
+	transactionRetryDelay = 10 * time.Millisecond
+)
+
+// retryTransaction run the process again when its transaction conflicts with a concurrent transaction.
+// The delay before each attempt is random and grows with the attempts so that the conflicted transactions do not collide again
+func retryTransaction(ctx context.Context, process func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := process()
+		if err == nil || attempt >= maxTransactionAttempts || !repo.IsTransactionConflict(err) {
+			return err
+		}
+
+		delay := time.Duration(rand.Int63n(int64(transactionRetryDelay) * int64(attempt)))
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
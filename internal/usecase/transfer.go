@@ -0,0 +1,415 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/helper"
+	repo "github.com/satriowisnugroho/catalog/internal/repository/postgres"
+	"github.com/satriowisnugroho/catalog/internal/response"
+)
+
+// TransferUsecaseInterface define contract for transfer related functions to usecase
+type TransferUsecaseInterface interface {
+	CreateTransfer(ctx context.Context, payload *entity.TransferPayload) (*entity.Transfer, error)
+	GetTransferByID(ctx context.Context, tenant types.TenantType, transferID int) (*entity.Transfer, error)
+	DispatchTransfer(ctx context.Context, tenant types.TenantType, transferID int, actor string) (*entity.Transfer, error)
+	ReceiveTransfer(ctx context.Context, tenant types.TenantType, transferID int, actor string) (*entity.Transfer, error)
+}
+
+type TransferUsecase struct {
+	repo               repo.TransferRepositoryInterface
+	productRepo        repo.ProductRepositoryInterface
+	locationRepo       repo.LocationRepositoryInterface
+	productStockRepo   repo.ProductStockRepositoryInterface
+	stockMovementRepo  repo.StockMovementRepositoryInterface
+	lowStockRepo       repo.LowStockRepositoryInterface
+	dbTransactionRepo  repo.PostgresTransactionRepositoryInterface
+	allocationStrategy types.AllocationStrategyType
+}
+
+func NewTransferUsecase(
+	r repo.TransferRepositoryInterface,
+	rProduct repo.ProductRepositoryInterface,
+	rLocation repo.LocationRepositoryInterface,
+	rProductStock repo.ProductStockRepositoryInterface,
+	rStockMovement repo.StockMovementRepositoryInterface,
+	rLowStock repo.LowStockRepositoryInterface,
+	rPgTrx repo.PostgresTransactionRepositoryInterface,
+	allocationStrategy types.AllocationStrategyType,
+) *TransferUsecase {
+	return &TransferUsecase{
+		repo:               r,
+		productRepo:        rProduct,
+		locationRepo:       rLocation,
+		productStockRepo:   rProductStock,
+		stockMovementRepo:  rStockMovement,
+		lowStockRepo:       rLowStock,
+		dbTransactionRepo:  rPgTrx,
+		allocationStrategy: allocationStrategy,
+	}
+}
+
+// CreateTransfer save a draft transfer of the products between two locations of the tenant,
+// the qty of the products is not changed until the transfer is dispatched
+func (uc *TransferUsecase) CreateTransfer(ctx context.Context, payload *entity.TransferPayload) (*entity.Transfer, error) {
+	functionName := "TransferUsecase.CreateTransfer"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	for _, locationID := range []int{payload.FromLocationID, payload.ToLocationID} {
+		if err := validateLocation(ctx, uc.locationRepo, payload.Tenant, locationID); err != nil {
+			if customErr, ok := err.(response.CustomError); ok {
+				return nil, customErr
+			}
+
+			return nil, errors.Wrap(err, functionName)
+		}
+	}
+
+	transfer := payload.ToEntity()
+	for _, item := range transfer.Items {
+		product, err := uc.productRepo.GetProductBySKU(ctx, item.SKU)
+		if err != nil {
+			if err == response.ErrNotFound {
+				return nil, err
+			}
+
+			return nil, errors.Wrap(fmt.Errorf("uc.productRepo.GetProductBySKU: %w", err), functionName)
+		}
+
+		if product.Tenant != transfer.Tenant {
+			return nil, response.ErrForbidden
+		}
+
+		item.ProductID = product.ID
+	}
+
+	// Begin transaction
+	tx, err := uc.dbTransactionRepo.StartTransactionQuery(ctx)
+	if err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.dbTransactionRepo.StartTransactionQuery: %w", err), functionName)
+	}
+
+	// Create flag and defer rollback when flag is true
+	rollbackProcess := true
+	defer func() {
+		if rollbackProcess {
+			uc.dbTransactionRepo.RollbackTransactionQuery(ctx, tx)
+		}
+	}()
+
+	if err := uc.repo.CreateTransfer(ctx, tx, transfer); err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.CreateTransfer: %w", err), functionName)
+	}
+
+	if err := uc.repo.CreateTransferItems(ctx, tx, transfer); err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.CreateTransferItems: %w", err), functionName)
+	}
+
+	// Commit transaction
+	if err = uc.dbTransactionRepo.CommitTransactionQuery(ctx, tx); err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.dbTransactionRepo.CommitTransactionQuery: %w", err), functionName)
+	}
+	rollbackProcess = false
+
+	return transfer, nil
+}
+
+func (uc *TransferUsecase) GetTransferByID(ctx context.Context, tenant types.TenantType, transferID int) (*entity.Transfer, error) {
+	functionName := "TransferUsecase.GetTransferByID"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return uc.getTransfer(ctx, tenant, transferID)
+}
+
+// DispatchTransfer move a draft transfer to in transit, the qty of its items is reduced from the source location
+// and kept as in transit qty of the products until the transfer is received
+func (uc *TransferUsecase) DispatchTransfer(ctx context.Context, tenant types.TenantType, transferID int, actor string) (*entity.Transfer, error) {
+	functionName := "TransferUsecase.DispatchTransfer"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	transfer, err := uc.getTransfer(ctx, tenant, transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.Status != types.TransferStatusDraftType {
+		return nil, response.ErrInvalidTransferStatus
+	}
+
+	if err := retryTransaction(ctx, func() error { return uc.dispatchTransfer(ctx, transfer, actor) }); err != nil {
+		if err == response.ErrNotFound {
+			return nil, response.ErrInvalidTransferStatus
+		}
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return transfer, nil
+}
+
+// dispatchTransfer move a draft transfer to in transit, reduce the qty of its items from the source location
+// and record them on the stock movements in one transaction
+func (uc *TransferUsecase) dispatchTransfer(ctx context.Context, transfer *entity.Transfer, actor string) error {
+	// Begin transaction
+	tx, err := uc.dbTransactionRepo.StartTransactionQuery(ctx)
+	if err != nil {
+		return fmt.Errorf("uc.dbTransactionRepo.StartTransactionQuery: %w", err)
+	}
+
+	// Create flag and defer rollback when flag is true
+	rollbackProcess := true
+	defer func() {
+		if rollbackProcess {
+			uc.dbTransactionRepo.RollbackTransactionQuery(ctx, tx)
+		}
+	}()
+
+	now := time.Now()
+	transfer.Status = types.TransferStatusInTransitType
+	transfer.DispatchedAt = &now
+	if err := uc.repo.UpdateTransferStatus(ctx, tx, transfer, types.TransferStatusDraftType); err != nil {
+		if err == response.ErrNotFound {
+			return err
+		}
+
+		return fmt.Errorf("uc.repo.UpdateTransferStatus: %w", err)
+	}
+
+	items := transfer.ItemsByProductID()
+	productByID, err := uc.lockProducts(ctx, tx, transfer.Tenant, items)
+	if err != nil {
+		return err
+	}
+
+	// The lots and serial numbers belong to the product rather than a location, so they are kept as they are
+	referenceID := fmt.Sprintf("transfer:%d", transfer.ID)
+	movements := make([]*entity.StockMovement, 0, len(items))
+	for _, item := range items {
+		product, ok := productByID[item.ProductID]
+		if !ok {
+			return fmt.Errorf("product %d of the transfer is not found", item.ProductID)
+		}
+
+		// The qty held by reservations, backorders or expired lots is not transferred
+		product.ShowAvailableQty()
+		if product.AvailableQty < item.Qty {
+			return response.ErrInsufficientStock
+		}
+
+		stocks, err := uc.productStockRepo.GetProductStocksForUpdate(ctx, tx, product.ID)
+		if err != nil {
+			return fmt.Errorf("uc.productStockRepo.GetProductStocksForUpdate: %w", err)
+		}
+
+		if err := allocateStocks(ctx, uc.productStockRepo, tx, stocks, item.Qty, &transfer.FromLocationID, uc.allocationStrategy); err != nil {
+			return err
+		}
+		product.Qty = entity.TotalStock(stocks)
+		product.InTransitQty += item.Qty
+
+		movements = append(movements, entity.NewStockMovement(product, -item.Qty, types.StockMovementReasonTransferType, actor, referenceID))
+
+		if err := uc.productRepo.UpdateProductQty(ctx, tx, product); err != nil {
+			return fmt.Errorf("uc.productRepo.UpdateProductQty: %w", err)
+		}
+
+		if err := recordLowStock(ctx, uc.productRepo, uc.lowStockRepo, tx, product); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.stockMovementRepo.CreateStockMovements(ctx, tx, movements); err != nil {
+		return fmt.Errorf("uc.stockMovementRepo.CreateStockMovements: %w", err)
+	}
+
+	// Commit transaction
+	if err = uc.dbTransactionRepo.CommitTransactionQuery(ctx, tx); err != nil {
+		return fmt.Errorf("uc.dbTransactionRepo.CommitTransactionQuery: %w", err)
+	}
+	rollbackProcess = false
+
+	return nil
+}
+
+// ReceiveTransfer move an in transit transfer to received, the in transit qty of its items is added to the destination location
+// and fills the open backorders of the products
+func (uc *TransferUsecase) ReceiveTransfer(ctx context.Context, tenant types.TenantType, transferID int, actor string) (*entity.Transfer, error) {
+	functionName := "TransferUsecase.ReceiveTransfer"
+
+	if err := helper.CheckDeadline(ctx); err != nil {
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	transfer, err := uc.getTransfer(ctx, tenant, transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.Status != types.TransferStatusInTransitType {
+		return nil, response.ErrInvalidTransferStatus
+	}
+
+	if err := retryTransaction(ctx, func() error { return uc.receiveTransfer(ctx, transfer, actor) }); err != nil {
+		if err == response.ErrNotFound {
+			return nil, response.ErrInvalidTransferStatus
+		}
+		if customErr, ok := err.(response.CustomError); ok {
+			return nil, customErr
+		}
+		return nil, errors.Wrap(err, functionName)
+	}
+
+	return transfer, nil
+}
+
+// receiveTransfer move an in transit transfer to received, add the qty of its items to the destination location
+// and record them on the stock movements in one transaction
+func (uc *TransferUsecase) receiveTransfer(ctx context.Context, transfer *entity.Transfer, actor string) error {
+	// Begin transaction
+	tx, err := uc.dbTransactionRepo.StartTransactionQuery(ctx)
+	if err != nil {
+		return fmt.Errorf("uc.dbTransactionRepo.StartTransactionQuery: %w", err)
+	}
+
+	// Create flag and defer rollback when flag is true
+	rollbackProcess := true
+	defer func() {
+		if rollbackProcess {
+			uc.dbTransactionRepo.RollbackTransactionQuery(ctx, tx)
+		}
+	}()
+
+	now := time.Now()
+	transfer.Status = types.TransferStatusReceivedType
+	transfer.ReceivedAt = &now
+	if err := uc.repo.UpdateTransferStatus(ctx, tx, transfer, types.TransferStatusInTransitType); err != nil {
+		if err == response.ErrNotFound {
+			return err
+		}
+
+		return fmt.Errorf("uc.repo.UpdateTransferStatus: %w", err)
+	}
+
+	items := transfer.ItemsByProductID()
+	productByID, err := uc.lockProducts(ctx, tx, transfer.Tenant, items)
+	if err != nil {
+		return err
+	}
+
+	referenceID := fmt.Sprintf("transfer:%d", transfer.ID)
+	movements := make([]*entity.StockMovement, 0, len(items))
+	for _, item := range items {
+		product, ok := productByID[item.ProductID]
+		if !ok {
+			return fmt.Errorf("product %d of the transfer is not found", item.ProductID)
+		}
+
+		stocks, err := uc.productStockRepo.GetProductStocksForUpdate(ctx, tx, product.ID)
+		if err != nil {
+			return fmt.Errorf("uc.productStockRepo.GetProductStocksForUpdate: %w", err)
+		}
+
+		stocks, err = addStocks(ctx, uc.locationRepo, uc.productStockRepo, tx, product, stocks, item.Qty, &transfer.ToLocationID)
+		if err != nil {
+			return err
+		}
+		product.Qty = entity.TotalStock(stocks)
+		product.InTransitQty -= item.Qty
+
+		movements = append(movements, entity.NewStockMovement(product, item.Qty, types.StockMovementReasonTransferType, actor, referenceID))
+
+		movement, err := fillBackorders(ctx, uc.productStockRepo, tx, product, item.Qty, &transfer.ToLocationID, uc.allocationStrategy, actor, referenceID)
+		if err != nil {
+			return err
+		}
+		if movement != nil {
+			movements = append(movements, movement)
+		}
+
+		if err := uc.productRepo.UpdateProductQty(ctx, tx, product); err != nil {
+			return fmt.Errorf("uc.productRepo.UpdateProductQty: %w", err)
+		}
+
+		if err := recordLowStock(ctx, uc.productRepo, uc.lowStockRepo, tx, product); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.stockMovementRepo.CreateStockMovements(ctx, tx, movements); err != nil {
+		return fmt.Errorf("uc.stockMovementRepo.CreateStockMovements: %w", err)
+	}
+
+	// Commit transaction
+	if err = uc.dbTransactionRepo.CommitTransactionQuery(ctx, tx); err != nil {
+		return fmt.Errorf("uc.dbTransactionRepo.CommitTransactionQuery: %w", err)
+	}
+	rollbackProcess = false
+
+	return nil
+}
+
+// lockProducts lock the products of the items in the order of the items and return them by their id,
+// the products are read after they are locked so that the qty is changed on their latest qty
+func (uc *TransferUsecase) lockProducts(ctx context.Context, tx interface{}, tenant types.TenantType, items []*entity.TransferItem) (map[int]*entity.Product, error) {
+	productIDs := make([]int, 0, len(items))
+	for _, item := range items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+
+	products, err := uc.productRepo.GetProductsForUpdate(ctx, tx, tenant, productIDs, nil)
+	if err != nil {
+		return nil, fmt.Errorf("uc.productRepo.GetProductsForUpdate: %w", err)
+	}
+
+	productByID := make(map[int]*entity.Product, len(products))
+	for _, product := range products {
+		productByID[product.ID] = product
+	}
+
+	return productByID, nil
+}
+
+func (uc *TransferUsecase) getTransfer(ctx context.Context, tenant types.TenantType, transferID int) (*entity.Transfer, error) {
+	functionName := "TransferUsecase.getTransfer"
+
+	transfer, err := uc.repo.GetTransferByID(ctx, transferID)
+	if err != nil {
+		if err == response.ErrNotFound {
+			return nil, err
+		}
+
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.GetTransferByID: %w", err), functionName)
+	}
+
+	if transfer.Tenant != tenant {
+		return nil, response.ErrForbidden
+	}
+
+	items, err := uc.repo.GetTransferItemsByTransferIDs(ctx, []int{transfer.ID})
+	if err != nil {
+		return nil, errors.Wrap(fmt.Errorf("uc.repo.GetTransferItemsByTransferIDs: %w", err), functionName)
+	}
+	transfer.Items = items
+
+	return transfer, nil
+}
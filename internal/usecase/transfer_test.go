@@ -0,0 +1,613 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/satriowisnugroho/catalog/internal/entity"
+	"github.com/satriowisnugroho/catalog/internal/entity/types"
+	"github.com/satriowisnugroho/catalog/internal/response"
+	"github.com/satriowisnugroho/catalog/internal/usecase"
+	"github.com/satriowisnugroho/catalog/test/fixture"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func validTransferPayload() *entity.TransferPayload {
+	return &entity.TransferPayload{
+		FromLocationID: 1,
+		ToLocationID:   2,
+		Items:          []entity.TransferItemPayload{{SKU: "SKU-1", Qty: 2}},
+		Tenant:         types.TenantLoremType,
+	}
+}
+
+func transferWithStatus(status types.TransferStatusType) *entity.Transfer {
+	return &entity.Transfer{
+		ID:             1,
+		Tenant:         types.TenantLoremType,
+		FromLocationID: 1,
+		ToLocationID:   2,
+		Status:         status,
+		Items:          []*entity.TransferItem{},
+	}
+}
+
+func TestCreateTransfer(t *testing.T) {
+	testcases := []struct {
+		name            string
+		ctx             context.Context
+		payload         *entity.TransferPayload
+		rGetLocationRes *entity.Location
+		rGetLocationErr error
+		rGetProductRes  *entity.Product
+		rGetProductErr  error
+		rStartTrxErr    error
+		rCreateErr      error
+		rCreateItemErr  error
+		rCommitTrxErr   error
+		wantErr         bool
+		wantCustomErr   error
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "invalid payload",
+			ctx:           context.Background(),
+			payload:       &entity.TransferPayload{FromLocationID: 1, ToLocationID: 1, Tenant: types.TenantLoremType},
+			wantErr:       true,
+			wantCustomErr: response.ErrInvalidLocation,
+		},
+		{
+			name:            "location not found",
+			ctx:             context.Background(),
+			payload:         validTransferPayload(),
+			rGetLocationErr: response.ErrNotFound,
+			wantErr:         true,
+			wantCustomErr:   response.ErrInvalidLocation,
+		},
+		{
+			name:            "failed to get location",
+			ctx:             context.Background(),
+			payload:         validTransferPayload(),
+			rGetLocationErr: errors.New("error get location"),
+			wantErr:         true,
+		},
+		{
+			name:            "location of other tenant",
+			ctx:             context.Background(),
+			payload:         validTransferPayload(),
+			rGetLocationRes: &entity.Location{ID: 1, Tenant: types.TenantIpsumType},
+			wantErr:         true,
+			wantCustomErr:   response.ErrInvalidLocation,
+		},
+		{
+			name:            "product not found",
+			ctx:             context.Background(),
+			payload:         validTransferPayload(),
+			rGetLocationRes: &entity.Location{ID: 1, Tenant: types.TenantLoremType},
+			rGetProductErr:  response.ErrNotFound,
+			wantErr:         true,
+			wantCustomErr:   response.ErrNotFound,
+		},
+		{
+			name:            "failed to get product",
+			ctx:             context.Background(),
+			payload:         validTransferPayload(),
+			rGetLocationRes: &entity.Location{ID: 1, Tenant: types.TenantLoremType},
+			rGetProductErr:  errors.New("error get product"),
+			wantErr:         true,
+		},
+		{
+			name:            "product of other tenant",
+			ctx:             context.Background(),
+			payload:         validTransferPayload(),
+			rGetLocationRes: &entity.Location{ID: 1, Tenant: types.TenantLoremType},
+			rGetProductRes:  &entity.Product{ID: 1, Tenant: types.TenantIpsumType},
+			wantErr:         true,
+			wantCustomErr:   response.ErrForbidden,
+		},
+		{
+			name:            "failed to start transaction",
+			ctx:             context.Background(),
+			payload:         validTransferPayload(),
+			rGetLocationRes: &entity.Location{ID: 1, Tenant: types.TenantLoremType},
+			rGetProductRes:  &entity.Product{ID: 1, Tenant: types.TenantLoremType},
+			rStartTrxErr:    response.ErrNoSQLTransactionFound,
+			wantErr:         true,
+		},
+		{
+			name:            "failed to create transfer",
+			ctx:             context.Background(),
+			payload:         validTransferPayload(),
+			rGetLocationRes: &entity.Location{ID: 1, Tenant: types.TenantLoremType},
+			rGetProductRes:  &entity.Product{ID: 1, Tenant: types.TenantLoremType},
+			rCreateErr:      errors.New("error create transfer"),
+			wantErr:         true,
+		},
+		{
+			name:            "failed to create transfer items",
+			ctx:             context.Background(),
+			payload:         validTransferPayload(),
+			rGetLocationRes: &entity.Location{ID: 1, Tenant: types.TenantLoremType},
+			rGetProductRes:  &entity.Product{ID: 1, Tenant: types.TenantLoremType},
+			rCreateItemErr:  errors.New("error create transfer items"),
+			wantErr:         true,
+		},
+		{
+			name:            "failed to commit transaction",
+			ctx:             context.Background(),
+			payload:         validTransferPayload(),
+			rGetLocationRes: &entity.Location{ID: 1, Tenant: types.TenantLoremType},
+			rGetProductRes:  &entity.Product{ID: 1, Tenant: types.TenantLoremType},
+			rCommitTrxErr:   response.ErrNoSQLTransactionFound,
+			wantErr:         true,
+		},
+		{
+			name:            "success",
+			ctx:             context.Background(),
+			payload:         validTransferPayload(),
+			rGetLocationRes: &entity.Location{ID: 1, Tenant: types.TenantLoremType},
+			rGetProductRes:  &entity.Product{ID: 1, Tenant: types.TenantLoremType},
+			wantErr:         false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			transferRepo := &testmock.TransferRepositoryInterface{}
+			transferRepo.On("CreateTransfer", mock.Anything, mock.Anything, mock.Anything).Return(tc.rCreateErr)
+			transferRepo.On("CreateTransferItems", mock.Anything, mock.Anything, mock.Anything).Return(tc.rCreateItemErr)
+
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("GetProductBySKU", mock.Anything, "SKU-1").Return(tc.rGetProductRes, tc.rGetProductErr)
+
+			locationRepo := &testmock.LocationRepositoryInterface{}
+			locationRepo.On("GetLocationByID", mock.Anything, mock.Anything).Return(tc.rGetLocationRes, tc.rGetLocationErr)
+
+			dbTransactionRepo := &testmock.PostgresTransactionRepositoryInterface{}
+			dbTransactionRepo.On("StartTransactionQuery", mock.Anything).Return(&sqlx.Tx{}, tc.rStartTrxErr)
+			dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(tc.rCommitTrxErr)
+			dbTransactionRepo.On("RollbackTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+
+			uc := usecase.NewTransferUsecase(transferRepo, productRepo, locationRepo, &testmock.ProductStockRepositoryInterface{}, &testmock.StockMovementRepositoryInterface{}, &testmock.LowStockRepositoryInterface{}, dbTransactionRepo, types.AllocationStrategyPriorityType)
+			res, err := uc.CreateTransfer(tc.ctx, tc.payload)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, tc.wantCustomErr, err)
+			}
+			if !tc.wantErr {
+				assert.Equal(t, types.TransferStatusDraftType, res.Status)
+				assert.Equal(t, []*entity.TransferItem{{ProductID: 1, SKU: "SKU-1", Qty: 2}}, res.Items)
+			}
+		})
+	}
+}
+
+func TestGetTransferByID(t *testing.T) {
+	testcases := []struct {
+		name          string
+		ctx           context.Context
+		rGetRes       *entity.Transfer
+		rGetErr       error
+		rGetItemsErr  error
+		wantErr       bool
+		wantCustomErr error
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "transfer not found",
+			ctx:           context.Background(),
+			rGetErr:       response.ErrNotFound,
+			wantErr:       true,
+			wantCustomErr: response.ErrNotFound,
+		},
+		{
+			name:    "failed to get transfer",
+			ctx:     context.Background(),
+			rGetErr: errors.New("error get transfer"),
+			wantErr: true,
+		},
+		{
+			name:          "transfer of other tenant",
+			ctx:           context.Background(),
+			rGetRes:       &entity.Transfer{ID: 1, Tenant: types.TenantIpsumType},
+			wantErr:       true,
+			wantCustomErr: response.ErrForbidden,
+		},
+		{
+			name:         "failed to get transfer items",
+			ctx:          context.Background(),
+			rGetRes:      transferWithStatus(types.TransferStatusDraftType),
+			rGetItemsErr: errors.New("error get transfer items"),
+			wantErr:      true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			rGetRes: transferWithStatus(types.TransferStatusDraftType),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			items := []*entity.TransferItem{{TransferID: 1, ProductID: 1, SKU: "SKU-1", Qty: 2}}
+
+			transferRepo := &testmock.TransferRepositoryInterface{}
+			transferRepo.On("GetTransferByID", mock.Anything, 1).Return(tc.rGetRes, tc.rGetErr)
+			transferRepo.On("GetTransferItemsByTransferIDs", mock.Anything, []int{1}).Return(items, tc.rGetItemsErr)
+
+			uc := usecase.NewTransferUsecase(transferRepo, &testmock.ProductRepositoryInterface{}, &testmock.LocationRepositoryInterface{}, &testmock.ProductStockRepositoryInterface{}, &testmock.StockMovementRepositoryInterface{}, &testmock.LowStockRepositoryInterface{}, &testmock.PostgresTransactionRepositoryInterface{}, types.AllocationStrategyPriorityType)
+			res, err := uc.GetTransferByID(tc.ctx, types.TenantLoremType, 1)
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, tc.wantCustomErr, err)
+			}
+			if !tc.wantErr {
+				assert.Equal(t, items, res.Items)
+			}
+		})
+	}
+}
+
+func TestDispatchTransfer(t *testing.T) {
+	testcases := []struct {
+		name              string
+		ctx               context.Context
+		rGetRes           *entity.Transfer
+		rGetErr           error
+		rStartTrxErr      error
+		rUpdateStatusErr  error
+		rGetProductErr    error
+		reservedQty       int
+		rStocksRes        []*entity.ProductStock
+		rStocksErr        error
+		rUpsertStocksErr  error
+		rUpdateProductErr error
+		rStockMovementErr error
+		rCommitTrxErr     error
+		wantErr           bool
+		wantCustomErr     error
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "transfer not found",
+			ctx:           context.Background(),
+			rGetErr:       response.ErrNotFound,
+			wantErr:       true,
+			wantCustomErr: response.ErrNotFound,
+		},
+		{
+			name:          "transfer already dispatched",
+			ctx:           context.Background(),
+			rGetRes:       transferWithStatus(types.TransferStatusInTransitType),
+			wantErr:       true,
+			wantCustomErr: response.ErrInvalidTransferStatus,
+		},
+		{
+			name:         "failed to start transaction",
+			ctx:          context.Background(),
+			rGetRes:      transferWithStatus(types.TransferStatusDraftType),
+			rStartTrxErr: response.ErrNoSQLTransactionFound,
+			wantErr:      true,
+		},
+		{
+			name:             "transfer dispatched by other request",
+			ctx:              context.Background(),
+			rGetRes:          transferWithStatus(types.TransferStatusDraftType),
+			rUpdateStatusErr: response.ErrNotFound,
+			wantErr:          true,
+			wantCustomErr:    response.ErrInvalidTransferStatus,
+		},
+		{
+			name:             "failed to update transfer status",
+			ctx:              context.Background(),
+			rGetRes:          transferWithStatus(types.TransferStatusDraftType),
+			rUpdateStatusErr: errors.New("error update transfer status"),
+			wantErr:          true,
+		},
+		{
+			name:           "failed to get products",
+			ctx:            context.Background(),
+			rGetRes:        transferWithStatus(types.TransferStatusDraftType),
+			rGetProductErr: errors.New("error get products"),
+			wantErr:        true,
+		},
+		{
+			name:          "qty held by reservations",
+			ctx:           context.Background(),
+			rGetRes:       transferWithStatus(types.TransferStatusDraftType),
+			reservedQty:   4,
+			wantErr:       true,
+			wantCustomErr: response.ErrInsufficientStock,
+		},
+		{
+			name:       "failed to get product stocks",
+			ctx:        context.Background(),
+			rGetRes:    transferWithStatus(types.TransferStatusDraftType),
+			rStocksErr: errors.New("error get product stocks"),
+			wantErr:    true,
+		},
+		{
+			name:          "insufficient stock on source location",
+			ctx:           context.Background(),
+			rGetRes:       transferWithStatus(types.TransferStatusDraftType),
+			rStocksRes:    []*entity.ProductStock{{LocationID: 1, Qty: 1}, {LocationID: 2, Qty: 4}},
+			wantErr:       true,
+			wantCustomErr: response.ErrInsufficientStock,
+		},
+		{
+			name:             "failed to upsert product stocks",
+			ctx:              context.Background(),
+			rGetRes:          transferWithStatus(types.TransferStatusDraftType),
+			rStocksRes:       []*entity.ProductStock{{LocationID: 1, Qty: 5}},
+			rUpsertStocksErr: errors.New("error upsert product stocks"),
+			wantErr:          true,
+		},
+		{
+			name:              "failed to update product",
+			ctx:               context.Background(),
+			rGetRes:           transferWithStatus(types.TransferStatusDraftType),
+			rStocksRes:        []*entity.ProductStock{{LocationID: 1, Qty: 5}},
+			rUpdateProductErr: errors.New("error update product"),
+			wantErr:           true,
+		},
+		{
+			name:              "failed to create stock movements",
+			ctx:               context.Background(),
+			rGetRes:           transferWithStatus(types.TransferStatusDraftType),
+			rStocksRes:        []*entity.ProductStock{{LocationID: 1, Qty: 5}},
+			rStockMovementErr: errors.New("error create stock movements"),
+			wantErr:           true,
+		},
+		{
+			name:          "failed to commit transaction",
+			ctx:           context.Background(),
+			rGetRes:       transferWithStatus(types.TransferStatusDraftType),
+			rStocksRes:    []*entity.ProductStock{{LocationID: 1, Qty: 5}},
+			rCommitTrxErr: response.ErrNoSQLTransactionFound,
+			wantErr:       true,
+		},
+		{
+			name:       "success",
+			ctx:        context.Background(),
+			rGetRes:    transferWithStatus(types.TransferStatusDraftType),
+			rStocksRes: []*entity.ProductStock{{LocationID: 1, Qty: 5}},
+			wantErr:    false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			items := []*entity.TransferItem{{TransferID: 1, ProductID: 1, SKU: "SKU-1", Qty: 2}}
+			product := &entity.Product{ID: 1, Tenant: types.TenantLoremType, Qty: 5, ReservedQty: tc.reservedQty}
+
+			transferRepo := &testmock.TransferRepositoryInterface{}
+			transferRepo.On("GetTransferByID", mock.Anything, 1).Return(tc.rGetRes, tc.rGetErr)
+			transferRepo.On("GetTransferItemsByTransferIDs", mock.Anything, []int{1}).Return(items, nil)
+			transferRepo.On("UpdateTransferStatus", mock.Anything, mock.Anything, mock.Anything, types.TransferStatusDraftType).Return(tc.rUpdateStatusErr)
+
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("GetProductsForUpdate", mock.Anything, mock.Anything, mock.Anything, []int{1}, []string(nil)).Return([]*entity.Product{product}, tc.rGetProductErr)
+			productRepo.On("UpdateProductQty", mock.Anything, mock.Anything, mock.Anything).Return(tc.rUpdateProductErr)
+
+			productStockRepo := &testmock.ProductStockRepositoryInterface{}
+			productStockRepo.On("GetProductStocksForUpdate", mock.Anything, mock.Anything, 1).Return(tc.rStocksRes, tc.rStocksErr)
+			productStockRepo.On("UpsertProductStocks", mock.Anything, mock.Anything, mock.Anything).Return(tc.rUpsertStocksErr)
+
+			dbTransactionRepo := &testmock.PostgresTransactionRepositoryInterface{}
+			dbTransactionRepo.On("StartTransactionQuery", mock.Anything).Return(&sqlx.Tx{}, tc.rStartTrxErr)
+			dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(tc.rCommitTrxErr)
+			dbTransactionRepo.On("RollbackTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+
+			stockMovementRepo := &testmock.StockMovementRepositoryInterface{}
+			stockMovementRepo.On("CreateStockMovements", mock.Anything, mock.Anything, mock.Anything).Return(tc.rStockMovementErr)
+
+			lowStockRepo := &testmock.LowStockRepositoryInterface{}
+			lowStockRepo.On("GetLowStockThreshold", mock.Anything, mock.Anything).Return(nil, response.ErrNotFound)
+
+			uc := usecase.NewTransferUsecase(transferRepo, productRepo, &testmock.LocationRepositoryInterface{}, productStockRepo, stockMovementRepo, lowStockRepo, dbTransactionRepo, types.AllocationStrategyPriorityType)
+			res, err := uc.DispatchTransfer(tc.ctx, types.TenantLoremType, 1, "jane")
+			assert.Equal(t, tc.wantErr, err != nil)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, tc.wantCustomErr, err)
+			}
+			if !tc.wantErr {
+				assert.Equal(t, types.TransferStatusInTransitType, res.Status)
+				assert.NotNil(t, res.DispatchedAt)
+				assert.Equal(t, 3, product.Qty)
+				assert.Equal(t, 2, product.InTransitQty)
+				productStockRepo.AssertCalled(t, "UpsertProductStocks", mock.Anything, mock.Anything, []*entity.ProductStock{{LocationID: 1, Qty: 3}})
+				stockMovementRepo.AssertCalled(t, "CreateStockMovements", mock.Anything, mock.Anything, []*entity.StockMovement{
+					entity.NewStockMovement(product, -2, types.StockMovementReasonTransferType, "jane", "transfer:1"),
+				})
+			}
+		})
+	}
+}
+
+func TestReceiveTransfer(t *testing.T) {
+	testcases := []struct {
+		name              string
+		ctx               context.Context
+		rGetRes           *entity.Transfer
+		rGetErr           error
+		rStartTrxErr      error
+		rUpdateStatusErr  error
+		rGetProductErr    error
+		rStocksErr        error
+		rUpsertStocksErr  error
+		rUpdateProductErr error
+		rStockMovementErr error
+		rCommitTrxErr     error
+		wantErr           bool
+		wantCustomErr     error
+	}{
+		{
+			name:    "deadline context",
+			ctx:     fixture.CtxEnded(),
+			wantErr: true,
+		},
+		{
+			name:          "transfer not found",
+			ctx:           context.Background(),
+			rGetErr:       response.ErrNotFound,
+			wantErr:       true,
+			wantCustomErr: response.ErrNotFound,
+		},
+		{
+			name:          "transfer not dispatched",
+			ctx:           context.Background(),
+			rGetRes:       transferWithStatus(types.TransferStatusDraftType),
+			wantErr:       true,
+			wantCustomErr: response.ErrInvalidTransferStatus,
+		},
+		{
+			name:         "failed to start transaction",
+			ctx:          context.Background(),
+			rGetRes:      transferWithStatus(types.TransferStatusInTransitType),
+			rStartTrxErr: response.ErrNoSQLTransactionFound,
+			wantErr:      true,
+		},
+		{
+			name:             "transfer received by other request",
+			ctx:              context.Background(),
+			rGetRes:          transferWithStatus(types.TransferStatusInTransitType),
+			rUpdateStatusErr: response.ErrNotFound,
+			wantErr:          true,
+			wantCustomErr:    response.ErrInvalidTransferStatus,
+		},
+		{
+			name:             "failed to update transfer status",
+			ctx:              context.Background(),
+			rGetRes:          transferWithStatus(types.TransferStatusInTransitType),
+			rUpdateStatusErr: errors.New("error update transfer status"),
+			wantErr:          true,
+		},
+		{
+			name:           "failed to get products",
+			ctx:            context.Background(),
+			rGetRes:        transferWithStatus(types.TransferStatusInTransitType),
+			rGetProductErr: errors.New("error get products"),
+			wantErr:        true,
+		},
+		{
+			name:       "failed to get product stocks",
+			ctx:        context.Background(),
+			rGetRes:    transferWithStatus(types.TransferStatusInTransitType),
+			rStocksErr: errors.New("error get product stocks"),
+			wantErr:    true,
+		},
+		{
+			name:             "failed to upsert product stocks",
+			ctx:              context.Background(),
+			rGetRes:          transferWithStatus(types.TransferStatusInTransitType),
+			rUpsertStocksErr: errors.New("error upsert product stocks"),
+			wantErr:          true,
+		},
+		{
+			name:              "failed to update product",
+			ctx:               context.Background(),
+			rGetRes:           transferWithStatus(types.TransferStatusInTransitType),
+			rUpdateProductErr: errors.New("error update product"),
+			wantErr:           true,
+		},
+		{
+			name:              "failed to create stock movements",
+			ctx:               context.Background(),
+			rGetRes:           transferWithStatus(types.TransferStatusInTransitType),
+			rStockMovementErr: errors.New("error create stock movements"),
+			wantErr:           true,
+		},
+		{
+			name:          "failed to commit transaction",
+			ctx:           context.Background(),
+			rGetRes:       transferWithStatus(types.TransferStatusInTransitType),
+			rCommitTrxErr: response.ErrNoSQLTransactionFound,
+			wantErr:       true,
+		},
+		{
+			name:    "success",
+			ctx:     context.Background(),
+			rGetRes: transferWithStatus(types.TransferStatusInTransitType),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// The received product has a backorder waiting which is filled from the destination location
+			items := []*entity.TransferItem{{TransferID: 1, ProductID: 1, SKU: "SKU-1", Qty: 2}}
+			product := &entity.Product{ID: 1, Tenant: types.TenantLoremType, Qty: 3, InTransitQty: 2, BackorderedQty: 1, BackorderPolicy: types.BackorderPolicyUnlimitedType}
+			destinationStock := &entity.ProductStock{ProductID: 1, LocationID: 2}
+
+			transferRepo := &testmock.TransferRepositoryInterface{}
+			transferRepo.On("GetTransferByID", mock.Anything, 1).Return(tc.rGetRes, tc.rGetErr)
+			transferRepo.On("GetTransferItemsByTransferIDs", mock.Anything, []int{1}).Return(items, nil)
+			transferRepo.On("UpdateTransferStatus", mock.Anything, mock.Anything, mock.Anything, types.TransferStatusInTransitType).Return(tc.rUpdateStatusErr)
+
+			productRepo := &testmock.ProductRepositoryInterface{}
+			productRepo.On("GetProductsForUpdate", mock.Anything, mock.Anything, mock.Anything, []int{1}, []string(nil)).Return([]*entity.Product{product}, tc.rGetProductErr)
+			productRepo.On("UpdateProductQty", mock.Anything, mock.Anything, mock.Anything).Return(tc.rUpdateProductErr)
+
+			productStockRepo := &testmock.ProductStockRepositoryInterface{}
+			productStockRepo.On("GetProductStocksForUpdate", mock.Anything, mock.Anything, 1).Return([]*entity.ProductStock{{ProductID: 1, LocationID: 1, Qty: 3}, destinationStock}, tc.rStocksErr)
+			productStockRepo.On("UpsertProductStocks", mock.Anything, mock.Anything, mock.Anything).Return(tc.rUpsertStocksErr)
+			productStockRepo.On("GetProductLotsForUpdate", mock.Anything, mock.Anything, mock.Anything).Return([]*entity.ProductLot{}, nil)
+			productStockRepo.On("UpdateProductLots", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			dbTransactionRepo := &testmock.PostgresTransactionRepositoryInterface{}
+			dbTransactionRepo.On("StartTransactionQuery", mock.Anything).Return(&sqlx.Tx{}, tc.rStartTrxErr)
+			dbTransactionRepo.On("CommitTransactionQuery", mock.Anything, mock.Anything).Return(tc.rCommitTrxErr)
+			dbTransactionRepo.On("RollbackTransactionQuery", mock.Anything, mock.Anything).Return(nil)
+
+			stockMovementRepo := &testmock.StockMovementRepositoryInterface{}
+			stockMovementRepo.On("CreateStockMovements", mock.Anything, mock.Anything, mock.Anything).Return(tc.rStockMovementErr)
+
+			lowStockRepo := &testmock.LowStockRepositoryInterface{}
+			lowStockRepo.On("GetLowStockThreshold", mock.Anything, mock.Anything).Return(nil, response.ErrNotFound)
+
+			uc := usecase.NewTransferUsecase(transferRepo, productRepo, &testmock.LocationRepositoryInterface{}, productStockRepo, stockMovementRepo, lowStockRepo, dbTransactionRepo, types.AllocationStrategyPriorityType)
+			res, err := uc.ReceiveTransfer(tc.ctx, types.TenantLoremType, 1, "jane")
+			assert.Equal(t, tc.wantErr, err != nil, err)
+			if tc.wantCustomErr != nil {
+				assert.Equal(t, tc.wantCustomErr, err)
+			}
+			if !tc.wantErr {
+				assert.Equal(t, types.TransferStatusReceivedType, res.Status)
+				assert.NotNil(t, res.ReceivedAt)
+				assert.Equal(t, 4, product.Qty)
+				assert.Equal(t, 0, product.InTransitQty)
+				assert.Equal(t, 0, product.BackorderedQty)
+				assert.Equal(t, 1, destinationStock.Qty)
+				stockMovementRepo.AssertCalled(t, "CreateStockMovements", mock.Anything, mock.Anything, mock.MatchedBy(func(movements []*entity.StockMovement) bool {
+					if len(movements) != 2 {
+						return false
+					}
+					for _, movement := range movements {
+						if movement.Actor != "jane" || movement.ReferenceID != "transfer:1" {
+							return false
+						}
+					}
+					return movements[0].Delta == 2 && movements[0].ResultingQty == 5 && movements[0].Reason == types.StockMovementReasonTransferType &&
+						movements[1].Delta == -1 && movements[1].ResultingQty == 4 && movements[1].Reason == types.StockMovementReasonSaleType
+				}))
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/satriowisnugroho/catalog/pkg/logger"
+)
+
+// LogNotifier -.
+type LogNotifier struct {
+	logger logger.LoggerInterface
+}
+
+var _ NotifierInterface = (*LogNotifier)(nil)
+
+// NewLogNotifier -.
+func NewLogNotifier(l logger.LoggerInterface) *LogNotifier {
+	return &LogNotifier{logger: l}
+}
+
+// Notify write the event to the log.
+func (n *LogNotifier) Notify(ctx context.Context, event *Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("notifier - LogNotifier - json.Marshal: %w", err)
+	}
+
+	n.logger.Info("notifier - event: %s", string(body))
+
+	return nil
+}
@@ -0,0 +1,19 @@
+// Package notifier implements delivery of events to external systems.
+package notifier
+
+import (
+	"context"
+	"time"
+)
+
+// Event -.
+type Event struct {
+	Type       string      `json:"type"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+// NotifierInterface -.
+type NotifierInterface interface {
+	Notify(ctx context.Context, event *Event) error
+}
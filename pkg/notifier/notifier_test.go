@@ -0,0 +1,76 @@
+package notifier_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/satriowisnugroho/catalog/pkg/notifier"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	testcases := []struct {
+		name       string
+		statusCode int
+		wantErr    bool
+	}{
+		{
+			name:       "webhook returns error",
+			statusCode: http.StatusInternalServerError,
+			wantErr:    true,
+		},
+		{
+			name:       "success",
+			statusCode: http.StatusNoContent,
+			wantErr:    false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var received notifier.Event
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+				body, _ := io.ReadAll(r.Body)
+				json.Unmarshal(body, &received)
+
+				w.WriteHeader(tc.statusCode)
+			}))
+			defer server.Close()
+
+			n := notifier.NewWebhookNotifier(server.URL, notifier.Timeout(time.Second))
+			err := n.Notify(context.Background(), &notifier.Event{Type: "product.low_stock", OccurredAt: time.Now(), Data: map[string]int{"qty": 1}})
+			assert.Equal(t, tc.wantErr, err != nil)
+			assert.Equal(t, "product.low_stock", received.Type)
+			assert.Equal(t, map[string]interface{}{"qty": float64(1)}, received.Data)
+		})
+	}
+}
+
+func TestWebhookNotifierUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	n := notifier.NewWebhookNotifier(server.URL)
+	err := n.Notify(context.Background(), &notifier.Event{Type: "product.low_stock"})
+	assert.Error(t, err)
+}
+
+func TestLogNotifier(t *testing.T) {
+	l := &testmock.LoggerInterface{}
+	l.On("Info", "notifier - event: %s", mock.Anything).Return()
+
+	n := notifier.NewLogNotifier(l)
+	err := n.Notify(context.Background(), &notifier.Event{Type: "product.low_stock"})
+	assert.NoError(t, err)
+	l.AssertNumberOfCalls(t, "Info", 1)
+}
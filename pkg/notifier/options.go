@@ -0,0 +1,13 @@
+package notifier
+
+import "time"
+
+// Option -.
+type Option func(*WebhookNotifier)
+
+// Timeout -.
+func Timeout(timeout time.Duration) Option {
+	return func(n *WebhookNotifier) {
+		n.client.Timeout = timeout
+	}
+}
@@ -0,0 +1,63 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	_defaultWebhookTimeout = 5 * time.Second
+)
+
+// WebhookNotifier -.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+var _ NotifierInterface = (*WebhookNotifier)(nil)
+
+// NewWebhookNotifier -.
+func NewWebhookNotifier(url string, opts ...Option) *WebhookNotifier {
+	n := &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: _defaultWebhookTimeout},
+	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	return n
+}
+
+// Notify post the event as JSON to the webhook url, any non 2xx response is an error.
+func (n *WebhookNotifier) Notify(ctx context.Context, event *Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("notifier - WebhookNotifier - json.Marshal: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("notifier - WebhookNotifier - http.NewRequestWithContext: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("notifier - WebhookNotifier - client.Do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("notifier - WebhookNotifier - unexpected status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
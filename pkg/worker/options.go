@@ -0,0 +1,20 @@
+package worker
+
+import "time"
+
+// Option -.
+type Option func(*Worker)
+
+// Interval -.
+func Interval(interval time.Duration) Option {
+	return func(w *Worker) {
+		w.interval = interval
+	}
+}
+
+// Timeout -.
+func Timeout(timeout time.Duration) Option {
+	return func(w *Worker) {
+		w.timeout = timeout
+	}
+}
@@ -0,0 +1,85 @@
+// Package worker implements periodic background job.
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/satriowisnugroho/catalog/pkg/logger"
+)
+
+const (
+	_defaultInterval = time.Minute
+	_defaultTimeout  = 30 * time.Second
+)
+
+// Job -.
+type Job func(ctx context.Context) error
+
+// Worker -.
+type Worker struct {
+	name     string
+	job      Job
+	logger   logger.LoggerInterface
+	interval time.Duration
+	timeout  time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// New -.
+func New(name string, job Job, l logger.LoggerInterface, opts ...Option) *Worker {
+	w := &Worker{
+		name:     name,
+		job:      job,
+		logger:   l,
+		interval: _defaultInterval,
+		timeout:  _defaultTimeout,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	w.start()
+
+	return w
+}
+
+func (w *Worker) start() {
+	go func() {
+		defer close(w.done)
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			w.run()
+
+			select {
+			case <-w.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (w *Worker) run() {
+	ctx, cancel := context.WithTimeout(context.Background(), w.timeout)
+	defer cancel()
+
+	if err := w.job(ctx); err != nil {
+		w.logger.Error(fmt.Errorf("worker - %s: %w", w.name, err))
+	}
+}
+
+// Shutdown -.
+func (w *Worker) Shutdown() {
+	close(w.stop)
+	<-w.done
+}
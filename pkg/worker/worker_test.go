@@ -0,0 +1,56 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/satriowisnugroho/catalog/pkg/worker"
+	testmock "github.com/satriowisnugroho/catalog/test/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWorker(t *testing.T) {
+	testcases := []struct {
+		name    string
+		jobErr  error
+		wantLog bool
+	}{
+		{
+			name:    "job failed",
+			jobErr:  errors.New("error job"),
+			wantLog: true,
+		},
+		{
+			name:    "success",
+			wantLog: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var runs int32
+
+			l := &testmock.LoggerInterface{}
+			l.On("Error", mock.Anything, mock.Anything)
+
+			w := worker.New("test", func(ctx context.Context) error {
+				atomic.AddInt32(&runs, 1)
+				return tc.jobErr
+			}, l, worker.Interval(time.Millisecond))
+
+			time.Sleep(20 * time.Millisecond)
+			w.Shutdown()
+
+			assert.Greater(t, atomic.LoadInt32(&runs), int32(1))
+			if tc.wantLog {
+				l.AssertCalled(t, "Error", mock.Anything)
+			} else {
+				l.AssertNotCalled(t, "Error", mock.Anything)
+			}
+		})
+	}
+}
@@ -3,11 +3,10 @@
 package mocks
 
 import (
-	gin "github.com/gin-gonic/gin"
-	entity "github.com/satriowisnugroho/catalog/internal/entity"
-
 	io "io"
 
+	gin "github.com/gin-gonic/gin"
+	entity "github.com/satriowisnugroho/catalog/internal/entity"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -16,6 +15,52 @@ type ProductParserInterface struct {
 	mock.Mock
 }
 
+// ParseAdjustQtyProductPayload provides a mock function with given fields: body
+func (_m *ProductParserInterface) ParseAdjustQtyProductPayload(body io.Reader) (*entity.AdjustQtyProductPayload, error) {
+	ret := _m.Called(body)
+
+	var r0 *entity.AdjustQtyProductPayload
+	if rf, ok := ret.Get(0).(func(io.Reader) *entity.AdjustQtyProductPayload); ok {
+		r0 = rf(body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.AdjustQtyProductPayload)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(io.Reader) error); ok {
+		r1 = rf(body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParseBulkIncreaseQtyProductPayload provides a mock function with given fields: body
+func (_m *ProductParserInterface) ParseBulkIncreaseQtyProductPayload(body io.Reader) (*entity.BulkIncreaseQtyProductPayload, error) {
+	ret := _m.Called(body)
+
+	var r0 *entity.BulkIncreaseQtyProductPayload
+	if rf, ok := ret.Get(0).(func(io.Reader) *entity.BulkIncreaseQtyProductPayload); ok {
+		r0 = rf(body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.BulkIncreaseQtyProductPayload)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(io.Reader) error); ok {
+		r1 = rf(body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ParseBulkReduceQtyProductPayload provides a mock function with given fields: body
 func (_m *ProductParserInterface) ParseBulkReduceQtyProductPayload(body io.Reader) (*entity.BulkReduceQtyProductPayload, error) {
 	ret := _m.Called(body)
@@ -40,7 +85,7 @@ func (_m *ProductParserInterface) ParseBulkReduceQtyProductPayload(body io.Reade
 }
 
 // ParseGetProductPayload provides a mock function with given fields: c
-func (_m *ProductParserInterface) ParseGetProductPayload(c *gin.Context) *entity.GetProductPayload {
+func (_m *ProductParserInterface) ParseGetProductPayload(c *gin.Context) (*entity.GetProductPayload, error) {
 	ret := _m.Called(c)
 
 	var r0 *entity.GetProductPayload
@@ -52,7 +97,60 @@ func (_m *ProductParserInterface) ParseGetProductPayload(c *gin.Context) *entity
 		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*gin.Context) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParseProductFieldset provides a mock function with given fields: c
+func (_m *ProductParserInterface) ParseProductFieldset(c *gin.Context) (*entity.ProductFieldset, error) {
+	ret := _m.Called(c)
+
+	var r0 *entity.ProductFieldset
+	if rf, ok := ret.Get(0).(func(*gin.Context) *entity.ProductFieldset); ok {
+		r0 = rf(c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ProductFieldset)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*gin.Context) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ParseProductPatchPayload provides a mock function with given fields: body
+func (_m *ProductParserInterface) ParseProductPatchPayload(body io.Reader) (*entity.ProductPatchPayload, error) {
+	ret := _m.Called(body)
+
+	var r0 *entity.ProductPatchPayload
+	if rf, ok := ret.Get(0).(func(io.Reader) *entity.ProductPatchPayload); ok {
+		r0 = rf(body)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ProductPatchPayload)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(io.Reader) error); ok {
+		r1 = rf(body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // ParseProductPayload provides a mock function with given fields: body
@@ -6,6 +6,7 @@ import (
 	context "context"
 
 	entity "github.com/satriowisnugroho/catalog/internal/entity"
+	types "github.com/satriowisnugroho/catalog/internal/entity/types"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -14,13 +15,13 @@ type ProductRepositoryInterface struct {
 	mock.Mock
 }
 
-// CreateProduct provides a mock function with given fields: ctx, product
-func (_m *ProductRepositoryInterface) CreateProduct(ctx context.Context, product *entity.Product) error {
-	ret := _m.Called(ctx, product)
+// CreateProduct provides a mock function with given fields: ctx, dbTrx, product
+func (_m *ProductRepositoryInterface) CreateProduct(ctx context.Context, dbTrx interface{}, product *entity.Product) error {
+	ret := _m.Called(ctx, dbTrx, product)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.Product) error); ok {
-		r0 = rf(ctx, product)
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, *entity.Product) error); ok {
+		r0 = rf(ctx, dbTrx, product)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -28,6 +29,52 @@ func (_m *ProductRepositoryInterface) CreateProduct(ctx context.Context, product
 	return r0
 }
 
+// GetInventoryValuations provides a mock function with given fields: ctx, tenant
+func (_m *ProductRepositoryInterface) GetInventoryValuations(ctx context.Context, tenant types.TenantType) ([]*entity.InventoryValuation, error) {
+	ret := _m.Called(ctx, tenant)
+
+	var r0 []*entity.InventoryValuation
+	if rf, ok := ret.Get(0).(func(context.Context, types.TenantType) []*entity.InventoryValuation); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.InventoryValuation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.TenantType) error); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPartialProductByID provides a mock function with given fields: ctx, productID, columns
+func (_m *ProductRepositoryInterface) GetPartialProductByID(ctx context.Context, productID int, columns []string) (*entity.Product, error) {
+	ret := _m.Called(ctx, productID, columns)
+
+	var r0 *entity.Product
+	if rf, ok := ret.Get(0).(func(context.Context, int, []string) *entity.Product); ok {
+		r0 = rf(ctx, productID, columns)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, []string) error); ok {
+		r1 = rf(ctx, productID, columns)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetProductByID provides a mock function with given fields: ctx, productID
 func (_m *ProductRepositoryInterface) GetProductByID(ctx context.Context, productID int) (*entity.Product, error) {
 	ret := _m.Called(ctx, productID)
@@ -118,8 +165,94 @@ func (_m *ProductRepositoryInterface) GetProductsCount(ctx context.Context, payl
 	return r0, r1
 }
 
-// UpdateProduct provides a mock function with given fields: ctx, dbTrx, product
-func (_m *ProductRepositoryInterface) UpdateProduct(ctx context.Context, dbTrx interface{}, product *entity.Product) error {
+// GetProductsForUpdate provides a mock function with given fields: ctx, dbTrx, tenant, productIDs, productSKUs
+func (_m *ProductRepositoryInterface) GetProductsForUpdate(ctx context.Context, dbTrx interface{}, tenant types.TenantType, productIDs []int, productSKUs []string) ([]*entity.Product, error) {
+	ret := _m.Called(ctx, dbTrx, tenant, productIDs, productSKUs)
+
+	var r0 []*entity.Product
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, types.TenantType, []int, []string) []*entity.Product); ok {
+		r0 = rf(ctx, dbTrx, tenant, productIDs, productSKUs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Product)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, types.TenantType, []int, []string) error); ok {
+		r1 = rf(ctx, dbTrx, tenant, productIDs, productSKUs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseProductQty provides a mock function with given fields: ctx, dbTrx, productID, qty
+func (_m *ProductRepositoryInterface) ReleaseProductQty(ctx context.Context, dbTrx interface{}, productID int, qty int) error {
+	ret := _m.Called(ctx, dbTrx, productID, qty)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, int, int) error); ok {
+		r0 = rf(ctx, dbTrx, productID, qty)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReserveProductQty provides a mock function with given fields: ctx, dbTrx, productID, qty
+func (_m *ProductRepositoryInterface) ReserveProductQty(ctx context.Context, dbTrx interface{}, productID int, qty int) error {
+	ret := _m.Called(ctx, dbTrx, productID, qty)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, int, int) error); ok {
+		r0 = rf(ctx, dbTrx, productID, qty)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetProductLowStockAlerted provides a mock function with given fields: ctx, dbTrx, productID, alerted
+func (_m *ProductRepositoryInterface) SetProductLowStockAlerted(ctx context.Context, dbTrx interface{}, productID int, alerted bool) (bool, error) {
+	ret := _m.Called(ctx, dbTrx, productID, alerted)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, int, bool) bool); ok {
+		r0 = rf(ctx, dbTrx, productID, alerted)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, int, bool) error); ok {
+		r1 = rf(ctx, dbTrx, productID, alerted)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateProduct provides a mock function with given fields: ctx, dbTrx, product, columns
+func (_m *ProductRepositoryInterface) UpdateProduct(ctx context.Context, dbTrx interface{}, product *entity.Product, columns []string) error {
+	ret := _m.Called(ctx, dbTrx, product, columns)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, *entity.Product, []string) error); ok {
+		r0 = rf(ctx, dbTrx, product, columns)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateProductQty provides a mock function with given fields: ctx, dbTrx, product
+func (_m *ProductRepositoryInterface) UpdateProductQty(ctx context.Context, dbTrx interface{}, product *entity.Product) error {
 	ret := _m.Called(ctx, dbTrx, product)
 
 	var r0 error
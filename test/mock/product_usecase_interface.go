@@ -6,9 +6,8 @@ import (
 	context "context"
 
 	entity "github.com/satriowisnugroho/catalog/internal/entity"
-	mock "github.com/stretchr/testify/mock"
-
 	types "github.com/satriowisnugroho/catalog/internal/entity/types"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // ProductUsecaseInterface is an autogenerated mock type for the ProductUsecaseInterface type
@@ -16,12 +15,35 @@ type ProductUsecaseInterface struct {
 	mock.Mock
 }
 
-// BulkReduceQtyProduct provides a mock function with given fields: ctx, tenant, payload
-func (_m *ProductUsecaseInterface) BulkReduceQtyProduct(ctx context.Context, tenant types.TenantType, payload *entity.BulkReduceQtyProductPayload) ([]*entity.Product, error) {
+// AdjustQtyProduct provides a mock function with given fields: ctx, productID, payload
+func (_m *ProductUsecaseInterface) AdjustQtyProduct(ctx context.Context, productID int, payload *entity.AdjustQtyProductPayload) (*entity.Product, error) {
+	ret := _m.Called(ctx, productID, payload)
+
+	var r0 *entity.Product
+	if rf, ok := ret.Get(0).(func(context.Context, int, *entity.AdjustQtyProductPayload) *entity.Product); ok {
+		r0 = rf(ctx, productID, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, *entity.AdjustQtyProductPayload) error); ok {
+		r1 = rf(ctx, productID, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BulkIncreaseQtyProduct provides a mock function with given fields: ctx, tenant, payload
+func (_m *ProductUsecaseInterface) BulkIncreaseQtyProduct(ctx context.Context, tenant types.TenantType, payload *entity.BulkIncreaseQtyProductPayload) ([]*entity.Product, error) {
 	ret := _m.Called(ctx, tenant, payload)
 
 	var r0 []*entity.Product
-	if rf, ok := ret.Get(0).(func(context.Context, types.TenantType, *entity.BulkReduceQtyProductPayload) []*entity.Product); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, types.TenantType, *entity.BulkIncreaseQtyProductPayload) []*entity.Product); ok {
 		r0 = rf(ctx, tenant, payload)
 	} else {
 		if ret.Get(0) != nil {
@@ -29,6 +51,29 @@ func (_m *ProductUsecaseInterface) BulkReduceQtyProduct(ctx context.Context, ten
 		}
 	}
 
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.TenantType, *entity.BulkIncreaseQtyProductPayload) error); ok {
+		r1 = rf(ctx, tenant, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BulkReduceQtyProduct provides a mock function with given fields: ctx, tenant, payload
+func (_m *ProductUsecaseInterface) BulkReduceQtyProduct(ctx context.Context, tenant types.TenantType, payload *entity.BulkReduceQtyProductPayload) (*entity.BulkReduceQtyProductResult, error) {
+	ret := _m.Called(ctx, tenant, payload)
+
+	var r0 *entity.BulkReduceQtyProductResult
+	if rf, ok := ret.Get(0).(func(context.Context, types.TenantType, *entity.BulkReduceQtyProductPayload) *entity.BulkReduceQtyProductResult); ok {
+		r0 = rf(ctx, tenant, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.BulkReduceQtyProductResult)
+		}
+	}
+
 	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, types.TenantType, *entity.BulkReduceQtyProductPayload) error); ok {
 		r1 = rf(ctx, tenant, payload)
@@ -62,13 +107,13 @@ func (_m *ProductUsecaseInterface) CreateProduct(ctx context.Context, payload *e
 	return r0, r1
 }
 
-// GetProductByID provides a mock function with given fields: ctx, tenant, productID
-func (_m *ProductUsecaseInterface) GetProductByID(ctx context.Context, tenant types.TenantType, productID int) (*entity.Product, error) {
-	ret := _m.Called(ctx, tenant, productID)
+// GetProductByID provides a mock function with given fields: ctx, payload
+func (_m *ProductUsecaseInterface) GetProductByID(ctx context.Context, payload *entity.GetProductByIDPayload) (*entity.Product, error) {
+	ret := _m.Called(ctx, payload)
 
 	var r0 *entity.Product
-	if rf, ok := ret.Get(0).(func(context.Context, types.TenantType, int) *entity.Product); ok {
-		r0 = rf(ctx, tenant, productID)
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.GetProductByIDPayload) *entity.Product); ok {
+		r0 = rf(ctx, payload)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Product)
@@ -76,8 +121,8 @@ func (_m *ProductUsecaseInterface) GetProductByID(ctx context.Context, tenant ty
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, types.TenantType, int) error); ok {
-		r1 = rf(ctx, tenant, productID)
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.GetProductByIDPayload) error); ok {
+		r1 = rf(ctx, payload)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -86,33 +131,49 @@ func (_m *ProductUsecaseInterface) GetProductByID(ctx context.Context, tenant ty
 }
 
 // GetProducts provides a mock function with given fields: ctx, payload
-func (_m *ProductUsecaseInterface) GetProducts(ctx context.Context, payload *entity.GetProductPayload) ([]*entity.Product, int, error) {
+func (_m *ProductUsecaseInterface) GetProducts(ctx context.Context, payload *entity.GetProductPayload) (*entity.ProductPage, error) {
 	ret := _m.Called(ctx, payload)
 
-	var r0 []*entity.Product
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.GetProductPayload) []*entity.Product); ok {
+	var r0 *entity.ProductPage
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.GetProductPayload) *entity.ProductPage); ok {
 		r0 = rf(ctx, payload)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entity.Product)
+			r0 = ret.Get(0).(*entity.ProductPage)
 		}
 	}
 
-	var r1 int
-	if rf, ok := ret.Get(1).(func(context.Context, *entity.GetProductPayload) int); ok {
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.GetProductPayload) error); ok {
 		r1 = rf(ctx, payload)
 	} else {
-		r1 = ret.Get(1).(int)
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchProduct provides a mock function with given fields: ctx, productID, payload
+func (_m *ProductUsecaseInterface) PatchProduct(ctx context.Context, productID int, payload *entity.ProductPatchPayload) (*entity.Product, error) {
+	ret := _m.Called(ctx, productID, payload)
+
+	var r0 *entity.Product
+	if rf, ok := ret.Get(0).(func(context.Context, int, *entity.ProductPatchPayload) *entity.Product); ok {
+		r0 = rf(ctx, productID, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
 	}
 
-	var r2 error
-	if rf, ok := ret.Get(2).(func(context.Context, *entity.GetProductPayload) error); ok {
-		r2 = rf(ctx, payload)
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, *entity.ProductPatchPayload) error); ok {
+		r1 = rf(ctx, productID, payload)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
 // UpdateProduct provides a mock function with given fields: ctx, productID, payload